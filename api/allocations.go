@@ -295,6 +295,7 @@ type Allocation struct {
 	NetworkStatus         *AllocNetworkStatus
 	PreemptedAllocations  []string
 	PreemptedByAllocation string
+	Preemption            *AllocPreemption
 	CreateIndex           uint64
 	ModifyIndex           uint64
 	AllocModifyIndex      uint64
@@ -429,6 +430,23 @@ type AllocDeploymentStatus struct {
 	ModifyIndex uint64
 }
 
+// AllocPreemption records why an allocation was preempted and the
+// preemption budgets it was counted against.
+type AllocPreemption struct {
+	Reason  string
+	Time    int64
+	Budgets []*PreemptionBudgetUsage
+}
+
+// PreemptionBudgetUsage is the number of preemptions counted against a
+// preemption budget when an allocation was preempted.
+type PreemptionBudgetUsage struct {
+	Scope          string
+	Preemptions    int
+	MaxPreemptions int
+	Window         time.Duration
+}
+
 // AllocNetworkStatus captures the status of an allocation's network during runtime.
 // Depending on the network mode, an allocation's address may need to be known to other
 // systems in Nomad such as service registration.
@@ -43,6 +43,17 @@ func (e *Evaluations) Count(q *QueryOptions) (*EvalCountResponse, *QueryMeta, er
 	return resp, qm, nil
 }
 
+// QueueDepth is used to get the number of evaluations queued in the eval
+// broker for each namespace.
+func (e *Evaluations) QueueDepth(q *QueryOptions) (*EvalQueueDepthResponse, *QueryMeta, error) {
+	var resp *EvalQueueDepthResponse
+	qm, err := e.client.query("/v1/evaluations/queue-depth", &resp, q)
+	if err != nil {
+		return resp, nil, err
+	}
+	return resp, qm, nil
+}
+
 // Info is used to query a single evaluation by its ID.
 func (e *Evaluations) Info(evalID string, q *QueryOptions) (*Evaluation, *QueryMeta, error) {
 	var resp Evaluation
@@ -166,6 +177,19 @@ type EvalCountResponse struct {
 	QueryMeta
 }
 
+type EvalQueueDepthResponse struct {
+	Namespaces map[string]*EvalQueueDepth
+	QueryMeta
+}
+
+// EvalQueueDepth is the number of evaluations in the eval broker for a
+// namespace.
+type EvalQueueDepth struct {
+	Ready   int
+	Unacked int
+	Pending int
+}
+
 // EvalIndexSort is a wrapper to sort evaluations by CreateIndex.
 // We reverse the test so that we get the highest index first.
 type EvalIndexSort []*Evaluation
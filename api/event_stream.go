@@ -14,14 +14,29 @@ import (
 )
 
 const (
-	TopicDeployment Topic = "Deployment"
-	TopicEvaluation Topic = "Evaluation"
-	TopicAllocation Topic = "Allocation"
-	TopicJob        Topic = "Job"
-	TopicNode       Topic = "Node"
-	TopicNodePool   Topic = "NodePool"
-	TopicService    Topic = "Service"
-	TopicAll        Topic = "*"
+	TopicDeployment    Topic = "Deployment"
+	TopicEvaluation    Topic = "Evaluation"
+	TopicAllocation    Topic = "Allocation"
+	TopicJob           Topic = "Job"
+	TopicNode          Topic = "Node"
+	TopicNodePool      Topic = "NodePool"
+	TopicService       Topic = "Service"
+	TopicVariables     Topic = "Variables"
+	TopicNamespace     Topic = "Namespace"
+	TopicCSIVolume     Topic = "CSIVolume"
+	TopicCSIPlugin     Topic = "CSIPlugin"
+	TopicScalingPolicy Topic = "ScalingPolicy"
+	TopicRootKey       Topic = "RootKey"
+	TopicAll           Topic = "*"
+
+	// TopicEventStream is the topic of events describing the stream itself,
+	// which are sent regardless of the topics subscribed to.
+	TopicEventStream Topic = "EventStream"
+
+	// EventTypeEventsTruncated is the type of the event sent in place of
+	// events that were no longer retained by the server when the subscriber
+	// requested them.
+	EventTypeEventsTruncated = "EventsTruncated"
 )
 
 // Events is a set of events for a corresponding index. Events returned for the
@@ -120,6 +135,88 @@ func (e *Event) Service() (*ServiceRegistration, error) {
 	return out.Service, nil
 }
 
+// Variable returns a VariableMetadata struct from a given event payload. If
+// the Event Topic is Variables this will return a valid VariableMetadata. The
+// variable's items are never included in events.
+func (e *Event) Variable() (*VariableMetadata, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.Variable, nil
+}
+
+// Namespace returns a Namespace struct from a given event payload. If the
+// Event Topic is Namespace this will return a valid Namespace.
+func (e *Event) Namespace() (*Namespace, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.Namespace, nil
+}
+
+// CSIVolume returns a CSIVolume struct from a given event payload. If the
+// Event Topic is CSIVolume this will return a valid CSIVolume.
+func (e *Event) CSIVolume() (*CSIVolume, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.CSIVolume, nil
+}
+
+// CSIPlugin returns a CSIPlugin struct from a given event payload. If the
+// Event Topic is CSIPlugin this will return a valid CSIPlugin.
+func (e *Event) CSIPlugin() (*CSIPlugin, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.CSIPlugin, nil
+}
+
+// ScalingPolicy returns a ScalingPolicy struct from a given event payload. If
+// the Event Topic is ScalingPolicy this will return a valid ScalingPolicy.
+func (e *Event) ScalingPolicy() (*ScalingPolicy, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.ScalingPolicy, nil
+}
+
+// RootKeyMeta returns a RootKeyMeta struct from a given event payload. If the
+// Event Topic is RootKey this will return a valid RootKeyMeta.
+func (e *Event) RootKeyMeta() (*RootKeyMeta, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.RootKeyMeta, nil
+}
+
+// EventsTruncated returns the range of events that were skipped from a given
+// event payload. If the Event Type is EventsTruncated this will return a valid
+// EventsTruncated.
+func (e *Event) EventsTruncated() (*EventsTruncated, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.EventsTruncated, nil
+}
+
+// EventsTruncated describes events that were removed by the server before they
+// could be delivered to the subscriber.
+type EventsTruncated struct {
+	// RequestedIndex is the first index that could not be delivered.
+	RequestedIndex uint64
+
+	// FirstIndex is the index the stream continues from.
+	FirstIndex uint64
+}
+
 type eventPayload struct {
 	Allocation *Allocation          `mapstructure:"Allocation"`
 	Deployment *Deployment          `mapstructure:"Deployment"`
@@ -128,6 +225,15 @@ type eventPayload struct {
 	Node       *Node                `mapstructure:"Node"`
 	NodePool   *NodePool            `mapstructure:"NodePool"`
 	Service    *ServiceRegistration `mapstructure:"Service"`
+
+	Variable      *VariableMetadata `mapstructure:"Variable"`
+	Namespace     *Namespace        `mapstructure:"Namespace"`
+	CSIVolume     *CSIVolume        `mapstructure:"CSIVolume"`
+	CSIPlugin     *CSIPlugin        `mapstructure:"CSIPlugin"`
+	ScalingPolicy *ScalingPolicy    `mapstructure:"ScalingPolicy"`
+	RootKeyMeta   *RootKeyMeta      `mapstructure:"RootKeyMeta"`
+
+	EventsTruncated *EventsTruncated `mapstructure:"EventsTruncated"`
 }
 
 func (e *Event) decodePayload() (*eventPayload, error) {
@@ -108,6 +108,84 @@ func (a *AllocFS) Stat(alloc *Allocation, path string, q *QueryOptions) (*AllocF
 	return &resp, qm, nil
 }
 
+// LogSearchMatch is a line of a task's logs matching a search
+type LogSearchMatch struct {
+	// File is the name of the log file holding the line, and Offset the
+	// offset of the line in the file once decompressed
+	File    string
+	LogType string
+	Offset  int64
+	Line    string
+
+	// Before and After are the lines surrounding the match
+	Before []string
+	After  []string
+}
+
+// LogSearchResponse is the result of a search of a task's logs
+type LogSearchResponse struct {
+	Matches []*LogSearchMatch
+
+	// Truncated is set if more lines matched than the search limit, or if
+	// the search stopped after reading the maximum amount of log content
+	Truncated bool
+}
+
+// LogSearchOptions are the optional parameters of a log search
+type LogSearchOptions struct {
+	// LogType is the log stream to search, stdout or stderr. Both are
+	// searched if unset.
+	LogType string
+
+	// Since skips the log files last written before this time
+	Since time.Time
+
+	// Context is the number of lines returned around each match
+	Context int
+
+	// Limit is the maximum number of matches returned
+	Limit int
+}
+
+// LogsSearch is used to search the logs of a task, including rotated files,
+// for lines matching the regular expression pattern.
+// Note: for cluster topologies where API consumers don't have network access to
+// Nomad clients, set api.ClientConnTimeout to a small value (ex 1ms) to avoid
+// long pauses on this API call.
+func (a *AllocFS) LogsSearch(alloc *Allocation, task, pattern string, opts *LogSearchOptions, q *QueryOptions) (*LogSearchResponse, *QueryMeta, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+	if opts == nil {
+		opts = &LogSearchOptions{}
+	}
+
+	q.Params["task"] = task
+	q.Params["pattern"] = pattern
+	if opts.LogType != "" {
+		q.Params["type"] = opts.LogType
+	}
+	if !opts.Since.IsZero() {
+		q.Params["since"] = opts.Since.Format(time.RFC3339)
+	}
+	if opts.Context > 0 {
+		q.Params["context"] = strconv.Itoa(opts.Context)
+	}
+	if opts.Limit > 0 {
+		q.Params["limit"] = strconv.Itoa(opts.Limit)
+	}
+
+	var resp LogSearchResponse
+	qm, err := a.client.query(fmt.Sprintf("/v1/client/fs/logs/search/%s", alloc.ID), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // ReadAt is used to read bytes at a given offset until limit at the given path
 // in an allocation directory. If limit is <= 0, there is no limit.
 // Note: for cluster topologies where API consumers don't have network access to
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"net/url"
+)
+
+// HostVolumeState is the state of a dynamic host volume.
+type HostVolumeState string
+
+const (
+	HostVolumeStatePending HostVolumeState = "pending"
+	HostVolumeStateReady   HostVolumeState = "ready"
+)
+
+// HostVolume is a dynamic host volume, provisioned on a node by a host volume
+// plugin.
+type HostVolume struct {
+	// ID is a namespace unique identifier for the volume, generated by the
+	// server when the volume is created.
+	ID string `mapstructure:"-" hcl:"-"`
+
+	// Name is the name of the volume. It must be unique on its node and is
+	// used as the source of host volume requests in job specifications.
+	Name string `hcl:"name"`
+
+	// Namespace is the namespace of the volume.
+	Namespace string `hcl:"namespace"`
+
+	// PluginID is the ID of the host volume plugin used to provision the
+	// volume on its node. The built-in plugin is "mkdir".
+	PluginID string `mapstructure:"plugin_id" hcl:"plugin_id"`
+
+	// NodePool is the node pool the volume's node is chosen from, if NodeID
+	// is not set.
+	NodePool string `mapstructure:"node_pool" hcl:"node_pool,optional"`
+
+	// NodeID is the ID of the node to provision the volume on.
+	NodeID string `mapstructure:"node_id" hcl:"node_id,optional"`
+
+	// RequestedCapacityMinBytes and RequestedCapacityMaxBytes are the
+	// capacity requested from the plugin.
+	RequestedCapacityMinBytes int64 `mapstructure:"capacity_min" hcl:"capacity_min,optional"`
+	RequestedCapacityMaxBytes int64 `mapstructure:"capacity_max" hcl:"capacity_max,optional"`
+
+	// CapacityBytes is the capacity reported by the plugin.
+	CapacityBytes int64 `mapstructure:"-" hcl:"-"`
+
+	// Parameters are passed to the plugin as-is.
+	Parameters map[string]string `hcl:"parameters,optional"`
+
+	// HostPath is the path of the volume on its node.
+	HostPath string `mapstructure:"-" hcl:"-"`
+
+	// State is the state of the volume.
+	State HostVolumeState `mapstructure:"-" hcl:"-"`
+
+	CreateIndex uint64
+	ModifyIndex uint64
+	CreateTime  int64
+	ModifyTime  int64
+}
+
+// HostVolumeStub is a summary of a dynamic host volume returned by List.
+type HostVolumeStub struct {
+	ID            string
+	Name          string
+	Namespace     string
+	PluginID      string
+	NodePool      string
+	NodeID        string
+	CapacityBytes int64
+	State         HostVolumeState
+	CreateIndex   uint64
+	ModifyIndex   uint64
+}
+
+// HostVolumeCreateRequest is used to create a dynamic host volume.
+type HostVolumeCreateRequest struct {
+	Volume *HostVolume
+}
+
+// HostVolumeCreateResponse is the response to a create request.
+type HostVolumeCreateResponse struct {
+	Volume *HostVolume
+}
+
+// HostVolumeListRequest filters the host volumes returned by List.
+type HostVolumeListRequest struct {
+	NodeID   string
+	NodePool string
+}
+
+// HostVolumes is used to access the dynamic host volume endpoints.
+type HostVolumes struct {
+	client *Client
+}
+
+// HostVolumes returns a handle on the HostVolumes endpoints.
+func (c *Client) HostVolumes() *HostVolumes {
+	return &HostVolumes{client: c}
+}
+
+// Create provisions a dynamic host volume on a node and registers it. The
+// node is chosen by the server unless the volume sets NodeID.
+func (hv *HostVolumes) Create(req *HostVolumeCreateRequest, w *WriteOptions) (*HostVolume, *WriteMeta, error) {
+	var out HostVolumeCreateResponse
+	wm, err := hv.client.put("/v1/volume/host/create", req, &out, w)
+	if err != nil {
+		return nil, wm, err
+	}
+	return out.Volume, wm, nil
+}
+
+// Get returns a single dynamic host volume.
+func (hv *HostVolumes) Get(id string, q *QueryOptions) (*HostVolume, *QueryMeta, error) {
+	var out HostVolume
+	qm, err := hv.client.query("/v1/volume/host/"+url.PathEscape(id), &out, q)
+	if err != nil {
+		return nil, qm, err
+	}
+	return &out, qm, nil
+}
+
+// List returns the dynamic host volumes, optionally filtered by node or node
+// pool.
+func (hv *HostVolumes) List(req *HostVolumeListRequest, q *QueryOptions) ([]*HostVolumeStub, *QueryMeta, error) {
+	var out []*HostVolumeStub
+	qv := url.Values{}
+	qv.Set("type", "host")
+	if req != nil {
+		if req.NodeID != "" {
+			qv.Set("node_id", req.NodeID)
+		}
+		if req.NodePool != "" {
+			qv.Set("node_pool", req.NodePool)
+		}
+	}
+
+	qm, err := hv.client.query("/v1/volumes?"+qv.Encode(), &out, q)
+	if err != nil {
+		return nil, qm, err
+	}
+	return out, qm, nil
+}
+
+// Delete removes a dynamic host volume from its node and deregisters it.
+func (hv *HostVolumes) Delete(id string, w *WriteOptions) (*WriteMeta, error) {
+	wm, err := hv.client.delete("/v1/volume/host/"+url.PathEscape(id), nil, nil, w)
+	return wm, err
+}
@@ -275,6 +275,39 @@ func (j *Jobs) Versions(jobID string, diffs bool, q *QueryOptions) ([]*Job, []*J
 	return resp.Versions, resp.Diffs, qm, nil
 }
 
+// VersionsOptions are the options for listing job versions.
+type VersionsOptions struct {
+	// Diffs requests the diff of each version against its predecessor.
+	Diffs bool
+
+	// DiffTag and DiffVersion diff each version against the version with the
+	// given tag or number instead. They imply Diffs.
+	DiffTag     string
+	DiffVersion *uint64
+}
+
+// VersionsOpts is used to retrieve all versions of a particular job given its
+// unique ID, with diffs against a chosen version.
+func (j *Jobs) VersionsOpts(jobID string, opts *VersionsOptions, q *QueryOptions) ([]*Job, []*JobDiff, *QueryMeta, error) {
+	qv := url.Values{}
+	if opts != nil {
+		qv.Set("diffs", strconv.FormatBool(opts.Diffs || opts.DiffTag != "" || opts.DiffVersion != nil))
+		if opts.DiffTag != "" {
+			qv.Set("diff_tag", opts.DiffTag)
+		}
+		if opts.DiffVersion != nil {
+			qv.Set("diff_version", strconv.FormatUint(*opts.DiffVersion, 10))
+		}
+	}
+
+	var resp JobVersionsResponse
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/versions?"+qv.Encode(), &resp, q)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return resp.Versions, resp.Diffs, qm, nil
+}
+
 // Submission is used to retrieve the original submitted source of a job given its
 // namespace, jobID, and version number. The original source might not be available,
 // which case nil is returned with no error.
@@ -544,6 +577,29 @@ func (j *Jobs) Stable(jobID string, version uint64, stable bool,
 	return &resp, wm, nil
 }
 
+// TagVersion attaches an immutable tag to a job version. Tagged versions are
+// never garbage collected and can be reverted to by name.
+func (j *Jobs) TagVersion(jobID string, version uint64, name string, description string,
+	q *WriteOptions) (*JobTagResponse, *WriteMeta, error) {
+
+	var resp JobTagResponse
+	req := &JobTagRequest{
+		JobID:       jobID,
+		Version:     version,
+		Description: description,
+	}
+	wm, err := j.client.put("/v1/job/"+url.PathEscape(jobID)+"/versions/"+url.PathEscape(name)+"/tag", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// UntagVersion removes a tag from the job version it is attached to.
+func (j *Jobs) UntagVersion(jobID string, name string, q *WriteOptions) (*WriteMeta, error) {
+	return j.client.delete("/v1/job/"+url.PathEscape(jobID)+"/versions/"+url.PathEscape(name)+"/tag", nil, nil, q)
+}
+
 // Services is used to return a list of service registrations associated to the
 // specified jobID.
 func (j *Jobs) Services(jobID string, q *QueryOptions) ([]*ServiceRegistration, *QueryMeta, error) {
@@ -559,15 +615,71 @@ type periodicForceResponse struct {
 
 // UpdateStrategy defines a task groups update strategy.
 type UpdateStrategy struct {
-	Stagger          *time.Duration `mapstructure:"stagger" hcl:"stagger,optional"`
-	MaxParallel      *int           `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
-	HealthCheck      *string        `mapstructure:"health_check" hcl:"health_check,optional"`
-	MinHealthyTime   *time.Duration `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
-	HealthyDeadline  *time.Duration `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
-	ProgressDeadline *time.Duration `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
-	Canary           *int           `mapstructure:"canary" hcl:"canary,optional"`
-	AutoRevert       *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
-	AutoPromote      *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	Stagger          *time.Duration  `mapstructure:"stagger" hcl:"stagger,optional"`
+	MaxParallel      *int            `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
+	HealthCheck      *string         `mapstructure:"health_check" hcl:"health_check,optional"`
+	MinHealthyTime   *time.Duration  `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
+	HealthyDeadline  *time.Duration  `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
+	ProgressDeadline *time.Duration  `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
+	Canary           *int            `mapstructure:"canary" hcl:"canary,optional"`
+	AutoRevert       *bool           `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
+	AutoPromote      *bool           `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	CanaryAnalysis   *CanaryAnalysis `mapstructure:"canary_analysis" hcl:"canary_analysis,block"`
+}
+
+// CanaryAnalysis promotes or fails a deployment based on metrics queried from
+// a metrics provider while the canaries are running.
+type CanaryAnalysis struct {
+	Provider *string                 `mapstructure:"provider" hcl:"provider,optional"`
+	Address  *string                 `mapstructure:"address" hcl:"address,optional"`
+	Interval *time.Duration          `mapstructure:"interval" hcl:"interval,optional"`
+	Runs     *int                    `mapstructure:"runs" hcl:"runs,optional"`
+	Metrics  []*CanaryAnalysisMetric `mapstructure:"metric" hcl:"metric,block"`
+}
+
+// CanaryAnalysisMetric is a query of a canary analysis and the bounds its
+// result must be within.
+type CanaryAnalysisMetric struct {
+	Name  string   `hcl:"name,label"`
+	Query string   `mapstructure:"query" hcl:"query"`
+	Min   *float64 `mapstructure:"min" hcl:"min,optional"`
+	Max   *float64 `mapstructure:"max" hcl:"max,optional"`
+}
+
+func (c *CanaryAnalysis) Canonicalize() {
+	if c.Provider == nil {
+		c.Provider = pointerOf("prometheus")
+	}
+	if c.Interval == nil {
+		c.Interval = pointerOf(30 * time.Second)
+	}
+	if c.Runs == nil {
+		c.Runs = pointerOf(3)
+	}
+}
+
+func (c *CanaryAnalysis) Copy() *CanaryAnalysis {
+	if c == nil {
+		return nil
+	}
+	nc := &CanaryAnalysis{
+		Provider: pointerCopy(c.Provider),
+		Address:  pointerCopy(c.Address),
+		Interval: pointerCopy(c.Interval),
+		Runs:     pointerCopy(c.Runs),
+	}
+	if c.Metrics != nil {
+		nc.Metrics = make([]*CanaryAnalysisMetric, len(c.Metrics))
+		for i, m := range c.Metrics {
+			nc.Metrics[i] = &CanaryAnalysisMetric{
+				Name:  m.Name,
+				Query: m.Query,
+				Min:   pointerCopy(m.Min),
+				Max:   pointerCopy(m.Max),
+			}
+		}
+	}
+	return nc
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
@@ -629,6 +741,8 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.AutoPromote = pointerOf(*u.AutoPromote)
 	}
 
+	copy.CanaryAnalysis = u.CanaryAnalysis.Copy()
+
 	return copy
 }
 
@@ -672,6 +786,10 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 	if o.AutoPromote != nil {
 		u.AutoPromote = pointerOf(*o.AutoPromote)
 	}
+
+	if o.CanaryAnalysis != nil {
+		u.CanaryAnalysis = o.CanaryAnalysis.Copy()
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -712,6 +830,10 @@ func (u *UpdateStrategy) Canonicalize() {
 	if u.AutoPromote == nil {
 		u.AutoPromote = d.AutoPromote
 	}
+
+	if u.CanaryAnalysis != nil {
+		u.CanaryAnalysis.Canonicalize()
+	}
 }
 
 // Empty returns whether the UpdateStrategy is empty or has user defined values.
@@ -756,6 +878,10 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if u.CanaryAnalysis != nil {
+		return false
+	}
+
 	return true
 }
 
@@ -944,6 +1070,38 @@ type JobSubmission struct {
 	Variables string
 }
 
+// PreemptionBudget limits how often the allocations of a job or namespace
+// can be preempted, and how long an allocation must run before it can be
+// preempted.
+type PreemptionBudget struct {
+	MaxPreemptions *int           `mapstructure:"max_preemptions" hcl:"max_preemptions,optional"`
+	Window         *time.Duration `mapstructure:"window" hcl:"window,optional"`
+	MinRuntime     *time.Duration `mapstructure:"min_runtime" hcl:"min_runtime,optional"`
+}
+
+func (p *PreemptionBudget) Canonicalize() {
+	if p.MaxPreemptions == nil {
+		p.MaxPreemptions = pointerOf(0)
+	}
+	if p.Window == nil {
+		p.Window = pointerOf(time.Duration(0))
+	}
+	if p.MinRuntime == nil {
+		p.MinRuntime = pointerOf(time.Duration(0))
+	}
+}
+
+func (p *PreemptionBudget) Copy() *PreemptionBudget {
+	if p == nil {
+		return nil
+	}
+	return &PreemptionBudget{
+		MaxPreemptions: pointerCopy(p.MaxPreemptions),
+		Window:         pointerCopy(p.Window),
+		MinRuntime:     pointerCopy(p.MinRuntime),
+	}
+}
+
 type JobUIConfig struct {
 	Description string       `hcl:"description,optional"`
 	Links       []*JobUILink `hcl:"link,block"`
@@ -1032,7 +1190,10 @@ type Job struct {
 	Name             *string                 `hcl:"name,optional"`
 	Type             *string                 `hcl:"type,optional"`
 	Priority         *int                    `hcl:"priority,optional"`
+	PreemptionBudget *PreemptionBudget       `mapstructure:"preemption_budget" hcl:"preemption_budget,block"`
 	AllAtOnce        *bool                   `mapstructure:"all_at_once" hcl:"all_at_once,optional"`
+	Gang             *bool                   `hcl:"gang,optional"`
+	Prefetch         *bool                   `hcl:"prefetch,optional"`
 	Datacenters      []string                `hcl:"datacenters,optional"`
 	NodePool         *string                 `mapstructure:"node_pool" hcl:"node_pool,optional"`
 	Constraints      []*Constraint           `hcl:"constraint,block"`
@@ -1064,6 +1225,7 @@ type Job struct {
 	StatusDescription        *string
 	Stable                   *bool
 	Version                  *uint64
+	VersionTag               *JobVersionTag
 	SubmitTime               *int64
 	CreateIndex              *uint64
 	ModifyIndex              *uint64
@@ -1116,6 +1278,12 @@ func (j *Job) Canonicalize() {
 	if j.AllAtOnce == nil {
 		j.AllAtOnce = pointerOf(false)
 	}
+	if j.Gang == nil {
+		j.Gang = pointerOf(false)
+	}
+	if j.Prefetch == nil {
+		j.Prefetch = pointerOf(false)
+	}
 	if j.ConsulToken == nil {
 		j.ConsulToken = pointerOf("")
 	}
@@ -1178,6 +1346,9 @@ func (j *Job) Canonicalize() {
 	if j.UI != nil {
 		j.UI.Canonicalize()
 	}
+	if j.PreemptionBudget != nil {
+		j.PreemptionBudget.Canonicalize()
+	}
 }
 
 // LookupTaskGroup finds a task group by name
@@ -1576,6 +1747,29 @@ type JobStabilityResponse struct {
 	WriteMeta
 }
 
+// JobVersionTag is a named, immutable label attached to a job version.
+type JobVersionTag struct {
+	Name        string
+	Description string
+	TaggedTime  int64
+}
+
+// JobTagRequest is used to tag a job version.
+type JobTagRequest struct {
+	JobID       string
+	Version     uint64
+	Description string
+	WriteRequest
+}
+
+// JobTagResponse is the response when tagging a job version.
+type JobTagResponse struct {
+	Name        string
+	Description string
+	TaggedTime  int64
+	WriteMeta
+}
+
 // JobEvaluateRequest is used when we just need to re-evaluate a target job
 type JobEvaluateRequest struct {
 	JobID       string
@@ -286,6 +286,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
 				AllAtOnce:         pointerOf(false),
+				Gang:              pointerOf(false),
+				Prefetch:          pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -385,6 +387,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
 				AllAtOnce:         pointerOf(false),
+				Gang:              pointerOf(false),
+				Prefetch:          pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -467,6 +471,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
 				AllAtOnce:         pointerOf(false),
+				Gang:              pointerOf(false),
+				Prefetch:          pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -643,6 +649,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Region:            pointerOf("global"),
 				Type:              pointerOf("service"),
 				AllAtOnce:         pointerOf(false),
+				Gang:              pointerOf(false),
+				Prefetch:          pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -816,6 +824,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
 				AllAtOnce:         pointerOf(false),
+				Gang:              pointerOf(false),
+				Prefetch:          pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -910,6 +920,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
 				AllAtOnce:         pointerOf(false),
+				Gang:              pointerOf(false),
+				Prefetch:          pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -1094,6 +1106,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 				NodePool:          pointerOf(""),
 				Priority:          pointerOf(JobDefaultPriority),
 				AllAtOnce:         pointerOf(false),
+				Gang:              pointerOf(false),
+				Prefetch:          pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
@@ -1273,6 +1287,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Priority:          pointerOf(JobDefaultPriority),
 				NodePool:          pointerOf(""),
 				AllAtOnce:         pointerOf(false),
+				Gang:              pointerOf(false),
+				Prefetch:          pointerOf(false),
 				ConsulToken:       pointerOf(""),
 				ConsulNamespace:   pointerOf(""),
 				VaultToken:        pointerOf(""),
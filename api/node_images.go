@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+// NodeImagePrefetchRequest lists the images a Node should pull ahead of the
+// tasks that use them.
+type NodeImagePrefetchRequest struct {
+	NodeID string
+
+	// Driver is the task driver pulling the images. Defaults to docker.
+	Driver string
+
+	Images []string
+}
+
+// NodeImagePrefetchResponse lists the images the Node started pulling.
+type NodeImagePrefetchResponse struct {
+	// Images are the normalized names of the images being pulled
+	Images []string
+}
+
+// NodeImages is a client for managing the images cached on a Node.
+type NodeImages struct {
+	client *Client
+}
+
+// Images returns a NodeImages client.
+func (n *Nodes) Images() *NodeImages {
+	return &NodeImages{client: n.client}
+}
+
+// Prefetch has a Node pull images in the background so tasks using them start
+// without waiting for the pull. Once pulled, the images are reported as Node
+// attributes. If NodeID is unset then the Node receiving the request pulls the
+// images.
+func (n *NodeImages) Prefetch(req *NodeImagePrefetchRequest, qo *QueryOptions) (*NodeImagePrefetchResponse, error) {
+	var out NodeImagePrefetchResponse
+	_, err := n.client.postQuery("/v1/client/images/prefetch", req, &out, qo)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
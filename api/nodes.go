@@ -568,10 +568,20 @@ type Node struct {
 	CSIControllerPlugins  map[string]*CSIInfo
 	CSINodePlugins        map[string]*CSIInfo
 	LastDrain             *DrainMetadata
+	Utilization           *NodeUtilization
 	CreateIndex           uint64
 	ModifyIndex           uint64
 }
 
+// NodeUtilization is the 95th percentile of the CPU and memory usage observed
+// by a client over a rolling window, as last reported to the servers.
+type NodeUtilization struct {
+	CPU       int64
+	MemoryMB  int64
+	Samples   int
+	UpdatedAt int64
+}
+
 type NodeResources struct {
 	Cpu      NodeCpuResources
 	Memory   NodeMemoryResources
@@ -180,11 +180,53 @@ type SchedulerConfiguration struct {
 	// until the configuration is updated and written to the Nomad servers.
 	PauseEvalBroker bool
 
+	// ScoringPlugins is the set of additional scoring plugins the scheduler
+	// applies when ranking nodes, along with their weights.
+	ScoringPlugins []*SchedulerScoringPlugin
+
+	// EvalBrokerFairShare configures weighted fair-share dequeueing of
+	// evaluations across namespaces by the eval broker.
+	EvalBrokerFairShare *EvalBrokerFairShare
+
+	// UtilizationScoring configures the bin packing score to blend the
+	// resources reserved on a node with the utilization observed by its
+	// client.
+	UtilizationScoring *SchedulerUtilizationScoring
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
 }
 
+// EvalBrokerFairShare is the configuration for weighted fair-share
+// dequeueing of evaluations across namespaces.
+type EvalBrokerFairShare struct {
+	// Enabled specifies whether fair-share dequeueing is enabled.
+	Enabled bool
+
+	// DefaultWeight is the weight of namespaces that are not listed in
+	// NamespaceWeights. Defaults to 1 if unset.
+	DefaultWeight int
+
+	// NamespaceWeights maps namespace names to their weight.
+	NamespaceWeights map[string]int
+}
+
+// SchedulerUtilizationScoring is the configuration for utilization-aware bin
+// packing.
+type SchedulerUtilizationScoring struct {
+	// Enabled specifies whether utilization-aware scoring is enabled.
+	Enabled bool
+
+	// ObservedWeight is the percentage of the score, between 0 and 100,
+	// computed from observed utilization. Defaults to 50 if unset.
+	ObservedWeight int
+
+	// MaxAge is how old a node's utilization report may be before it is
+	// ignored. Defaults to 5 minutes if unset.
+	MaxAge time.Duration
+}
+
 // SchedulerConfigurationResponse is the response object that wraps SchedulerConfiguration
 type SchedulerConfigurationResponse struct {
 	// SchedulerConfig contains scheduler config options
@@ -213,6 +255,13 @@ const (
 	SchedulerAlgorithmSpread  SchedulerAlgorithm = "spread"
 )
 
+// SchedulerScoringPlugin enables a scoring plugin registered with the
+// scheduler. Weight is between -100 and 100 and scales the plugin's score.
+type SchedulerScoringPlugin struct {
+	Name   string
+	Weight int8
+}
+
 // PreemptionConfig specifies whether preemption is enabled based on scheduler type
 type PreemptionConfig struct {
 	SystemSchedulerEnabled   bool
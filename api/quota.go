@@ -125,8 +125,9 @@ type QuotaLimit struct {
 
 	// RegionLimit is the quota limit that applies to any allocation within a
 	// referencing namespace in the region. A value of zero is treated as
-	// unlimited and a negative value is treated as fully disallowed. This is
-	// useful for once we support GPUs
+	// unlimited and a negative value is treated as fully disallowed. Devices
+	// are only limited when listed, in which case their count is the maximum
+	// number of device instances that may be allocated.
 	RegionLimit *Resources
 
 	// VariablesLimit is the maximum total size of all variables
@@ -134,6 +135,11 @@ type QuotaLimit struct {
 	// negative value is treated as fully disallowed.
 	VariablesLimit *int `mapstructure:"variables_limit" hcl:"variables_limit,optional"`
 
+	// HostVolumesLimit is the maximum total capacity in MiB of all dynamic
+	// host volumes. A value of zero is treated as unlimited and a negative
+	// value is treated as fully disallowed.
+	HostVolumesLimit *int `mapstructure:"host_volumes_limit" hcl:"host_volumes_limit,optional"`
+
 	// Hash is the hash of the object and is used to make replication efficient.
 	Hash []byte
 }
@@ -19,6 +19,11 @@ type Resources struct {
 	Devices     []*RequestedDevice `hcl:"device,block"`
 	NUMA        *NUMAResource      `hcl:"numa,block"`
 
+	IOWeight           *int   `mapstructure:"io_weight" hcl:"io_weight,optional"`
+	ReadBps            *int64 `mapstructure:"read_bps" hcl:"read_bps,optional"`
+	WriteBps           *int64 `mapstructure:"write_bps" hcl:"write_bps,optional"`
+	NetworkEgressMBits *int   `mapstructure:"network_egress_mbits" hcl:"network_egress_mbits,optional"`
+
 	// COMPAT(0.10)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
 	// 0.10 and is only being kept to allow any references to be removed before
@@ -103,6 +108,18 @@ func (r *Resources) Merge(other *Resources) {
 	if other.NUMA != nil {
 		r.NUMA = other.NUMA.Copy()
 	}
+	if other.IOWeight != nil {
+		r.IOWeight = other.IOWeight
+	}
+	if other.ReadBps != nil {
+		r.ReadBps = other.ReadBps
+	}
+	if other.WriteBps != nil {
+		r.WriteBps = other.WriteBps
+	}
+	if other.NetworkEgressMBits != nil {
+		r.NetworkEgressMBits = other.NetworkEgressMBits
+	}
 }
 
 // NUMAResource contains the NUMA affinity request for scheduling purposes.
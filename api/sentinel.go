@@ -69,9 +69,15 @@ type SentinelPolicy struct {
 	Description      string
 	Scope            string
 	EnforcementLevel string
-	Policy           string
-	CreateIndex      uint64
-	ModifyIndex      uint64
+
+	// Namespaces restricts the policy to jobs submitted to the given
+	// namespaces. The policy applies to all namespaces when empty.
+	Namespaces []string
+
+	Policy      string
+	Hash        []byte
+	CreateIndex uint64
+	ModifyIndex uint64
 }
 
 type SentinelPolicyListStub struct {
@@ -79,6 +85,8 @@ type SentinelPolicyListStub struct {
 	Description      string
 	Scope            string
 	EnforcementLevel string
+	Namespaces       []string
+	Hash             []byte
 	CreateIndex      uint64
 	ModifyIndex      uint64
 }
@@ -1,8 +1,6 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: MPL-2.0
 
-//go:build ent
-
 package api
 
 import (
@@ -30,7 +28,7 @@ func TestSentinelPolicies_ListUpsert(t *testing.T) {
 		Description:      "test",
 		EnforcementLevel: "advisory",
 		Scope:            "submit-job",
-		Policy:           "main = rule { true }",
+		Policy:           `rule "allow" { condition = true }`,
 	}
 	wm, err := ap.Upsert(policy, nil)
 	must.NoError(t, err)
@@ -56,7 +54,7 @@ func TestSentinelPolicies_Delete(t *testing.T) {
 		Description:      "test",
 		EnforcementLevel: "advisory",
 		Scope:            "submit-job",
-		Policy:           "main = rule { true } ",
+		Policy:           `rule "allow" { condition = true }`,
 	}
 	wm, err := ap.Upsert(policy, nil)
 	must.NoError(t, err)
@@ -87,7 +85,7 @@ func TestSentinelPolicies_Info(t *testing.T) {
 		Description:      "test",
 		EnforcementLevel: "advisory",
 		Scope:            "submit-job",
-		Policy:           "main = rule { true }",
+		Policy:           `rule "allow" { condition = true }`,
 	}
 	wm, err := ap.Upsert(policy, nil)
 	must.NoError(t, err)
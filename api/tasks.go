@@ -363,9 +363,10 @@ func (s *Spread) Canonicalize() {
 
 // EphemeralDisk is an ephemeral disk object
 type EphemeralDisk struct {
-	Sticky  *bool `hcl:"sticky,optional"`
-	Migrate *bool `hcl:"migrate,optional"`
-	SizeMB  *int  `mapstructure:"size" hcl:"size,optional"`
+	Sticky      *bool `hcl:"sticky,optional"`
+	Migrate     *bool `hcl:"migrate,optional"`
+	SizeMB      *int  `mapstructure:"size" hcl:"size,optional"`
+	LogBudgetMB *int  `mapstructure:"log_budget" hcl:"log_budget,optional"`
 }
 
 func DefaultEphemeralDisk() *EphemeralDisk {
@@ -395,6 +396,7 @@ type MigrateStrategy struct {
 	HealthCheck     *string        `mapstructure:"health_check" hcl:"health_check,optional"`
 	MinHealthyTime  *time.Duration `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
 	HealthyDeadline *time.Duration `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
+	Checkpoint      *bool          `mapstructure:"checkpoint" hcl:"checkpoint,optional"`
 }
 
 func DefaultMigrateStrategy() *MigrateStrategy {
@@ -438,6 +440,9 @@ func (m *MigrateStrategy) Merge(o *MigrateStrategy) {
 	if o.HealthyDeadline != nil {
 		m.HealthyDeadline = o.HealthyDeadline
 	}
+	if o.Checkpoint != nil {
+		m.Checkpoint = o.Checkpoint
+	}
 }
 
 func (m *MigrateStrategy) Copy() *MigrateStrategy {
@@ -721,6 +726,11 @@ type LogConfig struct {
 	Enabled *bool `mapstructure:"enabled" hcl:"enabled,optional"`
 
 	Disabled *bool `mapstructure:"disabled" hcl:"disabled,optional"`
+
+	RotateInterval *time.Duration `mapstructure:"rotate_interval" hcl:"rotate_interval,optional"`
+	Compression    *string        `mapstructure:"compression" hcl:"compression,optional"`
+
+	Sinks []*LogSink `mapstructure:"sink" hcl:"sink,block"`
 }
 
 func DefaultLogConfig() *LogConfig {
@@ -741,6 +751,31 @@ func (l *LogConfig) Canonicalize() {
 	if l.Disabled == nil {
 		l.Disabled = pointerOf(false)
 	}
+	for _, sink := range l.Sinks {
+		sink.Canonicalize()
+	}
+}
+
+// LogSink forwards a task's logs to an external system.
+type LogSink struct {
+	Name        string         `hcl:"name,label"`
+	Type        string         `mapstructure:"type" hcl:"type,optional"`
+	Address     string         `mapstructure:"address" hcl:"address,optional"`
+	BatchSize   *int           `mapstructure:"batch_size" hcl:"batch_size,optional"`
+	BatchWait   *time.Duration `mapstructure:"batch_wait" hcl:"batch_wait,optional"`
+	SpoolSizeMB *int           `mapstructure:"spool_size" hcl:"spool_size,optional"`
+}
+
+func (s *LogSink) Canonicalize() {
+	if s.BatchSize == nil {
+		s.BatchSize = pointerOf(100)
+	}
+	if s.BatchWait == nil {
+		s.BatchWait = pointerOf(time.Second)
+	}
+	if s.SpoolSizeMB == nil {
+		s.SpoolSizeMB = pointerOf(10)
+	}
 }
 
 // DispatchPayloadConfig configures how a task gets its input from a job dispatch
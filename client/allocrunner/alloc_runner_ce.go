@@ -12,10 +12,22 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
-func (ar *allocRunner) SetTaskPauseState(string, structs.TaskScheduleState) error {
-	return fmt.Errorf("Enterprise only")
+// SetTaskPauseState overrides the schedule of a task in the allocation.
+func (ar *allocRunner) SetTaskPauseState(taskName string, ps structs.TaskScheduleState) error {
+	tr, ok := ar.tasks[taskName]
+	if !ok {
+		return fmt.Errorf("Task not found")
+	}
+
+	return tr.SetTaskPauseState(ps)
 }
 
+// GetTaskPauseState returns the schedule state of a task in the allocation.
 func (ar *allocRunner) GetTaskPauseState(taskName string) (structs.TaskScheduleState, error) {
-	return "", fmt.Errorf("Enterprise only")
+	tr, ok := ar.tasks[taskName]
+	if !ok {
+		return "", fmt.Errorf("Task not found")
+	}
+
+	return tr.TaskState().Paused, nil
 }
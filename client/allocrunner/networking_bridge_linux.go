@@ -59,18 +59,18 @@ func newBridgeNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, b
 		b.allocSubnet = defaultNomadAllocSubnet
 	}
 
-	var netCfg []byte
+	var withConsulCNI bool
 
 	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
 	for _, svc := range tg.Services {
 		if svc.Connect.HasTransparentProxy() {
-			netCfg = buildNomadBridgeNetConfig(*b, true)
+			withConsulCNI = true
 			break
 		}
 	}
-	if netCfg == nil {
-		netCfg = buildNomadBridgeNetConfig(*b, false)
-	}
+	_, withBandwidth := getBandwidth(alloc)
+
+	netCfg := buildNomadBridgeNetConfig(*b, withConsulCNI, withBandwidth)
 
 	c, err := newCNINetworkConfiguratorWithConf(log, cniPath, bridgeNetworkAllocIfPrefix, ignorePortMappingHostIP, netCfg, node)
 	if err != nil {
@@ -152,7 +152,12 @@ func (b *bridgeNetworkConfigurator) Teardown(ctx context.Context, alloc *structs
 	return b.cni.Teardown(ctx, alloc, spec)
 }
 
-func buildNomadBridgeNetConfig(b bridgeNetworkConfigurator, withConsulCNI bool) []byte {
+func buildNomadBridgeNetConfig(b bridgeNetworkConfigurator, withConsulCNI, withBandwidth bool) []byte {
+	var bandwidth string
+	if withBandwidth {
+		bandwidth = bandwidthCNIBlock
+	}
+
 	var consulCNI string
 	if withConsulCNI {
 		consulCNI = consulCNIBlock
@@ -163,6 +168,7 @@ func buildNomadBridgeNetConfig(b bridgeNetworkConfigurator, withConsulCNI bool)
 		b.hairpinMode,
 		b.allocSubnet,
 		cniAdminChainName,
+		bandwidth,
 		consulCNI,
 	))
 }
@@ -207,11 +213,20 @@ const nomadCNIConfigTemplate = `{
 			"type": "portmap",
 			"capabilities": {"portMappings": true},
 			"snat": true
-		}%s
+		}%s%s
 	]
 }
 `
 
+// bandwidthCNIBlock shapes the traffic of the allocation with tc on the host
+// side of its veth pair, for allocations limiting their egress bandwidth
+const bandwidthCNIBlock = `,
+		{
+			"type": "bandwidth",
+			"capabilities": {"bandwidth": true}
+		}
+`
+
 const consulCNIBlock = `,
 		{
 			"type": "consul-cni",
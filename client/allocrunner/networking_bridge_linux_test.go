@@ -16,6 +16,7 @@ func Test_buildNomadBridgeNetConfig(t *testing.T) {
 	testCases := []struct {
 		name          string
 		withConsulCNI bool
+		withBandwidth bool
 		b             *bridgeNetworkConfigurator
 	}{
 		{
@@ -48,12 +49,29 @@ func Test_buildNomadBridgeNetConfig(t *testing.T) {
 				hairpinMode: true,
 			},
 		},
+		{
+			name:          "bandwidth",
+			withBandwidth: true,
+			b: &bridgeNetworkConfigurator{
+				bridgeName:  defaultNomadBridgeName,
+				allocSubnet: defaultNomadAllocSubnet,
+			},
+		},
+		{
+			name:          "bandwidth-consul-cni",
+			withConsulCNI: true,
+			withBandwidth: true,
+			b: &bridgeNetworkConfigurator{
+				bridgeName:  defaultNomadBridgeName,
+				allocSubnet: defaultNomadAllocSubnet,
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc := tc
 			ci.Parallel(t)
-			bCfg := buildNomadBridgeNetConfig(*tc.b, tc.withConsulCNI)
+			bCfg := buildNomadBridgeNetConfig(*tc.b, tc.withConsulCNI, tc.withBandwidth)
 			// Validate that the JSON created is rational
 			must.True(t, json.Valid(bCfg))
 			if tc.withConsulCNI {
@@ -61,6 +79,11 @@ func Test_buildNomadBridgeNetConfig(t *testing.T) {
 			} else {
 				must.StrNotContains(t, string(bCfg), "consul-cni")
 			}
+			if tc.withBandwidth {
+				must.StrContains(t, string(bCfg), `"type": "bandwidth"`)
+			} else {
+				must.StrNotContains(t, string(bCfg), "bandwidth")
+			}
 		})
 	}
 }
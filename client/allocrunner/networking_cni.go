@@ -132,6 +132,14 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 
 	portMaps := getPortMapping(alloc, c.ignorePortMappingHostIP)
 
+	setupOpts := []cni.NamespaceOpts{
+		cni.WithCapabilityPortMap(portMaps.ports),
+		cni.WithLabels(cniArgs), // "labels" turn into CNI_ARGS
+	}
+	if bandwidth, ok := getBandwidth(alloc); ok {
+		setupOpts = append(setupOpts, cni.WithCapabilityBandWidth(bandwidth))
+	}
+
 	tproxyArgs, err := c.setupTransparentProxyArgs(alloc, spec, portMaps)
 	if err != nil {
 		return nil, err
@@ -152,10 +160,7 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 	var res *cni.Result
 	for attempt := 1; ; attempt++ {
 		var err error
-		if res, err = c.cni.Setup(ctx, alloc.ID, spec.Path,
-			cni.WithCapabilityPortMap(portMaps.ports),
-			cni.WithLabels(cniArgs), // "labels" turn into CNI_ARGS
-		); err != nil {
+		if res, err = c.cni.Setup(ctx, alloc.ID, spec.Path, setupOpts...); err != nil {
 			c.logger.Warn("failed to configure network", "error", err, "attempt", attempt)
 			switch attempt {
 			case 1:
@@ -478,6 +483,27 @@ func loadCNIConf(confDir, name string) ([]byte, error) {
 	return nil, fmt.Errorf("CNI network config not found for name %q", name)
 }
 
+// getBandwidth returns the bandwidth capability arguments for the bandwidth
+// CNI plugin, which shapes the egress of the allocation to the sum of the
+// egress bandwidth of its tasks. It returns false if no task limits it.
+func getBandwidth(alloc *structs.Allocation) (cni.BandWidth, bool) {
+	var mbits int64
+	for _, tr := range alloc.AllocatedResources.Tasks {
+		mbits += tr.IO.NetworkEgressMBits
+	}
+	if mbits <= 0 {
+		return cni.BandWidth{}, false
+	}
+
+	// rates are in bits per second, and allowing bursts of up to a second of
+	// traffic keeps the shaping from penalizing short spikes
+	rate := uint64(mbits) * 1_000_000
+	return cni.BandWidth{
+		EgressRate:  rate,
+		EgressBurst: rate,
+	}, true
+}
+
 // Teardown calls the CNI plugins with the delete action
 func (c *cniNetworkConfigurator) Teardown(ctx context.Context, alloc *structs.Allocation, spec *drivers.NetworkIsolationSpec) error {
 	if err := c.ensureCNIInitialized(); err != nil {
@@ -252,6 +252,25 @@ func TestCNI_addCustomCNIArgs(t *testing.T) {
 	}
 }
 
+func TestCNI_getBandwidth(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	_, ok := getBandwidth(alloc)
+	must.False(t, ok)
+
+	alloc.AllocatedResources.Tasks["web"].IO.NetworkEgressMBits = 100
+	alloc.AllocatedResources.Tasks["sidecar"] = &structs.AllocatedTaskResources{
+		IO: structs.AllocatedIOResources{NetworkEgressMBits: 50},
+	}
+	bandwidth, ok := getBandwidth(alloc)
+	must.True(t, ok)
+	must.Eq(t, cni.BandWidth{
+		EgressRate:  150_000_000,
+		EgressBurst: 150_000_000,
+	}, bandwidth)
+}
+
 func TestCNI_setupTproxyArgs(t *testing.T) {
 	ci.Parallel(t)
 
@@ -114,6 +114,16 @@ func (h *DriverHandle) ExecStreaming(ctx context.Context,
 	return stream.Send(drivers.NewExecStreamingResponseExit(result.ExitCode))
 }
 
+// UpdateResources applies new resource limits to the running task, if the
+// driver supports resizing tasks in place.
+func (h *DriverHandle) UpdateResources(resources *drivers.Resources) error {
+	d, ok := h.driver.(drivers.ResourceUpdateDriver)
+	if !ok {
+		return fmt.Errorf("task driver does not support updating resources")
+	}
+	return d.UpdateResources(h.taskID, resources)
+}
+
 func (h *DriverHandle) Network() *drivers.DriverNetwork {
 	return h.net
 }
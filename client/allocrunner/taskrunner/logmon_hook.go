@@ -192,7 +192,7 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 		}
 	}
 
-	err := h.logmon.Start(&logmon.LogConfig{
+	cfg := &logmon.LogConfig{
 		LogDir:        h.config.logDir,
 		StdoutLogFile: fmt.Sprintf("%s.stdout", req.Task.Name),
 		StderrLogFile: fmt.Sprintf("%s.stderr", req.Task.Name),
@@ -200,7 +200,45 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 		StderrFifo:    h.config.stderrFifo,
 		MaxFiles:      req.Task.LogConfig.MaxFiles,
 		MaxFileSizeMB: req.Task.LogConfig.MaxFileSizeMB,
-	})
+
+		RotateInterval: req.Task.LogConfig.RotateInterval,
+		Compression:    req.Task.LogConfig.Compression,
+	}
+	if alloc := h.runner.Alloc(); alloc != nil && alloc.Job != nil {
+		if tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup); tg != nil && tg.EphemeralDisk != nil {
+			cfg.LogBudgetMB = tg.EphemeralDisk.LogBudgetMB
+		}
+	}
+	if sinks := req.Task.LogConfig.Sinks; len(sinks) > 0 {
+		alloc := h.runner.Alloc()
+		cfg.Labels = map[string]string{
+			"namespace": alloc.Namespace,
+			"job":       alloc.JobID,
+			"group":     alloc.TaskGroup,
+			"alloc":     alloc.ID,
+			"task":      req.Task.Name,
+		}
+		cfg.SpoolDir = filepath.Join(h.config.logDir, fmt.Sprintf(".%s.spool", req.Task.Name))
+		if p := h.runner.clientConfig.LogSinks; p != nil {
+			cfg.SinkPolicy = &logmon.SinkPolicy{
+				AllowedTypes:     p.AllowedTypes,
+				AllowedHosts:     p.AllowedHosts,
+				AllowUnixSockets: p.AllowUnixSockets,
+			}
+		}
+		for _, sink := range sinks {
+			cfg.Sinks = append(cfg.Sinks, &logmon.SinkConfig{
+				Name:        sink.Name,
+				Type:        sink.Type,
+				Address:     sink.Address,
+				BatchSize:   sink.BatchSize,
+				BatchWait:   sink.BatchWait,
+				SpoolSizeMB: sink.SpoolSizeMB,
+			})
+		}
+	}
+
+	err := h.logmon.Start(cfg)
 	if err != nil {
 		h.logger.Error("failed to start logmon", "error", err)
 		return err
@@ -6,29 +6,258 @@
 package taskrunner
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
-type pauseHook struct{}
+var _ interfaces.TaskPrestartHook = (*pauseHook)(nil)
 
-func (pauseHook) Name() string { return taskPauseHookName }
+// pauseHook drives the pause gate of a task with a schedule block. It opens
+// the gate when the task enters its schedule and closes it, stopping the
+// task, when the schedule ends.
+type pauseHook struct {
+	tr       *TaskRunner
+	schedule *structs.TaskSchedule
 
-func newPauseHook(...any) pauseHook {
-	return pauseHook{}
-}
+	// started is set once the schedule watcher is running. Prestart hooks
+	// are called serially by the task runner so it needs no lock.
+	started bool
 
-type pauseGate struct{}
+	logger hclog.Logger
+}
 
-func newPauseGate(...any) *pauseGate {
-	return &pauseGate{}
+func newPauseHook(tr *TaskRunner, logger hclog.Logger) *pauseHook {
+	h := &pauseHook{
+		tr:       tr,
+		schedule: tr.Task().Schedule,
+	}
+	h.logger = logger.Named(h.Name())
+	return h
 }
 
-func (*pauseGate) Wait() error {
+func (*pauseHook) Name() string { return taskPauseHookName }
+
+// Prestart restores any override of the schedule from the task state, applies
+// the current state of the schedule and starts watching for its transitions.
+func (h *pauseHook) Prestart(_ context.Context, _ *interfaces.TaskPrestartRequest, _ *interfaces.TaskPrestartResponse) error {
+	if h.started {
+		return nil
+	}
+
+	h.tr.pauser.restore(h.tr.TaskState().Paused)
+
+	next, err := h.updateSchedule(time.Now())
+	if err != nil {
+		return err
+	}
+
+	h.started = true
+	go h.run(next)
 	return nil
 }
 
-func (tr *TaskRunner) SetTaskPauseState(structs.TaskScheduleState) error {
-	return fmt.Errorf("Enterprise only")
+// run applies the schedule each time it transitions until the task runner
+// exits.
+func (h *pauseHook) run(next time.Duration) {
+	timer, stop := helper.NewSafeTimer(next)
+	defer stop()
+
+	for {
+		select {
+		case <-h.tr.killCtx.Done():
+			return
+		case <-h.tr.shutdownCtx.Done():
+			return
+		case <-h.tr.WaitCh():
+			return
+		case <-timer.C:
+		}
+
+		next, err := h.updateSchedule(time.Now())
+		if err != nil {
+			h.logger.Error("failed to compute task schedule", "error", err)
+			return
+		}
+		timer.Reset(next)
+	}
+}
+
+// updateSchedule applies the state of the schedule at the given time and
+// returns the time until its next transition.
+func (h *pauseHook) updateSchedule(now time.Time) (time.Duration, error) {
+	start, end, err := h.schedule.Next(now)
+	if err != nil {
+		return 0, err
+	}
+
+	if start == 0 {
+		h.tr.pauser.setScheduled(structs.TaskScheduleStateRun)
+		return end, nil
+	}
+
+	h.tr.pauser.setScheduled(structs.TaskScheduleStateSchedPause)
+	return start, nil
+}
+
+// pauseGate blocks the task runner from starting a task while the task is
+// paused, either by its schedule or by an override set with alloc pause.
+type pauseGate struct {
+	tr *TaskRunner
+
+	// applyLock serializes state transitions, which may block while the
+	// task is killed.
+	applyLock sync.Mutex
+
+	// scheduled is the state set by the task schedule, either
+	// TaskScheduleStateRun or TaskScheduleStateSchedPause.
+	scheduled structs.TaskScheduleState
+
+	// override is TaskScheduleStateForceRun or TaskScheduleStateForcePause
+	// when the schedule is overridden, otherwise TaskScheduleStateRun.
+	override structs.TaskScheduleState
+
+	// current is the last state applied to the task.
+	current structs.TaskScheduleState
+
+	// openCh is closed while the task is allowed to run.
+	openCh   chan struct{}
+	openLock sync.Mutex
+}
+
+func newPauseGate(tr *TaskRunner) *pauseGate {
+	openCh := make(chan struct{})
+	close(openCh)
+
+	return &pauseGate{
+		tr:     tr,
+		openCh: openCh,
+	}
+}
+
+// Wait blocks until the task is allowed to run or the task runner is killed
+// or shut down, in which case the error of the cancelled context is returned.
+func (g *pauseGate) Wait() error {
+	g.openLock.Lock()
+	openCh := g.openCh
+	g.openLock.Unlock()
+
+	select {
+	case <-openCh:
+		return nil
+	case <-g.tr.killCtx.Done():
+		return g.tr.killCtx.Err()
+	case <-g.tr.shutdownCtx.Done():
+		return g.tr.shutdownCtx.Err()
+	}
+}
+
+// restore sets the state recorded in the task state before the task runner
+// was restarted, so that overrides survive a client restart.
+func (g *pauseGate) restore(state structs.TaskScheduleState) {
+	g.applyLock.Lock()
+	defer g.applyLock.Unlock()
+
+	switch state {
+	case structs.TaskScheduleStateForceRun, structs.TaskScheduleStateForcePause:
+		g.override = state
+	}
+	g.current = state
+}
+
+// setScheduled sets the state dictated by the task schedule.
+func (g *pauseGate) setScheduled(state structs.TaskScheduleState) {
+	g.applyLock.Lock()
+	defer g.applyLock.Unlock()
+
+	g.scheduled = state
+	g.apply()
+}
+
+// setOverride overrides the task schedule. TaskScheduleStateRun removes the
+// override so the task follows its schedule again.
+func (g *pauseGate) setOverride(state structs.TaskScheduleState) {
+	g.applyLock.Lock()
+	defer g.applyLock.Unlock()
+
+	g.override = state
+	g.apply()
+}
+
+// apply opens or closes the gate and transitions the task if its state
+// changed. Callers must hold applyLock.
+func (g *pauseGate) apply() {
+	state := g.override
+	if state == structs.TaskScheduleStateRun {
+		state = g.scheduled
+	}
+
+	g.openLock.Lock()
+	select {
+	case <-g.openCh:
+		if state.Stop() {
+			g.openCh = make(chan struct{})
+		}
+	default:
+		if !state.Stop() {
+			close(g.openCh)
+		}
+	}
+	g.openLock.Unlock()
+
+	if state == g.current {
+		return
+	}
+	g.current = state
+	g.tr.setPauseState(state)
+}
+
+// setPauseState records the schedule state of the task and emits its event.
+// When the task is paused it is restarted so that the run loop blocks on the
+// pause gate until the task is allowed to run again.
+func (tr *TaskRunner) setPauseState(state structs.TaskScheduleState) {
+	tr.stateLock.Lock()
+	tr.state.Paused = state
+	tr.stateLock.Unlock()
+
+	event := state.Event()
+	if !state.Stop() || tr.getDriverHandle() == nil {
+		tr.EmitEvent(event)
+		return
+	}
+
+	err := tr.Restart(tr.killCtx, event, false)
+	switch {
+	case errors.Is(err, ErrTaskNotRunning):
+		tr.EmitEvent(event)
+	case err != nil:
+		tr.logger.Error("failed to pause task", "error", err)
+	}
+}
+
+// SetTaskPauseState overrides the schedule of the task.
+// TaskScheduleStateSchedResume removes the override so the task follows its
+// schedule again.
+func (tr *TaskRunner) SetTaskPauseState(state structs.TaskScheduleState) error {
+	if tr.Task().Schedule == nil {
+		return fmt.Errorf("task %q does not have a schedule", tr.taskName)
+	}
+
+	switch state {
+	case structs.TaskScheduleStateForceRun, structs.TaskScheduleStateForcePause:
+	case structs.TaskScheduleStateSchedResume:
+		state = structs.TaskScheduleStateRun
+	default:
+		return fmt.Errorf("invalid task schedule state %q", state)
+	}
+
+	tr.pauser.setOverride(state)
+	return nil
 }
@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !ent
+
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+// testWaitForPauseState waits for the task to reach the given state and
+// schedule state.
+func testWaitForPauseState(t *testing.T, tr *TaskRunner, state string, paused structs.TaskScheduleState) {
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			ts := tr.TaskState()
+			if ts.State != state || ts.Paused != paused {
+				return fmt.Errorf("expected task to be %s/%q, got %s/%q",
+					state, paused, ts.State, ts.Paused)
+			}
+			return nil
+		}),
+		wait.Timeout(10*time.Second),
+		wait.Gap(50*time.Millisecond),
+	))
+}
+
+func TestTaskRunner_PauseSchedule(t *testing.T) {
+	ci.Parallel(t)
+
+	// Schedule a one hour window half a day away so the task starts paused.
+	hour := time.Now().UTC().Add(12 * time.Hour).Hour()
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "30s",
+	}
+	task.Schedule = &structs.TaskSchedule{
+		Cron: &structs.TaskScheduleCron{
+			Start:    fmt.Sprintf("0 %d * * * *", hour),
+			End:      fmt.Sprintf("59 %d", hour),
+			Timezone: "UTC",
+		},
+	}
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+
+	testWaitForPauseState(t, tr, structs.TaskStatePending, structs.TaskScheduleStateSchedPause)
+
+	// Overriding the schedule starts the task
+	must.NoError(t, tr.SetTaskPauseState(structs.TaskScheduleStateForceRun))
+	testWaitForPauseState(t, tr, structs.TaskStateRunning, structs.TaskScheduleStateForceRun)
+
+	// Pausing the task stops it without failing it
+	must.NoError(t, tr.SetTaskPauseState(structs.TaskScheduleStateForcePause))
+	testWaitForPauseState(t, tr, structs.TaskStatePending, structs.TaskScheduleStateForcePause)
+	must.False(t, tr.TaskState().Failed)
+
+	// Removing the override returns the task to its schedule
+	must.NoError(t, tr.SetTaskPauseState(structs.TaskScheduleStateSchedResume))
+	testWaitForPauseState(t, tr, structs.TaskStatePending, structs.TaskScheduleStateSchedPause)
+
+	var messages []string
+	for _, ev := range tr.TaskState().Events {
+		if ev.Type == structs.TaskPausing || ev.Type == structs.TaskRunning {
+			messages = append(messages, ev.DisplayMessage)
+		}
+	}
+	must.SliceContains(t, messages, "Running due to override")
+	must.SliceContains(t, messages, "Pausing due to override")
+	must.Eq(t, "Pausing due to schedule", messages[len(messages)-1])
+
+	must.Error(t, tr.SetTaskPauseState(structs.TaskScheduleStateRun))
+}
+
+func TestTaskRunner_PauseSchedule_NoSchedule(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "1s",
+	}
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+
+	err := tr.SetTaskPauseState(structs.TaskScheduleStateForcePause)
+	must.EqError(t, err, fmt.Sprintf("task %q does not have a schedule", task.Name))
+}
+
+func TestPauseGate_Wait(t *testing.T) {
+	ci.Parallel(t)
+
+	newGate := func() (*pauseGate, context.CancelFunc, context.CancelFunc) {
+		killCtx, killCancel := context.WithCancel(context.Background())
+		shutdownCtx, shutdownCancel := context.WithCancel(context.Background())
+		t.Cleanup(killCancel)
+		t.Cleanup(shutdownCancel)
+		tr := &TaskRunner{killCtx: killCtx, shutdownCtx: shutdownCtx}
+		return newPauseGate(tr), killCancel, shutdownCancel
+	}
+
+	// An open gate lets the task run
+	gate, _, _ := newGate()
+	must.NoError(t, gate.Wait())
+
+	// A closed gate returns the error of the context that was cancelled
+	gate, kill, _ := newGate()
+	gate.openCh = make(chan struct{})
+	kill()
+	must.ErrorIs(t, gate.Wait(), context.Canceled)
+
+	gate, _, shutdown := newGate()
+	gate.openCh = make(chan struct{})
+	shutdown()
+	must.ErrorIs(t, gate.Wait(), context.Canceled)
+}
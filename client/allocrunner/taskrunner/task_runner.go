@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"sync"
@@ -75,12 +77,17 @@ const (
 )
 
 type TaskRunner struct {
-	// allocID, taskName, taskLeader, and taskResources are immutable so these fields may
+	// allocID, taskName, and taskLeader are immutable so these fields may
 	// be accessed without locks
-	allocID       string
-	taskName      string
-	taskLeader    bool
-	taskResources *structs.AllocatedTaskResources
+	allocID    string
+	taskName   string
+	taskLeader bool
+
+	// taskResources are the resources allocated to the task. They change when
+	// the task is resized in place.
+	// Must acquire taskResourcesLock to access.
+	taskResources     *structs.AllocatedTaskResources
+	taskResourcesLock sync.RWMutex
 
 	alloc     *structs.Allocation
 	allocLock sync.Mutex
@@ -282,7 +289,7 @@ type TaskRunner struct {
 	users dynamic.Pool
 
 	// pauser controls whether the task should be run or stopped based on a
-	// schedule. (Enterprise)
+	// schedule.
 	pauser *pauseGate
 }
 
@@ -620,7 +627,7 @@ MAIN:
 			goto RESTART
 		}
 
-		// Unblocks when the task runner is allowed to continue. (Enterprise)
+		// Unblocks when the task runner is allowed to continue.
 		if err := tr.pauser.Wait(); err != nil {
 			tr.logger.Error("pause scheduled failed", "error", err)
 			tr.restartTracker.SetStartError(err)
@@ -850,7 +857,8 @@ func (tr *TaskRunner) handleUpdates() {
 			return
 		}
 
-		// Non-terminal update; run hooks
+		// Non-terminal update; resize the task and run hooks
+		tr.updateResources()
 		tr.updateHooks()
 	}
 }
@@ -884,7 +892,7 @@ func (tr *TaskRunner) shouldRestart() (bool, time.Duration) {
 }
 
 func (tr *TaskRunner) assignCgroup(taskConfig *drivers.TaskConfig) {
-	reserveCores := len(tr.taskResources.Cpu.ReservedCores) > 0
+	reserveCores := len(tr.getTaskResources().Cpu.ReservedCores) > 0
 	p := cgroupslib.LinuxResourcesPath(taskConfig.AllocID, taskConfig.Name, reserveCores)
 	taskConfig.Resources.LinuxResources.CpusetCgroupPath = p
 }
@@ -943,7 +951,7 @@ func (tr *TaskRunner) runDriver() error {
 	}
 
 	// Start the job if there's no existing handle (or if RecoverTask failed)
-	handle, net, err := tr.driver.StartTask(taskConfig)
+	handle, net, err := tr.startTask(taskConfig)
 	if err != nil {
 		// The plugin has died, try relaunching it
 		if err == bstructs.ErrPluginShutdown {
@@ -987,6 +995,82 @@ func (tr *TaskRunner) runDriver() error {
 	return nil
 }
 
+// startTask starts the task, restoring it from the checkpoint migrated from
+// the previous allocation if there is one. Tasks that fail to be restored are
+// started afresh.
+func (tr *TaskRunner) startTask(taskConfig *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	dir := tr.checkpointDir()
+	if _, err := os.Stat(dir); err != nil {
+		return tr.driver.StartTask(taskConfig)
+	}
+
+	// The images are only valid for the process they were dumped from
+	defer os.RemoveAll(dir)
+
+	if cd, ok := tr.checkpointDriver(); ok {
+		handle, net, err := cd.RestoreTask(taskConfig, dir)
+		if err == nil {
+			tr.EmitEvent(structs.NewTaskEvent(structs.TaskRestoredFromCheckpoint).
+				SetDisplayMessage("Task restored from the checkpoint of the previous allocation"))
+			return handle, net, nil
+		}
+		tr.logger.Warn("failed to restore task from checkpoint, starting task", "error", err)
+	}
+
+	return tr.driver.StartTask(taskConfig)
+}
+
+// checkpointTask checkpoints the task before it's migrated if its group
+// enables checkpointing and the driver supports it, returning whether the
+// task was checkpointed. Drivers stop the task once it's checkpointed.
+func (tr *TaskRunner) checkpointTask(handle *DriverHandle) bool {
+	alloc := tr.Alloc()
+	if !alloc.DesiredTransition.ShouldMigrate() {
+		return false
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || tg.Migrate == nil || !tg.Migrate.Checkpoint {
+		return false
+	}
+
+	cd, ok := tr.checkpointDriver()
+	if !ok {
+		tr.logger.Debug("driver does not support checkpointing, stopping task")
+		return false
+	}
+
+	dir := tr.checkpointDir()
+	if err := os.RemoveAll(dir); err != nil {
+		tr.logger.Warn("failed to remove stale checkpoint, stopping task", "error", err)
+		return false
+	}
+	if err := cd.CheckpointTask(handle.ID(), dir); err != nil {
+		tr.logger.Warn("failed to checkpoint task, stopping task", "error", err)
+		os.RemoveAll(dir)
+		return false
+	}
+
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskCheckpointed).
+		SetDisplayMessage("Task checkpointed before migration"))
+	return true
+}
+
+// checkpointDriver returns the task driver if it's able to checkpoint and
+// restore tasks.
+func (tr *TaskRunner) checkpointDriver() (drivers.CheckpointDriver, bool) {
+	if tr.driverCapabilities == nil || !tr.driverCapabilities.Checkpoint {
+		return nil, false
+	}
+	cd, ok := tr.driver.(drivers.CheckpointDriver)
+	return cd, ok
+}
+
+// checkpointDir returns the directory holding the task's checkpoint. It's
+// within the task's local directory so it's migrated with the ephemeral disk.
+func (tr *TaskRunner) checkpointDir() string {
+	return filepath.Join(tr.taskDir.LocalDir, ".checkpoint")
+}
+
 // initDriver retrives the DriverPlugin from the plugin loader for this task
 func (tr *TaskRunner) initDriver() error {
 	driver, err := tr.driverManager.Dispense(tr.Task().Driver)
@@ -1062,12 +1146,17 @@ func (tr *TaskRunner) handleKill(resultCh <-chan *drivers.ExitResult) *drivers.E
 		return nil
 	}
 
-	// Kill the task using an exponential backoff in-case of failures.
-	result, killErr := tr.killTask(handle, resultCh)
-	if killErr != nil {
-		// We couldn't successfully destroy the resource created.
-		tr.logger.Error("failed to kill task. Resources may have been leaked", "error", killErr)
-		tr.setKillErr(killErr)
+	// Kill the task using an exponential backoff in-case of failures, unless
+	// it was stopped by being checkpointed.
+	var result *drivers.ExitResult
+	var killErr error
+	if !tr.checkpointTask(handle) {
+		result, killErr = tr.killTask(handle, resultCh)
+		if killErr != nil {
+			// We couldn't successfully destroy the resource created.
+			tr.logger.Error("failed to kill task. Resources may have been leaked", "error", killErr)
+			tr.setKillErr(killErr)
+		}
 	}
 
 	if result != nil {
@@ -1146,8 +1235,6 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	task := tr.Task()
 	alloc := tr.Alloc()
 	invocationid := uuid.Short()
-	taskResources := tr.taskResources
-	ports := tr.Alloc().AllocatedResources.Shared.Ports
 	env := tr.envBuilder.Build()
 	tr.networkIsolationLock.Lock()
 	defer tr.networkIsolationLock.Unlock()
@@ -1177,36 +1264,17 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		}
 	}
 
-	memoryLimit := taskResources.Memory.MemoryMB
-	if max := taskResources.Memory.MemoryMaxMB; max > memoryLimit {
-		memoryLimit = max
-	}
-
-	cpusetCpus := make([]string, len(taskResources.Cpu.ReservedCores))
-	for i, v := range taskResources.Cpu.ReservedCores {
-		cpusetCpus[i] = fmt.Sprintf("%d", v)
-	}
-
 	return &drivers.TaskConfig{
-		ID:            fmt.Sprintf("%s/%s/%s", alloc.ID, task.Name, invocationid),
-		Name:          task.Name,
-		JobName:       alloc.Job.Name,
-		JobID:         alloc.Job.ID,
-		TaskGroupName: alloc.TaskGroup,
-		Namespace:     alloc.Namespace,
-		NodeName:      alloc.NodeName,
-		NodeID:        alloc.NodeID,
-		ParentJobID:   alloc.Job.ParentID,
-		Resources: &drivers.Resources{
-			NomadResources: taskResources,
-			LinuxResources: &drivers.LinuxResources{
-				MemoryLimitBytes: memoryLimit * 1024 * 1024,
-				CPUShares:        taskResources.Cpu.CpuShares,
-				CpusetCpus:       strings.Join(cpusetCpus, ","),
-				PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Processors.Topology.UsableCompute()),
-			},
-			Ports: &ports,
-		},
+		ID:               fmt.Sprintf("%s/%s/%s", alloc.ID, task.Name, invocationid),
+		Name:             task.Name,
+		JobName:          alloc.Job.Name,
+		JobID:            alloc.Job.ID,
+		TaskGroupName:    alloc.TaskGroup,
+		Namespace:        alloc.Namespace,
+		NodeName:         alloc.NodeName,
+		NodeID:           alloc.NodeID,
+		ParentJobID:      alloc.Job.ParentID,
+		Resources:        tr.buildTaskResources(alloc, tr.getTaskResources()),
 		Devices:          tr.hookResources.getDevices(),
 		Mounts:           tr.hookResources.getMounts(),
 		Env:              env.Map(),
@@ -1221,6 +1289,33 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	}
 }
 
+// buildTaskResources builds the drivers.Resources of the task from its
+// allocated resources.
+func (tr *TaskRunner) buildTaskResources(alloc *structs.Allocation, taskResources *structs.AllocatedTaskResources) *drivers.Resources {
+	ports := alloc.AllocatedResources.Shared.Ports
+
+	memoryLimit := taskResources.Memory.MemoryMB
+	if max := taskResources.Memory.MemoryMaxMB; max > memoryLimit {
+		memoryLimit = max
+	}
+
+	cpusetCpus := make([]string, len(taskResources.Cpu.ReservedCores))
+	for i, v := range taskResources.Cpu.ReservedCores {
+		cpusetCpus[i] = fmt.Sprintf("%d", v)
+	}
+
+	return &drivers.Resources{
+		NomadResources: taskResources,
+		LinuxResources: &drivers.LinuxResources{
+			MemoryLimitBytes: memoryLimit * 1024 * 1024,
+			CPUShares:        taskResources.Cpu.CpuShares,
+			CpusetCpus:       strings.Join(cpusetCpus, ","),
+			PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Processors.Topology.UsableCompute()),
+		},
+		Ports: &ports,
+	}
+}
+
 // Restore task runner state. Called by AllocRunner.Restore after NewTaskRunner
 // but before Run so no locks need to be acquired.
 func (tr *TaskRunner) Restore() error {
@@ -1468,6 +1563,52 @@ func (tr *TaskRunner) Update(update *structs.Allocation) {
 	}
 }
 
+// updateResources applies the resources of an in-place allocation update to
+// the running task. The task is restarted with its new resources when the
+// driver is unable to resize it in place.
+func (tr *TaskRunner) updateResources() {
+	alloc := tr.Alloc()
+	if alloc.AllocatedResources == nil {
+		return
+	}
+	updated, ok := alloc.AllocatedResources.Tasks[tr.taskName]
+	if !ok || !resourcesResized(tr.getTaskResources(), updated) {
+		return
+	}
+	tr.setTaskResources(updated)
+
+	handle := tr.getDriverHandle()
+	if handle == nil {
+		// the task isn't running, it picks up its new resources on start
+		return
+	}
+
+	err := handle.UpdateResources(tr.buildTaskResources(alloc, updated))
+	if err == nil {
+		tr.logger.Info("updated task resources", "cpu", updated.Cpu.CpuShares,
+			"memory", updated.Memory.MemoryMB, "memory_max", updated.Memory.MemoryMaxMB)
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskResourcesUpdated).
+			SetDisplayMessage(fmt.Sprintf("Task resized to %d MHz cpu and %d MiB memory",
+				updated.Cpu.CpuShares, updated.Memory.MemoryMB)))
+		return
+	}
+
+	tr.logger.Info("unable to update task resources in place, restarting task", "error", err)
+	event := structs.NewTaskEvent(structs.TaskRestartSignal).
+		SetRestartReason("Restarting task to apply updated resources")
+	if err := tr.Restart(tr.killCtx, event, false); err != nil && !errors.Is(err, ErrTaskNotRunning) {
+		tr.logger.Error("failed to restart task to apply updated resources", "error", err)
+	}
+}
+
+// resourcesResized returns true if the cpu or memory allocated to the task
+// changed.
+func resourcesResized(a, b *structs.AllocatedTaskResources) bool {
+	return a.Cpu.CpuShares != b.Cpu.CpuShares ||
+		a.Memory.MemoryMB != b.Memory.MemoryMB ||
+		a.Memory.MemoryMaxMB != b.Memory.MemoryMaxMB
+}
+
 // SetNetworkIsolation is called by the PreRun allocation hook after configuring
 // the network isolation for the allocation
 func (tr *TaskRunner) SetNetworkIsolation(n *drivers.NetworkIsolationSpec) {
@@ -1527,7 +1668,7 @@ func (tr *TaskRunner) LatestResourceUsage() *cstructs.TaskResourceUsage {
 
 	// Look up device statistics lazily when fetched, as currently we do not emit any stats for them yet
 	if ru != nil && tr.deviceStatsReporter != nil {
-		deviceResources := tr.taskResources.Devices
+		deviceResources := tr.getTaskResources().Devices
 		ru.ResourceUsage.DeviceStats = tr.deviceStatsReporter.LatestDeviceResourceStats(deviceResources)
 	}
 	return ru
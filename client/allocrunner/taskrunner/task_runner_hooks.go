@@ -193,7 +193,7 @@ func (tr *TaskRunner) initHooks() {
 		tr.runnerHooks = append(tr.runnerHooks, newRemoteTaskHook(tr, hookLogger))
 	}
 
-	// If this task has a pause schedule, initialize the pause (Enterprise)
+	// If this task has a pause schedule, initialize the pause hook
 	if task.Schedule != nil {
 		tr.runnerHooks = append(tr.runnerHooks, newPauseHook(tr, hookLogger))
 	}
@@ -250,7 +250,7 @@ func (tr *TaskRunner) prestart() error {
 			Task:          tr.Task(),
 			TaskDir:       tr.taskDir,
 			TaskEnv:       tr.envBuilder.Build(),
-			TaskResources: tr.taskResources,
+			TaskResources: tr.getTaskResources(),
 		}
 
 		origHookState := tr.hookState(name)
@@ -383,6 +383,39 @@ func TestTaskRunner_Restore_Running(t *testing.T) {
 	assert.Equal(t, 1, started)
 }
 
+// TestTaskRunner_Checkpoint_Fallback asserts tasks migrated with a checkpoint
+// are started afresh when their driver can't restore them, and that the
+// checkpoint is removed.
+func TestTaskRunner_Checkpoint_Fallback(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10s",
+	}
+
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	defer cleanup()
+
+	checkpointDir := filepath.Join(conf.TaskDir.LocalDir, ".checkpoint")
+	must.NoError(t, os.MkdirAll(checkpointDir, 0o700))
+
+	tr, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+	go tr.Run()
+	defer tr.Kill(context.Background(), structs.NewTaskEvent("cleanup"))
+
+	testWaitForTaskToStart(t, tr)
+
+	_, err = os.Stat(checkpointDir)
+	must.True(t, os.IsNotExist(err))
+	for _, ev := range tr.TaskState().Events {
+		must.NotEq(t, structs.TaskRestoredFromCheckpoint, ev.Type)
+	}
+}
+
 // TestTaskRunner_Restore_Dead asserts that restoring a dead task will place it
 // back in the correct state. If the task was waiting for an alloc restart it
 // must be able to be restarted after restore, otherwise a restart must fail.
@@ -1328,6 +1361,62 @@ func TestTaskRunner_RestartTask(t *testing.T) {
 	require.True(t, found, "restarting task event not found", pretty.Sprint(events))
 }
 
+// TestTaskRunner_UpdateResources asserts that resizing a task whose driver
+// can't update resources in place restarts it with its new resources.
+func TestTaskRunner_UpdateResources(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10m",
+	}
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+
+	testWaitForTaskToStart(t, tr)
+
+	// An update without resource changes doesn't restart the task
+	update := alloc.Copy()
+	update.AllocModifyIndex++
+	tr.Update(update)
+
+	// Resize the task
+	update = update.Copy()
+	update.AllocModifyIndex++
+	update.AllocatedResources.Tasks[task.Name].Memory.MemoryMB = 512
+	update.AllocatedResources.Tasks[task.Name].Memory.MemoryMaxMB = 1024
+	tr.Update(update)
+
+	testutil.WaitForResult(func() (bool, error) {
+		ts := tr.TaskState()
+		if ts.Restarts != 1 {
+			return false, fmt.Errorf("expected 1 restart but found %d\nevents: %s",
+				ts.Restarts, pretty.Sprint(ts.Events))
+		}
+		if ts.State != structs.TaskStateRunning {
+			return false, fmt.Errorf("expected running but received %s", ts.State)
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+
+	must.Eq(t, 512, tr.getTaskResources().Memory.MemoryMB)
+	must.Eq(t, 1024, tr.getTaskResources().Memory.MemoryMaxMB)
+
+	found := false
+	for _, e := range tr.TaskState().Events {
+		if e.Type == structs.TaskRestartSignal {
+			found = true
+			must.StrContains(t, e.DisplayMessage, "updated resources")
+		}
+	}
+	must.True(t, found, must.Sprint("restart event not found"))
+}
+
 // TestTaskRunner_CheckWatcher_Restart asserts that when enabled an unhealthy
 // Consul check will cause a task to restart following restart policy rules.
 func TestTaskRunner_CheckWatcher_Restart(t *testing.T) {
@@ -34,6 +34,7 @@ import (
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	"github.com/hashicorp/nomad/client/fingerprint"
 	"github.com/hashicorp/nomad/client/hoststats"
+	hvm "github.com/hashicorp/nomad/client/hostvolumemanager"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/lib/numalib"
@@ -116,6 +117,10 @@ const (
 	// allocSyncRetryIntv is the interval on which we retry updating
 	// the status of the allocation
 	allocSyncRetryIntv = 5 * time.Second
+
+	// utilizationWindow is the window of host resource usage samples the
+	// reported utilization percentiles are computed over.
+	utilizationWindow = 10 * time.Minute
 )
 
 var (
@@ -242,6 +247,18 @@ type Client struct {
 	// HostStatsCollector collects host resource usage stats
 	hostStatsCollector *hoststats.HostStatsCollector
 
+	// utilizationTracker keeps a rolling window of host resource usage that
+	// is reported to the servers for utilization-aware scheduling
+	utilizationTracker *hoststats.UtilizationTracker
+
+	// taskUsageTracker collects the resource usage of running tasks that is
+	// reported to the servers to recommend task resources
+	taskUsageTracker *taskUsageTracker
+
+	// lastUtilizationReport is the time the observed utilization was last
+	// reported to the servers. Only accessed by the heartbeat loop.
+	lastUtilizationReport time.Time
+
 	// shutdown is true when the Client has been shutdown. Must hold
 	// shutdownLock to access.
 	shutdown bool
@@ -283,6 +300,9 @@ type Client struct {
 	// csimanager is responsible for managing csi plugins.
 	csimanager csimanager.Manager
 
+	// hostVolumeManager is responsible for managing dynamic host volumes.
+	hostVolumeManager *hvm.HostVolumeManager
+
 	// devicemanger is responsible for managing device plugins.
 	devicemanager devicemanager.Manager
 
@@ -504,6 +524,18 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	c.csimanager = csiManager
 	c.pluginManagers.RegisterAndRun(csiManager.PluginManager())
 
+	// Setup the host volume manager and restore the dynamic host volumes
+	// before the node registers, so they can be placed on right away.
+	c.hostVolumeManager = hvm.NewHostVolumeManager(logger, &hvm.Config{
+		PluginDir:      c.GetConfig().HostVolumePluginDir,
+		SharedMountDir: c.GetConfig().HostVolumesDir,
+		StateMgr:       c.stateDB,
+		UpdateNodeVols: c.updateNodeFromHostVolume,
+	})
+	if err := c.setupHostVolumes(); err != nil {
+		return nil, err
+	}
+
 	// Setup the driver manager
 	driverConfig := &drivermanager.Config{
 		Logger:              c.logger,
@@ -553,6 +585,8 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	// Add the stats collector
 	statsCollector := hoststats.NewHostStatsCollector(c.logger, c.topology, c.GetConfig().AllocDir, c.devicemanager.AllStats)
 	c.hostStatsCollector = statsCollector
+	c.utilizationTracker = hoststats.NewUtilizationTracker(utilizationWindow)
+	c.taskUsageTracker = newTaskUsageTracker()
 
 	// Add the garbage collector
 	gcConfig := &GCConfig{
@@ -629,6 +663,9 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	// Start collecting stats
 	c.shutdownGroup.Go(c.emitStats)
 
+	// Begin reporting task usage to the servers
+	c.shutdownGroup.Go(c.reportTaskUsage)
+
 	c.logger.Info("started client", "node_id", c.NodeID())
 	return c, nil
 }
@@ -721,6 +758,17 @@ func (c *Client) init() error {
 
 	c.logger.Info("using alloc directory", "alloc_dir", conf.AllocDir)
 
+	// Ensure the host volumes dir exists, defaulting to a directory in the
+	// state dir if we aren't configured with a custom path.
+	if conf.HostVolumesDir == "" {
+		conf = c.UpdateConfig(func(c *config.Config) {
+			c.HostVolumesDir = filepath.Join(c.StateDir, "host_volumes")
+		})
+	}
+	if err := os.MkdirAll(conf.HostVolumesDir, 0o711); err != nil {
+		return fmt.Errorf("failed creating host volumes dir: %w", err)
+	}
+
 	reserved := "<none>"
 	if conf.Node != nil && conf.Node.ReservedResources != nil {
 		// Node should always be non-nil due to initialization in the
@@ -1490,6 +1538,31 @@ func ensureNodeID(conf *config.Config) (id, secret string, err error) {
 	return id, secret, nil
 }
 
+// setupHostVolumes fingerprints the host volume plugins and adds the dynamic
+// host volumes restored from the client state to the node.
+func (c *Client) setupHostVolumes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), HostVolumePluginRequestTimeout)
+	defer cancel()
+	attrs := c.hostVolumeManager.Fingerprint(ctx)
+
+	vols, err := c.hostVolumeManager.Restore()
+	if err != nil {
+		return err
+	}
+
+	c.UpdateNode(func(node *structs.Node) {
+		maps.Copy(node.Attributes, attrs)
+		if len(vols) == 0 {
+			return
+		}
+		if node.HostVolumes == nil {
+			node.HostVolumes = make(map[string]*structs.ClientHostVolumeConfig, len(vols))
+		}
+		maps.Copy(node.HostVolumes, vols)
+	})
+	return nil
+}
+
 // setupNode is used to setup the initial node
 func (c *Client) setupNode() error {
 	c.configLock.Lock()
@@ -2095,6 +2168,7 @@ func (c *Client) updateNodeStatus() error {
 			Region:    c.Region(),
 			AuthToken: c.secretNodeID(),
 		},
+		Utilization: c.observedUtilization(start),
 	}
 	var resp structs.NodeUpdateResponse
 	if err := c.RPC("Node.UpdateStatus", &req, &resp); err != nil {
@@ -2103,6 +2177,10 @@ func (c *Client) updateNodeStatus() error {
 	}
 	end := time.Now()
 
+	if req.Utilization != nil {
+		c.lastUtilizationReport = start
+	}
+
 	if len(resp.EvalIDs) != 0 {
 		c.logger.Debug("evaluations triggered by node update", "num_evals", len(resp.EvalIDs))
 	}
@@ -2118,7 +2196,7 @@ func (c *Client) updateNodeStatus() error {
 	c.heartbeatLock.Unlock()
 	c.logger.Trace("next heartbeat", "period", resp.HeartbeatTTL)
 
-	if resp.Index != 0 {
+	if resp.Index != 0 && !resp.UtilizationOnly {
 		c.logger.Debug("state updated", "node_status", req.Status)
 
 		// We have potentially missed our TTL log how delayed we were
@@ -2152,6 +2230,27 @@ func (c *Client) updateNodeStatus() error {
 	return nil
 }
 
+// observedUtilization returns the observed utilization of the host to attach
+// to a heartbeat, or nil if reporting is disabled, there are no samples yet or
+// it was reported less than a report interval ago.
+func (c *Client) observedUtilization(now time.Time) *structs.NodeUtilization {
+	interval := c.GetConfig().UtilizationReportInterval
+	if interval <= 0 || now.Sub(c.lastUtilizationReport) < interval {
+		return nil
+	}
+
+	cpu, mem, samples := c.utilizationTracker.Percentile95()
+	if samples == 0 {
+		return nil
+	}
+
+	return &structs.NodeUtilization{
+		CPU:      int64(cpu),
+		MemoryMB: int64(mem / 1024 / 1024),
+		Samples:  samples,
+	}
+}
+
 func (c *Client) handleNodeUpdateResponse(resp structs.NodeUpdateResponse) error {
 	// Update the number of nodes in the cluster so we can adjust our server
 	// rebalance rate.
@@ -3213,9 +3312,17 @@ func (c *Client) emitStats() {
 			next.Reset(config.StatsCollectionInterval)
 			if err != nil {
 				c.logger.Warn("error fetching host resource usage stats", "error", err)
-			} else if config.PublishNodeMetrics {
+			} else {
+				c.utilizationTracker.Add(c.hostStatsCollector.Stats())
+
 				// Publish Node metrics if operator has opted in
-				c.emitHostStats()
+				if config.PublishNodeMetrics {
+					c.emitHostStats()
+				}
+			}
+
+			if config.TaskUsageReportInterval > 0 {
+				c.sampleTaskUsage()
 			}
 
 			c.emitClientMetrics()
@@ -107,6 +107,15 @@ type Config struct {
 	// should be owned  by root with file mode 0o755.
 	AllocMountsDir string
 
+	// HostVolumesDir is the directory under which the built-in mkdir plugin
+	// creates dynamic host volumes. External plugins are also told to create
+	// their volumes under this directory.
+	HostVolumesDir string
+
+	// HostVolumePluginDir is the directory searched for host volume plugin
+	// executables.
+	HostVolumePluginDir string
+
 	// Logger provides a logger to the client
 	Logger log.InterceptLogger
 
@@ -210,6 +219,16 @@ type Config struct {
 	// collection
 	GCInterval time.Duration
 
+	// UtilizationReportInterval is the interval at which the client reports
+	// its observed CPU and memory utilization to the servers along with a
+	// heartbeat. Reporting is disabled if zero.
+	UtilizationReportInterval time.Duration
+
+	// TaskUsageReportInterval is the interval at which the client reports
+	// the resource usage of its running tasks to the servers, which use it to
+	// recommend task resources. Reporting is disabled if zero.
+	TaskUsageReportInterval time.Duration
+
 	// GCParallelDestroys is the number of parallel destroys the garbage
 	// collector will allow.
 	GCParallelDestroys int
@@ -342,6 +361,9 @@ type Config struct {
 	// Uesrs configuration from the agent's config file.
 	Users *UsersConfig
 
+	// LogSinks restricts the sinks tasks may forward their logs to.
+	LogSinks *LogSinksConfig
+
 	// ExtraAllocHooks are run with other allocation hooks, mainly for testing.
 	ExtraAllocHooks []interfaces.RunnerHook
 }
@@ -838,6 +860,7 @@ func (c *Config) Copy() *Config {
 	nc.ReservableCores = slices.Clone(c.ReservableCores)
 	nc.Artifact = c.Artifact.Copy()
 	nc.Users = c.Users.Copy()
+	nc.LogSinks = c.LogSinks.Copy()
 	return &nc
 }
 
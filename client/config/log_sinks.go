@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"slices"
+
+	sconfig "github.com/hashicorp/nomad/nomad/structs/config"
+)
+
+// LogSinksConfig restricts the sinks tasks may forward their logs to.
+type LogSinksConfig struct {
+	// AllowedTypes are the sink types tasks may use.
+	AllowedTypes []string
+
+	// AllowedHosts are the host names and CIDR blocks sinks may connect to.
+	AllowedHosts []string
+
+	// AllowUnixSockets allows sinks to connect to unix sockets.
+	AllowUnixSockets bool
+}
+
+// LogSinksConfigFromAgent creates the internal read-only copy of the client
+// agent's LogSinksConfig. Without any configuration no sinks are allowed.
+func LogSinksConfigFromAgent(c *sconfig.LogSinksConfig) *LogSinksConfig {
+	if c == nil {
+		return &LogSinksConfig{}
+	}
+	return &LogSinksConfig{
+		AllowedTypes:     slices.Clone(c.AllowedTypes),
+		AllowedHosts:     slices.Clone(c.AllowedHosts),
+		AllowUnixSockets: c.AllowUnixSockets != nil && *c.AllowUnixSockets,
+	}
+}
+
+func (l *LogSinksConfig) Copy() *LogSinksConfig {
+	if l == nil {
+		return nil
+	}
+	return &LogSinksConfig{
+		AllowedTypes:     slices.Clone(l.AllowedTypes),
+		AllowedHosts:     slices.Clone(l.AllowedHosts),
+		AllowUnixSockets: l.AllowUnixSockets,
+	}
+}
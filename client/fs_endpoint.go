@@ -4,6 +4,7 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -12,6 +13,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -25,6 +28,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/client/allocdir"
 	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -70,6 +74,23 @@ const (
 	// and end of a file.
 	OriginStart = "start"
 	OriginEnd   = "end"
+
+	// defaultLogSearchLimit and maxLogSearchLimit are the default and maximum
+	// number of matches returned by a log search
+	defaultLogSearchLimit = 100
+	maxLogSearchLimit     = 1000
+
+	// maxLogSearchContext is the maximum number of lines returned around each
+	// match of a log search
+	maxLogSearchContext = 20
+
+	// maxLogSearchLineSize is the size at which a log line without a newline
+	// is split into several lines by a log search
+	maxLogSearchLineSize = 64 * 1024
+
+	// maxLogSearchBytes is the maximum amount of log content read by a log
+	// search
+	maxLogSearchBytes = 256 * 1024 * 1024
 )
 
 // FileSystem endpoint is used for accessing the logs and filesystem of
@@ -157,6 +178,84 @@ func (f *FileSystem) Stat(args *cstructs.FsStatRequest, reply *cstructs.FsStatRe
 	return nil
 }
 
+// LogsSearch is used to search the logs of a task for lines matching a regular
+// expression, including rotated log files.
+func (f *FileSystem) LogsSearch(args *cstructs.FsLogsSearchRequest, reply *cstructs.FsLogsSearchResponse) error {
+	defer metrics.MeasureSince([]string{"client", "file_system", "logs_search"}, time.Now())
+
+	alloc, err := f.c.GetAlloc(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check namespace read-logs *or* read-fs permissions.
+	aclObj, err := f.c.ResolveToken(args.QueryOptions.AuthToken)
+	if err != nil {
+		return err
+	}
+	readfs := aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadFS)
+	logs := aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadLogs)
+	if !readfs && !logs {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	if args.Task == "" {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, taskNotPresentErr.Error())
+	}
+	logTypes := []string{"stdout", "stderr"}
+	switch args.LogType {
+	case "stdout", "stderr":
+		logTypes = []string{args.LogType}
+	case "":
+	default:
+		return structs.NewErrRPCCoded(http.StatusBadRequest, logTypeNotPresentErr.Error())
+	}
+	re, err := regexp.Compile(args.Pattern)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid pattern: %v", err)
+	}
+	if args.Context < 0 || args.Context > maxLogSearchContext {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest,
+			"context must be between 0 and %d lines", maxLogSearchContext)
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultLogSearchLimit
+	} else if limit > maxLogSearchLimit {
+		limit = maxLogSearchLimit
+	}
+
+	allocState, err := f.c.GetAllocState(args.AllocID)
+	if err != nil {
+		return err
+	}
+	if allocState.TaskStates[args.Task] == nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "unknown task name %q", args.Task)
+	}
+
+	fs, err := f.c.GetAllocFS(args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	search := &logSearch{
+		fs:      fs,
+		re:      re,
+		since:   args.Since,
+		context: args.Context,
+		limit:   limit,
+		budget:  maxLogSearchBytes,
+		reply:   reply,
+	}
+	for _, logType := range logTypes {
+		if err := search.search(args.Task, logType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // stream is is used to stream the contents of file in an allocation's
 // directory.
 func (f *FileSystem) stream(conn io.ReadWriteCloser) {
@@ -569,6 +668,9 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 		return invalidOrigin
 	}
 
+	// sizes caches the decompressed size of compressed log files
+	sizes := make(map[string]int64)
+
 	for {
 		// Logic for picking next file is:
 		// 1) List log files
@@ -581,6 +683,13 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 			return fmt.Errorf("failed to list entries: %v", err)
 		}
 
+		// Offsets apply to the decompressed content of rotated log files
+		if offset != 0 {
+			if err := decompressedSizes(fs, logPath, entries, task, logType, sizes); err != nil {
+				return err
+			}
+		}
+
 		// If we are not following logs, determine the max index for the logs we are
 		// interested in so we can stop there.
 		maxIndex := int64(math.MaxInt64)
@@ -664,7 +773,7 @@ func (f *FileSystem) streamFile(ctx context.Context, offset int64, path string,
 	fs allocdir.AllocDirFS, framer *sframer.StreamFramer, eofCancelCh chan error, cancelAfterFirstEof bool) error {
 
 	// Get the reader
-	file, err := fs.ReadAt(path, offset)
+	file, err := readLogAt(fs, path, offset)
 	if err != nil {
 		return err
 	}
@@ -688,8 +797,9 @@ func (f *FileSystem) streamFile(ctx context.Context, offset int64, path string,
 	// read and reach EOF.
 	var changes *watch.FileChanges
 
-	// Only watch file when there is a need for it
-	cancelReceived := cancelAfterFirstEof
+	// Only watch file when there is a need for it. Compressed log files are
+	// never written to once rotated.
+	cancelReceived := cancelAfterFirstEof || isCompressedLog(path)
 
 	// Start streaming the data
 	bufSize := int64(streamFrameSize)
@@ -878,6 +988,7 @@ func (a indexTupleArray) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 // error is returned.
 func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) (indexTupleArray, error) {
 	var indexes []indexTuple
+	seen := make(map[int]int)
 	prefix := fmt.Sprintf("%s.%s.", task, logType)
 	for _, entry := range entries {
 		if entry.IsDir {
@@ -891,17 +1002,276 @@ func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) (indexT
 		}
 
 		// Convert to an int
+		idxStr, compressed := logging.TrimCompressedExt(idxStr)
 		idx, err := strconv.Atoi(idxStr)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert %q to a log index: %v", idxStr, err)
 		}
 
+		// A log file is briefly present both uncompressed and compressed
+		// while it's being compressed
+		if i, ok := seen[idx]; ok {
+			if !compressed {
+				indexes[i].entry = entry
+			}
+			continue
+		}
+		seen[idx] = len(indexes)
+
 		indexes = append(indexes, indexTuple{idx: int64(idx), entry: entry})
 	}
 
 	return indexTupleArray(indexes), nil
 }
 
+// logSearch collects the lines of a task's log files matching a regular
+// expression, up to a limit.
+type logSearch struct {
+	fs      allocdir.AllocDirFS
+	re      *regexp.Regexp
+	since   time.Time
+	context int
+	limit   int
+	reply   *cstructs.FsLogsSearchResponse
+
+	// budget is the number of bytes left to read before the search stops
+	budget int64
+}
+
+// search searches the log files of the given type, oldest first.
+func (s *logSearch) search(task, logType string) error {
+	logPath := filepath.Join(allocdir.SharedAllocName, allocdir.LogDirName)
+	entries, err := s.fs.List(logPath)
+	if err != nil {
+		return fmt.Errorf("failed to list entries: %v", err)
+	}
+	indexes, err := logIndexes(entries, task, logType)
+	if err != nil {
+		return err
+	}
+	sort.Sort(indexes)
+
+	for _, index := range indexes {
+		if s.reply.Truncated {
+			return nil
+		}
+		if !s.since.IsZero() && index.entry.ModTime.Before(s.since) {
+			continue
+		}
+
+		p := filepath.Join(logPath, index.entry.Name)
+		if err := s.searchFile(p, logType); err != nil {
+			// The file may have been rotated out since listed
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("failed to search %q: %v", p, err)
+		}
+	}
+	return nil
+}
+
+// searchFile appends the matching lines of the file to the reply.
+func (s *logSearch) searchFile(path, logType string) error {
+	file, err := readLogAt(s.fs, path, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// before holds the last lines read, and pending the matches still missing
+	// lines after them
+	var before []string
+	var pending []*cstructs.LogSearchMatch
+	var offset int64
+
+	// Lines are read up to maxLogSearchLineSize, and the size of each line
+	// including its newline is recorded to compute offsets
+	var lineSize int
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 4096), maxLogSearchLineSize+1)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := scanLogLine(data, atEOF)
+		lineSize = advance
+		return advance, token, err
+	})
+
+	for scanner.Scan() {
+		if s.budget <= 0 {
+			s.reply.Truncated = true
+			return nil
+		}
+		s.budget -= int64(lineSize)
+
+		lineOffset := offset
+		offset += int64(lineSize)
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+
+		for len(pending) > 0 {
+			m := pending[0]
+			m.After = append(m.After, line)
+			if len(m.After) < s.context {
+				break
+			}
+			pending = pending[1:]
+		}
+		if s.reply.Truncated && len(pending) == 0 {
+			return nil
+		}
+
+		if !s.reply.Truncated && s.re.MatchString(line) {
+			if len(s.reply.Matches) == s.limit {
+				s.reply.Truncated = true
+			} else {
+				m := &cstructs.LogSearchMatch{
+					File:    filepath.Base(path),
+					LogType: logType,
+					Offset:  lineOffset,
+					Line:    line,
+					Before:  slices.Clone(before),
+				}
+				s.reply.Matches = append(s.reply.Matches, m)
+				if s.context > 0 {
+					pending = append(pending, m)
+				}
+			}
+		}
+
+		if s.context > 0 {
+			before = append(before, line)
+			if len(before) > s.context {
+				before = before[1:]
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// scanLogLine is a bufio.SplitFunc returning log lines without their
+// newline. Lines longer than maxLogSearchLineSize are split.
+func scanLogLine(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexByte(data, '\n'); i >= 0 && i <= maxLogSearchLineSize {
+		return i + 1, data[:i], nil
+	}
+	if len(data) > maxLogSearchLineSize {
+		return maxLogSearchLineSize, data[:maxLogSearchLineSize], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// isCompressedLog returns whether the path is a rotated log file that was
+// compressed.
+func isCompressedLog(path string) bool {
+	dir, name := filepath.Split(filepath.Clean(path))
+	logPath := filepath.Join(allocdir.SharedAllocName, allocdir.LogDirName)
+	if filepath.Clean(strings.TrimPrefix(dir, "/")) != logPath {
+		return false
+	}
+	name, compressed := logging.TrimCompressedExt(name)
+	if !compressed {
+		return false
+	}
+	_, err := strconv.Atoi(strings.TrimPrefix(filepath.Ext(name), "."))
+	return err == nil
+}
+
+// decompressedFile closes both the decompressing reader and the file
+type decompressedFile struct {
+	io.ReadCloser
+	file io.Closer
+}
+
+func (d *decompressedFile) Close() error {
+	d.ReadCloser.Close()
+	return d.file.Close()
+}
+
+// readLogAt returns a reader for a file at the given offset. Compressed log
+// files are decompressed, the offset applying to their decompressed content.
+func readLogAt(fs allocdir.AllocDirFS, path string, offset int64) (io.ReadCloser, error) {
+	if !isCompressedLog(path) {
+		return fs.ReadAt(path, offset)
+	}
+
+	file, err := fs.ReadAt(path, 0)
+	if err != nil {
+		return nil, err
+	}
+	r, err := logging.NewDecompressReader(path, file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	df := &decompressedFile{ReadCloser: r, file: file}
+	if _, err := io.CopyN(io.Discard, df, offset); err != nil && err != io.EOF {
+		df.Close()
+		return nil, err
+	}
+	return df, nil
+}
+
+// decompressedSizes replaces the size of the task's compressed log files with
+// the size of their decompressed content. Sizes are cached as compressed
+// files never change.
+func decompressedSizes(fs allocdir.AllocDirFS, logPath string, entries []*cstructs.AllocFileInfo,
+	task, logType string, cache map[string]int64) error {
+
+	prefix := fmt.Sprintf("%s.%s.", task, logType)
+	for _, entry := range entries {
+		p := filepath.Join(logPath, entry.Name)
+		if entry.IsDir || !strings.HasPrefix(entry.Name, prefix) || !isCompressedLog(p) {
+			continue
+		}
+
+		size, ok := cache[entry.Name]
+		if !ok {
+			var err error
+			size, err = decompressedSize(fs, p)
+			if os.IsNotExist(err) {
+				// Rotated out since listed
+				continue
+			} else if err != nil {
+				return err
+			}
+			cache[entry.Name] = size
+		}
+		entry.Size = size
+	}
+	return nil
+}
+
+// decompressedSize returns the decompressed size of a compressed log file.
+// The size is read from the file's header, only decompressing files too
+// small to record it.
+func decompressedSize(fs allocdir.AllocDirFS, path string) (int64, error) {
+	file, err := fs.ReadAt(path, 0)
+	if err != nil {
+		return 0, err
+	}
+	size, ok, err := logging.DecompressedSize(path, file)
+	file.Close()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read header of %q: %v", path, err)
+	}
+	if ok {
+		return size, nil
+	}
+
+	r, err := readLogAt(fs, path, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	size, err = io.Copy(io.Discard, r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decompress %q: %v", path, err)
+	}
+	return size, nil
+}
+
 // notFoundErr is returned when a log is requested but cannot be found.
 // Implements agent.HTTPCodedError but does not reference it to avoid circular
 // imports.
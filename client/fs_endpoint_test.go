@@ -4,6 +4,8 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -13,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"runtime"
 	"strings"
 	"sync"
@@ -32,6 +35,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/klauspost/compress/zstd"
 	"github.com/shoenig/test/must"
 	"github.com/stretchr/testify/require"
 )
@@ -2186,3 +2190,170 @@ func TestFS_logsImpl_Follow(t *testing.T) {
 		t.Fatalf("did not receive data: got %q", string(received))
 	}
 }
+
+func TestFS_logsImpl_Compressed(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	// Get a temp alloc dir and create the log dir
+	ad := tempAllocDir(t)
+	must.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	must.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// Rotated files are compressed while the current one isn't
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	_, err := gw.Write([]byte("01"))
+	must.NoError(t, err)
+	must.NoError(t, gw.Close())
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.0.gz"), gz.Bytes(), 0777))
+
+	enc, err := zstd.NewWriter(nil)
+	must.NoError(t, err)
+	zst := enc.EncodeAll([]byte("23"), nil)
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.1.zst"), zst, 0777))
+
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.2"), []byte("45"), 0777))
+
+	cases := []struct {
+		origin   string
+		offset   int64
+		expected string
+	}{
+		{origin: OriginStart, offset: 0, expected: "012345"},
+		{origin: OriginStart, offset: 3, expected: "345"},
+		{origin: OriginEnd, offset: 5, expected: "12345"},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s_%d", tc.origin, tc.offset), func(t *testing.T) {
+			frames := make(chan *sframer.StreamFrame, 32)
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			must.NoError(t, c.endpoints.FileSystem.logsImpl(
+				ctx, false, false, tc.offset,
+				tc.origin, "foo", "stdout", ad, frames))
+
+			var received []byte
+			testutil.WaitForResult(func() (bool, error) {
+				for {
+					select {
+					case frame, ok := <-frames:
+						if ok {
+							received = append(received, frame.Data...)
+							continue
+						}
+					default:
+					}
+					return string(received) == tc.expected,
+						fmt.Errorf("expected %q, got %q", tc.expected, received)
+				}
+			}, func(err error) {
+				must.NoError(t, err)
+			})
+		})
+	}
+}
+
+func TestFS_logSearch(t *testing.T) {
+	ci.Parallel(t)
+
+	// Get a temp alloc dir and create the log dir
+	ad := tempAllocDir(t)
+	must.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	must.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// The rotated file is compressed while the current one isn't
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	_, err := gw.Write([]byte("a\nerror one\nb\n"))
+	must.NoError(t, err)
+	must.NoError(t, gw.Close())
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.0.gz"), gz.Bytes(), 0777))
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.1"), []byte("c\r\nerror two\nerror three"), 0777))
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stderr.0"), []byte("error four\n"), 0777))
+
+	search := func(logType string, context, limit int) *cstructs.FsLogsSearchResponse {
+		var reply cstructs.FsLogsSearchResponse
+		s := &logSearch{
+			fs:      ad,
+			re:      regexp.MustCompile(`^error`),
+			context: context,
+			limit:   limit,
+			reply:   &reply,
+		}
+		must.NoError(t, s.search("foo", logType))
+		return &reply
+	}
+
+	reply := search("stdout", 1, 10)
+	must.False(t, reply.Truncated)
+	must.Eq(t, []*cstructs.LogSearchMatch{
+		{File: "foo.stdout.0.gz", LogType: "stdout", Offset: 2, Line: "error one", Before: []string{"a"}, After: []string{"b"}},
+		{File: "foo.stdout.1", LogType: "stdout", Offset: 3, Line: "error two", Before: []string{"c"}, After: []string{"error three"}},
+		{File: "foo.stdout.1", LogType: "stdout", Offset: 13, Line: "error three", Before: []string{"error two"}},
+	}, reply.Matches)
+
+	// Searches stop at the limit
+	reply = search("stdout", 0, 2)
+	must.True(t, reply.Truncated)
+	must.Len(t, 2, reply.Matches)
+	must.Eq(t, "error two", reply.Matches[1].Line)
+	must.Nil(t, reply.Matches[1].After)
+
+	reply = search("stderr", 0, 10)
+	must.Len(t, 1, reply.Matches)
+	must.Eq(t, "foo.stderr.0", reply.Matches[0].File)
+}
+
+func TestFS_logSearch_Bounded(t *testing.T) {
+	ci.Parallel(t)
+
+	// Get a temp alloc dir and create the log dir
+	ad := tempAllocDir(t)
+	must.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	must.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// A line longer than maxLogSearchLineSize is searched in parts
+	long := strings.Repeat("x", maxLogSearchLineSize) + "error long"
+	content := "error one\n" + long + "\nerror two\n"
+	must.NoError(t, os.WriteFile(filepath.Join(logDir, "foo.stdout.0"), []byte(content), 0777))
+
+	search := func(budget int64) *cstructs.FsLogsSearchResponse {
+		var reply cstructs.FsLogsSearchResponse
+		s := &logSearch{
+			fs:     ad,
+			re:     regexp.MustCompile(`^error`),
+			limit:  10,
+			budget: budget,
+			reply:  &reply,
+		}
+		must.NoError(t, s.search("foo", "stdout"))
+		return &reply
+	}
+
+	reply := search(maxLogSearchBytes)
+	must.False(t, reply.Truncated)
+	must.Len(t, 3, reply.Matches)
+	must.Eq(t, "error one", reply.Matches[0].Line)
+	must.Eq(t, "error long", reply.Matches[1].Line)
+	must.Eq(t, int64(10+maxLogSearchLineSize), reply.Matches[1].Offset)
+	must.Eq(t, "error two", reply.Matches[2].Line)
+	must.Eq(t, int64(len(content)-10), reply.Matches[2].Offset)
+
+	// Searches stop once the budget is spent
+	reply = search(10)
+	must.True(t, reply.Truncated)
+	must.Len(t, 1, reply.Matches)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hoststats
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// utilizationSample is the CPU and memory usage of the host at a point in
+// time.
+type utilizationSample struct {
+	timestamp time.Time
+	cpuMHz    float64
+	memBytes  uint64
+}
+
+// UtilizationTracker keeps a rolling window of host CPU and memory usage
+// samples so the client can report its observed utilization to the servers.
+type UtilizationTracker struct {
+	window  time.Duration
+	samples []utilizationSample
+	l       sync.Mutex
+}
+
+// NewUtilizationTracker returns a tracker that keeps samples for the given
+// window.
+func NewUtilizationTracker(window time.Duration) *UtilizationTracker {
+	return &UtilizationTracker{window: window}
+}
+
+// Add records a sample from the host stats and drops samples that have
+// fallen out of the window.
+func (u *UtilizationTracker) Add(hs *HostStats) {
+	if hs == nil || hs.Memory == nil {
+		return
+	}
+
+	now := time.Unix(0, hs.Timestamp)
+	if hs.Timestamp == 0 {
+		now = time.Now()
+	}
+
+	u.l.Lock()
+	defer u.l.Unlock()
+
+	u.samples = append(u.samples, utilizationSample{
+		timestamp: now,
+		cpuMHz:    hs.CPUTicksConsumed,
+		memBytes:  hs.Memory.Used,
+	})
+
+	cutoff := now.Add(-u.window)
+	i := 0
+	for i < len(u.samples) && u.samples[i].timestamp.Before(cutoff) {
+		i++
+	}
+	u.samples = u.samples[i:]
+}
+
+// Percentile95 returns the 95th percentile of the CPU usage in MHz and the
+// memory usage in bytes over the window, along with the number of samples
+// they were computed from.
+func (u *UtilizationTracker) Percentile95() (cpuMHz float64, memBytes uint64, samples int) {
+	u.l.Lock()
+	defer u.l.Unlock()
+
+	samples = len(u.samples)
+	if samples == 0 {
+		return 0, 0, 0
+	}
+
+	cpus := make([]float64, samples)
+	mems := make([]uint64, samples)
+	for i, s := range u.samples {
+		cpus[i] = s.cpuMHz
+		mems[i] = s.memBytes
+	}
+	slices.Sort(cpus)
+	slices.Sort(mems)
+
+	// Nearest-rank percentile
+	rank := int(math.Ceil(0.95*float64(samples))) - 1
+	return cpus[rank], mems[rank], samples
+}
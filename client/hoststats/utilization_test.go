@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hoststats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestUtilizationTracker_Percentile95(t *testing.T) {
+	ci.Parallel(t)
+
+	tracker := NewUtilizationTracker(time.Hour)
+
+	_, _, samples := tracker.Percentile95()
+	must.Zero(t, samples)
+
+	start := time.Now()
+	for i := 1; i <= 100; i++ {
+		tracker.Add(&HostStats{
+			Timestamp:        start.Add(time.Duration(i) * time.Second).UnixNano(),
+			CPUTicksConsumed: float64(i * 10),
+			Memory:           &MemoryStats{Used: uint64(i) * 1024},
+		})
+	}
+
+	cpu, mem, samples := tracker.Percentile95()
+	must.Eq(t, 100, samples)
+	must.Eq(t, 950.0, cpu)
+	must.Eq(t, uint64(95*1024), mem)
+}
+
+func TestUtilizationTracker_Window(t *testing.T) {
+	ci.Parallel(t)
+
+	tracker := NewUtilizationTracker(time.Minute)
+
+	start := time.Now()
+	tracker.Add(&HostStats{
+		Timestamp:        start.UnixNano(),
+		CPUTicksConsumed: 4000,
+		Memory:           &MemoryStats{Used: 4096},
+	})
+	tracker.Add(&HostStats{
+		Timestamp:        start.Add(30 * time.Second).UnixNano(),
+		CPUTicksConsumed: 100,
+		Memory:           &MemoryStats{Used: 1024},
+	})
+
+	cpu, _, samples := tracker.Percentile95()
+	must.Eq(t, 2, samples)
+	must.Eq(t, 4000.0, cpu)
+
+	// The first sample falls out of the window
+	tracker.Add(&HostStats{
+		Timestamp:        start.Add(90 * time.Second).UnixNano(),
+		CPUTicksConsumed: 200,
+		Memory:           &MemoryStats{Used: 2048},
+	})
+
+	cpu, mem, samples := tracker.Percentile95()
+	must.Eq(t, 2, samples)
+	must.Eq(t, 200.0, cpu)
+	must.Eq(t, uint64(2048), mem)
+
+	// Stats without memory are ignored
+	tracker.Add(&HostStats{Timestamp: start.Add(91 * time.Second).UnixNano()})
+	_, _, samples = tracker.Percentile95()
+	must.Eq(t, 2, samples)
+}
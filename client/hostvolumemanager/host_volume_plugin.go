@@ -0,0 +1,284 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostvolumemanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-version"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// HostVolumePlugin provisions and removes dynamic host volumes on the client.
+type HostVolumePlugin interface {
+	Version(ctx context.Context) (*version.Version, error)
+	Create(ctx context.Context, req *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error)
+	Delete(ctx context.Context, req *cstructs.ClientHostVolumeDeleteRequest) error
+}
+
+// HostVolumePluginCreateResponse is the result of a plugin creating a volume.
+type HostVolumePluginCreateResponse struct {
+	Path  string `json:"path"`
+	Bytes int64  `json:"bytes"`
+}
+
+// HostVolumePluginFingerprintResponse is the result of fingerprinting an
+// external plugin.
+type HostVolumePluginFingerprintResponse struct {
+	Version string `json:"version"`
+}
+
+var _ HostVolumePlugin = &HostVolumePluginMkdir{}
+
+// HostVolumePluginMkdir is the built-in plugin. It creates a directory named
+// after the volume ID under the target path and does not enforce capacity.
+type HostVolumePluginMkdir struct {
+	ID         string
+	TargetPath string
+
+	log hclog.Logger
+}
+
+func (p *HostVolumePluginMkdir) Version(_ context.Context) (*version.Version, error) {
+	return version.NewVersion("0.0.1")
+}
+
+func (p *HostVolumePluginMkdir) Create(_ context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error) {
+
+	path := filepath.Join(p.TargetPath, req.ID)
+	log := p.log.With(
+		"operation", "create",
+		"volume_id", req.ID,
+		"path", path)
+	log.Debug("running plugin")
+
+	resp := &HostVolumePluginCreateResponse{
+		Path:  path,
+		Bytes: 0,
+	}
+
+	// the create request may be retried after a client restart, so an
+	// existing directory is not an error
+	if _, err := os.Stat(path); err == nil {
+		return resp, nil
+	}
+
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		log.Debug("error with plugin", "error", err)
+		return nil, err
+	}
+
+	log.Debug("plugin ran successfully")
+	return resp, nil
+}
+
+func (p *HostVolumePluginMkdir) Delete(_ context.Context, req *cstructs.ClientHostVolumeDeleteRequest) error {
+	// an empty ID would remove the whole volumes directory
+	if req.ID == "" {
+		return errors.New("missing volume ID")
+	}
+
+	path := filepath.Join(p.TargetPath, req.ID)
+	log := p.log.With(
+		"operation", "delete",
+		"volume_id", req.ID,
+		"path", path)
+	log.Debug("running plugin")
+
+	if err := os.RemoveAll(path); err != nil {
+		log.Debug("error with plugin", "error", err)
+		return err
+	}
+
+	log.Debug("plugin ran successfully")
+	return nil
+}
+
+var _ HostVolumePlugin = &HostVolumePluginExternal{}
+
+// NewHostVolumePluginExternal returns a plugin that runs the executable for
+// each operation. Volumes are created under the target path.
+func NewHostVolumePluginExternal(log hclog.Logger,
+	id, executable, targetPath string) (*HostVolumePluginExternal, error) {
+
+	// this should only be called with already-detected executables,
+	// but we'll double-check it.
+	f, err := os.Stat(executable)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %q", ErrPluginNotExists, id)
+		}
+		return nil, err
+	}
+	if !isExecutable(f) {
+		return nil, fmt.Errorf("%w: %q", ErrPluginNotExecutable, id)
+	}
+	return &HostVolumePluginExternal{
+		ID:         id,
+		Executable: executable,
+		TargetPath: targetPath,
+		log:        log,
+	}, nil
+}
+
+// HostVolumePluginExternal runs an executable for each plugin operation. The
+// operation is passed as the only argument, the volume is described by
+// DHV_* environment variables, and the plugin writes its result to stdout
+// as JSON.
+type HostVolumePluginExternal struct {
+	ID         string
+	Executable string
+	TargetPath string
+
+	log hclog.Logger
+}
+
+func (p *HostVolumePluginExternal) Version(ctx context.Context) (*version.Version, error) {
+	cmd := exec.CommandContext(ctx, p.Executable, "fingerprint")
+	cmd.Env = append(os.Environ(), "DHV_OPERATION=fingerprint")
+	stdout, stderr, err := runCommand(cmd)
+	if err != nil {
+		p.log.Debug("error with plugin",
+			"operation", "version",
+			"stdout", string(stdout),
+			"stderr", string(stderr),
+			"error", err)
+		return nil, fmt.Errorf("error getting version from plugin %q: %w", p.ID, err)
+	}
+
+	fprint := &HostVolumePluginFingerprintResponse{}
+	if err := json.Unmarshal(stdout, fprint); err != nil {
+		return nil, fmt.Errorf("error parsing fingerprint output from plugin %q: %w", p.ID, err)
+	}
+	v, err := version.NewVersion(fprint.Version)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing version %q from plugin %q: %w", fprint.Version, p.ID, err)
+	}
+	return v, nil
+}
+
+func (p *HostVolumePluginExternal) Create(ctx context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error) {
+
+	params, err := json.Marshal(req.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling volume parameters: %w", err)
+	}
+
+	envVars := []string{
+		"DHV_OPERATION=create",
+		"DHV_VOLUMES_DIR=" + p.TargetPath,
+		"DHV_VOLUME_ID=" + req.ID,
+		"DHV_VOLUME_NAME=" + req.Name,
+		"DHV_NODE_ID=" + req.NodeID,
+		"DHV_CAPACITY_MIN_BYTES=" + strconv.FormatInt(req.RequestedCapacityMinBytes, 10),
+		"DHV_CAPACITY_MAX_BYTES=" + strconv.FormatInt(req.RequestedCapacityMaxBytes, 10),
+		"DHV_PARAMETERS=" + string(params),
+	}
+
+	stdout, _, err := p.runPlugin(ctx, "create", req.ID, envVars)
+	if err != nil {
+		return nil, fmt.Errorf("error creating volume %q with plugin %q: %w", req.ID, p.ID, err)
+	}
+
+	resp := &HostVolumePluginCreateResponse{}
+	if err := json.Unmarshal(stdout, resp); err != nil {
+		// the plugin may have created the volume, so try to clean it up
+		// before returning the error
+		delReq := &cstructs.ClientHostVolumeDeleteRequest{
+			ID:         req.ID,
+			Name:       req.Name,
+			PluginID:   req.PluginID,
+			NodeID:     req.NodeID,
+			Parameters: req.Parameters,
+		}
+		if delErr := p.Delete(ctx, delReq); delErr != nil {
+			p.log.Warn("error deleting volume after failed create",
+				"volume_id", req.ID, "error", delErr)
+		}
+		return nil, fmt.Errorf("error parsing create output from plugin %q: %w", p.ID, err)
+	}
+	return resp, nil
+}
+
+func (p *HostVolumePluginExternal) Delete(ctx context.Context,
+	req *cstructs.ClientHostVolumeDeleteRequest) error {
+
+	params, err := json.Marshal(req.Parameters)
+	if err != nil {
+		return fmt.Errorf("error marshaling volume parameters: %w", err)
+	}
+
+	envVars := []string{
+		"DHV_OPERATION=delete",
+		"DHV_VOLUMES_DIR=" + p.TargetPath,
+		"DHV_VOLUME_ID=" + req.ID,
+		"DHV_VOLUME_NAME=" + req.Name,
+		"DHV_NODE_ID=" + req.NodeID,
+		"DHV_CREATED_PATH=" + req.HostPath,
+		"DHV_PARAMETERS=" + string(params),
+	}
+
+	if _, _, err := p.runPlugin(ctx, "delete", req.ID, envVars); err != nil {
+		return fmt.Errorf("error deleting volume %q with plugin %q: %w", req.ID, p.ID, err)
+	}
+	return nil
+}
+
+func (p *HostVolumePluginExternal) runPlugin(ctx context.Context,
+	op, volID string, env []string) (stdout, stderr []byte, err error) {
+
+	log := p.log.With(
+		"operation", op,
+		"volume_id", volID)
+	log.Debug("running plugin")
+
+	cmd := exec.CommandContext(ctx, p.Executable, op)
+	cmd.Env = append(os.Environ(), env...)
+
+	stdout, stderr, err = runCommand(cmd)
+
+	log = log.With(
+		"stdout", string(stdout),
+		"stderr", string(stderr),
+	)
+	if err != nil {
+		log.Debug("error with plugin", "error", err)
+		return stdout, stderr, err
+	}
+	log.Debug("plugin ran successfully")
+	return stdout, stderr, nil
+}
+
+// runCommand runs the command and returns its stdout and stderr. If the
+// command fails, its stderr is included in the error.
+func runCommand(cmd *exec.Cmd) (stdout, stderr []byte, err error) {
+	var errBuf bytes.Buffer
+	cmd.Stderr = &errBuf
+	stdout, err = cmd.Output()
+	stderr = errBuf.Bytes()
+	if err != nil {
+		if msg := strings.TrimSpace(string(stderr)); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+	}
+	return stdout, stderr, err
+}
+
+// isExecutable returns true if the file is a regular file that is executable
+// by someone.
+func isExecutable(f os.FileInfo) bool {
+	return f.Mode().IsRegular() && f.Mode()&0o111 != 0
+}
@@ -0,0 +1,261 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// hostvolumemanager manages the dynamic host volumes of a client. It runs the
+// host volume plugins that provision and remove volumes, persists the volumes
+// in the client state, and keeps the node's host volumes up to date.
+package hostvolumemanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var (
+	ErrPluginNotExists     = errors.New("no such plugin")
+	ErrPluginNotExecutable = errors.New("plugin not executable")
+)
+
+// HostVolumeStateManager persists the dynamic host volumes of the client.
+type HostVolumeStateManager interface {
+	PutDynamicHostVolume(*cstructs.HostVolumeState) error
+	GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error)
+	DeleteDynamicHostVolume(string) error
+}
+
+// HostVolumeNodeUpdater updates the node's host volume with the given name.
+// A nil volume removes it from the node.
+type HostVolumeNodeUpdater func(name string, volume *structs.ClientHostVolumeConfig)
+
+// Config is used to configure the host volume manager.
+type Config struct {
+	// PluginDir is the directory searched for external plugins.
+	PluginDir string
+
+	// SharedMountDir is the directory under which plugins create volumes.
+	SharedMountDir string
+
+	// StateMgr persists the volumes so they can be restored.
+	StateMgr HostVolumeStateManager
+
+	// UpdateNodeVols is called when a volume is added to or removed from
+	// the node.
+	UpdateNodeVols HostVolumeNodeUpdater
+}
+
+// HostVolumeManager creates and deletes the dynamic host volumes of a client.
+type HostVolumeManager struct {
+	pluginDir      string
+	sharedMountDir string
+	stateMgr       HostVolumeStateManager
+	updateNodeVols HostVolumeNodeUpdater
+	log            hclog.Logger
+
+	// volumesLock serializes operations on volumes of the same name, so that
+	// the node update and the client state stay consistent
+	volumesLock sync.Mutex
+}
+
+// NewHostVolumeManager returns a new host volume manager.
+func NewHostVolumeManager(logger hclog.Logger, config *Config) *HostVolumeManager {
+	return &HostVolumeManager{
+		pluginDir:      config.PluginDir,
+		sharedMountDir: config.SharedMountDir,
+		stateMgr:       config.StateMgr,
+		updateNodeVols: config.UpdateNodeVols,
+		log:            logger.Named("host_volume_manager"),
+	}
+}
+
+// Fingerprint returns the node attributes for the available plugins. Plugins
+// that can't be fingerprinted are logged and skipped.
+func (hvm *HostVolumeManager) Fingerprint(ctx context.Context) map[string]string {
+	attrs := map[string]string{}
+
+	mkdir := hvm.mkdirPlugin()
+	if v, err := mkdir.Version(ctx); err == nil {
+		attrs[fmt.Sprintf(structs.HostVolumePluginVersionAttrFormat, mkdir.ID)] = v.String()
+	}
+
+	if hvm.pluginDir == "" {
+		return attrs
+	}
+
+	entries, err := os.ReadDir(hvm.pluginDir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			hvm.log.Warn("error reading host volume plugin directory",
+				"dir", hvm.pluginDir, "error", err)
+		}
+		return attrs
+	}
+
+	for _, entry := range entries {
+		id := entry.Name()
+		if entry.IsDir() || id == structs.HostVolumePluginMkdirID {
+			continue
+		}
+		plug, err := NewHostVolumePluginExternal(hvm.log.With("plugin_id", id),
+			id, filepath.Join(hvm.pluginDir, id), hvm.sharedMountDir)
+		if err != nil {
+			// non-executable files in the plugin directory are ignored
+			continue
+		}
+		v, err := plug.Version(ctx)
+		if err != nil {
+			hvm.log.Warn("error fingerprinting host volume plugin",
+				"plugin_id", id, "error", err)
+			continue
+		}
+		attrs[fmt.Sprintf(structs.HostVolumePluginVersionAttrFormat, id)] = v.String()
+	}
+
+	return attrs
+}
+
+// Create provisions a volume with its plugin, persists it and adds it to the
+// node.
+func (hvm *HostVolumeManager) Create(ctx context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*cstructs.ClientHostVolumeCreateResponse, error) {
+
+	plug, err := hvm.getPlugin(req.PluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	hvm.volumesLock.Lock()
+	defer hvm.volumesLock.Unlock()
+
+	pluginResp, err := plug.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	volState := &cstructs.HostVolumeState{
+		ID:            req.ID,
+		CreateReq:     req,
+		HostPath:      pluginResp.Path,
+		CapacityBytes: pluginResp.Bytes,
+	}
+	if err := hvm.stateMgr.PutDynamicHostVolume(volState); err != nil {
+		// the volume can't be restored after a restart, so remove it
+		// rather than leaking it on the node
+		hvm.log.Error("failed to save volume in state, deleting it",
+			"volume_id", req.ID, "error", err)
+		delErr := plug.Delete(ctx, &cstructs.ClientHostVolumeDeleteRequest{
+			ID:         req.ID,
+			Name:       req.Name,
+			PluginID:   req.PluginID,
+			NodeID:     req.NodeID,
+			HostPath:   pluginResp.Path,
+			Parameters: req.Parameters,
+		})
+		if delErr != nil {
+			hvm.log.Warn("error deleting volume after state store failure",
+				"volume_id", req.ID, "error", delErr)
+		}
+		return nil, fmt.Errorf("failed to save volume in state: %w", err)
+	}
+
+	hvm.updateNodeVols(req.Name, genVolConfig(req, pluginResp.Path))
+
+	return &cstructs.ClientHostVolumeCreateResponse{
+		HostPath:      pluginResp.Path,
+		CapacityBytes: pluginResp.Bytes,
+	}, nil
+}
+
+// Delete removes a volume with its plugin, removes it from the node and from
+// the client state.
+func (hvm *HostVolumeManager) Delete(ctx context.Context,
+	req *cstructs.ClientHostVolumeDeleteRequest) (*cstructs.ClientHostVolumeDeleteResponse, error) {
+
+	plug, err := hvm.getPlugin(req.PluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	hvm.volumesLock.Lock()
+	defer hvm.volumesLock.Unlock()
+
+	if err := plug.Delete(ctx, req); err != nil {
+		return nil, err
+	}
+
+	hvm.updateNodeVols(req.Name, nil)
+
+	if err := hvm.stateMgr.DeleteDynamicHostVolume(req.ID); err != nil {
+		hvm.log.Error("failed to delete volume in state", "volume_id", req.ID, "error", err)
+		return nil, fmt.Errorf("failed to delete volume in state: %w", err)
+	}
+
+	return &cstructs.ClientHostVolumeDeleteResponse{}, nil
+}
+
+// Restore returns the volumes persisted in the client state, keyed by name,
+// so they can be added to the node before it registers.
+func (hvm *HostVolumeManager) Restore() (map[string]*structs.ClientHostVolumeConfig, error) {
+	vols, err := hvm.stateMgr.GetDynamicHostVolumes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore host volumes: %w", err)
+	}
+
+	volumes := make(map[string]*structs.ClientHostVolumeConfig, len(vols))
+	for _, vol := range vols {
+		if vol.CreateReq == nil {
+			hvm.log.Warn("skipping host volume with missing create request in state",
+				"volume_id", vol.ID)
+			continue
+		}
+		if _, err := os.Stat(vol.HostPath); err != nil {
+			hvm.log.Warn("host volume path is missing",
+				"volume_id", vol.ID, "path", vol.HostPath, "error", err)
+		}
+		volumes[vol.CreateReq.Name] = genVolConfig(vol.CreateReq, vol.HostPath)
+	}
+	return volumes, nil
+}
+
+func (hvm *HostVolumeManager) getPlugin(id string) (HostVolumePlugin, error) {
+	if id == structs.HostVolumePluginMkdirID {
+		return hvm.mkdirPlugin(), nil
+	}
+	if hvm.pluginDir == "" {
+		return nil, fmt.Errorf("%w: %q", ErrPluginNotExists, id)
+	}
+
+	// plugin IDs are file names in the plugin directory
+	if id == "" || filepath.Base(id) != id {
+		return nil, fmt.Errorf("%w: %q", ErrPluginNotExists, id)
+	}
+
+	log := hvm.log.With("plugin_id", id)
+	return NewHostVolumePluginExternal(log, id,
+		filepath.Join(hvm.pluginDir, id), hvm.sharedMountDir)
+}
+
+func (hvm *HostVolumeManager) mkdirPlugin() *HostVolumePluginMkdir {
+	return &HostVolumePluginMkdir{
+		ID:         structs.HostVolumePluginMkdirID,
+		TargetPath: hvm.sharedMountDir,
+		log:        hvm.log.With("plugin_id", structs.HostVolumePluginMkdirID),
+	}
+}
+
+// genVolConfig returns the node host volume for a dynamic volume.
+func genVolConfig(req *cstructs.ClientHostVolumeCreateRequest, hostPath string) *structs.ClientHostVolumeConfig {
+	return &structs.ClientHostVolumeConfig{
+		Name:     req.Name,
+		ID:       req.ID,
+		Path:     hostPath,
+		ReadOnly: false,
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostvolumemanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHostVolumeManager_Mkdir(t *testing.T) {
+	ci.Parallel(t)
+	logger := testlog.HCLogger(t)
+	volumesDir := t.TempDir()
+	db := state.NewMemDB(logger)
+
+	nodeVols := map[string]*structs.ClientHostVolumeConfig{}
+	hvm := NewHostVolumeManager(logger, &Config{
+		SharedMountDir: volumesDir,
+		StateMgr:       db,
+		UpdateNodeVols: func(name string, vol *structs.ClientHostVolumeConfig) {
+			if vol == nil {
+				delete(nodeVols, name)
+				return
+			}
+			nodeVols[name] = vol
+		},
+	})
+
+	attrs := hvm.Fingerprint(context.Background())
+	must.Eq(t, "0.0.1", attrs[fmt.Sprintf(structs.HostVolumePluginVersionAttrFormat, "mkdir")])
+
+	createReq := &cstructs.ClientHostVolumeCreateRequest{
+		ID:       "vol-id",
+		Name:     "vol-name",
+		PluginID: structs.HostVolumePluginMkdirID,
+		NodeID:   "node-id",
+	}
+	resp, err := hvm.Create(context.Background(), createReq)
+	must.NoError(t, err)
+	must.Eq(t, filepath.Join(volumesDir, "vol-id"), resp.HostPath)
+	must.DirExists(t, resp.HostPath)
+	must.MapContainsKey(t, nodeVols, "vol-name")
+	must.Eq(t, "vol-id", nodeVols["vol-name"].ID)
+
+	// A restarted client restores the volume from its state
+	restored, err := hvm.Restore()
+	must.NoError(t, err)
+	must.MapLen(t, 1, restored)
+	must.Eq(t, resp.HostPath, restored["vol-name"].Path)
+
+	// Unknown plugins are rejected
+	createReq.PluginID = "no-such-plugin"
+	_, err = hvm.Create(context.Background(), createReq)
+	must.ErrorIs(t, err, ErrPluginNotExists)
+
+	_, err = hvm.Delete(context.Background(), &cstructs.ClientHostVolumeDeleteRequest{
+		ID:       "vol-id",
+		Name:     "vol-name",
+		PluginID: structs.HostVolumePluginMkdirID,
+		NodeID:   "node-id",
+		HostPath: resp.HostPath,
+	})
+	must.NoError(t, err)
+	_, err = os.Stat(resp.HostPath)
+	must.True(t, os.IsNotExist(err))
+	must.MapEmpty(t, nodeVols)
+
+	vols, err := db.GetDynamicHostVolumes()
+	must.NoError(t, err)
+	must.Len(t, 0, vols)
+}
+
+func TestHostVolumeManager_External(t *testing.T) {
+	ci.Parallel(t)
+	logger := testlog.HCLogger(t)
+	volumesDir := t.TempDir()
+	pluginDir := t.TempDir()
+
+	script := `#!/bin/sh
+case "$1" in
+  fingerprint) echo '{"version": "1.2.3"}' ;;
+  create) mkdir -p "$DHV_VOLUMES_DIR/$DHV_VOLUME_ID" && echo "{\"path\": \"$DHV_VOLUMES_DIR/$DHV_VOLUME_ID\", \"bytes\": $DHV_CAPACITY_MIN_BYTES}" ;;
+  delete) rm -rf "$DHV_CREATED_PATH" ;;
+  *) exit 1 ;;
+esac
+`
+	must.NoError(t, os.WriteFile(filepath.Join(pluginDir, "example"), []byte(script), 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(pluginDir, "not-executable"), []byte(script), 0o644))
+
+	hvm := NewHostVolumeManager(logger, &Config{
+		PluginDir:      pluginDir,
+		SharedMountDir: volumesDir,
+		StateMgr:       state.NewMemDB(logger),
+		UpdateNodeVols: func(string, *structs.ClientHostVolumeConfig) {},
+	})
+
+	attrs := hvm.Fingerprint(context.Background())
+	must.Eq(t, "1.2.3", attrs[fmt.Sprintf(structs.HostVolumePluginVersionAttrFormat, "example")])
+	must.MapNotContainsKey(t, attrs, fmt.Sprintf(structs.HostVolumePluginVersionAttrFormat, "not-executable"))
+
+	resp, err := hvm.Create(context.Background(), &cstructs.ClientHostVolumeCreateRequest{
+		ID:                        "vol-id",
+		Name:                      "vol-name",
+		PluginID:                  "example",
+		RequestedCapacityMinBytes: 1024,
+	})
+	must.NoError(t, err)
+	must.Eq(t, filepath.Join(volumesDir, "vol-id"), resp.HostPath)
+	must.Eq(t, 1024, resp.CapacityBytes)
+	must.DirExists(t, resp.HostPath)
+
+	_, err = hvm.Delete(context.Background(), &cstructs.ClientHostVolumeDeleteRequest{
+		ID:       "vol-id",
+		Name:     "vol-name",
+		PluginID: "example",
+		HostPath: resp.HostPath,
+	})
+	must.NoError(t, err)
+	_, err = os.Stat(resp.HostPath)
+	must.True(t, os.IsNotExist(err))
+
+	// Plugin IDs can't escape the plugin directory
+	_, err = hvm.Create(context.Background(), &cstructs.ClientHostVolumeCreateRequest{
+		ID:       "vol-id",
+		PluginID: "../example",
+	})
+	must.ErrorIs(t, err, ErrPluginNotExists)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// NodeImages endpoint is used to manage the images cached by the task drivers
+// of the client.
+type NodeImages struct {
+	c *Client
+}
+
+func newNodeImagesEndpoint(c *Client) *NodeImages {
+	return &NodeImages{c: c}
+}
+
+// Prefetch starts pulling the requested images with the task driver. The
+// images are pulled in the background and reported as node attributes once
+// cached.
+func (n *NodeImages) Prefetch(args *structs.NodeImagePrefetchRequest, reply *structs.NodeImagePrefetchResponse) error {
+	defer metrics.MeasureSince([]string{"client", "node_images", "prefetch"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := n.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	return n.prefetch(args, reply)
+}
+
+// Warm pulls the images of a job with the prefetch hint. It is only called by
+// the servers, on behalf of the job's submitter.
+func (n *NodeImages) Warm(args *structs.NodeImagePrefetchRequest, reply *structs.NodeImagePrefetchResponse) error {
+	defer metrics.MeasureSince([]string{"client", "node_images", "warm"}, time.Now())
+
+	return n.prefetch(args, reply)
+}
+
+func (n *NodeImages) prefetch(args *structs.NodeImagePrefetchRequest, reply *structs.NodeImagePrefetchResponse) error {
+	args.Canonicalize()
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+
+	plugin, err := n.c.drivermanager.Dispense(args.Driver)
+	if err != nil {
+		if err == drivermanager.ErrDriverNotFound {
+			return structs.NewErrRPCCoded(http.StatusBadRequest,
+				fmt.Sprintf("driver %q is not available on this node", args.Driver))
+		}
+		return err
+	}
+	driver, ok := plugin.(drivers.ImagePrefetchDriver)
+	if !ok {
+		return structs.NewErrRPCCoded(http.StatusBadRequest,
+			fmt.Sprintf("driver %q does not support prefetching images", args.Driver))
+	}
+
+	reply.Images = make([]string, 0, len(args.Images))
+	for _, image := range args.Images {
+		if err := driver.PrefetchImage(image); err != nil {
+			return fmt.Errorf("failed to prefetch image %q: %v", image, err)
+		}
+		n.c.logger.Debug("prefetching image", "driver", args.Driver, "image", image)
+		reply.Images = append(reply.Images, structs.NormalizeImageRef(image))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestNodeImages_Prefetch(t *testing.T) {
+	ci.Parallel(t)
+
+	s, _, cleanupS := nomad.TestACLServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, s.RPC)
+
+	c1, cleanup := TestClient(t, func(c *config.Config) {
+		c.ACLEnabled = true
+		c.Servers = []string{s.GetConfig().RPCAddr.String()}
+	})
+	defer cleanup()
+
+	req := &structs.NodeImagePrefetchRequest{
+		NodeID: c1.NodeID(),
+		Driver: "mock_driver",
+		Images: []string{"redis"},
+	}
+	var resp structs.NodeImagePrefetchResponse
+
+	// Prefetching images requires node write permissions
+	err := c1.ClientRPC("NodeImages.Prefetch", req, &resp)
+	must.ErrorContains(t, err, structs.ErrPermissionDenied.Error())
+
+	token := mock.CreatePolicyAndToken(t, s.State(), 1009, "images", mock.NodePolicy(acl.PolicyWrite))
+	req.AuthToken = token.SecretID
+
+	// The mock driver doesn't prefetch images
+	err = c1.ClientRPC("NodeImages.Prefetch", req, &resp)
+	must.Error(t, err)
+
+	req.Driver = "unknown"
+	err = c1.ClientRPC("NodeImages.Prefetch", req, &resp)
+	must.ErrorContains(t, err, `driver "unknown" is not available`)
+
+	req.Images = nil
+	err = c1.ClientRPC("NodeImages.Prefetch", req, &resp)
+	must.ErrorContains(t, err, "missing required Images")
+}
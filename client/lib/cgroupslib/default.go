@@ -5,6 +5,8 @@
 
 package cgroupslib
 
+import "errors"
+
 // LinuxResourcesPath does nothing on non-Linux systems
 func LinuxResourcesPath(string, string, bool) string {
 	return ""
@@ -14,3 +16,8 @@ func LinuxResourcesPath(string, string, bool) string {
 func MaybeDisableMemorySwappiness() *uint64 {
 	return nil
 }
+
+// BlockDevice returns an error on non-Linux systems
+func BlockDevice(string) (uint32, uint32, error) {
+	return 0, 0, errors.New("disk I/O limits are only supported on Linux")
+}
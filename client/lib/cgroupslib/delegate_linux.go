@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// agentLeaf is the cgroup within the delegated cgroup that an unprivileged
+// agent moves its own processes into, because cgroups v2 only allows enabling
+// controllers on cgroups without processes.
+const agentLeaf = "nomad-agent"
+
+// delegated is the cgroup delegated to an unprivileged agent, e.g. by the
+// Delegate= option of its systemd unit. It is empty when the agent runs as
+// root and manages the whole hierarchy.
+var delegated string
+
+// Rootless returns whether the Nomad client runs unprivileged, managing only
+// the cgroups v2 subtree delegated to it.
+func Rootless() bool {
+	return GetMode() == CG2 && delegated != ""
+}
+
+// delegatedCgroup returns the cgroup of the current process if it is owned
+// by the effective user. The processes of an unprivileged agent, including
+// its executors, live in the agent leaf so the delegated cgroup is its parent.
+func delegatedCgroup() (string, error) {
+	b, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return "", err
+	}
+	cgroup, err := parseCgroup2(string(b))
+	if err != nil {
+		return "", err
+	}
+	if filepath.Base(cgroup) == agentLeaf {
+		cgroup = filepath.Dir(cgroup)
+	}
+
+	euid := os.Geteuid()
+	for _, name := range []string{"", "cgroup.procs", "cgroup.subtree_control"} {
+		fi, err := os.Stat(filepath.Join(root, cgroup, name))
+		if err != nil {
+			return "", err
+		}
+		if st, ok := fi.Sys().(*syscall.Stat_t); !ok || int(st.Uid) != euid {
+			return "", fmt.Errorf("cgroup %s is not delegated to uid %d", cgroup, euid)
+		}
+	}
+	return cgroup, nil
+}
+
+// parseCgroup2 returns the cgroups v2 path from the content of a
+// /proc/<pid>/cgroup file.
+func parseCgroup2(content string) (string, error) {
+	for _, line := range strings.Split(content, "\n") {
+		if cgroup, ok := strings.CutPrefix(line, "0::"); ok {
+			return cgroup, nil
+		}
+	}
+	return "", errors.New("process is not in a cgroups v2 hierarchy")
+}
+
+// moveToAgentLeaf moves the processes of the delegated cgroup into the agent
+// leaf, so controllers can be enabled on the delegated cgroup.
+func moveToAgentLeaf() error {
+	if err := mkCG(delegated, agentLeaf); err != nil {
+		return err
+	}
+	b, err := os.ReadFile(filepathCG(delegated, "cgroup.procs"))
+	if err != nil {
+		return err
+	}
+	for _, pid := range strings.Fields(string(b)) {
+		// processes may exit while they are being moved
+		err := writeCG(pid, delegated, agentLeaf, "cgroup.procs")
+		if err != nil && !errors.Is(err, syscall.ESRCH) {
+			return err
+		}
+	}
+	return nil
+}
@@ -131,10 +131,18 @@ func Init(log hclog.Logger, cores string) error {
 		const subtreeFile = "cgroup.subtree_control"
 
 		//
-		// configuring root cgroup (/sys/fs/cgroup)
+		// configuring root cgroup (/sys/fs/cgroup), or the cgroup delegated
+		// to an unprivileged agent
 		//
 
-		if err := writeCG(activation, subtreeFile); err != nil {
+		if Rootless() {
+			if err := moveToAgentLeaf(); err != nil {
+				return fmt.Errorf("failed to move agent out of delegated cgroup: %w", err)
+			}
+			log.Debug("running unprivileged in delegated cgroup", "cgroup", delegated)
+		}
+
+		if err := writeCG(activation, delegated, subtreeFile); err != nil {
 			return fmt.Errorf("failed to create nomad cgroup: %w", err)
 		}
 
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// sysBlock is where the kernel exposes block devices by major:minor
+	sysBlock = "/sys/dev/block"
+)
+
+// BlockDevice returns the major and minor numbers of the disk backing the
+// filesystem of path. Partitions are resolved to their disk, because I/O
+// limits can only be set on whole disks.
+func BlockDevice(path string) (uint32, uint32, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(path, &st); err != nil {
+		return 0, 0, err
+	}
+	major, minor := unix.Major(uint64(st.Dev)), unix.Minor(uint64(st.Dev))
+
+	// filesystems such as tmpfs or overlay have no block device
+	dev, err := filepath.EvalSymlinks(filepath.Join(sysBlock, fmt.Sprintf("%d:%d", major, minor)))
+	if err != nil {
+		return 0, 0, fmt.Errorf("no block device backs %s", path)
+	}
+	if _, err := os.Stat(filepath.Join(dev, "partition")); err != nil {
+		return major, minor, nil
+	}
+
+	b, err := os.ReadFile(filepath.Join(filepath.Dir(dev), "dev"))
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseDevNumbers(string(b))
+}
+
+// parseDevNumbers parses the "major:minor" content of a device's dev file.
+func parseDevNumbers(s string) (uint32, uint32, error) {
+	ma, mi, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid device numbers %q", s)
+	}
+	major, err := strconv.ParseUint(ma, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid device numbers %q", s)
+	}
+	minor, err := strconv.ParseUint(mi, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid device numbers %q", s)
+	}
+	return uint32(major), uint32(minor), nil
+}
+
+// IOMax returns the io.max line limiting the bytes per second read from and
+// written to the disk. A limit of zero leaves the direction unlimited.
+func IOMax(major, minor uint32, rbps, wbps int64) string {
+	limit := func(n int64) string {
+		if n <= 0 {
+			return "max"
+		}
+		return strconv.FormatInt(n, 10)
+	}
+	return fmt.Sprintf("%d:%d rbps=%s wbps=%s", major, minor, limit(rbps), limit(wbps))
+}
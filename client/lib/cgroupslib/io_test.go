@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package cgroupslib
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func Test_parseDevNumbers(t *testing.T) {
+	major, minor, err := parseDevNumbers("259:0\n")
+	must.NoError(t, err)
+	must.Eq(t, 259, major)
+	must.Eq(t, 0, minor)
+
+	_, _, err = parseDevNumbers("sda")
+	must.ErrorContains(t, err, "invalid device numbers")
+
+	_, _, err = parseDevNumbers("8:x")
+	must.ErrorContains(t, err, "invalid device numbers")
+}
+
+func TestIOMax(t *testing.T) {
+	must.Eq(t, "8:0 rbps=1048576 wbps=max", IOMax(8, 0, 1048576, 0))
+	must.Eq(t, "259:0 rbps=max wbps=2048", IOMax(259, 0, 0, 2048))
+}
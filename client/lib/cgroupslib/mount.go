@@ -16,10 +16,6 @@ import (
 )
 
 func detect() Mode {
-	if os.Geteuid() > 0 {
-		return OFF
-	}
-
 	f, err := os.Open("/proc/self/mountinfo")
 	if err != nil {
 		return OFF
@@ -29,17 +25,35 @@ func detect() Mode {
 	}()
 
 	mode := scan(f)
-	if mode == CG2 && !functionalCgroups2() {
+
+	// unprivileged agents can only manage a cgroups v2 subtree delegated to
+	// them
+	if os.Geteuid() > 0 {
+		if mode != CG2 {
+			return OFF
+		}
+		cg, err := delegatedCgroup()
+		if err != nil {
+			return OFF
+		}
+		delegated = cg
+	}
+
+	if mode == CG2 && !functionalCgroups2(delegated) {
+		delegated = ""
 		return OFF
 	}
 	return mode
 }
 
-func functionalCgroups2() bool {
+// functionalCgroups2 returns whether the controllers Nomad requires are
+// available to the cgroup managed by Nomad, which is either the root or the
+// cgroup delegated to an unprivileged agent.
+func functionalCgroups2(cgroup string) bool {
 	const controllersFile = "cgroup.controllers"
 	requiredCgroup2Controllers := []string{"cpuset", "cpu", "io", "memory", "pids"}
 
-	controllersRootPath := filepath.Join(root, controllersFile)
+	controllersRootPath := filepath.Join(root, cgroup, controllersFile)
 	content, err := os.ReadFile(controllersRootPath)
 	if err != nil {
 		return false
@@ -60,6 +60,15 @@ func Test_scan(t *testing.T) {
 	}
 }
 
+func Test_parseCgroup2(t *testing.T) {
+	cg, err := parseCgroup2("0::/user.slice/user-1000.slice/user@1000.service/nomad.service\n")
+	must.NoError(t, err)
+	must.Eq(t, "/user.slice/user-1000.slice/user@1000.service/nomad.service", cg)
+
+	_, err = parseCgroup2("12:pids:/user.slice\n11:memory:/user.slice\n")
+	must.ErrorContains(t, err, "not in a cgroups v2 hierarchy")
+}
+
 func TestGetMode(t *testing.T) {
 	mode := GetMode()
 	ok := mode == CG1 || mode == CG2
@@ -9,3 +9,8 @@ package cgroupslib
 func GetMode() Mode {
 	return OFF
 }
+
+// Rootless returns false on non-Linux systems.
+func Rootless() bool {
+	return false
+}
@@ -6,6 +6,7 @@
 package cgroupslib
 
 import (
+	"path/filepath"
 	"sync"
 )
 
@@ -22,7 +23,9 @@ func defaultParent() string {
 	case CG1:
 		return "/nomad"
 	default:
-		return "nomad.slice"
+		// unprivileged agents create nomad.slice within the cgroup delegated
+		// to them
+		return filepath.Join(delegated, "nomad.slice")
 	}
 }
 
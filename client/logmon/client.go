@@ -29,6 +29,29 @@ func (c *logmonClient) Start(cfg *LogConfig) error {
 		MaxFileSizeMb:  uint32(cfg.MaxFileSizeMB),
 		StdoutFifo:     cfg.StdoutFifo,
 		StderrFifo:     cfg.StderrFifo,
+		Labels:         cfg.Labels,
+		SpoolDir:       cfg.SpoolDir,
+
+		RotateIntervalNs: cfg.RotateInterval.Nanoseconds(),
+		Compression:      cfg.Compression,
+		LogBudgetMb:      uint32(cfg.LogBudgetMB),
+	}
+	for _, sink := range cfg.Sinks {
+		req.Sinks = append(req.Sinks, &proto.LogSink{
+			Name:        sink.Name,
+			Type:        sink.Type,
+			Address:     sink.Address,
+			BatchSize:   uint32(sink.BatchSize),
+			BatchWaitNs: sink.BatchWait.Nanoseconds(),
+			SpoolSizeMb: uint32(sink.SpoolSizeMB),
+		})
+	}
+	if p := cfg.SinkPolicy; p != nil {
+		req.SinkPolicy = &proto.LogSinkPolicy{
+			AllowedTypes:     p.AllowedTypes,
+			AllowedHosts:     p.AllowedHosts,
+			AllowUnixSockets: p.AllowUnixSockets,
+		}
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), logmonRPCTimeout)
 	defer cancel()
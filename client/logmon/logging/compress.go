@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// CompressionGzip compresses rotated files with gzip
+	CompressionGzip = "gzip"
+
+	// CompressionZstd compresses rotated files with zstd
+	CompressionZstd = "zstd"
+)
+
+// compressedExts maps each compression algorithm to the suffix appended to
+// the name of the files it compressed.
+var compressedExts = map[string]string{
+	CompressionGzip: ".gz",
+	CompressionZstd: ".zst",
+}
+
+// gzipSizeID identifies the gzip header extra field recording the size of the
+// compressed log file's content, as a little endian uint64.
+var gzipSizeID = [2]byte{'N', 'S'}
+
+// TrimCompressedExt returns the name of a log file without the suffix added
+// when it was compressed, and whether it had one.
+func TrimCompressedExt(name string) (string, bool) {
+	for _, ext := range compressedExts {
+		if trimmed, ok := strings.CutSuffix(name, ext); ok {
+			return trimmed, true
+		}
+	}
+	return name, false
+}
+
+// NewDecompressReader returns a reader of the decompressed content of r, which
+// is read from the compressed log file with the given name.
+func NewDecompressReader(name string, r io.Reader) (io.ReadCloser, error) {
+	switch filepath.Ext(name) {
+	case compressedExts[CompressionGzip]:
+		return gzip.NewReader(r)
+	case compressedExts[CompressionZstd]:
+		d, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("%q is not a compressed log file", name)
+	}
+}
+
+// DecompressedSize returns the size of the decompressed content recorded in
+// the header of the compressed log file with the given name, read from r. It
+// returns false if the file doesn't record its size.
+func DecompressedSize(name string, r io.Reader) (int64, bool, error) {
+	switch filepath.Ext(name) {
+	case compressedExts[CompressionGzip]:
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return 0, false, err
+		}
+		// The extra field is a list of subfields, each made of a two byte
+		// ID and a two byte little endian length
+		extra := zr.Header.Extra
+		for len(extra) >= 4 {
+			n := int(binary.LittleEndian.Uint16(extra[2:4]))
+			if len(extra) < 4+n {
+				break
+			}
+			if extra[0] == gzipSizeID[0] && extra[1] == gzipSizeID[1] && n == 8 {
+				return int64(binary.LittleEndian.Uint64(extra[4:12])), true, nil
+			}
+			extra = extra[4+n:]
+		}
+		return 0, false, nil
+	case compressedExts[CompressionZstd]:
+		buf := make([]byte, zstd.HeaderMaxSize)
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return 0, false, err
+		}
+		var h zstd.Header
+		if err := h.Decode(buf[:n]); err != nil {
+			return 0, false, err
+		}
+		return int64(h.FrameContentSize), h.HasFCS, nil
+	default:
+		return 0, false, fmt.Errorf("%q is not a compressed log file", name)
+	}
+}
+
+// newCompressWriter returns a writer compressing size bytes to w. The size is
+// recorded in the header, so readers can learn it without decompressing.
+func newCompressWriter(w io.Writer, compression string, size int64) (io.WriteCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		zw := gzip.NewWriter(w)
+		zw.Extra = make([]byte, 12)
+		copy(zw.Extra, gzipSizeID[:])
+		binary.LittleEndian.PutUint16(zw.Extra[2:], 8)
+		binary.LittleEndian.PutUint64(zw.Extra[4:], uint64(size))
+		return zw, nil
+	case CompressionZstd:
+		// The frame content size is only stored for 256 bytes or more
+		zw, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		zw.ResetContentSize(w, size)
+		return zw, nil
+	default:
+		return nil, fmt.Errorf("unknown compression %q", compression)
+	}
+}
+
+// compressFile replaces the file at path with its compressed copy. The copy
+// is written to a hidden temporary file first so readers never observe a
+// partially compressed file.
+func compressFile(path, compression string) error {
+	dir, name := filepath.Split(path)
+	tmp, err := os.CreateTemp(dir, "."+name+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	w, err := newCompressWriter(tmp, compression, info.Size())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, src); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), path+compressedExts[compression]); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logging
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shoenig/test/must"
+)
+
+func TestCompressFile_DecompressedSize(t *testing.T) {
+	content := bytes.Repeat([]byte("abcdefgh\n"), 1000)
+	for _, compression := range []string{CompressionGzip, CompressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "redis.stdout.0")
+			must.NoError(t, os.WriteFile(path, content, 0o644))
+			must.NoError(t, compressFile(path, compression))
+
+			compressed := path + compressedExts[compression]
+			f, err := os.Open(compressed)
+			must.NoError(t, err)
+			defer f.Close()
+
+			size, ok, err := DecompressedSize(compressed, f)
+			must.NoError(t, err)
+			must.True(t, ok)
+			must.Eq(t, int64(len(content)), size)
+		})
+	}
+}
@@ -35,11 +35,30 @@ const (
 	newLineDelimiter = '\n'
 )
 
+// RotatorOptions are the optional behaviours of a FileRotator.
+type RotatorOptions struct {
+	// RotateInterval is the maximum age of a file before it is rotated, even
+	// if it is smaller than the maximum size. Files are only rotated when
+	// written to, so an idle file is rotated by the first write after the
+	// interval elapses.
+	RotateInterval time.Duration
+
+	// Compression is the algorithm rotated files are compressed with, if any
+	Compression string
+
+	// MaxTotalSize is the maximum size of all the files in the path,
+	// including those written by other rotators. Once exceeded the oldest
+	// rotated files are removed.
+	MaxTotalSize int64
+}
+
 // FileRotator writes bytes to a rotated set of files
 type FileRotator struct {
 	MaxFiles int   // MaxFiles is the maximum number of rotated files allowed in a path
 	FileSize int64 // FileSize is the size a rotated file is allowed to grow
 
+	RotatorOptions
+
 	path         string // path is the path on the file system where the rotated set of files are opened
 	baseFileName string // baseFileName is the base file name of the rotated files
 	logFileIdx   int    // logFileIdx is the current index of the rotated files
@@ -48,8 +67,9 @@ type FileRotator struct {
 	closed           bool
 	fileLock         sync.Mutex
 
-	currentFile *os.File // currentFile is the file that is currently getting written
-	currentWr   int64    // currentWr is the number of bytes written to the current file
+	currentFile *os.File  // currentFile is the file that is currently getting written
+	currentWr   int64     // currentWr is the number of bytes written to the current file
+	currentOpen time.Time // currentOpen is the time the current file was opened
 	bufw        *bufio.Writer
 	bufLock     sync.Mutex
 
@@ -62,10 +82,22 @@ type FileRotator struct {
 // NewFileRotator returns a new file rotator
 func NewFileRotator(path string, baseFile string, maxFiles int,
 	fileSize int64, logger hclog.Logger) (*FileRotator, error) {
+	return NewFileRotatorWithOptions(path, baseFile, maxFiles, fileSize, RotatorOptions{}, logger)
+}
+
+// NewFileRotatorWithOptions returns a new file rotator with optional time based
+// rotation, compression and total size limit
+func NewFileRotatorWithOptions(path string, baseFile string, maxFiles int,
+	fileSize int64, opts RotatorOptions, logger hclog.Logger) (*FileRotator, error) {
+	if _, ok := compressedExts[opts.Compression]; opts.Compression != "" && !ok {
+		return nil, fmt.Errorf("unknown compression %q", opts.Compression)
+	}
+
 	logger = logger.Named("rotator")
 	rotator := &FileRotator{
-		MaxFiles: maxFiles,
-		FileSize: fileSize,
+		MaxFiles:       maxFiles,
+		FileSize:       fileSize,
+		RotatorOptions: opts,
 
 		path:         path,
 		baseFileName: baseFile,
@@ -79,16 +111,24 @@ func NewFileRotator(path string, baseFile string, maxFiles int,
 	if err := rotator.lastFile(); err != nil {
 		return nil, err
 	}
+
+	// Compress the files rotated by a previous rotator and apply the total
+	// size limit right away
+	if rotator.Compression != "" || rotator.MaxTotalSize > 0 {
+		rotator.purgeCh <- struct{}{}
+	}
 	go rotator.purgeOldFiles()
 	go rotator.flushPeriodically()
 	return rotator, nil
 }
 
 // Write writes a byte array to a file and rotates the file if it's size becomes
-// equal to the maximum size the user has defined.
+// equal to the maximum size the user has defined, or if it's older than the
+// rotation interval.
 func (f *FileRotator) Write(p []byte) (n int, err error) {
 	n = 0
-	var forceRotate bool
+	forceRotate := f.RotateInterval > 0 && f.currentWr > 0 &&
+		time.Since(f.currentOpen) >= f.RotateInterval
 
 	for n < len(p) {
 		// Check if we still have space in the current file, otherwise close and
@@ -171,16 +211,21 @@ func (f *FileRotator) nextFile() error {
 				continue
 			}
 		}
+		f.fileLock.Lock()
 		f.logFileIdx = nextFileIdx
+		f.fileLock.Unlock()
 		if err := f.createFile(); err != nil {
 			return err
 		}
 		break
 	}
-	// Purge old files if we have more files than MaxFiles
+	// Purge old files if we have more files than MaxFiles, and compress or
+	// enforce the total size limit after every rotation
 	f.fileLock.Lock()
 	defer f.fileLock.Unlock()
-	if f.logFileIdx-f.oldestLogFileIdx >= f.MaxFiles && !f.closed {
+	purge := f.logFileIdx-f.oldestLogFileIdx >= f.MaxFiles ||
+		f.Compression != "" || f.MaxTotalSize > 0
+	if purge && !f.closed {
 		select {
 		case f.purgeCh <- struct{}{}:
 		default:
@@ -197,21 +242,28 @@ func (f *FileRotator) lastFile() error {
 	}
 
 	prefix := fmt.Sprintf("%s.", f.baseFileName)
+	var lastCompressed bool
 	for _, fi := range finfos {
 		if fi.IsDir() {
 			continue
 		}
 		if strings.HasPrefix(fi.Name(), prefix) {
-			fileIdx := strings.TrimPrefix(fi.Name(), prefix)
+			fileIdx, compressed := TrimCompressedExt(strings.TrimPrefix(fi.Name(), prefix))
 			n, err := strconv.Atoi(fileIdx)
 			if err != nil {
 				continue
 			}
-			if n > f.logFileIdx {
+			if n > f.logFileIdx || (n == f.logFileIdx && compressed) {
 				f.logFileIdx = n
+				lastCompressed = compressed
 			}
 		}
 	}
+
+	// Compressed files are never appended to
+	if lastCompressed {
+		f.logFileIdx++
+	}
 	if err := f.createFile(); err != nil {
 		return err
 	}
@@ -232,6 +284,7 @@ func (f *FileRotator) createFile() error {
 		return err
 	}
 	f.currentWr = fi.Size()
+	f.currentOpen = time.Now()
 	f.createOrResetBuffer()
 	return nil
 }
@@ -270,55 +323,181 @@ func (f *FileRotator) Close() error {
 	return nil
 }
 
-// purgeOldFiles removes older files and keeps only the last N files rotated for
-// a file
+// purgeOldFiles compresses rotated files and removes older files, keeping
+// only the last N files rotated for a file and the total size of the path
+// below the limit
 func (f *FileRotator) purgeOldFiles() {
 	for {
 		select {
 		case <-f.purgeCh:
-			var fIndexes []int
-			files, err := os.ReadDir(f.path)
-			if err != nil {
-				f.logger.Error("error getting directory listing", "error", err)
-				return
+			if f.Compression != "" {
+				f.compressRotatedFiles()
 			}
-			// Inserting all the rotated files in a slice
-			for _, fi := range files {
-				if strings.HasPrefix(fi.Name(), f.baseFileName) {
-					fileIdx := strings.TrimPrefix(fi.Name(), fmt.Sprintf("%s.", f.baseFileName))
-					n, err := strconv.Atoi(fileIdx)
-					if err != nil {
-						f.logger.Error("error extracting file index", "error", err)
-						continue
-					}
-					fIndexes = append(fIndexes, n)
-				}
+			f.purgeByCount()
+			if f.MaxTotalSize > 0 {
+				f.purgeByTotalSize()
 			}
+		case <-f.doneCh:
+			return
+		}
+	}
+}
 
-			// Not continuing to delete files if the number of files is not more
-			// than MaxFiles
-			if len(fIndexes) <= f.MaxFiles {
-				continue
-			}
+// rotatedFiles returns the names of the rotated files keyed by their index.
+func (f *FileRotator) rotatedFiles(files []os.DirEntry) map[int]string {
+	names := make(map[int]string)
+	prefix := fmt.Sprintf("%s.", f.baseFileName)
+	for _, fi := range files {
+		if !strings.HasPrefix(fi.Name(), prefix) {
+			continue
+		}
+		fileIdx, compressed := TrimCompressedExt(strings.TrimPrefix(fi.Name(), prefix))
+		n, err := strconv.Atoi(fileIdx)
+		if err != nil {
+			f.logger.Error("error extracting file index", "error", err)
+			continue
+		}
 
-			// Sorting the file indexes so that we can purge the older files and keep
-			// only the number of files as configured by the user
-			sort.Ints(fIndexes)
-			toDelete := fIndexes[0 : len(fIndexes)-f.MaxFiles]
-			for _, fIndex := range toDelete {
-				fname := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, fIndex))
-				err := os.RemoveAll(fname)
-				if err != nil {
-					f.logger.Error("error removing file", "filename", fname, "error", err)
-				}
-			}
+		// A file left uncompressed by an interrupted compression is the
+		// complete copy
+		if _, ok := names[n]; ok && compressed {
+			continue
+		}
+		names[n] = fi.Name()
+	}
+	return names
+}
 
-			f.fileLock.Lock()
-			f.oldestLogFileIdx = fIndexes[0]
-			f.fileLock.Unlock()
-		case <-f.doneCh:
+// compressRotatedFiles compresses every file but the one being written.
+func (f *FileRotator) compressRotatedFiles() {
+	f.fileLock.Lock()
+	current := f.logFileIdx
+	f.fileLock.Unlock()
+
+	files, err := os.ReadDir(f.path)
+	if err != nil {
+		f.logger.Error("error getting directory listing", "error", err)
+		return
+	}
+
+	for idx, name := range f.rotatedFiles(files) {
+		if _, compressed := TrimCompressedExt(name); compressed || idx >= current {
+			continue
+		}
+		fname := filepath.Join(f.path, name)
+		if err := compressFile(fname, f.Compression); err != nil {
+			f.logger.Error("error compressing file", "filename", fname, "error", err)
+		}
+	}
+}
+
+// purgeByCount removes the oldest files if there are more than MaxFiles.
+func (f *FileRotator) purgeByCount() {
+	files, err := os.ReadDir(f.path)
+	if err != nil {
+		f.logger.Error("error getting directory listing", "error", err)
+		return
+	}
+
+	// Inserting all the rotated files in a slice
+	names := f.rotatedFiles(files)
+	fIndexes := make([]int, 0, len(names))
+	for n := range names {
+		fIndexes = append(fIndexes, n)
+	}
+
+	// Not continuing to delete files if the number of files is not more
+	// than MaxFiles
+	if len(fIndexes) <= f.MaxFiles {
+		return
+	}
+
+	// Sorting the file indexes so that we can purge the older files and keep
+	// only the number of files as configured by the user
+	sort.Ints(fIndexes)
+	toDelete := fIndexes[0 : len(fIndexes)-f.MaxFiles]
+	for _, fIndex := range toDelete {
+		fname := filepath.Join(f.path, names[fIndex])
+		err := os.RemoveAll(fname)
+		if err != nil {
+			f.logger.Error("error removing file", "filename", fname, "error", err)
+		}
+	}
+
+	f.fileLock.Lock()
+	f.oldestLogFileIdx = fIndexes[0]
+	f.fileLock.Unlock()
+}
+
+// purgeByTotalSize removes the oldest rotated files in the path, whichever
+// rotator wrote them, until the size of all the files is below MaxTotalSize.
+// The file each rotator is writing to is never removed.
+func (f *FileRotator) purgeByTotalSize() {
+	files, err := os.ReadDir(f.path)
+	if err != nil {
+		f.logger.Error("error getting directory listing", "error", err)
+		return
+	}
+
+	type logFile struct {
+		name    string
+		idx     int
+		size    int64
+		modTime time.Time
+	}
+
+	// Group the files by rotator to find the file each one is writing to
+	var total int64
+	current := make(map[string]int)
+	var logFiles []*logFile
+	for _, fi := range files {
+		// Hidden files are temporary files or belong to other components
+		if fi.IsDir() || strings.HasPrefix(fi.Name(), ".") {
+			continue
+		}
+		name, _ := TrimCompressedExt(fi.Name())
+		ext := filepath.Ext(name)
+		idx, err := strconv.Atoi(strings.TrimPrefix(ext, "."))
+		if err != nil {
+			continue
+		}
+		info, err := fi.Info()
+		if err != nil {
+			continue
+		}
+
+		total += info.Size()
+		base := strings.TrimSuffix(name, ext)
+		if cur, ok := current[base]; !ok || idx > cur {
+			current[base] = idx
+		}
+		logFiles = append(logFiles, &logFile{name: fi.Name(), idx: idx, size: info.Size(), modTime: info.ModTime()})
+	}
+	if total <= f.MaxTotalSize {
+		return
+	}
+
+	sort.Slice(logFiles, func(i, j int) bool {
+		if logFiles[i].modTime.Equal(logFiles[j].modTime) {
+			return logFiles[i].idx < logFiles[j].idx
+		}
+		return logFiles[i].modTime.Before(logFiles[j].modTime)
+	})
+	for _, lf := range logFiles {
+		if total <= f.MaxTotalSize {
 			return
 		}
+		name, compressed := TrimCompressedExt(lf.name)
+		if !compressed && lf.idx == current[strings.TrimSuffix(name, filepath.Ext(name))] {
+			continue
+		}
+
+		fname := filepath.Join(f.path, lf.name)
+		if err := os.Remove(fname); err != nil && !os.IsNotExist(err) {
+			f.logger.Error("error removing file", "filename", fname, "error", err)
+			continue
+		}
+		total -= lf.size
 	}
 }
 
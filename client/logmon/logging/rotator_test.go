@@ -5,10 +5,13 @@ package logging
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/testutil"
@@ -275,6 +278,129 @@ func TestFileRotator_PurgeOldFiles(t *testing.T) {
 	})
 }
 
+func TestFileRotator_RotateInterval(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	opts := RotatorOptions{RotateInterval: time.Hour}
+	fr, err := NewFileRotatorWithOptions(path, baseFileName, 10, 1024, opts, testlog.HCLogger(t))
+	must.NoError(t, err)
+
+	_, err = fr.Write([]byte("abc\n"))
+	must.NoError(t, err)
+	_, err = fr.Write([]byte("def\n"))
+	must.NoError(t, err)
+
+	// Once the interval elapses the next write goes to a new file
+	fr.currentOpen = time.Now().Add(-2 * time.Hour)
+	_, err = fr.Write([]byte("ghi\n"))
+	must.NoError(t, err)
+	must.NoError(t, fr.Close())
+
+	b, err := os.ReadFile(filepath.Join(path, "redis.stdout.0"))
+	must.NoError(t, err)
+	must.Eq(t, "abc\ndef\n", string(b))
+	b, err = os.ReadFile(filepath.Join(path, "redis.stdout.1"))
+	must.NoError(t, err)
+	must.Eq(t, "ghi\n", string(b))
+}
+
+func TestFileRotator_Compression(t *testing.T) {
+	for _, compression := range []string{CompressionGzip, CompressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+
+			path := t.TempDir()
+
+			opts := RotatorOptions{Compression: compression}
+			fr, err := NewFileRotatorWithOptions(path, baseFileName, 10, 5, opts, testlog.HCLogger(t))
+			must.NoError(t, err)
+
+			_, err = fr.Write([]byte("abcd\nefgh\n"))
+			must.NoError(t, err)
+
+			ext := compressedExts[compression]
+			compressed := filepath.Join(path, "redis.stdout.0"+ext)
+			testutil.WaitForResult(func() (bool, error) {
+				if _, err := os.Stat(compressed); err != nil {
+					return false, err
+				}
+				if _, err := os.Stat(filepath.Join(path, "redis.stdout.0")); !os.IsNotExist(err) {
+					return false, fmt.Errorf("rotated file wasn't removed: %v", err)
+				}
+				return true, nil
+			}, func(err error) {
+				must.NoError(t, err)
+			})
+			must.NoError(t, fr.Close())
+
+			f, err := os.Open(compressed)
+			must.NoError(t, err)
+			defer f.Close()
+			r, err := NewDecompressReader(compressed, f)
+			must.NoError(t, err)
+			defer r.Close()
+			b, err := io.ReadAll(r)
+			must.NoError(t, err)
+			must.Eq(t, "abcd\n", string(b))
+
+			// The file being written is never compressed, even on restart
+			fr, err = NewFileRotatorWithOptions(path, baseFileName, 10, 5, opts, testlog.HCLogger(t))
+			must.NoError(t, err)
+			must.Eq(t, filepath.Join(path, "redis.stdout.1"), fr.currentFile.Name())
+			must.NoError(t, fr.Close())
+		})
+	}
+}
+
+func TestFileRotator_MaxTotalSize(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	// Files of two rotators sharing the path, oldest first
+	now := time.Now()
+	files := []string{
+		"redis.stdout.0.gz",
+		"redis.stderr.0",
+		"redis.stdout.1",
+		"redis.stderr.1",
+		"redis.stdout.2",
+	}
+	for i, name := range files {
+		fname := filepath.Join(path, name)
+		must.NoError(t, os.WriteFile(fname, []byte("0123456789"), 0644))
+		mtime := now.Add(time.Duration(i-len(files)) * time.Hour)
+		must.NoError(t, os.Chtimes(fname, mtime, mtime))
+	}
+	must.NoError(t, os.WriteFile(filepath.Join(path, ".redis.stdout.fifo"), nil, 0644))
+
+	opts := RotatorOptions{MaxTotalSize: 25}
+	fr, err := NewFileRotatorWithOptions(path, baseFileName, 10, 1024, opts, testlog.HCLogger(t))
+	must.NoError(t, err)
+	defer fr.Close()
+
+	// The oldest files are removed, but not those being written to
+	testutil.WaitForResult(func() (bool, error) {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return false, err
+		}
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		expected := []string{".redis.stdout.fifo", "redis.stderr.1", "redis.stdout.2"}
+		if !slices.Equal(expected, names) {
+			return false, fmt.Errorf("expected files %v, got %v", expected, names)
+		}
+		return true, nil
+	}, func(err error) {
+		must.NoError(t, err)
+	})
+}
+
 func BenchmarkRotator(b *testing.B) {
 	kb := 1024
 	for _, inputSize := range []int{kb, 2 * kb, 4 * kb, 8 * kb, 16 * kb, 32 * kb, 64 * kb, 128 * kb, 256 * kb} {
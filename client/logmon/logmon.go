@@ -44,6 +44,29 @@ type LogConfig struct {
 
 	// MaxFileSizeMB is the max log file size in MB allowed before rotation occures
 	MaxFileSizeMB int
+
+	// RotateInterval is the max age of a log file before rotation occurs
+	RotateInterval time.Duration
+
+	// Compression is the algorithm used to compress rotated log files
+	Compression string
+
+	// LogBudgetMB is the max size in MB of all the log files in LogDir
+	LogBudgetMB int
+
+	// Sinks are the external systems logs are forwarded to
+	Sinks []*SinkConfig
+
+	// Labels are attached to every line forwarded to the sinks
+	Labels map[string]string
+
+	// SpoolDir is the host path where lines that can't be forwarded to the
+	// sinks are spooled
+	SpoolDir string
+
+	// SinkPolicy restricts the sinks logs may be forwarded to. No sinks are
+	// allowed without a policy.
+	SinkPolicy *SinkPolicy
 }
 
 type LogMon interface {
@@ -109,6 +132,9 @@ type TaskLogger struct {
 
 	// rotator for stderr
 	lre *logRotatorWrapper
+
+	// shippers forward stdout and stderr to the sinks
+	shippers []*shipper
 }
 
 // IsRunning will return true as long as one rotator wrapper is still running
@@ -136,33 +162,59 @@ func (tl *TaskLogger) Close() {
 		}()
 	}
 	wg.Wait()
+
+	// Shippers are closed last to forward what was written before the
+	// rotators closed
+	for _, s := range tl.shippers {
+		s.Close()
+	}
 }
 
 func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	tl := &TaskLogger{config: cfg}
 
+	for _, sinkCfg := range cfg.Sinks {
+		s, err := newShipper(sinkCfg, cfg.SinkPolicy, cfg.Labels, cfg.SpoolDir, logger)
+		if err != nil {
+			tl.Close()
+			return nil, fmt.Errorf("failed to create log sink %q: %v", sinkCfg.Name, err)
+		}
+		tl.shippers = append(tl.shippers, s)
+	}
+
 	logFileSize := int64(cfg.MaxFileSizeMB * 1024 * 1024)
-	lro, err := logging.NewFileRotator(cfg.LogDir, cfg.StdoutLogFile,
-		cfg.MaxFiles, logFileSize, logger)
+	opts := logging.RotatorOptions{
+		RotateInterval: cfg.RotateInterval,
+		Compression:    cfg.Compression,
+		MaxTotalSize:   int64(cfg.LogBudgetMB) * 1024 * 1024,
+	}
+	lro, err := logging.NewFileRotatorWithOptions(cfg.LogDir, cfg.StdoutLogFile,
+		cfg.MaxFiles, logFileSize, opts, logger)
 	if err != nil {
+		tl.Close()
 		return nil, fmt.Errorf("failed to create stdout logfile for %q: %v", cfg.StdoutLogFile, err)
 	}
 
-	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger, lro)
+	wrapperOut, err := newLogRotatorWrapper(cfg.StdoutFifo, logger,
+		newShippingWriter(lro, "stdout", tl.shippers))
 	if err != nil {
+		tl.Close()
 		return nil, err
 	}
 
 	tl.lro = wrapperOut
 
-	lre, err := logging.NewFileRotator(cfg.LogDir, cfg.StderrLogFile,
-		cfg.MaxFiles, logFileSize, logger)
+	lre, err := logging.NewFileRotatorWithOptions(cfg.LogDir, cfg.StderrLogFile,
+		cfg.MaxFiles, logFileSize, opts, logger)
 	if err != nil {
+		tl.Close()
 		return nil, fmt.Errorf("failed to create stderr logfile for %q: %v", cfg.StderrLogFile, err)
 	}
 
-	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger, lre)
+	wrapperErr, err := newLogRotatorWrapper(cfg.StderrFifo, logger,
+		newShippingWriter(lre, "stderr", tl.shippers))
 	if err != nil {
+		tl.Close()
 		return nil, err
 	}
 
@@ -25,16 +25,23 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type StartRequest struct {
-	LogDir               string   `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
-	StdoutFileName       string   `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
-	StderrFileName       string   `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
-	MaxFiles             uint32   `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
-	MaxFileSizeMb        uint32   `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
-	StdoutFifo           string   `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
-	StderrFifo           string   `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	LogDir               string            `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
+	StdoutFileName       string            `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
+	StderrFileName       string            `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
+	MaxFiles             uint32            `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
+	MaxFileSizeMb        uint32            `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
+	StdoutFifo           string            `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
+	StderrFifo           string            `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
+	Sinks                []*LogSink        `protobuf:"bytes,8,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SpoolDir             string            `protobuf:"bytes,10,opt,name=spool_dir,json=spoolDir,proto3" json:"spool_dir,omitempty"`
+	RotateIntervalNs     int64             `protobuf:"varint,11,opt,name=rotate_interval_ns,json=rotateIntervalNs,proto3" json:"rotate_interval_ns,omitempty"`
+	Compression          string            `protobuf:"bytes,12,opt,name=compression,proto3" json:"compression,omitempty"`
+	LogBudgetMb          uint32            `protobuf:"varint,13,opt,name=log_budget_mb,json=logBudgetMb,proto3" json:"log_budget_mb,omitempty"`
+	SinkPolicy           *LogSinkPolicy    `protobuf:"bytes,14,opt,name=sink_policy,json=sinkPolicy,proto3" json:"sink_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *StartRequest) Reset()         { *m = StartRequest{} }
@@ -111,6 +118,55 @@ func (m *StartRequest) GetStderrFifo() string {
 	return ""
 }
 
+func (m *StartRequest) GetSinks() []*LogSink {
+	if m != nil {
+		return m.Sinks
+	}
+	return nil
+}
+
+func (m *StartRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *StartRequest) GetSpoolDir() string {
+	if m != nil {
+		return m.SpoolDir
+	}
+	return ""
+}
+
+func (m *StartRequest) GetRotateIntervalNs() int64 {
+	if m != nil {
+		return m.RotateIntervalNs
+	}
+	return 0
+}
+
+func (m *StartRequest) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *StartRequest) GetLogBudgetMb() uint32 {
+	if m != nil {
+		return m.LogBudgetMb
+	}
+	return 0
+}
+
+func (m *StartRequest) GetSinkPolicy() *LogSinkPolicy {
+	if m != nil {
+		return m.SinkPolicy
+	}
+	return nil
+}
+
 type StartResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -204,11 +260,148 @@ func (m *StopResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StopResponse proto.InternalMessageInfo
 
+type LogSink struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Address              string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	BatchSize            uint32   `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	BatchWaitNs          int64    `protobuf:"varint,5,opt,name=batch_wait_ns,json=batchWaitNs,proto3" json:"batch_wait_ns,omitempty"`
+	SpoolSizeMb          uint32   `protobuf:"varint,6,opt,name=spool_size_mb,json=spoolSizeMb,proto3" json:"spool_size_mb,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogSink) Reset()         { *m = LogSink{} }
+func (m *LogSink) String() string { return proto.CompactTextString(m) }
+func (*LogSink) ProtoMessage()    {}
+func (*LogSink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be72d5e24d2ecba6, []int{4}
+}
+
+func (m *LogSink) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogSink.Unmarshal(m, b)
+}
+func (m *LogSink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogSink.Marshal(b, m, deterministic)
+}
+func (m *LogSink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogSink.Merge(m, src)
+}
+func (m *LogSink) XXX_Size() int {
+	return xxx_messageInfo_LogSink.Size(m)
+}
+func (m *LogSink) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogSink.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogSink proto.InternalMessageInfo
+
+func (m *LogSink) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LogSink) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *LogSink) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *LogSink) GetBatchSize() uint32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *LogSink) GetBatchWaitNs() int64 {
+	if m != nil {
+		return m.BatchWaitNs
+	}
+	return 0
+}
+
+func (m *LogSink) GetSpoolSizeMb() uint32 {
+	if m != nil {
+		return m.SpoolSizeMb
+	}
+	return 0
+}
+
+type LogSinkPolicy struct {
+	AllowedTypes         []string `protobuf:"bytes,1,rep,name=allowed_types,json=allowedTypes,proto3" json:"allowed_types,omitempty"`
+	AllowedHosts         []string `protobuf:"bytes,2,rep,name=allowed_hosts,json=allowedHosts,proto3" json:"allowed_hosts,omitempty"`
+	AllowUnixSockets     bool     `protobuf:"varint,3,opt,name=allow_unix_sockets,json=allowUnixSockets,proto3" json:"allow_unix_sockets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogSinkPolicy) Reset()         { *m = LogSinkPolicy{} }
+func (m *LogSinkPolicy) String() string { return proto.CompactTextString(m) }
+func (*LogSinkPolicy) ProtoMessage()    {}
+func (*LogSinkPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be72d5e24d2ecba6, []int{5}
+}
+
+func (m *LogSinkPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogSinkPolicy.Unmarshal(m, b)
+}
+func (m *LogSinkPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogSinkPolicy.Marshal(b, m, deterministic)
+}
+func (m *LogSinkPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogSinkPolicy.Merge(m, src)
+}
+func (m *LogSinkPolicy) XXX_Size() int {
+	return xxx_messageInfo_LogSinkPolicy.Size(m)
+}
+func (m *LogSinkPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogSinkPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogSinkPolicy proto.InternalMessageInfo
+
+func (m *LogSinkPolicy) GetAllowedTypes() []string {
+	if m != nil {
+		return m.AllowedTypes
+	}
+	return nil
+}
+
+func (m *LogSinkPolicy) GetAllowedHosts() []string {
+	if m != nil {
+		return m.AllowedHosts
+	}
+	return nil
+}
+
+func (m *LogSinkPolicy) GetAllowUnixSockets() bool {
+	if m != nil {
+		return m.AllowUnixSockets
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*StartRequest)(nil), "hashicorp.nomad.client.logmon.proto.StartRequest")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.client.logmon.proto.StartRequest.LabelsEntry")
 	proto.RegisterType((*StartResponse)(nil), "hashicorp.nomad.client.logmon.proto.StartResponse")
 	proto.RegisterType((*StopRequest)(nil), "hashicorp.nomad.client.logmon.proto.StopRequest")
 	proto.RegisterType((*StopResponse)(nil), "hashicorp.nomad.client.logmon.proto.StopResponse")
+	proto.RegisterType((*LogSink)(nil), "hashicorp.nomad.client.logmon.proto.LogSink")
+	proto.RegisterType((*LogSinkPolicy)(nil), "hashicorp.nomad.client.logmon.proto.LogSinkPolicy")
 }
 
 func init() {
@@ -216,27 +409,48 @@ func init() {
 }
 
 var fileDescriptor_be72d5e24d2ecba6 = []byte{
-	// 320 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x90, 0x31, 0x6f, 0xc2, 0x30,
-	0x10, 0x85, 0x1b, 0x0a, 0xa1, 0x1c, 0x0d, 0x45, 0x5e, 0x1a, 0xd1, 0xa1, 0x28, 0x1d, 0xca, 0x14,
-	0x0a, 0xfd, 0x07, 0x55, 0xd5, 0xa9, 0x74, 0x80, 0xad, 0x4b, 0x64, 0xc0, 0x09, 0x96, 0x62, 0x5f,
-	0x6a, 0x1b, 0x09, 0xb1, 0xf6, 0xd7, 0xf6, 0x5f, 0x54, 0x71, 0x4c, 0x94, 0x11, 0x26, 0xeb, 0xee,
-	0x7d, 0x4f, 0xf7, 0xfc, 0x60, 0xbc, 0xc9, 0x39, 0x93, 0x66, 0x9a, 0x63, 0x26, 0x50, 0x4e, 0x0b,
-	0x85, 0x06, 0xdd, 0x10, 0xdb, 0x81, 0x3c, 0xed, 0xa8, 0xde, 0xf1, 0x0d, 0xaa, 0x22, 0x96, 0x28,
-	0xe8, 0x36, 0xae, 0x1c, 0x71, 0x13, 0x8a, 0x7e, 0x5b, 0x70, 0xbb, 0x32, 0x54, 0x99, 0x25, 0xfb,
-	0xd9, 0x33, 0x6d, 0xc8, 0x3d, 0x74, 0x73, 0xcc, 0x92, 0x2d, 0x57, 0xa1, 0x37, 0xf6, 0x26, 0xbd,
-	0xa5, 0x9f, 0x63, 0xf6, 0xce, 0x15, 0x99, 0xc0, 0x50, 0x9b, 0x2d, 0xee, 0x4d, 0x92, 0xf2, 0x9c,
-	0x25, 0x92, 0x0a, 0x16, 0xb6, 0x2c, 0x31, 0xa8, 0xf6, 0x1f, 0x3c, 0x67, 0x5f, 0x54, 0x30, 0x47,
-	0x32, 0xa5, 0x1a, 0xe4, 0x75, 0x4d, 0x32, 0xa5, 0x6a, 0xf2, 0x01, 0x7a, 0x82, 0x1e, 0x2c, 0xa6,
-	0xc3, 0xf6, 0xd8, 0x9b, 0x04, 0xcb, 0x1b, 0x41, 0x0f, 0xa5, 0xae, 0xc9, 0x33, 0x0c, 0x4f, 0x62,
-	0xa2, 0xf9, 0x91, 0x25, 0x62, 0x1d, 0x76, 0x2c, 0x13, 0x38, 0x66, 0xc5, 0x8f, 0x6c, 0xb1, 0x26,
-	0x8f, 0xd0, 0xaf, 0x93, 0xa5, 0x18, 0xfa, 0xf6, 0x14, 0x9c, 0x42, 0xa5, 0xe8, 0x80, 0x2a, 0x50,
-	0x8a, 0x61, 0xb7, 0x06, 0x6c, 0x96, 0x14, 0xa3, 0x3b, 0x08, 0x5c, 0x09, 0xba, 0x40, 0xa9, 0x59,
-	0x14, 0x40, 0x7f, 0x65, 0xb0, 0x70, 0xa5, 0x44, 0x83, 0xb2, 0xa4, 0x72, 0xac, 0xe4, 0xf9, 0x9f,
-	0x07, 0xfe, 0x27, 0x66, 0x0b, 0x94, 0xa4, 0x80, 0x8e, 0xb5, 0x92, 0x59, 0x7c, 0x46, 0xdf, 0x71,
-	0xb3, 0xeb, 0xd1, 0xfc, 0x12, 0x8b, 0x4b, 0x76, 0x45, 0x04, 0xb4, 0xcb, 0x30, 0xe4, 0xe5, 0x4c,
-	0x77, 0xfd, 0x8d, 0xd1, 0xec, 0x02, 0xc7, 0xe9, 0xdc, 0x5b, 0xf7, 0xbb, 0x63, 0xf7, 0x6b, 0xdf,
-	0x3e, 0xaf, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd8, 0xb4, 0x87, 0xfc, 0x7a, 0x02, 0x00, 0x00,
+	// 656 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0xfd, 0xdc, 0x34, 0x7f, 0xd7, 0x71, 0xbf, 0x68, 0x84, 0x84, 0x55, 0x84, 0xb0, 0xd2, 0x05,
+	0x59, 0x54, 0x29, 0x0d, 0x1b, 0x40, 0x62, 0x53, 0x01, 0x02, 0xa9, 0xad, 0x90, 0x43, 0x85, 0xc4,
+	0xc6, 0x9a, 0x24, 0x93, 0x64, 0x94, 0xb1, 0xaf, 0xf1, 0x4c, 0xda, 0xa4, 0x2f, 0xc0, 0xfb, 0xf0,
+	0x02, 0x3c, 0x0f, 0x6f, 0x81, 0xe6, 0x7a, 0x1c, 0xa5, 0xbb, 0x66, 0xe5, 0xb9, 0xe7, 0x9e, 0x3b,
+	0x73, 0x7d, 0xce, 0x81, 0x68, 0xa2, 0xa4, 0xc8, 0xcc, 0x99, 0xc2, 0x79, 0x8a, 0xd9, 0x59, 0x5e,
+	0xa0, 0x41, 0x57, 0x0c, 0xa8, 0x60, 0x27, 0x0b, 0xae, 0x17, 0x72, 0x82, 0x45, 0x3e, 0xc8, 0x30,
+	0xe5, 0xd3, 0x41, 0x39, 0x31, 0xd8, 0x25, 0xf5, 0xfe, 0xd4, 0xa1, 0x33, 0x32, 0xbc, 0x30, 0xb1,
+	0xf8, 0xb9, 0x12, 0xda, 0xb0, 0xa7, 0xd0, 0x54, 0x38, 0x4f, 0xa6, 0xb2, 0x08, 0xbd, 0xc8, 0xeb,
+	0xb7, 0xe3, 0x86, 0xc2, 0xf9, 0x07, 0x59, 0xb0, 0x3e, 0x74, 0xb5, 0x99, 0xe2, 0xca, 0x24, 0x33,
+	0xa9, 0x44, 0x92, 0xf1, 0x54, 0x84, 0x07, 0xc4, 0x38, 0x2a, 0xf1, 0x4f, 0x52, 0x89, 0x6b, 0x9e,
+	0x0a, 0xc7, 0x14, 0x45, 0xb1, 0xc3, 0xac, 0x6d, 0x99, 0xa2, 0x28, 0xb6, 0xcc, 0x67, 0xd0, 0x4e,
+	0xf9, 0x9a, 0x68, 0x3a, 0x3c, 0x8c, 0xbc, 0x7e, 0x10, 0xb7, 0x52, 0xbe, 0xb6, 0x7d, 0xcd, 0x5e,
+	0x42, 0xb7, 0x6a, 0x26, 0x5a, 0xde, 0x8b, 0x24, 0x1d, 0x87, 0x75, 0xe2, 0x04, 0x8e, 0x33, 0x92,
+	0xf7, 0xe2, 0x6a, 0xcc, 0x5e, 0x80, 0xbf, 0xdd, 0x6c, 0x86, 0x61, 0x83, 0x9e, 0x82, 0x6a, 0xa9,
+	0x19, 0x3a, 0x42, 0xb9, 0xd0, 0x0c, 0xc3, 0xe6, 0x96, 0x40, 0xbb, 0xcc, 0x90, 0x5d, 0x40, 0x5d,
+	0xcb, 0x6c, 0xa9, 0xc3, 0x56, 0x54, 0xeb, 0xfb, 0xc3, 0xd3, 0xc1, 0x23, 0xa4, 0x1b, 0x5c, 0xe2,
+	0x7c, 0x24, 0xb3, 0x65, 0x5c, 0x8e, 0xb2, 0x1b, 0x68, 0x28, 0x3e, 0x16, 0x4a, 0x87, 0x6d, 0xba,
+	0xe4, 0xfd, 0xa3, 0x2e, 0xd9, 0xd5, 0x7e, 0x70, 0x49, 0xf3, 0x1f, 0x33, 0x53, 0x6c, 0x62, 0x77,
+	0x99, 0x95, 0x48, 0xe7, 0x88, 0x8a, 0x1c, 0x01, 0xda, 0xbc, 0x45, 0x80, 0xf5, 0xe4, 0x14, 0x58,
+	0x81, 0x86, 0x1b, 0x91, 0xc8, 0xcc, 0x88, 0xe2, 0x96, 0xab, 0x24, 0xd3, 0xa1, 0x1f, 0x79, 0xfd,
+	0x5a, 0xdc, 0x2d, 0x3b, 0x5f, 0x5c, 0xe3, 0x5a, 0xb3, 0x08, 0xfc, 0x09, 0xa6, 0x79, 0x21, 0xb4,
+	0x96, 0x98, 0x85, 0x1d, 0xba, 0x6c, 0x17, 0x62, 0x3d, 0x08, 0xac, 0xf9, 0xe3, 0xd5, 0x74, 0x2e,
+	0x8c, 0xd5, 0x3b, 0x20, 0xbd, 0x7d, 0x85, 0xf3, 0x0b, 0xc2, 0xae, 0xc6, 0x6c, 0x04, 0xbe, 0xfd,
+	0xe1, 0x24, 0x47, 0x25, 0x27, 0x9b, 0xf0, 0x28, 0xf2, 0xfa, 0xfe, 0x70, 0xb8, 0x8f, 0x62, 0x5f,
+	0x69, 0x32, 0x06, 0xbd, 0x3d, 0x1f, 0xbf, 0x05, 0x7f, 0xe7, 0xe7, 0x59, 0x17, 0x6a, 0x4b, 0xb1,
+	0x71, 0x01, 0xb4, 0x47, 0xf6, 0x04, 0xea, 0xb7, 0x5c, 0xad, 0xaa, 0xc8, 0x95, 0xc5, 0xbb, 0x83,
+	0x37, 0x5e, 0xef, 0x7f, 0x08, 0x9c, 0x88, 0x3a, 0xc7, 0x4c, 0x8b, 0x5e, 0x00, 0xfe, 0xc8, 0x60,
+	0xee, 0x44, 0xed, 0x1d, 0x41, 0xa7, 0x2c, 0x5d, 0xfb, 0xb7, 0x07, 0x4d, 0xb7, 0x08, 0x63, 0x70,
+	0x48, 0xe9, 0x2c, 0x1f, 0xa2, 0xb3, 0xc5, 0xcc, 0x26, 0xaf, 0x1e, 0xa2, 0x33, 0x0b, 0xa1, 0xc9,
+	0xa7, 0x53, 0xab, 0x92, 0x0b, 0x72, 0x55, 0xb2, 0xe7, 0x00, 0x63, 0x6e, 0x26, 0x0b, 0x4a, 0xa8,
+	0x8b, 0x70, 0x9b, 0x10, 0x1b, 0x4e, 0x2b, 0x68, 0xd9, 0xbe, 0xe3, 0xd2, 0x58, 0x6f, 0xea, 0xe4,
+	0x8d, 0x4f, 0xe0, 0x77, 0x2e, 0xcd, 0xb5, 0xb6, 0x9c, 0xd2, 0xe1, 0x2a, 0xe4, 0x8d, 0x52, 0x74,
+	0x02, 0xcb, 0x88, 0xf7, 0x7e, 0x79, 0x10, 0x3c, 0x50, 0x8f, 0x9d, 0x40, 0xc0, 0x95, 0xc2, 0x3b,
+	0x31, 0x4d, 0xec, 0x8a, 0x3a, 0xf4, 0xa2, 0x5a, 0xbf, 0x1d, 0x77, 0x1c, 0xf8, 0xcd, 0x62, 0xbb,
+	0xa4, 0x05, 0x6a, 0xa3, 0xc3, 0x83, 0x07, 0xa4, 0xcf, 0x16, 0xb3, 0x21, 0xa2, 0x3a, 0x59, 0x65,
+	0x72, 0x9d, 0x68, 0x9c, 0x2c, 0x85, 0x29, 0xff, 0xb3, 0x15, 0x77, 0xa9, 0x73, 0x93, 0xc9, 0xf5,
+	0xa8, 0xc4, 0x87, 0x7f, 0x3d, 0x68, 0x5c, 0xe2, 0xfc, 0x0a, 0x33, 0x96, 0x43, 0x9d, 0x94, 0x67,
+	0xe7, 0x7b, 0x47, 0xfd, 0x78, 0xb8, 0xcf, 0x88, 0x73, 0xee, 0x3f, 0x96, 0xc2, 0xa1, 0xf5, 0x92,
+	0xbd, 0x7a, 0xe4, 0xf4, 0x36, 0x05, 0xc7, 0xe7, 0x7b, 0x4c, 0x54, 0xcf, 0x5d, 0x34, 0x7f, 0xd4,
+	0x09, 0x1f, 0x37, 0xe8, 0xf3, 0xfa, 0xdf, 0x00, 0x4d, 0xb8, 0xff, 0x08, 0x75, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -5,6 +5,7 @@ package logmon
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/client/logmon/proto"
@@ -24,6 +25,29 @@ func (s *logmonServer) Start(ctx context.Context, req *proto.StartRequest) (*pro
 		MaxFileSizeMB: int(req.MaxFileSizeMb),
 		StdoutFifo:    req.StdoutFifo,
 		StderrFifo:    req.StderrFifo,
+		Labels:        req.Labels,
+		SpoolDir:      req.SpoolDir,
+
+		RotateInterval: time.Duration(req.RotateIntervalNs),
+		Compression:    req.Compression,
+		LogBudgetMB:    int(req.LogBudgetMb),
+	}
+	for _, sink := range req.Sinks {
+		cfg.Sinks = append(cfg.Sinks, &SinkConfig{
+			Name:        sink.Name,
+			Type:        sink.Type,
+			Address:     sink.Address,
+			BatchSize:   int(sink.BatchSize),
+			BatchWait:   time.Duration(sink.BatchWaitNs),
+			SpoolSizeMB: int(sink.SpoolSizeMb),
+		})
+	}
+	if p := req.SinkPolicy; p != nil {
+		cfg.SinkPolicy = &SinkPolicy{
+			AllowedTypes:     p.AllowedTypes,
+			AllowedHosts:     p.AllowedHosts,
+			AllowUnixSockets: p.AllowUnixSockets,
+		}
 	}
 
 	err := s.impl.Start(cfg)
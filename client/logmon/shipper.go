@@ -0,0 +1,271 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// maxLogLineSize is the size at which a line without a newline is split
+	// into several records
+	maxLogLineSize = 64 * 1024
+
+	// shipperRetryInterval is the time between attempts to send spooled
+	// batches while a sink is failing. Dropped lines are reported at the
+	// same interval.
+	shipperRetryInterval = 5 * time.Second
+)
+
+// shipper batches log records and sends them to a sink. Batches that can't be
+// sent are written to a bounded disk spool and retried oldest first, so a
+// slow or unavailable sink never blocks the task's output.
+type shipper struct {
+	cfg    *SinkConfig
+	sink   sink
+	labels map[string]string
+	spool  *diskSpool
+	logger hclog.Logger
+
+	records chan *logRecord
+
+	// dropped counts the records dropped because the queue was full since
+	// they were last reported
+	dropped atomic.Uint64
+
+	// failing is set once sending to the sink fails. Batches are then
+	// spooled without trying the sink until a retry succeeds.
+	failing bool
+
+	// ctx is canceled by Close, after which the shipper sends any queued
+	// records and closes doneCh
+	ctx      context.Context
+	cancelFn context.CancelFunc
+	doneCh   chan struct{}
+}
+
+func newShipper(cfg *SinkConfig, policy *SinkPolicy, labels map[string]string, spoolDir string, logger hclog.Logger) (*shipper, error) {
+	sink, err := newSink(cfg, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	spool, err := newDiskSpool(filepath.Join(spoolDir, cfg.Name), int64(cfg.SpoolSizeMB)*1024*1024)
+	if err != nil {
+		return nil, err
+	}
+
+	return startShipper(cfg, sink, labels, spool, logger), nil
+}
+
+func startShipper(cfg *SinkConfig, sink sink, labels map[string]string, spool *diskSpool, logger hclog.Logger) *shipper {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &shipper{
+		cfg:      cfg,
+		sink:     sink,
+		labels:   labels,
+		spool:    spool,
+		logger:   logger.With("sink", cfg.Name),
+		records:  make(chan *logRecord, cfg.BatchSize*4),
+		ctx:      ctx,
+		cancelFn: cancel,
+		doneCh:   make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// ship queues a record without blocking. Records are only dropped if the
+// queue is full because the shipper can't even keep up with spooling.
+func (s *shipper) ship(r *logRecord) {
+	select {
+	case s.records <- r:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Close sends or spools any queued records and stops the shipper.
+func (s *shipper) Close() {
+	s.cancelFn()
+	<-s.doneCh
+	s.sink.Close()
+}
+
+func (s *shipper) run() {
+	defer close(s.doneCh)
+
+	batch := make([]*logRecord, 0, s.cfg.BatchSize)
+	timer := time.NewTimer(s.cfg.BatchWait)
+	defer timer.Stop()
+	retry := time.NewTicker(shipperRetryInterval)
+	defer retry.Stop()
+
+	flush := func() {
+		if len(batch) > 0 {
+			s.send(batch)
+			batch = make([]*logRecord, 0, s.cfg.BatchSize)
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(s.cfg.BatchWait)
+	}
+
+	for {
+		select {
+		case r := <-s.records:
+			batch = append(batch, r)
+			if len(batch) >= s.cfg.BatchSize {
+				flush()
+			}
+		case <-timer.C:
+			flush()
+		case <-retry.C:
+			if s.failing && s.drainSpool() {
+				s.logger.Debug("sent spooled log lines, sink recovered")
+				s.failing = false
+			}
+			if n := s.dropped.Swap(0); n > 0 {
+				s.logger.Warn("dropped log lines, queue is full", "lines", n)
+			}
+		case <-s.ctx.Done():
+			// Send the queued records, spooling them for the next shipper if
+			// the sink is failing
+			for len(s.records) > 0 {
+				batch = append(batch, <-s.records)
+			}
+			if len(batch) > 0 {
+				s.send(batch)
+			}
+			return
+		}
+	}
+}
+
+// send sends the batch once any older spooled batches are sent, spooling it
+// otherwise to keep lines in order. While the sink is failing batches are
+// spooled directly, so a hanging sink only delays the retries.
+func (s *shipper) send(batch []*logRecord) {
+	if !s.failing && s.drainSpool() {
+		err := s.sendBatch(batch)
+		if err == nil {
+			return
+		}
+		s.logger.Debug("failed to send log lines, spooling", "error", err)
+	}
+	s.failing = true
+
+	if err := s.spool.push(batch); err != nil {
+		s.logger.Warn("failed to spool log lines", "error", err)
+	}
+}
+
+// drainSpool sends spooled batches, returning whether the spool is empty.
+func (s *shipper) drainSpool() bool {
+	for {
+		batch, ok, err := s.spool.peek()
+		if err != nil {
+			// Unreadable batches can't be retried
+			s.logger.Warn("dropping unreadable spooled log lines", "error", err)
+			s.spool.pop()
+			continue
+		}
+		if !ok {
+			return true
+		}
+		if err := s.sendBatch(batch); err != nil {
+			s.logger.Debug("failed to send spooled log lines", "error", err)
+			return false
+		}
+		s.spool.pop()
+	}
+}
+
+func (s *shipper) sendBatch(batch []*logRecord) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sinkSendTimeout)
+	defer cancel()
+	return s.sink.Send(ctx, s.labels, batch)
+}
+
+// shippingWriter writes task output to a log file and frames it into lines
+// for the shippers.
+type shippingWriter struct {
+	io.WriteCloser
+
+	stream   string
+	shippers []*shipper
+	partial  []byte
+	lock     sync.Mutex
+}
+
+func newShippingWriter(w io.WriteCloser, stream string, shippers []*shipper) io.WriteCloser {
+	if len(shippers) == 0 {
+		return w
+	}
+	return &shippingWriter{WriteCloser: w, stream: stream, shippers: shippers}
+}
+
+func (w *shippingWriter) Write(p []byte) (int, error) {
+	n, err := w.WriteCloser.Write(p)
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	now := time.Now()
+	w.partial = append(w.partial, p[:n]...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			if len(w.partial) < maxLogLineSize {
+				break
+			}
+			i = maxLogLineSize
+		}
+		w.emit(now, w.partial[:i])
+		if i < len(w.partial) && w.partial[i] == '\n' {
+			i++
+		}
+		w.partial = w.partial[i:]
+	}
+
+	// Avoid holding on to a large buffer once it's consumed
+	if len(w.partial) == 0 {
+		w.partial = nil
+	}
+	return n, err
+}
+
+func (w *shippingWriter) emit(t time.Time, line []byte) {
+	r := &logRecord{
+		Time:   t,
+		Stream: w.stream,
+		Line:   string(bytes.TrimSuffix(line, []byte("\r"))),
+	}
+	for _, s := range w.shippers {
+		s.ship(r)
+	}
+}
+
+// Close ships any unterminated line and closes the log file.
+func (w *shippingWriter) Close() error {
+	w.lock.Lock()
+	if len(w.partial) > 0 {
+		w.emit(time.Now(), w.partial)
+		w.partial = nil
+	}
+	w.lock.Unlock()
+	return w.WriteCloser.Close()
+}
@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/fifo"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+// testSink records the batches it is sent, failing while fail is set.
+type testSink struct {
+	l        sync.Mutex
+	fail     bool
+	attempts int
+	batches  [][]*logRecord
+}
+
+func (s *testSink) Send(_ context.Context, _ map[string]string, records []*logRecord) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.attempts++
+	if s.fail {
+		return errors.New("unavailable")
+	}
+	s.batches = append(s.batches, records)
+	return nil
+}
+
+func (s *testSink) Close() error { return nil }
+
+func (s *testSink) setFail(fail bool) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.fail = fail
+}
+
+func (s *testSink) lines() []string {
+	s.l.Lock()
+	defer s.l.Unlock()
+	var lines []string
+	for _, batch := range s.batches {
+		for _, r := range batch {
+			lines = append(lines, r.Stream+":"+r.Line)
+		}
+	}
+	return lines
+}
+
+type nopWriteCloser struct{}
+
+func (nopWriteCloser) Write(p []byte) (int, error) { return len(p), nil }
+func (nopWriteCloser) Close() error                { return nil }
+
+func TestShipper_SpoolsWhileSinkFails(t *testing.T) {
+	ci.Parallel(t)
+
+	spool, err := newDiskSpool(t.TempDir(), 1024*1024)
+	must.NoError(t, err)
+	sink := &testSink{fail: true}
+	cfg := &SinkConfig{Name: "test", BatchSize: 2, BatchWait: time.Hour}
+	s := startShipper(cfg, sink, nil, spool, testlog.HCLogger(t))
+
+	w := newShippingWriter(nopWriteCloser{}, "stdout", []*shipper{s})
+	_, err = w.Write([]byte("one\ntwo\nthr"))
+	must.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		sink.l.Lock()
+		defer sink.l.Unlock()
+		return sink.attempts >= 1, nil
+	}, func(error) {
+		t.Fatal("batch was not sent")
+	})
+
+	// Once the sink recovers the spooled batch is sent before newer lines
+	sink.setFail(false)
+	_, err = w.Write([]byte("ee\r\nfour\n"))
+	must.NoError(t, err)
+
+	// Spooled batches are only retried every shipperRetryInterval
+	testutil.WaitForResultRetries(int64(2*shipperRetryInterval/(10*time.Millisecond)), func() (bool, error) {
+		return len(sink.lines()) == 4, nil
+	}, func(error) {
+		t.Fatalf("unexpected lines: %v", sink.lines())
+	})
+	must.Eq(t, []string{"stdout:one", "stdout:two", "stdout:three", "stdout:four"}, sink.lines())
+
+	// Unterminated lines are shipped on close
+	_, err = w.Write([]byte("five"))
+	must.NoError(t, err)
+	must.NoError(t, w.Close())
+	s.Close()
+	must.Eq(t, "stdout:five", sink.lines()[4])
+	must.Eq(t, 0, spool.batches())
+}
+
+func TestShipper_SpoolsDirectlyWhileFailing(t *testing.T) {
+	ci.Parallel(t)
+
+	spool, err := newDiskSpool(t.TempDir(), 1024*1024)
+	must.NoError(t, err)
+	sink := &testSink{fail: true}
+	cfg := &SinkConfig{Name: "test", BatchSize: 2, BatchWait: time.Hour}
+	s := startShipper(cfg, sink, nil, spool, testlog.HCLogger(t))
+
+	w := newShippingWriter(nopWriteCloser{}, "stdout", []*shipper{s})
+	_, err = w.Write([]byte("one\ntwo\nthree\nfour\n"))
+	must.NoError(t, err)
+	s.Close()
+
+	// Only the first batch was sent to the failing sink, the second was
+	// spooled without waiting on it
+	must.Eq(t, 1, sink.attempts)
+	must.Eq(t, 2, spool.batches())
+}
+
+func TestDiskSpool_Bounded(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	batch := func(line string) []*logRecord {
+		return []*logRecord{{Time: time.Unix(0, 0), Stream: "stdout", Line: line}}
+	}
+	b, err := json.Marshal(batch("aaaa"))
+	must.NoError(t, err)
+
+	// Room for two batches only
+	spool, err := newDiskSpool(dir, int64(len(b)*2))
+	must.NoError(t, err)
+	must.NoError(t, spool.push(batch("aaaa")))
+	must.NoError(t, spool.push(batch("bbbb")))
+	must.NoError(t, spool.push(batch("cccc")))
+	must.Eq(t, 2, spool.batches())
+
+	// Spooled batches are kept across restarts, oldest first
+	spool, err = newDiskSpool(dir, int64(len(b)*2))
+	must.NoError(t, err)
+	got, ok, err := spool.peek()
+	must.NoError(t, err)
+	must.True(t, ok)
+	must.Eq(t, "bbbb", got[0].Line)
+	spool.pop()
+	got, _, err = spool.peek()
+	must.NoError(t, err)
+	must.Eq(t, "cccc", got[0].Line)
+	spool.pop()
+	_, ok, err = spool.peek()
+	must.NoError(t, err)
+	must.False(t, ok)
+
+	// Spooling can be disabled
+	spool, err = newDiskSpool(dir, 0)
+	must.NoError(t, err)
+	must.Error(t, spool.push(batch("aaaa")))
+}
+
+func TestLogmon_Start_LokiSink(t *testing.T) {
+	ci.Parallel(t)
+
+	type push struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"streams"`
+	}
+	pushes := make(chan push, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		must.Eq(t, "/loki/api/v1/push", r.URL.Path)
+		var p push
+		must.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		pushes <- p
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	var stdoutFifoPath, stderrFifoPath string
+	if runtime.GOOS == "windows" {
+		stdoutFifoPath = "//./pipe/test-loki.stdout"
+		stderrFifoPath = "//./pipe/test-loki.stderr"
+	} else {
+		stdoutFifoPath = filepath.Join(dir, "stdout.fifo")
+		stderrFifoPath = filepath.Join(dir, "stderr.fifo")
+	}
+
+	cfg := &LogConfig{
+		LogDir:        dir,
+		StdoutLogFile: "stdout",
+		StdoutFifo:    stdoutFifoPath,
+		StderrLogFile: "stderr",
+		StderrFifo:    stderrFifoPath,
+		MaxFiles:      2,
+		MaxFileSizeMB: 1,
+		Sinks: []*SinkConfig{{
+			Name:        "loki",
+			Type:        structs.LogSinkTypeLoki,
+			Address:     srv.URL,
+			BatchSize:   10,
+			BatchWait:   50 * time.Millisecond,
+			SpoolSizeMB: 1,
+		}},
+		Labels:   map[string]string{"job": "example", "task": "web"},
+		SpoolDir: filepath.Join(dir, ".spool"),
+		SinkPolicy: &SinkPolicy{
+			AllowedTypes: []string{structs.LogSinkTypeLoki},
+			AllowedHosts: []string{"127.0.0.0/8"},
+		},
+	}
+
+	lm := NewLogMon(testlog.HCLogger(t))
+	must.NoError(t, lm.Start(cfg))
+	t.Cleanup(func() { lm.Stop() })
+
+	stdout, err := fifo.OpenWriter(stdoutFifoPath)
+	must.NoError(t, err)
+	_, err = stdout.Write([]byte("hello\nworld\n"))
+	must.NoError(t, err)
+
+	select {
+	case p := <-pushes:
+		must.Len(t, 1, p.Streams)
+		must.Eq(t, map[string]string{"job": "example", "task": "web", "stream": "stdout"}, p.Streams[0].Stream)
+		must.Len(t, 2, p.Streams[0].Values)
+		must.Eq(t, "hello", p.Streams[0].Values[0][1])
+		must.Eq(t, "world", p.Streams[0].Values[1][1])
+	case <-time.After(5 * time.Second):
+		t.Fatal("logs were not pushed to loki")
+	}
+}
@@ -0,0 +1,455 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// sinkSendTimeout is the timeout of sending a single batch to a sink
+	sinkSendTimeout = 10 * time.Second
+)
+
+// SinkConfig configures the forwarding of logs to an external system.
+type SinkConfig struct {
+	// Name uniquely identifies the sink within the task
+	Name string
+
+	// Type is the protocol used to forward logs
+	Type string
+
+	// Address is the URL or socket address of the sink
+	Address string
+
+	// BatchSize is the maximum number of lines sent at once
+	BatchSize int
+
+	// BatchWait is the maximum time lines are buffered before being sent
+	BatchWait time.Duration
+
+	// SpoolSizeMB is the maximum size of the on-disk spool of unsent batches
+	SpoolSizeMB int
+}
+
+// logRecord is a single line written by a task.
+type logRecord struct {
+	Time   time.Time `json:"t"`
+	Stream string    `json:"s"`
+	Line   string    `json:"l"`
+}
+
+// sink sends batches of log lines to an external system.
+type sink interface {
+	// Send sends the records, which share the given labels.
+	Send(ctx context.Context, labels map[string]string, records []*logRecord) error
+
+	// Close releases any connection held by the sink.
+	Close() error
+}
+
+// SinkPolicy restricts the destinations of sinks. Logmon connects to sinks
+// from the client host, so the client operator decides which systems job
+// authors may reach.
+type SinkPolicy struct {
+	// AllowedTypes are the sink types tasks may use
+	AllowedTypes []string
+
+	// AllowedHosts are the host names and CIDR blocks sinks may connect to.
+	// Any host is allowed if empty. Loopback, link-local and unspecified
+	// addresses are only allowed when covered by a CIDR block.
+	AllowedHosts []string
+
+	// AllowUnixSockets allows sinks to connect to unix sockets
+	AllowUnixSockets bool
+}
+
+// checkSink returns an error if the policy doesn't allow the sink's type or
+// address.
+func (p *SinkPolicy) checkSink(cfg *SinkConfig) error {
+	if p == nil || !slices.Contains(p.AllowedTypes, cfg.Type) {
+		return fmt.Errorf("sink type %q is not allowed by the client", cfg.Type)
+	}
+
+	var host string
+	switch cfg.Type {
+	case structs.LogSinkTypeLoki, structs.LogSinkTypeOTLP:
+		u, err := url.Parse(cfg.Address)
+		if err != nil {
+			return err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("unsupported scheme %q", u.Scheme)
+		}
+		host = u.Hostname()
+	default:
+		network, address, err := splitSocketAddress(cfg.Address, "tcp")
+		if err != nil {
+			return err
+		}
+		if network == "unix" {
+			if !p.AllowUnixSockets {
+				return fmt.Errorf("unix socket sinks are not allowed by the client")
+			}
+			return nil
+		}
+		host, _, err = net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !p.allowsHost(host) {
+		return fmt.Errorf("sink host %q is not allowed by the client", host)
+	}
+	return nil
+}
+
+// allowsHost returns whether the host name or IP is one of the allowed
+// hosts.
+func (p *SinkPolicy) allowsHost(host string) bool {
+	if len(p.AllowedHosts) == 0 {
+		return true
+	}
+	ip := net.ParseIP(host)
+	for _, allowed := range p.AllowedHosts {
+		if _, cidr, err := net.ParseCIDR(allowed); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+		} else if strings.EqualFold(allowed, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDial is the Control function of the sinks' dialers. It refuses
+// connections to local addresses, which host names may resolve to, unless a
+// CIDR block allows them.
+func (p *SinkPolicy) checkDial(network, address string, _ syscall.RawConn) error {
+	if network == "unix" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !(ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsUnspecified()) {
+		return nil
+	}
+	for _, allowed := range p.AllowedHosts {
+		if _, cidr, err := net.ParseCIDR(allowed); err == nil && cidr.Contains(ip) {
+			return nil
+		}
+	}
+	return fmt.Errorf("connecting to local address %s is not allowed by the client", ip)
+}
+
+// newSink returns the sink for the configured type, once the policy allows
+// it.
+func newSink(cfg *SinkConfig, policy *SinkPolicy) (sink, error) {
+	if err := policy.checkSink(cfg); err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Control: policy.checkDial}
+
+	switch cfg.Type {
+	case structs.LogSinkTypeSyslog:
+		return newSyslogSink(cfg.Address, dialer)
+	case structs.LogSinkTypeLoki:
+		return newHTTPSink(strings.TrimSuffix(cfg.Address, "/")+"/loki/api/v1/push", encodeLoki, dialer), nil
+	case structs.LogSinkTypeOTLP:
+		return newHTTPSink(strings.TrimSuffix(cfg.Address, "/")+"/v1/logs", encodeOTLP, dialer), nil
+	case structs.LogSinkTypeFluent:
+		return newFluentSink(cfg.Address, dialer)
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", cfg.Type)
+	}
+}
+
+// splitSocketAddress splits an address such as tcp://host:port into its
+// network and address. Addresses without a scheme use the default network.
+func splitSocketAddress(addr, defaultNetwork string) (string, string, error) {
+	if !strings.Contains(addr, "://") {
+		return defaultNetwork, addr, nil
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		return "", "", err
+	}
+	switch u.Scheme {
+	case "tcp", "udp":
+		return u.Scheme, u.Host, nil
+	case "unix":
+		return u.Scheme, u.Path, nil
+	default:
+		return "", "", fmt.Errorf("unsupported network %q", u.Scheme)
+	}
+}
+
+// connSink is the base of sinks writing to a socket. The connection is
+// opened on first use and reopened after any error.
+type connSink struct {
+	network string
+	address string
+	dialer  *net.Dialer
+	conn    net.Conn
+}
+
+func (s *connSink) write(ctx context.Context, b []byte) error {
+	if s.conn == nil {
+		conn, err := s.dialer.DialContext(ctx, s.network, s.address)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	deadline, _ := ctx.Deadline()
+	s.conn.SetWriteDeadline(deadline)
+	if _, err := s.conn.Write(b); err != nil {
+		s.Close()
+		return err
+	}
+	return nil
+}
+
+func (s *connSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// syslogSink sends RFC 5424 messages. Over TCP messages are framed with
+// their length as described by RFC 6587.
+type syslogSink struct {
+	connSink
+	hostname string
+}
+
+func newSyslogSink(addr string, dialer *net.Dialer) (*syslogSink, error) {
+	network, address, err := splitSocketAddress(addr, "udp")
+	if err != nil {
+		return nil, err
+	}
+	hostname, _ := os.Hostname()
+	return &syslogSink{
+		connSink: connSink{network: network, address: address, dialer: dialer},
+		hostname: hostname,
+	}, nil
+}
+
+func (s *syslogSink) Send(ctx context.Context, labels map[string]string, records []*logRecord) error {
+	// Labels are sent as structured data, sorted to keep messages stable
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sd strings.Builder
+	sd.WriteString("[nomad@48524")
+	for _, k := range keys {
+		v := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(labels[k])
+		fmt.Fprintf(&sd, ` %s="%s"`, k, v)
+	}
+	sd.WriteString("]")
+
+	appName := labels["task"]
+	if appName == "" {
+		appName = "-"
+	}
+
+	for _, r := range records {
+		// Facility user, with stderr at error severity and stdout at info
+		pri := 14
+		if r.Stream == "stderr" {
+			pri = 11
+		}
+		msg := fmt.Sprintf("<%d>1 %s %s %s - %s %s %s",
+			pri, r.Time.UTC().Format(time.RFC3339Nano), s.hostname, appName, r.Stream, sd.String(), r.Line)
+		if s.network != "udp" {
+			msg = strconv.Itoa(len(msg)) + " " + msg
+		}
+		if err := s.write(ctx, []byte(msg)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fluentSink sends logs using the forward mode of the Fluentd forward
+// protocol, tagged nomad.<job>.<task>.
+type fluentSink struct {
+	connSink
+}
+
+func newFluentSink(addr string, dialer *net.Dialer) (*fluentSink, error) {
+	network, address, err := splitSocketAddress(addr, "tcp")
+	if err != nil {
+		return nil, err
+	}
+	if network == "udp" {
+		return nil, fmt.Errorf("fluent sinks don't support udp")
+	}
+	return &fluentSink{connSink{network: network, address: address, dialer: dialer}}, nil
+}
+
+func (s *fluentSink) Send(ctx context.Context, labels map[string]string, records []*logRecord) error {
+	entries := make([]interface{}, 0, len(records))
+	for _, r := range records {
+		record := make(map[string]string, len(labels)+2)
+		for k, v := range labels {
+			record[k] = v
+		}
+		record["stream"] = r.Stream
+		record["log"] = r.Line
+		entries = append(entries, []interface{}{r.Time.Unix(), record})
+	}
+	tag := fmt.Sprintf("nomad.%s.%s", labels["job"], labels["task"])
+
+	var buf bytes.Buffer
+	if err := codec.NewEncoder(&buf, &codec.MsgpackHandle{WriteExt: true}).Encode([]interface{}{tag, entries}); err != nil {
+		return err
+	}
+	return s.write(ctx, buf.Bytes())
+}
+
+// httpSink posts batches encoded as JSON to an HTTP endpoint.
+type httpSink struct {
+	url    string
+	client http.Client
+	encode func(labels map[string]string, records []*logRecord) interface{}
+}
+
+func newHTTPSink(url string, encode func(map[string]string, []*logRecord) interface{}, dialer *net.Dialer) *httpSink {
+	return &httpSink{
+		url: url,
+		client: http.Client{
+			// Every connection goes through the dialer checking the sink
+			// policy, so neither proxies nor redirects are followed
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		encode: encode,
+	}
+}
+
+func (s *httpSink) Send(ctx context.Context, labels map[string]string, records []*logRecord) error {
+	body, err := json.Marshal(s.encode(labels, records))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected response %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// encodeLoki encodes records for the Loki push API, with one stream per
+// task output.
+func encodeLoki(labels map[string]string, records []*logRecord) interface{} {
+	type stream struct {
+		Stream map[string]string `json:"stream"`
+		Values [][2]string       `json:"values"`
+	}
+
+	streams := make(map[string]*stream, 2)
+	var out []*stream
+	for _, r := range records {
+		st, ok := streams[r.Stream]
+		if !ok {
+			st = &stream{Stream: map[string]string{"stream": r.Stream}}
+			for k, v := range labels {
+				st.Stream[k] = v
+			}
+			streams[r.Stream] = st
+			out = append(out, st)
+		}
+		st.Values = append(st.Values, [2]string{strconv.FormatInt(r.Time.UnixNano(), 10), r.Line})
+	}
+	return map[string]interface{}{"streams": out}
+}
+
+// encodeOTLP encodes records as an OTLP/HTTP logs export request, with the
+// labels as resource attributes.
+func encodeOTLP(labels map[string]string, records []*logRecord) interface{} {
+	attr := func(k, v string) map[string]interface{} {
+		return map[string]interface{}{"key": k, "value": map[string]string{"stringValue": v}}
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	resource := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		resource = append(resource, attr("nomad."+k, labels[k]))
+	}
+
+	logs := make([]interface{}, 0, len(records))
+	for _, r := range records {
+		severity, severityText := 9, "INFO"
+		if r.Stream == "stderr" {
+			severity, severityText = 17, "ERROR"
+		}
+		logs = append(logs, map[string]interface{}{
+			"timeUnixNano":   strconv.FormatInt(r.Time.UnixNano(), 10),
+			"severityNumber": severity,
+			"severityText":   severityText,
+			"body":           map[string]string{"stringValue": r.Line},
+			"attributes":     []interface{}{attr("log.iostream", r.Stream)},
+		})
+	}
+
+	return map[string]interface{}{
+		"resourceLogs": []interface{}{map[string]interface{}{
+			"resource":  map[string]interface{}{"attributes": resource},
+			"scopeLogs": []interface{}{map[string]interface{}{"logRecords": logs}},
+		}},
+	}
+}
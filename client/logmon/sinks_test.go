@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestSinkPolicy_CheckSink(t *testing.T) {
+	ci.Parallel(t)
+
+	allTypes := []string{
+		structs.LogSinkTypeSyslog,
+		structs.LogSinkTypeLoki,
+		structs.LogSinkTypeOTLP,
+		structs.LogSinkTypeFluent,
+	}
+
+	cases := []struct {
+		name   string
+		policy *SinkPolicy
+		sink   *SinkConfig
+		expErr string
+	}{
+		{
+			name:   "no policy",
+			policy: nil,
+			sink:   &SinkConfig{Type: structs.LogSinkTypeLoki, Address: "http://loki:3100"},
+			expErr: `sink type "loki" is not allowed`,
+		},
+		{
+			name:   "type not allowed",
+			policy: &SinkPolicy{AllowedTypes: []string{structs.LogSinkTypeOTLP}},
+			sink:   &SinkConfig{Type: structs.LogSinkTypeLoki, Address: "http://loki:3100"},
+			expErr: `sink type "loki" is not allowed`,
+		},
+		{
+			name:   "any host",
+			policy: &SinkPolicy{AllowedTypes: allTypes},
+			sink:   &SinkConfig{Type: structs.LogSinkTypeSyslog, Address: "tcp://logs.example.com:514"},
+		},
+		{
+			name:   "unix socket",
+			policy: &SinkPolicy{AllowedTypes: allTypes},
+			sink:   &SinkConfig{Type: structs.LogSinkTypeFluent, Address: "unix:///var/run/docker.sock"},
+			expErr: "unix socket sinks are not allowed",
+		},
+		{
+			name:   "unix socket allowed",
+			policy: &SinkPolicy{AllowedTypes: allTypes, AllowUnixSockets: true},
+			sink:   &SinkConfig{Type: structs.LogSinkTypeFluent, Address: "unix:///var/run/fluent.sock"},
+		},
+		{
+			name:   "host name allowed",
+			policy: &SinkPolicy{AllowedTypes: allTypes, AllowedHosts: []string{"Loki.example.com"}},
+			sink:   &SinkConfig{Type: structs.LogSinkTypeLoki, Address: "https://loki.example.com"},
+		},
+		{
+			name:   "host name not allowed",
+			policy: &SinkPolicy{AllowedTypes: allTypes, AllowedHosts: []string{"loki.example.com"}},
+			sink:   &SinkConfig{Type: structs.LogSinkTypeOTLP, Address: "http://169.254.169.254"},
+			expErr: `sink host "169.254.169.254" is not allowed`,
+		},
+		{
+			name:   "cidr allowed",
+			policy: &SinkPolicy{AllowedTypes: allTypes, AllowedHosts: []string{"10.0.0.0/8"}},
+			sink:   &SinkConfig{Type: structs.LogSinkTypeSyslog, Address: "10.1.2.3:514"},
+		},
+		{
+			name:   "http scheme",
+			policy: &SinkPolicy{AllowedTypes: allTypes},
+			sink:   &SinkConfig{Type: structs.LogSinkTypeLoki, Address: "file:///etc/passwd"},
+			expErr: `unsupported scheme "file"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.checkSink(tc.sink)
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+func TestSinkPolicy_CheckDial(t *testing.T) {
+	ci.Parallel(t)
+
+	policy := &SinkPolicy{}
+	must.NoError(t, policy.checkDial("tcp4", "10.1.2.3:514", nil))
+	must.NoError(t, policy.checkDial("unix", "/var/run/fluent.sock", nil))
+	must.ErrorContains(t, policy.checkDial("tcp4", "127.0.0.1:8080", nil), "not allowed")
+	must.ErrorContains(t, policy.checkDial("tcp6", "[::1]:8080", nil), "not allowed")
+	must.ErrorContains(t, policy.checkDial("tcp4", "169.254.169.254:80", nil), "not allowed")
+	must.ErrorContains(t, policy.checkDial("udp4", "0.0.0.0:514", nil), "not allowed")
+
+	// Local addresses are allowed when a CIDR block covers them
+	policy.AllowedHosts = []string{"127.0.0.0/8"}
+	must.NoError(t, policy.checkDial("tcp4", "127.0.0.1:8080", nil))
+	must.ErrorContains(t, policy.checkDial("tcp6", "[::1]:8080", nil), "not allowed")
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package logmon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const spoolFileExt = ".batch"
+
+// diskSpool is a FIFO queue of log batches stored as files in a directory.
+// Once the spool holds more than maxBytes, the oldest batches are dropped.
+// The spool is only used by a single goroutine.
+type diskSpool struct {
+	dir      string
+	maxBytes int64
+
+	// files are the sequence numbers of the spooled batches, oldest first
+	files []uint64
+	sizes map[uint64]int64
+	size  int64
+	next  uint64
+}
+
+// newDiskSpool opens the spool in dir, creating it if needed. Batches spooled
+// by a previous logmon are kept.
+func newDiskSpool(dir string, maxBytes int64) (*diskSpool, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spool directory: %w", err)
+	}
+
+	s := &diskSpool{
+		dir:      dir,
+		maxBytes: maxBytes,
+		sizes:    make(map[uint64]int64),
+	}
+	for _, entry := range entries {
+		seq, err := strconv.ParseUint(strings.TrimSuffix(entry.Name(), spoolFileExt), 10, 64)
+		if err != nil || !strings.HasSuffix(entry.Name(), spoolFileExt) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		s.files = append(s.files, seq)
+		s.sizes[seq] = info.Size()
+		s.size += info.Size()
+		s.next = max(s.next, seq+1)
+	}
+	sort.Slice(s.files, func(i, j int) bool { return s.files[i] < s.files[j] })
+	s.trim()
+	return s, nil
+}
+
+func (s *diskSpool) path(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, spoolFileExt))
+}
+
+// push spools the batch, dropping the oldest batches if the spool is full.
+func (s *diskSpool) push(batch []*logRecord) error {
+	if s.maxBytes <= 0 {
+		return fmt.Errorf("spool is disabled, dropping %d lines", len(batch))
+	}
+
+	b, err := json.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	if int64(len(b)) > s.maxBytes {
+		return fmt.Errorf("batch of %d bytes is larger than the spool", len(b))
+	}
+
+	seq := s.next
+	if err := os.WriteFile(s.path(seq), b, 0o600); err != nil {
+		return err
+	}
+	s.next++
+	s.files = append(s.files, seq)
+	s.sizes[seq] = int64(len(b))
+	s.size += int64(len(b))
+	s.trim()
+	return nil
+}
+
+// peek returns the oldest batch, if any.
+func (s *diskSpool) peek() ([]*logRecord, bool, error) {
+	if len(s.files) == 0 {
+		return nil, false, nil
+	}
+
+	b, err := os.ReadFile(s.path(s.files[0]))
+	if err != nil {
+		return nil, false, err
+	}
+	var batch []*logRecord
+	if err := json.Unmarshal(b, &batch); err != nil {
+		return nil, false, err
+	}
+	return batch, true, nil
+}
+
+// pop removes the oldest batch.
+func (s *diskSpool) pop() {
+	if len(s.files) == 0 {
+		return
+	}
+	seq := s.files[0]
+	os.Remove(s.path(seq))
+	s.size -= s.sizes[seq]
+	delete(s.sizes, seq)
+	s.files = s.files[1:]
+}
+
+// trim drops the oldest batches until the spool fits in its maximum size.
+func (s *diskSpool) trim() {
+	for s.size > s.maxBytes && len(s.files) > 0 {
+		s.pop()
+	}
+}
+
+// batches returns the number of spooled batches.
+func (s *diskSpool) batches() int {
+	return len(s.files)
+}
@@ -314,6 +314,22 @@ func (c *Client) updateNodeFromDevicesLocked(devices []*structs.NodeDeviceResour
 	return false
 }
 
+// updateNodeFromHostVolume adds or removes a dynamic host volume on the node
+// and sends the update to the server. A nil volume removes it.
+func (c *Client) updateNodeFromHostVolume(name string, volume *structs.ClientHostVolumeConfig) {
+	c.UpdateNode(func(node *structs.Node) {
+		if volume == nil {
+			delete(node.HostVolumes, name)
+			return
+		}
+		if node.HostVolumes == nil {
+			node.HostVolumes = make(map[string]*structs.ClientHostVolumeConfig)
+		}
+		node.HostVolumes[name] = volume
+	})
+	c.updateNode()
+}
+
 // batchNodeUpdates allows for batching multiple Node updates from fingerprinting.
 // Once ready, the batches can be flushed and toggled to stop batching and forward
 // all updates to a configured callback to be performed incrementally
@@ -57,6 +57,9 @@ nodemeta/
 
 node/
 |--> registration -> *cstructs.NodeRegistration
+
+host_volumes/
+|--> <volume id> -> *cstructs.HostVolumeState
 */
 
 var (
@@ -138,6 +141,10 @@ var (
 
 	// nodeRegistrationKey is the key at which node registration data is stored.
 	nodeRegistrationKey = []byte("node_registration")
+
+	// hostVolBucket is the bucket name in which dynamic host volumes are
+	// stored, keyed by volume ID.
+	hostVolBucket = []byte("host_volumes")
 )
 
 // taskBucketName returns the bucket name for the given task name.
@@ -1048,6 +1055,40 @@ func (s *BoltStateDB) GetNodeRegistration() (*cstructs.NodeRegistration, error)
 	return &reg, err
 }
 
+func (s *BoltStateDB) PutDynamicHostVolume(vol *cstructs.HostVolumeState) error {
+	return s.db.Update(func(tx *boltdd.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(hostVolBucket)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(vol.ID), vol)
+	})
+}
+
+func (s *BoltStateDB) GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error) {
+	var vols []*cstructs.HostVolumeState
+	err := s.db.View(func(tx *boltdd.Tx) error {
+		b := tx.Bucket(hostVolBucket)
+		if b == nil {
+			return nil
+		}
+		return boltdd.Iterate(b, nil, func(_ []byte, vol cstructs.HostVolumeState) {
+			vols = append(vols, &vol)
+		})
+	})
+	return vols, err
+}
+
+func (s *BoltStateDB) DeleteDynamicHostVolume(id string) error {
+	return s.db.Update(func(tx *boltdd.Tx) error {
+		b := tx.Bucket(hostVolBucket)
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
 // init initializes metadata entries in a newly created state database.
 func (s *BoltStateDB) init() error {
 	return s.db.Update(func(tx *boltdd.Tx) error {
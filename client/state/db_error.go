@@ -154,6 +154,18 @@ func (m *ErrDB) GetNodeRegistration() (*cstructs.NodeRegistration, error) {
 	return nil, fmt.Errorf("Error!")
 }
 
+func (m *ErrDB) PutDynamicHostVolume(_ *cstructs.HostVolumeState) error {
+	return fmt.Errorf("Error!")
+}
+
+func (m *ErrDB) GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error) {
+	return nil, fmt.Errorf("Error!")
+}
+
+func (m *ErrDB) DeleteDynamicHostVolume(_ string) error {
+	return fmt.Errorf("Error!")
+}
+
 func (m *ErrDB) Close() error {
 	return fmt.Errorf("Error!")
 }
@@ -60,6 +60,9 @@ type MemDB struct {
 
 	nodeRegistration *cstructs.NodeRegistration
 
+	// volume id -> dynamic host volume
+	hostVolumes map[string]*cstructs.HostVolumeState
+
 	logger hclog.Logger
 
 	mu sync.RWMutex
@@ -76,6 +79,7 @@ func NewMemDB(logger hclog.Logger) *MemDB {
 		taskState:         make(map[string]map[string]*structs.TaskState),
 		checks:            make(checks.ClientResults),
 		identities:        make(map[string][]*structs.SignedWorkloadIdentity),
+		hostVolumes:       make(map[string]*cstructs.HostVolumeState),
 		logger:            logger,
 	}
 }
@@ -354,6 +358,30 @@ func (m *MemDB) GetNodeRegistration() (*cstructs.NodeRegistration, error) {
 	return m.nodeRegistration, nil
 }
 
+func (m *MemDB) PutDynamicHostVolume(vol *cstructs.HostVolumeState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hostVolumes[vol.ID] = vol
+	return nil
+}
+
+func (m *MemDB) GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	vols := make([]*cstructs.HostVolumeState, 0, len(m.hostVolumes))
+	for _, vol := range m.hostVolumes {
+		vols = append(vols, vol)
+	}
+	return vols, nil
+}
+
+func (m *MemDB) DeleteDynamicHostVolume(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.hostVolumes, id)
+	return nil
+}
+
 func (m *MemDB) Close() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
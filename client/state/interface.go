@@ -137,6 +137,18 @@ type StateDB interface {
 	PutNodeRegistration(*cstructs.NodeRegistration) error
 	GetNodeRegistration() (*cstructs.NodeRegistration, error)
 
+	// PutDynamicHostVolume stores a dynamic host volume provisioned by the
+	// client.
+	PutDynamicHostVolume(*cstructs.HostVolumeState) error
+
+	// GetDynamicHostVolumes is used to restore the dynamic host volumes
+	// provisioned by the client.
+	GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error)
+
+	// DeleteDynamicHostVolume removes the dynamic host volume with the given
+	// ID.
+	DeleteDynamicHostVolume(string) error
+
 	// Close the database. Unsafe for further use after calling regardless
 	// of return value.
 	Close() error
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+// ClientHostVolumeCreateRequest is used to provision a dynamic host volume on
+// a client.
+type ClientHostVolumeCreateRequest struct {
+	// ID is the ID of the volume, which plugins use to identify it.
+	ID string
+
+	// Name is the name the volume is fingerprinted with on the node.
+	Name string
+
+	// PluginID is the host volume plugin that provisions the volume.
+	PluginID string
+
+	// NodeID is the node the volume is provisioned on, used to route the
+	// request.
+	NodeID string
+
+	// RequestedCapacityMinBytes and RequestedCapacityMaxBytes are the
+	// capacity requested from the plugin.
+	RequestedCapacityMinBytes int64
+	RequestedCapacityMaxBytes int64
+
+	// Parameters are passed to the plugin as-is.
+	Parameters map[string]string
+}
+
+// ClientHostVolumeCreateResponse is the response to a host volume create
+// request.
+type ClientHostVolumeCreateResponse struct {
+	// HostPath is the path of the volume on the node.
+	HostPath string
+
+	// CapacityBytes is the capacity of the volume reported by the plugin.
+	CapacityBytes int64
+}
+
+// ClientHostVolumeDeleteRequest is used to remove a dynamic host volume from
+// a client.
+type ClientHostVolumeDeleteRequest struct {
+	ID       string
+	Name     string
+	PluginID string
+	NodeID   string
+
+	// HostPath is the path of the volume on the node, as reported by the
+	// plugin when the volume was created.
+	HostPath string
+
+	Parameters map[string]string
+}
+
+// ClientHostVolumeDeleteResponse is the response to a host volume delete
+// request.
+type ClientHostVolumeDeleteResponse struct{}
+
+// HostVolumeState is the client's record of a dynamic host volume it
+// provisioned, persisted so the volume can be restored when the client
+// restarts.
+type HostVolumeState struct {
+	ID        string
+	CreateReq *ClientHostVolumeCreateRequest
+
+	// HostPath and CapacityBytes are the values reported by the plugin.
+	HostPath      string
+	CapacityBytes int64
+}
@@ -164,6 +164,68 @@ type FsLogsRequest struct {
 	structs.QueryOptions
 }
 
+// FsLogsSearchRequest is used to search the logs of a task for lines matching
+// a regular expression.
+type FsLogsSearchRequest struct {
+	// AllocID is the allocation to search the logs of
+	AllocID string
+
+	// Task is the task to search the logs of
+	Task string
+
+	// LogType restricts the search to "stderr" or "stdout". Both are searched
+	// if empty.
+	LogType string
+
+	// Pattern is the regular expression lines are matched against
+	Pattern string
+
+	// Since skips the log files last written before it, if set. Lines aren't
+	// timestamped so matches written shortly before Since may be returned.
+	Since time.Time
+
+	// Context is the number of lines returned before and after each match
+	Context int
+
+	// Limit is the maximum number of matches returned
+	Limit int
+
+	structs.QueryOptions
+}
+
+// FsLogsSearchResponse is used to return the lines matching a log search.
+type FsLogsSearchResponse struct {
+	// Matches are the matching lines, ordered by log type and then by the
+	// order they were written in
+	Matches []*LogSearchMatch
+
+	// Truncated is set if more matches than the limit were found, or if the
+	// search stopped after reading the maximum amount of log content
+	Truncated bool
+
+	structs.QueryMeta
+}
+
+// LogSearchMatch is a log line matching a search.
+type LogSearchMatch struct {
+	// File is the name of the log file in the allocation's log directory
+	File string
+
+	// LogType is either "stderr" or "stdout"
+	LogType string
+
+	// Offset is the offset of the line in the file. The offset of a line in
+	// a compressed file applies to its decompressed content.
+	Offset int64
+
+	// Line is the matching line, without its newline
+	Line string
+
+	// Before and After are the lines surrounding the match in the same file
+	Before []string
+	After  []string
+}
+
 // StreamErrWrapper is used to serialize output of a stream of a file or logs.
 type StreamErrWrapper struct {
 	// Error stores any error that may have occurred.
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// taskUsageKey identifies a task of an allocation.
+type taskUsageKey struct {
+	allocID string
+	task    string
+}
+
+// taskUsageSamples are the usage samples of a task since the last report.
+type taskUsageSamples struct {
+	timestamp  int64
+	cpuMHz     []float64
+	peakMemory uint64
+}
+
+// taskUsageTracker collects the resource usage of running tasks between two
+// reports to the servers, which use them to recommend task resources.
+type taskUsageTracker struct {
+	samples map[taskUsageKey]*taskUsageSamples
+	l       sync.Mutex
+}
+
+func newTaskUsageTracker() *taskUsageTracker {
+	return &taskUsageTracker{
+		samples: make(map[taskUsageKey]*taskUsageSamples),
+	}
+}
+
+// Add records a sample of the usage of each task of the allocation. Stats that
+// were already sampled are skipped, since tasks collect stats on their own
+// interval.
+func (t *taskUsageTracker) Add(allocID string, usage *cstructs.AllocResourceUsage) {
+	if usage == nil {
+		return
+	}
+
+	t.l.Lock()
+	defer t.l.Unlock()
+
+	for task, tu := range usage.Tasks {
+		if tu == nil || tu.ResourceUsage == nil ||
+			tu.ResourceUsage.CpuStats == nil || tu.ResourceUsage.MemoryStats == nil {
+			continue
+		}
+
+		key := taskUsageKey{allocID, task}
+		s, ok := t.samples[key]
+		if !ok {
+			s = &taskUsageSamples{}
+			t.samples[key] = s
+		}
+		if tu.Timestamp != 0 && tu.Timestamp == s.timestamp {
+			continue
+		}
+		s.timestamp = tu.Timestamp
+
+		// Not all drivers measure RSS
+		mem := tu.ResourceUsage.MemoryStats.RSS
+		if mem == 0 {
+			mem = tu.ResourceUsage.MemoryStats.Usage
+		}
+		s.cpuMHz = append(s.cpuMHz, tu.ResourceUsage.CpuStats.TotalTicks)
+		s.peakMemory = max(s.peakMemory, mem)
+	}
+}
+
+// Report returns the 95th percentile of the CPU usage and the peak memory
+// usage of each task since the last report, and starts over.
+func (t *taskUsageTracker) Report() []*structs.TaskUsage {
+	t.l.Lock()
+	samples := t.samples
+	t.samples = make(map[taskUsageKey]*taskUsageSamples)
+	t.l.Unlock()
+
+	usage := make([]*structs.TaskUsage, 0, len(samples))
+	for key, s := range samples {
+		if len(s.cpuMHz) == 0 {
+			continue
+		}
+		slices.Sort(s.cpuMHz)
+
+		// Nearest-rank percentile
+		rank := int(math.Ceil(0.95*float64(len(s.cpuMHz)))) - 1
+		usage = append(usage, &structs.TaskUsage{
+			AllocID:  key.allocID,
+			Task:     key.task,
+			CPU:      s.cpuMHz[rank],
+			MemoryMB: float64(s.peakMemory) / 1024 / 1024,
+			Samples:  len(s.cpuMHz),
+		})
+	}
+
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].AllocID != usage[j].AllocID {
+			return usage[i].AllocID < usage[j].AllocID
+		}
+		return usage[i].Task < usage[j].Task
+	})
+	return usage
+}
+
+// sampleTaskUsage records the latest usage of the tasks of the running
+// allocations.
+func (c *Client) sampleTaskUsage() {
+	for id, ar := range c.getAllocRunners() {
+		if ar.AllocState().ClientStatus != structs.AllocClientStatusRunning {
+			continue
+		}
+		usage, err := ar.StatsReporter().LatestAllocStats("")
+		if err != nil {
+			continue
+		}
+		c.taskUsageTracker.Add(id, usage)
+	}
+}
+
+// reportTaskUsage periodically reports the usage of the running tasks to the
+// servers until the client is shut down. It returns right away if reporting
+// is disabled.
+func (c *Client) reportTaskUsage() {
+	interval := c.GetConfig().TaskUsageReportInterval
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.shutdownCh:
+			return
+		case <-ticker.C:
+		}
+
+		usage := c.taskUsageTracker.Report()
+		if len(usage) == 0 {
+			continue
+		}
+
+		req := structs.TaskUsageReportRequest{
+			NodeID: c.NodeID(),
+			Usage:  usage,
+			WriteRequest: structs.WriteRequest{
+				Region:    c.Region(),
+				AuthToken: c.secretNodeID(),
+			},
+		}
+		var resp structs.GenericResponse
+		if err := c.RPC("Node.ReportTaskUsage", &req, &resp); err != nil {
+			c.logger.Warn("failed to report task usage", "error", err)
+		}
+	}
+}
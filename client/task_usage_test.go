@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/shoenig/test/must"
+)
+
+func testTaskUsage(ts int64, cpu float64, rss, usage uint64) *cstructs.AllocResourceUsage {
+	return &cstructs.AllocResourceUsage{
+		Tasks: map[string]*cstructs.TaskResourceUsage{
+			"web": {
+				Timestamp: ts,
+				ResourceUsage: &cstructs.ResourceUsage{
+					CpuStats:    &cstructs.CpuStats{TotalTicks: cpu},
+					MemoryStats: &cstructs.MemoryStats{RSS: rss, Usage: usage},
+				},
+			},
+		},
+	}
+}
+
+func TestTaskUsageTracker_Report(t *testing.T) {
+	ci.Parallel(t)
+
+	tracker := newTaskUsageTracker()
+	must.SliceEmpty(t, tracker.Report())
+
+	for i := 1; i <= 20; i++ {
+		tracker.Add("alloc1", testTaskUsage(int64(i), float64(i*10), uint64(i)<<20, 0))
+	}
+
+	// Stats that were already sampled are skipped
+	tracker.Add("alloc1", testTaskUsage(20, 1000, 100<<20, 0))
+
+	// Usage is used when RSS isn't measured
+	tracker.Add("alloc2", testTaskUsage(1, 50, 0, 64<<20))
+
+	usage := tracker.Report()
+	must.Len(t, 2, usage)
+
+	must.Eq(t, "alloc1", usage[0].AllocID)
+	must.Eq(t, "web", usage[0].Task)
+	must.Eq(t, 190, usage[0].CPU)
+	must.Eq(t, 20, usage[0].MemoryMB)
+	must.Eq(t, 20, usage[0].Samples)
+
+	must.Eq(t, "alloc2", usage[1].AllocID)
+	must.Eq(t, 50, usage[1].CPU)
+	must.Eq(t, 64, usage[1].MemoryMB)
+	must.Eq(t, 1, usage[1].Samples)
+
+	// Reporting starts over
+	must.SliceEmpty(t, tracker.Report())
+}
@@ -579,6 +579,24 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 	}
 
+	// Set the event log configuration.
+	if eventLogConf := agentConfig.Server.EventLog; eventLogConf != nil {
+		if eventLogConf.Enabled != nil {
+			conf.EventLogEnabled = *eventLogConf.Enabled
+		}
+
+		if eventLogConf.MaxSizeMB < 0 {
+			return nil, fmt.Errorf("event_log.max_size_mb must be greater than or equal to 0")
+		} else if eventLogConf.MaxSizeMB > 0 {
+			conf.EventLogMaxBytes = int64(eventLogConf.MaxSizeMB) * 1024 * 1024
+		}
+
+		if eventLogConf.MaxAge < 0 {
+			return nil, fmt.Errorf("event_log.max_age must be greater than or equal to 0")
+		}
+		conf.EventLogMaxAge = eventLogConf.MaxAge
+	}
+
 	// Add Enterprise license configs
 	conf.LicenseConfig = &nomad.LicenseConfig{
 		BuildDate:         agentConfig.Version.BuildDate,
@@ -724,6 +742,8 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 		conf.AllocDir = filepath.Join(agentConfig.DataDir, "alloc")
 		dataParent := filepath.Dir(agentConfig.DataDir)
 		conf.AllocMountsDir = filepath.Join(dataParent, "alloc_mounts")
+		conf.HostVolumesDir = filepath.Join(agentConfig.DataDir, "host_volumes")
+		conf.HostVolumePluginDir = filepath.Join(agentConfig.DataDir, "host_volume_plugins")
 	}
 	if agentConfig.Client.StateDir != "" {
 		conf.StateDir = agentConfig.Client.StateDir
@@ -734,6 +754,12 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	if agentConfig.Client.AllocMountsDir != "" {
 		conf.AllocMountsDir = agentConfig.Client.AllocMountsDir
 	}
+	if agentConfig.Client.HostVolumesDir != "" {
+		conf.HostVolumesDir = agentConfig.Client.HostVolumesDir
+	}
+	if agentConfig.Client.HostVolumePluginDir != "" {
+		conf.HostVolumePluginDir = agentConfig.Client.HostVolumePluginDir
+	}
 	if agentConfig.Client.NetworkInterface != "" {
 		conf.NetworkInterface = agentConfig.Client.NetworkInterface
 	}
@@ -870,6 +896,9 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.GCDiskUsageThreshold = agentConfig.Client.GCDiskUsageThreshold
 	conf.GCInodeUsageThreshold = agentConfig.Client.GCInodeUsageThreshold
 	conf.GCMaxAllocs = agentConfig.Client.GCMaxAllocs
+
+	conf.UtilizationReportInterval = agentConfig.Client.UtilizationReportInterval
+	conf.TaskUsageReportInterval = agentConfig.Client.TaskUsageReportInterval
 	if agentConfig.Client.NoHostUUID != nil {
 		conf.NoHostUUID = *agentConfig.Client.NoHostUUID
 	} else {
@@ -913,6 +942,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.Drain = drainConfig
 
 	conf.Users = clientconfig.UsersConfigFromAgent(agentConfig.Client.Users)
+	conf.LogSinks = clientconfig.LogSinksConfigFromAgent(agentConfig.Client.LogSinks)
 
 	return conf, nil
 }
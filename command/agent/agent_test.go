@@ -447,6 +447,68 @@ func TestAgent_ServerConfig_PlanRejectionTracker(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_EventLog(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name           string
+		eventLogConfig *EventLog
+		expectEnabled  bool
+		expectMaxBytes int64
+		expectMaxAge   time.Duration
+		expectedErr    string
+	}{
+		{
+			name:           "default",
+			expectMaxBytes: 1024 * 1024 * 1024,
+		},
+		{
+			name: "valid config",
+			eventLogConfig: &EventLog{
+				Enabled:   pointer.Of(true),
+				MaxSizeMB: 10,
+				MaxAge:    time.Hour,
+			},
+			expectEnabled:  true,
+			expectMaxBytes: 10 * 1024 * 1024,
+			expectMaxAge:   time.Hour,
+		},
+		{
+			name: "invalid max size",
+			eventLogConfig: &EventLog{
+				MaxSizeMB: -1,
+			},
+			expectedErr: "max_size_mb must be greater than or equal to 0",
+		},
+		{
+			name: "invalid max age",
+			eventLogConfig: &EventLog{
+				MaxAge: -time.Hour,
+			},
+			expectedErr: "max_age must be greater than or equal to 0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := DevConfig(nil)
+			must.NoError(t, config.normalizeAddrs())
+			config.Server.EventLog = tc.eventLogConfig
+
+			serverConfig, err := convertServerConfig(config)
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			must.NoError(t, err)
+			must.Eq(t, tc.expectEnabled, serverConfig.EventLogEnabled)
+			must.Eq(t, tc.expectMaxBytes, serverConfig.EventLogMaxBytes)
+			must.Eq(t, tc.expectMaxAge, serverConfig.EventLogMaxAge)
+		})
+	}
+}
+
 func TestAgent_ServerConfig_RaftMultiplier_Ok(t *testing.T) {
 	ci.Parallel(t)
 
@@ -459,6 +459,18 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		return false
 	}
 
+	if interval := config.Client.UtilizationReportInterval; interval < 0 || interval > structs.MaxUtilizationReportInterval {
+		c.Ui.Error(fmt.Sprintf("Invalid utilization_report_interval %s: must be between 0 and %s so reports are not stale before the next one",
+			interval, structs.MaxUtilizationReportInterval))
+		return false
+	}
+
+	if config.Client.TaskUsageReportInterval < 0 {
+		c.Ui.Error(fmt.Sprintf("Invalid task_usage_report_interval %s: must not be negative",
+			config.Client.TaskUsageReportInterval))
+		return false
+	}
+
 	if config.Client.Reserved == nil {
 		// Coding error; should always be set by DefaultConfig()
 		c.Ui.Error("client.reserved must be initialized. Please report a bug.")
@@ -486,6 +498,11 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		return false
 	}
 
+	if err := config.Client.LogSinks.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("client.log_sinks block invalid: %v", err))
+		return false
+	}
+
 	if err := config.Client.PreferredAddressFamily.Validate(); err != nil {
 		c.Ui.Error(fmt.Sprintf("Invalid preferred-address-family value: %s (valid values: %s, %s)",
 			config.Client.PreferredAddressFamily,
@@ -226,6 +226,13 @@ type ClientConfig struct {
 	// AllocMountsDir is the directory for storing mounts into allocation data
 	AllocMountsDir string `hcl:"alloc_mounts_dir"`
 
+	// HostVolumesDir is the directory in which dynamic host volumes are
+	// created
+	HostVolumesDir string `hcl:"host_volumes_dir"`
+
+	// HostVolumePluginDir is the directory searched for host volume plugins
+	HostVolumePluginDir string `hcl:"host_volume_plugin_dir"`
+
 	// Servers is a list of known server addresses. These are as "host:port"
 	Servers []string `hcl:"servers"`
 
@@ -309,6 +316,18 @@ type ClientConfig struct {
 	GCInterval    time.Duration
 	GCIntervalHCL string `hcl:"gc_interval" json:"-"`
 
+	// UtilizationReportInterval is the interval at which the client reports
+	// its observed CPU and memory utilization to the servers. Reporting is
+	// disabled if zero.
+	UtilizationReportInterval    time.Duration
+	UtilizationReportIntervalHCL string `hcl:"utilization_report_interval" json:"-"`
+
+	// TaskUsageReportInterval is the interval at which the client reports
+	// the resource usage of its running tasks to the servers. Reporting is
+	// disabled if zero.
+	TaskUsageReportInterval    time.Duration
+	TaskUsageReportIntervalHCL string `hcl:"task_usage_report_interval" json:"-"`
+
 	// GCParallelDestroys is the number of parallel destroys the garbage
 	// collector will allow.
 	GCParallelDestroys int `hcl:"gc_parallel_destroys"`
@@ -391,6 +410,9 @@ type ClientConfig struct {
 	// Users is used to configure parameters around operating system users.
 	Users *config.UsersConfig `hcl:"users"`
 
+	// LogSinks restricts the sinks tasks may forward their logs to.
+	LogSinks *config.LogSinksConfig `hcl:"log_sinks"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
@@ -415,6 +437,7 @@ func (c *ClientConfig) Copy() *ClientConfig {
 	nc.Artifact = c.Artifact.Copy()
 	nc.Drain = c.Drain.Copy()
 	nc.Users = c.Users.Copy()
+	nc.LogSinks = c.LogSinks.Copy()
 	nc.ExtraKeysHCL = slices.Clone(c.ExtraKeysHCL)
 	return &nc
 }
@@ -658,6 +681,10 @@ type ServerConfig struct {
 	// for the EventBufferSize is 1.
 	EventBufferSize *int `hcl:"event_buffer_size"`
 
+	// EventLog configures the durable log of the event stream, which allows
+	// subscribers to resume from events no longer held in memory.
+	EventLog *EventLog `hcl:"event_log"`
+
 	// LicensePath is the path to search for an enterprise license.
 	LicensePath string `hcl:"license_path"`
 
@@ -737,6 +764,7 @@ func (s *ServerConfig) Copy() *ServerConfig {
 	ns.PlanRejectionTracker = s.PlanRejectionTracker.Copy()
 	ns.EnableEventBroker = pointer.Copy(s.EnableEventBroker)
 	ns.EventBufferSize = pointer.Copy(s.EventBufferSize)
+	ns.EventLog = s.EventLog.Copy()
 	ns.JobMaxSourceSize = pointer.Copy(s.JobMaxSourceSize)
 	ns.licenseAdditionalPublicKeys = slices.Clone(s.licenseAdditionalPublicKeys)
 	ns.ExtraKeysHCL = slices.Clone(s.ExtraKeysHCL)
@@ -829,6 +857,68 @@ func (p *PlanRejectionTracker) Merge(b *PlanRejectionTracker) *PlanRejectionTrac
 	return &result
 }
 
+// EventLog is used in servers to configure the durable log of the event
+// stream.
+type EventLog struct {
+	// Enabled controls if events are written to the event log.
+	//
+	// Default: false.
+	Enabled *bool `hcl:"enabled"`
+
+	// MaxSizeMB is the total size of the event log to retain, after which
+	// the oldest events are removed.
+	//
+	// Default: 1024.
+	MaxSizeMB int `hcl:"max_size_mb"`
+
+	// MaxAge is how long events are retained in the event log. Zero means
+	// events are only removed once the log reaches MaxSizeMB.
+	MaxAge    time.Duration
+	MaxAgeHCL string `hcl:"max_age" json:"-"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+func (e *EventLog) Copy() *EventLog {
+	if e == nil {
+		return nil
+	}
+
+	ne := *e
+	ne.Enabled = pointer.Copy(e.Enabled)
+	ne.ExtraKeysHCL = slices.Clone(e.ExtraKeysHCL)
+	return &ne
+}
+
+func (e *EventLog) Merge(b *EventLog) *EventLog {
+	if e == nil {
+		return b
+	}
+
+	result := *e
+
+	if b == nil {
+		return &result
+	}
+
+	if b.Enabled != nil {
+		result.Enabled = b.Enabled
+	}
+
+	if b.MaxSizeMB != 0 {
+		result.MaxSizeMB = b.MaxSizeMB
+	}
+
+	if b.MaxAge != 0 {
+		result.MaxAge = b.MaxAge
+	}
+	if b.MaxAgeHCL != "" {
+		result.MaxAgeHCL = b.MaxAgeHCL
+	}
+	return &result
+}
+
 // Search is used in servers to configure search API options.
 type Search struct {
 	// FuzzyEnabled toggles whether the FuzzySearch API is enabled. If not
@@ -1404,6 +1494,7 @@ func DefaultConfig() *Config {
 			Artifact:                       config.DefaultArtifactConfig(),
 			Drain:                          nil,
 			Users:                          config.DefaultUsersConfig(),
+			LogSinks:                       config.DefaultLogSinksConfig(),
 		},
 		Server: &ServerConfig{
 			Enabled:           false,
@@ -2175,6 +2266,10 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.EventBufferSize = b.EventBufferSize
 	}
 
+	if b.EventLog != nil {
+		result.EventLog = result.EventLog.Merge(b.EventLog)
+	}
+
 	result.JobMaxSourceSize = pointer.Merge(s.JobMaxSourceSize, b.JobMaxSourceSize)
 
 	if b.PlanRejectionTracker != nil {
@@ -2261,6 +2356,12 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.AllocMountsDir != "" {
 		result.AllocMountsDir = b.AllocMountsDir
 	}
+	if b.HostVolumesDir != "" {
+		result.HostVolumesDir = b.HostVolumesDir
+	}
+	if b.HostVolumePluginDir != "" {
+		result.HostVolumePluginDir = b.HostVolumePluginDir
+	}
 	if b.NodeClass != "" {
 		result.NodeClass = b.NodeClass
 	}
@@ -2320,6 +2421,18 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.GCIntervalHCL != "" {
 		result.GCIntervalHCL = b.GCIntervalHCL
 	}
+	if b.UtilizationReportInterval != 0 {
+		result.UtilizationReportInterval = b.UtilizationReportInterval
+	}
+	if b.UtilizationReportIntervalHCL != "" {
+		result.UtilizationReportIntervalHCL = b.UtilizationReportIntervalHCL
+	}
+	if b.TaskUsageReportInterval != 0 {
+		result.TaskUsageReportInterval = b.TaskUsageReportInterval
+	}
+	if b.TaskUsageReportIntervalHCL != "" {
+		result.TaskUsageReportIntervalHCL = b.TaskUsageReportIntervalHCL
+	}
 	if b.GCParallelDestroys != 0 {
 		result.GCParallelDestroys = b.GCParallelDestroys
 	}
@@ -2422,6 +2535,7 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	result.Artifact = a.Artifact.Merge(b.Artifact)
 	result.Drain = a.Drain.Merge(b.Drain)
 	result.Users = a.Users.Merge(b.Users)
+	result.LogSinks = a.LogSinks.Merge(b.LogSinks)
 
 	return &result
 }
@@ -95,6 +95,8 @@ func ParseConfigFile(path string) (*Config, error) {
 	// convert strings to time.Durations
 	tds := []durationConversionMap{
 		{"gc_interval", &c.Client.GCInterval, &c.Client.GCIntervalHCL, nil},
+		{"client.utilization_report_interval", &c.Client.UtilizationReportInterval, &c.Client.UtilizationReportIntervalHCL, nil},
+		{"client.task_usage_report_interval", &c.Client.TaskUsageReportInterval, &c.Client.TaskUsageReportIntervalHCL, nil},
 		{"acl.token_ttl", &c.ACL.TokenTTL, &c.ACL.TokenTTLHCL, nil},
 		{"acl.policy_ttl", &c.ACL.PolicyTTL, &c.ACL.PolicyTTLHCL, nil},
 		{"acl.policy_ttl", &c.ACL.RoleTTL, &c.ACL.RoleTTLHCL, nil},
@@ -173,6 +175,13 @@ func ParseConfigFile(path string) (*Config, error) {
 		},
 	}
 
+	// Parse the event log retention if provided.
+	if c.Server.EventLog != nil {
+		tds = append(tds, durationConversionMap{
+			"server.event_log.max_age", &c.Server.EventLog.MaxAge, &c.Server.EventLog.MaxAgeHCL, nil,
+		})
+	}
+
 	// Parse durations for Consul and Vault config blocks if provided.
 	for _, consulConfig := range c.Consuls {
 		// Capture consulConfig inside the loop so the parse duration function
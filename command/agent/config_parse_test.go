@@ -133,6 +133,12 @@ var basicConfig = &Config{
 		EncryptKey:                "abc",
 		EnableEventBroker:         pointer.Of(false),
 		EventBufferSize:           pointer.Of(200),
+		EventLog: &EventLog{
+			Enabled:   pointer.Of(true),
+			MaxSizeMB: 2048,
+			MaxAge:    72 * time.Hour,
+			MaxAgeHCL: "72h",
+		},
 		PlanRejectionTracker: &PlanRejectionTracker{
 			Enabled:       pointer.Of(true),
 			NodeThreshold: 100,
@@ -167,3 +167,22 @@ func (s *HTTPServer) EvalsCountRequest(resp http.ResponseWriter, req *http.Reque
 	setMeta(resp, &out.QueryMeta)
 	return &out, nil
 }
+
+func (s *HTTPServer) EvalsQueueDepthRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.EvalQueueDepthRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.EvalQueueDepthResponse
+	if err := s.agent.RPC("Eval.QueueDepth", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return &out, nil
+}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/hashicorp/go-msgpack/v2/codec"
@@ -41,6 +42,8 @@ func (s *HTTPServer) FsRequest(resp http.ResponseWriter, req *http.Request) (int
 		return s.wrapUntrustedContent(s.FileCatRequest)(resp, req)
 	case strings.HasPrefix(path, "stream/"):
 		return s.Stream(resp, req)
+	case strings.HasPrefix(path, "logs/search/"):
+		return s.LogsSearchRequest(resp, req)
 	case strings.HasPrefix(path, "logs/"):
 		// Logs are *trusted* content because the endpoint
 		// explicitly sets the Content-Type to text/plain or
@@ -339,6 +342,82 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 	return s.fsStreamImpl(resp, req, "FileSystem.Logs", fsReq, fsReq.AllocID)
 }
 
+// LogsSearchRequest searches the logs of a task, including rotated files, for
+// lines matching a regular expression. The parameters are:
+//   - task: task name to search the logs of.
+//   - pattern: the regular expression lines must match.
+//   - type: stdout/stderr to search. Both are searched if unset.
+//   - since: RFC 3339 time before which log files are skipped.
+//   - context: number of lines to return before and after each match.
+//   - limit: maximum number of matches to return.
+func (s *HTTPServer) LogsSearchRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var allocID string
+
+	q := req.URL.Query()
+	if allocID = strings.TrimPrefix(req.URL.Path, "/v1/client/fs/logs/search/"); allocID == "" {
+		return nil, allocIDNotPresentErr
+	}
+
+	args := &cstructs.FsLogsSearchRequest{
+		AllocID: allocID,
+		Task:    q.Get("task"),
+		LogType: q.Get("type"),
+		Pattern: q.Get("pattern"),
+	}
+	if args.Task == "" {
+		return nil, taskNotPresentErr
+	}
+	switch args.LogType {
+	case "", "stdout", "stderr":
+	default:
+		return nil, logTypeNotPresentErr
+	}
+	if args.Pattern == "" {
+		return nil, CodedError(400, "must provide a pattern")
+	}
+
+	var err error
+	if since := q.Get("since"); since != "" {
+		if args.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("error parsing since: %v", err))
+		}
+	}
+	if contextStr := q.Get("context"); contextStr != "" {
+		if args.Context, err = strconv.Atoi(contextStr); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("error parsing context: %v", err))
+		}
+	}
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if args.Limit, err = strconv.Atoi(limitStr); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("error parsing limit: %v", err))
+		}
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	// Make the RPC
+	localClient, remoteClient, localServer := s.rpcHandlerForAlloc(allocID)
+
+	var reply cstructs.FsLogsSearchResponse
+	var rpcErr error
+	if localClient {
+		rpcErr = s.agent.Client().ClientRPC("FileSystem.LogsSearch", &args, &reply)
+	} else if remoteClient {
+		rpcErr = s.agent.Client().RPC("FileSystem.LogsSearch", &args, &reply)
+	} else if localServer {
+		rpcErr = s.agent.Server().RPC("FileSystem.LogsSearch", &args, &reply)
+	}
+
+	if rpcErr != nil {
+		if structs.IsErrNoNodeConn(rpcErr) || structs.IsErrUnknownAllocation(rpcErr) {
+			rpcErr = CodedError(404, rpcErr.Error())
+		}
+
+		return nil, rpcErr
+	}
+
+	return reply, nil
+}
+
 // fsStreamImpl is used to make a streaming filesystem call that serializes the
 // args and then expects a stream of StreamErrWrapper results where the payload
 // is copied to the response body.
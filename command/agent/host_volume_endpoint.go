@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// HostVolumeSpecificRequest dispatches GET, DELETE and create requests for
+// dynamic host volumes.
+func (s *HTTPServer) HostVolumeSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	// Tokenize the suffix of the path to get the volume id, tolerating a
+	// present or missing trailing slash
+	reqSuffix := strings.TrimPrefix(req.URL.Path, "/v1/volume/host/")
+	tokens := strings.FieldsFunc(reqSuffix, func(c rune) bool { return c == '/' })
+
+	if len(tokens) != 1 {
+		return nil, CodedError(404, resourceNotFoundErr)
+	}
+
+	switch req.Method {
+	case http.MethodPut, http.MethodPost:
+		if tokens[0] == "create" {
+			return s.hostVolumeCreate(resp, req)
+		}
+		return nil, CodedError(404, resourceNotFoundErr)
+	case http.MethodGet:
+		return s.hostVolumeGet(tokens[0], resp, req)
+	case http.MethodDelete:
+		return s.hostVolumeDelete(tokens[0], resp, req)
+	}
+
+	return nil, CodedError(405, ErrInvalidMethod)
+}
+
+func (s *HTTPServer) hostVolumesListRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.HostVolumeListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	query := req.URL.Query()
+	args.Prefix = query.Get("prefix")
+	args.NodePool = query.Get("node_pool")
+	args.NodeID = query.Get("node_id")
+
+	var out structs.HostVolumeListResponse
+	if err := s.agent.RPC("HostVolume.List", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return out.Volumes, nil
+}
+
+func (s *HTTPServer) hostVolumeGet(id string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.HostVolumeGetRequest{
+		ID: id,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.HostVolumeGetResponse
+	if err := s.agent.RPC("HostVolume.Get", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Volume == nil {
+		return nil, CodedError(404, "volume not found")
+	}
+
+	return out.Volume, nil
+}
+
+func (s *HTTPServer) hostVolumeCreate(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.HostVolumeCreateRequest{}
+	if err := decodeBody(req, &args); err != nil {
+		return err, CodedError(400, err.Error())
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.HostVolumeCreateResponse
+	if err := s.agent.RPC("HostVolume.Create", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return &out, nil
+}
+
+func (s *HTTPServer) hostVolumeDelete(id string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.HostVolumeDeleteRequest{VolumeID: id}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.HostVolumeDeleteResponse
+	if err := s.agent.RPC("HostVolume.Delete", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
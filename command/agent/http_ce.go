@@ -10,24 +10,18 @@ import (
 	"net/http"
 )
 
-// registerEnterpriseHandlers is a no-op for the oss release
+// registerEnterpriseHandlers registers the community quota and
+// recommendation handlers
 func (s *HTTPServer) registerEnterpriseHandlers() {
-	s.mux.HandleFunc("/v1/sentinel/policies", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/sentinel/policy/", s.wrap(s.entOnly))
-
-	s.mux.HandleFunc("/v1/quotas", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/quota-usages", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/quota/", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/quota", s.wrap(s.entOnly))
-
-	s.mux.HandleFunc("/v1/recommendation", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/recommendations", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/recommendations/apply", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/recommendation/", s.wrap(s.entOnly))
-}
-
-func (s *HTTPServer) entOnly(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
-	return nil, CodedError(501, ErrEntOnly)
+	s.mux.HandleFunc("/v1/quotas", s.wrap(s.QuotasRequest))
+	s.mux.HandleFunc("/v1/quota-usages", s.wrap(s.QuotaUsagesRequest))
+	s.mux.HandleFunc("/v1/quota/", s.wrap(s.QuotaSpecificRequest))
+	s.mux.HandleFunc("/v1/quota", s.wrap(s.QuotaCreateRequest))
+
+	s.mux.HandleFunc("/v1/recommendation", s.wrap(s.RecommendationCreateRequest))
+	s.mux.HandleFunc("/v1/recommendations", s.wrap(s.RecommendationsRequest))
+	s.mux.HandleFunc("/v1/recommendations/apply", s.wrap(s.RecommendationsApplyRequest))
+	s.mux.HandleFunc("/v1/recommendation/", s.wrap(s.RecommendationSpecificRequest))
 }
 
 // auditHandler wraps the passed handlerFn
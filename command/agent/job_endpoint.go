@@ -88,6 +88,8 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/dispatch"):
 		jobID := strings.TrimSuffix(path, "/dispatch")
 		return s.jobDispatchRequest(resp, req, jobID)
+	case strings.HasSuffix(path, "/tag") && strings.Contains(path, "/versions/"):
+		return s.jobVersionTag(resp, req, strings.TrimSuffix(path, "/tag"))
 	case strings.HasSuffix(path, "/versions"):
 		jobID := strings.TrimSuffix(path, "/versions")
 		return s.jobVersions(resp, req, jobID)
@@ -695,8 +697,16 @@ func (s *HTTPServer) jobVersions(resp http.ResponseWriter, req *http.Request, jo
 	}
 
 	args := structs.JobVersionsRequest{
-		JobID: jobID,
-		Diffs: diffsBool,
+		JobID:       jobID,
+		Diffs:       diffsBool,
+		DiffTagName: req.URL.Query().Get("diff_tag"),
+	}
+	if diffVersionStr := req.URL.Query().Get("diff_version"); diffVersionStr != "" {
+		diffVersion, err := strconv.ParseUint(diffVersionStr, 10, 64)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Failed to parse value of %q (%v) as a uint64: %v", "diff_version", diffVersionStr, err))
+		}
+		args.DiffVersion = &diffVersion
 	}
 	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
 		return nil, nil
@@ -771,6 +781,50 @@ func (s *HTTPServer) jobStable(resp http.ResponseWriter, req *http.Request, jobI
 	return out, nil
 }
 
+// jobVersionTag tags or untags a job version. The path is of the form
+// <job ID>/versions/<tag name>.
+func (s *HTTPServer) jobVersionTag(resp http.ResponseWriter, req *http.Request, path string) (interface{}, error) {
+	idx := strings.LastIndex(path, "/versions/")
+	jobID, name := path[:idx], path[idx+len("/versions/"):]
+	if name == "" {
+		return nil, CodedError(400, "tag name must be specified")
+	}
+
+	args := structs.JobApplyTagRequest{
+		JobID: jobID,
+		Name:  name,
+	}
+
+	switch req.Method {
+	case http.MethodPut, http.MethodPost:
+		var tagRequest api.JobTagRequest
+		if err := decodeBody(req, &tagRequest); err != nil {
+			return nil, CodedError(400, err.Error())
+		}
+		if tagRequest.JobID != "" && tagRequest.JobID != jobID {
+			return nil, CodedError(400, "Job ID does not match")
+		}
+		args.Version = tagRequest.Version
+		args.Tag = &structs.JobVersionTag{
+			Name:        name,
+			Description: tagRequest.Description,
+		}
+	case http.MethodDelete:
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.JobTagResponse
+	if err := s.agent.RPC("Job.TagVersion", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) jobSummaryRequest(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
 	args := structs.JobSummaryRequest{
 		JobID: jobID,
@@ -1033,6 +1087,8 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		Type:           *job.Type,
 		Priority:       *job.Priority,
 		AllAtOnce:      *job.AllAtOnce,
+		Gang:           *job.Gang,
+		Prefetch:       *job.Prefetch,
 		Datacenters:    job.Datacenters,
 		NodePool:       *job.NodePool,
 		Payload:        job.Payload,
@@ -1045,6 +1101,14 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		UI:             ApiJobUIConfigToStructs(job.UI),
 	}
 
+	if job.PreemptionBudget != nil {
+		j.PreemptionBudget = &structs.PreemptionBudget{
+			MaxPreemptions: *job.PreemptionBudget.MaxPreemptions,
+			Window:         *job.PreemptionBudget.Window,
+			MinRuntime:     *job.PreemptionBudget.MinRuntime,
+		}
+	}
+
 	// Update has been pushed into the task groups. stagger and max_parallel are
 	// preserved at the job level, but all other values are discarded. The job.Update
 	// api value is merged into TaskGroups already in api.Canonicalize
@@ -1189,6 +1253,7 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 			HealthCheck:     *taskGroup.Migrate.HealthCheck,
 			MinHealthyTime:  *taskGroup.Migrate.MinHealthyTime,
 			HealthyDeadline: *taskGroup.Migrate.HealthyDeadline,
+			Checkpoint:      dereferenceBool(taskGroup.Migrate.Checkpoint),
 		}
 	}
 
@@ -1197,9 +1262,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 	}
 
 	tg.EphemeralDisk = &structs.EphemeralDisk{
-		Sticky:  *taskGroup.EphemeralDisk.Sticky,
-		SizeMB:  *taskGroup.EphemeralDisk.SizeMB,
-		Migrate: *taskGroup.EphemeralDisk.Migrate,
+		Sticky:      *taskGroup.EphemeralDisk.Sticky,
+		SizeMB:      *taskGroup.EphemeralDisk.SizeMB,
+		Migrate:     *taskGroup.EphemeralDisk.Migrate,
+		LogBudgetMB: dereferenceInt(taskGroup.EphemeralDisk.LogBudgetMB),
 	}
 
 	if len(taskGroup.Spreads) > 0 {
@@ -1258,6 +1324,8 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		if taskGroup.Update.AutoPromote != nil {
 			tg.Update.AutoPromote = *taskGroup.Update.AutoPromote
 		}
+
+		tg.Update.CanaryAnalysis = apiCanaryAnalysisToStructs(taskGroup.Update.CanaryAnalysis)
 	}
 
 	if len(taskGroup.Tasks) > 0 {
@@ -1276,6 +1344,32 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 	}
 }
 
+func apiCanaryAnalysisToStructs(in *api.CanaryAnalysis) *structs.CanaryAnalysis {
+	if in == nil {
+		return nil
+	}
+
+	// Provider, Interval and Runs have defaults set via Canonicalize
+	out := &structs.CanaryAnalysis{
+		Provider: *in.Provider,
+		Interval: *in.Interval,
+		Runs:     *in.Runs,
+	}
+	if in.Address != nil {
+		out.Address = *in.Address
+	}
+
+	for _, m := range in.Metrics {
+		out.Metrics = append(out.Metrics, &structs.CanaryAnalysisMetric{
+			Name:  m.Name,
+			Query: m.Query,
+			Min:   m.Min,
+			Max:   m.Max,
+		})
+	}
+	return out
+}
+
 // ApiTaskToStructsTask is a copy and type conversion between the API
 // representation of a task from a struct representation of a task.
 func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
@@ -1530,6 +1624,22 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 		}
 	}
 
+	if in.IOWeight != nil {
+		out.IOWeight = *in.IOWeight
+	}
+
+	if in.ReadBps != nil {
+		out.ReadBps = *in.ReadBps
+	}
+
+	if in.WriteBps != nil {
+		out.WriteBps = *in.WriteBps
+	}
+
+	if in.NetworkEgressMBits != nil {
+		out.NetworkEgressMBits = *in.NetworkEgressMBits
+	}
+
 	return out
 }
 
@@ -2058,11 +2168,31 @@ func apiLogConfigToStructs(in *api.LogConfig) *structs.LogConfig {
 		return nil
 	}
 
-	return &structs.LogConfig{
+	out := &structs.LogConfig{
 		Disabled:      dereferenceBool(in.Disabled),
 		MaxFiles:      dereferenceInt(in.MaxFiles),
 		MaxFileSizeMB: dereferenceInt(in.MaxFileSizeMB),
 	}
+	if in.RotateInterval != nil {
+		out.RotateInterval = *in.RotateInterval
+	}
+	if in.Compression != nil {
+		out.Compression = *in.Compression
+	}
+	for _, sink := range in.Sinks {
+		s := &structs.LogSink{
+			Name:        sink.Name,
+			Type:        sink.Type,
+			Address:     sink.Address,
+			BatchSize:   dereferenceInt(sink.BatchSize),
+			SpoolSizeMB: dereferenceInt(sink.SpoolSizeMB),
+		}
+		if sink.BatchWait != nil {
+			s.BatchWait = *sink.BatchWait
+		}
+		out.Sinks = append(out.Sinks, s)
+	}
+	return out
 }
 
 func dereferenceBool(in *bool) bool {
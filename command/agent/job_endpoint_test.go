@@ -2555,6 +2555,8 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 		Type:        pointer.Of("service"),
 		Priority:    pointer.Of(50),
 		AllAtOnce:   pointer.Of(true),
+		Gang:        pointer.Of(true),
+		Prefetch:    pointer.Of(true),
 		Datacenters: []string{"dc1", "dc2"},
 		Constraints: []*api.Constraint{
 			{
@@ -2571,6 +2573,11 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 				Weight:  pointer.Of(int8(50)),
 			},
 		},
+		PreemptionBudget: &api.PreemptionBudget{
+			MaxPreemptions: pointer.Of(3),
+			Window:         pointer.Of(time.Hour),
+			MinRuntime:     pointer.Of(10 * time.Minute),
+		},
 		Update: &api.UpdateStrategy{
 			Stagger:          pointer.Of(1 * time.Second),
 			MaxParallel:      pointer.Of(5),
@@ -2990,6 +2997,8 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 		Type:           "service",
 		Priority:       50,
 		AllAtOnce:      true,
+		Gang:           true,
+		Prefetch:       true,
 		Datacenters:    []string{"dc1", "dc2"},
 		NodePool:       "",
 		Constraints: []*structs.Constraint{
@@ -3007,6 +3016,11 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 				Weight:  50,
 			},
 		},
+		PreemptionBudget: &structs.PreemptionBudget{
+			MaxPreemptions: 3,
+			Window:         time.Hour,
+			MinRuntime:     10 * time.Minute,
+		},
 		Spreads: []*structs.Spread{
 			{
 				Attribute: "${meta.rack}",
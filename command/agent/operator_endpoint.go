@@ -329,6 +329,27 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 			ServiceSchedulerEnabled:  conf.PreemptionConfig.ServiceSchedulerEnabled,
 		},
 	}
+	for _, p := range conf.ScoringPlugins {
+		if p == nil {
+			continue
+		}
+		args.Config.ScoringPlugins = append(args.Config.ScoringPlugins,
+			&structs.SchedulerScoringPlugin{Name: p.Name, Weight: p.Weight})
+	}
+	if conf.EvalBrokerFairShare != nil {
+		args.Config.EvalBrokerFairShare = &structs.EvalBrokerFairShare{
+			Enabled:          conf.EvalBrokerFairShare.Enabled,
+			DefaultWeight:    conf.EvalBrokerFairShare.DefaultWeight,
+			NamespaceWeights: conf.EvalBrokerFairShare.NamespaceWeights,
+		}
+	}
+	if conf.UtilizationScoring != nil {
+		args.Config.UtilizationScoring = &structs.SchedulerUtilizationScoring{
+			Enabled:        conf.UtilizationScoring.Enabled,
+			ObservedWeight: conf.UtilizationScoring.ObservedWeight,
+			MaxAge:         conf.UtilizationScoring.MaxAge,
+		}
+	}
 
 	if err := args.Config.Validate(); err != nil {
 		return nil, CodedError(http.StatusBadRequest, err.Error())
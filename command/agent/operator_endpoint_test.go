@@ -518,6 +518,13 @@ func TestOperator_SchedulerSetConfiguration(t *testing.T) {
   "PreemptionConfig": {
     "SystemSchedulerEnabled": true,
     "ServiceSchedulerEnabled": true
+  },
+  "ScoringPlugins": [
+    {"Name": "alloc-churn", "Weight": 50}
+  ],
+  "EvalBrokerFairShare": {
+    "Enabled": true,
+    "NamespaceWeights": {"prod": 4}
   }
 }`))
 		req, _ := http.NewRequest(http.MethodPut, "/v1/operator/scheduler/configuration", body)
@@ -544,6 +551,12 @@ func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 		require.True(t, reply.SchedulerConfig.PreemptionConfig.ServiceSchedulerEnabled)
 		require.True(t, reply.SchedulerConfig.MemoryOversubscriptionEnabled)
 		require.True(t, reply.SchedulerConfig.PauseEvalBroker)
+		require.Equal(t, []*structs.SchedulerScoringPlugin{{Name: "alloc-churn", Weight: 50}},
+			reply.SchedulerConfig.ScoringPlugins)
+		require.Equal(t, &structs.EvalBrokerFairShare{
+			Enabled:          true,
+			NamespaceWeights: map[string]int{"prod": 4},
+		}, reply.SchedulerConfig.EvalBrokerFairShare)
 	})
 }
 
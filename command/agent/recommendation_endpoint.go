@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) RecommendationsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.RecommendationListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	query := req.URL.Query()
+	args.JobID = query.Get("job")
+	args.Group = query.Get("group")
+	args.Task = query.Get("task")
+	if args.Group != "" && args.JobID == "" {
+		return nil, CodedError(400, "Job must be specified when filtering by group")
+	}
+	if args.Task != "" && args.Group == "" {
+		return nil, CodedError(400, "Group must be specified when filtering by task")
+	}
+
+	var out structs.RecommendationListResponse
+	if err := s.agent.RPC("Recommendation.ListRecommendations", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Recommendations == nil {
+		out.Recommendations = make([]*structs.Recommendation, 0)
+	}
+	return out.Recommendations, nil
+}
+
+func (s *HTTPServer) RecommendationSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	id := strings.TrimPrefix(req.URL.Path, "/v1/recommendation/")
+	if len(id) == 0 {
+		return nil, CodedError(400, "Missing Recommendation ID")
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		return s.recommendationQuery(resp, req, id)
+	case http.MethodDelete:
+		return s.recommendationDelete(resp, req, id)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) RecommendationCreateRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var rec structs.Recommendation
+	if err := decodeBody(req, &rec); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+
+	args := structs.RecommendationUpsertRequest{
+		Recommendations: []*structs.Recommendation{&rec},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.RecommendationUpsertResponse
+	if err := s.agent.RPC("Recommendation.UpsertRecommendation", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+
+	if len(out.Recommendations) == 0 {
+		return nil, nil
+	}
+	return out.Recommendations[0], nil
+}
+
+func (s *HTTPServer) RecommendationsApplyRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.RecommendationApplyRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.RecommendationApplyResponse
+	if err := s.agent.RPC("Recommendation.ApplyRecommendations", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+
+	if out.UpdatedJobs == nil {
+		out.UpdatedJobs = make([]*structs.SingleRecommendationApplyResult, 0)
+	}
+	if out.Errors == nil {
+		out.Errors = make([]*structs.SingleRecommendationApplyError, 0)
+	}
+	return out, nil
+}
+
+func (s *HTTPServer) recommendationQuery(resp http.ResponseWriter, req *http.Request,
+	id string) (interface{}, error) {
+	args := structs.RecommendationSpecificRequest{
+		RecommendationID: id,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleRecommendationResponse
+	if err := s.agent.RPC("Recommendation.GetRecommendation", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Recommendation == nil {
+		return nil, CodedError(404, "Recommendation not found")
+	}
+	return out.Recommendation, nil
+}
+
+func (s *HTTPServer) recommendationDelete(resp http.ResponseWriter, req *http.Request,
+	id string) (interface{}, error) {
+	args := structs.RecommendationDeleteRequest{
+		IDs: []string{id},
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Recommendation.DeleteRecommendations", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHTTP_Recommendation_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		job := mock.Job()
+		regReq := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		must.NoError(t, s.Agent.RPC("Job.Register", &regReq, &regResp))
+
+		// Create the recommendation
+		rec := mock.Recommendation(job)
+		rec.ID = ""
+		req, err := http.NewRequest(http.MethodPut, "/v1/recommendation", encodeReq(rec))
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.RecommendationCreateRequest(respW, req)
+		must.NoError(t, err)
+		must.NotEq(t, "", respW.Result().Header.Get("X-Nomad-Index"))
+		created := obj.(*structs.Recommendation)
+		must.NotEq(t, "", created.ID)
+		must.Eq(t, 500, created.Current)
+
+		// List the recommendations of the job
+		req, err = http.NewRequest(http.MethodGet, "/v1/recommendations?job="+job.ID, nil)
+		must.NoError(t, err)
+		obj, err = s.Server.RecommendationsRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+		must.Len(t, 1, obj.([]*structs.Recommendation))
+
+		// Filtering by task requires a group
+		req, err = http.NewRequest(http.MethodGet, "/v1/recommendations?job="+job.ID+"&task=web", nil)
+		must.NoError(t, err)
+		_, err = s.Server.RecommendationsRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "Group must be specified")
+
+		// Read the recommendation
+		req, err = http.NewRequest(http.MethodGet, "/v1/recommendation/"+created.ID, nil)
+		must.NoError(t, err)
+		obj, err = s.Server.RecommendationSpecificRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+		must.Eq(t, rec.Value, obj.(*structs.Recommendation).Value)
+
+		// Apply the recommendation
+		applyReq := structs.RecommendationApplyRequest{Apply: []string{created.ID}}
+		req, err = http.NewRequest(http.MethodPost, "/v1/recommendations/apply", encodeReq(applyReq))
+		must.NoError(t, err)
+		obj, err = s.Server.RecommendationsApplyRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+		applyResp := obj.(structs.RecommendationApplyResponse)
+		must.Len(t, 1, applyResp.UpdatedJobs)
+		must.SliceEmpty(t, applyResp.Errors)
+
+		// Applying the recommendation dismissed it
+		req, err = http.NewRequest(http.MethodGet, "/v1/recommendation/"+created.ID, nil)
+		must.NoError(t, err)
+		_, err = s.Server.RecommendationSpecificRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "not found")
+	})
+}
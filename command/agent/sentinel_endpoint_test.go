@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHTTP_SentinelPolicy_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		policy := mock.SentinelPolicy()
+
+		// Create the policy
+		req, err := http.NewRequest(http.MethodPut, "/v1/sentinel/policy/"+policy.Name, encodeReq(policy))
+		must.NoError(t, err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.SentinelPolicySpecificRequest(respW, req)
+		must.NoError(t, err)
+		must.NotEq(t, "", respW.Result().Header.Get("X-Nomad-Index"))
+
+		// Names must match the path
+		req, err = http.NewRequest(http.MethodPut, "/v1/sentinel/policy/other", encodeReq(policy))
+		must.NoError(t, err)
+		_, err = s.Server.SentinelPolicySpecificRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "does not match request path")
+
+		// List returns stubs
+		req, err = http.NewRequest(http.MethodGet, "/v1/sentinel/policies", nil)
+		must.NoError(t, err)
+		obj, err := s.Server.SentinelPoliciesRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+		stubs := obj.([]*structs.SentinelPolicyListStub)
+		must.Len(t, 1, stubs)
+		must.Eq(t, policy.Name, stubs[0].Name)
+
+		// Read the policy
+		req, err = http.NewRequest(http.MethodGet, "/v1/sentinel/policy/"+policy.Name, nil)
+		must.NoError(t, err)
+		obj, err = s.Server.SentinelPolicySpecificRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+		must.Eq(t, policy.Policy, obj.(*structs.SentinelPolicy).Policy)
+
+		// Delete the policy
+		req, err = http.NewRequest(http.MethodDelete, "/v1/sentinel/policy/"+policy.Name, nil)
+		must.NoError(t, err)
+		_, err = s.Server.SentinelPolicySpecificRequest(httptest.NewRecorder(), req)
+		must.NoError(t, err)
+
+		req, err = http.NewRequest(http.MethodGet, "/v1/sentinel/policy/"+policy.Name, nil)
+		must.NoError(t, err)
+		_, err = s.Server.SentinelPolicySpecificRequest(httptest.NewRecorder(), req)
+		must.ErrorContains(t, err, "not found")
+	})
+}
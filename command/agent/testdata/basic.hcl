@@ -149,6 +149,12 @@ server {
     node_window    = "41m"
   }
 
+  event_log {
+    enabled     = true
+    max_size_mb = 2048
+    max_age     = "72h"
+  }
+
   server_join {
     retry_join     = ["1.1.1.1", "2.2.2.2"]
     retry_max      = 3
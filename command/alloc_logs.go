@@ -27,6 +27,8 @@ type AllocLogsCommand struct {
 	numLines                                   int64
 	numBytes                                   int64
 	task                                       string
+	grep, since                                string
+	context                                    int
 }
 
 func (l *AllocLogsCommand) Help() string {
@@ -80,6 +82,21 @@ Logs Specific Options:
   -c
     Sets the tail location in number of bytes relative to the end of the logs.
 
+  -grep <regex>
+    Search the logs, including rotated log files, for lines matching the
+    regular expression instead of streaming them. Matches are printed as
+    "<file>:<offset>:<line>". Both stdout and stderr are searched unless
+    "-stdout" or "-stderr" is set.
+
+  -since <time>
+    Only search log files written after the given time, which is either an
+    RFC 3339 timestamp or a duration relative to now such as "1h". Only
+    applies with "-grep".
+
+  -context <lines>
+    Number of lines to print before and after each match. Only applies with
+    "-grep". Defaults to 0.
+
   Note that the -no-color option applies to Nomad's own output. If the task's
   logs include terminal escape sequences for color codes, Nomad will not
   remove them.
@@ -104,6 +121,9 @@ func (l *AllocLogsCommand) AutocompleteFlags() complete.Flags {
 			"-tail":    complete.PredictAnything,
 			"-n":       complete.PredictAnything,
 			"-c":       complete.PredictAnything,
+			"-grep":    complete.PredictAnything,
+			"-since":   complete.PredictAnything,
+			"-context": complete.PredictAnything,
 		})
 }
 
@@ -137,6 +157,9 @@ func (l *AllocLogsCommand) Run(args []string) int {
 	flags.Int64Var(&l.numLines, "n", -1, "")
 	flags.Int64Var(&l.numBytes, "c", -1, "")
 	flags.StringVar(&l.task, "task", "", "")
+	flags.StringVar(&l.grep, "grep", "", "")
+	flags.StringVar(&l.since, "since", "", "")
+	flags.IntVar(&l.context, "context", 0, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -158,6 +181,30 @@ func (l *AllocLogsCommand) Run(args []string) int {
 		return 1
 	}
 
+	// Searching is a single request so can't be combined with following or
+	// tailing the logs
+	var since time.Time
+	if l.grep != "" {
+		if l.follow || l.tail {
+			l.Ui.Error("The -grep flag can't be used with -f or -tail")
+			return 1
+		}
+		if l.stderr && l.stdout {
+			l.Ui.Error("Unable to support both stdout and stderr")
+			return 1
+		}
+		if l.since != "" {
+			var err error
+			if since, err = parseLogsSince(l.since, time.Now()); err != nil {
+				l.Ui.Error(err.Error())
+				return 1
+			}
+		}
+	} else if l.since != "" || l.context != 0 {
+		l.Ui.Error("The -since and -context flags require -grep")
+		return 1
+	}
+
 	client, err := l.Meta.Client()
 	if err != nil {
 		l.Ui.Error(fmt.Sprintf("Error initializing client: %v", err))
@@ -235,6 +282,14 @@ func (l *AllocLogsCommand) Run(args []string) int {
 		return 1
 	}
 
+	if l.grep != "" {
+		if err := l.searchLogs(client, alloc, since); err != nil {
+			l.Ui.Error(fmt.Sprintf("Failed to search logs: %v", err))
+			return 1
+		}
+		return 0
+	}
+
 	// In order to run the mixed log output, we can only follow the files from
 	// their current positions. There is no way to interleave previous log
 	// lines as there is no timestamp references.
@@ -308,6 +363,55 @@ func (l *AllocLogsCommand) handleSingleFile(client *api.Client, alloc *api.Alloc
 	return nil
 }
 
+// searchLogs prints the lines of the task's logs matching the -grep pattern in
+// a format similar to grep, with groups of context lines separated by "--".
+func (l *AllocLogsCommand) searchLogs(client *api.Client, alloc *api.Allocation, since time.Time) error {
+	opts := &api.LogSearchOptions{
+		Since:   since,
+		Context: l.context,
+	}
+	if l.stdout {
+		opts.LogType = api.FSLogNameStdout
+	} else if l.stderr {
+		opts.LogType = api.FSLogNameStderr
+	}
+
+	resp, _, err := client.AllocFS().LogsSearch(alloc, l.task, l.grep, opts, nil)
+	if err != nil {
+		return err
+	}
+
+	for i, m := range resp.Matches {
+		if l.context > 0 && i > 0 {
+			l.Ui.Output("--")
+		}
+		for _, line := range m.Before {
+			l.Ui.Output(fmt.Sprintf("%s-%s", m.File, line))
+		}
+		l.Ui.Output(fmt.Sprintf("%s:%d:%s", m.File, m.Offset, m.Line))
+		for _, line := range m.After {
+			l.Ui.Output(fmt.Sprintf("%s-%s", m.File, line))
+		}
+	}
+	if resp.Truncated {
+		l.Ui.Warn(fmt.Sprintf("Search stopped early, only the first %d matches are shown", len(resp.Matches)))
+	}
+	return nil
+}
+
+// parseLogsSince parses the -since flag, which is either an RFC 3339 time or
+// a duration before now.
+func parseLogsSince(since string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(since)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("Invalid -since %q: must be an RFC 3339 time or a positive duration", since)
+	}
+	return now.Add(-d), nil
+}
+
 // followFile outputs the contents of the file to stdout relative to the end of
 // the file.
 func (l *AllocLogsCommand) followFile(client *api.Client, alloc *api.Allocation,
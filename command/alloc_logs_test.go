@@ -5,6 +5,7 @@ package command
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -66,6 +67,41 @@ func TestLogsCommand_Fails(t *testing.T) {
 
 	out = ui.ErrorWriter.String()
 	must.StrContains(t, out, "No allocation(s) with prefix or id")
+
+	ui.ErrorWriter.Reset()
+
+	// Fails on searching while following
+	code = cmd.Run([]string{"-address=" + url, "-grep=error", "-f", "foobar"})
+	must.One(t, code)
+
+	out = ui.ErrorWriter.String()
+	must.StrContains(t, out, "can't be used with -f or -tail")
+
+	ui.ErrorWriter.Reset()
+
+	// Fails on an invalid -since
+	code = cmd.Run([]string{"-address=" + url, "-grep=error", "-since=yesterday", "foobar"})
+	must.One(t, code)
+
+	out = ui.ErrorWriter.String()
+	must.StrContains(t, out, "Invalid -since")
+}
+
+func TestLogsCommand_parseLogsSince(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	since, err := parseLogsSince("2024-05-01T10:00:00Z", now)
+	must.NoError(t, err)
+	must.Eq(t, now.Add(-2*time.Hour), since)
+
+	since, err = parseLogsSince("90m", now)
+	must.NoError(t, err)
+	must.Eq(t, now.Add(-90*time.Minute), since)
+
+	_, err = parseLogsSince("-1h", now)
+	must.Error(t, err)
 }
 
 func TestLogsCommand_AutocompleteArgs(t *testing.T) {
@@ -22,7 +22,7 @@ func (c *AllocPauseCommand) Help() string {
 Usage: nomad alloc pause [options] <allocation> <task>
 
   Set the pause state of an allocation. This command is used to suspend the
-  operation of a specific task.
+  operation of a specific task, overriding its schedule block.
 
   When ACLs are enabled, this command requires the job-submit capability for
   the allocation's namespace.
@@ -33,12 +33,15 @@ General Options:
 
 Pause Specific Options:
 
-  -state=<state>
+  -op=<state>
     Specify the schedule state to apply to a task. Must be one of pause, run,
-	or scheduled. When set to pause the task is halted. When set to run the task
-	is started regardless of the task schedule. When in scheduled state the task
-	respects the task schedule state in the task configuration. Defaults to
-	pause.
+    or scheduled. When set to pause the task is halted. When set to run the task
+    is started regardless of the task schedule. When in scheduled state the task
+    respects the task schedule state in the task configuration. Defaults to
+    pause.
+
+  -state=<state>
+    Alias for -op. May not be set to a different value than -op.
 
   -status
     Get the current task schedule state status.
@@ -58,12 +61,13 @@ func (c *AllocPauseCommand) Name() string { return "alloc pause" }
 
 func (c *AllocPauseCommand) Run(args []string) int {
 	var verbose, status bool
-	var action, task string
+	var op, state, task string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&verbose, "verbose", false, "")
-	flags.StringVar(&action, "state", "pause", "")
+	flags.StringVar(&op, "op", "", "")
+	flags.StringVar(&state, "state", "", "")
 	flags.BoolVar(&status, "status", false, "")
 	flags.StringVar(&task, "task", "", "")
 
@@ -87,6 +91,18 @@ func (c *AllocPauseCommand) Run(args []string) int {
 		length = fullId
 	}
 
+	// -state is an alias for -op, so both may only be set if they agree
+	if op != "" && state != "" && op != state {
+		c.Ui.Error(fmt.Sprintf("Conflicting pause actions: -op=%q and -state=%q", op, state))
+		return 1
+	}
+	action := "pause"
+	if op != "" {
+		action = op
+	} else if state != "" {
+		action = state
+	}
+
 	// Ensure the specified action is valid
 	actions := []string{"pause", "run", "scheduled"}
 	if !slices.Contains(actions, action) {
@@ -181,7 +197,10 @@ func (c *AllocPauseCommand) Synopsis() string {
 func (c *AllocPauseCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-mode":    complete.PredictNothing,
+			"-op":      complete.PredictSet("pause", "run", "scheduled"),
+			"-state":   complete.PredictSet("pause", "run", "scheduled"),
+			"-status":  complete.PredictNothing,
+			"-task":    complete.PredictAnything,
 			"-verbose": complete.PredictNothing,
 		},
 	)
@@ -8,9 +8,28 @@ import (
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
 )
 
 func TestAllocPauseCommand_Implements(t *testing.T) {
 	ci.Parallel(t)
 	var _ cli.Command = &AllocPauseCommand{}
 }
+
+func TestAllocPauseCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &AllocPauseCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on conflicting -op and -state values
+	code := cmd.Run([]string{"-address=nope", "-op=run", "-state=pause", "foobar", "web"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Conflicting pause actions")
+	ui.ErrorWriter.Reset()
+
+	// Fails on an invalid action
+	code = cmd.Run([]string{"-address=nope", "-state=stop", "foobar", "web"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Pause action must be one of")
+}
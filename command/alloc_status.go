@@ -316,6 +316,17 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 		basic = append(basic,
 			fmt.Sprintf("Replacement Alloc ID|%s", limit(alloc.NextAllocation, uuidLength)))
 	}
+	if alloc.PreemptedByAllocation != "" {
+		basic = append(basic,
+			fmt.Sprintf("Preempted By|%s", limit(alloc.PreemptedByAllocation, uuidLength)))
+	}
+	if alloc.Preemption != nil {
+		basic = append(basic, fmt.Sprintf("Preemption Reason|%s", alloc.Preemption.Reason))
+		for _, budget := range alloc.Preemption.Budgets {
+			basic = append(basic, fmt.Sprintf("Preemption Budget|%s: %d/%d in %s",
+				budget.Scope, budget.Preemptions, budget.MaxPreemptions, budget.Window))
+		}
+	}
 	if alloc.FollowupEvalID != "" {
 		nextEvalTime := futureEvalTimePretty(alloc.FollowupEvalID, client)
 		if nextEvalTime != "" {
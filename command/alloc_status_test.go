@@ -236,6 +236,43 @@ func TestAllocStatusCommand_RescheduleInfo(t *testing.T) {
 	must.RegexMatch(t, regexp.MustCompile(".*Reschedule Attempts\\s*=\\s*1/2"), out)
 }
 
+func TestAllocStatusCommand_PreemptionInfo(t *testing.T) {
+	ci.Parallel(t)
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	waitForNodes(t, client)
+
+	ui := cli.NewMockUi()
+	cmd := &AllocStatusCommand{Meta: Meta{Ui: ui}}
+	state := srv.Agent.Server().State()
+	a := mock.Alloc()
+	a.Metrics = &structs.AllocMetric{}
+	a.DesiredStatus = structs.AllocDesiredStatusEvict
+	a.PreemptedByAllocation = uuid.Generate()
+	a.Preemption = &structs.AllocPreemption{
+		Reason: `Preempted to place an allocation of job "batch" with priority 90`,
+		Time:   time.Now().UnixNano(),
+		Budgets: []*structs.PreemptionBudgetUsage{
+			{
+				Scope:          structs.PreemptionBudgetScopeJob,
+				Preemptions:    2,
+				MaxPreemptions: 5,
+				Window:         time.Hour,
+			},
+		},
+	}
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{a}))
+
+	if code := cmd.Run([]string{"-address=" + url, a.ID}); code != 0 {
+		t.Fatalf("expected exit 0, got: %d", code)
+	}
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "Preempted By")
+	must.StrContains(t, out, `Preempted to place an allocation of job "batch" with priority 90`)
+	must.RegexMatch(t, regexp.MustCompile(`Preemption Budget\s*=\s*job: 2/5 in 1h0m0s`), out)
+}
+
 func TestAllocStatusCommand_ScoreMetrics(t *testing.T) {
 	ci.Parallel(t)
 	srv, client, url := testServer(t, true, nil)
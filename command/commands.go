@@ -521,6 +521,21 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job tag": func() (cli.Command, error) {
+			return &JobTagCommand{
+				Meta: meta,
+			}, nil
+		},
+		"job tag apply": func() (cli.Command, error) {
+			return &JobTagApplyCommand{
+				Meta: meta,
+			}, nil
+		},
+		"job tag unset": func() (cli.Command, error) {
+			return &JobTagUnsetCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job validate": func() (cli.Command, error) {
 			return &JobValidateCommand{
 				Meta: meta,
@@ -606,6 +621,16 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"node image": func() (cli.Command, error) {
+			return &NodeImageCommand{
+				Meta: meta,
+			}, nil
+		},
+		"node image prefetch": func() (cli.Command, error) {
+			return &NodeImagePrefetchCommand{
+				Meta: meta,
+			}, nil
+		},
 		"node meta": func() (cli.Command, error) {
 			return &NodeMetaCommand{
 				Meta: meta,
@@ -804,6 +829,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator scheduler simulate": func() (cli.Command, error) {
+			return &OperatorSchedulerSimulateCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator root keyring": func() (cli.Command, error) {
 			return &OperatorRootKeyringCommand{
 				Meta: meta,
@@ -6,6 +6,7 @@ package command
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -47,6 +48,13 @@ Eval List Options:
   -status
     Only show evaluations with this status.
 
+  -queue-depth
+    Show the number of evaluations queued in the eval broker for each
+    namespace instead of listing evaluations. Ready evaluations are waiting
+    for a scheduler, unacked evaluations are being processed by a scheduler,
+    and pending evaluations are waiting for an earlier evaluation of the
+    same job to complete.
+
   -json
     Output the evaluation in its JSON format.
 
@@ -64,14 +72,15 @@ func (c *EvalListCommand) Synopsis() string {
 func (c *EvalListCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-json":       complete.PredictNothing,
-			"-t":          complete.PredictAnything,
-			"-verbose":    complete.PredictNothing,
-			"-filter":     complete.PredictAnything,
-			"-job":        complete.PredictAnything,
-			"-status":     complete.PredictAnything,
-			"-per-page":   complete.PredictAnything,
-			"-page-token": complete.PredictAnything,
+			"-json":        complete.PredictNothing,
+			"-t":           complete.PredictAnything,
+			"-verbose":     complete.PredictNothing,
+			"-filter":      complete.PredictAnything,
+			"-job":         complete.PredictAnything,
+			"-status":      complete.PredictAnything,
+			"-per-page":    complete.PredictAnything,
+			"-page-token":  complete.PredictAnything,
+			"-queue-depth": complete.PredictNothing,
 		})
 }
 
@@ -93,7 +102,7 @@ func (c *EvalListCommand) AutocompleteArgs() complete.Predictor {
 func (c *EvalListCommand) Name() string { return "eval list" }
 
 func (c *EvalListCommand) Run(args []string) int {
-	var monitor, verbose, json bool
+	var monitor, verbose, json, queueDepth bool
 	var perPage int
 	var tmpl, pageToken, filter, filterJobID, filterStatus string
 
@@ -108,6 +117,7 @@ func (c *EvalListCommand) Run(args []string) int {
 	flags.StringVar(&filter, "filter", "", "")
 	flags.StringVar(&filterJobID, "job", "", "")
 	flags.StringVar(&filterStatus, "status", "", "")
+	flags.BoolVar(&queueDepth, "queue-depth", false, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -127,6 +137,10 @@ func (c *EvalListCommand) Run(args []string) int {
 		return 1
 	}
 
+	if queueDepth {
+		return c.outputQueueDepth(client, json, tmpl)
+	}
+
 	opts := &api.QueryOptions{
 		Filter:    filter,
 		PerPage:   int32(perPage),
@@ -176,6 +190,35 @@ Results have been paginated. To get the next page run:
 	return 0
 }
 
+// outputQueueDepth outputs the number of evaluations queued in the eval
+// broker for each namespace
+func (c *EvalListCommand) outputQueueDepth(client *api.Client, json bool, tmpl string) int {
+	resp, _, err := client.Evaluations().QueueDepth(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying eval queue depth: %v", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, resp.Namespaces)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(resp.Namespaces) == 0 {
+		c.Ui.Output("No queued evals found")
+		return 0
+	}
+
+	c.Ui.Output(formatEvalQueueDepth(resp.Namespaces))
+	return 0
+}
+
 // argsWithoutPageToken strips out of the -page-token argument and
 // returns the joined string
 func argsWithoutPageToken(osArgs []string) string {
@@ -227,3 +270,21 @@ func formatEvalList(evals []*api.Evaluation, verbose bool) string {
 
 	return formatList(out)
 }
+
+func formatEvalQueueDepth(namespaces map[string]*api.EvalQueueDepth) string {
+	names := make([]string, 0, len(namespaces))
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]string, len(names)+1)
+	out[0] = "Namespace|Ready|Unacked|Pending"
+	for i, name := range names {
+		depth := namespaces[name]
+		out[i+1] = fmt.Sprintf("%s|%d|%d|%d",
+			name, depth.Ready, depth.Unacked, depth.Pending)
+	}
+
+	return formatList(out)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/shoenig/test/must"
 )
@@ -61,3 +62,19 @@ func TestEvalList_ArgsWithoutPageToken(t *testing.T) {
 		must.Eq(t, tc.expected, argsWithoutPageToken(args), must.Sprintf("for input: %s", tc.cli))
 	}
 }
+
+func TestEvalList_FormatEvalQueueDepth(t *testing.T) {
+	ci.Parallel(t)
+
+	out := formatEvalQueueDepth(map[string]*api.EvalQueueDepth{
+		"prod":    {Ready: 10, Unacked: 2, Pending: 1},
+		"default": {Ready: 3},
+	})
+
+	lines := strings.Split(out, "\n")
+	must.Len(t, 3, lines)
+	must.StrContains(t, lines[0], "Namespace")
+	must.StrHasPrefix(t, "default", lines[1])
+	must.StrHasPrefix(t, "prod", lines[2])
+	must.StrContains(t, lines[2], "10")
+}
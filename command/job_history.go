@@ -48,6 +48,14 @@ History Options:
   -version <job version>
     Display only the history for the given job version.
 
+  -diff-tag <tag name>
+    Display the difference between each version and the version with the
+    given tag, instead of its predecessor. Implies -p.
+
+  -diff-version <job version>
+    Display the difference between each version and the given version,
+    instead of its predecessor. Implies -p.
+
   -json
     Output the job versions in a JSON format.
 
@@ -64,11 +72,13 @@ func (c *JobHistoryCommand) Synopsis() string {
 func (c *JobHistoryCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-p":       complete.PredictNothing,
-			"-full":    complete.PredictNothing,
-			"-version": complete.PredictAnything,
-			"-json":    complete.PredictNothing,
-			"-t":       complete.PredictAnything,
+			"-p":            complete.PredictNothing,
+			"-full":         complete.PredictNothing,
+			"-version":      complete.PredictAnything,
+			"-diff-tag":     complete.PredictAnything,
+			"-diff-version": complete.PredictAnything,
+			"-json":         complete.PredictNothing,
+			"-t":            complete.PredictAnything,
 		})
 }
 
@@ -91,7 +101,7 @@ func (c *JobHistoryCommand) Name() string { return "job history" }
 
 func (c *JobHistoryCommand) Run(args []string) int {
 	var json, diff, full bool
-	var tmpl, versionStr string
+	var tmpl, versionStr, diffTag, diffVersionStr string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -99,6 +109,8 @@ func (c *JobHistoryCommand) Run(args []string) int {
 	flags.BoolVar(&full, "full", false, "")
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&versionStr, "version", "", "")
+	flags.StringVar(&diffTag, "diff-tag", "", "")
+	flags.StringVar(&diffVersionStr, "diff-version", "", "")
 	flags.StringVar(&tmpl, "t", "", "")
 
 	if err := flags.Parse(args); err != nil {
@@ -113,8 +125,27 @@ func (c *JobHistoryCommand) Run(args []string) int {
 		return 1
 	}
 
+	if diffTag != "" && diffVersionStr != "" {
+		c.Ui.Error("-diff-tag and -diff-version are mutually exclusive")
+		return 1
+	}
+
+	opts := &api.VersionsOptions{DiffTag: diffTag}
+	if diffVersionStr != "" {
+		diffVersion, _, err := parseVersion(diffVersionStr)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error parsing diff version value %q: %v", diffVersionStr, err))
+			return 1
+		}
+		opts.DiffVersion = &diffVersion
+	}
+	if diffTag != "" || opts.DiffVersion != nil {
+		diff = true
+	}
+	opts.Diffs = diff
+
 	if (json || len(tmpl) != 0) && (diff || full) {
-		c.Ui.Error("-json and -t are exclusive with -p and -full")
+		c.Ui.Error("-json and -t are exclusive with -p, -diff-tag, -diff-version and -full")
 		return 1
 	}
 
@@ -136,12 +167,22 @@ func (c *JobHistoryCommand) Run(args []string) int {
 	q := &api.QueryOptions{Namespace: namespace}
 
 	// Prefix lookup matched a single job
-	versions, diffs, _, err := client.Jobs().Versions(jobID, diff, q)
+	versions, diffs, _, err := client.Jobs().VersionsOpts(jobID, opts, q)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error retrieving job versions: %s", err))
 		return 1
 	}
 
+	// When diffing against a chosen version there is one diff per version
+	var diffTarget *uint64
+	for _, v := range versions {
+		if (opts.DiffVersion != nil && *v.Version == *opts.DiffVersion) ||
+			(diffTag != "" && v.VersionTag != nil && v.VersionTag.Name == diffTag) {
+			diffTarget = v.Version
+			break
+		}
+	}
+
 	f, err := DataFormat("json", "")
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error getting formatter: %s", err))
@@ -165,7 +206,10 @@ func (c *JobHistoryCommand) Run(args []string) int {
 			}
 
 			job = v
-			if i+1 <= len(diffs) {
+			if diffTarget != nil && i < len(diffs) {
+				diff = diffs[i]
+				nextVersion = *diffTarget
+			} else if i+1 <= len(diffs) {
 				diff = diffs[i]
 				nextVersion = *versions[i+1].Version
 			}
@@ -199,7 +243,7 @@ func (c *JobHistoryCommand) Run(args []string) int {
 			return 0
 		}
 
-		if err := c.formatJobVersions(versions, diffs, full); err != nil {
+		if err := c.formatJobVersions(versions, diffs, diffTarget, full); err != nil {
 			c.Ui.Error(err.Error())
 			return 1
 		}
@@ -219,17 +263,26 @@ func parseVersion(input string) (uint64, bool, error) {
 	return u, true, err
 }
 
-func (c *JobHistoryCommand) formatJobVersions(versions []*api.Job, diffs []*api.JobDiff, full bool) error {
+// formatJobVersions displays the job versions. If diffTarget is set the diffs
+// are against that version and line up with the versions, otherwise each diff
+// is against the version's predecessor.
+func (c *JobHistoryCommand) formatJobVersions(versions []*api.Job, diffs []*api.JobDiff, diffTarget *uint64, full bool) error {
 	vLen := len(versions)
 	dLen := len(diffs)
-	if dLen != 0 && vLen != dLen+1 {
+	if diffTarget != nil && vLen != dLen {
+		return fmt.Errorf("Number of job versions %d doesn't match number of diffs %d", vLen, dLen)
+	}
+	if diffTarget == nil && dLen != 0 && vLen != dLen+1 {
 		return fmt.Errorf("Number of job versions %d doesn't match number of diffs %d", vLen, dLen)
 	}
 
 	for i, version := range versions {
 		var diff *api.JobDiff
 		var nextVersion uint64
-		if i+1 <= dLen {
+		if diffTarget != nil {
+			diff = diffs[i]
+			nextVersion = *diffTarget
+		} else if i+1 <= dLen {
 			diff = diffs[i]
 			nextVersion = *versions[i+1].Version
 		}
@@ -258,6 +311,13 @@ func (c *JobHistoryCommand) formatJobVersion(job *api.Job, diff *api.JobDiff, ne
 		fmt.Sprintf("Submit Date|%v", formatTime(time.Unix(0, *job.SubmitTime))),
 	}
 
+	if job.VersionTag != nil {
+		basic = append(basic, fmt.Sprintf("Tag Name|%s", job.VersionTag.Name))
+		if job.VersionTag.Description != "" {
+			basic = append(basic, fmt.Sprintf("Tag Description|%s", job.VersionTag.Description))
+		}
+	}
+
 	if diff != nil {
 		//diffStr := fmt.Sprintf("Difference between version %d and %d:", *job.Version, nextVersion)
 		basic = append(basic, fmt.Sprintf("Diff|\n%s", strings.TrimSpace(formatJobDiff(diff, false))))
@@ -440,6 +440,9 @@ func formatDryRun(resp *api.JobPlanResponse, job *api.Job) string {
 		// Change the output depending on if we are a system job or not
 		if job.Type != nil && *job.Type == "system" {
 			out = "[bold][yellow]- WARNING: Failed to place allocations on all nodes.[reset]\n"
+		} else if job.Gang != nil && *job.Gang {
+			out = "[bold][yellow]- WARNING: Failed to place all allocations of gang job.[reset]\n"
+			out += "[yellow]  No allocations will be placed until the following task groups fit:[reset]\n"
 		} else {
 			out = "[bold][yellow]- WARNING: Failed to place all allocations.[reset]\n"
 		}
@@ -19,10 +19,11 @@ type JobRevertCommand struct {
 
 func (c *JobRevertCommand) Help() string {
 	helpText := `
-Usage: nomad job revert [options] <job> <version>
+Usage: nomad job revert [options] <job> <version|tag>
 
   Revert is used to revert a job to a prior version of the job. The available
-  versions to revert to can be found using "nomad job history" command.
+  versions to revert to can be found using "nomad job history" command. The
+  version can be given by number or by the name of its tag.
 
   When ACLs are enabled, this command requires a token with the 'submit-job'
   capability for the job's namespace. The 'list-jobs' capability is required to
@@ -108,7 +109,7 @@ func (c *JobRevertCommand) Run(args []string) int {
 	// Check that we got two args
 	args = flags.Args()
 	if l := len(args); l != 2 {
-		c.Ui.Error("This command takes two arguments: <job> <version>")
+		c.Ui.Error("This command takes two arguments: <job> <version|tag>")
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
@@ -132,15 +133,16 @@ func (c *JobRevertCommand) Run(args []string) int {
 		vaultToken = os.Getenv("VAULT_TOKEN")
 	}
 
-	// Parse the job version
+	// Parse the job version. Anything that isn't a number is a tag name,
+	// which is resolved once the job is known
 	revertVersion, ok, err := parseVersion(args[1])
 	if !ok {
-		c.Ui.Error("The job version to revert to must be specified using the -job-version flag")
+		c.Ui.Error("The job version to revert to must be specified")
 		return 1
 	}
+	tagName := ""
 	if err != nil {
-		c.Ui.Error(fmt.Sprintf("Failed to parse job-version flag: %v", err))
-		return 1
+		tagName = args[1]
 	}
 
 	// Check if the job exists
@@ -151,6 +153,14 @@ func (c *JobRevertCommand) Run(args []string) int {
 		return 1
 	}
 
+	if tagName != "" {
+		revertVersion, err = jobVersionByTag(client, jobID, tagName, &api.QueryOptions{Namespace: namespace})
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+	}
+
 	// Prefix lookup matched a single job
 	q := &api.WriteOptions{Namespace: namespace}
 	resp, _, err := client.Jobs().Revert(jobID, revertVersion, nil, q, consulToken, vaultToken)
@@ -174,3 +184,17 @@ func (c *JobRevertCommand) Run(args []string) int {
 	mon := newMonitor(c.Ui, client, length)
 	return mon.monitor(resp.EvalID)
 }
+
+// jobVersionByTag returns the version of the job with the given tag.
+func jobVersionByTag(client *api.Client, jobID, tagName string, q *api.QueryOptions) (uint64, error) {
+	versions, _, _, err := client.Jobs().Versions(jobID, false, q)
+	if err != nil {
+		return 0, fmt.Errorf("Error retrieving job versions: %s", err)
+	}
+	for _, v := range versions {
+		if v.VersionTag != nil && v.VersionTag.Name == tagName {
+			return *v.Version, nil
+		}
+	}
+	return 0, fmt.Errorf("No version of job %q is tagged %q", jobID, tagName)
+}
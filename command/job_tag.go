@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type JobTagCommand struct {
+	Meta
+}
+
+func (c *JobTagCommand) Name() string { return "job tag" }
+
+func (c *JobTagCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *JobTagCommand) Synopsis() string {
+	return "Manage job version tags"
+}
+
+func (c *JobTagCommand) Help() string {
+	helpText := `
+Usage: nomad job tag <subcommand> [options] [args]
+
+  This command groups subcommands for tagging job versions. Tagged versions
+  are never garbage collected and can be used by name with "nomad job revert"
+  and "nomad job history -diff-tag".
+
+  Tag the latest version of a job:
+
+      $ nomad job tag apply -name=release-42 <job_id>
+
+  Remove a tag:
+
+      $ nomad job tag unset -name=release-42 <job_id>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
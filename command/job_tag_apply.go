@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobTagApplyCommand struct {
+	Meta
+}
+
+func (c *JobTagApplyCommand) Help() string {
+	helpText := `
+Usage: nomad job tag apply [options] <job>
+
+  Apply attaches a tag to a version of a job. Tags are immutable: a version can
+  have only one tag, and a tag name can be used only once per job. Tagged
+  versions are never garbage collected.
+
+  When ACLs are enabled, this command requires a token with the 'submit-job'
+  capability for the job's namespace. The 'list-jobs' capability is required to
+  run the command with a job prefix instead of the exact job ID.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Apply Options:
+
+  -name
+    The name of the tag. Required. Tag names can't be numbers, so they can't
+    be confused with versions.
+
+  -description
+    An optional description of the tag.
+
+  -version
+    The version of the job to tag. Defaults to the latest version.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobTagApplyCommand) Synopsis() string {
+	return "Attach a tag to a job version"
+}
+
+func (c *JobTagApplyCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-name":        complete.PredictAnything,
+			"-description": complete.PredictAnything,
+			"-version":     complete.PredictAnything,
+		})
+}
+
+func (c *JobTagApplyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobTagApplyCommand) Name() string { return "job tag apply" }
+
+func (c *JobTagApplyCommand) Run(args []string) int {
+	var name, description, versionStr string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&name, "name", "", "")
+	flags.StringVar(&description, "description", "", "")
+	flags.StringVar(&versionStr, "version", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if name == "" {
+		c.Ui.Error("A tag name must be specified with the -name flag")
+		return 1
+	}
+
+	version, versionSet, err := parseVersion(versionStr)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing version value %q: %v", versionStr, err))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, nil)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	// Default to the latest version of the job
+	if !versionSet {
+		job, _, err := client.Jobs().Info(jobID, &api.QueryOptions{Namespace: namespace})
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving job: %s", err))
+			return 1
+		}
+		version = *job.Version
+	}
+
+	q := &api.WriteOptions{Namespace: namespace}
+	if _, _, err := client.Jobs().TagVersion(jobID, version, name, description, q); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error tagging job version: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Job %q version %d tagged %q", jobID, version, name))
+	return 0
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobTagUnsetCommand struct {
+	Meta
+}
+
+func (c *JobTagUnsetCommand) Help() string {
+	helpText := `
+Usage: nomad job tag unset [options] <job>
+
+  Unset removes a tag from the job version it is attached to. The version can
+  then be garbage collected like any other version.
+
+  When ACLs are enabled, this command requires a token with the 'submit-job'
+  capability for the job's namespace. The 'list-jobs' capability is required to
+  run the command with a job prefix instead of the exact job ID.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Unset Options:
+
+  -name
+    The name of the tag to remove. Required.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobTagUnsetCommand) Synopsis() string {
+	return "Remove a tag from a job version"
+}
+
+func (c *JobTagUnsetCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-name": complete.PredictAnything,
+		})
+}
+
+func (c *JobTagUnsetCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobTagUnsetCommand) Name() string { return "job tag unset" }
+
+func (c *JobTagUnsetCommand) Run(args []string) int {
+	var name string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&name, "name", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if name == "" {
+		c.Ui.Error("A tag name must be specified with the -name flag")
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, nil)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	q := &api.WriteOptions{Namespace: namespace}
+	if _, err := client.Jobs().UntagVersion(jobID, name, q); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error removing job version tag: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Tag %q removed from job %q", name, jobID))
+	return 0
+}
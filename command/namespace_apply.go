@@ -229,6 +229,7 @@ func parseNamespaceSpecImpl(result *api.Namespace, list *ast.ObjectList) error {
 	delete(m, "node_pool_config")
 	delete(m, "vault")
 	delete(m, "consul")
+	delete(m, "preemption_budget")
 
 	// Decode the rest
 	if err := mapstructure.WeakDecode(m, result); err != nil {
@@ -299,6 +300,35 @@ func parseNamespaceSpecImpl(result *api.Namespace, list *ast.ObjectList) error {
 		}
 	}
 
+	pbObj := list.Filter("preemption_budget")
+	if len(pbObj.Items) > 0 {
+		for _, o := range pbObj.Elem().Items {
+			ot, ok := o.Val.(*ast.ObjectType)
+			if !ok {
+				break
+			}
+			var m map[string]interface{}
+			if err := hcl.DecodeObject(&m, ot.List); err != nil {
+				return err
+			}
+
+			var budget api.PreemptionBudget
+			dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+				DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+				WeaklyTypedInput: true,
+				Result:           &budget,
+			})
+			if err != nil {
+				return err
+			}
+			if err := dec.Decode(m); err != nil {
+				return err
+			}
+			result.PreemptionBudget = &budget
+			break
+		}
+	}
+
 	if metaO := list.Filter("meta"); len(metaO.Items) > 0 {
 		for _, o := range metaO.Elem().Items {
 			var m map[string]interface{}
@@ -6,9 +6,11 @@ package command
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/mitchellh/cli"
 	"github.com/shoenig/test/must"
 )
@@ -155,6 +157,26 @@ node_pool_config {
 				},
 			},
 		},
+		{
+			name: "preemption budget",
+			input: `
+name = "batch"
+
+preemption_budget {
+  max_preemptions = 5
+  window          = "1h"
+  min_runtime     = "10m"
+}
+`,
+			expected: &api.Namespace{
+				Name: "batch",
+				PreemptionBudget: &api.PreemptionBudget{
+					MaxPreemptions: pointer.Of(5),
+					Window:         pointer.Of(time.Hour),
+					MinRuntime:     pointer.Of(10 * time.Minute),
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -194,6 +194,18 @@ func (c *NamespaceStatusCommand) Run(args []string) int {
 		c.Ui.Output(formatKV(cConfigOut))
 	}
 
+	if ns.PreemptionBudget != nil {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Preemption Budget[reset]"))
+		budget := ns.PreemptionBudget
+		budget.Canonicalize()
+		budgetOut := []string{
+			fmt.Sprintf("Max Preemptions|%d", *budget.MaxPreemptions),
+			fmt.Sprintf("Window|%s", *budget.Window),
+			fmt.Sprintf("Min Runtime|%s", *budget.MinRuntime),
+		}
+		c.Ui.Output(formatKV(budgetOut))
+	}
+
 	return 0
 }
 
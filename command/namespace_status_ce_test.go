@@ -6,8 +6,23 @@
 
 package command
 
-import "github.com/hashicorp/nomad/api"
+import (
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+)
 
+// testQuotaSpec returns a test quota specification
 func testQuotaSpec() *api.QuotaSpec {
-	panic("not implemented - enterprise only")
+	return &api.QuotaSpec{
+		Name: "quota-test-" + uuid.Short(),
+		Limits: []*api.QuotaLimit{
+			{
+				Region: "global",
+				RegionLimit: &api.Resources{
+					CPU: pointer.Of(100),
+				},
+			},
+		},
+	}
 }
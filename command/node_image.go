@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type NodeImageCommand struct {
+	Meta
+}
+
+func (c *NodeImageCommand) Help() string {
+	helpText := `
+Usage: nomad node image [subcommand]
+
+  Interact with the images cached on nodes. The prefetch subcommand has nodes
+  pull images before any task uses them, so that tasks scheduled later on
+  these nodes start without waiting for the pull.
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeImageCommand) Synopsis() string {
+	return "Interact with images cached on nodes"
+}
+
+func (c *NodeImageCommand) Name() string { return "node image" }
+
+func (c *NodeImageCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type NodeImagePrefetchCommand struct {
+	Meta
+}
+
+func (c *NodeImagePrefetchCommand) Help() string {
+	helpText := `
+Usage: nomad node image prefetch [options] <image> [<image>...]
+
+  Pull images on nodes before any task uses them. Images are pulled in the
+  background, this command returns once the nodes started pulling them. Pulled
+  images are reported as "unique.driver.<driver>.image.<image>" node
+  attributes, which the scheduler uses to prefer nodes that already have the
+  images of a task group.
+
+  When garbage collection of images is enabled, prefetched images are kept
+  until a task uses them and are then removed after their last task stops.
+
+  If neither -node-id nor -node-pool are set, the node receiving the request
+  pulls the images.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Node Image Prefetch Options:
+
+  -node-id
+    Pull the images on the specified node.
+
+  -node-pool
+    Pull the images on all the ready nodes of the node pool.
+
+  -driver
+    The task driver pulling the images. Defaults to "docker".
+
+  Example:
+    $ nomad node image prefetch -node-pool=gpu registry.example.com/trainer:1.4
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeImagePrefetchCommand) Synopsis() string {
+	return "Pull images on nodes ahead of tasks"
+}
+
+func (c *NodeImagePrefetchCommand) Name() string { return "node image prefetch" }
+
+func (c *NodeImagePrefetchCommand) Run(args []string) int {
+	var nodeID, nodePool, driver string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&nodeID, "node-id", "", "")
+	flags.StringVar(&nodePool, "node-pool", "", "")
+	flags.StringVar(&driver, "driver", "docker", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	images := flags.Args()
+
+	if len(images) == 0 {
+		c.Ui.Error("This command takes at least one argument: <image>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if nodeID != "" && nodePool != "" {
+		c.Ui.Error("The -node-id and -node-pool options are mutually exclusive")
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Resolve the target nodes
+	nodeIDs := []string{nodeID}
+	switch {
+	case nodeID != "":
+		nodeID, err = lookupNodeID(client.Nodes(), nodeID)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		nodeIDs = []string{nodeID}
+	case nodePool != "":
+		nodes, _, err := client.NodePools().ListNodes(nodePool, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error listing nodes of node pool %q: %s", nodePool, err))
+			return 1
+		}
+		nodeIDs = nodeIDs[:0]
+		for _, node := range nodes {
+			if node.Status == api.NodeStatusReady {
+				nodeIDs = append(nodeIDs, node.ID)
+			}
+		}
+		if len(nodeIDs) == 0 {
+			c.Ui.Error(fmt.Sprintf("No ready nodes in node pool %q", nodePool))
+			return 1
+		}
+	}
+
+	code := 0
+	for _, id := range nodeIDs {
+		req := &api.NodeImagePrefetchRequest{
+			NodeID: id,
+			Driver: driver,
+			Images: images,
+		}
+		resp, err := client.Nodes().Images().Prefetch(req, nil)
+		target := "local node"
+		if id != "" {
+			target = fmt.Sprintf("node %q", limit(id, shortId))
+		}
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error prefetching images on %s: %s", target, err))
+			code = 1
+			continue
+		}
+		c.Ui.Output(fmt.Sprintf("Prefetching %s on %s", strings.Join(resp.Images, ", "), target))
+	}
+
+	return code
+}
+
+func (c *NodeImagePrefetchCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-node-id":   complete.PredictNothing,
+			"-node-pool": nodePoolPredictor(c.Client, nil),
+			"-driver":    complete.PredictAnything,
+		})
+}
+
+func (c *NodeImagePrefetchCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestNodeImagePrefetchCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &NodeImagePrefetchCommand{}
+}
+
+func TestNodeImagePrefetchCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &NodeImagePrefetchCommand{Meta: Meta{Ui: ui}}
+
+	// Fails without images
+	must.One(t, cmd.Run([]string{"-address=" + url}))
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails when targeting both a node and a node pool
+	must.One(t, cmd.Run([]string{"-address=" + url, "-node-id=abcd", "-node-pool=gpu", "redis:7"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "mutually exclusive")
+	ui.ErrorWriter.Reset()
+
+	// Fails on node pools without ready nodes
+	must.One(t, cmd.Run([]string{"-address=" + url, "-node-pool=default", "redis:7"}))
+	must.StrContains(t, ui.ErrorWriter.String(), `No ready nodes in node pool "default"`)
+	ui.ErrorWriter.Reset()
+
+	// Fails on unknown nodes
+	must.One(t, cmd.Run([]string{"-address=" + url, "-node-id=12345678-abcd-efab-cdef-123456789abc", "redis:7"}))
+	must.StrContains(t, ui.ErrorWriter.String(), "No node(s) with prefix")
+}
@@ -31,6 +31,10 @@ Usage: nomad operator scheduler <subcommand> [options]
 
       $ nomad operator scheduler set-config -scheduler-algorithm=spread
 
+  Simulate scheduling a job against a snapshot:
+
+      $ nomad operator scheduler simulate -snapshot=backup.snap example.nomad.hcl
+
   Please see the individual subcommand help for detailed usage information.
 `
 	return strings.TrimSpace(helpText)
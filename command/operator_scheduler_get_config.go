@@ -5,8 +5,11 @@ package command
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
 )
@@ -86,11 +89,47 @@ func (o *OperatorSchedulerGetConfig) Run(args []string) int {
 		fmt.Sprintf("Preemption Service Scheduler|%v", schedConfig.PreemptionConfig.ServiceSchedulerEnabled),
 		fmt.Sprintf("Preemption Batch Scheduler|%v", schedConfig.PreemptionConfig.BatchSchedulerEnabled),
 		fmt.Sprintf("Preemption SysBatch Scheduler|%v", schedConfig.PreemptionConfig.SysBatchSchedulerEnabled),
+		fmt.Sprintf("Scoring Plugins|%s", formatScoringPlugins(schedConfig.ScoringPlugins)),
+		fmt.Sprintf("Eval Broker Fair Share|%v", schedConfig.EvalBrokerFairShare != nil && schedConfig.EvalBrokerFairShare.Enabled),
+		fmt.Sprintf("Eval Broker Fair Share Weights|%s", formatFairShareWeights(schedConfig.EvalBrokerFairShare)),
+		fmt.Sprintf("Utilization Scoring|%s", formatUtilizationScoring(schedConfig.UtilizationScoring)),
 		fmt.Sprintf("Modify Index|%v", resp.SchedulerConfig.ModifyIndex),
 	}))
 	return 0
 }
 
+// formatScoringPlugins returns the enabled scoring plugins and their weights
+// as a comma separated list.
+func formatScoringPlugins(plugins []*api.SchedulerScoringPlugin) string {
+	if len(plugins) == 0 {
+		return "<none>"
+	}
+	out := make([]string, len(plugins))
+	for i, p := range plugins {
+		out[i] = fmt.Sprintf("%s=%d", p.Name, p.Weight)
+	}
+	return strings.Join(out, ", ")
+}
+
+// formatFairShareWeights returns the namespace fair-share weights as a
+// comma separated list sorted by namespace.
+func formatFairShareWeights(fairShare *api.EvalBrokerFairShare) string {
+	if fairShare == nil || len(fairShare.NamespaceWeights) == 0 {
+		return "<none>"
+	}
+	namespaces := make([]string, 0, len(fairShare.NamespaceWeights))
+	for namespace := range fairShare.NamespaceWeights {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	out := make([]string, len(namespaces))
+	for i, namespace := range namespaces {
+		out[i] = fmt.Sprintf("%s=%d", namespace, fairShare.NamespaceWeights[namespace])
+	}
+	return strings.Join(out, ", ")
+}
+
 func (o *OperatorSchedulerGetConfig) Synopsis() string {
 	return "Display the current scheduler configuration"
 }
@@ -119,3 +158,20 @@ Scheduler Get Config Options:
 
 	return strings.TrimSpace(helpText)
 }
+
+// formatUtilizationScoring returns whether utilization-aware scoring is
+// enabled along with its observed weight and maximum report age.
+func formatUtilizationScoring(utilization *api.SchedulerUtilizationScoring) string {
+	if utilization == nil || !utilization.Enabled {
+		return "false"
+	}
+	weight := utilization.ObservedWeight
+	if weight == 0 {
+		weight = 50
+	}
+	maxAge := utilization.MaxAge
+	if maxAge == 0 {
+		maxAge = 5 * time.Minute
+	}
+	return fmt.Sprintf("true (observed weight %d%%, max age %s)", weight, maxAge)
+}
@@ -5,7 +5,9 @@ package command
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	flagHelper "github.com/hashicorp/nomad/helper/flags"
@@ -31,6 +33,12 @@ type OperatorSchedulerSetConfig struct {
 	preemptServiceScheduler  flagHelper.BoolValue
 	preemptSysBatchScheduler flagHelper.BoolValue
 	preemptSystemScheduler   flagHelper.BoolValue
+	scoringPlugins           flagHelper.StringFlag
+	fairShare                flagHelper.BoolValue
+	fairShareWeights         flagHelper.StringFlag
+	utilizationScoring       flagHelper.BoolValue
+	utilizationWeight        int
+	utilizationMaxAge        time.Duration
 }
 
 func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
@@ -41,13 +49,19 @@ func (o *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
 				string(api.SchedulerAlgorithmBinpack),
 				string(api.SchedulerAlgorithmSpread),
 			),
-			"-memory-oversubscription":    complete.PredictSet("true", "false"),
-			"-reject-job-registration":    complete.PredictSet("true", "false"),
-			"-pause-eval-broker":          complete.PredictSet("true", "false"),
-			"-preempt-batch-scheduler":    complete.PredictSet("true", "false"),
-			"-preempt-service-scheduler":  complete.PredictSet("true", "false"),
-			"-preempt-sysbatch-scheduler": complete.PredictSet("true", "false"),
-			"-preempt-system-scheduler":   complete.PredictSet("true", "false"),
+			"-memory-oversubscription":       complete.PredictSet("true", "false"),
+			"-reject-job-registration":       complete.PredictSet("true", "false"),
+			"-pause-eval-broker":             complete.PredictSet("true", "false"),
+			"-preempt-batch-scheduler":       complete.PredictSet("true", "false"),
+			"-preempt-service-scheduler":     complete.PredictSet("true", "false"),
+			"-preempt-sysbatch-scheduler":    complete.PredictSet("true", "false"),
+			"-preempt-system-scheduler":      complete.PredictSet("true", "false"),
+			"-scoring-plugin":                complete.PredictAnything,
+			"-eval-broker-fair-share":        complete.PredictSet("true", "false"),
+			"-eval-broker-fair-share-weight": complete.PredictAnything,
+			"-utilization-scoring":           complete.PredictSet("true", "false"),
+			"-utilization-observed-weight":   complete.PredictAnything,
+			"-utilization-max-age":           complete.PredictAnything,
 		},
 	)
 }
@@ -72,6 +86,12 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	flags.Var(&o.preemptServiceScheduler, "preempt-service-scheduler", "")
 	flags.Var(&o.preemptSysBatchScheduler, "preempt-sysbatch-scheduler", "")
 	flags.Var(&o.preemptSystemScheduler, "preempt-system-scheduler", "")
+	flags.Var(&o.scoringPlugins, "scoring-plugin", "")
+	flags.Var(&o.fairShare, "eval-broker-fair-share", "")
+	flags.Var(&o.fairShareWeights, "eval-broker-fair-share-weight", "")
+	flags.Var(&o.utilizationScoring, "utilization-scoring", "")
+	flags.IntVar(&o.utilizationWeight, "utilization-observed-weight", -1, "")
+	flags.DurationVar(&o.utilizationMaxAge, "utilization-max-age", -1, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -135,6 +155,58 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	o.preemptSysBatchScheduler.Merge(&schedulerConfig.PreemptionConfig.SysBatchSchedulerEnabled)
 	o.preemptSystemScheduler.Merge(&schedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
 
+	for _, raw := range o.scoringPlugins {
+		plugin, err := parseScoringPluginFlag(raw)
+		if err != nil {
+			o.Ui.Error(fmt.Sprintf("Error parsing scoring-plugin value %q: %v", raw, err))
+			return 1
+		}
+		schedulerConfig.ScoringPlugins = mergeScoringPlugin(schedulerConfig.ScoringPlugins, plugin)
+	}
+
+	fairShareEnabled := schedulerConfig.EvalBrokerFairShare != nil &&
+		schedulerConfig.EvalBrokerFairShare.Enabled
+	o.fairShare.Merge(&fairShareEnabled)
+	if schedulerConfig.EvalBrokerFairShare == nil && (fairShareEnabled || len(o.fairShareWeights) > 0) {
+		schedulerConfig.EvalBrokerFairShare = &api.EvalBrokerFairShare{}
+	}
+	if schedulerConfig.EvalBrokerFairShare != nil {
+		schedulerConfig.EvalBrokerFairShare.Enabled = fairShareEnabled
+	}
+	for _, raw := range o.fairShareWeights {
+		namespace, weight, err := parseFairShareWeightFlag(raw)
+		if err != nil {
+			o.Ui.Error(fmt.Sprintf("Error parsing eval-broker-fair-share-weight value %q: %v", raw, err))
+			return 1
+		}
+		fairShare := schedulerConfig.EvalBrokerFairShare
+		if weight == 0 {
+			delete(fairShare.NamespaceWeights, namespace)
+			continue
+		}
+		if fairShare.NamespaceWeights == nil {
+			fairShare.NamespaceWeights = make(map[string]int)
+		}
+		fairShare.NamespaceWeights[namespace] = weight
+	}
+
+	utilizationEnabled := schedulerConfig.UtilizationScoring != nil &&
+		schedulerConfig.UtilizationScoring.Enabled
+	o.utilizationScoring.Merge(&utilizationEnabled)
+	if schedulerConfig.UtilizationScoring == nil &&
+		(utilizationEnabled || o.utilizationWeight >= 0 || o.utilizationMaxAge >= 0) {
+		schedulerConfig.UtilizationScoring = &api.SchedulerUtilizationScoring{}
+	}
+	if utilization := schedulerConfig.UtilizationScoring; utilization != nil {
+		utilization.Enabled = utilizationEnabled
+		if o.utilizationWeight >= 0 {
+			utilization.ObservedWeight = o.utilizationWeight
+		}
+		if o.utilizationMaxAge >= 0 {
+			utilization.MaxAge = o.utilizationMaxAge
+		}
+	}
+
 	// Check-and-set the new configuration.
 	result, _, err := client.Operator().SchedulerCASConfiguration(schedulerConfig, nil)
 	if err != nil {
@@ -149,6 +221,55 @@ func (o *OperatorSchedulerSetConfig) Run(args []string) int {
 	return 1
 }
 
+// parseScoringPluginFlag parses a scoring plugin flag value in the form
+// "name=weight".
+func parseScoringPluginFlag(raw string) (*api.SchedulerScoringPlugin, error) {
+	name, weightStr, ok := strings.Cut(raw, "=")
+	if !ok || name == "" {
+		return nil, fmt.Errorf(`must be in the form "name=weight"`)
+	}
+	weight, err := strconv.ParseInt(weightStr, 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid weight: %v", err)
+	}
+	return &api.SchedulerScoringPlugin{Name: name, Weight: int8(weight)}, nil
+}
+
+// mergeScoringPlugin adds or updates the scoring plugin in the current set
+// of plugins. A weight of zero removes the plugin.
+func mergeScoringPlugin(current []*api.SchedulerScoringPlugin, plugin *api.SchedulerScoringPlugin) []*api.SchedulerScoringPlugin {
+	merged := make([]*api.SchedulerScoringPlugin, 0, len(current)+1)
+	found := false
+	for _, p := range current {
+		if p.Name != plugin.Name {
+			merged = append(merged, p)
+			continue
+		}
+		found = true
+		if plugin.Weight != 0 {
+			merged = append(merged, plugin)
+		}
+	}
+	if !found && plugin.Weight != 0 {
+		merged = append(merged, plugin)
+	}
+	return merged
+}
+
+// parseFairShareWeightFlag parses a fair-share weight flag value in the form
+// "namespace=weight".
+func parseFairShareWeightFlag(raw string) (string, int, error) {
+	namespace, weightStr, ok := strings.Cut(raw, "=")
+	if !ok || namespace == "" {
+		return "", 0, fmt.Errorf(`must be in the form "namespace=weight"`)
+	}
+	weight, err := strconv.Atoi(weightStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid weight: %v", err)
+	}
+	return namespace, weight, nil
+}
+
 func (o *OperatorSchedulerSetConfig) Synopsis() string {
 	return "Modify the current scheduler configuration"
 }
@@ -208,6 +329,39 @@ Scheduler Set Config Options:
   -preempt-system-scheduler=[true|false]
     Specifies whether preemption for system jobs is enabled. Note that if this
     is set to true, then system jobs can preempt any other jobs.
+
+  -scoring-plugin=<name>=<weight>
+    Enables a scoring plugin registered with the scheduler, such as
+    "alloc-churn", with a weight between -100 and 100. A weight of 0 disables
+    a previously enabled plugin. This flag can be specified multiple times.
+
+  -eval-broker-fair-share=[true|false]
+    When true, the eval broker round-robins between namespaces with
+    evaluations ready to be processed in proportion to their weights, instead
+    of dequeueing evaluations strictly by priority. This prevents a single
+    namespace with a large backlog of evaluations from starving the others.
+
+  -eval-broker-fair-share-weight=<namespace>=<weight>
+    Sets the fair-share weight of a namespace, between 1 and 1000. Namespaces
+    without a weight default to 1. A weight of 0 removes a previously set
+    weight. This flag can be specified multiple times.
+
+  -utilization-scoring=[true|false]
+    When true, the scheduler scores nodes from a blend of the resources
+    reserved on them and the CPU and memory usage reported by their clients,
+    so nodes full of over-reserved but idle allocations are scored by how busy
+    they actually are. Clients must set utilization_report_interval to report
+    their usage.
+
+  -utilization-observed-weight=<0-100>
+    The percentage of the node score computed from observed usage when
+    utilization scoring is enabled. The remainder is computed from reserved
+    resources. A value of 0 uses the default of 50.
+
+  -utilization-max-age=<duration>
+    How old a node's usage report may be before it is ignored and the node is
+    scored from its reserved resources only. Must be at least 5m. A value of
+    0 uses the default of 5m.
 `
 	return strings.TrimSpace(helpText)
 }
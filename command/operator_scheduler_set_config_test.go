@@ -51,6 +51,9 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		"-preempt-service-scheduler=true",
 		"-preempt-sysbatch-scheduler=true",
 		"-preempt-system-scheduler=false",
+		"-scoring-plugin=alloc-churn=50",
+		"-eval-broker-fair-share=true",
+		"-eval-broker-fair-share-weight=default=2",
 	}
 	must.Zero(t, c.Run(modifyingArgs))
 	s := ui.OutputWriter.String()
@@ -69,6 +72,13 @@ func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
 		MemoryOversubscriptionEnabled: true,
 		RejectJobRegistration:         true,
 		PauseEvalBroker:               true,
+		ScoringPlugins: []*api.SchedulerScoringPlugin{
+			{Name: "alloc-churn", Weight: 50},
+		},
+		EvalBrokerFairShare: &api.EvalBrokerFairShare{
+			Enabled:          true,
+			NamespaceWeights: map[string]int{"default": 2},
+		},
 	}, modifiedConfig.SchedulerConfig)
 
 	ui.ErrorWriter.Reset()
@@ -108,4 +118,56 @@ func schedulerConfigEquals(t *testing.T, expected, actual *api.SchedulerConfigur
 	must.Eq(t, expected.MemoryOversubscriptionEnabled, actual.MemoryOversubscriptionEnabled)
 	must.Eq(t, expected.PauseEvalBroker, actual.PauseEvalBroker)
 	must.Eq(t, expected.PreemptionConfig, actual.PreemptionConfig)
+	must.Eq(t, expected.ScoringPlugins, actual.ScoringPlugins)
+	must.Eq(t, expected.EvalBrokerFairShare, actual.EvalBrokerFairShare)
+}
+
+func TestOperatorSchedulerSetConfig_mergeScoringPlugin(t *testing.T) {
+	ci.Parallel(t)
+
+	plugin, err := parseScoringPluginFlag("alloc-churn=50")
+	must.NoError(t, err)
+	must.Eq(t, &api.SchedulerScoringPlugin{Name: "alloc-churn", Weight: 50}, plugin)
+
+	_, err = parseScoringPluginFlag("alloc-churn")
+	must.ErrorContains(t, err, "must be in the form")
+
+	_, err = parseScoringPluginFlag("alloc-churn=500")
+	must.ErrorContains(t, err, "invalid weight")
+
+	current := []*api.SchedulerScoringPlugin{
+		{Name: "alloc-churn", Weight: 50},
+		{Name: "other", Weight: 10},
+	}
+
+	// Updating an existing plugin keeps its position.
+	merged := mergeScoringPlugin(current, &api.SchedulerScoringPlugin{Name: "alloc-churn", Weight: -20})
+	must.Eq(t, []*api.SchedulerScoringPlugin{
+		{Name: "alloc-churn", Weight: -20},
+		{Name: "other", Weight: 10},
+	}, merged)
+
+	// A zero weight removes the plugin.
+	merged = mergeScoringPlugin(current, &api.SchedulerScoringPlugin{Name: "alloc-churn"})
+	must.Eq(t, []*api.SchedulerScoringPlugin{{Name: "other", Weight: 10}}, merged)
+
+	// New plugins are appended.
+	merged = mergeScoringPlugin(current, &api.SchedulerScoringPlugin{Name: "new", Weight: 1})
+	must.Len(t, 3, merged)
+	must.Eq(t, "new", merged[2].Name)
+}
+
+func TestOperatorSchedulerSetConfig_parseFairShareWeightFlag(t *testing.T) {
+	ci.Parallel(t)
+
+	namespace, weight, err := parseFairShareWeightFlag("prod=5")
+	must.NoError(t, err)
+	must.Eq(t, "prod", namespace)
+	must.Eq(t, 5, weight)
+
+	_, _, err = parseFairShareWeightFlag("=5")
+	must.ErrorContains(t, err, "must be in the form")
+
+	_, _, err = parseFairShareWeightFlag("prod=high")
+	must.ErrorContains(t, err, "invalid weight")
 }
@@ -0,0 +1,388 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/posener/complete"
+)
+
+type OperatorSchedulerSimulateCommand struct {
+	Meta
+	JobGetter
+}
+
+func (c *OperatorSchedulerSimulateCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler simulate [options] -snapshot=<file> <path>
+
+  Runs the scheduler offline for the job at the given path against the
+  cluster state of a snapshot, and displays the placements, preemptions and
+  failed placements that would result. The snapshot can be changed before
+  the job is scheduled to answer capacity planning questions, such as how
+  the job would be placed with additional nodes or with another scheduler
+  algorithm. No Nomad agent is contacted and the snapshot is not modified.
+
+  Every plan is accepted in full, as if no other evaluations were being
+  processed by the cluster at the same time.
+
+  To simulate the job "example.nomad.hcl" with 20 copies of an existing
+  node added to the cluster:
+
+      $ nomad operator scheduler simulate -snapshot=backup.snap \
+          -clone-node=f8a3c6e2 -add-nodes=20 example.nomad.hcl
+
+Simulate Options:
+
+  -snapshot=<file>
+    Path to a snapshot saved with "nomad operator snapshot save". Required.
+
+  -clone-node=<node-id>
+    The ID, or a unique ID prefix, of the node in the snapshot that is copied
+    when adding nodes with -add-nodes.
+
+  -add-nodes=<count>
+    Number of copies of the -clone-node node to add to the cluster before the
+    job is scheduled.
+
+  -scheduler-algorithm=<binpack|spread>
+    Overrides the scheduler algorithm of the cluster.
+
+  -verbose
+    Display full IDs and the scores of the nodes evaluated for failed
+    placements.
+
+  -json
+    Parses the job file as JSON. If the outer object has a Job field, such as
+    from "nomad job inspect" or "nomad run -output", the value of the field is
+    used as the job.
+
+  -hcl1
+    Parses the job file as HCLv1. Takes precedence over "-hcl2-strict".
+
+  -hcl2-strict
+    Whether an error should be produced from the HCL2 parser where a variable
+    has been supplied which is not defined within the root variables. Defaults
+    to true, but ignored if "-hcl1" is also defined.
+
+  -var 'key=value'
+    Variable for template, can be used multiple times.
+
+  -var-file=path
+    Path to HCL2 file containing user variables.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSchedulerSimulateCommand) Synopsis() string {
+	return "Simulate scheduling a job against a snapshot"
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-snapshot":   complete.PredictFiles("*.snap"),
+		"-clone-node": complete.PredictAnything,
+		"-add-nodes":  complete.PredictAnything,
+		"-scheduler-algorithm": complete.PredictSet(
+			string(api.SchedulerAlgorithmBinpack),
+			string(api.SchedulerAlgorithmSpread),
+		),
+		"-verbose":     complete.PredictNothing,
+		"-json":        complete.PredictNothing,
+		"-hcl1":        complete.PredictNothing,
+		"-hcl2-strict": complete.PredictNothing,
+		"-var":         complete.PredictAnything,
+		"-var-file":    complete.PredictFiles("*.var"),
+	}
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictOr(
+		complete.PredictFiles("*.nomad"),
+		complete.PredictFiles("*.hcl"),
+		complete.PredictFiles("*.json"),
+	)
+}
+
+func (c *OperatorSchedulerSimulateCommand) Name() string { return "operator scheduler simulate" }
+
+func (c *OperatorSchedulerSimulateCommand) Run(args []string) int {
+	var snapshotPath, cloneNode, algorithm string
+	var addNodes int
+	var verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&snapshotPath, "snapshot", "", "")
+	flags.StringVar(&cloneNode, "clone-node", "", "")
+	flags.IntVar(&addNodes, "add-nodes", 0, "")
+	flags.StringVar(&algorithm, "scheduler-algorithm", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&c.JobGetter.JSON, "json", false, "")
+	flags.BoolVar(&c.JobGetter.HCL1, "hcl1", false, "")
+	flags.BoolVar(&c.JobGetter.Strict, "hcl2-strict", true, "")
+	flags.Var(&c.JobGetter.Vars, "var", "")
+	flags.Var(&c.JobGetter.VarFiles, "var-file", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <path>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if snapshotPath == "" {
+		c.Ui.Error("The -snapshot flag is required")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if addNodes < 0 {
+		c.Ui.Error("The -add-nodes flag must not be negative")
+		return 1
+	}
+	if addNodes > 0 && cloneNode == "" {
+		c.Ui.Error("The -add-nodes flag requires -clone-node")
+		return 1
+	}
+
+	if c.JobGetter.HCL1 {
+		c.JobGetter.Strict = false
+	}
+	if err := c.JobGetter.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid job options: %s", err))
+		return 1
+	}
+
+	_, aj, err := c.JobGetter.Get(args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+		return 1
+	}
+	job := agent.ApiJobToStructJob(aj)
+
+	f, err := os.Open(snapshotPath)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error opening snapshot file: %s", err))
+		return 1
+	}
+	defer f.Close()
+
+	store, _, err := raftutil.RestoreFromArchive(f, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read snapshot file: %s", err))
+		return 1
+	}
+
+	// Look up existing allocations before the simulation registers the job,
+	// so that placements can be told apart from in-place updates.
+	existing, err := store.AllocsByJob(nil, job.Namespace, job.ID, true)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading allocations: %s", err))
+		return 1
+	}
+	existingIDs := make(map[string]struct{}, len(existing))
+	for _, alloc := range existing {
+		existingIDs[alloc.ID] = struct{}{}
+	}
+
+	result, err := raftutil.Simulate(store, job, &raftutil.SimulateOptions{
+		CloneNodeID:        cloneNode,
+		AddNodes:           addNodes,
+		SchedulerAlgorithm: structs.SchedulerAlgorithm(algorithm),
+	})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error simulating job: %s", err))
+		return 1
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	c.Ui.Output(c.Colorize().Color("[bold]Scheduler[reset]"))
+	c.Ui.Output(formatKV([]string{
+		fmt.Sprintf("Algorithm|%s", result.SchedulerConfig.EffectiveSchedulerAlgorithm()),
+		fmt.Sprintf("Added Nodes|%d", len(result.AddedNodes)),
+	}))
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Task Groups[reset]"))
+	c.Ui.Output(formatSimulateTaskGroups(job, result, existingIDs))
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Placements[reset]"))
+	c.Ui.Output(formatSimulatePlacements(store, result, existingIDs, length))
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Preemptions[reset]"))
+	c.Ui.Output(formatSimulatePreemptions(result, length))
+
+	if len(result.Eval.FailedTGAllocs) == 0 {
+		return 0
+	}
+
+	c.Ui.Output(c.Colorize().Color("\n[bold][yellow]Failed Placements[reset]"))
+	tgs := make([]string, 0, len(result.Eval.FailedTGAllocs))
+	for tg := range result.Eval.FailedTGAllocs {
+		tgs = append(tgs, tg)
+	}
+	sort.Strings(tgs)
+	for _, tg := range tgs {
+		metrics := result.Eval.FailedTGAllocs[tg]
+		noun := "allocation"
+		if metrics.CoalescedFailures > 0 {
+			noun += "s"
+		}
+		c.Ui.Output(fmt.Sprintf("Task Group %q (failed to place %d %s):",
+			tg, metrics.CoalescedFailures+1, noun))
+		c.Ui.Output(strings.TrimSuffix(formatAllocMetrics(apiAllocMetric(metrics), verbose, "  "), "\n"))
+	}
+	return 2
+}
+
+// formatSimulateTaskGroups summarizes the changes to each task group of the
+// job.
+func formatSimulateTaskGroups(job *structs.Job, result *raftutil.SimulateResult, existing map[string]struct{}) string {
+	type changes struct{ place, update, stop int }
+	byGroup := make(map[string]*changes, len(job.TaskGroups))
+	for _, tg := range job.TaskGroups {
+		byGroup[tg.Name] = &changes{}
+	}
+	group := func(name string) *changes {
+		if byGroup[name] == nil {
+			byGroup[name] = &changes{}
+		}
+		return byGroup[name]
+	}
+
+	for _, plan := range result.Plans {
+		for _, allocs := range plan.NodeAllocation {
+			for _, alloc := range allocs {
+				if _, ok := existing[alloc.ID]; ok {
+					group(alloc.TaskGroup).update++
+				} else {
+					group(alloc.TaskGroup).place++
+				}
+			}
+		}
+		for _, allocs := range plan.NodeUpdate {
+			for _, alloc := range allocs {
+				group(alloc.TaskGroup).stop++
+			}
+		}
+	}
+
+	names := make([]string, 0, len(byGroup))
+	for name := range byGroup {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]string, 0, len(names)+1)
+	out = append(out, "Task Group|Place|In-Place Update|Stop|Unplaced")
+	for _, name := range names {
+		c := byGroup[name]
+		out = append(out, fmt.Sprintf("%s|%d|%d|%d|%d",
+			name, c.place, c.update, c.stop, result.Eval.QueuedAllocations[name]))
+	}
+	return formatList(out)
+}
+
+// formatSimulatePlacements lists the number of allocations placed on each
+// node by task group.
+func formatSimulatePlacements(store *state.StateStore, result *raftutil.SimulateResult, existing map[string]struct{}, length int) string {
+	type key struct{ tg, node string }
+	counts := make(map[key]int)
+	for _, plan := range result.Plans {
+		for nodeID, allocs := range plan.NodeAllocation {
+			for _, alloc := range allocs {
+				if _, ok := existing[alloc.ID]; ok {
+					continue
+				}
+				counts[key{alloc.TaskGroup, nodeID}]++
+			}
+		}
+	}
+	if len(counts) == 0 {
+		return "No allocations placed"
+	}
+
+	keys := make([]key, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].tg != keys[j].tg {
+			return keys[i].tg < keys[j].tg
+		}
+		return keys[i].node < keys[j].node
+	})
+
+	out := make([]string, 0, len(keys)+1)
+	out = append(out, "Task Group|Node ID|Node Name|Allocations")
+	for _, k := range keys {
+		name := ""
+		if node, err := store.NodeByID(nil, k.node); err == nil && node != nil {
+			name = node.Name
+		}
+		out = append(out, fmt.Sprintf("%s|%s|%s|%d", k.tg, limit(k.node, length), name, counts[k]))
+	}
+	return formatList(out)
+}
+
+// formatSimulatePreemptions lists the allocations preempted to make room for
+// the job.
+func formatSimulatePreemptions(result *raftutil.SimulateResult, length int) string {
+	var out []string
+	for _, plan := range result.Plans {
+		for nodeID, allocs := range plan.NodePreemptions {
+			for _, alloc := range allocs {
+				out = append(out, fmt.Sprintf("%s|%s|%s|%s",
+					limit(alloc.ID, length), alloc.JobID, limit(nodeID, length),
+					limit(alloc.PreemptedByAllocation, length)))
+			}
+		}
+	}
+	if len(out) == 0 {
+		return "No allocations preempted"
+	}
+	sort.Strings(out)
+	return formatList(append([]string{"Alloc ID|Job ID|Node ID|Preempted By"}, out...))
+}
+
+// apiAllocMetric converts the allocation metrics computed by the scheduler to
+// their API representation for display.
+func apiAllocMetric(m *structs.AllocMetric) *api.AllocationMetric {
+	out := &api.AllocationMetric{
+		NodesEvaluated:     m.NodesEvaluated,
+		NodesFiltered:      m.NodesFiltered,
+		NodesInPool:        m.NodesInPool,
+		NodesAvailable:     m.NodesAvailable,
+		ClassFiltered:      m.ClassFiltered,
+		ConstraintFiltered: m.ConstraintFiltered,
+		NodesExhausted:     m.NodesExhausted,
+		ClassExhausted:     m.ClassExhausted,
+		DimensionExhausted: m.DimensionExhausted,
+		QuotaExhausted:     m.QuotaExhausted,
+		CoalescedFailures:  m.CoalescedFailures,
+	}
+	for _, score := range m.ScoreMetaData {
+		out.ScoreMetaData = append(out.ScoreMetaData, &api.NodeScoreMeta{
+			NodeID:    score.NodeID,
+			Scores:    score.Scores,
+			NormScore: score.NormScore,
+		})
+	}
+	return out
+}
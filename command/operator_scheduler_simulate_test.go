@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestOperatorSchedulerSimulate_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerSimulateCommand{}
+}
+
+func TestOperatorSchedulerSimulate_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		args   []string
+		expect string
+	}{
+		{
+			name:   "no job",
+			args:   []string{"-snapshot=backup.snap"},
+			expect: "This command takes one argument",
+		},
+		{
+			name:   "no snapshot",
+			args:   []string{"example.nomad.hcl"},
+			expect: "The -snapshot flag is required",
+		},
+		{
+			name:   "add nodes without template",
+			args:   []string{"-snapshot=backup.snap", "-add-nodes=2", "example.nomad.hcl"},
+			expect: "The -add-nodes flag requires -clone-node",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+			code := cmd.Run(tc.args)
+			must.One(t, code)
+			must.StrContains(t, ui.ErrorWriter.String(), tc.expect)
+		})
+	}
+}
+
+func TestOperatorSchedulerSimulate_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	snapPath := generateSnapshotFile(t, nil)
+
+	jobPath := filepath.Join(t.TempDir(), "example.nomad.hcl")
+	must.NoError(t, os.WriteFile(jobPath, []byte(`
+job "example" {
+  datacenters = ["dc1"]
+
+  group "web" {
+    count = 2
+
+    task "web" {
+      driver = "exec"
+
+      config {
+        command = "/bin/sleep"
+      }
+    }
+  }
+}
+`), 0o600))
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+	// The snapshot has no nodes, so every placement fails.
+	code := cmd.Run([]string{"-snapshot=" + snapPath, "-scheduler-algorithm=spread", jobPath})
+	must.Eq(t, 2, code)
+
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "spread")
+	must.StrContains(t, out, "No allocations placed")
+	must.StrContains(t, out, `Task Group "web" (failed to place 2 allocations)`)
+	must.StrContains(t, out, "No nodes were eligible for evaluation")
+}
@@ -268,6 +268,7 @@ func parseQuotaResource(result *api.Resources, list *ast.ObjectList) error {
 	valid := []string{
 		"cores",
 		"cpu",
+		"device",
 		"memory",
 		"memory_max",
 	}
@@ -280,9 +281,51 @@ func parseQuotaResource(result *api.Resources, list *ast.ObjectList) error {
 		return err
 	}
 
+	// Manually parse
+	delete(m, "device")
+
 	if err := mapstructure.WeakDecode(m, result); err != nil {
 		return err
 	}
 
+	// Parse devices
+	if o := listVal.Filter("device"); len(o.Items) > 0 {
+		if err := parseQuotaDevices(&result.Devices, o); err != nil {
+			return multierror.Prefix(err, "device ->")
+		}
+	}
+
+	return nil
+}
+
+// parseQuotaDevices parses the device limits of the region_limit
+func parseQuotaDevices(result *[]*api.RequestedDevice, list *ast.ObjectList) error {
+	for _, o := range list.Items {
+		if len(o.Keys) != 1 {
+			return fmt.Errorf("device block should have exactly one label for the device name")
+		}
+		name := o.Keys[0].Token.Value().(string)
+
+		// Check for invalid keys
+		valid := []string{
+			"count",
+		}
+		if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("%q ->", name))
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, o.Val); err != nil {
+			return err
+		}
+
+		device := api.RequestedDevice{Name: name}
+		if err := mapstructure.WeakDecode(m, &device); err != nil {
+			return err
+		}
+
+		*result = append(*result, &device)
+	}
+
 	return nil
 }
@@ -1,19 +1,13 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: BUSL-1.1
 
-//go:build ent
-// +build ent
-
 package command
 
 import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
-	"github.com/hashicorp/nomad/helper/pointer"
-	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
 	"github.com/shoenig/test/must"
@@ -93,18 +87,3 @@ func TestQuotaDeleteCommand_AutocompleteArgs(t *testing.T) {
 	must.Len(t, 1, res)
 	must.Eq(t, qs.Name, res[0])
 }
-
-// testQuotaSpec returns a test quota specification
-func testQuotaSpec() *api.QuotaSpec {
-	return &api.QuotaSpec{
-		Name: "quota-test-" + uuid.Short(),
-		Limits: []*api.QuotaLimit{
-			{
-				Region: "global",
-				RegionLimit: &api.Resources{
-					CPU: pointer.Of(100),
-				},
-			},
-		},
-	}
-}
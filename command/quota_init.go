@@ -123,8 +123,14 @@ limit {
     cpu        = 2500
     memory     = 1000
     memory_max = 1000
+
+    # Devices are only limited when listed, for example:
+    # device "nvidia/gpu" {
+    #   count = 2
+    # }
   }
-  variables_limit = 1000
+  variables_limit    = 1000
+  host_volumes_limit = 10000
 }
 `)
 
@@ -141,7 +147,8 @@ var defaultJsonQuotaSpec = strings.TrimSpace(`
 				"MemoryMB": 1000,
 				"MemoryMaxMB": 1000
 			},
-			"VariablesLimit": 1000
+			"VariablesLimit": 1000,
+			"HostVolumesLimit": 10000
 		}
 	]
 }
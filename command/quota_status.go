@@ -123,6 +123,12 @@ func (c *QuotaStatusCommand) Run(args []string) int {
 	c.Ui.Output(c.Colorize().Color("\n[bold]Quota Limits[reset]"))
 	c.Ui.Output(formatQuotaLimits(spec, usages))
 
+	// Format the device limits, if any
+	if devices := formatQuotaDeviceLimits(spec, usages); devices != "" {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Device Limits[reset]"))
+		c.Ui.Output(devices)
+	}
+
 	// Display any failures
 	if len(failures) != 0 {
 		c.Ui.Error(c.Colorize().Color("\n[bold][red]Lookup Failures[reset]"))
@@ -188,7 +194,7 @@ func formatQuotaLimits(spec *api.QuotaSpec, usages map[string]*api.QuotaUsage) s
 	sort.Sort(api.QuotaLimitSort(spec.Limits))
 
 	limits := make([]string, len(spec.Limits)+1)
-	limits[0] = "Region|CPU Usage|Core Usage|Memory Usage|Memory Max Usage|Variables Usage"
+	limits[0] = "Region|CPU Usage|Core Usage|Memory Usage|Memory Max Usage|Variables Usage|Host Volumes Usage"
 	i := 0
 	for _, specLimit := range spec.Limits {
 		i++
@@ -212,7 +218,8 @@ func formatQuotaLimits(spec *api.QuotaSpec, usages map[string]*api.QuotaUsage) s
 			memoryMax := fmt.Sprintf("- / %s", formatQuotaLimitInt(specLimit.RegionLimit.MemoryMaxMB))
 
 			vars := fmt.Sprintf("- / %s", formatQuotaLimitInt(specLimit.VariablesLimit))
-			limits[i] = fmt.Sprintf("%s|%s|%s|%s|%s|%s", specLimit.Region, cpu, cores, memory, memoryMax, vars)
+			vols := fmt.Sprintf("- / %s", formatQuotaLimitInt(specLimit.HostVolumesLimit))
+			limits[i] = fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s", specLimit.Region, cpu, cores, memory, memoryMax, vars, vols)
 			continue
 		}
 
@@ -229,12 +236,58 @@ func formatQuotaLimits(spec *api.QuotaSpec, usages map[string]*api.QuotaUsage) s
 		memoryMax := fmt.Sprintf("%d / %s", orZero(used.RegionLimit.MemoryMaxMB), formatQuotaLimitInt(specLimit.RegionLimit.MemoryMaxMB))
 
 		vars := fmt.Sprintf("%d / %s", orZero(used.VariablesLimit), formatQuotaLimitInt(specLimit.VariablesLimit))
-		limits[i] = fmt.Sprintf("%s|%s|%s|%s|%s|%s", specLimit.Region, cpu, cores, memory, memoryMax, vars)
+		vols := fmt.Sprintf("%d / %s", orZero(used.HostVolumesLimit), formatQuotaLimitInt(specLimit.HostVolumesLimit))
+		limits[i] = fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s", specLimit.Region, cpu, cores, memory, memoryMax, vars, vols)
 	}
 
 	return formatList(limits)
 }
 
+// formatQuotaDeviceLimits formats the device limits to display the device
+// usage versus the limit per region. It returns an empty string if the quota
+// doesn't limit any devices.
+func formatQuotaDeviceLimits(spec *api.QuotaSpec, usages map[string]*api.QuotaUsage) string {
+	// Sort the limits
+	sort.Sort(api.QuotaLimitSort(spec.Limits))
+
+	var devices []string
+	for _, specLimit := range spec.Limits {
+		if specLimit.RegionLimit == nil {
+			continue
+		}
+
+		var used *api.QuotaLimit
+		if usage, ok := usages[specLimit.Region]; ok {
+			used = usage.Used[base64.StdEncoding.EncodeToString(specLimit.Hash)]
+		}
+
+		for _, d := range specLimit.RegionLimit.Devices {
+			usedCount := "-"
+			if used != nil && used.RegionLimit != nil {
+				var count uint64
+				for _, ud := range used.RegionLimit.Devices {
+					if ud.Name == d.Name && ud.Count != nil {
+						count = *ud.Count
+					}
+				}
+				usedCount = strconv.FormatUint(count, 10)
+			}
+
+			var limit uint64
+			if d.Count != nil {
+				limit = *d.Count
+			}
+			devices = append(devices, fmt.Sprintf("%s|%s|%s / %d",
+				specLimit.Region, d.Name, usedCount, limit))
+		}
+	}
+
+	if len(devices) == 0 {
+		return ""
+	}
+	return formatList(append([]string{"Region|Device|Usage"}, devices...))
+}
+
 // formatQuotaLimitInt takes a integer resource value and returns the
 // appropriate string for output.
 func formatQuotaLimitInt(value *int) string {
@@ -65,23 +65,14 @@ func TestRecommendationDismissCommand_Run(t *testing.T) {
 		Stats:    map[string]float64{"p13": 1.13},
 	}
 	recResp, _, err := client.Recommendations().Upsert(&rec, nil)
-	if srv.Enterprise {
-		must.NoError(t, err)
-
-		// Read the recommendation out to ensure it is there as a control on
-		// later tests.
-		recInfo, _, err := client.Recommendations().Info(recResp.ID, nil)
-		must.NoError(t, err)
-		must.NotNil(t, recInfo)
-	} else {
-		must.ErrorContains(t, err, "Nomad Enterprise only endpoint")
-	}
+	must.NoError(t, err)
+
+	// Read the recommendation out to ensure it is there as a control on later
+	// tests.
+	recInfo, _, err := client.Recommendations().Info(recResp.ID, nil)
+	must.NoError(t, err)
+	must.NotNil(t, recInfo)
 
-	// Only perform the call if we are running enterprise tests. Otherwise the
-	// recResp object will be nil.
-	if !srv.Enterprise {
-		return
-	}
 	code := cmd.Run([]string{"-address=" + url, recResp.ID})
 	must.Zero(t, code)
 	out := ui.OutputWriter.String()
@@ -89,7 +80,7 @@ func TestRecommendationDismissCommand_Run(t *testing.T) {
 
 	// Perform an info call on the recommendation which should return not
 	// found.
-	recInfo, _, err := client.Recommendations().Info(recResp.ID, nil)
+	recInfo, _, err = client.Recommendations().Info(recResp.ID, nil)
 	must.ErrorContains(t, err, "not found")
 	must.Nil(t, recInfo)
 }
@@ -129,12 +120,7 @@ func testRecommendationAutocompleteCommand(t *testing.T, client *api.Client, srv
 		Stats:    map[string]float64{"p13": 1.13},
 	}
 	rec, _, err = client.Recommendations().Upsert(rec, nil)
-	if srv.Enterprise {
-		must.NoError(t, err)
-	} else {
-		must.ErrorContains(t, err, "Nomad Enterprise only endpoint")
-		return
-	}
+	must.NoError(t, err)
 
 	prefix := rec.ID[:5]
 	args := complete.Args{Last: prefix}
@@ -44,14 +44,8 @@ func TestRecommendationInfoCommand_Run(t *testing.T) {
 
 	// Perform an initial call, which should return a not found error.
 	code := cmd.Run([]string{"-address=" + url, "2c13f001-f5b6-ce36-03a5-e37afe160df5"})
-	if srv.Enterprise {
-		must.One(t, code)
-		out := ui.ErrorWriter.String()
-		must.StrContains(t, out, "Recommendation not found")
-	} else {
-		must.One(t, code)
-		must.StrContains(t, ui.ErrorWriter.String(), "Nomad Enterprise only endpoint")
-	}
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Recommendation not found")
 
 	// Register a test job to write a recommendation against.
 	testJob := testJob("recommendation_info")
@@ -71,23 +65,15 @@ func TestRecommendationInfoCommand_Run(t *testing.T) {
 		Stats:    map[string]float64{"p13": 1.13},
 	}
 	recResp, _, err := client.Recommendations().Upsert(&rec, nil)
-	if srv.Enterprise {
-		must.NoError(t, err)
-	} else {
-		must.ErrorContains(t, err, "Nomad Enterprise only endpoint")
-	}
+	must.NoError(t, err)
 
-	// Only perform the call if we are running enterprise tests. Otherwise the
-	// recResp object will be nil.
-	if srv.Enterprise {
-		code = cmd.Run([]string{"-address=" + url, recResp.ID})
-		must.Zero(t, code)
-		out := ui.OutputWriter.String()
-		must.StrContains(t, out, "test-meta-entry")
-		must.StrContains(t, out, "p13")
-		must.StrContains(t, out, "1.13")
-		must.StrContains(t, out, recResp.ID)
-	}
+	code = cmd.Run([]string{"-address=" + url, recResp.ID})
+	must.Zero(t, code)
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "test-meta-entry")
+	must.StrContains(t, out, "p13")
+	must.StrContains(t, out, "1.13")
+	must.StrContains(t, out, recResp.ID)
 }
 
 func TestRecommendationInfoCommand_AutocompleteArgs(t *testing.T) {
@@ -29,14 +29,8 @@ func TestRecommendationListCommand_Run(t *testing.T) {
 
 	// Perform an initial list, which should return zero results.
 	code := cmd.Run([]string{"-address=" + url})
-	if srv.Enterprise {
-		must.Zero(t, code)
-		out := ui.OutputWriter.String()
-		must.StrContains(t, out, "No recommendations found")
-	} else {
-		must.One(t, code)
-		must.StrContains(t, ui.ErrorWriter.String(), "Nomad Enterprise only endpoint")
-	}
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "No recommendations found")
 
 	// Register a test job to write a recommendation against.
 	testJob := testJob("recommendation_list")
@@ -56,28 +50,19 @@ func TestRecommendationListCommand_Run(t *testing.T) {
 		Stats:    map[string]float64{"p13": 1.13},
 	}
 	_, _, err = client.Recommendations().Upsert(&rec, nil)
-	if srv.Enterprise {
-		must.NoError(t, err)
-	} else {
-		must.ErrorContains(t, err, "Nomad Enterprise only endpoint")
-	}
+	must.NoError(t, err)
 
 	// Perform a new list which should yield results.
 	code = cmd.Run([]string{"-address=" + url})
-	if srv.Enterprise {
-		must.Zero(t, code)
-		out := ui.OutputWriter.String()
-		must.StrContains(t, out, "ID")
-		must.StrContains(t, out, "Job")
-		must.StrContains(t, out, "Group")
-		must.StrContains(t, out, "Task")
-		must.StrContains(t, out, "Resource")
-		must.StrContains(t, out, "Value")
-		must.StrContains(t, out, "CPU")
-	} else {
-		must.One(t, code)
-		must.StrContains(t, ui.ErrorWriter.String(), "Nomad Enterprise only endpoint")
-	}
+	must.Zero(t, code)
+	out := ui.OutputWriter.String()
+	must.StrContains(t, out, "ID")
+	must.StrContains(t, out, "Job")
+	must.StrContains(t, out, "Group")
+	must.StrContains(t, out, "Task")
+	must.StrContains(t, out, "Resource")
+	must.StrContains(t, out, "Value")
+	must.StrContains(t, out, "CPU")
 }
 
 func TestRecommendationListCommand_Sort(t *testing.T) {
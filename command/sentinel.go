@@ -53,3 +53,11 @@ func (f *SentinelCommand) Name() string { return "sentinel" }
 func (f *SentinelCommand) Run(args []string) int {
 	return cli.RunResultHelp
 }
+
+// formatSentinelNamespaces formats the namespaces a policy is enforced in.
+func formatSentinelNamespaces(namespaces []string) string {
+	if len(namespaces) == 0 {
+		return "<all>"
+	}
+	return strings.Join(namespaces, ",")
+}
@@ -25,8 +25,7 @@ Usage: nomad sentinel apply [options] <name> <file>
   The name of the policy and file must be specified. The file will be read
   from stdin by specifying "-".
 
-  Sentinel commands are only available when ACLs are enabled. This command
-  requires a management token.
+  When ACLs are enabled, this command requires a management token.
 
 General Options:
 
@@ -44,6 +43,10 @@ Apply Options:
     Sets the enforcement level of the policy. Must be one of advisory,
     soft-mandatory, hard-mandatory.
 
+  -namespaces
+    Comma separated list of namespaces the policy is enforced in. The policy
+    is enforced in all namespaces if not set.
+
 `
 	return strings.TrimSpace(helpText)
 }
@@ -54,6 +57,7 @@ func (c *SentinelApplyCommand) AutocompleteFlags() complete.Flags {
 			"-description": complete.PredictAnything,
 			"-scope":       complete.PredictAnything,
 			"-level":       complete.PredictAnything,
+			"-namespaces":  complete.PredictAnything,
 		})
 }
 
@@ -68,13 +72,14 @@ func (c *SentinelApplyCommand) Synopsis() string {
 func (c *SentinelApplyCommand) Name() string { return "sentinel apply" }
 
 func (c *SentinelApplyCommand) Run(args []string) int {
-	var description, scope, enfLevel string
+	var description, scope, enfLevel, namespaces string
 	var err error
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.StringVar(&description, "description", "", "")
 	flags.StringVar(&scope, "scope", "submit-job", "")
 	flags.StringVar(&enfLevel, "level", "advisory", "")
+	flags.StringVar(&namespaces, "namespaces", "", "")
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
@@ -115,6 +120,11 @@ func (c *SentinelApplyCommand) Run(args []string) int {
 		EnforcementLevel: enfLevel,
 		Policy:           string(rawPolicy),
 	}
+	if namespaces != "" {
+		for _, ns := range strings.Split(namespaces, ",") {
+			sp.Namespaces = append(sp.Namespaces, strings.TrimSpace(ns))
+		}
+	}
 
 	// Get the HTTP client
 	client, err := c.Meta.Client()
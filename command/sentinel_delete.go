@@ -20,8 +20,7 @@ Usage: nomad sentinel delete [options] <name>
 
   Delete is used to delete an existing Sentinel policy.
 
-  Sentinel commands are only available when ACLs are enabled. This command
-  requires a management token.
+  When ACLs are enabled, this command requires a management token.
 
 General Options:
 
@@ -20,8 +20,7 @@ Usage: nomad sentinel list [options]
 
   List is used to display all the installed Sentinel policies.
 
-  Sentinel commands are only available when ACLs are enabled. This command
-  requires a management token.
+  When ACLs are enabled, this command requires a management token.
 
 General Options:
 
@@ -77,9 +76,10 @@ func (c *SentinelListCommand) Run(args []string) int {
 	}
 
 	out := []string{}
-	out = append(out, "Name|Scope|Enforcement Level|Description")
+	out = append(out, "Name|Scope|Enforcement Level|Namespaces|Description")
 	for _, p := range policies {
-		line := fmt.Sprintf("%s|%s|%s|%s", p.Name, p.Scope, p.EnforcementLevel, p.Description)
+		line := fmt.Sprintf("%s|%s|%s|%s|%s", p.Name, p.Scope, p.EnforcementLevel,
+			formatSentinelNamespaces(p.Namespaces), p.Description)
 		out = append(out, line)
 	}
 	c.Ui.Output(formatList(out))
@@ -20,8 +20,7 @@ Usage: nomad sentinel read [options] <name>
 
   Read is used to inspect a Sentinel policy.
 
-  Sentinel commands are only available when ACLs are enabled. This command
-  requires a management token.
+  When ACLs are enabled, this command requires a management token.
 
 General Options:
 
@@ -98,6 +97,7 @@ func (c *SentinelReadCommand) Run(args []string) int {
 		fmt.Sprintf("Name|%s", policy.Name),
 		fmt.Sprintf("Scope|%s", policy.Scope),
 		fmt.Sprintf("Enforcement Level|%s", policy.EnforcementLevel),
+		fmt.Sprintf("Namespaces|%s", formatSentinelNamespaces(policy.Namespaces)),
 		fmt.Sprintf("Description|%s", policy.Description),
 	}
 	c.Ui.Output(formatKV(info))
@@ -20,13 +20,15 @@ func (c *VolumeCreateCommand) Help() string {
 	helpText := `
 Usage: nomad volume create [options] <input>
 
-  Creates a volume in an external storage provider and registers it in Nomad.
+  Creates a volume in an external storage provider and registers it in Nomad,
+  or creates a dynamic host volume on a client node with a host volume plugin.
 
   If the supplied path is "-" the volume file is read from stdin. Otherwise, it
   is read from the file at the supplied path.
 
   When ACLs are enabled, this command requires a token with the
-  'csi-write-volume' capability for the volume's namespace.
+  'csi-write-volume' capability for CSI volumes or the 'host-volume-create'
+  capability for host volumes, in the volume's namespace.
 
 General Options:
 
@@ -44,7 +46,7 @@ func (c *VolumeCreateCommand) AutocompleteArgs() complete.Predictor {
 }
 
 func (c *VolumeCreateCommand) Synopsis() string {
-	return "Create an external volume"
+	return "Create an external volume or a dynamic host volume"
 }
 
 func (c *VolumeCreateCommand) Name() string { return "volume create" }
@@ -101,6 +103,8 @@ func (c *VolumeCreateCommand) Run(args []string) int {
 	case "csi":
 		code := c.csiCreate(client, ast)
 		return code
+	case "host":
+		return c.hostVolumeCreate(client, ast)
 	default:
 		c.Ui.Error(fmt.Sprintf("Error unknown volume type: %s", volType))
 		return 1
@@ -16,6 +16,7 @@ import (
 type VolumeDeleteCommand struct {
 	Meta
 	Secrets string
+	typeArg string
 }
 
 func (c *VolumeDeleteCommand) Help() string {
@@ -28,8 +29,13 @@ Usage: nomad volume delete [options] <vol id>
   unpublished. If the volume no longer exists, this command will silently
   return without an error.
 
+  With -type=host, delete a dynamic host volume from its node and deregister
+  it. Deleting will fail if the volume is in use by an allocation that is not
+  terminal.
+
   When ACLs are enabled, this command requires a token with the
-  'csi-write-volume' and 'csi-read-volume' capabilities for the volume's
+  'csi-write-volume' and 'csi-read-volume' capabilities for CSI volumes, or
+  the 'host-volume-delete' capability for host volumes, in the volume's
   namespace.
 
 General Options:
@@ -40,14 +46,20 @@ Delete Options:
 
   -secret
     Secrets to pass to the plugin to delete the snapshot. Accepts multiple
-    flags in the form -secret key=value
+    flags in the form -secret key=value. Only valid for CSI volumes.
+
+  -type <csi|host>
+    Type of volume to delete. Defaults to "csi".
 `
 	return strings.TrimSpace(helpText)
 }
 
 func (c *VolumeDeleteCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
-		complete.Flags{})
+		complete.Flags{
+			"-type":   complete.PredictSet("csi", "host"),
+			"-secret": complete.PredictNothing,
+		})
 }
 
 func (c *VolumeDeleteCommand) AutocompleteArgs() complete.Predictor {
@@ -83,6 +95,7 @@ func (c *VolumeDeleteCommand) Run(args []string) int {
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.Var(&secretsArgs, "secret", "secrets for snapshot, ex. -secret key=value")
+	flags.StringVar(&c.typeArg, "type", "csi", "type of volume (csi or host)")
 
 	if err := flags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error parsing arguments %s", err))
@@ -105,6 +118,15 @@ func (c *VolumeDeleteCommand) Run(args []string) int {
 		return 1
 	}
 
+	switch c.typeArg {
+	case "csi":
+	case "host":
+		return c.deleteHostVolume(client, volID)
+	default:
+		c.Ui.Error(fmt.Sprintf("No such volume type %q", c.typeArg))
+		return 1
+	}
+
 	secrets := api.CSISecrets{}
 	for _, kv := range secretsArgs {
 		if key, value, found := strings.Cut(kv, "="); found {
@@ -127,3 +149,14 @@ func (c *VolumeDeleteCommand) Run(args []string) int {
 	c.Ui.Output(fmt.Sprintf("Successfully deleted volume %q!", volID))
 	return 0
 }
+
+func (c *VolumeDeleteCommand) deleteHostVolume(client *api.Client, volID string) int {
+	_, err := client.HostVolumes().Delete(volID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error deleting volume: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully deleted volume %q!", volID))
+	return 0
+}
@@ -24,12 +24,13 @@ func (c *VolumeStatusCommand) Help() string {
 	helpText := `
 Usage: nomad volume status [options] <id>
 
-  Display status information about a CSI volume. If no volume id is given, a
-  list of all volumes will be displayed.
+  Display status information about a CSI volume or, with -type=host, a
+  dynamic host volume. If no volume id is given, a list of all volumes will be
+  displayed.
 
   When ACLs are enabled, this command requires a token with the
-  'csi-read-volume' and 'csi-list-volumes' capability for the volume's
-  namespace.
+  'csi-read-volume' and 'csi-list-volumes' capability for CSI volumes, or the
+  'host-volume-read' capability for host volumes, in the volume's namespace.
 
 General Options:
 
@@ -130,6 +131,15 @@ func (c *VolumeStatusCommand) Run(args []string) int {
 		id = args[0]
 	}
 
+	switch typeArg {
+	case "host":
+		return c.hostVolumeStatus(client, id)
+	case "csi", "":
+	default:
+		c.Ui.Error(fmt.Sprintf("No such volume type %q", typeArg))
+		return 1
+	}
+
 	code := c.csiStatus(client, id)
 	if code != 0 {
 		return code
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/api"
+)
+
+func (c *VolumeStatusCommand) hostVolumeStatus(client *api.Client, id string) int {
+	// Invoke list mode if no volume id
+	if id == "" {
+		return c.listHostVolumes(client)
+	}
+
+	// get a host volume that matches the given prefix or a list of all
+	// matches if an exact match is not found.
+	volStub, possible, err := getByPrefix[api.HostVolumeStub]("volumes",
+		func(q *api.QueryOptions) ([]*api.HostVolumeStub, *api.QueryMeta, error) {
+			return client.HostVolumes().List(nil, q)
+		},
+		func(vol *api.HostVolumeStub, prefix string) bool { return vol.ID == prefix },
+		&api.QueryOptions{
+			Prefix:    id,
+			Namespace: c.namespace,
+		})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing volumes: %s", err))
+		return 1
+	}
+	if len(possible) > 0 {
+		out, err := c.formatHostVolumes(possible)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting: %s", err))
+			return 1
+		}
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple volumes\n\n%s", out))
+		return 1
+	}
+
+	vol, _, err := client.HostVolumes().Get(volStub.ID, &api.QueryOptions{Namespace: volStub.Namespace})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying volume: %s", err))
+		return 1
+	}
+
+	str, err := c.formatHostVolume(vol)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error formatting volume: %s", err))
+		return 1
+	}
+	c.Ui.Output(str)
+	return 0
+}
+
+func (c *VolumeStatusCommand) listHostVolumes(client *api.Client) int {
+	if !(c.json || len(c.template) > 0) {
+		c.Ui.Output(c.Colorize().Color("[bold]Dynamic Host Volumes[reset]"))
+	}
+
+	vols, _, err := client.HostVolumes().List(nil, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying volumes: %s", err))
+		return 1
+	}
+
+	if len(vols) == 0 {
+		// No output if we have no volumes
+		c.Ui.Error("No dynamic host volumes")
+		return 0
+	}
+
+	str, err := c.formatHostVolumes(vols)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error formatting: %s", err))
+		return 1
+	}
+	c.Ui.Output(str)
+	return 0
+}
+
+func (c *VolumeStatusCommand) formatHostVolume(vol *api.HostVolume) (string, error) {
+	if c.json || len(c.template) > 0 {
+		out, err := Format(c.json, c.template, vol)
+		if err != nil {
+			return "", fmt.Errorf("format error: %v", err)
+		}
+		return out, nil
+	}
+
+	output := []string{
+		fmt.Sprintf("ID|%s", vol.ID),
+		fmt.Sprintf("Name|%s", vol.Name),
+		fmt.Sprintf("Namespace|%s", vol.Namespace),
+		fmt.Sprintf("Plugin ID|%s", vol.PluginID),
+		fmt.Sprintf("Node ID|%s", vol.NodeID),
+		fmt.Sprintf("Node Pool|%s", vol.NodePool),
+		fmt.Sprintf("Capacity|%s", humanize.IBytes(uint64(vol.CapacityBytes))),
+		fmt.Sprintf("State|%s", vol.State),
+		fmt.Sprintf("Host Path|%s", vol.HostPath),
+	}
+
+	if c.short || len(vol.Parameters) == 0 {
+		return formatKV(output), nil
+	}
+
+	full := []string{formatKV(output)}
+	full = append(full, c.Colorize().Color("\n[bold]Parameters[reset]"))
+	keys := make([]string, 0, len(vol.Parameters))
+	for k := range vol.Parameters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	params := make([]string, 0, len(keys))
+	for _, k := range keys {
+		params = append(params, fmt.Sprintf("%s|%s", k, vol.Parameters[k]))
+	}
+	full = append(full, formatKV(params))
+
+	return strings.Join(full, "\n"), nil
+}
+
+func (c *VolumeStatusCommand) formatHostVolumes(vols []*api.HostVolumeStub) (string, error) {
+	// Sort the output by volume id
+	sort.Slice(vols, func(i, j int) bool { return vols[i].ID < vols[j].ID })
+
+	if c.json || len(c.template) > 0 {
+		out, err := Format(c.json, c.template, vols)
+		if err != nil {
+			return "", fmt.Errorf("format error: %v", err)
+		}
+		return out, nil
+	}
+
+	rows := make([]string, len(vols)+1)
+	rows[0] = "ID|Name|Namespace|Plugin ID|Node ID|Node Pool|State"
+	for i, v := range vols {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s",
+			limit(v.ID, c.length),
+			v.Name,
+			v.Namespace,
+			v.PluginID,
+			limit(v.NodeID, c.length),
+			v.NodePool,
+			v.State,
+		)
+	}
+	return formatList(rows), nil
+}
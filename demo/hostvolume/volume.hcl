@@ -0,0 +1,12 @@
+# Copyright (c) HashiCorp, Inc.
+# SPDX-License-Identifier: BUSL-1.1
+
+type         = "host"
+name         = "database"
+plugin_id    = "example-plugin-mkfs"
+capacity_min = "50MiB"
+capacity_max = "50MiB"
+
+parameters {
+  owner = "nomad"
+}
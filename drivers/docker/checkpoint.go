@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// checkpointName is the name of the checkpoint Nomad creates for a container.
+// Each task gets its own checkpoint directory, so a fixed name is enough to
+// find it again on restore.
+const checkpointName = "nomad"
+
+var _ drivers.CheckpointDriver = (*Driver)(nil)
+
+// CheckpointTask dumps the state of the task's container into dir, stopping
+// the container.
+func (d *Driver) CheckpointTask(taskID string, dir string) error {
+	if !d.checkpoint.Load() {
+		return fmt.Errorf("checkpoints require an experimental docker daemon and criu")
+	}
+
+	h, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	client, err := d.getInfinityClient()
+	if err != nil {
+		return fmt.Errorf("failed to create long operations docker client: %v", err)
+	}
+
+	body := map[string]any{
+		"CheckpointID":  checkpointName,
+		"CheckpointDir": dir,
+		"Exit":          true,
+	}
+	path := "/containers/" + url.PathEscape(h.containerID) + "/checkpoints"
+	if err := dockerAPIPost(client, path, nil, body); err != nil {
+		return fmt.Errorf("failed to checkpoint container %s: %v", h.containerID, err)
+	}
+	return nil
+}
+
+// RestoreTask starts the task from the container checkpoint in dir.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if !d.checkpoint.Load() {
+		return nil, nil, fmt.Errorf("checkpoints require an experimental docker daemon and criu")
+	}
+	return d.startTask(cfg, dir)
+}
+
+// restoreContainer starts a created container from the checkpoint in dir
+// instead of running its entrypoint.
+func (d *Driver) restoreContainer(c *docker.Container, dir string) error {
+	client, err := d.getInfinityClient()
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("checkpoint", checkpointName)
+	query.Set("checkpoint-dir", dir)
+	path := "/containers/" + url.PathEscape(c.ID) + "/start"
+	return dockerAPIPost(client, path, query, nil)
+}
+
+// dockerAPIPost sends a POST request to the docker API for the endpoints
+// go-dockerclient does not wrap, reusing the client's connection settings.
+func dockerAPIPost(client *docker.Client, path string, query url.Values, body any) error {
+	base, err := dockerAPIBaseURL(client)
+	if err != nil {
+		return err
+	}
+
+	u := base + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var r io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(msg, &apiErr) == nil && apiErr.Message != "" {
+			msg = []byte(apiErr.Message)
+		}
+		return &docker.Error{Status: resp.StatusCode, Message: strings.TrimSpace(string(msg))}
+	}
+	return nil
+}
+
+// dockerAPIBaseURL returns the URL the client's HTTP client expects requests
+// to be sent to. Socket endpoints are dialed by the client's transport, so
+// only the path of those requests matters.
+func dockerAPIBaseURL(client *docker.Client) (string, error) {
+	endpoint := client.Endpoint()
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "tcp://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid docker endpoint %q: %v", client.Endpoint(), err)
+	}
+
+	switch u.Scheme {
+	case "unix", "npipe":
+		return "http://unix.sock", nil
+	case "tcp", "http", "https":
+		if client.TLSConfig != nil || u.Scheme == "https" {
+			return "https://" + u.Host, nil
+		}
+		return "http://" + u.Host, nil
+	default:
+		return "", fmt.Errorf("unsupported docker endpoint scheme %q", u.Scheme)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package docker
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestDockerAPIBaseURL(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		endpoint string
+		expected string
+	}{
+		{"unix:///var/run/docker.sock", "http://unix.sock"},
+		{"tcp://127.0.0.1:2375", "http://127.0.0.1:2375"},
+		{"127.0.0.1:2375", "http://127.0.0.1:2375"},
+		{"http://127.0.0.1:2375", "http://127.0.0.1:2375"},
+		{"https://127.0.0.1:2376", "https://127.0.0.1:2376"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.endpoint, func(t *testing.T) {
+			client, err := docker.NewClient(tc.endpoint)
+			must.NoError(t, err)
+
+			base, err := dockerAPIBaseURL(client)
+			must.NoError(t, err)
+			must.Eq(t, tc.expected, base)
+		})
+	}
+}
+
+func TestDockerAPIPost(t *testing.T) {
+	ci.Parallel(t)
+
+	var gotPath, gotQuery string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		gotBody = nil
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+
+		if r.URL.Path == "/containers/missing/checkpoints" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"No such container: missing"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client, err := docker.NewClient(srv.URL)
+	must.NoError(t, err)
+
+	body := map[string]any{"CheckpointID": checkpointName, "Exit": true}
+	must.NoError(t, dockerAPIPost(client, "/containers/abc/checkpoints", nil, body))
+	must.Eq(t, "/containers/abc/checkpoints", gotPath)
+	must.Eq(t, checkpointName, gotBody["CheckpointID"])
+	must.Eq(t, true, gotBody["Exit"])
+
+	err = dockerAPIPost(client, "/containers/missing/checkpoints", nil, body)
+	var apiErr *docker.Error
+	must.True(t, errors.As(err, &apiErr))
+	must.Eq(t, http.StatusNotFound, apiErr.Status)
+	must.Eq(t, "No such container: missing", apiErr.Message)
+
+	query := map[string][]string{"checkpoint": {checkpointName}, "checkpoint-dir": {"/tmp/ckpt"}}
+	must.NoError(t, dockerAPIPost(client, "/containers/abc/start", query, nil))
+	must.Eq(t, "/containers/abc/start", gotPath)
+	must.Eq(t, "checkpoint=nomad&checkpoint-dir=%2Ftmp%2Fckpt", gotQuery)
+}
@@ -821,6 +821,8 @@ func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
 // Capabilities is returned by the Capabilities RPC and indicates what optional
 // features this driver supports.
 func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
-	driverCapabilities.DisableLogCollection = d.config != nil && d.config.DisableLogCollection
-	return driverCapabilities, nil
+	caps := *driverCapabilities
+	caps.DisableLogCollection = d.config != nil && d.config.DisableLogCollection
+	caps.Checkpoint = d.checkpoint.Load()
+	return &caps, nil
 }
@@ -6,6 +6,7 @@ package docker
 import (
 	"context"
 	"fmt"
+	"maps"
 	"regexp"
 	"sync"
 	"time"
@@ -21,6 +22,17 @@ var (
 	imageNotFoundMatcher = regexp.MustCompile(`Error: image .+ not found`)
 )
 
+const (
+	// prefetchCallerID is the caller holding a reference on prefetched images
+	// until a task uses them.
+	prefetchCallerID = "prefetch"
+
+	// maxCachedImages is the number of images reported as cached. Beyond it,
+	// the least recently used images stop being reported, which bounds the
+	// number of node attributes.
+	maxCachedImages = 64
+)
+
 // pullFuture is a sharable future for retrieving a pulled images ID and user,
 // and any error that may have occurred during the pull.
 type pullFuture struct {
@@ -117,6 +129,19 @@ type dockerCoordinator struct {
 
 	// deleteFuture is indexed by image ID and has a cancelable delete future
 	deleteFuture map[string]context.CancelFunc
+
+	// cachedImages maps the names of the images pulled or used by tasks to
+	// their image ID, until the image is removed
+	cachedImages map[string]cachedImage
+
+	// cacheSeq orders the uses of the cached images
+	cacheSeq uint64
+}
+
+// cachedImage is an image known to be present and when it was last used.
+type cachedImage struct {
+	id  string
+	seq uint64
 }
 
 // newDockerCoordinator returns a new Docker coordinator
@@ -131,6 +156,7 @@ func newDockerCoordinator(config *dockerCoordinatorConfig) *dockerCoordinator {
 		pullLoggers:             make(map[string][]LogEventFn),
 		imageRefCount:           make(map[string]map[string]struct{}),
 		deleteFuture:            make(map[string]context.CancelFunc),
+		cachedImages:            make(map[string]cachedImage),
 	}
 }
 
@@ -161,6 +187,10 @@ func (d *dockerCoordinator) PullImage(image string, authOptions *docker.AuthConf
 	// Nomad).
 	delete(d.pullFutures, image)
 
+	if err == nil {
+		d.cacheImageImpl(image, id)
+	}
+
 	// If we are cleaning up, we increment the reference count on the image
 	if err == nil && d.cleanup {
 		d.incrementImageReferenceImpl(id, image, callerID)
@@ -169,6 +199,54 @@ func (d *dockerCoordinator) PullImage(image string, authOptions *docker.AuthConf
 	return id, user, err
 }
 
+// PrefetchImage pulls an image in the background so that it is cached before
+// any task uses it. When cleaning up images, the image is kept until a task
+// references it and from then on removed like any other image.
+func (d *dockerCoordinator) PrefetchImage(image string, authOptions *docker.AuthConfiguration,
+	pullTimeout, pullActivityTimeout time.Duration) {
+	go func() {
+		_, _, err := d.PullImage(image, authOptions, prefetchCallerID, noopLogEventFn,
+			pullTimeout, pullActivityTimeout)
+		if err != nil {
+			d.logger.Warn("failed to prefetch image", "image_name", image, "error", err)
+			return
+		}
+		d.logger.Debug("prefetched image", "image_name", image)
+	}()
+}
+
+// CachedImages returns the names of the images known to be present, mapped to
+// their image ID. At most maxCachedImages images are returned.
+func (d *dockerCoordinator) CachedImages() map[string]string {
+	d.imageLock.Lock()
+	defer d.imageLock.Unlock()
+	images := make(map[string]string, len(d.cachedImages))
+	for name, img := range d.cachedImages {
+		images[name] = img.id
+	}
+	return images
+}
+
+// cacheImageImpl records the image as present and recently used, forgetting
+// the least recently used image once more than maxCachedImages are known. The
+// image lock must be held.
+func (d *dockerCoordinator) cacheImageImpl(image, id string) {
+	d.cacheSeq++
+	d.cachedImages[structs.NormalizeImageRef(image)] = cachedImage{id: id, seq: d.cacheSeq}
+	if len(d.cachedImages) <= maxCachedImages {
+		return
+	}
+
+	var oldest string
+	oldestSeq := d.cacheSeq
+	for name, img := range d.cachedImages {
+		if img.seq < oldestSeq {
+			oldest, oldestSeq = name, img.seq
+		}
+	}
+	delete(d.cachedImages, oldest)
+}
+
 // pullImageImpl is the implementation of pulling an image. The results are
 // returned via the passed future
 func (d *dockerCoordinator) pullImageImpl(image string, authOptions *docker.AuthConfiguration,
@@ -234,6 +312,7 @@ func (d *dockerCoordinator) pullImageImpl(image string, authOptions *docker.Auth
 func (d *dockerCoordinator) IncrementImageReference(imageID, imageName, callerID string) {
 	d.imageLock.Lock()
 	defer d.imageLock.Unlock()
+	d.cacheImageImpl(imageName, imageID)
 	if d.cleanup {
 		d.incrementImageReferenceImpl(imageID, imageName, callerID)
 	}
@@ -259,6 +338,11 @@ func (d *dockerCoordinator) incrementImageReferenceImpl(imageID, imageName, call
 		references[callerID] = struct{}{}
 		d.logger.Debug("image reference count incremented", "image_name", imageName, "image_id", imageID, "references", len(references))
 	}
+
+	// Once a task uses a prefetched image it is removed with its last task
+	if callerID != prefetchCallerID {
+		delete(references, prefetchCallerID)
+	}
 }
 
 // RemoveImage removes the given image. If there are any errors removing the
@@ -339,6 +423,7 @@ func (d *dockerCoordinator) removeImageImpl(id string, ctx context.Context) {
 
 		if err == docker.ErrNoSuchImage {
 			d.logger.Debug("unable to cleanup image, does not exist", "image_id", id)
+			d.forgetImage(id)
 			return
 		}
 		if derr, ok := err.(*docker.Error); ok && derr.Status == 409 {
@@ -358,6 +443,7 @@ func (d *dockerCoordinator) removeImageImpl(id string, ctx context.Context) {
 	}
 
 	d.logger.Debug("cleanup removed downloaded image", "image_id", id)
+	d.forgetImage(id)
 
 	// Cleanup the future from the map and free the context by cancelling it
 	d.imageLock.Lock()
@@ -368,6 +454,15 @@ func (d *dockerCoordinator) removeImageImpl(id string, ctx context.Context) {
 	d.imageLock.Unlock()
 }
 
+// forgetImage stops reporting the removed image as cached
+func (d *dockerCoordinator) forgetImage(id string) {
+	d.imageLock.Lock()
+	defer d.imageLock.Unlock()
+	maps.DeleteFunc(d.cachedImages, func(_ string, img cachedImage) bool {
+		return img.id == id
+	})
+}
+
 func (d *dockerCoordinator) registerPullLogger(image string, logger LogEventFn) {
 	d.pullLoggerLock.Lock()
 	defer d.pullLoggerLock.Unlock()
@@ -320,3 +320,85 @@ func TestDockerCoordinator_Cleanup_HonorsCtx(t *testing.T) {
 	// Check that only no delete happened
 	require.Equal(t, map[string]int{id1: 1}, mock.removed, "removed images")
 }
+
+func TestDockerCoordinator_PrefetchImage(t *testing.T) {
+	ci.Parallel(t)
+	image := "foo"
+	imageID := uuid.Generate()
+	mapping := map[string]string{image: imageID}
+
+	mock := newMockImageClient(mapping, 1*time.Millisecond)
+	config := &dockerCoordinatorConfig{
+		ctx:         context.Background(),
+		logger:      testlog.HCLogger(t),
+		cleanup:     true,
+		client:      mock,
+		removeDelay: 1 * time.Millisecond,
+	}
+	coordinator := newDockerCoordinator(config)
+
+	// The prefetched image is reported as cached under its normalized name
+	coordinator.PrefetchImage(image, nil, 5*time.Minute, 2*time.Minute)
+	testutil.WaitForResult(func() (bool, error) {
+		cached := coordinator.CachedImages()
+		if cached["foo:latest"] != imageID {
+			return false, fmt.Errorf("expected image to be cached, got %v", cached)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	coordinator.imageLock.Lock()
+	require.Contains(t, coordinator.imageRefCount[imageID], prefetchCallerID)
+	coordinator.imageLock.Unlock()
+
+	// A task using the image drops the prefetch reference, so the image is
+	// removed with the task
+	callerID := uuid.Generate()
+	coordinator.IncrementImageReference(imageID, image, callerID)
+	coordinator.imageLock.Lock()
+	require.Len(t, coordinator.imageRefCount[imageID], 1, "image reference count")
+	coordinator.imageLock.Unlock()
+
+	coordinator.RemoveImage(imageID, callerID)
+	testutil.WaitForResult(func() (bool, error) {
+		mock.lock.Lock()
+		defer mock.lock.Unlock()
+		if _, ok := mock.removed[imageID]; !ok {
+			return false, fmt.Errorf("expected image to delete found %v", mock.removed)
+		}
+		if cached := coordinator.CachedImages(); len(cached) != 0 {
+			return false, fmt.Errorf("expected no cached images, got %v", cached)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+}
+
+func TestDockerCoordinator_CachedImagesLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	mock := newMockImageClient(map[string]string{}, 1*time.Millisecond)
+	config := &dockerCoordinatorConfig{
+		ctx:    context.Background(),
+		logger: testlog.HCLogger(t),
+		client: mock,
+	}
+	coordinator := newDockerCoordinator(config)
+
+	for i := 0; i < maxCachedImages; i++ {
+		coordinator.IncrementImageReference(fmt.Sprintf("id%d", i), fmt.Sprintf("image%d", i), uuid.Generate())
+	}
+
+	// Using the oldest image again keeps it reported over the next oldest
+	coordinator.IncrementImageReference("id0", "image0", uuid.Generate())
+	coordinator.IncrementImageReference("new", "new", uuid.Generate())
+
+	cached := coordinator.CachedImages()
+	require.Len(t, cached, maxCachedImages)
+	require.Equal(t, "id0", cached["image0:latest"])
+	require.Equal(t, "new", cached["new:latest"])
+	require.NotContains(t, cached, "image1:latest")
+}
@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	docker "github.com/fsouza/go-dockerclient"
@@ -63,6 +64,10 @@ var (
 	// and understands how to decode driver state
 	taskHandleVersion = 1
 
+	// imagePrefetchTimeout is the timeout for pulling prefetched images. Slow
+	// pulls are still aborted by the pull_activity_timeout.
+	imagePrefetchTimeout = 30 * time.Minute
+
 	// Nvidia-container-runtime environment variable names
 	nvidiaVisibleDevices = "NVIDIA_VISIBLE_DEVICES"
 
@@ -145,6 +150,10 @@ type Driver struct {
 	// gpuRuntime indicates nvidia-docker runtime availability
 	gpuRuntime bool
 
+	// checkpoint is set when the docker daemon runs in experimental mode and
+	// CRIU is installed, allowing containers to be checkpointed and restored
+	checkpoint atomic.Bool
+
 	// compute contains information about the available cpu compute
 	compute cpustats.Compute
 
@@ -309,6 +318,12 @@ func loggingIsEnabled(driverCfg *DriverConfig, taskCfg *drivers.TaskConfig) bool
 }
 
 func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, "")
+}
+
+// startTask starts the task, restoring its container from the checkpoint in
+// checkpointDir if set.
+func (d *Driver) startTask(cfg *drivers.TaskConfig, checkpointDir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
 	if _, ok := d.tasks.Get(cfg.ID); ok {
 		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
 	}
@@ -388,7 +403,12 @@ CREATE:
 	// and are running
 	if !container.State.Running {
 		// Start the container
-		if err := d.startContainer(container); err != nil {
+		if checkpointDir != "" {
+			err = d.restoreContainer(container, checkpointDir)
+		} else {
+			err = d.startContainer(container)
+		}
+		if err != nil {
 			d.logger.Error("failed to start container", "container_id", container.ID, "error", err)
 			dockerClient.RemoveContainer(docker.RemoveContainerOptions{
 				ID:    container.ID,
@@ -934,6 +954,30 @@ func memoryLimits(driverHardLimitMB int64, taskMemory drivers.MemoryResources) (
 	return hard * 1024 * 1024, softBytes
 }
 
+// setBlkioLimits sets the disk I/O weight of the container, and throttles its
+// reads and writes to the disk backing the task directory, where the bind
+// mounted alloc, local and secrets directories live.
+func (d *Driver) setBlkioLimits(hostConfig *docker.HostConfig, task *drivers.TaskConfig) {
+	limits := task.Resources.NomadResources.IO
+	hostConfig.BlkioWeight = limits.Weight
+
+	if limits.ReadBps == 0 && limits.WriteBps == 0 {
+		return
+	}
+	major, minor, err := cgroupslib.BlockDevice(task.TaskDir().Dir)
+	if err != nil {
+		d.logger.Warn("unable to limit disk bandwidth of task", "task_name", task.Name, "error", err)
+		return
+	}
+	device := fmt.Sprintf("/dev/block/%d:%d", major, minor)
+	if limits.ReadBps > 0 {
+		hostConfig.BlkioDeviceReadBps = []docker.BlockLimit{{Path: device, Rate: limits.ReadBps}}
+	}
+	if limits.WriteBps > 0 {
+		hostConfig.BlkioDeviceWriteBps = []docker.BlockLimit{{Path: device, Rate: limits.WriteBps}}
+	}
+}
+
 func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *TaskConfig,
 	imageID string) (docker.CreateContainerOptions, error) {
 
@@ -1078,6 +1122,8 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		} else {
 			hostConfig.MemorySwappiness = nil
 		}
+
+		d.setBlkioLimits(hostConfig, task)
 	}
 
 	loggingDriver := driverConfig.Logging.Type
@@ -1747,6 +1793,71 @@ func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*
 	return h.Exec(ctx, cmd[0], cmd[1:])
 }
 
+var _ drivers.ResourceUpdateDriver = (*Driver)(nil)
+
+// UpdateResources changes the cpu and memory limits of the running container.
+func (d *Driver) UpdateResources(taskID string, resources *drivers.Resources) error {
+	h, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+	if resources == nil || resources.NomadResources == nil || resources.LinuxResources == nil {
+		return fmt.Errorf("resources are required")
+	}
+
+	var driverConfig TaskConfig
+	if err := h.task.DecodeDriverConfig(&driverConfig); err != nil {
+		return fmt.Errorf("failed to decode driver config: %v", err)
+	}
+
+	memory, memoryReservation := memoryLimits(driverConfig.MemoryHardLimit, resources.NomadResources.Memory)
+	opts := docker.UpdateContainerOptions{
+		Memory:            int(memory),
+		MemoryReservation: int(memoryReservation),
+		CPUShares:         int(resources.LinuxResources.CPUShares),
+	}
+	if runtime.GOOS != "windows" {
+		opts.MemorySwap = int(memory)
+	}
+
+	// recompute the cpu quota the same way as when creating the container
+	if driverConfig.CPUHardLimit {
+		period := driverConfig.CPUCFSPeriod
+		if period == 0 {
+			period = resources.LinuxResources.CPUPeriod
+		}
+		opts.CPUPeriod = int(period)
+		opts.CPUQuota = int(int64(resources.LinuxResources.PercentTicks*float64(period)) * int64(runtime.NumCPU()))
+	}
+
+	d.logger.Debug("updating container resources", "container_id", h.containerID,
+		"memory", opts.Memory, "memory_reservation", opts.MemoryReservation,
+		"cpu_shares", opts.CPUShares, "cpu_quota", opts.CPUQuota)
+	if err := h.dockerClient.UpdateContainer(h.containerID, opts); err != nil {
+		return fmt.Errorf("failed to update container %s: %v", h.containerID, err)
+	}
+	return nil
+}
+
+var _ drivers.ImagePrefetchDriver = (*Driver)(nil)
+
+// PrefetchImage pulls the image in the background, authenticating with the
+// plugin's auth configuration since there is no task to take it from.
+func (d *Driver) PrefetchImage(image string) error {
+	if image == "" {
+		return fmt.Errorf("image is required")
+	}
+
+	repo, _ := parseDockerImage(image)
+	authOptions, err := d.resolveRegistryAuthentication(&TaskConfig{}, repo)
+	if err != nil {
+		return fmt.Errorf("Failed to find docker auth for repo %q: %v", repo, err)
+	}
+
+	d.coordinator.PrefetchImage(image, authOptions, imagePrefetchTimeout, d.config.pullActivityTimeoutDuration)
+	return nil
+}
+
 var _ drivers.ExecTaskStreamingDriver = (*Driver)(nil)
 
 func (d *Driver) ExecTaskStreaming(ctx context.Context, taskID string, opts *drivers.ExecOptions) (*drivers.ExitResult, error) {
@@ -1680,6 +1680,38 @@ func TestDockerDriver_MemoryHardLimit(t *testing.T) {
 	require.Equal(t, cfg.MemoryHardLimit*1024*1024, container.HostConfig.Memory)
 }
 
+func TestDockerDriver_UpdateResources(t *testing.T) {
+	ci.Parallel(t)
+	testutil.DockerCompatible(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows does not support MemoryReservation")
+	}
+
+	task, cfg, _ := dockerTask(t)
+	must.NoError(t, task.EncodeConcreteDriverConfig(cfg))
+
+	client, d, handle, cleanup := dockerSetup(t, task, nil)
+	defer cleanup()
+	must.NoError(t, d.WaitUntilStarted(task.ID, 5*time.Second))
+
+	resources := task.Resources.Copy()
+	resources.NomadResources.Cpu.CpuShares = 1024
+	resources.NomadResources.Memory.MemoryMB = 256
+	resources.NomadResources.Memory.MemoryMaxMB = 512
+	resources.LinuxResources.CPUShares = 1024
+
+	rd, ok := d.DriverPlugin.(drivers.ResourceUpdateDriver)
+	must.True(t, ok)
+	must.NoError(t, rd.UpdateResources(task.ID, resources))
+
+	container, err := client.InspectContainer(handle.containerID)
+	must.NoError(t, err)
+
+	must.Eq(t, 1024, container.HostConfig.CPUShares)
+	must.Eq(t, 512*1024*1024, container.HostConfig.Memory)
+	must.Eq(t, 256*1024*1024, container.HostConfig.MemoryReservation)
+}
+
 func TestDockerDriver_MACAddress(t *testing.T) {
 	ci.Parallel(t)
 	testutil.DockerCompatible(t)
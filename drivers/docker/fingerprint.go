@@ -5,12 +5,14 @@ package docker
 
 import (
 	"context"
+	"os/exec"
 	"runtime"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/hashicorp/nomad/plugins/drivers/utils"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
@@ -141,6 +143,12 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 		fp.Attributes["driver.docker.volumes.enabled"] = pstructs.NewBoolAttribute(true)
 	}
 
+	// Report the images pulled or used by tasks so the scheduler can prefer
+	// nodes that don't need to pull them again
+	for image, id := range d.coordinator.CachedImages() {
+		fp.Attributes[structs.ImageAttribute("docker", image)] = pstructs.NewStringAttribute(id)
+	}
+
 	if nets, err := dockerClient.ListNetworks(); err != nil {
 		d.logger.Warn("error discovering bridge IP", "error", err)
 	} else {
@@ -183,6 +191,11 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 			strings.Join(runtimeNames, ","))
 		fp.Attributes["driver.docker.os_type"] = pstructs.NewStringAttribute(dockerInfo.OSType)
 
+		// Checkpoints are only available from experimental daemons on Linux,
+		// which rely on CRIU to dump and restore the container processes
+		_, err := exec.LookPath("criu")
+		d.checkpoint.Store(runtime.GOOS == "linux" && dockerInfo.ExperimentalBuild && err == nil)
+
 		// If this situations arises, we are running in Windows 10 with Linux Containers enabled via VM
 		if runtime.GOOS == "windows" && dockerInfo.OSType == "linux" {
 			if d.fingerprintSuccessful() {
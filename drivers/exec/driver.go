@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	osexec "os/exec"
 	"path/filepath"
 	"runtime"
 	"sync"
@@ -83,6 +84,10 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"rootless_network": hclspec.NewDefault(
+			hclspec.NewAttr("rootless_network", "string", false),
+			hclspec.NewLiteral(`"host"`),
+		),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
@@ -108,6 +113,12 @@ var (
 		},
 		MountConfigs: drivers.MountConfigSupportAll,
 	}
+
+	// rootlessNetIsolationModes are the network isolation modes of rootless
+	// tasks, which can't join the network namespaces created by the client
+	rootlessNetIsolationModes = []drivers.NetIsolationMode{
+		drivers.NetIsolationModeHost,
+	}
 )
 
 // Driver fork/execs tasks using many of the underlying OS's isolation
@@ -140,6 +151,10 @@ type Driver struct {
 
 	// compute contains cpu compute information
 	compute cpustats.Compute
+
+	// checkpoint is set when CRIU is installed, allowing tasks to be
+	// checkpointed and restored
+	checkpoint bool
 }
 
 // Config is the driver configuration set by the SetConfig RPC call
@@ -159,6 +174,10 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// RootlessNetwork is the network of tasks when the agent runs without
+	// root privileges, either "host" or "slirp4netns".
+	RootlessNetwork string `codec:"rootless_network"`
 }
 
 func (c *Config) validate() error {
@@ -179,6 +198,12 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	switch c.RootlessNetwork {
+	case "", executor.RootlessNetworkHost, executor.RootlessNetworkSlirp4netns:
+	default:
+		return fmt.Errorf("rootless_network must be %q or %q, got %q", executor.RootlessNetworkHost, executor.RootlessNetworkSlirp4netns, c.RootlessNetwork)
+	}
+
 	return nil
 }
 
@@ -244,11 +269,14 @@ type TaskState struct {
 // NewExecDriver returns a new DrivePlugin implementation
 func NewExecDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugin {
 	logger = logger.Named(pluginName)
+	// CRIU can't restore processes without root privileges
+	_, err := osexec.LookPath("criu")
 	return &Driver{
-		eventer: eventer.NewEventer(ctx, logger),
-		tasks:   newTaskStore(),
-		ctx:     ctx,
-		logger:  logger,
+		eventer:    eventer.NewEventer(ctx, logger),
+		tasks:      newTaskStore(),
+		ctx:        ctx,
+		logger:     logger,
+		checkpoint: err == nil && utils.IsUnixRoot(),
 	}
 }
 
@@ -309,6 +337,15 @@ func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
 // Capabilities is returned by the Capabilities RPC and indicates what
 // optional features this driver supports
 func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
+	rootless := !utils.IsUnixRoot()
+	if d.checkpoint || rootless {
+		caps := *driverCapabilities
+		caps.Checkpoint = d.checkpoint
+		if rootless {
+			caps.NetIsolationModes = rootlessNetIsolationModes
+		}
+		return &caps, nil
+	}
 	return driverCapabilities, nil
 }
 
@@ -349,11 +386,24 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 		HealthDescription: drivers.DriverHealthy,
 	}
 
-	if !utils.IsUnixRoot() {
-		fp.Health = drivers.HealthStateUndetected
-		fp.HealthDescription = drivers.DriverRequiresRootMessage
-		d.setFingerprintFailure()
-		return fp
+	// without root privileges tasks run in user namespaces, within the
+	// cgroup delegated to the agent
+	rootless := !utils.IsUnixRoot()
+	if rootless {
+		if err := executor.RootlessSupported(); err != nil {
+			fp.Health = drivers.HealthStateUndetected
+			fp.HealthDescription = fmt.Sprintf("%s or in rootless mode: %v", drivers.DriverRequiresRootMessage, err)
+			d.setFingerprintFailure()
+			return fp
+		}
+		if d.config.RootlessNetwork == executor.RootlessNetworkSlirp4netns {
+			if _, err := osexec.LookPath("slirp4netns"); err != nil {
+				fp.Health = drivers.HealthStateUnhealthy
+				fp.HealthDescription = "slirp4netns is required by rootless_network"
+				d.setFingerprintFailure()
+				return fp
+			}
+		}
 	}
 
 	if cgroupslib.GetMode() == cgroupslib.OFF {
@@ -364,6 +414,7 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	}
 
 	fp.Attributes["driver.exec"] = pstructs.NewBoolAttribute(true)
+	fp.Attributes["driver.exec.rootless"] = pstructs.NewBoolAttribute(rootless)
 	d.setFingerprintSuccess()
 	return fp
 }
@@ -424,6 +475,12 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 }
 
 func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, "")
+}
+
+// startTask starts the task, restoring it from the checkpoint images in
+// checkpointDir if set.
+func (d *Driver) startTask(cfg *drivers.TaskConfig, checkpointDir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
 	if _, ok := d.tasks.Get(cfg.ID); ok {
 		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
 	}
@@ -456,9 +513,24 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("failed to create executor: %v", err)
 	}
 
+	rootless := !utils.IsUnixRoot()
+	if rootless && cfg.NetworkIsolation != nil {
+		return nil, nil, fmt.Errorf("network isolation is not supported in rootless mode")
+	}
+
 	user := cfg.User
 	if user == "" {
 		user = "nobody"
+		if rootless {
+			// root within the user namespace is the agent's user, which owns
+			// the task directory
+			user = "root"
+		}
+	}
+
+	// slirp4netns serves DNS from the network of rootless tasks
+	if rootless && d.config.RootlessNetwork == executor.RootlessNetworkSlirp4netns && cfg.DNS == nil {
+		cfg.DNS = &drivers.DNSConfig{Servers: []string{"10.0.2.3"}}
 	}
 
 	if cfg.DNS != nil {
@@ -494,6 +566,9 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
 		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
 		Capabilities:     caps,
+		CheckpointDir:    checkpointDir,
+		Rootless:         rootless,
+		RootlessNetwork:  d.config.RootlessNetwork,
 	}
 
 	ps, err := exec.Launch(execCmd)
@@ -531,6 +606,43 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	return handle, nil, nil
 }
 
+var _ drivers.CheckpointDriver = (*Driver)(nil)
+
+// CheckpointTask dumps the state of the task's processes into dir, stopping
+// the task.
+func (d *Driver) CheckpointTask(taskID string, dir string) error {
+	if !d.checkpoint {
+		return fmt.Errorf("criu is not installed")
+	}
+
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.Checkpoint(dir)
+}
+
+// RestoreTask starts the task from the checkpoint images in dir.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if !d.checkpoint {
+		return nil, nil, fmt.Errorf("criu is not installed")
+	}
+	return d.startTask(cfg, dir)
+}
+
+var _ drivers.ResourceUpdateDriver = (*Driver)(nil)
+
+// UpdateResources changes the cgroup limits of the running task.
+func (d *Driver) UpdateResources(taskID string, resources *drivers.Resources) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.UpdateResources(resources)
+}
+
 func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -870,6 +870,24 @@ func TestDriver_Config_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("rootless_network", func(t *testing.T) {
+		for _, tc := range []struct {
+			network string
+			exp     error
+		}{
+			{network: "", exp: nil},
+			{network: "host", exp: nil},
+			{network: "slirp4netns", exp: nil},
+			{network: "bridge", exp: errors.New(`rootless_network must be "host" or "slirp4netns", got "bridge"`)},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID:  "private",
+				DefaultModeIPC:  "private",
+				RootlessNetwork: tc.network,
+			}).validate())
+		}
+	})
 }
 
 func TestDriver_TaskConfig_validate(t *testing.T) {
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package oci
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/shoenig/test/must"
+)
+
+func TestTaskConfig_argv(t *testing.T) {
+	ci.Parallel(t)
+
+	img := ocispec.ImageConfig{
+		Entrypoint: []string{"/entrypoint.sh"},
+		Cmd:        []string{"serve"},
+	}
+
+	cases := []struct {
+		name string
+		tc   TaskConfig
+		exp  []string
+	}{
+		{
+			name: "image defaults",
+			exp:  []string{"/entrypoint.sh", "serve"},
+		},
+		{
+			name: "args replace command",
+			tc:   TaskConfig{Args: []string{"migrate"}},
+			exp:  []string{"/entrypoint.sh", "migrate"},
+		},
+		{
+			name: "entrypoint resets command",
+			tc:   TaskConfig{Entrypoint: []string{"/bin/sh", "-c"}},
+			exp:  []string{"/bin/sh", "-c"},
+		},
+		{
+			name: "command replaces entrypoint",
+			tc:   TaskConfig{Command: "redis-server", Args: []string{"--port", "6379"}},
+			exp:  []string{"redis-server", "--port", "6379"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			must.Eq(t, c.exp, c.tc.argv(img))
+		})
+	}
+}
+
+func TestImageEnv(t *testing.T) {
+	ci.Parallel(t)
+
+	env := imageEnv(
+		[]string{"PATH=/usr/bin", "LANG=C.UTF-8"},
+		map[string]string{"LANG": "en_US.UTF-8", "NOMAD_TASK_NAME": "web"},
+	)
+	sort.Strings(env)
+	must.Eq(t, []string{"LANG=en_US.UTF-8", "NOMAD_TASK_NAME=web", "PATH=/usr/bin"}, env)
+}
@@ -0,0 +1,367 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	hclog "github.com/hashicorp/go-hclog"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// mediaTypeDockerManifest and mediaTypeDockerManifestList are the Docker
+	// equivalents of the OCI image manifest and index, still served by most
+	// registries
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	// maxManifestSize is the size limit of the manifests and image configs
+	// read from registries
+	maxManifestSize = 4 * 1024 * 1024
+)
+
+// manifestMediaTypes are the manifest media types accepted from registries
+var manifestMediaTypes = []string{
+	ocispec.MediaTypeImageIndex,
+	ocispec.MediaTypeImageManifest,
+	mediaTypeDockerManifestList,
+	mediaTypeDockerManifest,
+}
+
+// RegistryAuth is the credentials used to pull an image.
+type RegistryAuth struct {
+	Username string `codec:"username"`
+	Password string `codec:"password"`
+}
+
+// image is an image pulled into the store.
+type image struct {
+	// Name is the normalized reference of the image
+	Name string
+
+	// Digest is the digest of the image manifest
+	Digest digest.Digest
+
+	// Config is the configuration the image runs with by default
+	Config ocispec.ImageConfig
+
+	// Layers are the layers of the image's root filesystem, from the bottom
+	// up
+	Layers []ocispec.Descriptor
+}
+
+// imageStore pulls images from registries without a container daemon. The
+// blobs of pulled images are cached by digest, so layers shared by images or
+// tasks are only downloaded once.
+type imageStore struct {
+	dir    string
+	client *http.Client
+	logger hclog.Logger
+}
+
+func newImageStore(dir string, logger hclog.Logger) *imageStore {
+	return &imageStore{
+		dir:    dir,
+		client: &http.Client{},
+		logger: logger.Named("images"),
+	}
+}
+
+// blobPath returns the path of the cached blob with the given digest.
+func (s *imageStore) blobPath(d digest.Digest) string {
+	return filepath.Join(s.dir, "blobs", d.Algorithm().String(), d.Encoded())
+}
+
+// Pull fetches the manifest of the image for the client's platform and
+// caches its config and layers.
+func (s *imageStore) Pull(ctx context.Context, ref string, auth *RegistryAuth) (*image, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image reference %q: %v", ref, err)
+	}
+	named = reference.TagNameOnly(named)
+
+	r := newRegistry(s.client, named, auth)
+	tag := ""
+	if tagged, ok := named.(reference.Tagged); ok {
+		tag = tagged.Tag()
+	}
+	if canonical, ok := named.(reference.Canonical); ok {
+		tag = canonical.Digest().String()
+	}
+
+	m, md, err := s.fetchManifest(ctx, r, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	var config ocispec.Image
+	if err := s.fetchBlob(ctx, r, m.Config); err != nil {
+		return nil, fmt.Errorf("failed to pull image config: %v", err)
+	}
+	b, err := os.ReadFile(s.blobPath(m.Config.Digest))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode image config: %v", err)
+	}
+
+	for _, layer := range m.Layers {
+		s.logger.Debug("pulling layer", "image", named.String(), "digest", layer.Digest, "size", layer.Size)
+		if err := s.fetchBlob(ctx, r, layer); err != nil {
+			return nil, fmt.Errorf("failed to pull layer %s: %v", layer.Digest, err)
+		}
+	}
+
+	return &image{
+		Name:   named.String(),
+		Digest: md,
+		Config: config.Config,
+		Layers: m.Layers,
+	}, nil
+}
+
+// manifest holds the fields of image indexes and manifests, in both their OCI
+// and Docker flavors.
+type manifest struct {
+	MediaType string               `json:"mediaType"`
+	Manifests []ocispec.Descriptor `json:"manifests"`
+	Config    ocispec.Descriptor   `json:"config"`
+	Layers    []ocispec.Descriptor `json:"layers"`
+}
+
+// fetchManifest fetches the image manifest for ref, resolving indexes to the
+// manifest of the client's platform. It returns the manifest and its digest.
+func (s *imageStore) fetchManifest(ctx context.Context, r *registry, ref string) (*manifest, digest.Digest, error) {
+	for depth := 0; depth < 2; depth++ {
+		resp, err := r.get(ctx, "manifests/"+ref, manifestMediaTypes)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to fetch manifest: %v", err)
+		}
+		b, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+		resp.Body.Close()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read manifest: %v", err)
+		}
+
+		md := digest.FromBytes(b)
+		if d, err := digest.Parse(ref); err == nil && d != md {
+			return nil, "", fmt.Errorf("manifest digest %s does not match %s", md, d)
+		}
+
+		var m manifest
+		if err := json.Unmarshal(b, &m); err != nil {
+			return nil, "", fmt.Errorf("failed to decode manifest: %v", err)
+		}
+		if m.MediaType == "" {
+			m.MediaType = resp.Header.Get("Content-Type")
+		}
+
+		switch m.MediaType {
+		case ocispec.MediaTypeImageManifest, mediaTypeDockerManifest:
+			return &m, md, nil
+		case ocispec.MediaTypeImageIndex, mediaTypeDockerManifestList:
+			d, ok := matchPlatform(m.Manifests)
+			if !ok {
+				return nil, "", fmt.Errorf("no image found for platform %s/%s", runtime.GOOS, runtime.GOARCH)
+			}
+			ref = d.Digest.String()
+		default:
+			return nil, "", fmt.Errorf("unsupported manifest media type %q", m.MediaType)
+		}
+	}
+	return nil, "", fmt.Errorf("image index does not reference a manifest")
+}
+
+// matchPlatform returns the manifest of an index for the client's platform.
+func matchPlatform(manifests []ocispec.Descriptor) (ocispec.Descriptor, bool) {
+	for _, d := range manifests {
+		if d.Platform != nil && d.Platform.OS == runtime.GOOS && d.Platform.Architecture == runtime.GOARCH {
+			return d, true
+		}
+	}
+	return ocispec.Descriptor{}, false
+}
+
+// fetchBlob downloads the blob into the cache unless it's already cached.
+// Blobs are verified against their digest and written to a temporary file
+// first, so concurrent pulls never observe partial blobs.
+func (s *imageStore) fetchBlob(ctx context.Context, r *registry, desc ocispec.Descriptor) error {
+	if err := desc.Digest.Validate(); err != nil {
+		return err
+	}
+	path := s.blobPath(desc.Digest)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	resp, err := r.get(ctx, "blobs/"+desc.Digest.String(), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".pull-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	verifier := desc.Digest.Verifier()
+	if _, err := io.Copy(io.MultiWriter(tmp, verifier), resp.Body); err != nil {
+		return err
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("blob does not match digest %s", desc.Digest)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// registry is a client of the OCI distribution API for a single repository.
+type registry struct {
+	client *http.Client
+	base   string
+	repo   string
+	auth   *RegistryAuth
+
+	// authz is the Authorization header sent once the registry challenged
+	// a request
+	authz string
+}
+
+func newRegistry(client *http.Client, named reference.Named, auth *RegistryAuth) *registry {
+	host := reference.Domain(named)
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	return &registry{
+		client: client,
+		base:   "https://" + host + "/v2/",
+		repo:   reference.Path(named),
+		auth:   auth,
+	}
+}
+
+// get sends a GET request for the repository path, authenticating and
+// retrying once if the registry challenges it.
+func (r *registry) get(ctx context.Context, path string, accept []string) (*http.Response, error) {
+	u := r.base + r.repo + "/" + path
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(accept) > 0 {
+			req.Header.Set("Accept", strings.Join(accept, ", "))
+		}
+		if r.authz != "" {
+			req.Header.Set("Authorization", r.authz)
+		}
+
+		resp, err := r.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return resp, nil
+		case resp.StatusCode == http.StatusUnauthorized && attempt == 0:
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close()
+			if err := r.authorize(ctx, challenge); err != nil {
+				return nil, err
+			}
+		default:
+			msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected response %d from %s: %s", resp.StatusCode, u, strings.TrimSpace(string(msg)))
+		}
+	}
+}
+
+// challengeParamRe matches the parameters of a WWW-Authenticate challenge
+var challengeParamRe = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// authorize sets the Authorization header answering the challenge, which is
+// either a request for basic credentials or for a bearer token issued by the
+// realm of the challenge.
+func (r *registry) authorize(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if r.auth == nil || r.auth.Username == "" {
+			return fmt.Errorf("registry requires credentials")
+		}
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth(r.auth.Username, r.auth.Password)
+		r.authz = req.Header.Get("Authorization")
+		return nil
+	case "bearer":
+	default:
+		return fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	values := url.Values{}
+	var realm string
+	for _, m := range challengeParamRe.FindAllStringSubmatch(params, -1) {
+		if m[1] == "realm" {
+			realm = m[2]
+		} else {
+			values.Set(m[1], m[2])
+		}
+	}
+	if realm == "" {
+		return fmt.Errorf("authentication challenge has no realm")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+values.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if r.auth != nil && r.auth.Username != "" {
+		req.SetBasicAuth(r.auth.Username, r.auth.Password)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request registry token: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to request registry token: unexpected response %d", resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode registry token: %v", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	r.authz = "Bearer " + token.Token
+	return nil
+}
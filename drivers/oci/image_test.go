@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/shoenig/test/must"
+)
+
+// testLayer returns a gzipped layer tarball of the files, with directories
+// ending with a slash and symlinks written as "-> target".
+func testLayer(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	// Write parents before their children
+	sort.Strings(names)
+
+	for _, name := range names {
+		content := files[name]
+		hdr := &tar.Header{Name: name, Mode: 0o644}
+		switch {
+		case strings.HasSuffix(name, "/"):
+			hdr.Typeflag = tar.TypeDir
+			hdr.Mode = 0o755
+		case strings.HasPrefix(content, "-> "):
+			hdr.Typeflag = tar.TypeSymlink
+			hdr.Linkname = strings.TrimPrefix(content, "-> ")
+		default:
+			hdr.Typeflag = tar.TypeReg
+			hdr.Size = int64(len(content))
+		}
+		must.NoError(t, tw.WriteHeader(hdr))
+		if hdr.Typeflag == tar.TypeReg {
+			_, err := tw.Write([]byte(content))
+			must.NoError(t, err)
+		}
+	}
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// testRegistry serves a single image behind bearer token authentication and
+// counts the blobs it serves.
+type testRegistry struct {
+	srv   *httptest.Server
+	blobs map[digest.Digest][]byte
+	index []byte
+
+	l      sync.Mutex
+	served map[digest.Digest]int
+}
+
+func newTestRegistry(t *testing.T, config ocispec.Image, layers ...[]byte) *testRegistry {
+	r := &testRegistry{
+		blobs:  make(map[digest.Digest][]byte),
+		served: make(map[digest.Digest]int),
+	}
+	add := func(mediaType string, b []byte) ocispec.Descriptor {
+		d := digest.FromBytes(b)
+		r.blobs[d] = b
+		return ocispec.Descriptor{MediaType: mediaType, Digest: d, Size: int64(len(b))}
+	}
+
+	b, err := json.Marshal(config)
+	must.NoError(t, err)
+	m := ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    add(ocispec.MediaTypeImageConfig, b),
+	}
+	m.SchemaVersion = 2
+	for _, layer := range layers {
+		m.Layers = append(m.Layers, add(ocispec.MediaTypeImageLayerGzip, layer))
+	}
+	b, err = json.Marshal(m)
+	must.NoError(t, err)
+	md := add(ocispec.MediaTypeImageManifest, b)
+
+	// The index references manifests for other platforms too
+	index := ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{
+			{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("other"), Platform: &ocispec.Platform{OS: "plan9", Architecture: "386"}},
+			{MediaType: md.MediaType, Digest: md.Digest, Size: md.Size, Platform: &ocispec.Platform{OS: runtime.GOOS, Architecture: runtime.GOARCH}},
+		},
+	}
+	index.SchemaVersion = 2
+	r.index, err = json.Marshal(index)
+	must.NoError(t, err)
+
+	r.srv = httptest.NewTLSServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.srv.Close)
+	return r
+}
+
+func (r *testRegistry) host() string {
+	return strings.TrimPrefix(r.srv.URL, "https://")
+}
+
+func (r *testRegistry) serve(rw http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if req.URL.Query().Get("scope") != "repository:library/app:pull" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(rw).Encode(map[string]string{"token": "secret"})
+		return
+	}
+
+	if req.Header.Get("Authorization") != "Bearer secret" {
+		rw.Header().Set("WWW-Authenticate",
+			`Bearer realm="`+r.srv.URL+`/token",service="test",scope="repository:library/app:pull"`)
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case req.URL.Path == "/v2/library/app/manifests/latest":
+		rw.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+		rw.Write(r.index)
+	case strings.HasPrefix(req.URL.Path, "/v2/library/app/manifests/"),
+		strings.HasPrefix(req.URL.Path, "/v2/library/app/blobs/"):
+		d := digest.Digest(filepath.Base(req.URL.Path))
+		b, ok := r.blobs[d]
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		r.l.Lock()
+		r.served[d]++
+		r.l.Unlock()
+		rw.Write(b)
+	default:
+		rw.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestImageStore_PullUnpack(t *testing.T) {
+	ci.Parallel(t)
+
+	layer := testLayer(t, map[string]string{
+		"bin/":       "",
+		"bin/app":    "#!/bin/sh",
+		"etc/":       "",
+		"etc/config": "v1",
+	})
+	config := ocispec.Image{Config: ocispec.ImageConfig{
+		Entrypoint: []string{"/bin/app"},
+		Env:        []string{"PATH=/bin"},
+	}}
+	reg := newTestRegistry(t, config, layer)
+
+	store := newImageStore(t.TempDir(), testlog.HCLogger(t))
+	store.client = reg.srv.Client()
+
+	img, err := store.Pull(context.Background(), reg.host()+"/library/app", nil)
+	must.NoError(t, err)
+	must.Eq(t, reg.host()+"/library/app:latest", img.Name)
+	must.Eq(t, []string{"/bin/app"}, img.Config.Entrypoint)
+	must.Len(t, 1, img.Layers)
+
+	// Cached blobs are not downloaded again
+	_, err = store.Pull(context.Background(), reg.host()+"/library/app:latest", nil)
+	must.NoError(t, err)
+	must.Eq(t, 1, reg.served[digest.FromBytes(layer)])
+
+	root := t.TempDir()
+	must.NoError(t, store.Unpack(img, root))
+	b, err := os.ReadFile(filepath.Join(root, "etc", "config"))
+	must.NoError(t, err)
+	must.Eq(t, "v1", string(b))
+
+	// Pulling by digest verifies the manifest
+	_, err = store.Pull(context.Background(), reg.host()+"/library/app@"+img.Digest.String(), nil)
+	must.NoError(t, err)
+}
+
+func TestImageStore_Pull_Errors(t *testing.T) {
+	ci.Parallel(t)
+
+	reg := newTestRegistry(t, ocispec.Image{})
+	store := newImageStore(t.TempDir(), testlog.HCLogger(t))
+	store.client = reg.srv.Client()
+
+	_, err := store.Pull(context.Background(), reg.host()+"/library/missing", nil)
+	must.ErrorContains(t, err, "unexpected response 404")
+
+	_, err = store.Pull(context.Background(), "Invalid/Reference", nil)
+	must.ErrorContains(t, err, "failed to parse image reference")
+
+	_, err = store.Pull(context.Background(), reg.host()+"/library/app@"+digest.FromString("missing").String(), nil)
+	must.ErrorContains(t, err, "unexpected response 404")
+}
@@ -0,0 +1,213 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package oci
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/klauspost/compress/zstd"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// whiteoutPrefix marks files deleted by a layer from the layers below
+	whiteoutPrefix = ".wh."
+
+	// whiteoutOpaque marks directories whose content from the layers below
+	// is hidden
+	whiteoutOpaque = whiteoutPrefix + whiteoutPrefix + ".opq"
+
+	// mediaTypeDockerLayer is the Docker equivalent of the OCI gzip layer
+	mediaTypeDockerLayer = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+)
+
+// Unpack extracts the layers of the image into root, from the bottom up.
+func (s *imageStore) Unpack(img *image, root string) error {
+	for _, layer := range img.Layers {
+		if err := s.unpackLayer(layer, root); err != nil {
+			return fmt.Errorf("failed to unpack layer %s: %v", layer.Digest, err)
+		}
+	}
+	return nil
+}
+
+func (s *imageStore) unpackLayer(layer ocispec.Descriptor, root string) error {
+	f, err := os.Open(s.blobPath(layer.Digest))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader
+	switch layer.MediaType {
+	case ocispec.MediaTypeImageLayer:
+		r = f
+	case ocispec.MediaTypeImageLayerGzip, mediaTypeDockerLayer:
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	case ocispec.MediaTypeImageLayerZstd:
+		zr, err := zstd.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		return fmt.Errorf("unsupported layer media type %q", layer.MediaType)
+	}
+
+	return unpackTar(r, root)
+}
+
+// unpackTar extracts a layer tarball into root, applying its whiteouts to the
+// content of the layers below it. Paths are resolved within root, so entries
+// can't escape it through symlinks or relative paths. Device nodes are
+// skipped as the task's /dev is created by the executor.
+func unpackTar(r io.Reader, root string) error {
+	// unpacked tracks the paths extracted from this layer, which opaque
+	// whiteouts must not remove
+	unpacked := make(map[string]struct{})
+	isRoot := os.Geteuid() == 0
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean("/" + hdr.Name)
+		if name == "/" {
+			continue
+		}
+		dir, base := filepath.Split(name)
+		parent, err := securejoin.SecureJoin(root, dir)
+		if err != nil {
+			return err
+		}
+
+		if base == whiteoutOpaque {
+			entries, err := os.ReadDir(parent)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			for _, entry := range entries {
+				path := filepath.Join(parent, entry.Name())
+				if _, ok := unpacked[path]; ok {
+					continue
+				}
+				if err := os.RemoveAll(path); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(base, whiteoutPrefix) {
+			path, err := whiteoutPath(root, parent, strings.TrimPrefix(base, whiteoutPrefix))
+			if err != nil {
+				return fmt.Errorf("invalid whiteout %q: %v", hdr.Name, err)
+			}
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(parent, 0o755); err != nil {
+			return err
+		}
+		path := filepath.Join(parent, base)
+
+		// Entries replace the content of the layers below, except for
+		// directories which are merged
+		if fi, err := os.Lstat(path); err == nil && !(fi.IsDir() && hdr.Typeflag == tar.TypeDir) {
+			if err := os.RemoveAll(path); err != nil {
+				return err
+			}
+		}
+
+		mode := hdr.FileInfo().Mode() & (os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.Mkdir(path, 0o755); err != nil && !os.IsExist(err) {
+				return err
+			}
+		case tar.TypeReg:
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.Symlink(hdr.Linkname, path); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			target, err := securejoin.SecureJoin(root, hdr.Linkname)
+			if err != nil {
+				return err
+			}
+			if err := os.Link(target, path); err != nil {
+				return err
+			}
+		default:
+			continue
+		}
+		unpacked[path] = struct{}{}
+
+		if isRoot {
+			if err := os.Lchown(path, hdr.Uid, hdr.Gid); err != nil {
+				return err
+			}
+		}
+		if hdr.Typeflag != tar.TypeSymlink && hdr.Typeflag != tar.TypeLink {
+			if err := os.Chmod(path, mode); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// whiteoutPath returns the path removed by the whiteout of target in parent,
+// which must already be resolved within root. Whiteouts can only name an
+// entry of their own directory, never the directory itself, its parent or the
+// root.
+func whiteoutPath(root, parent, target string) (string, error) {
+	if target == "" || target == "." || target == ".." ||
+		strings.ContainsRune(target, '/') || strings.ContainsRune(target, filepath.Separator) {
+		return "", fmt.Errorf("target %q is not a file name", target)
+	}
+
+	// The target itself isn't resolved, as a whiteout of a symlink removes
+	// the symlink and not what it points to
+	path := filepath.Join(parent, target)
+	rel, err := filepath.Rel(filepath.Clean(root), path)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("target %q resolves outside of the image", target)
+	}
+	return path, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package oci
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func unpackTestLayer(t *testing.T, root string, files map[string]string) {
+	gz, err := gzip.NewReader(bytes.NewReader(testLayer(t, files)))
+	must.NoError(t, err)
+	must.NoError(t, unpackTar(gz, root))
+}
+
+func TestUnpackTar_Whiteouts(t *testing.T) {
+	ci.Parallel(t)
+
+	root := t.TempDir()
+	unpackTestLayer(t, root, map[string]string{
+		"etc/":          "",
+		"etc/keep":      "lower",
+		"etc/remove":    "lower",
+		"var/":          "",
+		"var/lib/":      "",
+		"var/lib/lower": "lower",
+	})
+	unpackTestLayer(t, root, map[string]string{
+		"etc/":                 "",
+		"etc/.wh.remove":       "",
+		"etc/keep":             "upper",
+		"var/lib/":             "",
+		"var/lib/.wh..wh..opq": "",
+		"var/lib/upper":        "upper",
+	})
+
+	b, err := os.ReadFile(filepath.Join(root, "etc", "keep"))
+	must.NoError(t, err)
+	must.Eq(t, "upper", string(b))
+	must.FileNotExists(t, filepath.Join(root, "etc", "remove"))
+
+	// Opaque directories only hide the content of the layers below
+	must.FileNotExists(t, filepath.Join(root, "var", "lib", "lower"))
+	must.FileExists(t, filepath.Join(root, "var", "lib", "upper"))
+}
+
+func TestUnpackTar_InvalidWhiteouts(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []string{
+		".wh..",
+		".wh...",
+		"etc/.wh..",
+		"etc/.wh...",
+		"etc/sub/.wh..",
+		"etc/sub/.wh...",
+	}
+	for _, whiteout := range cases {
+		t.Run(whiteout, func(t *testing.T) {
+			root := t.TempDir()
+			unpackTestLayer(t, root, map[string]string{
+				"etc/":         "",
+				"etc/keep":     "lower",
+				"etc/sub/":     "",
+				"etc/sub/keep": "lower",
+			})
+
+			gz, err := gzip.NewReader(bytes.NewReader(testLayer(t, map[string]string{
+				whiteout: "",
+			})))
+			must.NoError(t, err)
+			must.ErrorContains(t, unpackTar(gz, root), "invalid whiteout")
+
+			// Nothing is removed from the image or around it
+			must.DirExists(t, root)
+			must.FileExists(t, filepath.Join(root, "etc", "keep"))
+			must.FileExists(t, filepath.Join(root, "etc", "sub", "keep"))
+		})
+	}
+}
+
+func TestUnpackTar_Escape(t *testing.T) {
+	ci.Parallel(t)
+
+	outside := t.TempDir()
+	root := t.TempDir()
+	unpackTestLayer(t, root, map[string]string{
+		"escape":          "-> " + outside,
+		"escape/file":     "written through symlink",
+		"../../traversal": "written with relative path",
+	})
+
+	// Entries are written within the root, not where the symlink points
+	entries, err := os.ReadDir(outside)
+	must.NoError(t, err)
+	must.SliceEmpty(t, entries)
+	must.FileExists(t, filepath.Join(root, "traversal"))
+}
+
+func TestResolveCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	root := t.TempDir()
+	unpackTestLayer(t, root, map[string]string{
+		"bin/":        "",
+		"bin/app":     "#!/bin/sh",
+		"usr/":        "",
+		"usr/bin/":    "",
+		"usr/bin/sh":  "-> /bin/app",
+		"usr/bin/dir": "-> /bin",
+	})
+
+	cmd, err := resolveCommand(root, "app", nil)
+	must.NoError(t, err)
+	must.Eq(t, "/bin/app", cmd)
+
+	// Absolute symlinks are resolved within the root
+	cmd, err = resolveCommand(root, "/usr/bin/sh", nil)
+	must.NoError(t, err)
+	must.Eq(t, "/bin/app", cmd)
+
+	// The PATH of the environment is searched
+	_, err = resolveCommand(root, "app", []string{"PATH=/usr/bin"})
+	must.ErrorContains(t, err, `command "app" not found in image`)
+
+	// Directories aren't commands
+	_, err = resolveCommand(root, "dir", nil)
+	must.ErrorContains(t, err, `command "dir" not found in image`)
+}
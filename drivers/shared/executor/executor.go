@@ -5,6 +5,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -43,12 +44,23 @@ const (
 
 	// IsolationModeHost represents the host isolation mode for a namespace
 	IsolationModeHost = "host"
+
+	// RootlessNetworkHost shares the host network with rootless processes
+	RootlessNetworkHost = "host"
+
+	// RootlessNetworkSlirp4netns gives rootless processes a private network
+	// namespace connected to the host by slirp4netns
+	RootlessNetworkSlirp4netns = "slirp4netns"
 )
 
 var (
 	// The statistics the basic executor exposes
 	ExecutorBasicMeasuredMemStats = []string{"RSS", "Swap"}
 	ExecutorBasicMeasuredCpuStats = []string{"System Mode", "User Mode", "Percent"}
+
+	// ErrCheckpointNotSupported is returned by executors which can't
+	// checkpoint or restore processes
+	ErrCheckpointNotSupported = errors.New("checkpointing is not supported by this executor")
 )
 
 // Executor is the interface which allows a driver to launch and supervise
@@ -90,6 +102,11 @@ type Executor interface {
 
 	ExecStreaming(ctx context.Context, cmd []string, tty bool,
 		stream drivers.ExecTaskStream) error
+
+	// Checkpoint dumps the state of the user process tree into the given
+	// directory with CRIU, stopping the process. It is only supported by
+	// executors isolating the process in a container.
+	Checkpoint(dir string) error
 }
 
 // ExecCommand holds the user command, args, and other isolation related
@@ -167,6 +184,23 @@ type ExecCommand struct {
 	// OOMScoreAdj allows setting oom_score_adj (likelihood of process being
 	// OOM killed) on Linux systems
 	OOMScoreAdj int32
+
+	// CheckpointDir is the directory of the images dumped by Checkpoint. If
+	// set the process is restored from the images instead of being started.
+	CheckpointDir string
+
+	// WorkDir is the working directory of the process within its root
+	// filesystem. It is only honored by executors isolating the process in a
+	// container, and defaults to the root.
+	WorkDir string
+
+	// Rootless runs the process in a user namespace, mapping the executor's
+	// unprivileged user to root within the container.
+	Rootless bool
+
+	// RootlessNetwork is the network of rootless processes, either sharing
+	// the host network or a private one connected through slirp4netns.
+	RootlessNetwork string
 }
 
 func (c *ExecCommand) getCgroupOr(controller, fallback string) string {
@@ -352,6 +386,10 @@ func (e *UniversalExecutor) Version() (*ExecutorVersion, error) {
 func (e *UniversalExecutor) Launch(command *ExecCommand) (*ProcessState, error) {
 	e.logger.Trace("preparing to launch command", "command", command.Cmd, "args", strings.Join(command.Args, " "))
 
+	if command.CheckpointDir != "" {
+		return nil, ErrCheckpointNotSupported
+	}
+
 	e.command = command
 
 	// setting the user of the process
@@ -547,6 +585,12 @@ func (e *UniversalExecutor) UpdateResources(resources *drivers.Resources) error
 	return nil
 }
 
+// Checkpoint is not supported by the universal executor as the process isn't
+// isolated in a container.
+func (e *UniversalExecutor) Checkpoint(dir string) error {
+	return ErrCheckpointNotSupported
+}
+
 func (e *UniversalExecutor) wait() {
 	defer close(e.processExited)
 	defer e.command.Close()
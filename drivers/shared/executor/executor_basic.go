@@ -6,6 +6,7 @@
 package executor
 
 import (
+	"errors"
 	"os/exec"
 
 	"github.com/hashicorp/go-hclog"
@@ -21,6 +22,11 @@ func NewExecutorWithIsolation(logger hclog.Logger, compute cpustats.Compute) Exe
 	return NewExecutor(logger, compute)
 }
 
+// RootlessSupported returns an error on non-Linux systems.
+func RootlessSupported() error {
+	return errors.New("rootless mode is only supported on Linux")
+}
+
 func (e *UniversalExecutor) configureResourceContainer(_ *ExecCommand, _ int) (func(), error) {
 	nothing := func() {}
 	return nothing, nil
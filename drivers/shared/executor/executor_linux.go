@@ -80,6 +80,10 @@ type LibcontainerExecutor struct {
 	userProcExited chan interface{}
 	exitState      *ProcessState
 	sigChan        chan os.Signal
+
+	// slirp4netns connects the network namespace of rootless containers to
+	// the host
+	slirp4netns *exec.Cmd
 }
 
 func (l *LibcontainerExecutor) catchSignals() {
@@ -164,14 +168,30 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 
 	l.command = command
 
-	// create a new factory which will store the container state in the allocDir
-	factory, err := libcontainer.New(
-		path.Join(command.TaskDir, "../alloc/container"),
-		// note that os.Args[0] refers to the executor shim typically
-		// and first args arguments is ignored now due
-		// until https://github.com/opencontainers/runc/pull/1888 is merged
+	// note that os.Args[0] refers to the executor shim typically
+	// and first args arguments is ignored now due
+	// until https://github.com/opencontainers/runc/pull/1888 is merged
+	factoryOpts := []func(*libcontainer.LinuxFactory) error{
 		libcontainer.InitArgs(os.Args[0], "libcontainer-shim"),
-	)
+	}
+	if command.Rootless {
+		// the setuid helpers map the subordinate IDs of unprivileged users
+		newuidmap, err := exec.LookPath("newuidmap")
+		if err != nil {
+			return nil, fmt.Errorf("failed to find newuidmap: %v", err)
+		}
+		newgidmap, err := exec.LookPath("newgidmap")
+		if err != nil {
+			return nil, fmt.Errorf("failed to find newgidmap: %v", err)
+		}
+		factoryOpts = append(factoryOpts,
+			libcontainer.NewuidmapPath(newuidmap),
+			libcontainer.NewgidmapPath(newgidmap),
+		)
+	}
+
+	// create a new factory which will store the container state in the allocDir
+	factory, err := libcontainer.New(path.Join(command.TaskDir, "../alloc/container"), factoryOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create factory: %v", err)
 	}
@@ -223,14 +243,24 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		process.User = command.User
 	}
 
+	if command.WorkDir != "" {
+		process.Cwd = command.WorkDir
+	}
+
 	l.userProc = process
 
 	l.totalCpuStats = cpustats.New(l.compute)
 	l.userCpuStats = cpustats.New(l.compute)
 	l.systemCpuStats = cpustats.New(l.compute)
 
-	// Starts the task
-	if err := container.Run(process); err != nil {
+	// Starts the task, or restores it from its checkpoint
+	if command.CheckpointDir != "" {
+		l.logger.Debug("restoring from checkpoint", "dir", command.CheckpointDir)
+		if err := container.Restore(process, l.criuOpts(command.CheckpointDir)); err != nil {
+			container.Destroy()
+			return nil, fmt.Errorf("failed to restore checkpoint: %v", err)
+		}
+	} else if err := container.Run(process); err != nil {
 		container.Destroy()
 		return nil, err
 	}
@@ -241,6 +271,14 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		return nil, err
 	}
 
+	if command.Rootless && command.RootlessNetwork == RootlessNetworkSlirp4netns {
+		l.slirp4netns, err = startSlirp4netns(pid)
+		if err != nil {
+			container.Destroy()
+			return nil, err
+		}
+	}
+
 	// start a goroutine to wait on the process to complete, so Wait calls can
 	// be multiplexed
 	l.userProcExited = make(chan interface{})
@@ -298,6 +336,11 @@ func (l *LibcontainerExecutor) wait() {
 
 	l.command.Close()
 
+	if l.slirp4netns != nil {
+		_ = l.slirp4netns.Process.Kill()
+		_ = l.slirp4netns.Wait()
+	}
+
 	exitCode := 1
 	var signal int
 	if status, ok := ps.Sys().(syscall.WaitStatus); ok {
@@ -381,9 +424,70 @@ func (l *LibcontainerExecutor) Shutdown(signal string, grace time.Duration) erro
 
 // UpdateResources updates the resource isolation with new values to be enforced
 func (l *LibcontainerExecutor) UpdateResources(resources *drivers.Resources) error {
+	if l.container == nil {
+		return fmt.Errorf("container has not been started")
+	}
+	if !l.command.ResourceLimits || resources == nil || resources.LinuxResources == nil {
+		return nil
+	}
+
+	command := *l.command
+	command.Resources = resources
+
+	// copy the cgroup config so it's left untouched if the update fails
+	cfg := l.container.Config()
+	cg := *cfg.Cgroups
+	res := *cg.Resources
+	cg.Resources = &res
+	cfg.Cgroups = &cg
+
+	l.configureCgroupMemory(&cfg, &command)
+
+	cpuShares := l.clampCpuShares(resources.LinuxResources.CPUShares)
+	switch cgroupslib.GetMode() {
+	case cgroupslib.CG1:
+		cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
+	default:
+		cfg.Cgroups.Resources.CpuWeight = cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
+	}
+
+	l.logger.Debug("updating container resources", "cpu_shares", cpuShares, "memory", cfg.Cgroups.Resources.Memory)
+	if err := l.container.Set(cfg); err != nil {
+		return fmt.Errorf("failed to update container(%s) resources: %v", l.id, err)
+	}
+
+	l.command.Resources = resources
+	return nil
+}
+
+// Checkpoint dumps the container's process tree into dir with CRIU. The
+// processes are killed once dumped.
+func (l *LibcontainerExecutor) Checkpoint(dir string) error {
+	if l.container == nil {
+		return fmt.Errorf("container has not been started")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %v", err)
+	}
+
+	l.logger.Debug("checkpointing container", "dir", dir)
+	if err := l.container.Checkpoint(l.criuOpts(dir)); err != nil {
+		return fmt.Errorf("failed to checkpoint container(%s): %v", l.id, err)
+	}
 	return nil
 }
 
+// criuOpts returns the CRIU options used to checkpoint and restore tasks. The
+// work directory holding CRIU's logs is kept out of the images directory so
+// it isn't migrated with them.
+func (l *LibcontainerExecutor) criuOpts(dir string) *libcontainer.CriuOpts {
+	return &libcontainer.CriuOpts{
+		ImagesDirectory: dir,
+		WorkDirectory:   filepath.Join(l.command.TaskDir, "criu"),
+		FileLocks:       true,
+	}
+}
+
 // Version returns the api version of the executor
 func (l *LibcontainerExecutor) Version() (*ExecutorVersion, error) {
 	return &ExecutorVersion{Version: ExecutorVersionLatest}, nil
@@ -749,6 +853,9 @@ func (l *LibcontainerExecutor) configureCgroups(cfg *runc.Config, command *ExecC
 	// set the libcontainer memory limits
 	l.configureCgroupMemory(cfg, command)
 
+	// set the libcontainer disk I/O limits
+	l.configureCgroupIO(cfg, command)
+
 	// set cgroup v1/v2 specific attributes (cpu, path)
 	switch cgroupslib.GetMode() {
 	case cgroupslib.CG1:
@@ -782,6 +889,32 @@ func (l *LibcontainerExecutor) configureCgroupMemory(cfg *runc.Config, command *
 	cfg.Cgroups.Resources.MemorySwappiness = cgroupslib.MaybeDisableMemorySwappiness()
 }
 
+// configureCgroupIO sets the disk I/O weight of the task, and throttles its
+// reads and writes to the disk backing the task directory.
+func (l *LibcontainerExecutor) configureCgroupIO(cfg *runc.Config, command *ExecCommand) {
+	limits := command.Resources.NomadResources.IO
+	cfg.Cgroups.Resources.BlkioWeight = uint16(limits.Weight)
+
+	if limits.ReadBps == 0 && limits.WriteBps == 0 {
+		return
+	}
+	major, minor, err := cgroupslib.BlockDevice(command.TaskDir)
+	if err != nil {
+		l.logger.Warn("unable to limit disk bandwidth of task", "error", err)
+		return
+	}
+	if limits.ReadBps > 0 {
+		cfg.Cgroups.Resources.BlkioThrottleReadBpsDevice = []*runc.ThrottleDevice{
+			runc.NewThrottleDevice(int64(major), int64(minor), uint64(limits.ReadBps)),
+		}
+	}
+	if limits.WriteBps > 0 {
+		cfg.Cgroups.Resources.BlkioThrottleWriteBpsDevice = []*runc.ThrottleDevice{
+			runc.NewThrottleDevice(int64(major), int64(minor), uint64(limits.WriteBps)),
+		}
+	}
+}
+
 func (l *LibcontainerExecutor) configureCG1(cfg *runc.Config, command *ExecCommand, cgroup string) error {
 
 	cpuShares := l.clampCpuShares(command.Resources.LinuxResources.CPUShares)
@@ -858,6 +991,12 @@ func (l *LibcontainerExecutor) newLibcontainerConfig(command *ExecCommand) (*run
 		return nil, err
 	}
 
+	if command.Rootless {
+		if err := configureRootless(cfg, command); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := l.configureCgroups(cfg, command); err != nil {
 		return nil, err
 	}
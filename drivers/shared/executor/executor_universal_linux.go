@@ -278,6 +278,31 @@ func (e *UniversalExecutor) configureCG2(cgroup string, command *ExecCommand) {
 	// write cpuset cgroup file, if set
 	cpusetCpus := command.Resources.LinuxResources.CpusetCpus
 	_ = ed.Write("cpuset.cpus", cpusetCpus)
+
+	// write io cgroup files, if set
+	e.configureIO(cgroup, command)
+}
+
+// configureIO writes the disk I/O weight of the task, and throttles its reads
+// and writes to the disk backing the task directory.
+func (e *UniversalExecutor) configureIO(cgroup string, command *ExecCommand) {
+	limits := command.Resources.NomadResources.IO
+	ed := cgroupslib.OpenPath(cgroup)
+
+	if limits.Weight > 0 {
+		weight := cgroups.ConvertBlkIOToIOWeightValue(uint16(limits.Weight))
+		_ = ed.Write("io.weight", "default "+strconv.FormatUint(weight, 10))
+	}
+
+	if limits.ReadBps == 0 && limits.WriteBps == 0 {
+		return
+	}
+	major, minor, err := cgroupslib.BlockDevice(command.TaskDir)
+	if err != nil {
+		e.logger.Warn("unable to limit disk bandwidth of task", "error", err)
+		return
+	}
+	_ = ed.Write("io.max", cgroupslib.IOMax(major, minor, limits.ReadBps, limits.WriteBps))
 }
 
 func (e *UniversalExecutor) setOomAdj(oomScore int32) error {
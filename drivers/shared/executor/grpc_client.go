@@ -55,6 +55,10 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		CgroupV2Override: cmd.OverrideCgroupV2,
 		CgroupV1Override: cmd.OverrideCgroupV1,
 		OomScoreAdj:      cmd.OOMScoreAdj,
+		CheckpointDir:    cmd.CheckpointDir,
+		WorkDir:          cmd.WorkDir,
+		Rootless:         cmd.Rootless,
+		RootlessNetwork:  cmd.RootlessNetwork,
 	}
 	resp, err := c.client.Launch(ctx, req)
 	if err != nil {
@@ -108,6 +112,15 @@ func (c *grpcExecutorClient) UpdateResources(r *drivers.Resources) error {
 	return nil
 }
 
+func (c *grpcExecutorClient) Checkpoint(dir string) error {
+	ctx := context.Background()
+	if _, err := c.client.Checkpoint(ctx, &proto.CheckpointRequest{ImagesDir: dir}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (c *grpcExecutorClient) Version() (*ExecutorVersion, error) {
 	ctx := context.Background()
 	resp, err := c.client.Version(ctx, &proto.VersionRequest{})
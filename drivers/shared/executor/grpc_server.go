@@ -44,6 +44,10 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		OverrideCgroupV2: req.CgroupV2Override,
 		OverrideCgroupV1: req.CgroupV1Override,
 		OOMScoreAdj:      req.OomScoreAdj,
+		CheckpointDir:    req.CheckpointDir,
+		WorkDir:          req.WorkDir,
+		Rootless:         req.Rootless,
+		RootlessNetwork:  req.RootlessNetwork,
 	})
 
 	if err != nil {
@@ -92,6 +96,14 @@ func (s *grpcExecutorServer) UpdateResources(ctx context.Context, req *proto.Upd
 	return &proto.UpdateResourcesResponse{}, nil
 }
 
+func (s *grpcExecutorServer) Checkpoint(ctx context.Context, req *proto.CheckpointRequest) (*proto.CheckpointResponse, error) {
+	if err := s.impl.Checkpoint(req.ImagesDir); err != nil {
+		return nil, err
+	}
+
+	return &proto.CheckpointResponse{}, nil
+}
+
 func (s *grpcExecutorServer) Version(context.Context, *proto.VersionRequest) (*proto.VersionResponse, error) {
 	v, err := s.impl.Version()
 	if err != nil {
@@ -49,6 +49,10 @@ type LaunchRequest struct {
 	CgroupV2Override     string                       `protobuf:"bytes,20,opt,name=cgroup_v2_override,json=cgroupV2Override,proto3" json:"cgroup_v2_override,omitempty"`
 	CgroupV1Override     map[string]string            `protobuf:"bytes,21,rep,name=cgroup_v1_override,json=cgroupV1Override,proto3" json:"cgroup_v1_override,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	OomScoreAdj          int32                        `protobuf:"varint,22,opt,name=oom_score_adj,json=oomScoreAdj,proto3" json:"oom_score_adj,omitempty"`
+	CheckpointDir        string                       `protobuf:"bytes,23,opt,name=checkpoint_dir,json=checkpointDir,proto3" json:"checkpoint_dir,omitempty"`
+	WorkDir              string                       `protobuf:"bytes,24,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	Rootless             bool                         `protobuf:"varint,25,opt,name=rootless,proto3" json:"rootless,omitempty"`
+	RootlessNetwork      string                       `protobuf:"bytes,26,opt,name=rootless_network,json=rootlessNetwork,proto3" json:"rootless_network,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -234,6 +238,34 @@ func (m *LaunchRequest) GetOomScoreAdj() int32 {
 	return 0
 }
 
+func (m *LaunchRequest) GetCheckpointDir() string {
+	if m != nil {
+		return m.CheckpointDir
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetWorkDir() string {
+	if m != nil {
+		return m.WorkDir
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetRootless() bool {
+	if m != nil {
+		return m.Rootless
+	}
+	return false
+}
+
+func (m *LaunchRequest) GetRootlessNetwork() string {
+	if m != nil {
+		return m.RootlessNetwork
+	}
+	return ""
+}
+
 type LaunchResponse struct {
 	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -882,6 +914,76 @@ func (m *ProcessState) GetOomKilled() bool {
 	return false
 }
 
+type CheckpointRequest struct {
+	ImagesDir            string   `protobuf:"bytes,1,opt,name=images_dir,json=imagesDir,proto3" json:"images_dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointRequest) Reset()         { *m = CheckpointRequest{} }
+func (m *CheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointRequest) ProtoMessage()    {}
+func (*CheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{17}
+}
+
+func (m *CheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointRequest.Unmarshal(m, b)
+}
+func (m *CheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointRequest.Merge(m, src)
+}
+func (m *CheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointRequest.Size(m)
+}
+func (m *CheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointRequest proto.InternalMessageInfo
+
+func (m *CheckpointRequest) GetImagesDir() string {
+	if m != nil {
+		return m.ImagesDir
+	}
+	return ""
+}
+
+type CheckpointResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointResponse) Reset()         { *m = CheckpointResponse{} }
+func (m *CheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointResponse) ProtoMessage()    {}
+func (*CheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{18}
+}
+
+func (m *CheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointResponse.Unmarshal(m, b)
+}
+func (m *CheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointResponse.Merge(m, src)
+}
+func (m *CheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointResponse.Size(m)
+}
+func (m *CheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*LaunchRequest)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest.CgroupV1OverrideEntry")
@@ -901,6 +1003,8 @@ func init() {
 	proto.RegisterType((*ExecRequest)(nil), "hashicorp.nomad.plugins.executor.proto.ExecRequest")
 	proto.RegisterType((*ExecResponse)(nil), "hashicorp.nomad.plugins.executor.proto.ExecResponse")
 	proto.RegisterType((*ProcessState)(nil), "hashicorp.nomad.plugins.executor.proto.ProcessState")
+	proto.RegisterType((*CheckpointRequest)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointRequest")
+	proto.RegisterType((*CheckpointResponse)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointResponse")
 }
 
 func init() {
@@ -908,82 +1012,89 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1187 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x7d, 0x6f, 0xdb, 0x44,
-	0x1c, 0x9e, 0x9b, 0xa6, 0x49, 0x7e, 0x49, 0xda, 0xec, 0xd8, 0x8a, 0x17, 0x84, 0x56, 0x8c, 0xc4,
-	0x22, 0x18, 0xee, 0xd6, 0xbd, 0x0a, 0x24, 0x06, 0xeb, 0x06, 0x9a, 0xf6, 0x42, 0xe5, 0x8e, 0x4d,
-	0xe2, 0x0f, 0xcc, 0xcd, 0xbe, 0x25, 0xb7, 0x38, 0x3e, 0x73, 0x77, 0xce, 0x5a, 0x09, 0x89, 0x2f,
-	0x01, 0x12, 0x1f, 0x80, 0x4f, 0xc4, 0x27, 0x42, 0xf7, 0xe6, 0x26, 0xdd, 0x98, 0xdc, 0x22, 0xfe,
-	0xca, 0xdd, 0xe3, 0xe7, 0xf7, 0x7e, 0xf7, 0x5c, 0xe0, 0x72, 0xca, 0xe9, 0x9c, 0x70, 0xb1, 0x2d,
-	0x26, 0x98, 0x93, 0x74, 0x9b, 0x1c, 0x90, 0xa4, 0x94, 0x8c, 0x6f, 0x17, 0x9c, 0x49, 0x56, 0x6d,
-	0x43, 0xbd, 0x45, 0x9f, 0x4c, 0xb0, 0x98, 0xd0, 0x84, 0xf1, 0x22, 0xcc, 0xd9, 0x0c, 0xa7, 0x61,
-	0x91, 0x95, 0x63, 0x9a, 0x8b, 0x70, 0x99, 0x37, 0xbc, 0x38, 0x66, 0x6c, 0x9c, 0x11, 0xe3, 0xe4,
-	0x45, 0xf9, 0x72, 0x5b, 0xd2, 0x19, 0x11, 0x12, 0xcf, 0x0a, 0x4b, 0x08, 0xac, 0xe1, 0xb6, 0x0b,
-	0x6f, 0xc2, 0x99, 0x9d, 0xe1, 0x04, 0x7f, 0xb7, 0xa1, 0xff, 0x08, 0x97, 0x79, 0x32, 0x89, 0xc8,
-	0x2f, 0x25, 0x11, 0x12, 0x0d, 0xa0, 0x91, 0xcc, 0x52, 0xdf, 0xdb, 0xf2, 0x46, 0x9d, 0x48, 0x2d,
-	0x11, 0x82, 0x55, 0xcc, 0xc7, 0xc2, 0x5f, 0xd9, 0x6a, 0x8c, 0x3a, 0x91, 0x5e, 0xa3, 0x27, 0xd0,
-	0xe1, 0x44, 0xb0, 0x92, 0x27, 0x44, 0xf8, 0x8d, 0x2d, 0x6f, 0xd4, 0xdd, 0xb9, 0x12, 0xfe, 0x5b,
-	0xe2, 0x36, 0xbe, 0x09, 0x19, 0x46, 0xce, 0x2e, 0x3a, 0x72, 0x81, 0x2e, 0x42, 0x57, 0xc8, 0x94,
-	0x95, 0x32, 0x2e, 0xb0, 0x9c, 0xf8, 0xab, 0x3a, 0x3a, 0x18, 0x68, 0x0f, 0xcb, 0x89, 0x25, 0x10,
-	0xce, 0x0d, 0xa1, 0x59, 0x11, 0x08, 0xe7, 0x9a, 0x30, 0x80, 0x06, 0xc9, 0xe7, 0xfe, 0x9a, 0x4e,
-	0x52, 0x2d, 0x55, 0xde, 0xa5, 0x20, 0xdc, 0x6f, 0x69, 0xae, 0x5e, 0xa3, 0x0b, 0xd0, 0x96, 0x58,
-	0x4c, 0xe3, 0x94, 0x72, 0xbf, 0xad, 0xf1, 0x96, 0xda, 0xdf, 0xa3, 0x1c, 0x5d, 0x82, 0x0d, 0x97,
-	0x4f, 0x9c, 0xd1, 0x19, 0x95, 0xc2, 0xef, 0x6c, 0x79, 0xa3, 0x76, 0xb4, 0xee, 0xe0, 0x47, 0x1a,
-	0x45, 0xd7, 0xe1, 0xdc, 0x0b, 0x2c, 0x68, 0x12, 0x17, 0x9c, 0x25, 0x44, 0x88, 0x38, 0x19, 0x73,
-	0x56, 0x16, 0x3e, 0x28, 0xf6, 0xdd, 0x15, 0xdf, 0x8b, 0x90, 0xfe, 0xbe, 0x67, 0x3e, 0xef, 0xea,
-	0xaf, 0xe8, 0x1e, 0xac, 0xcd, 0x58, 0x99, 0x4b, 0xe1, 0x77, 0xb7, 0x1a, 0xa3, 0xee, 0xce, 0xe5,
-	0x9a, 0xed, 0x7a, 0xac, 0x8c, 0x22, 0x6b, 0x8b, 0xbe, 0x83, 0x56, 0x4a, 0xe6, 0x54, 0x75, 0xbd,
-	0xa7, 0xdd, 0x7c, 0x5e, 0xd3, 0xcd, 0x3d, 0x6d, 0x15, 0x39, 0x6b, 0x34, 0x81, 0xb3, 0x39, 0x91,
-	0xaf, 0x19, 0x9f, 0xc6, 0x54, 0xb0, 0x0c, 0x4b, 0xca, 0x72, 0xbf, 0xaf, 0x07, 0xf9, 0x65, 0x4d,
-	0x97, 0x4f, 0x8c, 0xfd, 0x03, 0x67, 0xbe, 0x5f, 0x90, 0x24, 0x1a, 0xe4, 0xc7, 0x50, 0x14, 0x40,
-	0x3f, 0x67, 0x71, 0x41, 0xe7, 0x4c, 0xc6, 0x9c, 0x31, 0xe9, 0xaf, 0xeb, 0xae, 0x76, 0x73, 0xb6,
-	0xa7, 0xb0, 0x88, 0x31, 0x89, 0x46, 0x30, 0x48, 0xc9, 0x4b, 0x5c, 0x66, 0x32, 0x2e, 0x68, 0x1a,
-	0xcf, 0x58, 0x4a, 0xfc, 0x0d, 0x3d, 0x9e, 0x75, 0x8b, 0xef, 0xd1, 0xf4, 0x31, 0x4b, 0xc9, 0x22,
-	0x93, 0x16, 0x89, 0x61, 0x0e, 0x96, 0x98, 0x0f, 0x8a, 0x44, 0x33, 0x3f, 0x86, 0x7e, 0x52, 0x94,
-	0x82, 0x48, 0x37, 0x9f, 0xb3, 0x9a, 0xd6, 0x33, 0xa0, 0x9d, 0xca, 0x87, 0x00, 0x38, 0xcb, 0xd8,
-	0xeb, 0x38, 0xc1, 0x85, 0xf0, 0x91, 0x3e, 0x3c, 0x1d, 0x8d, 0xec, 0xe2, 0x42, 0xa0, 0x00, 0x7a,
-	0x09, 0x2e, 0xf0, 0x0b, 0x9a, 0x51, 0x49, 0x89, 0xf0, 0xdf, 0xd3, 0x84, 0x25, 0x0c, 0x5d, 0x06,
-	0x64, 0x02, 0xc4, 0xf3, 0x9d, 0x98, 0xcd, 0x09, 0xe7, 0x34, 0x25, 0xfe, 0x39, 0x1d, 0x6c, 0x60,
-	0xbe, 0x3c, 0xdb, 0xf9, 0xde, 0xe2, 0xe8, 0xf0, 0x88, 0x7d, 0xf5, 0x88, 0x7d, 0x5e, 0xcf, 0xf2,
-	0x61, 0x58, 0xef, 0xea, 0x87, 0x4b, 0x37, 0x36, 0x34, 0xa5, 0x3c, 0xbb, 0xea, 0x62, 0xdc, 0xcf,
-	0x25, 0x3f, 0xac, 0x42, 0x57, 0xb0, 0x1a, 0x04, 0x63, 0xb3, 0x58, 0x24, 0x8c, 0x93, 0x18, 0xa7,
-	0xaf, 0xfc, 0xcd, 0x2d, 0x6f, 0xd4, 0x8c, 0xba, 0x8c, 0xcd, 0xf6, 0x15, 0xf6, 0x4d, 0xfa, 0x6a,
-	0xb8, 0x0b, 0xe7, 0xdf, 0xea, 0x4e, 0x5d, 0xaf, 0x29, 0x39, 0x74, 0xb2, 0x30, 0x25, 0x87, 0xe8,
-	0x1c, 0x34, 0xe7, 0x38, 0x2b, 0x89, 0xbf, 0xa2, 0x31, 0xb3, 0xf9, 0x62, 0xe5, 0xb6, 0x17, 0xfc,
-	0x0c, 0xeb, 0x2e, 0x43, 0x51, 0xb0, 0x5c, 0x10, 0xf4, 0x04, 0x5a, 0xf6, 0xb2, 0x68, 0x0f, 0xdd,
-	0x9d, 0xeb, 0x75, 0x4b, 0xb5, 0x97, 0x68, 0x5f, 0x62, 0x49, 0x22, 0xe7, 0x24, 0xe8, 0x43, 0xf7,
-	0x39, 0xa6, 0xd2, 0x76, 0x20, 0xf8, 0x09, 0x7a, 0x66, 0xfb, 0x3f, 0x85, 0x7b, 0x04, 0x1b, 0xfb,
-	0x93, 0x52, 0xa6, 0xec, 0x75, 0xee, 0x64, 0x72, 0x13, 0xd6, 0x04, 0x1d, 0xe7, 0x38, 0xb3, 0x2d,
-	0xb1, 0x3b, 0xf4, 0x11, 0xf4, 0xc6, 0x1c, 0x27, 0x24, 0x2e, 0x08, 0xa7, 0x2c, 0xd5, 0xcd, 0x69,
-	0x44, 0x5d, 0x8d, 0xed, 0x69, 0x28, 0x40, 0x30, 0x38, 0xf2, 0x66, 0x32, 0x0e, 0x26, 0xb0, 0xf9,
-	0x43, 0x91, 0xaa, 0xa0, 0x95, 0x3a, 0xda, 0x40, 0x4b, 0x4a, 0xeb, 0xfd, 0x67, 0xa5, 0x0d, 0x2e,
-	0xc0, 0xfb, 0x6f, 0x44, 0xb2, 0x49, 0x0c, 0x60, 0xfd, 0x19, 0xe1, 0x82, 0x32, 0x57, 0x65, 0xf0,
-	0x19, 0x6c, 0x54, 0x88, 0xed, 0xad, 0x0f, 0xad, 0xb9, 0x81, 0x6c, 0xe5, 0x6e, 0x1b, 0x7c, 0x0a,
-	0x3d, 0xd5, 0xb7, 0x2a, 0xf3, 0x21, 0xb4, 0x69, 0x2e, 0x09, 0x9f, 0xdb, 0x26, 0x35, 0xa2, 0x6a,
-	0x1f, 0x3c, 0x87, 0xbe, 0xe5, 0x5a, 0xb7, 0xdf, 0x42, 0x53, 0x28, 0xe0, 0x84, 0x25, 0x3e, 0xc5,
-	0x62, 0x6a, 0x1c, 0x19, 0xf3, 0xe0, 0x12, 0xf4, 0xf7, 0xf5, 0x24, 0xde, 0x3e, 0xa8, 0xa6, 0x1b,
-	0x94, 0x2a, 0xd6, 0x11, 0x6d, 0xf9, 0x53, 0xe8, 0xde, 0x3f, 0x20, 0x89, 0x33, 0xbc, 0x09, 0xed,
-	0x94, 0xe0, 0x34, 0xa3, 0x39, 0xb1, 0x49, 0x0d, 0x43, 0xf3, 0xe4, 0x86, 0xee, 0xc9, 0x0d, 0x9f,
-	0xba, 0x27, 0x37, 0xaa, 0xb8, 0xee, 0x01, 0x5d, 0x79, 0xf3, 0x01, 0x6d, 0x1c, 0x3d, 0xa0, 0xc1,
-	0x2e, 0xf4, 0x4c, 0x30, 0x5b, 0xff, 0x26, 0xac, 0xb1, 0x52, 0x16, 0xa5, 0xd4, 0xb1, 0x7a, 0x91,
-	0xdd, 0xa1, 0x0f, 0xa0, 0x43, 0x0e, 0xa8, 0x8c, 0x13, 0x25, 0x74, 0x2b, 0xba, 0x82, 0xb6, 0x02,
-	0x76, 0x59, 0x4a, 0x82, 0xbf, 0x3c, 0xe8, 0x2d, 0x9e, 0x58, 0x15, 0xbb, 0xa0, 0xa9, 0xad, 0x54,
-	0x2d, 0xdf, 0x69, 0xbf, 0xd0, 0x9b, 0xc6, 0x62, 0x6f, 0x50, 0x08, 0xab, 0xea, 0xcf, 0x84, 0x7e,
-	0x86, 0xdf, 0x5d, 0xb6, 0xe6, 0x29, 0x15, 0x55, 0xca, 0x32, 0xa5, 0x59, 0x46, 0x52, 0xfd, 0x36,
-	0xb7, 0xa3, 0x0e, 0x63, 0xb3, 0x87, 0x1a, 0xd8, 0xf9, 0xa3, 0x03, 0xed, 0xfb, 0xf6, 0x9e, 0xa1,
-	0x43, 0x58, 0x33, 0xe2, 0x80, 0x6e, 0x9c, 0x4a, 0xee, 0x86, 0x37, 0x4f, 0x6a, 0x66, 0xc7, 0x7b,
-	0x06, 0x09, 0x58, 0x55, 0x32, 0x81, 0xae, 0xd5, 0xf5, 0xb0, 0xa0, 0x31, 0xc3, 0xeb, 0x27, 0x33,
-	0xaa, 0x82, 0xfe, 0x06, 0x6d, 0x77, 0xdb, 0xd1, 0xad, 0xba, 0x3e, 0x8e, 0xa9, 0xcd, 0xf0, 0xf6,
-	0xc9, 0x0d, 0xab, 0x04, 0x7e, 0xf7, 0x60, 0xe3, 0xd8, 0x8d, 0x47, 0x5f, 0xd5, 0xf5, 0xf7, 0x76,
-	0x51, 0x1a, 0xde, 0x39, 0xb5, 0x7d, 0x95, 0xd6, 0xaf, 0xd0, 0xb2, 0xd2, 0x82, 0x6a, 0x4f, 0x74,
-	0x59, 0x9d, 0x86, 0xb7, 0x4e, 0x6c, 0x57, 0x45, 0x3f, 0x80, 0xa6, 0x96, 0x0d, 0x54, 0x7b, 0xac,
-	0x8b, 0xd2, 0x36, 0xbc, 0x71, 0x42, 0x2b, 0x17, 0xf7, 0x8a, 0xa7, 0xce, 0xbf, 0xd1, 0x9d, 0xfa,
-	0xe7, 0x7f, 0x49, 0xd0, 0xea, 0x9f, 0xff, 0x63, 0xf2, 0xa6, 0xcf, 0xbf, 0xba, 0x86, 0xf5, 0xcf,
-	0xff, 0x82, 0x1c, 0xd6, 0x3f, 0xff, 0x8b, 0xb2, 0x16, 0x9c, 0x41, 0x7f, 0x7a, 0xd0, 0x57, 0xd0,
-	0xbe, 0xe4, 0x04, 0xcf, 0x68, 0x3e, 0x46, 0x77, 0x6a, 0x6a, 0xbb, 0xb2, 0x32, 0xfa, 0x6e, 0x2d,
-	0x5d, 0x2a, 0x5f, 0x9f, 0xde, 0x81, 0x4b, 0x6b, 0xe4, 0x5d, 0xf1, 0xee, 0xb6, 0x7e, 0x6c, 0x1a,
-	0x49, 0x5b, 0xd3, 0x3f, 0xd7, 0xfe, 0x09, 0x00, 0x00, 0xff, 0xff, 0xd2, 0x85, 0x8d, 0xe6, 0xa9,
-	0x0d, 0x00, 0x00,
+	// 1297 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xdb, 0x72, 0xdb, 0x44,
+	0x18, 0x46, 0x71, 0x0e, 0xf6, 0x6f, 0x3b, 0x71, 0x97, 0x34, 0x55, 0xc4, 0x74, 0x1a, 0xc4, 0x40,
+	0x0d, 0x14, 0xa7, 0x75, 0xd3, 0x03, 0x65, 0x86, 0x42, 0x93, 0xc2, 0x74, 0x7a, 0x20, 0xa3, 0x94,
+	0x76, 0x86, 0x0b, 0x84, 0x2a, 0x6d, 0xed, 0xad, 0x65, 0xad, 0xd8, 0x5d, 0xb9, 0xc9, 0x0c, 0x33,
+	0x5c, 0xf1, 0x06, 0x5c, 0x70, 0xc3, 0x1d, 0x6f, 0xc5, 0xcb, 0x30, 0x7b, 0x92, 0xed, 0xb6, 0x80,
+	0x1c, 0x86, 0x2b, 0xef, 0x7e, 0xfa, 0xbf, 0xff, 0xb8, 0xfb, 0xad, 0xe1, 0x52, 0xc2, 0xc8, 0x04,
+	0x33, 0xbe, 0xcb, 0x87, 0x11, 0xc3, 0xc9, 0x2e, 0x3e, 0xc6, 0x71, 0x21, 0x28, 0xdb, 0xcd, 0x19,
+	0x15, 0xb4, 0xdc, 0xf6, 0xd4, 0x16, 0x7d, 0x30, 0x8c, 0xf8, 0x90, 0xc4, 0x94, 0xe5, 0xbd, 0x8c,
+	0x8e, 0xa3, 0xa4, 0x97, 0xa7, 0xc5, 0x80, 0x64, 0xbc, 0x37, 0x6f, 0xe7, 0x5d, 0x18, 0x50, 0x3a,
+	0x48, 0xb1, 0x76, 0xf2, 0xac, 0x78, 0xbe, 0x2b, 0xc8, 0x18, 0x73, 0x11, 0x8d, 0x73, 0x63, 0xe0,
+	0x1b, 0xe2, 0xae, 0x0d, 0xaf, 0xc3, 0xe9, 0x9d, 0xb6, 0xf1, 0xff, 0x6c, 0x40, 0xfb, 0x41, 0x54,
+	0x64, 0xf1, 0x30, 0xc0, 0x3f, 0x16, 0x98, 0x0b, 0xd4, 0x81, 0x5a, 0x3c, 0x4e, 0x5c, 0x67, 0xc7,
+	0xe9, 0x36, 0x02, 0xb9, 0x44, 0x08, 0x96, 0x23, 0x36, 0xe0, 0xee, 0xd2, 0x4e, 0xad, 0xdb, 0x08,
+	0xd4, 0x1a, 0x3d, 0x82, 0x06, 0xc3, 0x9c, 0x16, 0x2c, 0xc6, 0xdc, 0xad, 0xed, 0x38, 0xdd, 0x66,
+	0xff, 0x72, 0xef, 0xef, 0x12, 0x37, 0xf1, 0x75, 0xc8, 0x5e, 0x60, 0x79, 0xc1, 0xd4, 0x05, 0xba,
+	0x00, 0x4d, 0x2e, 0x12, 0x5a, 0x88, 0x30, 0x8f, 0xc4, 0xd0, 0x5d, 0x56, 0xd1, 0x41, 0x43, 0x87,
+	0x91, 0x18, 0x1a, 0x03, 0xcc, 0x98, 0x36, 0x58, 0x29, 0x0d, 0x30, 0x63, 0xca, 0xa0, 0x03, 0x35,
+	0x9c, 0x4d, 0xdc, 0x55, 0x95, 0xa4, 0x5c, 0xca, 0xbc, 0x0b, 0x8e, 0x99, 0xbb, 0xa6, 0x6c, 0xd5,
+	0x1a, 0x6d, 0x43, 0x5d, 0x44, 0x7c, 0x14, 0x26, 0x84, 0xb9, 0x75, 0x85, 0xaf, 0xc9, 0xfd, 0x01,
+	0x61, 0xe8, 0x22, 0x6c, 0xd8, 0x7c, 0xc2, 0x94, 0x8c, 0x89, 0xe0, 0x6e, 0x63, 0xc7, 0xe9, 0xd6,
+	0x83, 0x75, 0x0b, 0x3f, 0x50, 0x28, 0xda, 0x83, 0xcd, 0x67, 0x11, 0x27, 0x71, 0x98, 0x33, 0x1a,
+	0x63, 0xce, 0xc3, 0x78, 0xc0, 0x68, 0x91, 0xbb, 0x20, 0xad, 0xef, 0x2c, 0xb9, 0x4e, 0x80, 0xd4,
+	0xf7, 0x43, 0xfd, 0x79, 0x5f, 0x7d, 0x45, 0x07, 0xb0, 0x3a, 0xa6, 0x45, 0x26, 0xb8, 0xdb, 0xdc,
+	0xa9, 0x75, 0x9b, 0xfd, 0x4b, 0x15, 0xdb, 0xf5, 0x50, 0x92, 0x02, 0xc3, 0x45, 0x5f, 0xc3, 0x5a,
+	0x82, 0x27, 0x44, 0x76, 0xbd, 0xa5, 0xdc, 0x7c, 0x52, 0xd1, 0xcd, 0x81, 0x62, 0x05, 0x96, 0x8d,
+	0x86, 0x70, 0x26, 0xc3, 0xe2, 0x25, 0x65, 0xa3, 0x90, 0x70, 0x9a, 0x46, 0x82, 0xd0, 0xcc, 0x6d,
+	0xab, 0x41, 0x7e, 0x56, 0xd1, 0xe5, 0x23, 0xcd, 0xbf, 0x67, 0xe9, 0x47, 0x39, 0x8e, 0x83, 0x4e,
+	0xf6, 0x0a, 0x8a, 0x7c, 0x68, 0x67, 0x34, 0xcc, 0xc9, 0x84, 0x8a, 0x90, 0x51, 0x2a, 0xdc, 0x75,
+	0xd5, 0xd5, 0x66, 0x46, 0x0f, 0x25, 0x16, 0x50, 0x2a, 0x50, 0x17, 0x3a, 0x09, 0x7e, 0x1e, 0x15,
+	0xa9, 0x08, 0x73, 0x92, 0x84, 0x63, 0x9a, 0x60, 0x77, 0x43, 0x8d, 0x67, 0xdd, 0xe0, 0x87, 0x24,
+	0x79, 0x48, 0x13, 0x3c, 0x6b, 0x49, 0xf2, 0x58, 0x5b, 0x76, 0xe6, 0x2c, 0xef, 0xe5, 0xb1, 0xb2,
+	0x7c, 0x0f, 0xda, 0x71, 0x5e, 0x70, 0x2c, 0xec, 0x7c, 0xce, 0x28, 0xb3, 0x96, 0x06, 0xcd, 0x54,
+	0xce, 0x03, 0x44, 0x69, 0x4a, 0x5f, 0x86, 0x71, 0x94, 0x73, 0x17, 0xa9, 0xc3, 0xd3, 0x50, 0xc8,
+	0x7e, 0x94, 0x73, 0xe4, 0x43, 0x2b, 0x8e, 0xf2, 0xe8, 0x19, 0x49, 0x89, 0x20, 0x98, 0xbb, 0x6f,
+	0x2b, 0x83, 0x39, 0x0c, 0x5d, 0x02, 0xa4, 0x03, 0x84, 0x93, 0x7e, 0x48, 0x27, 0x98, 0x31, 0x92,
+	0x60, 0x77, 0x53, 0x05, 0xeb, 0xe8, 0x2f, 0x4f, 0xfa, 0xdf, 0x18, 0x1c, 0x9d, 0x4c, 0xad, 0xaf,
+	0x4c, 0xad, 0xcf, 0xaa, 0x59, 0xde, 0xef, 0x55, 0xbb, 0xfa, 0xbd, 0xb9, 0x1b, 0xdb, 0xd3, 0xa5,
+	0x3c, 0xb9, 0x62, 0x63, 0xdc, 0xcd, 0x04, 0x3b, 0x29, 0x43, 0x97, 0xb0, 0x1c, 0x04, 0xa5, 0xe3,
+	0x90, 0xc7, 0x94, 0xe1, 0x30, 0x4a, 0x5e, 0xb8, 0x5b, 0x3b, 0x4e, 0x77, 0x25, 0x68, 0x52, 0x3a,
+	0x3e, 0x92, 0xd8, 0x97, 0xc9, 0x0b, 0xf4, 0x3e, 0xac, 0xc7, 0x43, 0x1c, 0x8f, 0x72, 0x4a, 0x32,
+	0xa1, 0x6e, 0xc9, 0x39, 0x55, 0x48, 0x7b, 0x8a, 0xca, 0xbb, 0xb2, 0x0d, 0x75, 0x75, 0x74, 0xa4,
+	0x81, 0xab, 0xaf, 0x91, 0xdc, 0xcb, 0x4f, 0x1e, 0xd4, 0xe5, 0x94, 0x53, 0xcc, 0xb9, 0xbb, 0xad,
+	0x26, 0x5d, 0xee, 0xd1, 0x87, 0xd0, 0xb1, 0xeb, 0xd0, 0x9c, 0x13, 0xd7, 0x53, 0xf4, 0x0d, 0x8b,
+	0x9b, 0x43, 0xe5, 0xed, 0xc3, 0xd9, 0x37, 0xd6, 0x25, 0xef, 0xf9, 0x08, 0x9f, 0x58, 0x7d, 0x1a,
+	0xe1, 0x13, 0xb4, 0x09, 0x2b, 0x93, 0x28, 0x2d, 0xb0, 0xbb, 0xa4, 0x30, 0xbd, 0xb9, 0xb5, 0x74,
+	0xd3, 0xf1, 0x7f, 0x80, 0x75, 0xdb, 0x2a, 0x9e, 0xd3, 0x8c, 0x63, 0xf4, 0x08, 0xd6, 0xcc, 0xad,
+	0x55, 0x1e, 0x9a, 0xfd, 0xbd, 0xaa, 0x3d, 0x37, 0xb7, 0xf9, 0x48, 0x44, 0x02, 0x07, 0xd6, 0x89,
+	0xdf, 0x86, 0xe6, 0xd3, 0x88, 0x08, 0x33, 0x0a, 0xff, 0x7b, 0x68, 0xe9, 0xed, 0xff, 0x14, 0xee,
+	0x01, 0x6c, 0x1c, 0x0d, 0x0b, 0x91, 0xd0, 0x97, 0x99, 0xd5, 0xeb, 0x2d, 0x58, 0xe5, 0x64, 0x90,
+	0x45, 0xa9, 0x69, 0x89, 0xd9, 0xa1, 0x77, 0xa1, 0x35, 0x60, 0x51, 0x8c, 0xc3, 0x1c, 0x33, 0x42,
+	0x13, 0xd5, 0x9c, 0x5a, 0xd0, 0x54, 0xd8, 0xa1, 0x82, 0x7c, 0x04, 0x9d, 0xa9, 0x37, 0x9d, 0xb1,
+	0x3f, 0x84, 0xad, 0x6f, 0xf3, 0x44, 0x06, 0x2d, 0x65, 0xda, 0x04, 0x9a, 0x93, 0x7c, 0xe7, 0x3f,
+	0x4b, 0xbe, 0xbf, 0x0d, 0xe7, 0x5e, 0x8b, 0x64, 0x92, 0xe8, 0xc0, 0xfa, 0x13, 0xcc, 0x38, 0xa1,
+	0xb6, 0x4a, 0xff, 0x63, 0xd8, 0x28, 0x11, 0xd3, 0x5b, 0x17, 0xd6, 0x26, 0x1a, 0x32, 0x95, 0xdb,
+	0xad, 0xff, 0x11, 0xb4, 0x64, 0xdf, 0xca, 0xcc, 0x3d, 0xa8, 0x93, 0x4c, 0x60, 0x36, 0x31, 0x4d,
+	0xaa, 0x05, 0xe5, 0xde, 0x7f, 0x0a, 0x6d, 0x63, 0x6b, 0xdc, 0x7e, 0x05, 0x2b, 0x5c, 0x02, 0x0b,
+	0x96, 0xf8, 0x38, 0xe2, 0x23, 0xed, 0x48, 0xd3, 0xfd, 0x8b, 0xd0, 0x3e, 0x52, 0x93, 0x78, 0xf3,
+	0xa0, 0x56, 0xec, 0xa0, 0x64, 0xb1, 0xd6, 0xd0, 0x94, 0x3f, 0x82, 0xe6, 0xdd, 0x63, 0x1c, 0x5b,
+	0xe2, 0x75, 0xa8, 0x27, 0x38, 0x4a, 0x52, 0x92, 0x61, 0x93, 0x94, 0xd7, 0xd3, 0x6f, 0x7f, 0xcf,
+	0xbe, 0xfd, 0xbd, 0xc7, 0xf6, 0xed, 0x0f, 0x4a, 0x5b, 0xfb, 0x92, 0x2f, 0xbd, 0xfe, 0x92, 0xd7,
+	0xa6, 0x2f, 0xb9, 0xbf, 0x0f, 0x2d, 0x1d, 0xcc, 0xd4, 0xbf, 0x05, 0xab, 0xb4, 0x10, 0x79, 0x21,
+	0x54, 0xac, 0x56, 0x60, 0x76, 0xe8, 0x1d, 0x68, 0xe0, 0x63, 0x22, 0xc2, 0x58, 0x2a, 0xee, 0x92,
+	0xaa, 0xa0, 0x2e, 0x81, 0x7d, 0x9a, 0x60, 0xff, 0x0f, 0x07, 0x5a, 0xb3, 0x27, 0x56, 0xc6, 0xce,
+	0x49, 0x62, 0x2a, 0x95, 0xcb, 0x7f, 0xe4, 0xcf, 0xf4, 0xa6, 0x36, 0xdb, 0x1b, 0xd4, 0x83, 0x65,
+	0xf9, 0xaf, 0xc6, 0x5d, 0xfe, 0xd7, 0xb2, 0x95, 0x9d, 0x94, 0x73, 0x29, 0x71, 0x23, 0x92, 0xa6,
+	0x38, 0x51, 0x7f, 0x12, 0xea, 0x41, 0x83, 0xd2, 0xf1, 0x7d, 0x05, 0xf8, 0x7d, 0x38, 0xb3, 0x5f,
+	0xea, 0x98, 0x6d, 0xef, 0x79, 0x00, 0x32, 0x8e, 0x06, 0x98, 0x2b, 0x35, 0xd3, 0x47, 0xa9, 0xa1,
+	0x91, 0x03, 0xc2, 0xfc, 0x4d, 0x40, 0xb3, 0x1c, 0xdd, 0xa5, 0xfe, 0xef, 0x00, 0xf5, 0xbb, 0xe6,
+	0xc6, 0xa2, 0x13, 0x58, 0xd5, 0x32, 0x83, 0xae, 0x9d, 0x4a, 0xc1, 0xbd, 0xeb, 0x8b, 0xd2, 0xcc,
+	0x41, 0x79, 0x0b, 0x71, 0x58, 0x96, 0x82, 0x83, 0xae, 0x56, 0xf5, 0x30, 0xa3, 0x56, 0xde, 0xde,
+	0x62, 0xa4, 0x32, 0xe8, 0xcf, 0x50, 0xb7, 0xba, 0x81, 0x6e, 0x54, 0xf5, 0xf1, 0x8a, 0x6e, 0x79,
+	0x37, 0x17, 0x27, 0x96, 0x09, 0xfc, 0xea, 0xc0, 0xc6, 0x2b, 0xda, 0x81, 0x3e, 0xaf, 0xea, 0xef,
+	0xcd, 0xf2, 0xe6, 0xdd, 0x3e, 0x35, 0xbf, 0x4c, 0xeb, 0x27, 0x58, 0x33, 0x22, 0x85, 0x2a, 0x4f,
+	0x74, 0x5e, 0xe7, 0xbc, 0x1b, 0x0b, 0xf3, 0xca, 0xe8, 0xc7, 0xb0, 0xa2, 0x04, 0x08, 0x55, 0x1e,
+	0xeb, 0xac, 0x48, 0x7a, 0xd7, 0x16, 0x64, 0xd9, 0xb8, 0x97, 0x1d, 0x79, 0xfe, 0xb5, 0x82, 0x55,
+	0x3f, 0xff, 0x73, 0xd2, 0xe8, 0x5d, 0x5f, 0x94, 0x36, 0x7b, 0xfe, 0xe5, 0x35, 0xac, 0x7e, 0xfe,
+	0x67, 0x84, 0xd5, 0xdb, 0x5b, 0x8c, 0x54, 0x06, 0xfd, 0xcd, 0x81, 0xb6, 0x84, 0x8e, 0x04, 0xc3,
+	0xd1, 0x98, 0x64, 0x03, 0x74, 0xbb, 0xe2, 0x2b, 0x21, 0x59, 0xfa, 0xa5, 0x30, 0x4c, 0x9b, 0xca,
+	0x17, 0xa7, 0x77, 0x60, 0xd3, 0xea, 0x3a, 0x97, 0x1d, 0xf4, 0x8b, 0x03, 0x30, 0x95, 0x2b, 0xf4,
+	0x69, 0xd5, 0x0a, 0x5f, 0x93, 0x45, 0xef, 0xd6, 0x69, 0xa8, 0x36, 0x97, 0x3b, 0x6b, 0xdf, 0xad,
+	0x68, 0x91, 0x5e, 0x55, 0x3f, 0x57, 0xff, 0x1a, 0x00, 0x8e, 0xa6, 0x4b, 0x80, 0x04, 0x0f, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1008,6 +1119,7 @@ type ExecutorClient interface {
 	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error)
+	Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
 }
 
 type executorClient struct {
@@ -1144,6 +1256,15 @@ func (x *executorExecStreamingClient) Recv() (*proto1.ExecTaskStreamingResponse,
 	return m, nil
 }
 
+func (c *executorClient) Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error) {
+	out := new(CheckpointResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExecutorServer is the server API for Executor service.
 type ExecutorServer interface {
 	Launch(context.Context, *LaunchRequest) (*LaunchResponse, error)
@@ -1156,6 +1277,7 @@ type ExecutorServer interface {
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(Executor_ExecStreamingServer) error
+	Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error)
 }
 
 // UnimplementedExecutorServer can be embedded to have forward compatible implementations.
@@ -1189,6 +1311,9 @@ func (*UnimplementedExecutorServer) Exec(ctx context.Context, req *ExecRequest)
 func (*UnimplementedExecutorServer) ExecStreaming(srv Executor_ExecStreamingServer) error {
 	return status.Errorf(codes.Unimplemented, "method ExecStreaming not implemented")
 }
+func (*UnimplementedExecutorServer) Checkpoint(ctx context.Context, req *CheckpointRequest) (*CheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checkpoint not implemented")
+}
 
 func RegisterExecutorServer(s *grpc.Server, srv ExecutorServer) {
 	s.RegisterService(&_Executor_serviceDesc, srv)
@@ -1367,6 +1492,24 @@ func (x *executorExecStreamingServer) Recv() (*proto1.ExecTaskStreamingRequest,
 	return m, nil
 }
 
+func _Executor_Checkpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Checkpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Checkpoint(ctx, req.(*CheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Executor_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.executor.proto.Executor",
 	HandlerType: (*ExecutorServer)(nil),
@@ -1399,6 +1542,10 @@ var _Executor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Exec",
 			Handler:    _Executor_Exec_Handler,
 		},
+		{
+			MethodName: "Checkpoint",
+			Handler:    _Executor_Checkpoint_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
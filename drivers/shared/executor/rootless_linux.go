@@ -0,0 +1,212 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package executor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/hashicorp/nomad/client/lib/cgroupslib"
+	runc "github.com/opencontainers/runc/libcontainer/configs"
+)
+
+const (
+	// subUIDFile and subGIDFile delegate ranges of subordinate IDs to users
+	subUIDFile = "/etc/subuid"
+	subGIDFile = "/etc/subgid"
+
+	// minSubIDs is the number of subordinate IDs a user must be delegated to
+	// run rootless tasks, covering the IDs of the usual system users and
+	// groups such as nobody
+	minSubIDs = 65536
+)
+
+// subIDRange is a range of subordinate IDs delegated to a user.
+type subIDRange struct {
+	Start int64
+	Count int64
+}
+
+// parseSubIDs returns the first range of at least minSubIDs subordinate IDs
+// delegated to the user with the given name or ID. Entries of the subuid and
+// subgid files are formatted as "<name or id>:<start>:<count>".
+func parseSubIDs(r io.Reader, name string, id int) (subIDRange, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.Split(line, ":")
+		if len(parts) != 3 || (parts[0] != name && parts[0] != strconv.Itoa(id)) {
+			continue
+		}
+		start, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		count, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil || count < minSubIDs {
+			continue
+		}
+		return subIDRange{Start: start, Count: count}, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return subIDRange{}, err
+	}
+	return subIDRange{}, fmt.Errorf("no range of %d subordinate IDs found for user %s", minSubIDs, name)
+}
+
+// lookupSubIDs returns the subordinate IDs delegated to the current user by
+// the given file.
+func lookupSubIDs(path string) (subIDRange, error) {
+	u, err := user.Current()
+	if err != nil {
+		return subIDRange{}, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return subIDRange{}, err
+	}
+	defer f.Close()
+
+	r, err := parseSubIDs(f, u.Username, os.Getuid())
+	if err != nil {
+		return subIDRange{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return r, nil
+}
+
+// RootlessSupported returns nil if the executor can run processes without
+// root privileges, or the reason it can't.
+func RootlessSupported() error {
+	if !cgroupslib.Rootless() {
+		return fmt.Errorf("no cgroups v2 subtree is delegated to the agent")
+	}
+
+	b, err := os.ReadFile("/proc/sys/user/max_user_namespaces")
+	if err != nil {
+		return fmt.Errorf("user namespaces aren't supported by the kernel: %w", err)
+	}
+	if strings.TrimSpace(string(b)) == "0" {
+		return fmt.Errorf("user namespaces are disabled by user.max_user_namespaces")
+	}
+	// only set by some distributions
+	if b, err := os.ReadFile("/proc/sys/kernel/unprivileged_userns_clone"); err == nil && strings.TrimSpace(string(b)) == "0" {
+		return fmt.Errorf("unprivileged user namespaces are disabled by kernel.unprivileged_userns_clone")
+	}
+
+	for _, path := range []string{subUIDFile, subGIDFile} {
+		if _, err := lookupSubIDs(path); err != nil {
+			return err
+		}
+	}
+	for _, bin := range []string{"newuidmap", "newgidmap"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			return fmt.Errorf("%s is required to map subordinate IDs: %w", bin, err)
+		}
+	}
+	return nil
+}
+
+// rootlessIDMappings maps root within the user namespace to the executor's
+// user, and the IDs above it to the user's subordinate IDs.
+func rootlessIDMappings() ([]runc.IDMap, []runc.IDMap, error) {
+	subUIDs, err := lookupSubIDs(subUIDFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	subGIDs, err := lookupSubIDs(subGIDFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uids := []runc.IDMap{
+		{ContainerID: 0, HostID: int64(os.Geteuid()), Size: 1},
+		{ContainerID: 1, HostID: subUIDs.Start, Size: subUIDs.Count},
+	}
+	gids := []runc.IDMap{
+		{ContainerID: 0, HostID: int64(os.Getegid()), Size: 1},
+		{ContainerID: 1, HostID: subGIDs.Start, Size: subGIDs.Count},
+	}
+	return uids, gids, nil
+}
+
+// configureRootless runs the container in a user namespace. Errors from
+// cgroup controllers which aren't delegated are ignored by libcontainer.
+func configureRootless(cfg *runc.Config, command *ExecCommand) error {
+	uids, gids, err := rootlessIDMappings()
+	if err != nil {
+		return err
+	}
+
+	cfg.Namespaces = append(cfg.Namespaces, runc.Namespace{Type: runc.NEWUSER})
+	cfg.UidMappings = uids
+	cfg.GidMappings = gids
+	cfg.RootlessEUID = true
+	cfg.RootlessCgroups = true
+	cfg.Cgroups.Rootless = true
+
+	if command.RootlessNetwork == RootlessNetworkSlirp4netns {
+		cfg.Namespaces = append(cfg.Namespaces, runc.Namespace{Type: runc.NEWNET})
+	}
+
+	cfg.Mounts = rootlessMounts(cfg)
+	return nil
+}
+
+// rootlessMounts returns the mounts of the container in a user namespace.
+// proc, sysfs and mqueue can only be mounted from namespaces owned by the
+// user namespace, so the host's are bind mounted instead when the container
+// shares them.
+func rootlessMounts(cfg *runc.Config) []*runc.Mount {
+	mounts := make([]*runc.Mount, 0, len(cfg.Mounts))
+	for _, m := range cfg.Mounts {
+		switch {
+		case m.Device == "proc" && !cfg.Namespaces.Contains(runc.NEWPID):
+			mounts = append(mounts, rootlessBindMount("/proc"))
+		case m.Device == "sysfs" && !cfg.Namespaces.Contains(runc.NEWNET):
+			mounts = append(mounts, rootlessBindMount("/sys"))
+		case m.Device == "mqueue" && !cfg.Namespaces.Contains(runc.NEWIPC):
+			// the task shares the host's message queues
+		default:
+			mounts = append(mounts, m)
+		}
+	}
+	return mounts
+}
+
+// rootlessBindMount returns a read-only recursive bind mount of the host
+// path at the same path in the container.
+func rootlessBindMount(path string) *runc.Mount {
+	return &runc.Mount{
+		Source:      path,
+		Destination: path,
+		Device:      "bind",
+		Flags:       syscall.MS_BIND | syscall.MS_REC | syscall.MS_RDONLY | syscall.MS_NOEXEC | syscall.MS_NOSUID | syscall.MS_NODEV,
+	}
+}
+
+// startSlirp4netns connects the network namespace of the process to the
+// host with user-mode networking. The process reaches the host's network
+// through the gateway 10.0.2.2, and its DNS through 10.0.2.3.
+func startSlirp4netns(pid int) (*exec.Cmd, error) {
+	cmd := exec.Command("slirp4netns",
+		"--configure", "--mtu=65520", "--disable-host-loopback",
+		strconv.Itoa(pid), "tap0")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start slirp4netns: %w", err)
+	}
+	return cmd, nil
+}
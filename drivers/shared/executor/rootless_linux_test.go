@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	runc "github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/shoenig/test/must"
+)
+
+func TestRootless_parseSubIDs(t *testing.T) {
+	ci.Parallel(t)
+
+	const subuid = `
+# comment
+alice:100000:65536
+bob:165536:1000
+1001:231072:65536
+bob:296608:65536
+`
+
+	cases := []struct {
+		name string
+		user string
+		id   int
+		exp  subIDRange
+		err  string
+	}{
+		{name: "by name", user: "alice", id: 1000, exp: subIDRange{Start: 100000, Count: 65536}},
+		{name: "by id", user: "carol", id: 1001, exp: subIDRange{Start: 231072, Count: 65536}},
+		{name: "skips small ranges", user: "bob", id: 1002, exp: subIDRange{Start: 296608, Count: 65536}},
+		{name: "missing", user: "dave", id: 1003, err: "no range of 65536 subordinate IDs found for user dave"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := parseSubIDs(strings.NewReader(subuid), tc.user, tc.id)
+			if tc.err != "" {
+				must.EqError(t, err, tc.err)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.exp, r)
+		})
+	}
+}
+
+func TestRootless_rootlessMounts(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg := &runc.Config{
+		Mounts: []*runc.Mount{
+			{Source: "proc", Destination: "/proc", Device: "proc"},
+			{Source: "mqueue", Destination: "/dev/mqueue", Device: "mqueue"},
+			{Source: "sysfs", Destination: "/sys", Device: "sysfs"},
+		},
+	}
+
+	// private namespaces own their filesystems
+	cfg.Namespaces = runc.Namespaces{{Type: runc.NEWPID}, {Type: runc.NEWIPC}, {Type: runc.NEWNET}}
+	must.Eq(t, cfg.Mounts, rootlessMounts(cfg))
+
+	// shared namespaces are bind mounted from the host
+	cfg.Namespaces = runc.Namespaces{{Type: runc.NEWNS}}
+	mounts := rootlessMounts(cfg)
+	must.Len(t, 2, mounts)
+	must.Eq(t, "bind", mounts[0].Device)
+	must.Eq(t, "/proc", mounts[0].Source)
+	must.Eq(t, "bind", mounts[1].Device)
+	must.Eq(t, "/sys", mounts[1].Source)
+}
@@ -65,6 +65,15 @@ var msgTypeNames = map[structs.MessageType]string{
 	structs.ACLBindingRulesDeleteRequestType:             "ACLBindingRulesDeleteRequestType",
 	structs.NodePoolUpsertRequestType:                    "NodePoolUpsertRequestType",
 	structs.NodePoolDeleteRequestType:                    "NodePoolDeleteRequestType",
+	structs.HostVolumeRegisterRequestType:                "HostVolumeRegisterRequestType",
+	structs.HostVolumeDeleteRequestType:                  "HostVolumeDeleteRequestType",
+	structs.JobVersionTagRequestType:                     "JobVersionTagRequestType",
 	structs.NamespaceUpsertRequestType:                   "NamespaceUpsertRequestType",
 	structs.NamespaceDeleteRequestType:                   "NamespaceDeleteRequestType",
+	structs.QuotaSpecUpsertRequestType:                   "QuotaSpecUpsertRequestType",
+	structs.QuotaSpecDeleteRequestType:                   "QuotaSpecDeleteRequestType",
+	structs.SentinelPolicyUpsertRequestType:              "SentinelPolicyUpsertRequestType",
+	structs.SentinelPolicyDeleteRequestType:              "SentinelPolicyDeleteRequestType",
+	structs.RecommendationUpsertRequestType:              "RecommendationUpsertRequestType",
+	structs.RecommendationDeleteRequestType:              "RecommendationDeleteRequestType",
 }
@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package raftutil
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-version"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+)
+
+// SimulateOptions are the changes applied to the cluster state before a job
+// is simulated against it.
+type SimulateOptions struct {
+	// CloneNodeID is the ID, or a unique ID prefix, of the node copied to
+	// add nodes to the cluster.
+	CloneNodeID string
+
+	// AddNodes is the number of copies of the CloneNodeID node to add.
+	AddNodes int
+
+	// SchedulerAlgorithm overrides the scheduler algorithm of the cluster.
+	SchedulerAlgorithm structs.SchedulerAlgorithm
+}
+
+// SimulateResult is the outcome of scheduling a job against a cluster state.
+type SimulateResult struct {
+	// Eval is the evaluation processed by the scheduler, including its
+	// failed placement metrics and queued allocations.
+	Eval *structs.Evaluation
+
+	// Plans are the plans submitted by the scheduler. Every plan is accepted
+	// in full, as if there were no concurrent evaluations.
+	Plans []*structs.Plan
+
+	// BlockedEval is the evaluation the scheduler created to wait for
+	// capacity for failed placements, if any.
+	BlockedEval *structs.Evaluation
+
+	// AddedNodes are the nodes added to the cluster state.
+	AddedNodes []*structs.Node
+
+	// SchedulerConfig is the scheduler configuration the job was simulated
+	// with.
+	SchedulerConfig *structs.SchedulerConfiguration
+}
+
+// Simulate registers the job in the state store and runs the scheduler for
+// the job type against it, without applying the resulting plans. The state
+// store is modified, so it should be a store restored for this purpose only,
+// such as one returned by RestoreFromArchive.
+func Simulate(store *state.StateStore, job *structs.Job, opts *SimulateOptions) (*SimulateResult, error) {
+	if opts == nil {
+		opts = &SimulateOptions{}
+	}
+
+	index, err := store.LatestIndex()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read latest index: %w", err)
+	}
+
+	result := &SimulateResult{}
+
+	_, schedConfig, err := store.SchedulerConfig()
+	if err != nil {
+		return nil, err
+	}
+	if schedConfig == nil {
+		schedConfig = &structs.SchedulerConfiguration{}
+	} else {
+		schedConfig = schedConfig.Copy()
+	}
+	if opts.SchedulerAlgorithm != "" {
+		schedConfig.SchedulerAlgorithm = opts.SchedulerAlgorithm
+	}
+	schedConfig.Canonicalize()
+	if err := schedConfig.Validate(); err != nil {
+		return nil, err
+	}
+	index++
+	if err := store.SchedulerSetConfig(index, schedConfig); err != nil {
+		return nil, fmt.Errorf("failed to set scheduler configuration: %w", err)
+	}
+	result.SchedulerConfig = schedConfig
+
+	if opts.AddNodes > 0 {
+		template, err := simulateNodeTemplate(store, opts.CloneNodeID)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < opts.AddNodes; i++ {
+			node := template.Copy()
+			node.ID = uuid.Generate()
+			node.SecretID = uuid.Generate()
+			node.Name = fmt.Sprintf("%s-simulated-%d", template.Name, i)
+			node.Status = structs.NodeStatusReady
+			node.SchedulingEligibility = structs.NodeSchedulingEligible
+			node.DrainStrategy = nil
+
+			index++
+			if err := store.UpsertNode(structs.NodeRegisterRequestType, index, node); err != nil {
+				return nil, fmt.Errorf("failed to add node: %w", err)
+			}
+			result.AddedNodes = append(result.AddedNodes, node)
+		}
+	}
+
+	job.Canonicalize()
+	if err := job.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid job: %w", err)
+	}
+	index++
+	if err := store.UpsertJob(structs.JobRegisterRequestType, index, nil, job); err != nil {
+		return nil, fmt.Errorf("failed to register job: %w", err)
+	}
+
+	now := time.Now().UnixNano()
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    structs.EvalTriggerJobRegister,
+		JobID:          job.ID,
+		JobModifyIndex: index,
+		Status:         structs.EvalStatusPending,
+		CreateTime:     now,
+		ModifyTime:     now,
+	}
+	index++
+	if err := store.UpsertEvals(structs.EvalUpdateRequestType, index, []*structs.Evaluation{eval}); err != nil {
+		return nil, fmt.Errorf("failed to create evaluation: %w", err)
+	}
+
+	planner := &simulatePlanner{}
+	sched, err := scheduler.NewScheduler(eval.Type, hclog.NewNullLogger(), nil, store, planner)
+	if err != nil {
+		return nil, err
+	}
+	if err := sched.Process(eval); err != nil {
+		return nil, fmt.Errorf("failed to process evaluation: %w", err)
+	}
+
+	result.Eval = eval
+	if planner.eval != nil {
+		result.Eval = planner.eval
+	}
+	result.Plans = planner.plans
+	result.BlockedEval = planner.blocked
+	return result, nil
+}
+
+// simulateNodeTemplate returns the node with the given ID or unique ID
+// prefix.
+func simulateNodeTemplate(store *state.StateStore, prefix string) (*structs.Node, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("a node to clone is required to add nodes")
+	}
+
+	iter, err := store.NodesByIDPrefix(nil, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var node *structs.Node
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if node != nil {
+			return nil, fmt.Errorf("node prefix %q matched multiple nodes", prefix)
+		}
+		node = raw.(*structs.Node)
+	}
+	if node == nil {
+		return nil, fmt.Errorf("no node matches %q", prefix)
+	}
+	return node, nil
+}
+
+// simulatePlanner is a scheduler.Planner that accepts every plan without
+// applying it, and records the evaluation updates of the scheduler.
+type simulatePlanner struct {
+	l       sync.Mutex
+	plans   []*structs.Plan
+	eval    *structs.Evaluation
+	blocked *structs.Evaluation
+}
+
+func (p *simulatePlanner) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, scheduler.State, error) {
+	p.l.Lock()
+	defer p.l.Unlock()
+	p.plans = append(p.plans, plan)
+
+	result := &structs.PlanResult{
+		NodeUpdate:        plan.NodeUpdate,
+		NodeAllocation:    plan.NodeAllocation,
+		NodePreemptions:   plan.NodePreemptions,
+		Deployment:        plan.Deployment,
+		DeploymentUpdates: plan.DeploymentUpdates,
+	}
+	return result, nil, nil
+}
+
+func (p *simulatePlanner) UpdateEval(eval *structs.Evaluation) error {
+	p.l.Lock()
+	defer p.l.Unlock()
+	p.eval = eval.Copy()
+	return nil
+}
+
+func (p *simulatePlanner) CreateEval(eval *structs.Evaluation) error {
+	p.l.Lock()
+	defer p.l.Unlock()
+	if eval.Status == structs.EvalStatusBlocked {
+		p.blocked = eval.Copy()
+	}
+	return nil
+}
+
+func (p *simulatePlanner) ReblockEval(eval *structs.Evaluation) error {
+	return nil
+}
+
+func (p *simulatePlanner) ServersMeetMinimumVersion(_ *version.Version, _ bool) bool {
+	return true
+}
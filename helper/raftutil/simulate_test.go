@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package raftutil
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// simulatePlaced returns the number of allocations placed by the plans.
+func simulatePlaced(plans []*structs.Plan) int {
+	placed := 0
+	for _, plan := range plans {
+		for _, allocs := range plan.NodeAllocation {
+			placed += len(allocs)
+		}
+	}
+	return placed
+}
+
+func TestSimulate(t *testing.T) {
+	ci.Parallel(t)
+
+	// Each node fits a single allocation of the job.
+	newStore := func(t *testing.T) (*state.StateStore, *structs.Node) {
+		store := state.TestStateStore(t)
+		node := mock.Node()
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+		return store, node
+	}
+	newJob := func() *structs.Job {
+		job := mock.Job()
+		job.TaskGroups[0].Count = 3
+		job.TaskGroups[0].Tasks[0].Resources.CPU = 3000
+		return job
+	}
+
+	t.Run("failed placements", func(t *testing.T) {
+		store, _ := newStore(t)
+
+		result, err := Simulate(store, newJob(), nil)
+		must.NoError(t, err)
+		must.Eq(t, 1, simulatePlaced(result.Plans))
+		must.MapContainsKey(t, result.Eval.FailedTGAllocs, "web")
+		must.Eq(t, 2, result.Eval.FailedTGAllocs["web"].CoalescedFailures+1)
+		must.NotNil(t, result.BlockedEval)
+	})
+
+	t.Run("added nodes", func(t *testing.T) {
+		store, node := newStore(t)
+
+		result, err := Simulate(store, newJob(), &SimulateOptions{
+			CloneNodeID: node.ID[:8],
+			AddNodes:    2,
+		})
+		must.NoError(t, err)
+		must.Len(t, 2, result.AddedNodes)
+		must.Eq(t, 3, simulatePlaced(result.Plans))
+		must.MapEmpty(t, result.Eval.FailedTGAllocs)
+		must.Nil(t, result.BlockedEval)
+	})
+
+	t.Run("unknown node", func(t *testing.T) {
+		store, _ := newStore(t)
+
+		_, err := Simulate(store, newJob(), &SimulateOptions{
+			CloneNodeID: "ffffffff",
+			AddNodes:    1,
+		})
+		must.ErrorContains(t, err, "no node matches")
+	})
+
+	t.Run("scheduler algorithm", func(t *testing.T) {
+		store, _ := newStore(t)
+
+		result, err := Simulate(store, newJob(), &SimulateOptions{
+			SchedulerAlgorithm: structs.SchedulerAlgorithmSpread,
+		})
+		must.NoError(t, err)
+		must.Eq(t, structs.SchedulerAlgorithmSpread, result.SchedulerConfig.SchedulerAlgorithm)
+
+		_, err = Simulate(store, newJob(), &SimulateOptions{
+			SchedulerAlgorithm: "random",
+		})
+		must.ErrorContains(t, err, "invalid scheduler algorithm")
+	})
+}
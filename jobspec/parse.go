@@ -507,6 +507,7 @@ func parseMigrate(result **api.MigrateStrategy, list *ast.ObjectList) error {
 		"health_check",
 		"min_healthy_time",
 		"healthy_deadline",
+		"checkpoint",
 	}
 	if err := checkHCLKeys(o.Val, valid); err != nil {
 		return err
@@ -66,7 +66,9 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"affinity",
 		"spread",
 		"datacenters",
+		"gang",
 		"node_pool",
+		"prefetch",
 		"group",
 		"id",
 		"meta",
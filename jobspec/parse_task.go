@@ -629,6 +629,10 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 		"network",
 		"device",
 		"cores",
+		"io_weight",
+		"read_bps",
+		"write_bps",
+		"network_egress_mbits",
 	}
 	if err := checkHCLKeys(listVal, valid); err != nil {
 		return multierror.Prefix(err, "resources ->")
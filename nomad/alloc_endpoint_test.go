@@ -1236,6 +1236,9 @@ func TestAllocEndpoint_List_AllNamespaces_ACL_OSS(t *testing.T) {
 		acl.NamespaceCapabilityCSIReadVolume,
 		acl.NamespaceCapabilityCSIListVolume,
 		acl.NamespaceCapabilityCSIMountVolume,
+		acl.NamespaceCapabilityHostVolumeCreate,
+		acl.NamespaceCapabilityHostVolumeRead,
+		acl.NamespaceCapabilityHostVolumeDelete,
 		acl.NamespaceCapabilityListScalingPolicies,
 		acl.NamespaceCapabilityReadScalingPolicy,
 		acl.NamespaceCapabilityReadJobScaling,
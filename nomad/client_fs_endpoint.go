@@ -220,6 +220,66 @@ func (f *FileSystem) Stat(args *cstructs.FsStatRequest, reply *cstructs.FsStatRe
 	return NodeRpc(state.Session, "FileSystem.Stat", args, reply)
 }
 
+// LogsSearch is used to search the logs of a task for lines matching a regular
+// expression.
+func (f *FileSystem) LogsSearch(args *cstructs.FsLogsSearchRequest, reply *cstructs.FsLogsSearchResponse) error {
+	// We only allow stale reads since the only potentially stale information is
+	// the Node registration and the cost is fairly high for adding another hope
+	// in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+
+	authErr := f.srv.Authenticate(nil, args)
+
+	// Potentially forward to a different region.
+	if done, err := f.srv.forward("FileSystem.LogsSearch", args, args, reply); done {
+		return err
+	}
+	f.srv.MeasureRPCRate("file_system", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "file_system", "logs_search"}, time.Now())
+
+	// Verify the arguments.
+	if args.AllocID == "" {
+		return errors.New("missing allocation ID")
+	}
+
+	// Lookup the allocation
+	snap, err := f.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	alloc, err := getAlloc(snap, args.AllocID)
+	if err != nil {
+		return err
+	}
+
+	// Check namespace read-logs *or* read-fs permissions.
+	if aclObj, err := f.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadFS) &&
+		!aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilityReadLogs) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Make sure Node is valid and new enough to support RPC
+	_, err = getNodeForRpc(snap, alloc.NodeID)
+	if err != nil {
+		return err
+	}
+
+	// Get the connection to the client
+	state, ok := f.srv.getNodeConn(alloc.NodeID)
+	if !ok {
+		return findNodeConnAndForward(f.srv, alloc.NodeID, "FileSystem.LogsSearch", args, reply)
+	}
+
+	// Make the RPC
+	return NodeRpc(state.Session, "FileSystem.LogsSearch", args, reply)
+}
+
 // stream is is used to stream the contents of file in an allocation's
 // directory.
 func (f *FileSystem) stream(conn io.ReadWriteCloser) {
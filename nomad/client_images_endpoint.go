@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// NodeImages endpoint forwards requests managing the images cached on a node
+// to its client.
+type NodeImages struct {
+	srv    *Server
+	ctx    *RPCContext
+	logger log.Logger
+}
+
+func newNodeImagesEndpoint(srv *Server, ctx *RPCContext) *NodeImages {
+	return &NodeImages{
+		srv:    srv,
+		ctx:    ctx,
+		logger: srv.logger.Named("node_images"),
+	}
+}
+
+func (n *NodeImages) Prefetch(args *structs.NodeImagePrefetchRequest, reply *structs.NodeImagePrefetchResponse) error {
+	const method = "NodeImages.Prefetch"
+
+	// Prevent infinite loop between leader and
+	// follower-with-the-target-node-connection.
+	args.QueryOptions.AllowStale = true
+
+	authErr := n.srv.Authenticate(n.ctx, args)
+	if done, err := n.srv.forward(method, args, args, reply); done {
+		return err
+	}
+	n.srv.MeasureRPCRate("node_images", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client_images", "prefetch"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := n.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	return n.srv.forwardClientRPC(method, args.NodeID, args, reply)
+}
+
+// Warm is the server only counterpart of Prefetch, used by the leader to warm
+// the images of jobs with the prefetch hint on their nodes.
+func (n *NodeImages) Warm(args *structs.NodeImagePrefetchRequest, reply *structs.NodeImagePrefetchResponse) error {
+	aclObj, err := n.srv.AuthenticateServerOnly(n.ctx, args)
+	n.srv.MeasureRPCRate("node_images", structs.RateMetricWrite, args)
+	if err != nil || !aclObj.AllowServerOp() {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client_images", "warm"}, time.Now())
+
+	return n.srv.forwardClientRPC("NodeImages.Warm", args.NodeID, args, reply)
+}
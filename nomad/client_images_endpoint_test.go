@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestNodeImages_Prefetch_Forward(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	c, cleanupC := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s1.config.RPCAddr.String()}
+	})
+	defer cleanupC()
+	testutil.WaitForClient(t, s1.RPC, c.NodeID(), c.Region())
+
+	req := &structs.NodeImagePrefetchRequest{
+		NodeID:       c.NodeID(),
+		Driver:       "unknown",
+		Images:       []string{"redis:7"},
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.NodeImagePrefetchResponse
+
+	// The request reaches the client, which doesn't have the driver
+	err := msgpackrpc.CallWithCodec(codec, "NodeImages.Prefetch", req, &resp)
+	must.ErrorContains(t, err, `driver "unknown" is not available`)
+
+	// Unknown nodes are rejected by the server
+	req.NodeID = uuid.Generate()
+	err = msgpackrpc.CallWithCodec(codec, "NodeImages.Prefetch", req, &resp)
+	must.ErrorContains(t, err, structs.ErrUnknownNodePrefix)
+}
@@ -67,6 +67,19 @@ type Config struct {
 	// EventBufferSize is the amount of events to hold in memory.
 	EventBufferSize int64
 
+	// EventLogEnabled enables writing the event stream to a durable log in
+	// the data directory, so subscribers can resume from events no longer
+	// held in memory.
+	EventLogEnabled bool
+
+	// EventLogMaxBytes is the total size of the event log to retain. Zero
+	// means the event log is not limited by size.
+	EventLogMaxBytes int64
+
+	// EventLogMaxAge is how long to retain events in the event log. Zero
+	// means the event log is not limited by age.
+	EventLogMaxAge time.Duration
+
 	// JobMaxSourceSize limits the maximum size of a jobs source hcl/json
 	// before being discarded automatically. A value of zero indicates no job
 	// sources will be stored.
@@ -599,6 +612,7 @@ func DefaultConfig() *Config {
 		LicenseConfig:            &LicenseConfig{},
 		EnableEventBroker:        true,
 		EventBufferSize:          100,
+		EventLogMaxBytes:         1024 * 1024 * 1024,
 		ACLTokenMinExpirationTTL: 1 * time.Minute,
 		ACLTokenMaxExpirationTTL: 24 * time.Hour,
 		AutopilotConfig: &structs.AutopilotConfig{
@@ -0,0 +1,295 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// canaryAnalysisQueryTimeout is the timeout of a single metric query.
+	canaryAnalysisQueryTimeout = 10 * time.Second
+)
+
+// CanaryAnalysisProvider queries a metrics provider for the value of a canary
+// analysis metric.
+type CanaryAnalysisProvider interface {
+	// Query runs the query against the provider at the given address and
+	// returns its single value.
+	Query(ctx context.Context, address, query string) (float64, error)
+}
+
+// defaultCanaryAnalysisProviders returns the providers available to canary
+// analysis, keyed by the provider name used in the update block.
+func defaultCanaryAnalysisProviders() map[string]CanaryAnalysisProvider {
+	return map[string]CanaryAnalysisProvider{
+		structs.CanaryAnalysisProviderPrometheus: &prometheusProvider{
+			client: &http.Client{Timeout: canaryAnalysisQueryTimeout},
+		},
+	}
+}
+
+// prometheusProvider queries the instant query API of Prometheus or any
+// compatible server.
+type prometheusProvider struct {
+	client *http.Client
+}
+
+// prometheusResponse is the subset of the Prometheus query API response used
+// by canary analysis.
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+func (p *prometheusProvider) Query(ctx context.Context, address, query string) (float64, error) {
+	u := strings.TrimSuffix(address, "/") + "/api/v1/query?" + url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, err
+	}
+
+	var out prometheusResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return 0, fmt.Errorf("unexpected response with status %d: %w", resp.StatusCode, err)
+	}
+	if out.Status != "success" {
+		return 0, fmt.Errorf("query failed: %s", out.Error)
+	}
+
+	var sample []interface{}
+	switch out.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(out.Data.Result, &sample); err != nil {
+			return 0, err
+		}
+	case "vector":
+		var series []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(out.Data.Result, &series); err != nil {
+			return 0, err
+		}
+		if len(series) != 1 {
+			return 0, fmt.Errorf("query returned %d series, expected 1", len(series))
+		}
+		sample = series[0].Value
+	default:
+		return 0, fmt.Errorf("unsupported result type %q", out.Data.ResultType)
+	}
+
+	// Samples are a [timestamp, "value"] pair
+	if len(sample) != 2 {
+		return 0, errors.New("malformed sample")
+	}
+	value, ok := sample[1].(string)
+	if !ok {
+		return 0, errors.New("malformed sample value")
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// canaryAnalyses returns the canary analyses of the deployment's task groups
+// that have canaries, keyed by group name.
+func (w *deploymentWatcher) canaryAnalyses() map[string]*structs.CanaryAnalysis {
+	d := w.getDeployment()
+	analyses := make(map[string]*structs.CanaryAnalysis)
+	for _, tg := range w.j.TaskGroups {
+		if tg.Update == nil || tg.Update.CanaryAnalysis == nil {
+			continue
+		}
+		if dstate, ok := d.TaskGroups[tg.Name]; ok && dstate.DesiredCanaries > 0 {
+			analyses[tg.Name] = tg.Update.CanaryAnalysis
+		}
+	}
+	return analyses
+}
+
+// canaryAnalysisPassed returns whether the canaries of the task group passed
+// analysis. Groups without analysis have nothing to pass.
+func (w *deploymentWatcher) canaryAnalysisPassed(group string) (analyzed, passed bool) {
+	w.l.RLock()
+	defer w.l.RUnlock()
+	if _, ok := w.analyses[group]; !ok {
+		return false, false
+	}
+	return true, w.analysisPassed[group]
+}
+
+// watchCanaryAnalysis runs the canary analyses of the deployment until the
+// canaries are promoted or the deployment fails. Each group's canaries are
+// analyzed once all of them are healthy. A metric outside of its bounds fails
+// the deployment, and once every group has passed enough consecutive runs
+// the deployment is promoted.
+func (w *deploymentWatcher) watchCanaryAnalysis() {
+	interval := time.Duration(0)
+	for _, a := range w.analyses {
+		if interval == 0 || a.Interval < interval {
+			interval = a.Interval
+		}
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastRun := make(map[string]time.Time, len(w.analyses))
+	passes := make(map[string]int, len(w.analyses))
+	started := false
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case now := <-ticker.C:
+			d := w.getDeployment()
+			if !d.Active() || !d.RequiresPromotion() {
+				return
+			}
+			if d.Status != structs.DeploymentStatusRunning {
+				continue
+			}
+
+			allocs, err := w.state.AllocsByDeployment(nil, d.ID)
+			if err != nil {
+				w.logger.Error("failed to look up allocations for canary analysis", "error", err)
+				continue
+			}
+			healthy := make(map[string]bool, len(allocs))
+			stubs := make([]*structs.AllocListStub, 0, len(allocs))
+			for _, alloc := range allocs {
+				healthy[alloc.ID] = alloc.DeploymentStatus.IsHealthy()
+				stubs = append(stubs, alloc.Stub(nil))
+			}
+
+			for group, analysis := range w.analyses {
+				if w.analysisGroupPassed(group) || now.Sub(lastRun[group]) < analysis.Interval {
+					continue
+				}
+
+				// Wait for every canary to be healthy before analysis
+				dstate := d.TaskGroups[group]
+				if dstate == nil || len(dstate.PlacedCanaries) < dstate.DesiredCanaries {
+					continue
+				}
+				ready := true
+				for _, id := range dstate.PlacedCanaries {
+					ready = ready && healthy[id]
+				}
+				if !ready {
+					continue
+				}
+
+				if !started {
+					started = true
+					w.setCanaryAnalysisStatus(structs.DeploymentStatusDescriptionRunningCanaryAnalysis)
+				}
+
+				lastRun[group] = now
+				metric, err := w.runCanaryAnalysis(d, group, analysis)
+				if metric != nil {
+					desc := structs.DeploymentStatusDescriptionFailedCanaryAnalysis(group, metric.Name, err)
+					w.logger.Info("canaries failed analysis", "group", group, "metric", metric.Name, "error", err)
+					if _, _, _, err := w.failDeployment(desc); err != nil {
+						w.logger.Error("failed to fail deployment after canary analysis", "error", err)
+					}
+					return
+				}
+				if err != nil {
+					// Provider errors don't count either way
+					w.logger.Warn("canary analysis query failed", "group", group, "error", err)
+					continue
+				}
+
+				passes[group]++
+				w.logger.Debug("canary analysis run passed", "group", group, "passes", passes[group])
+				if passes[group] >= analysis.Runs {
+					w.l.Lock()
+					w.analysisPassed[group] = true
+					w.l.Unlock()
+				}
+			}
+
+			if err := w.autoPromoteDeployment(stubs); err != nil {
+				w.logger.Error("failed to auto promote deployment", "error", err)
+			}
+		}
+	}
+}
+
+// analysisGroupPassed returns whether the group's canaries passed analysis.
+func (w *deploymentWatcher) analysisGroupPassed(group string) bool {
+	_, passed := w.canaryAnalysisPassed(group)
+	return passed
+}
+
+// runCanaryAnalysis runs a single analysis of a group's canaries. If a metric
+// is outside of its bounds it is returned along with the reason. Otherwise
+// any error is from querying the provider.
+func (w *deploymentWatcher) runCanaryAnalysis(d *structs.Deployment, group string, analysis *structs.CanaryAnalysis) (*structs.CanaryAnalysisMetric, error) {
+	provider, ok := w.analysisProviders[analysis.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown canary analysis provider %q", analysis.Provider)
+	}
+
+	replacer := strings.NewReplacer(
+		"${NOMAD_JOB_ID}", d.JobID,
+		"${NOMAD_GROUP_NAME}", group,
+		"${NOMAD_DEPLOYMENT_ID}", d.ID,
+	)
+
+	for _, metric := range analysis.Metrics {
+		ctx, cancel := context.WithTimeout(w.ctx, canaryAnalysisQueryTimeout)
+		value, err := provider.Query(ctx, analysis.Address, replacer.Replace(metric.Query))
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("metric %q: %w", metric.Name, err)
+		}
+
+		// Queries return NaN when they have no samples to compute from, such
+		// as an error ratio while the canaries receive no requests. That is
+		// no evidence either way, so it is treated as a failed query.
+		if math.IsNaN(value) {
+			return nil, fmt.Errorf("metric %q: query returned NaN", metric.Name)
+		}
+		if err := metric.Check(value); err != nil {
+			return metric, err
+		}
+	}
+	return nil, nil
+}
+
+// setCanaryAnalysisStatus records the progress of canary analysis in the
+// deployment's status description.
+func (w *deploymentWatcher) setCanaryAnalysisStatus(desc string) {
+	u := w.getDeploymentStatusUpdate(structs.DeploymentStatusRunning, desc)
+	if _, err := w.upsertDeploymentStatusUpdate(u, nil, nil); err != nil {
+		w.logger.Error("failed to update deployment status", "error", err)
+	}
+}
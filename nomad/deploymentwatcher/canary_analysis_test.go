@@ -0,0 +1,278 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package deploymentwatcher
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+	mocker "github.com/stretchr/testify/mock"
+)
+
+// staticProvider is a canary analysis provider that returns a fixed value
+// and records the queries it was sent.
+type staticProvider struct {
+	value   float64
+	queries chan string
+}
+
+func (p *staticProvider) Query(_ context.Context, _, query string) (float64, error) {
+	select {
+	case p.queries <- query:
+	default:
+	}
+	return p.value, nil
+}
+
+func TestPrometheusProvider_Query(t *testing.T) {
+	ci.Parallel(t)
+
+	responses := map[string]string{
+		"scalar":   `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"0.25"]}}`,
+		"vector":   `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"12.5"]}]}}`,
+		"empty":    `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		"multiple": `{"status":"success","data":{"resultType":"vector","result":[{"value":[1,"1"]},{"value":[1,"2"]}]}}`,
+		"error":    `{"status":"error","error":"parse error"}`,
+		"nan":      `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"NaN"]}}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		must.Eq(t, "/api/v1/query", r.URL.Path)
+		fmt.Fprint(rw, responses[r.URL.Query().Get("query")])
+	}))
+	t.Cleanup(srv.Close)
+
+	p := defaultCanaryAnalysisProviders()[structs.CanaryAnalysisProviderPrometheus]
+
+	value, err := p.Query(context.Background(), srv.URL, "scalar")
+	must.NoError(t, err)
+	must.Eq(t, 0.25, value)
+
+	value, err = p.Query(context.Background(), srv.URL+"/", "vector")
+	must.NoError(t, err)
+	must.Eq(t, 12.5, value)
+
+	_, err = p.Query(context.Background(), srv.URL, "empty")
+	must.ErrorContains(t, err, "returned 0 series")
+
+	_, err = p.Query(context.Background(), srv.URL, "multiple")
+	must.ErrorContains(t, err, "returned 2 series")
+
+	_, err = p.Query(context.Background(), srv.URL, "error")
+	must.ErrorContains(t, err, "parse error")
+
+	value, err = p.Query(context.Background(), srv.URL, "nan")
+	must.NoError(t, err)
+	must.True(t, math.IsNaN(value))
+}
+
+func TestWatcher_RunCanaryAnalysis_NotANumber(t *testing.T) {
+	ci.Parallel(t)
+
+	analysis := &structs.CanaryAnalysis{
+		Provider: structs.CanaryAnalysisProviderPrometheus,
+		Metrics: []*structs.CanaryAnalysisMetric{{
+			Name:  "error_rate",
+			Query: "errors",
+			Max:   pointer.Of(0.1),
+		}},
+	}
+	d := mock.Deployment()
+
+	testCases := []struct {
+		name      string
+		value     float64
+		expFailed bool
+		expErr    string
+	}{
+		{
+			name:   "NaN is a query error",
+			value:  math.NaN(),
+			expErr: `metric "error_rate": query returned NaN`,
+		},
+		{
+			name:      "+Inf is above maximum",
+			value:     math.Inf(1),
+			expFailed: true,
+			expErr:    "value +Inf is above maximum 0.1",
+		},
+		{
+			name:  "-Inf is within bounds",
+			value: math.Inf(-1),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &deploymentWatcher{
+				ctx: context.Background(),
+				analysisProviders: map[string]CanaryAnalysisProvider{
+					structs.CanaryAnalysisProviderPrometheus: &staticProvider{value: tc.value},
+				},
+			}
+
+			metric, err := w.runCanaryAnalysis(d, "web", analysis)
+			if tc.expFailed {
+				must.NotNil(t, metric)
+			} else {
+				must.Nil(t, metric)
+			}
+			if tc.expErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.EqError(t, err, tc.expErr)
+			}
+		})
+	}
+}
+
+// testCanaryAnalysisDeployment upserts a job whose canaries are analyzed and
+// a deployment with healthy canaries for it.
+func testCanaryAnalysisDeployment(t *testing.T, m *mockBackend) (*structs.Job, *structs.Deployment) {
+	upd := structs.DefaultUpdateStrategy.Copy()
+	upd.Canary = 2
+	upd.MaxParallel = 2
+	upd.ProgressDeadline = 0
+	upd.CanaryAnalysis = &structs.CanaryAnalysis{
+		Provider: structs.CanaryAnalysisProviderPrometheus,
+		Address:  "http://127.0.0.1:9090",
+		Interval: 50 * time.Millisecond,
+		Runs:     2,
+		Metrics: []*structs.CanaryAnalysisMetric{{
+			Name:  "error_rate",
+			Query: `job:errors:rate{job="${NOMAD_JOB_ID}",group="${NOMAD_GROUP_NAME}"}`,
+			Max:   pointer.Of(0.1),
+		}},
+	}
+
+	j := mock.Job()
+	j.TaskGroups[0].Update = upd
+	j.TaskGroups[0].Count = 2
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups["web"].DesiredCanaries = 2
+	d.TaskGroups["web"].DesiredTotal = 2
+
+	var allocs []*structs.Allocation
+	for range 2 {
+		a := mock.Alloc()
+		a.JobID = j.ID
+		a.Job = j
+		a.DeploymentID = d.ID
+		a.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Canary:  true,
+			Healthy: pointer.Of(true),
+		}
+		allocs = append(allocs, a)
+		d.TaskGroups["web"].PlacedCanaries = append(d.TaskGroups["web"].PlacedCanaries, a.ID)
+	}
+	d.TaskGroups["web"].PlacedAllocs = 2
+	d.TaskGroups["web"].HealthyAllocs = 2
+
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+	must.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), allocs))
+	return j, d
+}
+
+func TestWatcher_CanaryAnalysis_Promote(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	provider := &staticProvider{value: 0.01, queries: make(chan string, 1)}
+	w.analysisProviders = map[string]CanaryAnalysisProvider{
+		structs.CanaryAnalysisProviderPrometheus: provider,
+	}
+
+	j, d := testCanaryAnalysisDeployment(t, m)
+
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(func(*structs.DeploymentStatusUpdateRequest) bool {
+		return true
+	})).Return(nil).Maybe()
+	m.On("UpdateDeploymentPromotion", mocker.MatchedBy(matchDeploymentPromoteRequest(&matchDeploymentPromoteRequestConfig{
+		Promotion: &structs.DeploymentPromoteRequest{
+			DeploymentID: d.ID,
+			All:          true,
+		},
+		Eval: true,
+	}))).Return(nil)
+
+	w.SetEnabled(true, m.state)
+
+	// Placeholders are replaced before the query is sent
+	select {
+	case query := <-provider.queries:
+		must.Eq(t, fmt.Sprintf(`job:errors:rate{job="%s",group="web"}`, j.ID), query)
+	case <-time.After(5 * time.Second):
+		t.Fatal("canary analysis did not query the provider")
+	}
+
+	// The canaries are promoted without auto_promote once they pass analysis
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			out, err := m.state.DeploymentByID(nil, d.ID)
+			if err != nil {
+				return err
+			}
+			if !out.TaskGroups["web"].Promoted {
+				return fmt.Errorf("deployment not promoted: %q", out.StatusDescription)
+			}
+			if out.StatusDescription != structs.DeploymentStatusDescriptionCanaryAnalysisPassed {
+				return fmt.Errorf("unexpected status description: %q", out.StatusDescription)
+			}
+			return nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(20*time.Millisecond),
+	))
+}
+
+func TestWatcher_CanaryAnalysis_Fail(t *testing.T) {
+	ci.Parallel(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	w.analysisProviders = map[string]CanaryAnalysisProvider{
+		structs.CanaryAnalysisProviderPrometheus: &staticProvider{value: 0.5},
+	}
+
+	_, d := testCanaryAnalysisDeployment(t, m)
+
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(func(*structs.DeploymentStatusUpdateRequest) bool {
+		return true
+	})).Return(nil).Maybe()
+
+	w.SetEnabled(true, m.state)
+
+	expected := structs.DeploymentStatusDescriptionFailedCanaryAnalysis(
+		"web", "error_rate", fmt.Errorf("value 0.5 is above maximum 0.1"))
+	must.Wait(t, wait.InitialSuccess(
+		wait.ErrorFunc(func() error {
+			out, err := m.state.DeploymentByID(nil, d.ID)
+			if err != nil {
+				return err
+			}
+			if out.Status != structs.DeploymentStatusFailed {
+				return fmt.Errorf("deployment not failed: %q", out.StatusDescription)
+			}
+			if out.StatusDescription != expected {
+				return fmt.Errorf("unexpected status description: %q", out.StatusDescription)
+			}
+			return nil
+		}),
+		wait.Timeout(5*time.Second),
+		wait.Gap(20*time.Millisecond),
+	))
+	m.AssertNotCalled(t, "UpdateDeploymentPromotion", mocker.Anything)
+}
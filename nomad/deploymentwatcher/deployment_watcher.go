@@ -66,13 +66,14 @@ type deploymentWatcher struct {
 	deploymentTriggers
 
 	// DeploymentRPC holds methods for interacting with peer regions
-	// in enterprise edition
 	DeploymentRPC
 
 	// JobRPC holds methods for interacting with peer regions
-	// in enterprise edition
 	JobRPC
 
+	// peerToken authenticates RPCs sent to peer regions
+	peerToken string
+
 	// state is the state that is watched for state changes.
 	state *state.StateStore
 
@@ -101,6 +102,21 @@ type deploymentWatcher struct {
 	// by holding the lock or using the setter and getter methods.
 	latestEval uint64
 
+	// multiregionStatus is the last deployment status nextRegion acted on
+	// for multiregion deployments. Access should be done through the lock.
+	multiregionStatus string
+
+	// analysisProviders are the metrics providers canary analysis can query
+	analysisProviders map[string]CanaryAnalysisProvider
+
+	// analyses are the canary analyses of the deployment's task groups, keyed
+	// by group name. It is set on creation and never modified.
+	analyses map[string]*structs.CanaryAnalysis
+
+	// analysisPassed marks the task groups whose canaries passed analysis.
+	// Access should be done through the lock.
+	analysisPassed map[string]bool
+
 	logger log.Logger
 	ctx    context.Context
 	exitFn context.CancelFunc
@@ -112,7 +128,8 @@ type deploymentWatcher struct {
 func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 	logger log.Logger, state *state.StateStore, d *structs.Deployment,
 	j *structs.Job, triggers deploymentTriggers,
-	deploymentRPC DeploymentRPC, jobRPC JobRPC) *deploymentWatcher {
+	deploymentRPC DeploymentRPC, jobRPC JobRPC, peerToken string,
+	analysisProviders map[string]CanaryAnalysisProvider) *deploymentWatcher {
 
 	ctx, exitFn := context.WithCancel(parent)
 	w := &deploymentWatcher{
@@ -125,14 +142,23 @@ func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 		deploymentTriggers: triggers,
 		DeploymentRPC:      deploymentRPC,
 		JobRPC:             jobRPC,
+		peerToken:          peerToken,
+		analysisProviders:  analysisProviders,
+		analysisPassed:     make(map[string]bool),
 		logger:             logger.With("deployment_id", d.ID, "job", j.NamespacedID()),
 		ctx:                ctx,
 		exitFn:             exitFn,
 	}
+	w.analyses = w.canaryAnalyses()
 
 	// Start the long lived watcher that scans for allocation updates
 	go w.watch()
 
+	// Analyze canaries against the metrics providers, if configured
+	if len(w.analyses) > 0 {
+		go w.watchCanaryAnalysis()
+	}
+
 	return w
 }
 
@@ -288,7 +314,8 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 
 	// AutoPromote iff every task group with canaries is marked auto_promote and is healthy. The whole
 	// job version has been incremented, so we promote together. See also AutoRevert
-	for _, dstate := range d.TaskGroups {
+	analyzed := false
+	for group, dstate := range d.TaskGroups {
 
 		// skip auto promote canary validation if the task group has no canaries
 		// to prevent auto promote hanging on mixed canary/non-canary taskgroup deploys
@@ -296,7 +323,15 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 			continue
 		}
 
-		if !dstate.AutoPromote || len(dstate.PlacedCanaries) < dstate.DesiredCanaries {
+		// groups with canary analysis are promoted once their canaries pass
+		// it, regardless of auto_promote
+		hasAnalysis, passed := w.canaryAnalysisPassed(group)
+		if hasAnalysis && !passed {
+			return nil
+		}
+		analyzed = analyzed || hasAnalysis
+
+		if (!hasAnalysis && !dstate.AutoPromote) || len(dstate.PlacedCanaries) < dstate.DesiredCanaries {
 			return nil
 		}
 
@@ -315,10 +350,14 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 	}
 
 	// Send the request
-	_, err := w.upsertDeploymentPromotion(&structs.ApplyDeploymentPromoteRequest{
+	req := &structs.ApplyDeploymentPromoteRequest{
 		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{DeploymentID: d.GetID(), All: true},
 		Eval:                     w.getEval(),
-	})
+	}
+	if analyzed {
+		req.StatusDescription = structs.DeploymentStatusDescriptionCanaryAnalysisPassed
+	}
+	_, err := w.upsertDeploymentPromotion(req)
 	return err
 }
 
@@ -357,7 +396,26 @@ func (w *deploymentWatcher) FailDeployment(
 	req *structs.DeploymentFailRequest,
 	resp *structs.DeploymentUpdateResponse) error {
 
-	status, desc := structs.DeploymentStatusFailed, structs.DeploymentStatusDescriptionFailedByUser
+	i, eval, rollbackJob, err := w.failDeployment(structs.DeploymentStatusDescriptionFailedByUser)
+	if err != nil {
+		return err
+	}
+
+	// Build the response
+	resp.EvalID = eval.ID
+	resp.EvalCreateIndex = i
+	resp.DeploymentModifyIndex = i
+	resp.Index = i
+	if rollbackJob != nil {
+		resp.RevertedJobVersion = pointer.Of(rollbackJob.Version)
+	}
+	return nil
+}
+
+// failDeployment fails the deployment with the given description, rolling
+// back to the latest stable job if any task group is marked auto_revert.
+func (w *deploymentWatcher) failDeployment(desc string) (uint64, *structs.Evaluation, *structs.Job, error) {
+	status := structs.DeploymentStatusFailed
 
 	// Determine if we should rollback
 	rollback := false
@@ -373,7 +431,7 @@ func (w *deploymentWatcher) FailDeployment(
 		var err error
 		rollbackJob, err = w.latestStableJob()
 		if err != nil {
-			return err
+			return 0, nil, nil, err
 		}
 
 		if rollbackJob != nil {
@@ -388,18 +446,9 @@ func (w *deploymentWatcher) FailDeployment(
 	eval := w.getEval()
 	i, err := w.upsertDeploymentStatusUpdate(update, eval, rollbackJob)
 	if err != nil {
-		return err
+		return 0, nil, nil, err
 	}
-
-	// Build the response
-	resp.EvalID = eval.ID
-	resp.EvalCreateIndex = i
-	resp.DeploymentModifyIndex = i
-	resp.Index = i
-	if rollbackJob != nil {
-		resp.RevertedJobVersion = pointer.Of(rollbackJob.Version)
-	}
-	return nil
+	return i, eval, rollbackJob, nil
 }
 
 // StopWatch stops watching the deployment. This should be called whenever a
@@ -84,9 +84,17 @@ type Watcher struct {
 	// server interface for Job RPCs
 	jobRPC JobRPC
 
+	// peerToken authenticates RPCs sent to peer regions on behalf of
+	// multiregion deployments
+	peerToken string
+
 	// watchers is the set of active watchers, one per deployment
 	watchers map[string]*deploymentWatcher
 
+	// analysisProviders are the metrics providers canary analysis can query,
+	// keyed by provider name
+	analysisProviders map[string]CanaryAnalysisProvider
+
 	// allocUpdateBatcher is used to batch the creation of evaluations and
 	// allocation desired transition updates
 	allocUpdateBatcher *AllocUpdateBatcher
@@ -103,6 +111,7 @@ type Watcher struct {
 func NewDeploymentsWatcher(logger log.Logger,
 	raft DeploymentRaftEndpoints,
 	deploymentRPC DeploymentRPC, jobRPC JobRPC,
+	peerToken string,
 	stateQueriesPerSecond float64,
 	updateBatchDuration time.Duration,
 ) *Watcher {
@@ -111,8 +120,10 @@ func NewDeploymentsWatcher(logger log.Logger,
 		raft:                raft,
 		deploymentRPC:       deploymentRPC,
 		jobRPC:              jobRPC,
+		peerToken:           peerToken,
 		queryLimiter:        rate.NewLimiter(rate.Limit(stateQueriesPerSecond), 100),
 		updateBatchDuration: updateBatchDuration,
+		analysisProviders:   defaultCanaryAnalysisProviders(),
 		logger:              logger.Named("deployments_watcher"),
 	}
 }
@@ -301,7 +312,7 @@ func (w *Watcher) addLocked(d *structs.Deployment) (*deploymentWatcher, error) {
 	}
 
 	watcher := newDeploymentWatcher(w.ctx, w.queryLimiter, w.logger, w.state, d, job,
-		w, w.deploymentRPC, w.jobRPC)
+		w, w.deploymentRPC, w.jobRPC, w.peerToken, w.analysisProviders)
 	w.watchers[d.ID] = watcher
 	return watcher, nil
 }
@@ -6,32 +6,361 @@
 
 package deploymentwatcher
 
-import "github.com/hashicorp/nomad/nomad/structs"
+import (
+	"errors"
+	"fmt"
+	"strings"
 
-// DeploymentRPC and JobRPC hold methods for interacting with peer regions
-// in enterprise edition.
-type DeploymentRPC interface{}
-type JobRPC interface{}
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
 
+// DeploymentRPC holds the deployment methods used to coordinate a multiregion
+// deployment with its peer regions.
+type DeploymentRPC interface {
+	Run(*structs.DeploymentRunRequest, *structs.DeploymentUpdateResponse) error
+	Unblock(*structs.DeploymentUnblockRequest, *structs.DeploymentUpdateResponse) error
+	Cancel(*structs.DeploymentCancelRequest, *structs.DeploymentUpdateResponse) error
+}
+
+// JobRPC holds the job methods used to find the deployments of a multiregion
+// job in its peer regions.
+type JobRPC interface {
+	LatestDeployment(*structs.JobSpecificRequest, *structs.SingleDeploymentResponse) error
+}
+
+// regionDeployment is the latest deployment of a multiregion job in one of
+// its regions. The deployment is nil if the region has not yet registered the
+// job.
+type regionDeployment struct {
+	region string
+	d      *structs.Deployment
+}
+
+// status returns the status of the region's deployment, or the empty string
+// if the region has no deployment.
+func (r *regionDeployment) status() string {
+	if r.d == nil {
+		return ""
+	}
+	return r.d.Status
+}
+
+// settled returns whether the region has finished its local rollout and no
+// longer occupies one of the max_parallel slots.
+func (r *regionDeployment) settled() bool {
+	switch r.status() {
+	case structs.DeploymentStatusBlocked, structs.DeploymentStatusUnblocking,
+		structs.DeploymentStatusSuccessful, structs.DeploymentStatusCancelled,
+		structs.DeploymentStatusFailed:
+		return true
+	}
+	return false
+}
+
+// nextRegion is called by the watch loop whenever the deployment status may
+// have changed. For multiregion deployments it drives the rollout of the peer
+// regions: pending regions are started up to the strategy's max_parallel,
+// failures are propagated according to on_failure, and once every region has
+// completed its local rollout all regions are unblocked. Errors talking to
+// peer regions are logged rather than returned so that an unreachable region
+// does not fail the local deployment.
 func (w *deploymentWatcher) nextRegion(status string) error {
+	if !w.j.IsMultiregion() || w.DeploymentRPC == nil || w.JobRPC == nil {
+		return nil
+	}
+
+	// Only act on transitions. The watch loop calls us for every deployment
+	// update, but peers only need to be contacted when our status changes.
+	w.l.Lock()
+	if status == w.multiregionStatus {
+		w.l.Unlock()
+		return nil
+	}
+	w.multiregionStatus = status
+	w.l.Unlock()
+
+	switch status {
+	case structs.DeploymentStatusPending, structs.DeploymentStatusBlocked:
+		return w.advanceRegions()
+	case structs.DeploymentStatusFailed:
+		return w.failRegions()
+	}
 	return nil
 }
 
+// advanceRegions starts as many pending regions as the max_parallel strategy
+// allows and unblocks every region once all of them have completed.
+func (w *deploymentWatcher) advanceRegions() error {
+	regions := w.regionDeployments()
+
+	var active, failed int
+	for _, r := range regions {
+		switch r.status() {
+		case "", structs.DeploymentStatusInitializing:
+			// Wait until every region has accepted the job before starting
+			// any of them.
+			return nil
+		case structs.DeploymentStatusRunning, structs.DeploymentStatusPaused:
+			active++
+		case structs.DeploymentStatusFailed:
+			failed++
+		}
+	}
+
+	maxParallel := 0
+	if strategy := w.j.Multiregion.Strategy; strategy != nil {
+		maxParallel = strategy.MaxParallel
+	}
+
+	pending := false
+	for _, r := range regions {
+		if r.status() != structs.DeploymentStatusPending {
+			continue
+		}
+		pending = true
+		if maxParallel > 0 && active >= maxParallel {
+			break
+		}
+		if err := w.runRegion(r); err != nil {
+			w.logger.Error("failed to run multiregion deployment", "region", r.region, "error", err)
+			continue
+		}
+		active++
+	}
+
+	// Regions that failed locally under fail_local have to be unblocked by
+	// an operator once the failure has been resolved.
+	if pending || active > 0 || failed > 0 {
+		return nil
+	}
+
+	for _, r := range regions {
+		if r.status() != structs.DeploymentStatusBlocked {
+			continue
+		}
+		if err := w.unblockRegion(r); err != nil {
+			w.logger.Error("failed to unblock multiregion deployment", "region", r.region, "error", err)
+		}
+	}
+	return nil
+}
+
+// failRegions propagates the failure of the local deployment to its peers
+// according to the multiregion on_failure strategy.
+func (w *deploymentWatcher) failRegions() error {
+	// A failure that was caused by a peer region has already been propagated
+	// by that peer.
+	if strings.HasPrefix(w.getDeployment().StatusDescription, structs.DeploymentStatusDescriptionFailedByPeer) {
+		return nil
+	}
+
+	onFailure := ""
+	if strategy := w.j.Multiregion.Strategy; strategy != nil {
+		onFailure = strategy.OnFailure
+	}
+
+	regions := w.regionDeployments()
+	switch onFailure {
+	case structs.MultiregionOnFailureFailLocal:
+		// Only this region fails, so let the remaining regions continue.
+		return w.advanceRegions()
+
+	case structs.MultiregionOnFailureFailAll:
+		for _, r := range regions {
+			w.cancelRegion(r)
+		}
+
+	default:
+		// Fail this region and every region that comes after it.
+		after := false
+		for _, r := range regions {
+			if r.region == w.j.Region {
+				after = true
+				continue
+			}
+			if after {
+				w.cancelRegion(r)
+			}
+		}
+	}
+	return nil
+}
+
+// regionDeployments returns the latest deployment of the job in each of its
+// regions, in the order the regions are declared in the job.
+func (w *deploymentWatcher) regionDeployments() []*regionDeployment {
+	regions := make([]*regionDeployment, 0, len(w.j.Multiregion.Regions))
+	for _, region := range w.j.Multiregion.Regions {
+		r := &regionDeployment{region: region.Name}
+		regions = append(regions, r)
+
+		if region.Name == w.j.Region {
+			r.d = w.getDeployment()
+			continue
+		}
+
+		args := &structs.JobSpecificRequest{
+			JobID: w.j.ID,
+			QueryOptions: structs.QueryOptions{
+				Region:    region.Name,
+				Namespace: w.j.Namespace,
+				AuthToken: w.peerToken,
+			},
+		}
+		var resp structs.SingleDeploymentResponse
+		if err := w.JobRPC.LatestDeployment(args, &resp); err != nil {
+			w.logger.Warn("failed to fetch multiregion deployment", "region", region.Name, "error", err)
+			continue
+		}
+		r.d = resp.Deployment
+	}
+	return regions
+}
+
+// runRegion starts the pending deployment of a region.
+func (w *deploymentWatcher) runRegion(r *regionDeployment) error {
+	var resp structs.DeploymentUpdateResponse
+	if r.region == w.j.Region {
+		return w.RunDeployment(&structs.DeploymentRunRequest{DeploymentID: r.d.ID}, &resp)
+	}
+	return w.DeploymentRPC.Run(&structs.DeploymentRunRequest{
+		DeploymentID: r.d.ID,
+		WriteRequest: w.multiregionWriteRequest(r.region),
+	}, &resp)
+}
+
+// unblockRegion marks the blocked deployment of a region as successful.
+func (w *deploymentWatcher) unblockRegion(r *regionDeployment) error {
+	var resp structs.DeploymentUpdateResponse
+	if r.region == w.j.Region {
+		return w.UnblockDeployment(&structs.DeploymentUnblockRequest{DeploymentID: r.d.ID}, &resp)
+	}
+	return w.DeploymentRPC.Unblock(&structs.DeploymentUnblockRequest{
+		DeploymentID: r.d.ID,
+		WriteRequest: w.multiregionWriteRequest(r.region),
+	}, &resp)
+}
+
+// cancelRegion fails the active deployment of a peer region because of a
+// failure in this region.
+func (w *deploymentWatcher) cancelRegion(r *regionDeployment) {
+	if r.region == w.j.Region || r.d == nil || !r.d.Active() {
+		return
+	}
+	var resp structs.DeploymentUpdateResponse
+	err := w.DeploymentRPC.Cancel(&structs.DeploymentCancelRequest{
+		DeploymentID: r.d.ID,
+		WriteRequest: w.multiregionWriteRequest(r.region),
+	}, &resp)
+	if err != nil {
+		w.logger.Error("failed to fail multiregion deployment", "region", r.region, "error", err)
+	}
+}
+
+// multiregionWriteRequest returns the write request used for RPCs to a peer
+// region.
+func (w *deploymentWatcher) multiregionWriteRequest(region string) structs.WriteRequest {
+	return structs.WriteRequest{
+		Region:    region,
+		Namespace: w.j.Namespace,
+		AuthToken: w.peerToken,
+	}
+}
+
 // RunDeployment is used to run a pending multiregion deployment.  In
 // single-region deployments, the pending state is unused.
 func (w *deploymentWatcher) RunDeployment(req *structs.DeploymentRunRequest, resp *structs.DeploymentUpdateResponse) error {
+	switch w.getStatus() {
+	case structs.DeploymentStatusPending:
+	case structs.DeploymentStatusInitializing:
+		// The scheduler has not yet computed the deployment. The region will
+		// look for a free slot itself once it becomes pending.
+		return nil
+	default:
+		return fmt.Errorf("deployment %q is not pending", w.deploymentID)
+	}
+
+	desc := structs.DeploymentStatusDescriptionRunning
+	if d := w.getDeployment(); d.RequiresPromotion() {
+		desc = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+		if d.HasAutoPromote() {
+			desc = structs.DeploymentStatusDescriptionRunningAutoPromotion
+		}
+	}
+
+	eval := w.getEval()
+	update := w.getDeploymentStatusUpdate(structs.DeploymentStatusRunning, desc)
+	i, err := w.upsertDeploymentStatusUpdate(update, eval, nil)
+	if err != nil {
+		return err
+	}
+
+	resp.EvalID = eval.ID
+	resp.EvalCreateIndex = i
+	resp.DeploymentModifyIndex = i
+	resp.Index = i
 	return nil
 }
 
 // UnblockDeployment is used to unblock a multiregion deployment.  In
 // single-region deployments, the blocked state is unused.
 func (w *deploymentWatcher) UnblockDeployment(req *structs.DeploymentUnblockRequest, resp *structs.DeploymentUpdateResponse) error {
+	if w.getStatus() != structs.DeploymentStatusBlocked {
+		return errors.New("deployment is not blocked")
+	}
+
+	update := w.getDeploymentStatusUpdate(structs.DeploymentStatusSuccessful,
+		structs.DeploymentStatusDescriptionSuccessful)
+	i, err := w.upsertDeploymentStatusUpdate(update, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	resp.DeploymentModifyIndex = i
+	resp.Index = i
 	return nil
 }
 
-// CancelDeployment is used to cancel a multiregion deployment.  In
-// single-region deployments, the deploymentwatcher has sole responsibility to
-// cancel deployments so this RPC is never used.
+// CancelDeployment is used to fail a multiregion deployment because of a
+// failure in a peer region. In single-region deployments, the
+// deploymentwatcher has sole responsibility to fail deployments so this RPC
+// is never used.
 func (w *deploymentWatcher) CancelDeployment(req *structs.DeploymentCancelRequest, resp *structs.DeploymentUpdateResponse) error {
+	desc := structs.DeploymentStatusDescriptionFailedByPeer
+
+	// Peers follow the auto_revert setting of their own update block.
+	var rollbackJob *structs.Job
+	for _, dstate := range w.getDeployment().TaskGroups {
+		if !dstate.AutoRevert {
+			continue
+		}
+		var err error
+		rollbackJob, err = w.latestStableJob()
+		if err != nil {
+			return err
+		}
+		if rollbackJob != nil {
+			rollbackJob, desc = w.handleRollbackValidity(rollbackJob, desc)
+		} else {
+			desc = structs.DeploymentStatusDescriptionNoRollbackTarget(desc)
+		}
+		break
+	}
+
+	update := w.getDeploymentStatusUpdate(structs.DeploymentStatusFailed, desc)
+	eval := w.getEval()
+	i, err := w.upsertDeploymentStatusUpdate(update, eval, rollbackJob)
+	if err != nil {
+		return err
+	}
+
+	resp.EvalID = eval.ID
+	resp.EvalCreateIndex = i
+	resp.DeploymentModifyIndex = i
+	resp.Index = i
+	if rollbackJob != nil {
+		resp.RevertedJobVersion = pointer.Of(rollbackJob.Version)
+	}
 	return nil
 }
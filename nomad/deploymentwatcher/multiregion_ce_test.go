@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !ent
+// +build !ent
+
+package deploymentwatcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	mocker "github.com/stretchr/testify/mock"
+)
+
+// mockMultiregionRPC stands in for the peer regions of a multiregion
+// deployment. It records the regions each request was sent to and the tokens
+// they were sent with.
+type mockMultiregionRPC struct {
+	l           sync.Mutex
+	deployments map[string]*structs.Deployment
+	lookupErrs  map[string]error
+	runs        []string
+	unblocks    []string
+	cancels     []string
+	tokens      []string
+}
+
+func newMockMultiregionRPC() *mockMultiregionRPC {
+	return &mockMultiregionRPC{
+		deployments: make(map[string]*structs.Deployment),
+		lookupErrs:  make(map[string]error),
+	}
+}
+
+func (m *mockMultiregionRPC) setStatus(region, status string) {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.deployments[region].Status = status
+}
+
+func (m *mockMultiregionRPC) LatestDeployment(args *structs.JobSpecificRequest, reply *structs.SingleDeploymentResponse) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.tokens = append(m.tokens, args.AuthToken)
+	if err := m.lookupErrs[args.Region]; err != nil {
+		return err
+	}
+	if d := m.deployments[args.Region]; d != nil {
+		reply.Deployment = d.Copy()
+	}
+	return nil
+}
+
+func (m *mockMultiregionRPC) Run(args *structs.DeploymentRunRequest, reply *structs.DeploymentUpdateResponse) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.tokens = append(m.tokens, args.AuthToken)
+	m.runs = append(m.runs, args.Region)
+	m.deployments[args.Region].Status = structs.DeploymentStatusRunning
+	return nil
+}
+
+func (m *mockMultiregionRPC) Unblock(args *structs.DeploymentUnblockRequest, reply *structs.DeploymentUpdateResponse) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.tokens = append(m.tokens, args.AuthToken)
+	m.unblocks = append(m.unblocks, args.Region)
+	m.deployments[args.Region].Status = structs.DeploymentStatusSuccessful
+	return nil
+}
+
+func (m *mockMultiregionRPC) Cancel(args *structs.DeploymentCancelRequest, reply *structs.DeploymentUpdateResponse) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.tokens = append(m.tokens, args.AuthToken)
+	m.cancels = append(m.cancels, args.Region)
+	m.deployments[args.Region].Status = structs.DeploymentStatusFailed
+	return nil
+}
+
+// testMultiregionJob returns a multiregion job interpolated for the local
+// region, with the given regions in rollout order.
+func testMultiregionJob(t *testing.T, local, onFailure string, regions ...string) *structs.Job {
+	j := mock.MultiregionJob()
+	j.Multiregion.Strategy.OnFailure = onFailure
+	j.Multiregion.Regions = nil
+	for _, region := range regions {
+		j.Multiregion.Regions = append(j.Multiregion.Regions, &structs.MultiregionRegion{
+			Name:        region,
+			Count:       1,
+			Datacenters: []string{"dc1"},
+		})
+	}
+	must.NoError(t, j.InterpolateRegion(local))
+	return j
+}
+
+// testMultiregionWatcher returns a watcher for the local deployment of a
+// multiregion job, with its peer regions backed by the given RPCs. The
+// watch loop is not started so tests drive the rollout directly.
+func testMultiregionWatcher(t *testing.T, j *structs.Job, status string, rpc *mockMultiregionRPC) (*deploymentWatcher, *mockBackend) {
+	w, m := defaultTestDeploymentWatcher(t)
+	m.On("UpdateDeploymentStatus", mocker.Anything).Return(nil).Maybe()
+
+	d := structs.NewDeployment(j, 50)
+	d.Status = status
+	must.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), nil, j))
+	must.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+
+	for _, region := range j.Multiregion.Regions {
+		if region.Name == j.Region {
+			continue
+		}
+		peer := structs.NewDeployment(j, 50)
+		peer.Status = structs.DeploymentStatusPending
+		rpc.deployments[region.Name] = peer
+	}
+
+	ctx, exitFn := context.WithCancel(context.Background())
+	t.Cleanup(exitFn)
+	return &deploymentWatcher{
+		queryLimiter:       w.queryLimiter,
+		deploymentTriggers: w,
+		DeploymentRPC:      rpc,
+		JobRPC:             rpc,
+		peerToken:          "peer-token",
+		state:              m.state,
+		deploymentID:       d.ID,
+		deploymentUpdateCh: make(chan struct{}, 1),
+		d:                  d,
+		j:                  j,
+		logger:             testlog.HCLogger(t),
+		ctx:                ctx,
+		exitFn:             exitFn,
+	}, m
+}
+
+// Tests that a multiregion rollout is handed from region to region within the
+// max_parallel limit and that every region is unblocked once all of them have
+// completed.
+func TestMultiregion_RolloutHandoff(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := newMockMultiregionRPC()
+	j := testMultiregionJob(t, "west", "", "west", "east", "south")
+	w, m := testMultiregionWatcher(t, j, structs.DeploymentStatusBlocked, rpc)
+
+	// The local region has completed, so only the next region may run.
+	must.NoError(t, w.nextRegion(structs.DeploymentStatusBlocked))
+	must.Eq(t, []string{"east"}, rpc.runs)
+
+	// Nothing else runs while the region is still rolling out.
+	must.NoError(t, w.advanceRegions())
+	must.Eq(t, []string{"east"}, rpc.runs)
+
+	rpc.setStatus("east", structs.DeploymentStatusBlocked)
+	must.NoError(t, w.advanceRegions())
+	must.Eq(t, []string{"east", "south"}, rpc.runs)
+	must.SliceEmpty(t, rpc.unblocks)
+
+	// Once every region is blocked they are all unblocked.
+	rpc.setStatus("south", structs.DeploymentStatusBlocked)
+	must.NoError(t, w.advanceRegions())
+	must.Eq(t, []string{"east", "south"}, rpc.unblocks)
+
+	d, err := m.state.DeploymentByID(nil, w.deploymentID)
+	must.NoError(t, err)
+	must.Eq(t, structs.DeploymentStatusSuccessful, d.Status)
+
+	// Peers are contacted with the server's token, never the submitter's.
+	for _, token := range rpc.tokens {
+		must.Eq(t, "peer-token", token)
+	}
+}
+
+// Tests that no region is started while a peer region can't be reached.
+func TestMultiregion_PeerLookupFailure(t *testing.T) {
+	ci.Parallel(t)
+
+	rpc := newMockMultiregionRPC()
+	j := testMultiregionJob(t, "west", "", "west", "east", "south")
+	w, _ := testMultiregionWatcher(t, j, structs.DeploymentStatusBlocked, rpc)
+	rpc.lookupErrs["east"] = errors.New("no path to region")
+
+	must.NoError(t, w.nextRegion(structs.DeploymentStatusBlocked))
+	must.SliceEmpty(t, rpc.runs)
+
+	// The rollout continues once the region is reachable again.
+	delete(rpc.lookupErrs, "east")
+	must.NoError(t, w.advanceRegions())
+	must.Eq(t, []string{"east"}, rpc.runs)
+}
+
+// Tests that a local failure is propagated to the peer regions according to
+// the on_failure strategy.
+func TestMultiregion_FailurePropagation(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name      string
+		onFailure string
+		expect    []string
+	}{
+		{
+			name:   "default fails later regions",
+			expect: []string{"south"},
+		},
+		{
+			name:      "fail_all",
+			onFailure: structs.MultiregionOnFailureFailAll,
+			expect:    []string{"east", "south"},
+		},
+		{
+			name:      "fail_local",
+			onFailure: structs.MultiregionOnFailureFailLocal,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rpc := newMockMultiregionRPC()
+			j := testMultiregionJob(t, "west", tc.onFailure, "east", "west", "south")
+			w, _ := testMultiregionWatcher(t, j, structs.DeploymentStatusFailed, rpc)
+			rpc.setStatus("east", structs.DeploymentStatusRunning)
+
+			must.NoError(t, w.nextRegion(structs.DeploymentStatusFailed))
+			must.Eq(t, tc.expect, rpc.cancels)
+			for _, token := range rpc.tokens {
+				must.Eq(t, "peer-token", token)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -74,8 +75,19 @@ type EvalBroker struct {
 	// now safe for the Eval.Ack RPC to cancel in batches
 	cancelable []*structs.Evaluation
 
-	// ready tracks the ready jobs by scheduler in a priority queue
-	ready map[string]ReadyEvaluations
+	// ready tracks the ready jobs by scheduler and namespace in a priority
+	// queue
+	ready map[string]*NamespacedReadyEvaluations
+
+	// fairShare is the configuration for fair-share dequeueing across
+	// namespaces. If nil or disabled, evaluations are dequeued strictly by
+	// priority.
+	fairShare *structs.EvalBrokerFairShare
+
+	// fairShareCurrent tracks the current weight of each namespace with
+	// ready evaluations for the smooth weighted round-robin used when
+	// fair-share dequeueing is enabled.
+	fairShareCurrent map[string]int
 
 	// unack is a map of evalID to an un-acknowledged evaluation
 	unack map[string]*unackEval
@@ -157,7 +169,8 @@ func NewEvalBroker(ctx context.Context, timeout, initialNackDelay, subsequentNac
 		jobEvals:             make(map[structs.NamespacedID]string),
 		pending:              make(map[structs.NamespacedID]PendingEvaluations),
 		cancelable:           make([]*structs.Evaluation, 0, structs.MaxUUIDsPerWriteRequest),
-		ready:                make(map[string]ReadyEvaluations),
+		ready:                make(map[string]*NamespacedReadyEvaluations),
+		fairShareCurrent:     make(map[string]int),
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
@@ -170,6 +183,7 @@ func NewEvalBroker(ctx context.Context, timeout, initialNackDelay, subsequentNac
 		delayedEvalsUpdateCh: make(chan struct{}, 1),
 	}
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 
 	return b, nil
@@ -210,6 +224,23 @@ func (b *EvalBroker) SetEnabled(enabled bool) {
 	b.enabledNotifier.Notify("eval broker enabled status changed to " + strconv.FormatBool(enabled))
 }
 
+// SetFairShare sets the configuration for fair-share dequeueing of
+// evaluations across namespaces. A nil configuration disables fair-share
+// dequeueing.
+func (b *EvalBroker) SetFairShare(fairShare *structs.EvalBrokerFairShare) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.fairShare = fairShare.Copy()
+	b.fairShareCurrent = make(map[string]int)
+}
+
+// fairShareEnabled returns whether fair-share dequeueing is enabled. This
+// assumes the lock is held.
+func (b *EvalBroker) fairShareEnabled() bool {
+	return b.fairShare != nil && b.fairShare.Enabled
+}
+
 // Enqueue is used to enqueue a new evaluation
 func (b *EvalBroker) Enqueue(eval *structs.Evaluation) {
 	b.l.Lock()
@@ -345,21 +376,22 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string, track
 		heap.Push(&pending, eval)
 		b.pending[namespacedID] = pending
 		b.stats.TotalPending += 1
+		b.namespaceStats(eval.Namespace).Pending += 1
 		return
 	}
 
 	// Find the next ready eval by scheduler class
-	readyQueue, ok := b.ready[sched]
+	readyQueues, ok := b.ready[sched]
 	if !ok {
-		readyQueue = make([]*structs.Evaluation, 0, 16)
+		readyQueues = newNamespacedReadyEvaluations()
+		b.ready[sched] = readyQueues
 		if _, ok := b.waiting[sched]; !ok {
 			b.waiting[sched] = make(chan struct{}, 1)
 		}
 	}
 
 	// Push onto the heap
-	heap.Push(&readyQueue, eval)
-	b.ready[sched] = readyQueue
+	readyQueues.Push(eval)
 
 	// Update the stats
 	b.stats.TotalReady += 1
@@ -369,6 +401,7 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, sched string, track
 		b.stats.ByScheduler[sched] = bySched
 	}
 	bySched.Ready += 1
+	b.namespaceStats(eval.Namespace).Ready += 1
 
 	// Unblock any pending dequeues
 	select {
@@ -431,7 +464,9 @@ SCAN:
 }
 
 // scanForSchedulers scans for work on any of the schedulers. The highest priority work
-// is dequeued first. This may return nothing if there is no work waiting.
+// is dequeued first. This may return nothing if there is no work waiting. If
+// fair-share dequeueing is enabled, the namespace to dequeue from is picked
+// first and the highest priority work within that namespace is dequeued.
 func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation, string, error) {
 	b.l.Lock()
 	defer b.l.Unlock()
@@ -441,18 +476,28 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 		return nil, "", fmt.Errorf("eval broker disabled")
 	}
 
+	// Pick the namespace to dequeue from. An empty namespace considers work
+	// from all namespaces.
+	var namespace string
+	if b.fairShareEnabled() {
+		namespace = b.nextFairShareNamespace(schedulers)
+		if namespace == "" {
+			return nil, "", nil
+		}
+	}
+
 	// Scan for eligible work
 	var eligibleSched []string
 	var eligiblePriority int
 	for _, sched := range schedulers {
 		// Get the ready queue for this scheduler
-		readyQueue, ok := b.ready[sched]
+		readyQueues, ok := b.ready[sched]
 		if !ok {
 			continue
 		}
 
 		// Peek at the next item
-		ready := readyQueue.Peek()
+		ready := readyQueues.Peek(namespace)
 		if ready == nil {
 			continue
 		}
@@ -478,23 +523,68 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 
 	case 1:
 		// Only a single task, dequeue
-		return b.dequeueForSched(eligibleSched[0])
+		return b.dequeueForSched(eligibleSched[0], namespace)
 
 	default:
 		// Multiple tasks. We pick a random task so that we fairly
 		// distribute work.
 		offset := rand.Intn(n)
-		return b.dequeueForSched(eligibleSched[offset])
+		return b.dequeueForSched(eligibleSched[offset], namespace)
 	}
 }
 
-// dequeueForSched is used to dequeue the next work item for a given scheduler.
-// This assumes locks are held and that this scheduler has work
-func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string, error) {
-	readyQueue := b.ready[sched]
-	raw := heap.Pop(&readyQueue)
-	b.ready[sched] = readyQueue
-	eval := raw.(*structs.Evaluation)
+// nextFairShareNamespace picks the namespace to dequeue from next using a
+// smooth weighted round-robin across the namespaces that have work ready for
+// any of the schedulers. This returns an empty string if there is no work
+// waiting. This assumes locks are held.
+func (b *EvalBroker) nextFairShareNamespace(schedulers []string) string {
+	candidates := make(map[string]struct{})
+	for _, sched := range schedulers {
+		if readyQueues, ok := b.ready[sched]; ok {
+			for namespace := range readyQueues.byNamespace {
+				candidates[namespace] = struct{}{}
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	// Namespaces without ready work don't accrue weight, so a namespace
+	// can't build up credit while it is idle and then starve the others.
+	for namespace := range b.fairShareCurrent {
+		if _, ok := candidates[namespace]; !ok {
+			delete(b.fairShareCurrent, namespace)
+		}
+	}
+
+	// Sort the namespaces so that ties are broken consistently
+	namespaces := make([]string, 0, len(candidates))
+	for namespace := range candidates {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	var next string
+	var total int
+	for _, namespace := range namespaces {
+		weight := b.fairShare.Weight(namespace)
+		total += weight
+		b.fairShareCurrent[namespace] += weight
+		if next == "" || b.fairShareCurrent[namespace] > b.fairShareCurrent[next] {
+			next = namespace
+		}
+	}
+	b.fairShareCurrent[next] -= total
+
+	return next
+}
+
+// dequeueForSched is used to dequeue the next work item for a given scheduler
+// and namespace, or from any namespace if the namespace is empty. This
+// assumes locks are held and that this scheduler has work
+func (b *EvalBroker) dequeueForSched(sched, namespace string) (*structs.Evaluation, string, error) {
+	eval := b.ready[sched].Pop(namespace)
 
 	// Generate a UUID for the token
 	token := uuid.Generate()
@@ -520,6 +610,9 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	bySched := b.stats.ByScheduler[sched]
 	bySched.Ready -= 1
 	bySched.Unacked += 1
+	byNamespace := b.namespaceStats(eval.Namespace)
+	byNamespace.Ready -= 1
+	byNamespace.Unacked += 1
 
 	return eval, token, nil
 }
@@ -629,6 +722,8 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 	}
 	bySched := b.stats.ByScheduler[queue]
 	bySched.Unacked -= 1
+	byNamespace := b.namespaceStats(unack.Eval.Namespace)
+	byNamespace.Unacked -= 1
 
 	// Cleanup
 	delete(b.unack, evalID)
@@ -649,12 +744,14 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 		b.cancelable = append(b.cancelable, cancelable...)
 		b.stats.TotalCancelable = len(b.cancelable)
 		b.stats.TotalPending -= len(cancelable)
+		byNamespace.Pending -= len(cancelable)
 
 		// If any remain, enqueue an eval
 		if len(pending) > 0 {
 			raw := heap.Pop(&pending)
 			eval := raw.(*structs.Evaluation)
 			b.stats.TotalPending -= 1
+			byNamespace.Pending -= 1
 			b.enqueueLocked(eval, eval.Type, true)
 		}
 
@@ -671,6 +768,7 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 		b.processEnqueue(eval, "", true)
 	}
 
+	b.pruneNamespaceStats(unack.Eval.Namespace)
 	return nil
 }
 
@@ -703,6 +801,8 @@ func (b *EvalBroker) Nack(evalID, token string) error {
 	b.stats.TotalUnacked -= 1
 	bySched := b.stats.ByScheduler[unack.Eval.Type]
 	bySched.Unacked -= 1
+	b.namespaceStats(unack.Eval.Namespace).Unacked -= 1
+	b.pruneNamespaceStats(unack.Eval.Namespace)
 
 	// Check if we've hit the delivery limit, and re-enqueue
 	// in the failedQueue
@@ -839,11 +939,13 @@ func (b *EvalBroker) flush() {
 	b.stats.TotalCancelable = 0
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.evals = make(map[string]int)
 	b.jobEvals = make(map[structs.NamespacedID]string)
 	b.pending = make(map[structs.NamespacedID]PendingEvaluations)
 	b.cancelable = make([]*structs.Evaluation, 0, structs.MaxUUIDsPerWriteRequest)
-	b.ready = make(map[string]ReadyEvaluations)
+	b.ready = make(map[string]*NamespacedReadyEvaluations)
+	b.fairShareCurrent = make(map[string]int)
 	b.unack = make(map[string]*unackEval)
 	b.timeWait = make(map[string]*time.Timer)
 	b.delayHeap = delayheap.NewDelayHeap()
@@ -928,6 +1030,7 @@ func (b *EvalBroker) Stats() *BrokerStats {
 	stats := new(BrokerStats)
 	stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	stats.ByScheduler = make(map[string]*SchedulerStats)
+	stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	b.l.RLock()
 	defer b.l.RUnlock()
@@ -946,9 +1049,34 @@ func (b *EvalBroker) Stats() *BrokerStats {
 		subStatCopy := *subStat
 		stats.ByScheduler[sched] = &subStatCopy
 	}
+	for namespace, subStat := range b.stats.ByNamespace {
+		subStatCopy := *subStat
+		stats.ByNamespace[namespace] = &subStatCopy
+	}
 	return stats
 }
 
+// namespaceStats returns the stats for the namespace, creating them if they
+// don't exist yet. This assumes the lock is held.
+func (b *EvalBroker) namespaceStats(namespace string) *NamespaceStats {
+	byNamespace, ok := b.stats.ByNamespace[namespace]
+	if !ok {
+		byNamespace = &NamespaceStats{}
+		b.stats.ByNamespace[namespace] = byNamespace
+	}
+	return byNamespace
+}
+
+// pruneNamespaceStats removes the stats for the namespace once it has no
+// evaluations left in the broker, so that deleted namespaces don't linger.
+// This assumes the lock is held.
+func (b *EvalBroker) pruneNamespaceStats(namespace string) {
+	byNamespace, ok := b.stats.ByNamespace[namespace]
+	if ok && byNamespace.Ready == 0 && byNamespace.Unacked == 0 && byNamespace.Pending == 0 {
+		delete(b.stats.ByNamespace, namespace)
+	}
+}
+
 // Cancelable retrieves a batch of previously-pending evaluations that are now
 // stale and ready to mark for canceling. The eval RPC will call this with a
 // batch size set to avoid sending overly large raft messages.
@@ -996,6 +1124,12 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 				metrics.SetGauge([]string{"nomad", "broker", sched, "ready"}, float32(schedStats.Ready))
 				metrics.SetGauge([]string{"nomad", "broker", sched, "unacked"}, float32(schedStats.Unacked))
 			}
+			for namespace, nsStats := range stats.ByNamespace {
+				labels := []metrics.Label{{Name: "namespace", Value: namespace}}
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace_ready"}, float32(nsStats.Ready), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace_unacked"}, float32(nsStats.Unacked), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace_pending"}, float32(nsStats.Pending), labels)
+			}
 
 		case <-stopCh:
 			return
@@ -1012,6 +1146,7 @@ type BrokerStats struct {
 	TotalCancelable int
 	DelayedEvals    map[string]*structs.Evaluation
 	ByScheduler     map[string]*SchedulerStats
+	ByNamespace     map[string]*NamespaceStats
 }
 
 // SchedulerStats returns the stats per scheduler
@@ -1020,6 +1155,13 @@ type SchedulerStats struct {
 	Unacked int
 }
 
+// NamespaceStats returns the stats per namespace
+type NamespaceStats struct {
+	Ready   int
+	Unacked int
+	Pending int
+}
+
 // Len is for the sorting interface
 func (r ReadyEvaluations) Len() int {
 	return len(r)
@@ -1029,10 +1171,16 @@ func (r ReadyEvaluations) Len() int {
 // so that the "min" in the min-heap is the element with the
 // highest priority
 func (r ReadyEvaluations) Less(i, j int) bool {
-	if r[i].JobID != r[j].JobID && r[i].Priority != r[j].Priority {
-		return !(r[i].Priority < r[j].Priority)
+	return readyEvalLess(r[i], r[j])
+}
+
+// readyEvalLess returns whether the ready evaluation a should be dequeued
+// before b.
+func readyEvalLess(a, b *structs.Evaluation) bool {
+	if a.JobID != b.JobID && a.Priority != b.Priority {
+		return !(a.Priority < b.Priority)
 	}
-	return r[i].CreateIndex < r[j].CreateIndex
+	return a.CreateIndex < b.CreateIndex
 }
 
 // Swap is for the sorting interface
@@ -1063,6 +1211,123 @@ func (r ReadyEvaluations) Peek() *structs.Evaluation {
 	return r[n-1]
 }
 
+// namespaceReadyQueue is the priority queue of ready evaluations of a
+// namespace.
+type namespaceReadyQueue struct {
+	namespace string
+	evals     ReadyEvaluations
+
+	// index is the position of the queue in the heap of namespace queues
+	index int
+}
+
+// namespaceReadyQueues is a priority queue of the namespace queues with
+// ready evaluations, ordered by the evaluation at the head of each queue.
+type namespaceReadyQueues []*namespaceReadyQueue
+
+func (q namespaceReadyQueues) Len() int {
+	return len(q)
+}
+
+func (q namespaceReadyQueues) Less(i, j int) bool {
+	return readyEvalLess(q[i].evals[0], q[j].evals[0])
+}
+
+func (q namespaceReadyQueues) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *namespaceReadyQueues) Push(e interface{}) {
+	queue := e.(*namespaceReadyQueue)
+	queue.index = len(*q)
+	*q = append(*q, queue)
+}
+
+func (q *namespaceReadyQueues) Pop() interface{} {
+	n := len(*q)
+	queue := (*q)[n-1]
+	(*q)[n-1] = nil
+	*q = (*q)[:n-1]
+	return queue
+}
+
+// NamespacedReadyEvaluations tracks the ready evaluations for a scheduler in
+// a priority queue per namespace. The namespace queues are themselves kept in
+// a priority queue so the next evaluation across all namespaces is found
+// without visiting every namespace.
+type NamespacedReadyEvaluations struct {
+	byNamespace map[string]*namespaceReadyQueue
+	heads       namespaceReadyQueues
+}
+
+func newNamespacedReadyEvaluations() *NamespacedReadyEvaluations {
+	return &NamespacedReadyEvaluations{
+		byNamespace: make(map[string]*namespaceReadyQueue),
+	}
+}
+
+// Push is used to add a new evaluation to the priority queue of its
+// namespace
+func (n *NamespacedReadyEvaluations) Push(eval *structs.Evaluation) {
+	queue, ok := n.byNamespace[eval.Namespace]
+	if !ok {
+		queue = &namespaceReadyQueue{
+			namespace: eval.Namespace,
+			evals:     make([]*structs.Evaluation, 0, 16),
+		}
+		n.byNamespace[eval.Namespace] = queue
+		heap.Push(&queue.evals, eval)
+		heap.Push(&n.heads, queue)
+		return
+	}
+
+	heap.Push(&queue.evals, eval)
+	heap.Fix(&n.heads, queue.index)
+}
+
+// queue returns the queue of the namespace, or the queue holding the next
+// evaluation across all namespaces if the namespace is empty
+func (n *NamespacedReadyEvaluations) queue(namespace string) *namespaceReadyQueue {
+	if namespace != "" {
+		return n.byNamespace[namespace]
+	}
+	if len(n.heads) == 0 {
+		return nil
+	}
+	return n.heads[0]
+}
+
+// Peek is used to peek at the queue of the namespace, or of the namespace
+// holding the next evaluation if the namespace is empty, as
+// ReadyEvaluations.Peek does
+func (n *NamespacedReadyEvaluations) Peek(namespace string) *structs.Evaluation {
+	queue := n.queue(namespace)
+	if queue == nil {
+		return nil
+	}
+	return queue.evals.Peek()
+}
+
+// Pop is used to remove the next evaluation for the namespace, or across all
+// namespaces if the namespace is empty
+func (n *NamespacedReadyEvaluations) Pop(namespace string) *structs.Evaluation {
+	queue := n.queue(namespace)
+	if queue == nil || len(queue.evals) == 0 {
+		return nil
+	}
+
+	eval := heap.Pop(&queue.evals).(*structs.Evaluation)
+	if len(queue.evals) == 0 {
+		heap.Remove(&n.heads, queue.index)
+		delete(n.byNamespace, queue.namespace)
+	} else {
+		heap.Fix(&n.heads, queue.index)
+	}
+	return eval
+}
+
 // Len is for the sorting interface
 func (p PendingEvaluations) Len() int {
 	return len(p)
@@ -752,6 +752,146 @@ func TestEvalBroker_Dequeue_Fairness(t *testing.T) {
 	}
 }
 
+// Ensure namespaces are dequeued in proportion to their weights when
+// fair-share dequeueing is enabled
+func TestEvalBroker_Dequeue_FairShare(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+	b.SetFairShare(&structs.EvalBrokerFairShare{
+		Enabled:          true,
+		NamespaceWeights: map[string]int{"busy": 2},
+	})
+
+	// The busy namespace has a large backlog of higher priority evals which
+	// would starve the other namespaces without fair-share dequeueing.
+	for i := 0; i < 100; i++ {
+		eval := mock.Eval()
+		eval.Namespace = "busy"
+		eval.Priority = 100
+		b.Enqueue(eval)
+	}
+	var quiet []*structs.Evaluation
+	for i := 0; i < 3; i++ {
+		eval := mock.Eval()
+		eval.Namespace = "quiet"
+		eval.Priority = 10 + i
+		eval.Type = structs.JobTypeBatch
+		quiet = append(quiet, eval)
+		b.Enqueue(eval)
+	}
+
+	stats := b.Stats()
+	must.Eq(t, 100, stats.ByNamespace["busy"].Ready)
+	must.Eq(t, 3, stats.ByNamespace["quiet"].Ready)
+
+	var order []string
+	var quietOut []*structs.Evaluation
+	for i := 0; i < 9; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		must.NotNil(t, out)
+		order = append(order, out.Namespace)
+		if out.Namespace == "quiet" {
+			quietOut = append(quietOut, out)
+		}
+	}
+	must.Eq(t, []string{
+		"busy", "quiet", "busy",
+		"busy", "quiet", "busy",
+		"busy", "quiet", "busy",
+	}, order)
+
+	// Evals are still dequeued by priority within a namespace.
+	must.Eq(t, []*structs.Evaluation{quiet[2], quiet[1], quiet[0]}, quietOut)
+
+	// Once a namespace has no ready evals, the others are dequeued
+	// without waiting for it.
+	out, _, err := b.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, "busy", out.Namespace)
+
+	stats = b.Stats()
+	must.Eq(t, 93, stats.ByNamespace["busy"].Ready)
+	must.Eq(t, 7, stats.ByNamespace["busy"].Unacked)
+	must.Eq(t, 0, stats.ByNamespace["quiet"].Ready)
+	must.Eq(t, 3, stats.ByNamespace["quiet"].Unacked)
+}
+
+// Ensure evals are dequeued strictly by priority across namespaces when
+// fair-share dequeueing is disabled
+func TestEvalBroker_Dequeue_FairShareDisabled(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+	b.SetFairShare(&structs.EvalBrokerFairShare{
+		Enabled:          false,
+		NamespaceWeights: map[string]int{"quiet": 10},
+	})
+
+	for i := 0; i < 3; i++ {
+		eval := mock.Eval()
+		eval.Namespace = "busy"
+		eval.Priority = 100
+		b.Enqueue(eval)
+	}
+	eval := mock.Eval()
+	eval.Namespace = "quiet"
+	eval.Priority = 10
+	b.Enqueue(eval)
+
+	for i := 0; i < 3; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		must.NoError(t, err)
+		must.Eq(t, "busy", out.Namespace)
+	}
+	out, _, err := b.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, eval, out)
+}
+
+// Ensure the per-namespace stats track pending evals and are cleaned up on
+// Ack
+func TestEvalBroker_NamespaceStats(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	eval1 := mock.Eval()
+	eval1.Namespace = "prod"
+	eval2 := mock.Eval()
+	eval2.Namespace = "prod"
+	eval2.JobID = eval1.JobID
+	eval2.CreateIndex = eval1.CreateIndex + 1
+	eval2.ModifyIndex = eval1.ModifyIndex + 1
+	b.Enqueue(eval1)
+	b.Enqueue(eval2)
+
+	stats := b.Stats()
+	must.Eq(t, &NamespaceStats{Ready: 1, Pending: 1}, stats.ByNamespace["prod"])
+
+	out, token, err := b.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, eval1, out)
+
+	stats = b.Stats()
+	must.Eq(t, &NamespaceStats{Unacked: 1, Pending: 1}, stats.ByNamespace["prod"])
+
+	must.NoError(t, b.Ack(eval1.ID, token))
+
+	stats = b.Stats()
+	must.Eq(t, &NamespaceStats{Ready: 1}, stats.ByNamespace["prod"])
+
+	// The stats are removed once the namespace has no evaluations left
+	out, token, err = b.Dequeue(defaultSched, time.Second)
+	must.NoError(t, err)
+	must.Eq(t, eval2, out)
+	must.NoError(t, b.Ack(eval2.ID, token))
+
+	stats = b.Stats()
+	must.MapNotContainsKey(t, stats.ByNamespace, "prod")
+}
+
 // Ensure we get unblocked
 func TestEvalBroker_Dequeue_Blocked(t *testing.T) {
 	ci.Parallel(t)
@@ -1424,6 +1564,40 @@ func TestEvalBroker_ReadyEvals_Ordering(t *testing.T) {
 
 }
 
+func TestEvalBroker_NamespacedReadyEvals_Ordering(t *testing.T) {
+	ci.Parallel(t)
+
+	ready := newNamespacedReadyEvaluations()
+
+	newEval := func(namespace, evalID string, priority int, index uint64) *structs.Evaluation {
+		eval := mock.Eval()
+		eval.Namespace = namespace
+		eval.ID = evalID
+		eval.Priority = priority
+		eval.CreateIndex = index
+		return eval
+	}
+
+	ready.Push(newEval("a", "eval01", 50, 1))
+	ready.Push(newEval("b", "eval02", 50, 2))
+	ready.Push(newEval("b", "eval03", 70, 3))
+	ready.Push(newEval("c", "eval04", 60, 4))
+
+	// Across namespaces, the highest priority is popped first
+	must.Eq(t, "eval03", ready.Pop("").ID)
+	must.Eq(t, "eval04", ready.Pop("").ID)
+
+	// Popping from a namespace leaves the others in place
+	must.Eq(t, "eval02", ready.Pop("b").ID)
+	must.Nil(t, ready.Pop("b"))
+	must.MapNotContainsKey(t, ready.byNamespace, "b")
+
+	must.Eq(t, "eval01", ready.Pop("").ID)
+	must.Nil(t, ready.Pop(""))
+	must.Nil(t, ready.Peek(""))
+	must.SliceEmpty(t, ready.heads)
+}
+
 func TestEvalBroker_PendingEval_Ordering(t *testing.T) {
 	pending := PendingEvaluations{}
 
@@ -865,6 +865,65 @@ func (e *Eval) Count(args *structs.EvalCountRequest, reply *structs.EvalCountRes
 	return e.srv.blockingRPC(&opts)
 }
 
+// QueueDepth is used to get the number of evaluations queued in the eval
+// broker for each namespace
+func (e *Eval) QueueDepth(args *structs.EvalQueueDepthRequest, reply *structs.EvalQueueDepthResponse) error {
+
+	authErr := e.srv.Authenticate(e.ctx, args)
+	// The eval broker only runs on the leader, so this can't be served by a
+	// stale read.
+	args.AllowStale = false
+	if done, err := e.srv.forward("Eval.QueueDepth", args, args, reply); done {
+		return err
+	}
+	e.srv.MeasureRPCRate("eval", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "eval", "queue_depth"}, time.Now())
+	namespace := args.RequestNamespace()
+
+	// Check for read-job permissions
+	aclObj, err := e.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+	allow := aclObj.AllowNsOpFunc(acl.NamespaceCapabilityReadJob)
+
+	// Get the namespaces the user is allowed to access.
+	store := e.srv.fsm.State()
+	allowableNamespaces, err := allowedNSes(aclObj, store, allow)
+	if err != nil {
+		return err
+	}
+
+	reply.Namespaces = make(map[string]*structs.EvalQueueDepth)
+	for ns, stats := range e.srv.evalBroker.Stats().ByNamespace {
+		if namespace != structs.AllNamespacesSentinel && ns != namespace {
+			continue
+		}
+		if allowableNamespaces != nil && !allowableNamespaces[ns] {
+			continue
+		}
+		reply.Namespaces[ns] = &structs.EvalQueueDepth{
+			Ready:   stats.Ready,
+			Unacked: stats.Unacked,
+			Pending: stats.Pending,
+		}
+	}
+
+	index, err := store.Index("evals")
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	e.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
 // Allocations is used to list the allocations for an evaluation
 func (e *Eval) Allocations(args *structs.EvalSpecificRequest,
 	reply *structs.EvalAllocationsResponse) error {
@@ -1760,6 +1760,93 @@ func TestEvalEndpoint_Count(t *testing.T) {
 
 }
 
+func TestEvalEndpoint_QueueDepth(t *testing.T) {
+	ci.Parallel(t)
+	s1, _, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	nondefaultNS := mock.Namespace()
+	nondefaultNS.Name = "non-default"
+	must.NoError(t, store.UpsertNamespaces(100, []*structs.Namespace{nondefaultNS}))
+
+	for i := 0; i < 3; i++ {
+		s1.evalBroker.Enqueue(mock.Eval())
+	}
+	eval := mock.Eval()
+	eval.Namespace = nondefaultNS.Name
+	s1.evalBroker.Enqueue(eval)
+
+	aclToken := mock.CreatePolicyAndToken(t, store, 101, "test-read-any",
+		mock.NamespacePolicy("*", "read", nil)).SecretID
+	limitedACLToken := mock.CreatePolicyAndToken(t, store, 102, "test-read-limited",
+		mock.NamespacePolicy("default", "read", nil)).SecretID
+
+	cases := []struct {
+		name      string
+		namespace string
+		token     string
+		expected  map[string]*structs.EvalQueueDepth
+		expectErr error
+	}{
+		{
+			name:      "all namespaces",
+			namespace: structs.AllNamespacesSentinel,
+			token:     aclToken,
+			expected: map[string]*structs.EvalQueueDepth{
+				structs.DefaultNamespace: {Ready: 3},
+				nondefaultNS.Name:        {Ready: 1},
+			},
+		},
+		{
+			name:      "single namespace",
+			namespace: nondefaultNS.Name,
+			token:     aclToken,
+			expected: map[string]*structs.EvalQueueDepth{
+				nondefaultNS.Name: {Ready: 1},
+			},
+		},
+		{
+			name:      "all namespaces with limited token",
+			namespace: structs.AllNamespacesSentinel,
+			token:     limitedACLToken,
+			expected: map[string]*structs.EvalQueueDepth{
+				structs.DefaultNamespace: {Ready: 3},
+			},
+		},
+		{
+			name:      "namespace denied",
+			namespace: nondefaultNS.Name,
+			token:     limitedACLToken,
+			expectErr: structs.ErrPermissionDenied,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &structs.EvalQueueDepthRequest{
+				QueryOptions: structs.QueryOptions{
+					Region:    "global",
+					Namespace: tc.namespace,
+					AuthToken: tc.token,
+				},
+			}
+			var resp structs.EvalQueueDepthResponse
+			err := msgpackrpc.CallWithCodec(codec, "Eval.QueueDepth", args, &resp)
+			if tc.expectErr != nil {
+				must.EqError(t, err, tc.expectErr.Error())
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expected, resp.Namespaces)
+		})
+	}
+}
+
 func TestEvalEndpoint_Allocations(t *testing.T) {
 	ci.Parallel(t)
 
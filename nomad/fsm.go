@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/nomad/helper/pointer"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/scheduler"
 	"github.com/hashicorp/raft"
@@ -66,9 +67,23 @@ const (
 	ACLBindingRuleSnapshot               SnapshotType = 27
 	NodePoolSnapshot                     SnapshotType = 28
 	JobSubmissionSnapshot                SnapshotType = 29
+	HostVolumeSnapshot                   SnapshotType = 30
 
 	// Namespace appliers were moved from enterprise and therefore start at 64
 	NamespaceSnapshot SnapshotType = 64
+
+	// Quota snapshots were moved from enterprise and therefore follow the
+	// namespace snapshots
+	QuotaSpecSnapshot  SnapshotType = 65
+	QuotaUsageSnapshot SnapshotType = 66
+
+	// Sentinel policy snapshots were moved from enterprise and therefore
+	// follow the quota snapshots
+	SentinelPolicySnapshot SnapshotType = 67
+
+	// Recommendation snapshots were moved from enterprise and therefore
+	// follow the Sentinel policy snapshots
+	RecommendationSnapshot SnapshotType = 68
 )
 
 var snapshotTypeStrings = map[SnapshotType]string{
@@ -102,7 +117,12 @@ var snapshotTypeStrings = map[SnapshotType]string{
 	ACLBindingRuleSnapshot:               "ACLBindingRule",
 	NodePoolSnapshot:                     "NodePool",
 	JobSubmissionSnapshot:                "JobSubmission",
+	HostVolumeSnapshot:                   "HostVolume",
 	NamespaceSnapshot:                    "Namespace",
+	QuotaSpecSnapshot:                    "QuotaSpec",
+	QuotaUsageSnapshot:                   "QuotaUsage",
+	SentinelPolicySnapshot:               "SentinelPolicy",
+	RecommendationSnapshot:               "Recommendation",
 }
 
 // LogApplier is the definition of a function that can apply a Raft log
@@ -184,6 +204,9 @@ type FSMConfig struct {
 	// EventBufferSize is the amount of messages to hold in memory
 	EventBufferSize int64
 
+	// EventLog is the optional durable log the event broker writes events to
+	EventLog *stream.EventLog
+
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
 }
@@ -196,6 +219,7 @@ func NewFSM(config *FSMConfig) (*nomadFSM, error) {
 		Region:             config.Region,
 		EnablePublisher:    config.EnableEventBroker,
 		EventBufferSize:    config.EventBufferSize,
+		EventLog:           config.EventLog,
 		JobTrackedVersions: config.JobTrackedVersions,
 	}
 	state, err := state.NewStateStore(sconfig)
@@ -271,6 +295,10 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyNodePoolUpsert(msgType, buf[1:], log.Index)
 	case structs.NodePoolDeleteRequestType:
 		return n.applyNodePoolDelete(msgType, buf[1:], log.Index)
+	case structs.HostVolumeRegisterRequestType:
+		return n.applyHostVolumeRegister(msgType, buf[1:], log.Index)
+	case structs.HostVolumeDeleteRequestType:
+		return n.applyHostVolumeDelete(msgType, buf[1:], log.Index)
 	case structs.JobRegisterRequestType:
 		return n.applyUpsertJob(msgType, buf[1:], log.Index)
 	case structs.JobDeregisterRequestType:
@@ -301,6 +329,8 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyDeploymentDelete(buf[1:], log.Index)
 	case structs.JobStabilityRequestType:
 		return n.applyJobStability(buf[1:], log.Index)
+	case structs.JobVersionTagRequestType:
+		return n.applyJobVersionTag(buf[1:], log.Index)
 	case structs.ACLPolicyUpsertRequestType:
 		return n.applyACLPolicyUpsert(msgType, buf[1:], log.Index)
 	case structs.ACLPolicyDeleteRequestType:
@@ -349,6 +379,18 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyNamespaceUpsert(buf[1:], log.Index)
 	case structs.NamespaceDeleteRequestType:
 		return n.applyNamespaceDelete(buf[1:], log.Index)
+	case structs.QuotaSpecUpsertRequestType:
+		return n.applyQuotaSpecUpsert(msgType, buf[1:], log.Index)
+	case structs.QuotaSpecDeleteRequestType:
+		return n.applyQuotaSpecDelete(msgType, buf[1:], log.Index)
+	case structs.SentinelPolicyUpsertRequestType:
+		return n.applySentinelPolicyUpsert(msgType, buf[1:], log.Index)
+	case structs.SentinelPolicyDeleteRequestType:
+		return n.applySentinelPolicyDelete(msgType, buf[1:], log.Index)
+	case structs.RecommendationUpsertRequestType:
+		return n.applyRecommendationUpsert(msgType, buf[1:], log.Index)
+	case structs.RecommendationDeleteRequestType:
+		return n.applyRecommendationDelete(msgType, buf[1:], log.Index)
 	// COMPAT(1.0): These messages were added and removed during the 1.0-beta
 	// series and should not be immediately reused for other purposes
 	case structs.EventSinkUpsertRequestType,
@@ -486,7 +528,8 @@ func (n *nomadFSM) applyStatusUpdate(msgType structs.MessageType, buf []byte, in
 		panic(fmt.Errorf("failed to decode request: %v", err))
 	}
 
-	if err := n.state.UpdateNodeStatus(msgType, index, req.NodeID, req.Status, req.UpdatedAt, req.NodeEvent); err != nil {
+	if err := n.state.UpdateNodeStatusUtilization(msgType, index, req.NodeID, req.Status, req.UpdatedAt,
+		req.NodeEvent, req.Utilization); err != nil {
 		n.logger.Error("UpdateNodeStatus failed", "error", err)
 		return err
 	}
@@ -597,6 +640,38 @@ func (n *nomadFSM) applyNodePoolDelete(msgType structs.MessageType, buf []byte,
 	return nil
 }
 
+// applyHostVolumeRegister is used to upsert a set of dynamic host volumes
+func (n *nomadFSM) applyHostVolumeRegister(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_host_volume_register"}, time.Now())
+	var req structs.HostVolumeRegisterRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertHostVolumes(msgType, index, req.Volumes); err != nil {
+		n.logger.Error("UpsertHostVolumes failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyHostVolumeDelete is used to delete a dynamic host volume
+func (n *nomadFSM) applyHostVolumeDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_host_volume_delete"}, time.Now())
+	var req structs.HostVolumeDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteHostVolume(msgType, index, req.RequestNamespace(), req.VolumeID); err != nil {
+		n.logger.Error("DeleteHostVolume failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *nomadFSM) applyUpsertJob(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "register_job"}, time.Now())
 	var req structs.JobRegisterRequest
@@ -1106,6 +1181,30 @@ func (n *nomadFSM) applyPlanResults(msgType structs.MessageType, buf []byte, ind
 
 	// Add evals for jobs that were preempted
 	n.handleUpsertedEvals(req.PreemptionEvals)
+
+	// Stopping or preempting allocations frees up quota, so unblock any
+	// evals waiting on the quotas they were accounted against.
+	stopped := make([]string, 0, len(req.AllocsStopped)+len(req.AllocsPreempted))
+	for _, diff := range req.AllocsStopped {
+		stopped = append(stopped, diff.ID)
+	}
+	for _, diff := range req.AllocsPreempted {
+		stopped = append(stopped, diff.ID)
+	}
+
+	unblocked := make(map[string]struct{})
+	for _, allocID := range stopped {
+		quota, err := n.allocQuota(allocID)
+		if err != nil {
+			n.logger.Error("looking up quota associated with alloc failed", "alloc_id", allocID, "error", err)
+			return err
+		}
+		if _, ok := unblocked[quota]; quota == "" || ok {
+			continue
+		}
+		unblocked[quota] = struct{}{}
+		n.blockedEvals.UnblockQuota(quota, index)
+	}
 	return nil
 }
 
@@ -1194,6 +1293,22 @@ func (n *nomadFSM) applyJobStability(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyJobVersionTag is used to tag or untag a job version
+func (n *nomadFSM) applyJobVersionTag(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_version_tag"}, time.Now())
+	var req structs.JobApplyTagRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateJobVersionTag(index, req.RequestNamespace(), &req); err != nil {
+		n.logger.Error("UpdateJobVersionTag failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 // applyACLPolicyUpsert is used to upsert a set of policies
 func (n *nomadFSM) applyACLPolicyUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_policy_upsert"}, time.Now())
@@ -1387,13 +1502,9 @@ func (n *nomadFSM) applyCSIVolumeBatchClaim(buf []byte, index uint64) interface{
 	}
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_csi_volume_batch_claim"}, time.Now())
 
-	for _, req := range batch.Claims {
-		err := n.state.CSIVolumeClaim(index, req.RequestNamespace(),
-			req.VolumeID, req.ToClaim())
-		if err != nil {
-			n.logger.Error("CSIVolumeClaim for batch failed", "error", err)
-			return err // note: fails the remaining batch
-		}
+	if err := n.state.CSIVolumeClaimBatch(index, batch.Claims); err != nil {
+		n.logger.Error("CSIVolumeClaim for batch failed", "error", err)
+		return err // note: fails the remaining batch
 	}
 	return nil
 }
@@ -1482,6 +1593,108 @@ func (n *nomadFSM) applyNamespaceDelete(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyQuotaSpecUpsert is used to upsert a set of quota specifications
+func (n *nomadFSM) applyQuotaSpecUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_quota_spec_upsert"}, time.Now())
+	var req structs.QuotaSpecUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertQuotaSpecs(msgType, index, req.Quotas); err != nil {
+		n.logger.Error("UpsertQuotaSpecs failed", "error", err)
+		return err
+	}
+
+	// The limits may have been raised so unblock any evals waiting on the
+	// quotas.
+	for _, quota := range req.Quotas {
+		n.blockedEvals.UnblockQuota(quota.Name, index)
+	}
+
+	return nil
+}
+
+// applyQuotaSpecDelete is used to delete a set of quota specifications
+func (n *nomadFSM) applyQuotaSpecDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_quota_spec_delete"}, time.Now())
+	var req structs.QuotaSpecDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteQuotaSpecs(msgType, index, req.Names); err != nil {
+		n.logger.Error("DeleteQuotaSpecs failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applySentinelPolicyUpsert is used to upsert a set of Sentinel policies
+func (n *nomadFSM) applySentinelPolicyUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_sentinel_policy_upsert"}, time.Now())
+	var req structs.SentinelPolicyUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertSentinelPolicies(msgType, index, req.Policies); err != nil {
+		n.logger.Error("UpsertSentinelPolicies failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applySentinelPolicyDelete is used to delete a set of Sentinel policies
+func (n *nomadFSM) applySentinelPolicyDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_sentinel_policy_delete"}, time.Now())
+	var req structs.SentinelPolicyDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteSentinelPolicies(msgType, index, req.Names); err != nil {
+		n.logger.Error("DeleteSentinelPolicies failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyRecommendationUpsert is used to upsert a set of recommendations
+func (n *nomadFSM) applyRecommendationUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_recommendation_upsert"}, time.Now())
+	var req structs.RecommendationUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertRecommendations(msgType, index, req.Recommendations); err != nil {
+		n.logger.Error("UpsertRecommendations failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyRecommendationDelete is used to delete a set of recommendations
+func (n *nomadFSM) applyRecommendationDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_recommendation_delete"}, time.Now())
+	var req structs.RecommendationDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteRecommendations(msgType, index, req.IDs); err != nil {
+		n.logger.Error("DeleteRecommendations failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *nomadFSM) Snapshot() (raft.FSMSnapshot, error) {
 	// Create a new snapshot
 	snap, err := n.state.Snapshot()
@@ -1518,6 +1731,7 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 		Region:             n.config.Region,
 		EnablePublisher:    n.config.EnableEventBroker,
 		EventBufferSize:    n.config.EventBufferSize,
+		EventLog:           n.config.EventLog,
 		JobTrackedVersions: n.config.JobTrackedVersions,
 	}
 	newState, err := state.NewStateStore(config)
@@ -1785,6 +1999,42 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				return err
 			}
 
+		case QuotaSpecSnapshot:
+			spec := new(structs.QuotaSpec)
+			if err := dec.Decode(spec); err != nil {
+				return err
+			}
+			if err := restore.QuotaSpecRestore(spec); err != nil {
+				return err
+			}
+
+		case QuotaUsageSnapshot:
+			usage := new(structs.QuotaUsage)
+			if err := dec.Decode(usage); err != nil {
+				return err
+			}
+			if err := restore.QuotaUsageRestore(usage); err != nil {
+				return err
+			}
+
+		case SentinelPolicySnapshot:
+			policy := new(structs.SentinelPolicy)
+			if err := dec.Decode(policy); err != nil {
+				return err
+			}
+			if err := restore.SentinelPolicyRestore(policy); err != nil {
+				return err
+			}
+
+		case RecommendationSnapshot:
+			rec := new(structs.Recommendation)
+			if err := dec.Decode(rec); err != nil {
+				return err
+			}
+			if err := restore.RecommendationRestore(rec); err != nil {
+				return err
+			}
+
 		// COMPAT(1.0): Allow 1.0-beta clusterers to gracefully handle
 		case EventSinkSnapshot:
 			return nil
@@ -1892,6 +2142,15 @@ func (n *nomadFSM) restoreImpl(old io.ReadCloser, filter *FSMFilter) error {
 				return err
 			}
 
+		case HostVolumeSnapshot:
+			vol := new(structs.HostVolume)
+			if err := dec.Decode(vol); err != nil {
+				return err
+			}
+			if err := restore.HostVolumeRestore(vol); err != nil {
+				return err
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -2423,6 +2682,18 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistQuotas(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistSentinelPolicies(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistRecommendations(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistEnterpriseTables(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
@@ -2467,6 +2738,10 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistHostVolumes(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	return nil
 }
 
@@ -2865,6 +3140,77 @@ func (s *nomadSnapshot) persistNamespaces(sink raft.SnapshotSink, encoder *codec
 	return nil
 }
 
+// persistQuotas persists all the quota specifications and their usage.
+func (s *nomadSnapshot) persistQuotas(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	ws := memdb.NewWatchSet()
+	specs, err := s.snap.QuotaSpecs(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := specs.Next(); raw != nil; raw = specs.Next() {
+		spec := raw.(*structs.QuotaSpec)
+
+		sink.Write([]byte{byte(QuotaSpecSnapshot)})
+		if err := encoder.Encode(spec); err != nil {
+			return err
+		}
+	}
+
+	usages, err := s.snap.QuotaUsages(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := usages.Next(); raw != nil; raw = usages.Next() {
+		usage := raw.(*structs.QuotaUsage)
+
+		sink.Write([]byte{byte(QuotaUsageSnapshot)})
+		if err := encoder.Encode(usage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// persistSentinelPolicies persists all the Sentinel policies.
+func (s *nomadSnapshot) persistSentinelPolicies(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	ws := memdb.NewWatchSet()
+	policies, err := s.snap.SentinelPolicies(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := policies.Next(); raw != nil; raw = policies.Next() {
+		policy := raw.(*structs.SentinelPolicy)
+
+		sink.Write([]byte{byte(SentinelPolicySnapshot)})
+		if err := encoder.Encode(policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// persistRecommendations persists all the recommendations.
+func (s *nomadSnapshot) persistRecommendations(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	ws := memdb.NewWatchSet()
+	recs, err := s.snap.Recommendations(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := recs.Next(); raw != nil; raw = recs.Next() {
+		rec := raw.(*structs.Recommendation)
+
+		sink.Write([]byte{byte(RecommendationSnapshot)})
+		if err := encoder.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistSchedulerConfig(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 	// Get scheduler config
@@ -3204,6 +3550,25 @@ func (s *nomadSnapshot) persistJobSubmissions(sink raft.SnapshotSink, encoder *c
 	return nil
 }
 
+// persistHostVolumes persists all the dynamic host volumes.
+func (s *nomadSnapshot) persistHostVolumes(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.HostVolumes(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		vol := raw.(*structs.HostVolume)
+
+		sink.Write([]byte{byte(HostVolumeSnapshot)})
+		if err := encoder.Encode(vol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Release is a no-op, as we just need to GC the pointer
 // to the state store snapshot. There is nothing to explicitly
 // cleanup.
@@ -6,13 +6,28 @@
 
 package nomad
 
-// allocQuota returns the quota object associated with the allocation. In
-// anything but Premium this will always be empty
-func (n *nomadFSM) allocQuota(_ string) (string, error) {
-	return "", nil
-}
-
 // enterpriseSnapshotType is a no-op for community edition.
 func enterpriseSnapshotType(s SnapshotType) (string, bool) {
 	return "", false
 }
+
+// allocQuota returns the quota the namespace of the allocation accounts its
+// usage against, if any.
+func (n *nomadFSM) allocQuota(allocID string) (string, error) {
+	alloc, err := n.state.AllocByID(nil, allocID)
+	if err != nil {
+		return "", err
+	}
+	if alloc == nil {
+		return "", nil
+	}
+
+	ns, err := n.state.NamespaceByName(nil, alloc.Namespace)
+	if err != nil {
+		return "", err
+	}
+	if ns == nil {
+		return "", nil
+	}
+	return ns.Quota, nil
+}
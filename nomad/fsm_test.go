@@ -418,6 +418,36 @@ func TestFSM_UpdateNodeStatus(t *testing.T) {
 	})
 }
 
+func TestFSM_UpdateNodeStatus_Utilization(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	node := mock.Node()
+	node.Status = structs.NodeStatusReady
+	must.NoError(t, fsm.State().UpsertNode(structs.MsgTypeTestSetup, 1, node))
+
+	req := structs.NodeUpdateStatusRequest{
+		NodeID:    node.ID,
+		Status:    structs.NodeStatusReady,
+		UpdatedAt: 70,
+		Utilization: &structs.NodeUtilization{
+			CPU: 1000, MemoryMB: 512, Samples: 10, UpdatedAt: 70,
+		},
+	}
+	buf, err := structs.Encode(structs.NodeUpdateStatusRequestType, req)
+	must.NoError(t, err)
+
+	log := makeLog(buf)
+	log.Index = 2
+	must.Nil(t, fsm.Apply(log))
+
+	out, err := fsm.State().NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, req.Utilization, out.Utilization)
+	must.Eq(t, 2, out.ModifyIndex)
+	must.Eq(t, structs.NodeStatusReady, out.Status)
+}
+
 func TestFSM_BatchUpdateNodeDrain(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -2445,6 +2475,25 @@ func TestFSM_SnapshotRestore_NodePools(t *testing.T) {
 	must.Eq(t, pool, out)
 }
 
+func TestFSM_SnapshotRestore_HostVolumes(t *testing.T) {
+	ci.Parallel(t)
+
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	vol := mock.HostVolume(node)
+	must.NoError(t, state.UpsertHostVolumes(structs.MsgTypeTestSetup, 1001, []*structs.HostVolume{vol}))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out, err := state2.HostVolumeByID(nil, vol.Namespace, vol.ID)
+	must.NoError(t, err)
+	must.Eq(t, vol, out)
+}
+
 func TestFSM_SnapshotRestore_Jobs(t *testing.T) {
 	ci.Parallel(t)
 	// Add some state
@@ -3405,6 +3454,160 @@ func TestFSM_SnapshotRestore_Namespaces(t *testing.T) {
 	}
 }
 
+func TestFSM_UpsertQuotaSpecs(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	spec := mock.QuotaSpec()
+	req := structs.QuotaSpecUpsertRequest{
+		Quotas: []*structs.QuotaSpec{spec},
+	}
+	buf, err := structs.Encode(structs.QuotaSpecUpsertRequestType, req)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+
+	usage, err := fsm.State().QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.NotNil(t, usage)
+
+	// Delete the quota
+	delReq := structs.QuotaSpecDeleteRequest{
+		Names: []string{spec.Name},
+	}
+	buf, err = structs.Encode(structs.QuotaSpecDeleteRequestType, delReq)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err = fsm.State().QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Nil(t, out)
+}
+
+func TestFSM_SnapshotRestore_Quotas(t *testing.T) {
+	ci.Parallel(t)
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	spec := mock.QuotaSpec()
+	must.NoError(t, state.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+	usage, err := state.QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	outSpec, _ := state2.QuotaSpecByName(nil, spec.Name)
+	must.Eq(t, spec, outSpec)
+	outUsage, _ := state2.QuotaUsageByName(nil, spec.Name)
+	must.Eq(t, usage, outUsage)
+}
+
+func TestFSM_UpsertSentinelPolicies(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	policy := mock.SentinelPolicy()
+	req := structs.SentinelPolicyUpsertRequest{
+		Policies: []*structs.SentinelPolicy{policy},
+	}
+	buf, err := structs.Encode(structs.SentinelPolicyUpsertRequestType, req)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().SentinelPolicyByName(nil, policy.Name)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, policy.Hash, out.Hash)
+
+	// Delete the policy
+	delReq := structs.SentinelPolicyDeleteRequest{
+		Names: []string{policy.Name},
+	}
+	buf, err = structs.Encode(structs.SentinelPolicyDeleteRequestType, delReq)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err = fsm.State().SentinelPolicyByName(nil, policy.Name)
+	must.NoError(t, err)
+	must.Nil(t, out)
+}
+
+func TestFSM_SnapshotRestore_SentinelPolicies(t *testing.T) {
+	ci.Parallel(t)
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	p1, p2 := mock.SentinelPolicy(), mock.SentinelPolicy()
+	must.NoError(t, state.UpsertSentinelPolicies(structs.MsgTypeTestSetup, 1000, []*structs.SentinelPolicy{p1, p2}))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, _ := state2.SentinelPolicyByName(nil, p1.Name)
+	must.Eq(t, p1, out1)
+	out2, _ := state2.SentinelPolicyByName(nil, p2.Name)
+	must.Eq(t, p2, out2)
+}
+
+func TestFSM_UpsertRecommendations(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	job := mock.Job()
+	must.NoError(t, fsm.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	rec := mock.Recommendation(job)
+	req := structs.RecommendationUpsertRequest{
+		Recommendations: []*structs.Recommendation{rec},
+	}
+	buf, err := structs.Encode(structs.RecommendationUpsertRequestType, req)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().RecommendationByID(nil, rec.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out)
+	must.Eq(t, rec.Value, out.Value)
+
+	// Delete the recommendation
+	delReq := structs.RecommendationDeleteRequest{
+		IDs: []string{rec.ID},
+	}
+	buf, err = structs.Encode(structs.RecommendationDeleteRequestType, delReq)
+	must.NoError(t, err)
+	must.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err = fsm.State().RecommendationByID(nil, rec.ID)
+	must.NoError(t, err)
+	must.Nil(t, out)
+}
+
+func TestFSM_SnapshotRestore_Recommendations(t *testing.T) {
+	ci.Parallel(t)
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, nil, job))
+	r1 := mock.Recommendation(job)
+	r2 := mock.Recommendation(job)
+	r2.Resource = structs.RecommendationResourceMemoryMB
+	r2.Value = 512
+	must.NoError(t, state.UpsertRecommendations(structs.MsgTypeTestSetup, 1000, []*structs.Recommendation{r1, r2}))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, _ := state2.RecommendationByID(nil, r1.ID)
+	must.Eq(t, r1, out1)
+	out2, _ := state2.RecommendationByID(nil, r2.ID)
+	must.Eq(t, r2, out2)
+}
+
 func TestFSM_UpsertServiceRegistrations(t *testing.T) {
 	ci.Parallel(t)
 	fsm := testFSM(t)
@@ -0,0 +1,376 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/nomad/acl"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// HostVolume is the server RPC endpoint for dynamic host volumes.
+type HostVolume struct {
+	srv    *Server
+	ctx    *RPCContext
+	logger log.Logger
+}
+
+func NewHostVolumeEndpoint(srv *Server, ctx *RPCContext) *HostVolume {
+	return &HostVolume{srv: srv, ctx: ctx, logger: srv.logger.Named("host_volume")}
+}
+
+// Create registers a dynamic host volume and provisions it on a node. The
+// volume is registered as pending first, so its requested capacity counts
+// against the namespace's quota while the plugin runs, and is marked ready
+// once the client has provisioned it.
+func (v *HostVolume) Create(args *structs.HostVolumeCreateRequest, reply *structs.HostVolumeCreateResponse) error {
+
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("HostVolume.Create", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("host_volume", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "create"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeCreate)
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !allowVolume(aclObj, args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Volume == nil {
+		return errors.New("missing volume definition")
+	}
+
+	// This is the only namespace we ACL checked, force the volume to use it.
+	vol := args.Volume.Copy()
+	vol.Namespace = args.RequestNamespace()
+	if vol.ID != "" {
+		return errors.New("host volumes can't be updated, volume ID must not be set")
+	}
+	if vol.NodeID == "" && vol.NodePool == "" {
+		vol.NodePool = structs.NodePoolDefault
+	}
+	if err := vol.Validate(); err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid host volume: %v", err)
+	}
+
+	snap, err := v.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	node, err := v.placeHostVolume(snap, vol)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "could not place host volume: %v", err)
+	}
+
+	now := time.Now().UnixNano()
+	vol.ID = uuid.Generate()
+	vol.NodeID = node.ID
+	vol.NodePool = node.NodePool
+	vol.State = structs.HostVolumeStatePending
+	vol.CapacityBytes = 0
+	vol.HostPath = ""
+	vol.CreateTime = now
+	vol.ModifyTime = now
+
+	regArgs := &structs.HostVolumeRegisterRequest{
+		Volumes:      []*structs.HostVolume{vol},
+		WriteRequest: args.WriteRequest,
+	}
+	if _, _, err := v.srv.raftApply(structs.HostVolumeRegisterRequestType, regArgs); err != nil {
+		return err
+	}
+
+	cReq := &cstructs.ClientHostVolumeCreateRequest{
+		ID:                        vol.ID,
+		Name:                      vol.Name,
+		PluginID:                  vol.PluginID,
+		NodeID:                    vol.NodeID,
+		RequestedCapacityMinBytes: vol.RequestedCapacityMinBytes,
+		RequestedCapacityMaxBytes: vol.RequestedCapacityMaxBytes,
+		Parameters:                vol.Parameters,
+	}
+	cResp := &cstructs.ClientHostVolumeCreateResponse{}
+	if err := v.srv.RPC("ClientHostVolume.Create", cReq, cResp); err != nil {
+		// Release the capacity accounted for the pending volume
+		delArgs := &structs.HostVolumeDeleteRequest{
+			VolumeID:     vol.ID,
+			WriteRequest: args.WriteRequest,
+		}
+		if _, _, delErr := v.srv.raftApply(structs.HostVolumeDeleteRequestType, delArgs); delErr != nil {
+			v.logger.Error("failed to deregister host volume", "volume_id", vol.ID, "error", delErr)
+		}
+		return fmt.Errorf("could not create host volume on node %q: %w", vol.NodeID, err)
+	}
+
+	vol = vol.Copy()
+	vol.HostPath = cResp.HostPath
+	vol.CapacityBytes = cResp.CapacityBytes
+	vol.State = structs.HostVolumeStateReady
+	vol.ModifyTime = time.Now().UnixNano()
+
+	regArgs.Volumes = []*structs.HostVolume{vol}
+	_, index, err := v.srv.raftApply(structs.HostVolumeRegisterRequestType, regArgs)
+	if err != nil {
+		return err
+	}
+
+	reply.Volume, err = v.srv.State().HostVolumeByID(nil, vol.Namespace, vol.ID)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// placeHostVolume returns the node to provision the volume on. If the volume
+// doesn't request a specific node, a node is chosen at random among the
+// ready nodes of its node pool that have the plugin and don't have a volume
+// with the same name.
+func (v *HostVolume) placeHostVolume(snap *state.StateSnapshot, vol *structs.HostVolume) (*structs.Node, error) {
+	if vol.NodeID != "" {
+		node, err := snap.NodeByID(nil, vol.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, fmt.Errorf("node %q does not exist", vol.NodeID)
+		}
+		if vol.NodePool != "" && node.NodePool != vol.NodePool {
+			return nil, fmt.Errorf("node %q is not in node pool %q", vol.NodeID, vol.NodePool)
+		}
+		if err := v.nodeFeasible(snap, node, vol); err != nil {
+			return nil, fmt.Errorf("node %q %v", vol.NodeID, err)
+		}
+		return node, nil
+	}
+
+	iter, err := snap.NodesByNodePool(nil, vol.NodePool)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*structs.Node
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if err := v.nodeFeasible(snap, node, vol); err == nil {
+			candidates = append(candidates, node)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no node in node pool %q is ready and has plugin %q", vol.NodePool, vol.PluginID)
+	}
+	return candidates[rand.Intn(len(candidates))], nil
+}
+
+// nodeFeasible returns an error if the volume can't be provisioned on the
+// node.
+func (v *HostVolume) nodeFeasible(snap *state.StateSnapshot, node *structs.Node, vol *structs.HostVolume) error {
+	if !node.Ready() {
+		return errors.New("is not ready")
+	}
+	if _, ok := node.Attributes[fmt.Sprintf(structs.HostVolumePluginVersionAttrFormat, vol.PluginID)]; !ok {
+		return fmt.Errorf("does not have host volume plugin %q", vol.PluginID)
+	}
+	if _, ok := node.HostVolumes[vol.Name]; ok {
+		return fmt.Errorf("already has a host volume named %q", vol.Name)
+	}
+
+	// Pending volumes aren't fingerprinted on the node yet
+	iter, err := snap.HostVolumesByNodeID(nil, node.ID)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if raw.(*structs.HostVolume).Name == vol.Name {
+			return fmt.Errorf("already has a host volume named %q", vol.Name)
+		}
+	}
+	return nil
+}
+
+// Delete deregisters a dynamic host volume and removes it from its node.
+// Volumes in use by non-terminal allocations can't be deleted.
+func (v *HostVolume) Delete(args *structs.HostVolumeDeleteRequest, reply *structs.HostVolumeDeleteResponse) error {
+
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("HostVolume.Delete", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("host_volume", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "delete"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeDelete)
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !allowVolume(aclObj, args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.VolumeID == "" {
+		return errors.New("missing volume ID")
+	}
+
+	vol, err := v.srv.State().HostVolumeByID(nil, args.RequestNamespace(), args.VolumeID)
+	if err != nil {
+		return err
+	}
+	if vol == nil {
+		return structs.NewErrRPCCodedf(http.StatusNotFound, "host volume %q not found", args.VolumeID)
+	}
+
+	// Deregister the volume first, which fails if the volume is in use, so
+	// the scheduler can't place allocations on a volume being removed.
+	_, index, err := v.srv.raftApply(structs.HostVolumeDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	cReq := &cstructs.ClientHostVolumeDeleteRequest{
+		ID:         vol.ID,
+		Name:       vol.Name,
+		PluginID:   vol.PluginID,
+		NodeID:     vol.NodeID,
+		HostPath:   vol.HostPath,
+		Parameters: vol.Parameters,
+	}
+	if err := v.srv.RPC("ClientHostVolume.Delete", cReq, &cstructs.ClientHostVolumeDeleteResponse{}); err != nil {
+		return fmt.Errorf("host volume was deregistered but could not be removed from node %q: %w", vol.NodeID, err)
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// Get returns a single dynamic host volume.
+func (v *HostVolume) Get(args *structs.HostVolumeGetRequest, reply *structs.HostVolumeGetResponse) error {
+
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("HostVolume.Get", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("host_volume", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "get"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeRead)
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !allowVolume(aclObj, args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			vol, err := s.HostVolumeByID(ws, args.RequestNamespace(), args.ID)
+			if err != nil {
+				return err
+			}
+
+			reply.Volume = vol
+			if vol != nil {
+				reply.Index = vol.ModifyIndex
+				return nil
+			}
+			return v.srv.replySetIndex(state.TableHostVolumes, &reply.QueryMeta)
+		}}
+	return v.srv.blockingRPC(&opts)
+}
+
+// List returns the dynamic host volumes in the namespace, or in every
+// namespace the token can read when querying all namespaces.
+func (v *HostVolume) List(args *structs.HostVolumeListRequest, reply *structs.HostVolumeListResponse) error {
+
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("HostVolume.List", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("host_volume", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "list"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeRead)
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	ns := args.RequestNamespace()
+	if ns != structs.AllNamespacesSentinel && !allowVolume(aclObj, ns) {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			var iter memdb.ResultIterator
+			var err error
+			switch {
+			case args.NodeID != "":
+				iter, err = s.HostVolumesByNodeID(ws, args.NodeID)
+			case ns == structs.AllNamespacesSentinel:
+				iter, err = s.HostVolumes(ws)
+			default:
+				iter, err = s.HostVolumesByIDPrefix(ws, ns, args.Prefix)
+			}
+			if err != nil {
+				return err
+			}
+
+			reply.Volumes = []*structs.HostVolumeStub{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				vol := raw.(*structs.HostVolume)
+				if ns != structs.AllNamespacesSentinel && vol.Namespace != ns {
+					continue
+				}
+				if !allowVolume(aclObj, vol.Namespace) {
+					continue
+				}
+				if args.NodePool != "" && vol.NodePool != args.NodePool {
+					continue
+				}
+				if args.Prefix != "" && !strings.HasPrefix(vol.ID, args.Prefix) {
+					continue
+				}
+				reply.Volumes = append(reply.Volumes, vol.Stub())
+			}
+
+			return v.srv.replySetIndex(state.TableHostVolumes, &reply.QueryMeta)
+		}}
+	return v.srv.blockingRPC(&opts)
+}
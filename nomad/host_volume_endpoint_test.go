@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"os"
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+)
+
+func TestHostVolumeEndpoint_CreateGetListDelete(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	t.Cleanup(cleanupSrv)
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	volumesDir := t.TempDir()
+	c, cleanupClient := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{srv.config.RPCAddr.String()}
+		c.HostVolumesDir = volumesDir
+	})
+	t.Cleanup(func() { cleanupClient() })
+	testutil.WaitForClient(t, srv.RPC, c.NodeID(), c.Region())
+
+	// Volumes can't be placed without a node that has the plugin
+	createReq := &structs.HostVolumeCreateRequest{
+		Volume: &structs.HostVolume{
+			Name:                      "example",
+			PluginID:                  "no-such-plugin",
+			RequestedCapacityMaxBytes: 100 * structs.BytesInMegabyte,
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    srv.Region(),
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var createResp structs.HostVolumeCreateResponse
+	err := msgpackrpc.CallWithCodec(codec, "HostVolume.Create", createReq, &createResp)
+	must.ErrorContains(t, err, "could not place host volume")
+
+	// The built-in plugin creates a directory on the client
+	createReq.Volume.PluginID = structs.HostVolumePluginMkdirID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.Create", createReq, &createResp))
+	vol := createResp.Volume
+	must.NotNil(t, vol)
+	must.NotEq(t, "", vol.ID)
+	must.Eq(t, c.NodeID(), vol.NodeID)
+	must.Eq(t, structs.NodePoolDefault, vol.NodePool)
+	must.Eq(t, structs.HostVolumeStateReady, vol.State)
+	must.DirExists(t, vol.HostPath)
+
+	// Volume names are unique on a node
+	createReq.Volume.NodeID = c.NodeID()
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Create", createReq, &createResp)
+	must.ErrorContains(t, err, "already has a host volume named")
+
+	getReq := &structs.HostVolumeGetRequest{
+		ID: vol.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    srv.Region(),
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var getResp structs.HostVolumeGetResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.Get", getReq, &getResp))
+	must.Eq(t, vol.ID, getResp.Volume.ID)
+	must.Eq(t, vol.HostPath, getResp.Volume.HostPath)
+
+	listReq := &structs.HostVolumeListRequest{
+		NodeID: c.NodeID(),
+		QueryOptions: structs.QueryOptions{
+			Region:    srv.Region(),
+			Namespace: structs.AllNamespacesSentinel,
+		},
+	}
+	var listResp structs.HostVolumeListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.List", listReq, &listResp))
+	must.Len(t, 1, listResp.Volumes)
+
+	listReq.NodePool = "other"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.List", listReq, &listResp))
+	must.Len(t, 0, listResp.Volumes)
+
+	// The node fingerprints the volume so jobs can use it
+	testutil.WaitForResult(func() (bool, error) {
+		node, err := srv.State().NodeByID(nil, c.NodeID())
+		if err != nil {
+			return false, err
+		}
+		nodeVol, ok := node.HostVolumes[vol.Name]
+		if !ok {
+			return false, nil
+		}
+		return nodeVol.ID == vol.ID && nodeVol.Path == vol.HostPath, nil
+	}, func(err error) {
+		t.Fatalf("host volume was not added to the node: %v", err)
+	})
+
+	delReq := &structs.HostVolumeDeleteRequest{
+		VolumeID: vol.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    srv.Region(),
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var delResp structs.HostVolumeDeleteResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.Delete", delReq, &delResp))
+
+	_, err = os.Stat(vol.HostPath)
+	must.True(t, os.IsNotExist(err))
+
+	got, err := srv.State().HostVolumeByID(nil, structs.DefaultNamespace, vol.ID)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Delete", delReq, &delResp)
+	must.ErrorContains(t, err, "not found")
+}
+
+func TestHostVolumeEndpoint_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, root, cleanupSrv := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	t.Cleanup(cleanupSrv)
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+	store := srv.fsm.State()
+
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	vol := mock.HostVolume(node)
+	must.NoError(t, store.UpsertHostVolumes(structs.MsgTypeTestSetup, 1001, []*structs.HostVolume{vol}))
+
+	readToken := mock.CreatePolicyAndToken(t, store, 1002, "host-volume-read",
+		mock.NamespacePolicy(structs.DefaultNamespace, "",
+			[]string{acl.NamespaceCapabilityHostVolumeRead}))
+
+	getReq := &structs.HostVolumeGetRequest{
+		ID: vol.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    srv.Region(),
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var getResp structs.HostVolumeGetResponse
+	err := msgpackrpc.CallWithCodec(codec, "HostVolume.Get", getReq, &getResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	getReq.AuthToken = readToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.Get", getReq, &getResp))
+	must.Eq(t, vol.ID, getResp.Volume.ID)
+
+	listReq := &structs.HostVolumeListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    srv.Region(),
+			Namespace: structs.AllNamespacesSentinel,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var listResp structs.HostVolumeListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.List", listReq, &listResp))
+	must.Len(t, 1, listResp.Volumes)
+
+	// Reading isn't enough to delete
+	delReq := &structs.HostVolumeDeleteRequest{
+		VolumeID: vol.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    srv.Region(),
+			Namespace: structs.DefaultNamespace,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var delResp structs.HostVolumeDeleteResponse
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Delete", delReq, &delResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	createReq := &structs.HostVolumeCreateRequest{
+		Volume: &structs.HostVolume{
+			Name:     "example",
+			PluginID: structs.HostVolumePluginMkdirID,
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    srv.Region(),
+			Namespace: structs.DefaultNamespace,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var createResp structs.HostVolumeCreateResponse
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Create", createReq, &createResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Volumes can't be created with an ID
+	createReq.AuthToken = root.SecretID
+	createReq.Volume.ID = vol.ID
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Create", createReq, &createResp)
+	must.ErrorContains(t, err, "volume ID must not be set")
+}
@@ -331,16 +331,13 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		}
 	}
 
-	// Submit a multiregion job to other regions (enterprise only).
-	// The job will have its region interpolated.
-	var newVersion uint64
-	if existingJob != nil {
-		newVersion = existingJob.Version + 1
-	}
-	isRunner, err := j.multiregionRegister(args, reply, newVersion)
+	// Interpolate a multiregion job for this region. The peer regions are
+	// registered once the job has been committed here.
+	globalJob, err := j.multiregionInterpolate(args)
 	if err != nil {
 		return err
 	}
+	isRunner := globalJob != nil
 
 	// Create a new evaluation
 	now := time.Now().UnixNano()
@@ -375,13 +372,19 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	}
 
 	// Check if the job has changed at all
-	specChanged, err := j.multiregionSpecChanged(existingJob, args)
-	if err != nil {
-		return err
+	var peerJobs map[string]*structs.Job
+	if isRunner {
+		peerJobs = j.multiregionPeerJobs(args)
 	}
+	specChanged := j.multiregionSpecChanged(existingJob, args, peerJobs)
 
 	if existingJob == nil || specChanged {
 
+		// Pin the version shared by every region of a multiregion job
+		if isRunner {
+			args.Job.Version = multiregionVersion(existingJob, peerJobs)
+		}
+
 		if eval != nil {
 			args.Eval = eval
 			submittedEval = true
@@ -405,6 +408,11 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 			reply.EvalCreateIndex = index
 		}
 
+		// Warm the images of the job on the nodes it may be placed on
+		if args.Job.Prefetch && !args.Job.Stopped() {
+			go j.srv.prefetchJobImages(args.Job.Copy())
+		}
+
 	} else {
 		reply.JobModifyIndex = existingJob.JobModifyIndex
 	}
@@ -412,6 +420,14 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	// used for multiregion start
 	args.Job.JobModifyIndex = reply.JobModifyIndex
 
+	// Register the job in the peer regions now that it has been committed
+	// here. Peers place no allocations until the rollout starts them.
+	if isRunner && (existingJob == nil || specChanged) {
+		if err := j.multiregionRegister(globalJob, args); err != nil {
+			return err
+		}
+	}
+
 	if eval == nil {
 		// For dispatch jobs we return early, so we need to drop regions
 		// here rather than after eval for deployments is kicked off
@@ -726,6 +742,59 @@ func (j *Job) Stable(args *structs.JobStabilityRequest, reply *structs.JobStabil
 	return nil
 }
 
+// TagVersion is used to attach a tag to a job version, or to remove a tag
+func (j *Job) TagVersion(args *structs.JobApplyTagRequest, reply *structs.JobTagResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.TagVersion", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "tag_version"}, time.Now())
+
+	// Check for submit-job permissions
+	if aclObj, err := j.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID for tagging job version")
+	}
+	if args.Name == "" {
+		return fmt.Errorf("missing tag name")
+	}
+
+	if args.Tag != nil {
+		args.Tag.Name = args.Name
+		args.Tag.TaggedTime = time.Now().UTC().UnixNano()
+		if err := args.Tag.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// Commit this tag request via Raft. The FSM checks that the version
+	// exists and that the tag isn't in use
+	_, index, err := j.srv.raftApply(structs.JobVersionTagRequestType, args)
+	if err != nil {
+		j.logger.Error("submitting job version tag request failed", "error", err)
+		return err
+	}
+
+	// Setup the reply
+	reply.Index = index
+	reply.Name = args.Name
+	if args.Tag != nil {
+		reply.Description = args.Tag.Description
+		reply.TaggedTime = args.Tag.TaggedTime
+	}
+	return nil
+}
+
 // Evaluate is used to force a job for re-evaluation
 func (j *Job) Evaluate(args *structs.JobEvaluateRequest, reply *structs.JobRegisterResponse) error {
 	authErr := j.srv.Authenticate(j.ctx, args)
@@ -1298,10 +1367,20 @@ func (j *Job) GetJobVersions(args *structs.JobVersionsRequest,
 			// Setup the output
 			reply.Versions = out
 			if len(out) != 0 {
-				reply.Index = out[0].ModifyIndex
+				// Older versions are modified when they're tagged, so use
+				// the highest index of any version
+				for _, v := range out {
+					reply.Index = max(reply.Index, v.ModifyIndex)
+				}
 
 				// Compute the diffs
-				if args.Diffs {
+				if args.Diffs && (args.DiffVersion != nil || args.DiffTagName != "") {
+					diffs, err := jobVersionDiffs(out, args.DiffVersion, args.DiffTagName)
+					if err != nil {
+						return err
+					}
+					reply.Diffs = diffs
+				} else if args.Diffs {
 					for i := 0; i < len(out)-1; i++ {
 						old, new := out[i+1], out[i]
 						d, err := old.Diff(new, true)
@@ -1327,6 +1406,42 @@ func (j *Job) GetJobVersions(args *structs.JobVersionsRequest,
 	return j.srv.blockingRPC(&opts)
 }
 
+// jobVersionDiffs diffs every job version against the version with the given
+// number or tag, so the diffs line up with the versions.
+func jobVersionDiffs(versions []*structs.Job, version *uint64, tagName string) ([]*structs.JobDiff, error) {
+	if version != nil && tagName != "" {
+		return nil, fmt.Errorf("only one of diff version and diff tag may be set")
+	}
+
+	var target *structs.Job
+	for _, v := range versions {
+		if version != nil && v.Version == *version {
+			target = v
+			break
+		}
+		if tagName != "" && v.VersionTag != nil && v.VersionTag.Name == tagName {
+			target = v
+			break
+		}
+	}
+	if target == nil {
+		if version != nil {
+			return nil, fmt.Errorf("job version %d not found", *version)
+		}
+		return nil, fmt.Errorf("job version tagged %q not found", tagName)
+	}
+
+	diffs := make([]*structs.JobDiff, 0, len(versions))
+	for _, v := range versions {
+		d, err := target.Diff(v, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create job diff: %v", err)
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs, nil
+}
+
 // allowedNSes returns a set (as map of ns->true) of the namespaces a token has access to.
 // Returns `nil` set if the token has access to all namespaces
 // and ErrPermissionDenied if the token has no capabilities on any namespace.
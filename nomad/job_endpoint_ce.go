@@ -7,52 +7,307 @@
 package nomad
 
 import (
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
-// enforceSubmitJob is used to check any Sentinel policies for the submit-job scope
-func (j *Job) enforceSubmitJob(override bool, job *structs.Job, existingJob *structs.Job, nomadACLToken *structs.ACLToken, ns *structs.Namespace) (error, error) {
-	return nil, nil
-}
-
 // multiregionCreateDeployment is used to create a deployment to register along
-// with the job, if required.
+// with the job, if required. Multiregion deployments start out initializing so
+// that no region places allocations until every region has accepted the job
+// and the rollout strategy starts it.
 func (j *Job) multiregionCreateDeployment(job *structs.Job, eval *structs.Evaluation) *structs.Deployment {
+	if eval == nil || !job.IsMultiregion() || job.Type != structs.JobTypeService {
+		return nil
+	}
+
+	hasUpdate := false
+	for _, tg := range job.TaskGroups {
+		if !tg.Update.IsEmpty() {
+			hasUpdate = true
+			break
+		}
+	}
+	if !hasUpdate {
+		return nil
+	}
+
+	d := structs.NewDeployment(job, eval.Priority)
+	d.Status = structs.DeploymentStatusInitializing
+	d.StatusDescription = structs.DeploymentStatusDescriptionPendingForPeer
+	return d
+}
+
+// multiregionInterpolate is used to interpolate a multiregion job for this
+// region. The job submitted by the user has the global region; a copy of it is
+// returned so that it can be interpolated for each peer region once the job has
+// been committed here. A nil job is returned if this server is not responsible
+// for registering the job in its peer regions and starting the deployment.
+func (j *Job) multiregionInterpolate(args *structs.JobRegisterRequest) (*structs.Job, error) {
+	if !args.Job.IsMultiregion() || args.Job.Region != structs.MultiregionGlobalRegion {
+		return nil, nil
+	}
+
+	localRegion := j.srv.Region()
+	if args.Job.Multiregion.Region(localRegion) == nil {
+		return nil, fmt.Errorf("multiregion job must be registered in one of its regions, not %q", localRegion)
+	}
+
+	globalJob := args.Job.Copy()
+	if err := args.Job.InterpolateRegion(localRegion); err != nil {
+		return nil, err
+	}
+	return globalJob, nil
+}
+
+// multiregionRegister is used to send a job across multiple regions once it
+// has been committed in this region. The global job is interpolated for each
+// peer region and registered there with the version of the local job, so that
+// every region runs the same job version. Registration stops at the first
+// region that fails; registering the job again completes it, as regions that
+// are missing the job are always registered.
+func (j *Job) multiregionRegister(globalJob *structs.Job, args *structs.JobRegisterRequest) error {
+	localRegion := j.srv.Region()
+	for _, region := range globalJob.Multiregion.Regions {
+		if region.Name == localRegion {
+			continue
+		}
+
+		job := globalJob.Copy()
+		if err := job.InterpolateRegion(region.Name); err != nil {
+			return err
+		}
+		job.Version = args.Job.Version
+
+		peerArgs := &structs.JobRegisterRequest{
+			Submission:     args.Submission,
+			Job:            job,
+			PreserveCounts: args.PreserveCounts,
+			PolicyOverride: args.PolicyOverride,
+			EvalPriority:   args.EvalPriority,
+			WriteRequest: structs.WriteRequest{
+				Region:    region.Name,
+				Namespace: args.RequestNamespace(),
+				AuthToken: args.AuthToken,
+			},
+		}
+		var peerReply structs.JobRegisterResponse
+		if err := j.srv.RPC("Job.Register", peerArgs, &peerReply); err != nil {
+			j.logger.Error("multiregion job registration failed",
+				"job", job.ID, "region", region.Name, "error", err)
+			return fmt.Errorf("job registered in region %q but failed to register in region %q: %w",
+				localRegion, region.Name, err)
+		}
+		j.logger.Debug("registered multiregion job",
+			"job", job.ID, "region", region.Name, "version", job.Version)
+	}
 	return nil
 }
 
-// multiregionRegister is used to send a job across multiple regions
-func (j *Job) multiregionRegister(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse, newVersion uint64) (bool, error) {
-	return false, nil
+// multiregionPeerJobs returns the job registered in each peer region of a
+// multiregion job, keyed by region. Regions that could not be reached or that
+// don't have the job are mapped to nil.
+func (j *Job) multiregionPeerJobs(args *structs.JobRegisterRequest) map[string]*structs.Job {
+	peerJobs := make(map[string]*structs.Job, len(args.Job.Multiregion.Regions))
+	for _, region := range args.Job.Multiregion.Regions {
+		if region.Name == j.srv.Region() {
+			continue
+		}
+
+		var peerReply structs.SingleJobResponse
+		err := j.srv.RPC("Job.GetJob", &structs.JobSpecificRequest{
+			JobID: args.Job.ID,
+			QueryOptions: structs.QueryOptions{
+				Region:    region.Name,
+				Namespace: args.RequestNamespace(),
+				AuthToken: args.AuthToken,
+			},
+		}, &peerReply)
+		if err != nil {
+			j.logger.Warn("failed to lookup multiregion job",
+				"job", args.Job.ID, "region", region.Name, "error", err)
+		}
+		peerJobs[region.Name] = peerReply.Job
+	}
+	return peerJobs
 }
 
-// multiregionStart is used to kick-off a deployment across multiple regions
+// multiregionVersion returns the version to register a multiregion job with
+// in every region: the next version of the job in whichever region is furthest
+// ahead. Peers keep the version they are sent, since the state store only bumps
+// the version of a job that doesn't move it forward.
+func multiregionVersion(existingJob *structs.Job, peerJobs map[string]*structs.Job) uint64 {
+	var version uint64
+	if existingJob != nil {
+		version = existingJob.Version + 1
+	}
+	for _, peerJob := range peerJobs {
+		if peerJob != nil && peerJob.Version+1 > version {
+			version = peerJob.Version + 1
+		}
+	}
+	return version
+}
+
+// multiregionStart is used to kick-off a deployment across multiple regions.
+// The first max_parallel regions are started; every other region is started
+// by the deployment watchers as earlier regions complete. Regions that are
+// still initializing start themselves once they become pending.
 func (j *Job) multiregionStart(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) error {
+	job := args.Job
+	if !job.IsMultiregion() {
+		return nil
+	}
+
+	regions := job.Multiregion.Regions
+	if strategy := job.Multiregion.Strategy; strategy != nil &&
+		strategy.MaxParallel > 0 && strategy.MaxParallel < len(regions) {
+		regions = regions[:strategy.MaxParallel]
+	}
+
+	for _, region := range regions {
+		write := structs.WriteRequest{
+			Region:    region.Name,
+			Namespace: args.RequestNamespace(),
+			AuthToken: args.AuthToken,
+		}
+
+		var deployReply structs.SingleDeploymentResponse
+		err := j.srv.RPC("Job.LatestDeployment", &structs.JobSpecificRequest{
+			JobID: job.ID,
+			QueryOptions: structs.QueryOptions{
+				Region:    write.Region,
+				Namespace: write.Namespace,
+				AuthToken: write.AuthToken,
+			},
+		}, &deployReply)
+		if err != nil {
+			j.logger.Warn("failed to lookup multiregion deployment",
+				"job", job.ID, "region", region.Name, "error", err)
+			continue
+		}
+
+		d := deployReply.Deployment
+		if d == nil || d.Status != structs.DeploymentStatusPending {
+			continue
+		}
+
+		var runReply structs.DeploymentUpdateResponse
+		err = j.srv.RPC("Deployment.Run", &structs.DeploymentRunRequest{
+			DeploymentID: d.ID,
+			WriteRequest: write,
+		}, &runReply)
+		if err != nil {
+			j.logger.Warn("failed to start multiregion deployment",
+				"job", job.ID, "region", region.Name, "error", err)
+		}
+	}
 	return nil
 }
 
 // multiregionDrop is used to deregister regions from a previous version of the
-// job that are no longer in use
+// job that are no longer in use. Only the first region of the job drops
+// regions so that the peers don't all race to do the same.
 func (j *Job) multiregionDrop(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) error {
+	job := args.Job
+	if !job.IsMultiregion() || job.Multiregion.Regions[0].Name != j.srv.Region() {
+		return nil
+	}
+
+	versions, err := j.srv.State().JobVersionsByID(nil, args.RequestNamespace(), job.ID)
+	if err != nil {
+		return err
+	}
+	if len(versions) < 2 || !versions[1].IsMultiregion() {
+		return nil
+	}
+
+	for _, region := range versions[1].Multiregion.Regions {
+		if job.Multiregion.Region(region.Name) != nil {
+			continue
+		}
+
+		dropArgs := &structs.JobDeregisterRequest{
+			JobID: job.ID,
+			WriteRequest: structs.WriteRequest{
+				Region:    region.Name,
+				Namespace: args.RequestNamespace(),
+				AuthToken: args.AuthToken,
+			},
+		}
+		var dropReply structs.JobDeregisterResponse
+		if err := j.srv.RPC("Job.Deregister", dropArgs, &dropReply); err != nil {
+			j.logger.Warn("failed to drop multiregion job from region",
+				"job", job.ID, "region", region.Name, "error", err)
+		}
+	}
 	return nil
 }
 
 // multiregionStop is used to fan-out Job.Deregister RPCs to all regions if
 // the global flag is passed to Job.Deregister
 func (j *Job) multiregionStop(job *structs.Job, args *structs.JobDeregisterRequest, reply *structs.JobDeregisterResponse) error {
-	return nil
+	if job == nil || !job.IsMultiregion() || !args.Global {
+		return nil
+	}
+
+	var mErr multierror.Error
+	for _, region := range job.Multiregion.Regions {
+		if region.Name == j.srv.Region() {
+			continue
+		}
+
+		peerArgs := &structs.JobDeregisterRequest{
+			JobID:           args.JobID,
+			Purge:           args.Purge,
+			EvalPriority:    args.EvalPriority,
+			NoShutdownDelay: args.NoShutdownDelay,
+			WriteRequest: structs.WriteRequest{
+				Region:    region.Name,
+				Namespace: args.RequestNamespace(),
+				AuthToken: args.AuthToken,
+			},
+		}
+		var peerReply structs.JobDeregisterResponse
+		if err := j.srv.RPC("Job.Deregister", peerArgs, &peerReply); err != nil {
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("failed to deregister job in region %q: %w", region.Name, err))
+		}
+	}
+	return mErr.ErrorOrNil()
 }
 
 // interpolateMultiregionFields interpolates a job for a specific region
 func (j *Job) interpolateMultiregionFields(args *structs.JobPlanRequest) error {
-	return nil
+	if !args.Job.IsMultiregion() || args.Job.Region != structs.MultiregionGlobalRegion {
+		return nil
+	}
+	return args.Job.InterpolateRegion(j.srv.Region())
 }
 
-// multiregionSpecChanged checks to see if the job spec has changed. If the job is multiregion,
-// it checks all regions to determine if any deployed jobs instances have been stopped or
-// otherwise differ from the incoming jobspec. Since multiregion jobs require coordinated
-// deployments and synchronized job versions across all regions, a change in one requires
-// redeployment of all.
-func (j *Job) multiregionSpecChanged(existingJob *structs.Job, args *structs.JobRegisterRequest) (bool, error) {
-	return existingJob.SpecChanged(args.Job), nil
+// multiregionSpecChanged checks to see if the job spec has changed. If the job
+// is multiregion, a change in any region requires redeployment of all of them,
+// since multiregion jobs require coordinated deployments and synchronized job
+// versions across all regions. The region registering the job in its peers
+// treats a peer as changed if the job is missing, stopped or at a different
+// version there. Peers treat a newer version as a change so that they follow
+// the version of the registering region.
+func (j *Job) multiregionSpecChanged(existingJob *structs.Job, args *structs.JobRegisterRequest, peerJobs map[string]*structs.Job) bool {
+	if existingJob == nil || existingJob.SpecChanged(args.Job) {
+		return true
+	}
+	if !args.Job.IsMultiregion() {
+		return false
+	}
+	if peerJobs == nil {
+		return existingJob.Version < args.Job.Version
+	}
+
+	for _, peerJob := range peerJobs {
+		if peerJob == nil || peerJob.Stop || peerJob.Version != existingJob.Version {
+			return true
+		}
+	}
+	return false
 }
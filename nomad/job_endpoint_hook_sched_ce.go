@@ -6,18 +6,25 @@
 package nomad
 
 import (
-	"errors"
+	"fmt"
 
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
+// Validate ensures the schedule of each task can be parsed by the clients.
 func (jobSchedHook) Validate(job *structs.Job) ([]error, error) {
+	var mErr *multierror.Error
 	for _, tg := range job.TaskGroups {
 		for _, task := range tg.Tasks {
-			if task.Schedule != nil {
-				return nil, errors.New("task schedules requires Nomad Enterprise")
+			if task.Schedule == nil {
+				continue
+			}
+			if err := task.Schedule.Validate(); err != nil {
+				mErr = multierror.Append(mErr,
+					fmt.Errorf("task %q in group %q has an invalid schedule: %w", task.Name, tg.Name, err))
 			}
 		}
 	}
-	return nil, nil
+	return nil, mErr.ErrorOrNil()
 }
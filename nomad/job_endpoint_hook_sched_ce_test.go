@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !ent
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func Test_jobSchedHook_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Tasks[0].Schedule = &structs.TaskSchedule{
+		Cron: &structs.TaskScheduleCron{
+			Start: "30 9 * * MON-FRI *",
+			End:   "0 16",
+		},
+	}
+
+	hook := jobSchedHook{}
+	warnings, err := hook.Validate(job)
+	must.SliceEmpty(t, warnings)
+	must.NoError(t, err)
+
+	// schedules must have a cron block
+	job.TaskGroups[0].Tasks[0].Schedule.Cron = nil
+	_, err = hook.Validate(job)
+	must.ErrorContains(t, err, `task "web" in group "web" has an invalid schedule: must specify cron block`)
+}
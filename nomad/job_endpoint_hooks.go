@@ -26,6 +26,7 @@ const (
 	attrHostLocalCNI      = `${attr.plugins.cni.version.host-local}`
 	attrLoopbackCNI       = `${attr.plugins.cni.version.loopback}`
 	attrPortMapCNI        = `${attr.plugins.cni.version.portmap}`
+	attrBandwidthCNI      = `${attr.plugins.cni.version.bandwidth}`
 	attrConsulCNI         = `${attr.plugins.cni.version.consul-cni}`
 )
 
@@ -136,6 +137,15 @@ var (
 		Operand: structs.ConstraintSemver,
 	}
 
+	// cniBandwidthConstraint is an implicit constraint added to jobs making
+	// use of bridge networking mode and limiting the egress bandwidth of their
+	// tasks, which is shaped by the bandwidth CNI plugin.
+	cniBandwidthConstraint = &structs.Constraint{
+		LTarget: attrBandwidthCNI,
+		RTarget: cniMinVersion,
+		Operand: structs.ConstraintSemver,
+	}
+
 	// cniConsulConstraint is an implicit constraint added to jobs making use of
 	// transparent proxy mode.
 	cniConsulConstraint = &structs.Constraint{
@@ -153,7 +163,7 @@ var (
 	}
 
 	// taskScheduleConstraint is an implicit constraint added to jobs that have
-	// tasks with a schedule{} block for time based task execution (Enterprise)
+	// tasks with a schedule{} block for time based task execution
 	taskScheduleConstraint = &structs.Constraint{
 		LTarget: attrNomadVersion,
 		RTarget: ">= 1.8.0-dev",
@@ -275,6 +285,8 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 
 	bridgeNetworkingTaskGroups := j.RequiredBridgeNetwork()
 
+	bridgeEgressLimitTaskGroups := j.RequiredBridgeEgressLimit()
+
 	transparentProxyTaskGroups := j.RequiredTransparentProxy()
 
 	taskScheduleTaskGroups := j.RequiredScheduleTask()
@@ -285,6 +297,7 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 	if len(signals) == 0 && len(vaultBlocks) == 0 &&
 		nativeServiceDisco.Empty() && len(consulServiceDisco) == 0 &&
 		numaTaskGroups.Empty() && bridgeNetworkingTaskGroups.Empty() &&
+		bridgeEgressLimitTaskGroups.Empty() &&
 		transparentProxyTaskGroups.Empty() &&
 		taskScheduleTaskGroups.Empty() {
 		return j, nil, nil
@@ -352,6 +365,10 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 			mutateConstraint(constraintMatcherLeft, tg, cniPortMapConstraint)
 		}
 
+		if bridgeEgressLimitTaskGroups.Contains(tg.Name) {
+			mutateConstraint(constraintMatcherLeft, tg, cniBandwidthConstraint)
+		}
+
 		if transparentProxyTaskGroups.Contains(tg.Name) {
 			mutateConstraint(constraintMatcherLeft, tg, cniConsulConstraint)
 			mutateConstraint(constraintMatcherLeft, tg, tproxyConstraint)
@@ -1236,6 +1236,55 @@ func Test_jobImpliedConstraints_Mutate(t *testing.T) {
 			expectedOutputError:    nil,
 			name:                   "task group with bridge network",
 		},
+		{
+			inputJob: &structs.Job{
+				Name: "example",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group-with-egress-limit",
+						Networks: []*structs.NetworkResource{
+							{Mode: "bridge"},
+						},
+						Tasks: []*structs.Task{
+							{
+								Resources: &structs.Resources{
+									NetworkEgressMBits: 100,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedOutputJob: &structs.Job{
+				Name: "example",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group-with-egress-limit",
+						Networks: []*structs.NetworkResource{
+							{Mode: "bridge"},
+						},
+						Tasks: []*structs.Task{
+							{
+								Resources: &structs.Resources{
+									NetworkEgressMBits: 100,
+								},
+							},
+						},
+						Constraints: []*structs.Constraint{
+							cniBridgeConstraint,
+							cniFirewallConstraint,
+							cniHostLocalConstraint,
+							cniLoopbackConstraint,
+							cniPortMapConstraint,
+							cniBandwidthConstraint,
+						},
+					},
+				},
+			},
+			expectedOutputWarnings: nil,
+			expectedOutputError:    nil,
+			name:                   "task group with bridge network egress limit",
+		},
 		{
 			inputJob: &structs.Job{
 				Name: "example",
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !ent
+// +build !ent
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+// testMultiregionServers returns a pair of joined servers in region1 and
+// region2.
+func testMultiregionServers(t *testing.T) (*Server, *Server) {
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.Region = "region1"
+	})
+	t.Cleanup(cleanupS1)
+	s2, cleanupS2 := TestServer(t, func(c *Config) {
+		c.Region = "region2"
+	})
+	t.Cleanup(cleanupS2)
+
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+	return s1, s2
+}
+
+// testMultiregionJob returns a multiregion job for the given regions.
+func testMultiregionJob(regions ...string) *structs.Job {
+	job := mock.MultiregionJob()
+	job.Multiregion.Regions = nil
+	for _, region := range regions {
+		job.Multiregion.Regions = append(job.Multiregion.Regions, &structs.MultiregionRegion{
+			Name:        region,
+			Count:       1,
+			Datacenters: []string{"dc1"},
+		})
+	}
+	return job
+}
+
+func TestJobEndpoint_Register_Multiregion_PeerFailure(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, s2 := testMultiregionServers(t)
+	codec := rpcClient(t, s1)
+
+	// region3 doesn't exist, so registration fails after the job has been
+	// committed in region1 and region2.
+	job := testMultiregionJob("region1", "region2", "region3")
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "region1",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	must.ErrorContains(t, err, `failed to register in region "region3"`)
+
+	local, err := s1.State().JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, local)
+	must.Eq(t, "region1", local.Region)
+
+	peer, err := s2.State().JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, peer)
+	must.Eq(t, "region2", peer.Region)
+	must.Eq(t, local.Version, peer.Version)
+
+	// Registering the job again without the missing region completes the
+	// registration, with every region on the same new version.
+	job = testMultiregionJob("region1", "region2")
+	job.ID = local.ID
+	req.Job = job
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	local, err = s1.State().JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	peer, err = s2.State().JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, uint64(1), local.Version)
+	must.Eq(t, local.Version, peer.Version)
+}
+
+func TestJobEndpoint_Register_Multiregion_VersionSync(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, s2 := testMultiregionServers(t)
+	codec := rpcClient(t, s1)
+
+	// The job is already further ahead in region2 than in region1.
+	job := testMultiregionJob("region1", "region2")
+	peerJob := job.Copy()
+	must.NoError(t, peerJob.InterpolateRegion("region2"))
+	peerJob.Version = 5
+	must.NoError(t, s2.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, peerJob))
+
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "region1",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	local, err := s1.State().JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	peer, err := s2.State().JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, uint64(6), local.Version)
+	must.Eq(t, uint64(6), peer.Version)
+
+	// The deployments of both regions are for the same job version.
+	localDeploy, err := s1.State().LatestDeploymentByJobID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, localDeploy)
+	peerDeploy, err := s2.State().LatestDeploymentByJobID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, peerDeploy)
+	must.Eq(t, uint64(6), localDeploy.JobVersion)
+	must.Eq(t, uint64(6), peerDeploy.JobVersion)
+}
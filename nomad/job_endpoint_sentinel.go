@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/sentinel"
+)
+
+// sentinelPolicyCacheSize is the number of compiled Sentinel policies cached
+// by each server.
+const sentinelPolicyCacheSize = 128
+
+// enforceSubmitJob is used to check any Sentinel policies for the submit-job
+// scope. Failures of advisory policies, and of soft-mandatory policies when
+// overridden, are returned as warnings. Any other failure is returned as an
+// error.
+func (j *Job) enforceSubmitJob(override bool, job *structs.Job, existingJob *structs.Job, nomadACLToken *structs.ACLToken, ns *structs.Namespace) (error, error) {
+	snap, err := j.srv.fsm.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	iter, err := snap.SentinelPoliciesByScope(nil, structs.SentinelScopeSubmitJob)
+	if err != nil {
+		return nil, err
+	}
+
+	// Never expose the secret of the submitter to policies
+	if nomadACLToken != nil {
+		nomadACLToken = nomadACLToken.Copy()
+		nomadACLToken.SecretID = ""
+	}
+	data := map[string]any{
+		sentinel.VarJob:         job,
+		sentinel.VarExistingJob: existingJob,
+		sentinel.VarNamespace:   ns,
+		sentinel.VarACLToken:    nomadACLToken,
+	}
+
+	var warnings, failures multierror.Error
+	softFailed := false
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		policy := raw.(*structs.SentinelPolicy)
+		if !policy.AppliesToNamespace(job.Namespace) {
+			continue
+		}
+
+		compiled, err := j.srv.sentinelPolicies.Compile(policy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile Sentinel policy %q: %v", policy.Name, err)
+		}
+		results, err := compiled.Eval(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate Sentinel policy %q: %v", policy.Name, err)
+		}
+		if len(results) == 0 {
+			continue
+		}
+
+		reasons := make([]string, 0, len(results))
+		for _, result := range results {
+			reasons = append(reasons, result.String())
+		}
+		err = fmt.Errorf("%s policy %q failed: %s",
+			policy.EnforcementLevel, policy.Name, strings.Join(reasons, "; "))
+
+		switch policy.EnforcementLevel {
+		case structs.SentinelEnforcementLevelAdvisory:
+			_ = multierror.Append(&warnings, err)
+		case structs.SentinelEnforcementLevelSoftMandatory:
+			if override {
+				_ = multierror.Append(&warnings, err)
+			} else {
+				softFailed = true
+				_ = multierror.Append(&failures, err)
+			}
+		default:
+			_ = multierror.Append(&failures, err)
+		}
+	}
+
+	if err := failures.ErrorOrNil(); err != nil {
+		if softFailed {
+			return nil, fmt.Errorf("%w\nSoft-mandatory policies can be overridden with -policy-override", err)
+		}
+		return nil, err
+	}
+	return warnings.ErrorOrNil(), nil
+}
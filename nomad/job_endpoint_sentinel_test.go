@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobEndpoint_Register_Sentinel(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	store := s1.fsm.State()
+
+	newPolicy := func(level string) *structs.SentinelPolicy {
+		policy := mock.SentinelPolicy()
+		policy.EnforcementLevel = level
+		policy.Policy = `
+rule "priority" {
+  description = "priority must be at most 80"
+  condition   = job.Priority <= 80
+}
+`
+		policy.SetHash()
+		return policy
+	}
+	register := func(job *structs.Job, override bool) (*structs.JobRegisterResponse, error) {
+		req := &structs.JobRegisterRequest{
+			Job:            job,
+			PolicyOverride: override,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+		return &resp, err
+	}
+
+	job := mock.Job()
+	job.Priority = 90
+
+	// Advisory policies only warn
+	advisory := newPolicy(structs.SentinelEnforcementLevelAdvisory)
+	must.NoError(t, store.UpsertSentinelPolicies(structs.MsgTypeTestSetup, 1000, []*structs.SentinelPolicy{advisory}))
+	resp, err := register(job, false)
+	must.NoError(t, err)
+	must.StrContains(t, resp.Warnings, "priority must be at most 80")
+
+	// Soft-mandatory policies fail unless overridden
+	soft := newPolicy(structs.SentinelEnforcementLevelSoftMandatory)
+	must.NoError(t, store.UpsertSentinelPolicies(structs.MsgTypeTestSetup, 1001, []*structs.SentinelPolicy{soft}))
+	_, err = register(job, false)
+	must.ErrorContains(t, err, "soft-mandatory policy")
+	must.ErrorContains(t, err, "-policy-override")
+
+	resp, err = register(job, true)
+	must.NoError(t, err)
+	must.StrContains(t, resp.Warnings, soft.Name)
+
+	// Hard-mandatory policies can't be overridden
+	hard := newPolicy(structs.SentinelEnforcementLevelHardMandatory)
+	must.NoError(t, store.UpsertSentinelPolicies(structs.MsgTypeTestSetup, 1002, []*structs.SentinelPolicy{hard}))
+	_, err = register(job, true)
+	must.ErrorContains(t, err, "hard-mandatory policy")
+
+	// Policies scoped to other namespaces are not enforced
+	hard = hard.Copy()
+	hard.Namespaces = []string{"other"}
+	hard.SetHash()
+	must.NoError(t, store.UpsertSentinelPolicies(structs.MsgTypeTestSetup, 1003, []*structs.SentinelPolicy{hard}))
+	_, err = register(job, true)
+	must.NoError(t, err)
+
+	// Jobs passing the policies are registered without policy warnings
+	job = job.Copy()
+	job.Priority = 50
+	resp, err = register(job, false)
+	must.NoError(t, err)
+	must.StrNotContains(t, resp.Warnings, "policy")
+}
+
+func TestJobEndpoint_Register_Sentinel_NewJob(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	policy := mock.SentinelPolicy()
+	policy.EnforcementLevel = structs.SentinelEnforcementLevelHardMandatory
+	policy.Policy = `
+rule "keep-priority" {
+  description = "the priority of existing jobs can't change"
+  condition   = existing_job == null || existing_job.Priority == job.Priority
+}
+`
+	policy.SetHash()
+	must.NoError(t, s1.fsm.State().UpsertSentinelPolicies(
+		structs.MsgTypeTestSetup, 1000, []*structs.SentinelPolicy{policy}))
+
+	register := func(job *structs.Job) error {
+		req := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		return msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	}
+
+	// New jobs pass rules guarding against a null existing_job
+	job := mock.Job()
+	must.NoError(t, register(job))
+
+	// Updates of the job are checked against the existing job
+	job = job.Copy()
+	job.Priority++
+	must.ErrorContains(t, register(job), "the priority of existing jobs can't change")
+}
+
+func TestJobEndpoint_Plan_Sentinel(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	policy := mock.SentinelPolicy()
+	policy.Policy = `
+rule "no-raw-exec" {
+  description = "raw_exec tasks are not allowed"
+  condition   = alltrue([for t in flatten(job.TaskGroups[*].Tasks) : t.Driver != "raw_exec"])
+}
+`
+	policy.SetHash()
+	must.NoError(t, s1.fsm.State().UpsertSentinelPolicies(
+		structs.MsgTypeTestSetup, 1000, []*structs.SentinelPolicy{policy}))
+
+	job := mock.Job()
+	job.TaskGroups[0].Tasks[0].Driver = "raw_exec"
+	planReq := &structs.JobPlanRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	var planResp structs.JobPlanResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Plan", planReq, &planResp))
+	must.StrContains(t, planResp.Warnings, `advisory policy "`+policy.Name+`" failed`)
+	must.StrContains(t, planResp.Warnings, "raw_exec tasks are not allowed")
+}
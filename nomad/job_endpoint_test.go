@@ -3157,6 +3157,113 @@ func TestJobEndpoint_Stable_ACL(t *testing.T) {
 	require.Equal(true, out.Stable)
 }
 
+func TestJobEndpoint_TagVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Register the job twice to get two versions
+	job := mock.Job()
+	for i := 0; i < 2; i++ {
+		req := &structs.JobRegisterRequest{
+			Job: job.Copy(),
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+				AuthToken: root.SecretID,
+			},
+		}
+		req.Job.Meta = map[string]string{"version": fmt.Sprint(i)}
+		var resp structs.JobRegisterResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	}
+
+	tagReq := &structs.JobApplyTagRequest{
+		JobID:   job.ID,
+		Name:    "release-42",
+		Version: 0,
+		Tag:     &structs.JobVersionTag{Description: "known good"},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var tagResp structs.JobTagResponse
+
+	// Tagging requires submit-job
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "read-job",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	tagReq.AuthToken = readToken.SecretID
+	err := msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	tagReq.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp))
+	must.Eq(t, "release-42", tagResp.Name)
+	must.Eq(t, "known good", tagResp.Description)
+	must.Positive(t, tagResp.TaggedTime)
+
+	out, err := state.JobByIDAndVersion(nil, job.Namespace, job.ID, 0)
+	must.NoError(t, err)
+	must.Eq(t, "release-42", out.VersionTag.Name)
+
+	// Tags are immutable
+	tagReq.Tag = &structs.JobVersionTag{}
+	err = msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp)
+	must.ErrorContains(t, err, "already tagged")
+
+	// Numeric tag names would be ambiguous with versions
+	tagReq.Name = "1"
+	tagReq.Version = 1
+	tagReq.Tag = &structs.JobVersionTag{}
+	err = msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp)
+	must.ErrorContains(t, err, "must not be a number")
+
+	// Versions can be diffed against the tagged version
+	versionsReq := &structs.JobVersionsRequest{
+		JobID:       job.ID,
+		Diffs:       true,
+		DiffTagName: "release-42",
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: root.SecretID,
+		},
+	}
+	var versionsResp structs.JobVersionsResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.GetJobVersions", versionsReq, &versionsResp))
+	must.Len(t, 2, versionsResp.Versions)
+	must.Len(t, 2, versionsResp.Diffs)
+	must.Eq(t, structs.DiffTypeEdited, versionsResp.Diffs[0].Type)
+	must.Eq(t, structs.DiffTypeNone, versionsResp.Diffs[1].Type)
+
+	versionsReq.DiffTagName = "missing"
+	err = msgpackrpc.CallWithCodec(codec, "Job.GetJobVersions", versionsReq, &versionsResp)
+	must.ErrorContains(t, err, "not found")
+
+	// Unset the tag
+	untagReq := &structs.JobApplyTagRequest{
+		JobID: job.ID,
+		Name:  "release-42",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: root.SecretID,
+		},
+	}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.TagVersion", untagReq, &tagResp))
+
+	out, err = state.JobByIDAndVersion(nil, job.Namespace, job.ID, 0)
+	must.NoError(t, err)
+	must.Nil(t, out.VersionTag)
+}
+
 func TestJobEndpoint_Evaluate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"slices"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// prefetchJobImages asks the nodes the job may be placed on to pull the images
+// of its tasks, so that its allocations start without waiting for the pull.
+// Nodes are eligible when they are ready, in one of the datacenters and the
+// node pool of the job, and the task driver is healthy. Constraints are not
+// evaluated, so nodes failing them may pull images they never use.
+func (s *Server) prefetchJobImages(job *structs.Job) {
+	images := jobImagesByDriver(job)
+	if len(images) == 0 {
+		return
+	}
+
+	snap, err := s.fsm.State().Snapshot()
+	if err != nil {
+		s.logger.Error("failed to prefetch job images", "error", err)
+		return
+	}
+	nodes, err := prefetchNodes(snap, job)
+	if err != nil {
+		s.logger.Error("failed to prefetch job images", "error", err)
+		return
+	}
+
+	for _, node := range nodes {
+		for driver, driverImages := range images {
+			info := node.Drivers[driver]
+			if info == nil || !info.Detected || !info.Healthy {
+				continue
+			}
+
+			// Skip the images the node already has
+			missing := slices.DeleteFunc(slices.Clone(driverImages), func(image string) bool {
+				_, ok := node.Attributes[structs.ImageAttribute(driver, image)]
+				return ok
+			})
+			if len(missing) == 0 {
+				continue
+			}
+
+			args := &structs.NodeImagePrefetchRequest{
+				NodeID: node.ID,
+				Driver: driver,
+				Images: missing,
+				QueryOptions: structs.QueryOptions{
+					Region:     s.Region(),
+					AllowStale: true,
+				},
+			}
+			var reply structs.NodeImagePrefetchResponse
+			if err := s.forwardClientRPC("NodeImages.Warm", node.ID, args, &reply); err != nil {
+				s.logger.Warn("failed to prefetch job images",
+					"job_id", job.ID, "namespace", job.Namespace, "node_id", node.ID, "error", err)
+				continue
+			}
+			s.logger.Debug("prefetching job images",
+				"job_id", job.ID, "namespace", job.Namespace, "node_id", node.ID, "images", reply.Images)
+		}
+	}
+}
+
+// jobImagesByDriver returns the images used by the tasks of the job, indexed
+// by task driver.
+func jobImagesByDriver(job *structs.Job) map[string][]string {
+	images := make(map[string][]string)
+	for _, tg := range job.TaskGroups {
+		for _, task := range tg.Tasks {
+			image := task.Image()
+			if image == "" || slices.Contains(images[task.Driver], image) {
+				continue
+			}
+			images[task.Driver] = append(images[task.Driver], image)
+		}
+	}
+	return images
+}
+
+// prefetchNodes returns the ready nodes in the datacenters and node pool of
+// the job.
+func prefetchNodes(snap *state.StateSnapshot, job *structs.Job) ([]*structs.Node, error) {
+	var iter memdb.ResultIterator
+	var err error
+	if job.NodePool == structs.NodePoolAll || job.NodePool == "" {
+		iter, err = snap.Nodes(nil)
+	} else {
+		iter, err = snap.NodesByNodePool(nil, job.NodePool)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []*structs.Node
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if node.Ready() && node.IsInAnyDC(job.Datacenters) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobPrefetch_jobImagesByDriver(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	web := job.TaskGroups[0].Tasks[0]
+	web.Driver = "docker"
+	web.Config = map[string]interface{}{"image": "redis:7"}
+
+	sidecar := web.Copy()
+	sidecar.Name = "sidecar"
+	sidecar.Config = map[string]interface{}{"image": "envoy:${NOMAD_envoy_version}"}
+
+	again := web.Copy()
+	again.Name = "again"
+
+	job.TaskGroups[0].Tasks = append(job.TaskGroups[0].Tasks, sidecar, again)
+
+	images := jobImagesByDriver(job)
+	must.Eq(t, map[string][]string{"docker": {"redis:7"}}, images)
+}
+
+func TestJobPrefetch_prefetchNodes(t *testing.T) {
+	ci.Parallel(t)
+	store := state.TestStateStore(t)
+
+	pool := mock.NodePool()
+	must.NoError(t, store.UpsertNodePools(structs.MsgTypeTestSetup, 999, []*structs.NodePool{pool}))
+
+	ready := mock.Node()
+	ready.NodePool = pool.Name
+
+	otherDC := mock.Node()
+	otherDC.NodePool = pool.Name
+	otherDC.Datacenter = "dc2"
+
+	otherPool := mock.Node()
+
+	ineligible := mock.Node()
+	ineligible.NodePool = pool.Name
+	ineligible.SchedulingEligibility = structs.NodeSchedulingIneligible
+
+	for i, node := range []*structs.Node{ready, otherDC, otherPool, ineligible} {
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(1000+i), node))
+	}
+
+	job := mock.Job()
+	job.NodePool = pool.Name
+	job.Datacenters = []string{"dc1"}
+
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	nodes, err := prefetchNodes(snap, job)
+	must.NoError(t, err)
+	must.Len(t, 1, nodes)
+	must.Eq(t, ready.ID, nodes[0].ID)
+
+	job.NodePool = structs.NodePoolAll
+	nodes, err = prefetchNodes(snap, job)
+	must.NoError(t, err)
+	must.Len(t, 2, nodes)
+}
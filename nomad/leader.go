@@ -79,6 +79,23 @@ var minNomadServiceRegistrationVersion = version.Must(version.NewVersion("1.3.0"
 // prevent older versions of the server from crashing.
 var minNodePoolsVersion = version.Must(version.NewVersion("1.6.0"))
 
+// minQuotaVersion is the Nomad version at which community servers can apply
+// the quota specification and usage raft messages. Quotas can't be written or
+// replicated until all servers meet it.
+var minQuotaVersion = version.Must(version.NewVersion("1.9.0"))
+
+// minSentinelPolicyVersion is the first Nomad version whose community servers
+// apply the Sentinel policy raft messages. Older servers would fail to apply
+// them, so policies are neither written nor replicated before all servers are
+// upgraded.
+var minSentinelPolicyVersion = version.Must(version.NewVersion("1.9.0"))
+
+// minRecommendationVersion is the first Nomad version whose community servers
+// apply the recommendation raft messages. Older servers can't apply them, so
+// recommendations are neither written nor dismissed before all servers are
+// upgraded.
+var minRecommendationVersion = version.Must(version.NewVersion("1.9.0"))
+
 // minVersionMultiIdentities is the Nomad version at which users can add
 // multiple identity blocks to tasks and workload identities can be
 // automatically added to jobs that need access to Consul or Vault
@@ -397,6 +414,9 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Enable the volume watcher, since we are now the leader
 	s.volumeWatcher.SetEnabled(true, s.State(), s.getLeaderAcl())
 
+	// Enable the recommender, since we are now the leader
+	s.recommender.SetEnabled(true)
+
 	// Restore the eval broker state and blocked eval state. If these are
 	// currently paused, we do not need to do this.
 	if restoreEvals {
@@ -448,6 +468,9 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Periodically publish job status metrics
 	go s.publishJobStatusMetrics(stopCh)
 
+	// Periodically compute recommendations from reported task usage
+	go s.recommender.run(stopCh)
+
 	// Populate the variable lock TTL timers, so we can start tracking renewals
 	// and expirations.
 	if err := s.restoreLockTTLTimers(); err != nil {
@@ -492,6 +515,8 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 			go s.replicateACLBindingRules(stopCh)
 			go s.replicateNamespaces(stopCh)
 			go s.replicateNodePools(stopCh)
+			go s.replicateQuotaSpecs(stopCh)
+			go s.replicateSentinelPolicies(stopCh)
 		}
 	}
 
@@ -803,6 +828,286 @@ func diffNodePools(store *state.StateStore, minIndex uint64, remoteList []*struc
 	return
 }
 
+// replicateQuotaSpecs is used to replicate quota specifications from the
+// authoritative region to this region.
+func (s *Server) replicateQuotaSpecs(stopCh chan struct{}) {
+	req := structs.QuotaSpecListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting quota replication from authoritative region", "region", req.Region)
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		// Rate limit how often we attempt replication
+		limiter.Wait(context.Background())
+
+		if !ServersMeetMinimumVersion(
+			s.serf.Members(), s.Region(), minQuotaVersion, true) {
+			s.logger.Trace(
+				"all servers must be upgraded to 1.9.0 before quotas can be replicated")
+			if s.replicationBackoffContinue(stopCh) {
+				continue
+			} else {
+				return
+			}
+		}
+
+		var resp structs.QuotaSpecListResponse
+		req.AuthToken = s.ReplicationToken()
+		err := s.forwardRegion(s.config.AuthoritativeRegion, "Quota.ListQuotaSpecs", &req, &resp)
+		if err != nil {
+			s.logger.Error("failed to fetch quotas from authoritative region", "error", err)
+			if s.replicationBackoffContinue(stopCh) {
+				continue
+			} else {
+				return
+			}
+		}
+
+		// Perform a two-way diff
+		delete, update := diffQuotaSpecs(s.State(), req.MinQueryIndex, resp.Quotas)
+
+		// A significant amount of time could pass between the last check
+		// on whether we should stop the replication process. Therefore, do
+		// a check here, before calling Raft.
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		// Delete quotas that should not exist
+		if len(delete) > 0 {
+			args := &structs.QuotaSpecDeleteRequest{
+				Names: delete,
+			}
+			_, _, err := s.raftApply(structs.QuotaSpecDeleteRequestType, args)
+			if err != nil {
+				s.logger.Error("failed to delete quotas", "error", err)
+				if s.replicationBackoffContinue(stopCh) {
+					continue
+				} else {
+					return
+				}
+			}
+		}
+
+		// Update local quotas
+		if len(update) > 0 {
+			args := &structs.QuotaSpecUpsertRequest{
+				Quotas: update,
+			}
+			_, _, err := s.raftApply(structs.QuotaSpecUpsertRequestType, args)
+			if err != nil {
+				s.logger.Error("failed to update quotas", "error", err)
+				if s.replicationBackoffContinue(stopCh) {
+					continue
+				} else {
+					return
+				}
+			}
+		}
+
+		// Update the minimum query index, blocks until there is a change.
+		req.MinQueryIndex = resp.Index
+	}
+}
+
+// diffQuotaSpecs is used to perform a two-way diff between the local quotas
+// and the remote quotas to determine which quotas need to be deleted or
+// updated.
+func diffQuotaSpecs(store *state.StateStore, minIndex uint64, remoteList []*structs.QuotaSpec) (delete []string, update []*structs.QuotaSpec) {
+	// Construct a set of the local and remote quotas
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	// Add all the local quotas
+	iter, err := store.QuotaSpecs(nil)
+	if err != nil {
+		panic("failed to iterate local quotas")
+	}
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		spec := raw.(*structs.QuotaSpec)
+		local[spec.Name] = spec.Hash
+	}
+
+	for _, rspec := range remoteList {
+		remote[rspec.Name] = struct{}{}
+
+		if localHash, ok := local[rspec.Name]; !ok {
+			// Quotas that are missing locally should be added
+			update = append(update, rspec)
+
+		} else if rspec.ModifyIndex > minIndex && !bytes.Equal(localHash, rspec.Hash) {
+			// Quotas that have been added/updated more recently than the last
+			// index we saw, and have a hash mismatch with what we have
+			// locally, should be updated.
+			update = append(update, rspec)
+		}
+	}
+
+	// Quotas that don't exist on the remote should be deleted
+	for lspec := range local {
+		if _, ok := remote[lspec]; !ok {
+			delete = append(delete, lspec)
+		}
+	}
+	return
+}
+
+// replicateSentinelPolicies is used to replicate Sentinel policies from the
+// authoritative region to this region.
+func (s *Server) replicateSentinelPolicies(stopCh chan struct{}) {
+	req := structs.SentinelPolicyListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting Sentinel policy replication from authoritative region", "region", req.Region)
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		// Rate limit how often we attempt replication
+		limiter.Wait(context.Background())
+
+		if !ServersMeetMinimumVersion(
+			s.serf.Members(), s.Region(), minSentinelPolicyVersion, true) {
+			s.logger.Trace(
+				"all servers must be upgraded to 1.9.0 before Sentinel policies can be replicated")
+			if s.replicationBackoffContinue(stopCh) {
+				continue
+			} else {
+				return
+			}
+		}
+
+		var resp structs.SentinelPolicyListResponse
+		req.AuthToken = s.ReplicationToken()
+		err := s.forwardRegion(s.config.AuthoritativeRegion, "Sentinel.ListPolicies", &req, &resp)
+		if err != nil {
+			s.logger.Error("failed to fetch Sentinel policies from authoritative region", "error", err)
+			if s.replicationBackoffContinue(stopCh) {
+				continue
+			} else {
+				return
+			}
+		}
+
+		// Perform a two-way diff
+		delete, update := diffSentinelPolicies(s.State(), req.MinQueryIndex, resp.Policies)
+
+		// A significant amount of time could pass between the last check
+		// on whether we should stop the replication process. Therefore, do
+		// a check here, before calling Raft.
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		// Delete policies that should not exist
+		if len(delete) > 0 {
+			args := &structs.SentinelPolicyDeleteRequest{
+				Names: delete,
+			}
+			_, _, err := s.raftApply(structs.SentinelPolicyDeleteRequestType, args)
+			if err != nil {
+				s.logger.Error("failed to delete Sentinel policies", "error", err)
+				if s.replicationBackoffContinue(stopCh) {
+					continue
+				} else {
+					return
+				}
+			}
+		}
+
+		// Update local policies
+		if len(update) > 0 {
+			args := &structs.SentinelPolicyUpsertRequest{
+				Policies: update,
+			}
+			_, _, err := s.raftApply(structs.SentinelPolicyUpsertRequestType, args)
+			if err != nil {
+				s.logger.Error("failed to update Sentinel policies", "error", err)
+				if s.replicationBackoffContinue(stopCh) {
+					continue
+				} else {
+					return
+				}
+			}
+		}
+
+		// Update the minimum query index, blocks until there is a change.
+		req.MinQueryIndex = resp.Index
+	}
+}
+
+// diffSentinelPolicies is used to perform a two-way diff between the local
+// policies and the remote policies to determine which policies need to be
+// deleted or updated.
+func diffSentinelPolicies(store *state.StateStore, minIndex uint64, remoteList []*structs.SentinelPolicy) (delete []string, update []*structs.SentinelPolicy) {
+	// Construct a set of the local and remote policies
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	// Add all the local policies
+	iter, err := store.SentinelPolicies(nil)
+	if err != nil {
+		panic("failed to iterate local Sentinel policies")
+	}
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		policy := raw.(*structs.SentinelPolicy)
+		local[policy.Name] = policy.Hash
+	}
+
+	for _, rpolicy := range remoteList {
+		remote[rpolicy.Name] = struct{}{}
+
+		if localHash, ok := local[rpolicy.Name]; !ok {
+			// Policies that are missing locally should be added
+			update = append(update, rpolicy)
+
+		} else if rpolicy.ModifyIndex > minIndex && !bytes.Equal(localHash, rpolicy.Hash) {
+			// Policies that have been added/updated more recently than the
+			// last index we saw, and have a hash mismatch with what we have
+			// locally, should be updated.
+			update = append(update, rpolicy)
+		}
+	}
+
+	// Policies that don't exist on the remote should be deleted
+	for lpolicy := range local {
+		if _, ok := remote[lpolicy]; !ok {
+			delete = append(delete, lpolicy)
+		}
+	}
+	return
+}
+
 // restoreEvals is used to restore pending evaluations into the eval broker and
 // blocked evaluations into the blocked eval tracker. The broker and blocked
 // eval tracker is maintained only by the leader, so it must be restored anytime
@@ -1522,6 +1827,9 @@ func (s *Server) revokeLeadership() error {
 	// Disable the volume watcher
 	s.volumeWatcher.SetEnabled(false, nil, "")
 
+	// Disable the recommender and drop the usage history
+	s.recommender.SetEnabled(false)
+
 	// Disable any enterprise systems required.
 	if err := s.revokeEnterpriseLeadership(); err != nil {
 		return err
@@ -2841,8 +3149,10 @@ func (s *Server) handleEvalBrokerStateChange(schedConfig *structs.SchedulerConfi
 	switch schedConfig {
 	case nil:
 		enableBrokers = !s.config.DefaultSchedulerConfig.PauseEvalBroker
+		s.evalBroker.SetFairShare(s.config.DefaultSchedulerConfig.EvalBrokerFairShare)
 	default:
 		enableBrokers = !schedConfig.PauseEvalBroker
+		s.evalBroker.SetFairShare(schedConfig.EvalBrokerFairShare)
 	}
 
 	// If the evalBroker status is changing, set the new state.
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package mock
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// HostVolume returns a ready dynamic host volume provisioned on the node by
+// the built-in mkdir plugin.
+func HostVolume(node *structs.Node) *structs.HostVolume {
+	id := uuid.Generate()
+	return &structs.HostVolume{
+		ID:                        id,
+		Name:                      fmt.Sprintf("volume-%s", uuid.Short()),
+		Namespace:                 structs.DefaultNamespace,
+		PluginID:                  structs.HostVolumePluginMkdirID,
+		NodePool:                  node.NodePool,
+		NodeID:                    node.ID,
+		RequestedCapacityMinBytes: 100 * structs.BytesInMegabyte,
+		RequestedCapacityMaxBytes: 200 * structs.BytesInMegabyte,
+		CapacityBytes:             150 * structs.BytesInMegabyte,
+		Parameters:                map[string]string{"owner": "nomad"},
+		HostPath:                  "/var/nomad/host_volumes/" + id,
+		State:                     structs.HostVolumeStateReady,
+	}
+}
@@ -252,6 +252,53 @@ func Namespace() *structs.Namespace {
 	return ns
 }
 
+func QuotaSpec() *structs.QuotaSpec {
+	qs := &structs.QuotaSpec{
+		Name:        fmt.Sprintf("quota-%s", uuid.Short()),
+		Description: "test quota",
+		Limits: []*structs.QuotaLimit{
+			{
+				Region: "global",
+				RegionLimit: &structs.Resources{
+					CPU:      2000,
+					MemoryMB: 2000,
+				},
+			},
+		},
+	}
+	qs.SetHash()
+	return qs
+}
+
+func SentinelPolicy() *structs.SentinelPolicy {
+	sp := &structs.SentinelPolicy{
+		Name:             fmt.Sprintf("policy-%s", uuid.Short()),
+		Description:      "test policy",
+		Scope:            structs.SentinelScopeSubmitJob,
+		EnforcementLevel: structs.SentinelEnforcementLevelAdvisory,
+		Policy:           `rule "priority" { condition = job.Priority <= 80 }`,
+	}
+	sp.SetHash()
+	return sp
+}
+
+// Recommendation returns a CPU recommendation for the first task of the job.
+func Recommendation(job *structs.Job) *structs.Recommendation {
+	task := job.TaskGroups[0].Tasks[0]
+	return &structs.Recommendation{
+		ID:        uuid.Generate(),
+		Region:    job.Region,
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		Group:     job.TaskGroups[0].Name,
+		Task:      task.Name,
+		Resource:  structs.RecommendationResourceCPU,
+		Value:     task.Resources.CPU * 2,
+		Meta:      map[string]interface{}{"source": "test"},
+		Stats:     map[string]float64{"p95": float64(task.Resources.CPU) * 1.6},
+	}
+}
+
 func NodePool() *structs.NodePool {
 	pool := &structs.NodePool{
 		Name:        fmt.Sprintf("pool-%s", uuid.Short()),
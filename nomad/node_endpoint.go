@@ -597,6 +597,25 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 	// Update the timestamp of when the node status was updated
 	args.UpdatedAt = time.Now().Unix()
 
+	// Only commit utilization reports the scheduler will use, and only when
+	// they have changed enough to matter, so reports don't turn every
+	// heartbeat into a write that wakes up everything watching nodes.
+	if args.Utilization != nil {
+		args.Utilization.UpdatedAt = args.UpdatedAt
+		_, schedConfig, err := snap.SchedulerConfig()
+		if err != nil {
+			return err
+		}
+		var utilization *structs.SchedulerUtilizationScoring
+		if schedConfig != nil {
+			utilization = schedConfig.UtilizationScoring
+		}
+		if utilization.EffectiveObservedWeight() == 0 ||
+			!args.Utilization.Changed(node.Utilization, node, utilization.EffectiveMaxAge()) {
+			args.Utilization = nil
+		}
+	}
+
 	// Compute next status.
 	switch node.Status {
 	case structs.NodeStatusInit:
@@ -639,7 +658,8 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 
 	// Commit this update via Raft
 	var index uint64
-	if node.Status != args.Status || args.NodeEvent != nil {
+	statusChanged := node.Status != args.Status || args.NodeEvent != nil
+	if statusChanged || args.Utilization != nil {
 		// Attach an event if we are updating the node status to ready when it
 		// is down via a heartbeat
 		if node.Status == structs.NodeStatusDown && args.NodeEvent == nil {
@@ -654,6 +674,7 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 			return err
 		}
 		reply.NodeModifyIndex = index
+		reply.UtilizationOnly = !statusChanged
 	}
 
 	// Check if we should trigger evaluations
@@ -2229,6 +2250,48 @@ func taskUsesConnect(task *structs.Task) bool {
 	return task.UsesConnect()
 }
 
+// ReportTaskUsage is used by clients to report the resource usage of their
+// running tasks. The leader keeps the reports in memory and computes
+// recommendations for the resources of the tasks from them.
+func (n *Node) ReportTaskUsage(args *structs.TaskUsageReportRequest, reply *structs.GenericResponse) error {
+	aclObj, err := n.srv.AuthenticateClientOnly(n.ctx, args)
+	n.srv.MeasureRPCRate("node", structs.RateMetricWrite, args)
+	if err != nil {
+		return structs.ErrPermissionDenied
+	}
+
+	if done, err := n.srv.forward("Node.ReportTaskUsage", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client", "report_task_usage"}, time.Now())
+
+	if !aclObj.AllowClientOp() || args.GetIdentity().ClientID != args.NodeID {
+		return structs.ErrPermissionDenied
+	}
+	if args.NodeID == "" {
+		return fmt.Errorf("missing node ID")
+	}
+
+	snap, err := n.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Only record usage of allocations that are running on the node
+	now := time.Now()
+	for _, usage := range args.Usage {
+		alloc, err := snap.AllocByID(nil, usage.AllocID)
+		if err != nil {
+			return err
+		}
+		if alloc == nil || alloc.NodeID != args.NodeID || alloc.ClientTerminalStatus() {
+			continue
+		}
+		n.srv.recommender.Record(alloc, usage, now)
+	}
+	return nil
+}
+
 func (n *Node) EmitEvents(args *structs.EmitNodeEventsRequest, reply *structs.EmitNodeEventsResponse) error {
 	// COMPAT(1.9.0): move to AuthenticateClientOnly
 	aclObj, err := n.srv.AuthenticateClientOnlyLegacy(n.ctx, args)
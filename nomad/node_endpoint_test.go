@@ -806,6 +806,123 @@ func TestClientEndpoint_UpdateStatus(t *testing.T) {
 	})
 }
 
+func TestClientEndpoint_UpdateStatus_Utilization(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeUpdateResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	heartbeat := func(util *structs.NodeUtilization) structs.NodeUpdateResponse {
+		req := &structs.NodeUpdateStatusRequest{
+			NodeID:       node.ID,
+			Status:       structs.NodeStatusReady,
+			Utilization:  util,
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.NodeUpdateResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.UpdateStatus", req, &resp))
+		return resp
+	}
+	utilization := func() *structs.NodeUtilization {
+		out, err := s1.fsm.State().NodeByID(nil, node.ID)
+		must.NoError(t, err)
+		return out.Utilization
+	}
+
+	// Reports are dropped while utilization scoring is disabled
+	heartbeat(nil)
+	resp = heartbeat(&structs.NodeUtilization{CPU: 1000, MemoryMB: 1024, Samples: 10})
+	must.Zero(t, resp.Index)
+	must.Nil(t, utilization())
+
+	_, schedConfig, err := s1.fsm.State().SchedulerConfig()
+	must.NoError(t, err)
+	schedConfig = schedConfig.Copy()
+	schedConfig.UtilizationScoring = &structs.SchedulerUtilizationScoring{Enabled: true}
+	must.NoError(t, s1.fsm.State().SchedulerSetConfig(100, schedConfig))
+
+	// The first report is written without changing the status
+	resp = heartbeat(&structs.NodeUtilization{CPU: 1000, MemoryMB: 1024, Samples: 10})
+	must.NonZero(t, resp.Index)
+	must.True(t, resp.UtilizationOnly)
+	must.Eq(t, 1000, utilization().CPU)
+
+	// Small changes are not written
+	resp = heartbeat(&structs.NodeUtilization{CPU: 1010, MemoryMB: 1030, Samples: 10})
+	must.Zero(t, resp.Index)
+	must.Eq(t, 1000, utilization().CPU)
+
+	// Material changes are written
+	resp = heartbeat(&structs.NodeUtilization{CPU: 3000, MemoryMB: 1024, Samples: 10})
+	must.NonZero(t, resp.Index)
+	must.Eq(t, 3000, utilization().CPU)
+}
+
+func TestClientEndpoint_ReportTaskUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	other := mock.Alloc()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, alloc.Job))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1002, []*structs.Allocation{alloc, other}))
+
+	testutil.WaitForResult(func() (bool, error) {
+		s1.recommender.l.Lock()
+		defer s1.recommender.l.Unlock()
+		return s1.recommender.enabled, fmt.Errorf("recommender not enabled")
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	req := &structs.TaskUsageReportRequest{
+		NodeID: node.ID,
+		Usage: []*structs.TaskUsage{
+			{AllocID: alloc.ID, Task: "web", CPU: 100, MemoryMB: 128, Samples: 10},
+			{AllocID: other.ID, Task: "web", CPU: 100, MemoryMB: 128, Samples: 10},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global", AuthToken: node.SecretID},
+	}
+	var resp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.ReportTaskUsage", req, &resp))
+
+	// Only the usage of allocations on the node is recorded
+	s1.recommender.l.Lock()
+	history := s1.recommender.history[usageTarget{alloc.Namespace, alloc.JobID, alloc.TaskGroup, "web"}]
+	s1.recommender.l.Unlock()
+	must.Len(t, 1, history)
+	must.Eq(t, 500, history[0].allocCPU)
+
+	// Nodes can only report the usage of their own allocations
+	req.NodeID = other.NodeID
+	err := msgpackrpc.CallWithCodec(codec, "Node.ReportTaskUsage", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Reports must be authenticated by a node
+	req.AuthToken = ""
+	err = msgpackrpc.CallWithCodec(codec, "Node.ReportTaskUsage", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+}
+
 func TestClientEndpoint_UpdateStatus_Vault(t *testing.T) {
 	ci.Parallel(t)
 
@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/nomad/helper/snapshot"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
 )
 
 // Operator endpoint is used to perform low-level operator tasks for Nomad.
@@ -488,6 +489,12 @@ func (op *Operator) SchedulerSetConfiguration(args *structs.SchedulerSetConfigRe
 		return fmt.Errorf("All servers should be running version %v to update scheduler config", minSchedulerConfigVersion)
 	}
 
+	// Scoring plugins are compiled into the scheduler, so reject any the
+	// servers don't know about rather than silently ignoring them.
+	if err := scheduler.ValidateScoringPlugins(args.Config.ScoringPlugins); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+
 	// Apply the update
 	resp, index, err := op.srv.raftApply(structs.SchedulerConfigRequestType, args)
 	if err != nil {
@@ -744,6 +744,40 @@ func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	require.False(t, s1.blockedEvals.Enabled())
 }
 
+func TestOperator_SchedulerSetConfiguration_ScoringPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	rpcCodec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Enabling a registered plugin succeeds.
+	arg := structs.SchedulerSetConfigRequest{
+		Config: structs.SchedulerConfiguration{
+			ScoringPlugins: []*structs.SchedulerScoringPlugin{
+				{Name: "alloc-churn", Weight: 50},
+			},
+		},
+	}
+	arg.Region = s1.config.Region
+
+	var setResponse structs.SchedulerSetConfigurationResponse
+	err := msgpackrpc.CallWithCodec(rpcCodec, "Operator.SchedulerSetConfiguration", &arg, &setResponse)
+	must.NoError(t, err)
+
+	_, config, err := s1.fsm.State().SchedulerConfig()
+	must.NoError(t, err)
+	must.Eq(t, arg.Config.ScoringPlugins, config.ScoringPlugins)
+
+	// Enabling an unknown plugin is rejected.
+	arg.Config.ScoringPlugins = []*structs.SchedulerScoringPlugin{
+		{Name: "does-not-exist", Weight: 50},
+	}
+	err = msgpackrpc.CallWithCodec(rpcCodec, "Operator.SchedulerSetConfiguration", &arg, &setResponse)
+	must.ErrorContains(t, err, `unknown scoring plugin "does-not-exist"`)
+}
+
 func TestOperator_SchedulerGetConfiguration_ACL(t *testing.T) {
 	ci.Parallel(t)
 
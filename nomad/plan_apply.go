@@ -371,6 +371,7 @@ func normalizePreemptedAlloc(preemptedAlloc *structs.Allocation, now int64) *str
 	return &structs.AllocationDiff{
 		ID:                    preemptedAlloc.ID,
 		PreemptedByAllocation: preemptedAlloc.PreemptedByAllocation,
+		Preemption:            preemptedAlloc.Preemption,
 		ModifyTime:            now,
 	}
 }
@@ -12,7 +12,7 @@ import (
 )
 
 // refreshIndex returns the index the scheduler should refresh to as the maximum
-// of both the allocation and node tables.
+// of the allocation, node and quota tables.
 func refreshIndex(snap *state.StateSnapshot) (uint64, error) {
 	allocIndex, err := snap.Index("allocs")
 	if err != nil {
@@ -22,10 +22,57 @@ func refreshIndex(snap *state.StateSnapshot) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return maxUint64(nodeIndex, allocIndex), nil
+	quotaIndex, err := snap.Index(state.TableQuotaSpec)
+	if err != nil {
+		return 0, err
+	}
+	return maxUint64(nodeIndex, allocIndex, quotaIndex), nil
 }
 
-// evaluatePlanQuota returns whether the plan would be over quota
-func evaluatePlanQuota(_ *state.StateSnapshot, _ *structs.Plan) (bool, error) {
-	return false, nil
+// evaluatePlanQuota returns whether the plan would push the quota the job's
+// namespace accounts against over its limit in this region.
+func evaluatePlanQuota(snap *state.StateSnapshot, plan *structs.Plan) (bool, error) {
+	if plan.Job == nil {
+		return false, nil
+	}
+
+	ns, err := snap.NamespaceByName(nil, plan.Job.Namespace)
+	if err != nil || ns == nil || ns.Quota == "" {
+		return false, err
+	}
+
+	spec, err := snap.QuotaSpecByName(nil, ns.Quota)
+	if err != nil || spec == nil {
+		return false, err
+	}
+	limit := spec.LimitForRegion(snap.Config().Region)
+	if limit == nil {
+		return false, nil
+	}
+
+	usage, err := snap.QuotaUsageByName(nil, ns.Quota)
+	if err != nil {
+		return false, err
+	}
+	before := usage.UsageForLimit(limit)
+	if before == nil {
+		return false, nil
+	}
+
+	inQuota := func(namespace string) bool {
+		if namespace == ns.Name {
+			return true
+		}
+		other, err := snap.NamespaceByName(nil, namespace)
+		return err == nil && other != nil && other.Quota == ns.Quota
+	}
+	lookup := func(allocID string) (*structs.Allocation, error) {
+		return snap.AllocByID(nil, allocID)
+	}
+
+	after := before.Copy()
+	if err := after.ApplyPlan(plan, inQuota, lookup); err != nil {
+		return false, err
+	}
+	return len(limit.ExhaustedBy(before, after)) != 0, nil
 }
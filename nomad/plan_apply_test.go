@@ -738,6 +738,73 @@ func TestPlanApply_EvalPlan_Partial_AllAtOnce(t *testing.T) {
 	}
 }
 
+func TestPlanApply_EvalPlan_Quota(t *testing.T) {
+	ci.Parallel(t)
+	state := testStateStore(t)
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	spec := mock.QuotaSpec()
+	spec.Limits[0].RegionLimit.CPU = 1000
+	spec.SetHash()
+	must.NoError(t, state.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1001, []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, state.UpsertNamespaces(1002, []*structs.Namespace{ns}))
+
+	// Place an allocation using half of the quota
+	existing := mock.Alloc()
+	existing.Namespace = ns.Name
+	existing.Job.Namespace = ns.Name
+	existing.NodeID = node.ID
+	existing.AllocatedResources.Tasks["web"].Networks = nil
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1003, nil, existing.Job))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1004, []*structs.Allocation{existing}))
+
+	pool := NewEvaluatePool(workerPoolSize, workerPoolBufferSize)
+	defer pool.Shutdown()
+
+	newAlloc := func() *structs.Allocation {
+		alloc := mock.Alloc()
+		alloc.Namespace = ns.Name
+		alloc.Job = existing.Job
+		alloc.JobID = existing.JobID
+		alloc.NodeID = node.ID
+		alloc.AllocatedResources.Tasks["web"].Networks = nil
+		return alloc
+	}
+
+	// Placing another allocation fits within the quota
+	snap, _ := state.Snapshot()
+	plan := &structs.Plan{
+		Job: existing.Job,
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID: {newAlloc()},
+		},
+	}
+	result, err := evaluatePlan(pool, snap, plan, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.Zero(t, result.RefreshIndex)
+	must.Eq(t, plan.NodeAllocation, result.NodeAllocation)
+
+	// Placing two more allocations goes over the quota and forces a refresh
+	plan.NodeAllocation[node.ID] = append(plan.NodeAllocation[node.ID], newAlloc())
+	result, err = evaluatePlan(pool, snap, plan, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.NonZero(t, result.RefreshIndex)
+	must.MapEmpty(t, result.NodeAllocation)
+
+	// Stopping the existing allocation makes room for them
+	plan.NodeUpdate = map[string][]*structs.Allocation{
+		node.ID: {existing},
+	}
+	result, err = evaluatePlan(pool, snap, plan, testlog.HCLogger(t))
+	must.NoError(t, err)
+	must.Zero(t, result.RefreshIndex)
+	must.Len(t, 2, result.NodeAllocation[node.ID])
+}
+
 func TestPlanApply_EvalNodePlan_Simple(t *testing.T) {
 	ci.Parallel(t)
 	state := testStateStore(t)
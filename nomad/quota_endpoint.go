@@ -0,0 +1,378 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Quota endpoint is used for manipulating quota specifications and querying
+// their usage.
+type Quota struct {
+	srv *Server
+	ctx *RPCContext
+}
+
+func NewQuotaEndpoint(srv *Server, ctx *RPCContext) *Quota {
+	return &Quota{srv: srv, ctx: ctx}
+}
+
+// UpsertQuotaSpecs is used to upsert a set of quota specifications
+func (q *Quota) UpsertQuotaSpecs(args *structs.QuotaSpecUpsertRequest,
+	reply *structs.GenericResponse) error {
+
+	authErr := q.srv.Authenticate(q.ctx, args)
+	if q.srv.config.ACLEnabled || args.Region == "" {
+		// only forward to the authoritative region if ACLs are enabled,
+		// otherwise we silently write to the local region
+		args.Region = q.srv.config.AuthoritativeRegion
+	}
+	if done, err := q.srv.forward("Quota.UpsertQuotaSpecs", args, args, reply); done {
+		return err
+	}
+	q.srv.MeasureRPCRate("quota", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "quota", "upsert_quota_specs"}, time.Now())
+
+	// Check quota write permissions
+	if aclObj, err := q.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowQuotaWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !ServersMeetMinimumVersion(
+		q.srv.serf.Members(), q.srv.Region(), minQuotaVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to upsert quotas", minQuotaVersion)
+	}
+
+	// Validate there is at least one quota
+	if len(args.Quotas) == 0 {
+		return fmt.Errorf("must specify at least one quota")
+	}
+
+	// Validate the quotas and set the hash
+	for _, spec := range args.Quotas {
+		if err := spec.Validate(); err != nil {
+			return fmt.Errorf("Invalid quota %q: %v", spec.Name, err)
+		}
+
+		spec.SetHash()
+	}
+
+	// Update via Raft
+	_, index, err := q.srv.raftApply(structs.QuotaSpecUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// DeleteQuotaSpecs is used to delete a set of quota specifications
+func (q *Quota) DeleteQuotaSpecs(args *structs.QuotaSpecDeleteRequest,
+	reply *structs.GenericResponse) error {
+
+	authErr := q.srv.Authenticate(q.ctx, args)
+	if q.srv.config.ACLEnabled || args.Region == "" {
+		// only forward to the authoritative region if ACLs are enabled,
+		// otherwise we silently write to the local region
+		args.Region = q.srv.config.AuthoritativeRegion
+	}
+	if done, err := q.srv.forward("Quota.DeleteQuotaSpecs", args, args, reply); done {
+		return err
+	}
+	q.srv.MeasureRPCRate("quota", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "quota", "delete_quota_specs"}, time.Now())
+
+	// Check quota write permissions
+	if aclObj, err := q.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowQuotaWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !ServersMeetMinimumVersion(
+		q.srv.serf.Members(), q.srv.Region(), minQuotaVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to delete quotas", minQuotaVersion)
+	}
+
+	// Validate at least one quota
+	if len(args.Names) == 0 {
+		return fmt.Errorf("must specify at least one quota to delete")
+	}
+
+	// Quotas can't be deleted while a namespace is accounted against them.
+	snap, err := q.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, name := range args.Names {
+		iter, err := snap.NamespacesByQuota(nil, name)
+		if err != nil {
+			return err
+		}
+		if raw := iter.Next(); raw != nil {
+			return fmt.Errorf("quota %q is in use by namespace %q", name, raw.(*structs.Namespace).Name)
+		}
+	}
+
+	// Update via Raft
+	_, index, err := q.srv.raftApply(structs.QuotaSpecDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// ListQuotaSpecs is used to list the quota specifications
+func (q *Quota) ListQuotaSpecs(args *structs.QuotaSpecListRequest,
+	reply *structs.QuotaSpecListResponse) error {
+
+	authErr := q.srv.Authenticate(q.ctx, args)
+	if done, err := q.srv.forward("Quota.ListQuotaSpecs", args, args, reply); done {
+		return err
+	}
+	q.srv.MeasureRPCRate("quota", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "quota", "list_quota_specs"}, time.Now())
+
+	// Check quota read permissions
+	if aclObj, err := q.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowQuotaRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			// Iterate over all the quotas
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = s.QuotaSpecsByNamePrefix(ws, prefix)
+			} else {
+				iter, err = s.QuotaSpecs(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			reply.Quotas = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				reply.Quotas = append(reply.Quotas, raw.(*structs.QuotaSpec))
+			}
+
+			// Use the last index that affected the quota table
+			return q.setIndex(s, state.TableQuotaSpec, &reply.QueryMeta)
+		}}
+	return q.srv.blockingRPC(&opts)
+}
+
+// GetQuotaSpec is used to get a specific quota specification
+func (q *Quota) GetQuotaSpec(args *structs.QuotaSpecSpecificRequest,
+	reply *structs.SingleQuotaSpecResponse) error {
+
+	authErr := q.srv.Authenticate(q.ctx, args)
+	if done, err := q.srv.forward("Quota.GetQuotaSpec", args, args, reply); done {
+		return err
+	}
+	q.srv.MeasureRPCRate("quota", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "quota", "get_quota_spec"}, time.Now())
+
+	// Check quota read permissions
+	if aclObj, err := q.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowQuotaRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			// Look for the quota
+			out, err := s.QuotaSpecByName(ws, args.Name)
+			if err != nil {
+				return err
+			}
+
+			// Setup the output
+			reply.Quota = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+				return nil
+			}
+			return q.setIndex(s, state.TableQuotaSpec, &reply.QueryMeta)
+		}}
+	return q.srv.blockingRPC(&opts)
+}
+
+// ListQuotaUsages is used to list the usage of the quotas in the local region
+func (q *Quota) ListQuotaUsages(args *structs.QuotaSpecListRequest,
+	reply *structs.QuotaUsageListResponse) error {
+
+	authErr := q.srv.Authenticate(q.ctx, args)
+	if done, err := q.srv.forward("Quota.ListQuotaUsages", args, args, reply); done {
+		return err
+	}
+	q.srv.MeasureRPCRate("quota", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "quota", "list_quota_usages"}, time.Now())
+
+	// Check quota read permissions
+	if aclObj, err := q.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowQuotaRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			// Iterate over all the quota usages
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = s.QuotaUsagesByNamePrefix(ws, prefix)
+			} else {
+				iter, err = s.QuotaUsages(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			reply.Usages = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				usage, err := quotaUsageWithStorage(ws, s, raw.(*structs.QuotaUsage))
+				if err != nil {
+					return err
+				}
+				reply.Usages = append(reply.Usages, usage)
+			}
+
+			// Use the last index that affected the quota usage table
+			return q.setIndex(s, state.TableQuotaUsage, &reply.QueryMeta)
+		}}
+	return q.srv.blockingRPC(&opts)
+}
+
+// GetQuotaUsage is used to get the usage of a specific quota in the local
+// region
+func (q *Quota) GetQuotaUsage(args *structs.QuotaSpecSpecificRequest,
+	reply *structs.SingleQuotaUsageResponse) error {
+
+	authErr := q.srv.Authenticate(q.ctx, args)
+	if done, err := q.srv.forward("Quota.GetQuotaUsage", args, args, reply); done {
+		return err
+	}
+	q.srv.MeasureRPCRate("quota", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "quota", "get_quota_usage"}, time.Now())
+
+	// Check quota read permissions
+	if aclObj, err := q.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.AllowQuotaRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			// Look for the quota usage
+			out, err := s.QuotaUsageByName(ws, args.Name)
+			if err != nil {
+				return err
+			}
+
+			// Setup the output
+			reply.Usage = nil
+			if out != nil {
+				reply.Usage, err = quotaUsageWithStorage(ws, s, out)
+				if err != nil {
+					return err
+				}
+				reply.Index = out.ModifyIndex
+				return nil
+			}
+			return q.setIndex(s, state.TableQuotaUsage, &reply.QueryMeta)
+		}}
+	return q.srv.blockingRPC(&opts)
+}
+
+// setIndex sets the index of the reply to the last index that affected the
+// given table.
+func (q *Quota) setIndex(s *state.StateStore, table string, reply *structs.QueryMeta) error {
+	index, err := s.Index(table)
+	if err != nil {
+		return err
+	}
+
+	// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+	// We floor the index at one, since realistically the first write must have a higher index.
+	if index == 0 {
+		index = 1
+	}
+	reply.Index = index
+	return nil
+}
+
+// quotaUsageWithStorage returns a copy of the quota usage with the storage
+// used by variables and dynamic host volumes filled in. Storage usage isn't
+// tracked in the quota usage table, so it is reported in whole MiB rounded
+// up.
+func quotaUsageWithStorage(ws memdb.WatchSet, s *state.StateStore, usage *structs.QuotaUsage) (*structs.QuotaUsage, error) {
+	varSize, err := s.QuotaVariablesUsage(ws, usage.Name)
+	if err != nil {
+		return nil, err
+	}
+	volSize, err := s.QuotaHostVolumesUsage(ws, usage.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	varMiB := int((varSize + structs.BytesInMegabyte - 1) / structs.BytesInMegabyte)
+	volMiB := int((volSize + structs.BytesInMegabyte - 1) / structs.BytesInMegabyte)
+	usage = usage.Copy()
+	for _, used := range usage.Used {
+		used.VariablesLimit = &varMiB
+		used.HostVolumesLimit = &volMiB
+	}
+	return usage, nil
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestQuotaEndpoint_UpsertQuotaSpecs(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	spec := mock.QuotaSpec()
+	spec.Hash = nil
+	req := &structs.QuotaSpecUpsertRequest{
+		Quotas:       []*structs.QuotaSpec{spec},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", req, &resp))
+	must.NonZero(t, resp.Index)
+
+	got, err := s1.fsm.State().QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.NotNil(t, got)
+	must.NotNil(t, got.Hash)
+	must.Eq(t, resp.Index, got.ModifyIndex)
+
+	// Invalid quotas are rejected
+	invalid := mock.QuotaSpec()
+	invalid.Limits[0].RegionLimit.DiskMB = 100
+	req.Quotas = []*structs.QuotaSpec{invalid}
+	err = msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", req, &resp)
+	must.ErrorContains(t, err, "disk limits are not supported")
+}
+
+func TestQuotaEndpoint_DeleteQuotaSpecs(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	spec := mock.QuotaSpec()
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, store.UpsertNamespaces(1001, []*structs.Namespace{ns}))
+
+	req := &structs.QuotaSpecDeleteRequest{
+		Names:        []string{spec.Name},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "Quota.DeleteQuotaSpecs", req, &resp)
+	must.ErrorContains(t, err, "is in use by namespace")
+
+	ns = ns.Copy()
+	ns.Quota = ""
+	must.NoError(t, store.UpsertNamespaces(1002, []*structs.Namespace{ns}))
+
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.DeleteQuotaSpecs", req, &resp))
+	got, err := store.QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Nil(t, got)
+}
+
+func TestQuotaEndpoint_GetQuota_ACL(t *testing.T) {
+	ci.Parallel(t)
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	spec := mock.QuotaSpec()
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+
+	validToken := mock.CreatePolicyAndToken(t, store, 1001, "test-valid",
+		mock.QuotaPolicy(acl.PolicyRead))
+	invalidToken := mock.CreatePolicyAndToken(t, store, 1002, "test-invalid",
+		mock.NodePolicy(acl.PolicyRead))
+
+	get := &structs.QuotaSpecSpecificRequest{
+		Name:         spec.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	// Lookups without a valid token are denied
+	var resp structs.SingleQuotaSpecResponse
+	err := msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaSpec", get, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	get.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaSpec", get, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Quota read permission allows reading specs and usages
+	get.AuthToken = validToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaSpec", get, &resp))
+	must.Eq(t, spec.Name, resp.Quota.Name)
+	must.Eq(t, 1000, resp.Index)
+
+	var usageResp structs.SingleQuotaUsageResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaUsage", get, &usageResp))
+	must.Eq(t, spec.Name, usageResp.Usage.Name)
+
+	// Quota read permission doesn't allow writes
+	upsert := &structs.QuotaSpecUpsertRequest{
+		Quotas: []*structs.QuotaSpec{mock.QuotaSpec()},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: validToken.SecretID,
+		},
+	}
+	var upsertResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", upsert, &upsertResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsert.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.UpsertQuotaSpecs", upsert, &upsertResp))
+}
+
+func TestQuotaEndpoint_ListQuotaSpecs(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	spec1 := mock.QuotaSpec()
+	spec2 := mock.QuotaSpec()
+	spec1.Name = "aaaa-" + spec1.Name
+	spec2.Name = "bbbb-" + spec2.Name
+	must.NoError(t, s1.fsm.State().UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000,
+		[]*structs.QuotaSpec{spec1, spec2}))
+
+	get := &structs.QuotaSpecListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.QuotaSpecListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.ListQuotaSpecs", get, &resp))
+	must.Len(t, 2, resp.Quotas)
+	must.Eq(t, 1000, resp.Index)
+
+	get.Prefix = "bbbb"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.ListQuotaSpecs", get, &resp))
+	must.Len(t, 1, resp.Quotas)
+	must.Eq(t, spec2.Name, resp.Quotas[0].Name)
+
+	var usageResp structs.QuotaUsageListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.ListQuotaUsages", get, &usageResp))
+	must.Len(t, 1, usageResp.Usages)
+	must.Eq(t, spec2.Name, usageResp.Usages[0].Name)
+}
+
+func TestQuotaEndpoint_GetQuotaUsage_Variables(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	spec := mock.QuotaSpec()
+	spec.Limits[0].VariablesLimit = pointer.Of(10)
+	spec.SetHash()
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, store.UpsertNamespaces(1001, []*structs.Namespace{ns}))
+
+	sv := mock.VariableEncrypted()
+	sv.Namespace = ns.Name
+	sv.Data = make([]byte, structs.BytesInMegabyte+1)
+	resp := store.VarSet(1002, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv})
+	must.NoError(t, resp.Error)
+
+	get := &structs.QuotaSpecSpecificRequest{
+		Name:         spec.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var usageResp structs.SingleQuotaUsageResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Quota.GetQuotaUsage", get, &usageResp))
+
+	used := usageResp.Usage.UsageForLimit(spec.Limits[0])
+	must.NotNil(t, used)
+	must.Eq(t, 2, *used.VariablesLimit)
+}
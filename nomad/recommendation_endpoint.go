@@ -0,0 +1,513 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Recommendation endpoint is used for listing, submitting, applying and
+// dismissing recommendations for the resources of tasks.
+type Recommendation struct {
+	srv    *Server
+	ctx    *RPCContext
+	logger hclog.Logger
+}
+
+func NewRecommendationEndpoint(srv *Server, ctx *RPCContext) *Recommendation {
+	return &Recommendation{srv: srv, ctx: ctx, logger: srv.logger.Named("recommendation")}
+}
+
+// ListRecommendations is used to list the recommendations in a namespace,
+// optionally filtered to a job, group and task
+func (r *Recommendation) ListRecommendations(args *structs.RecommendationListRequest,
+	reply *structs.RecommendationListResponse) error {
+
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Recommendation.ListRecommendations", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("recommendation", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "recommendation", "list_recommendations"}, time.Now())
+
+	aclObj, err := r.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	namespace := args.RequestNamespace()
+	if namespace != structs.AllNamespacesSentinel && !allowRecommendationRead(aclObj, namespace) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			var err error
+			var allowed map[string]bool
+			if namespace == structs.AllNamespacesSentinel {
+				allowed, err = allowedNSes(aclObj, store, func(ns string) bool {
+					return allowRecommendationRead(aclObj, ns)
+				})
+				if err == structs.ErrPermissionDenied {
+					// return empty if token isn't authorized for any namespace
+					reply.Recommendations = []*structs.Recommendation{}
+					return r.setIndex(store, &reply.QueryMeta)
+				} else if err != nil {
+					return err
+				}
+			}
+
+			var iter memdb.ResultIterator
+			switch {
+			case args.Prefix != "":
+				iter, err = store.RecommendationsByIDPrefix(ws, namespace, args.Prefix)
+			case namespace == structs.AllNamespacesSentinel:
+				iter, err = store.Recommendations(ws)
+			case args.JobID != "":
+				iter, err = store.RecommendationsByJob(ws, namespace, args.JobID)
+			default:
+				iter, err = store.RecommendationsByNamespace(ws, namespace)
+			}
+			if err != nil {
+				return err
+			}
+
+			recs := []*structs.Recommendation{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				rec := raw.(*structs.Recommendation)
+				if allowed != nil && !allowed[rec.Namespace] {
+					continue
+				}
+				if (args.JobID != "" && rec.JobID != args.JobID) ||
+					(args.Group != "" && rec.Group != args.Group) ||
+					(args.Task != "" && rec.Task != args.Task) {
+					continue
+				}
+				recs = append(recs, rec)
+			}
+			reply.Recommendations = recs
+
+			// Use the last index that affected the recommendation table
+			return r.setIndex(store, &reply.QueryMeta)
+		}}
+	return r.srv.blockingRPC(&opts)
+}
+
+// GetRecommendation is used to get a specific recommendation
+func (r *Recommendation) GetRecommendation(args *structs.RecommendationSpecificRequest,
+	reply *structs.SingleRecommendationResponse) error {
+
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Recommendation.GetRecommendation", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("recommendation", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "recommendation", "get_recommendation"}, time.Now())
+
+	aclObj, err := r.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			out, err := store.RecommendationByID(ws, args.RecommendationID)
+			if err != nil {
+				return err
+			}
+
+			// The recommendation may be in any namespace, so check the
+			// permissions against its own
+			if out != nil && !allowRecommendationRead(aclObj, out.Namespace) {
+				return structs.ErrPermissionDenied
+			}
+
+			reply.Recommendation = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+				return nil
+			}
+			return r.setIndex(store, &reply.QueryMeta)
+		}}
+	return r.srv.blockingRPC(&opts)
+}
+
+// UpsertRecommendation is used to create or update a set of recommendations.
+// A recommendation replaces any existing recommendation for the same resource
+// of the same task.
+func (r *Recommendation) UpsertRecommendation(args *structs.RecommendationUpsertRequest,
+	reply *structs.RecommendationUpsertResponse) error {
+
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Recommendation.UpsertRecommendation", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("recommendation", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "recommendation", "upsert_recommendation"}, time.Now())
+
+	if !ServersMeetMinimumVersion(
+		r.srv.serf.Members(), r.srv.Region(), minRecommendationVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to upsert recommendations", minRecommendationVersion)
+	}
+
+	if len(args.Recommendations) == 0 {
+		return fmt.Errorf("must specify at least one recommendation")
+	}
+
+	aclObj, err := r.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	snap, err := r.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UnixNano()
+	for _, rec := range args.Recommendations {
+		if rec.Namespace == "" {
+			rec.Namespace = args.RequestNamespace()
+		}
+		if rec.Region == "" {
+			rec.Region = r.srv.Region()
+		}
+		if !aclObj.AllowNsOp(rec.Namespace, acl.NamespaceCapabilitySubmitRecommendation) &&
+			!aclObj.AllowNsOp(rec.Namespace, acl.NamespaceCapabilitySubmitJob) {
+			return structs.ErrPermissionDenied
+		}
+		if err := rec.Validate(); err != nil {
+			return fmt.Errorf("Invalid recommendation: %v", err)
+		}
+		if err := r.resolveID(snap, rec); err != nil {
+			return err
+		}
+		rec.SubmitTime = now
+	}
+
+	// Update via Raft
+	_, index, err := r.srv.raftApply(structs.RecommendationUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Return the recommendations as stored, with their current value
+	for _, rec := range args.Recommendations {
+		out, err := r.srv.State().RecommendationByID(nil, rec.ID)
+		if err != nil {
+			return err
+		}
+		if out != nil {
+			reply.Recommendations = append(reply.Recommendations, out)
+		}
+	}
+	reply.Index = index
+	return nil
+}
+
+// resolveID sets the ID of the recommendation to that of the recommendation
+// it replaces, or a new ID. The task it targets must exist.
+func (r *Recommendation) resolveID(snap *state.StateSnapshot, rec *structs.Recommendation) error {
+	job, err := snap.JobByID(nil, rec.Namespace, rec.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return fmt.Errorf("job %q in namespace %q not found", rec.JobID, rec.Namespace)
+	}
+	if _, err := rec.Target(job); err != nil {
+		return err
+	}
+
+	if rec.ID != "" {
+		existing, err := snap.RecommendationByID(nil, rec.ID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("recommendation %s not found", rec.ID)
+		}
+		return nil
+	}
+
+	iter, err := snap.RecommendationsByJob(nil, rec.Namespace, rec.JobID)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if existing := raw.(*structs.Recommendation); existing.SameTarget(rec) {
+			rec.ID = existing.ID
+			return nil
+		}
+	}
+	rec.ID = uuid.Generate()
+	return nil
+}
+
+// DeleteRecommendations is used to delete a set of recommendations
+func (r *Recommendation) DeleteRecommendations(args *structs.RecommendationDeleteRequest,
+	reply *structs.GenericResponse) error {
+
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Recommendation.DeleteRecommendations", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("recommendation", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "recommendation", "delete_recommendations"}, time.Now())
+
+	if !ServersMeetMinimumVersion(
+		r.srv.serf.Members(), r.srv.Region(), minRecommendationVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to delete recommendations", minRecommendationVersion)
+	}
+
+	if len(args.IDs) == 0 {
+		return fmt.Errorf("must specify at least one recommendation to delete")
+	}
+
+	aclObj, err := r.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if err := r.checkDismiss(aclObj, args.IDs); err != nil {
+		return err
+	}
+
+	// Update via Raft
+	_, index, err := r.srv.raftApply(structs.RecommendationDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// checkDismiss returns an error if any of the recommendations doesn't exist or
+// the token isn't allowed to dismiss it.
+func (r *Recommendation) checkDismiss(aclObj *acl.ACL, ids []string) error {
+	for _, id := range ids {
+		rec, err := r.srv.State().RecommendationByID(nil, id)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			return fmt.Errorf("recommendation %s not found", id)
+		}
+		if !aclObj.AllowNsOp(rec.Namespace, acl.NamespaceCapabilitySubmitRecommendation) &&
+			!aclObj.AllowNsOp(rec.Namespace, acl.NamespaceCapabilitySubmitJob) {
+			return structs.ErrPermissionDenied
+		}
+	}
+	return nil
+}
+
+// ApplyRecommendations is used to apply and dismiss a set of recommendations.
+// The recommendations applied to each job are submitted together as a new
+// version of the job, and are dismissed once it is registered.
+func (r *Recommendation) ApplyRecommendations(args *structs.RecommendationApplyRequest,
+	reply *structs.RecommendationApplyResponse) error {
+
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Recommendation.ApplyRecommendations", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("recommendation", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "recommendation", "apply_recommendations"}, time.Now())
+
+	if !ServersMeetMinimumVersion(
+		r.srv.serf.Members(), r.srv.Region(), minRecommendationVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to apply recommendations", minRecommendationVersion)
+	}
+
+	if len(args.Apply) == 0 && len(args.Dismiss) == 0 {
+		return fmt.Errorf("must specify at least one recommendation to apply or dismiss")
+	}
+
+	aclObj, err := r.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	// Group the recommendations to apply by job
+	type jobKey struct{ namespace, id string }
+	byJob := make(map[jobKey][]*structs.Recommendation)
+	for _, id := range args.Apply {
+		rec, err := r.srv.State().RecommendationByID(nil, id)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			return fmt.Errorf("recommendation %s not found", id)
+		}
+		if !aclObj.AllowNsOp(rec.Namespace, acl.NamespaceCapabilitySubmitJob) {
+			return structs.ErrPermissionDenied
+		}
+		key := jobKey{rec.Namespace, rec.JobID}
+		byJob[key] = append(byJob[key], rec)
+	}
+	if err := r.checkDismiss(aclObj, args.Dismiss); err != nil {
+		return err
+	}
+
+	keys := make([]jobKey, 0, len(byJob))
+	for key := range byJob {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].namespace != keys[j].namespace {
+			return keys[i].namespace < keys[j].namespace
+		}
+		return keys[i].id < keys[j].id
+	})
+
+	for _, key := range keys {
+		recs := byJob[key]
+		ids := make([]string, 0, len(recs))
+		for _, rec := range recs {
+			ids = append(ids, rec.ID)
+		}
+
+		resp, err := r.applyToJob(args, key.namespace, key.id, recs)
+		if err != nil {
+			reply.Errors = append(reply.Errors, &structs.SingleRecommendationApplyError{
+				Namespace:       key.namespace,
+				JobID:           key.id,
+				Recommendations: ids,
+				Error:           err.Error(),
+			})
+			continue
+		}
+
+		reply.UpdatedJobs = append(reply.UpdatedJobs, &structs.SingleRecommendationApplyResult{
+			Namespace:       key.namespace,
+			JobID:           key.id,
+			JobModifyIndex:  resp.JobModifyIndex,
+			EvalID:          resp.EvalID,
+			EvalCreateIndex: resp.EvalCreateIndex,
+			Warnings:        resp.Warnings,
+			Recommendations: ids,
+		})
+		reply.Index = max(reply.Index, resp.Index)
+	}
+
+	// Recommendations dismissed by registering a job are already gone
+	dismiss := make([]string, 0, len(args.Dismiss))
+	for _, id := range args.Dismiss {
+		rec, err := r.srv.State().RecommendationByID(nil, id)
+		if err != nil {
+			return err
+		}
+		if rec != nil {
+			dismiss = append(dismiss, id)
+		}
+	}
+	if len(dismiss) > 0 {
+		req := &structs.RecommendationDeleteRequest{
+			IDs:          dismiss,
+			WriteRequest: args.WriteRequest,
+		}
+		_, index, err := r.srv.raftApply(structs.RecommendationDeleteRequestType, req)
+		if err != nil {
+			return err
+		}
+		reply.Index = max(reply.Index, index)
+	}
+	return nil
+}
+
+// applyToJob registers a new version of the job with the recommendations
+// applied. Registering the job dismisses the recommendations, since the job
+// now uses the recommended values.
+func (r *Recommendation) applyToJob(args *structs.RecommendationApplyRequest, namespace, jobID string,
+	recs []*structs.Recommendation) (*structs.JobRegisterResponse, error) {
+
+	job, err := r.srv.State().JobByID(nil, namespace, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, fmt.Errorf("job not found")
+	}
+
+	job = job.Copy()
+	for _, rec := range recs {
+		if rec.EnforceVersion && rec.JobVersion != job.Version {
+			return nil, fmt.Errorf("recommendation %s is for version %d of the job, but the job is at version %d",
+				rec.ID, rec.JobVersion, job.Version)
+		}
+		task, err := rec.Target(job)
+		if err != nil {
+			return nil, err
+		}
+		rec.ApplyTo(task)
+	}
+
+	reg := &structs.JobRegisterRequest{
+		Job:            job,
+		EnforceIndex:   true,
+		JobModifyIndex: job.JobModifyIndex,
+		PolicyOverride: args.PolicyOverride,
+		WriteRequest:   args.WriteRequest,
+	}
+	reg.Namespace = namespace
+
+	var resp structs.JobRegisterResponse
+	if err := NewJobEndpoints(r.srv, r.ctx).Register(reg, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// setIndex sets the index of the reply to the last index that affected the
+// recommendation table.
+func (r *Recommendation) setIndex(store *state.StateStore, reply *structs.QueryMeta) error {
+	index, err := store.Index(state.TableRecommendations)
+	if err != nil {
+		return err
+	}
+
+	// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+	// We floor the index at one, since realistically the first write must have a higher index.
+	reply.Index = max(1, index)
+	return nil
+}
+
+// allowRecommendationRead returns whether the token is allowed to read the
+// recommendations in the namespace.
+func allowRecommendationRead(aclObj *acl.ACL, namespace string) bool {
+	return aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityReadJob) ||
+		aclObj.AllowNsOp(namespace, acl.NamespaceCapabilitySubmitRecommendation) ||
+		aclObj.AllowNsOp(namespace, acl.NamespaceCapabilitySubmitJob)
+}
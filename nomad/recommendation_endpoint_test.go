@@ -0,0 +1,279 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestRecommendationEndpoint_UpsertRecommendation(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	job := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	rec := mock.Recommendation(job)
+	rec.ID = ""
+	rec.Namespace = ""
+	req := &structs.RecommendationUpsertRequest{
+		Recommendations: []*structs.Recommendation{rec},
+		WriteRequest:    structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.RecommendationUpsertResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.UpsertRecommendation", req, &resp))
+	must.NonZero(t, resp.Index)
+	must.Len(t, 1, resp.Recommendations)
+
+	out := resp.Recommendations[0]
+	must.UUIDv4(t, out.ID)
+	must.Eq(t, structs.DefaultNamespace, out.Namespace)
+	must.Eq(t, 500, out.Current)
+	must.NonZero(t, out.SubmitTime)
+
+	// Upserting a recommendation for the same target updates it in place
+	rec = mock.Recommendation(job)
+	rec.ID = ""
+	rec.Value = 750
+	req.Recommendations = []*structs.Recommendation{rec}
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.UpsertRecommendation", req, &resp))
+	must.Eq(t, out.ID, resp.Recommendations[0].ID)
+	must.Eq(t, 750, resp.Recommendations[0].Value)
+
+	// Invalid recommendations are rejected
+	invalid := mock.Recommendation(job)
+	invalid.Resource = "DiskMB"
+	req.Recommendations = []*structs.Recommendation{invalid}
+	err := msgpackrpc.CallWithCodec(codec, "Recommendation.UpsertRecommendation", req, &resp)
+	must.ErrorContains(t, err, `got "DiskMB"`)
+
+	// Recommendations for unknown jobs are rejected
+	unknown := mock.Recommendation(mock.Job())
+	req.Recommendations = []*structs.Recommendation{unknown}
+	err = msgpackrpc.CallWithCodec(codec, "Recommendation.UpsertRecommendation", req, &resp)
+	must.ErrorContains(t, err, "not found")
+}
+
+func TestRecommendationEndpoint_ListGetRecommendations(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	job1, job2 := mock.Job(), mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job1))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, job2))
+
+	r1 := mock.Recommendation(job1)
+	r2 := mock.Recommendation(job1)
+	r2.Resource = structs.RecommendationResourceMemoryMB
+	r2.Value = 512
+	r3 := mock.Recommendation(job2)
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1002, []*structs.Recommendation{r1, r2, r3}))
+
+	list := &structs.RecommendationListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var listResp structs.RecommendationListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp))
+	must.Len(t, 3, listResp.Recommendations)
+	must.Eq(t, 1002, listResp.Index)
+
+	// Filter by job
+	list.JobID = job1.ID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp))
+	must.Len(t, 2, listResp.Recommendations)
+
+	// Filter by task
+	list.Group = job1.TaskGroups[0].Name
+	list.Task = "unknown"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp))
+	must.Len(t, 0, listResp.Recommendations)
+
+	get := &structs.RecommendationSpecificRequest{
+		RecommendationID: r3.ID,
+		QueryOptions:     structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.SingleRecommendationResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.GetRecommendation", get, &getResp))
+	must.NotNil(t, getResp.Recommendation)
+	must.Eq(t, job2.ID, getResp.Recommendation.JobID)
+	must.Eq(t, 1002, getResp.Index)
+
+	get.RecommendationID = "2c13f001-f5b6-ce36-03a5-e37afe160df5"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.GetRecommendation", get, &getResp))
+	must.Nil(t, getResp.Recommendation)
+}
+
+func TestRecommendationEndpoint_ListGetRecommendations_ACL(t *testing.T) {
+	ci.Parallel(t)
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	job := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+	rec := mock.Recommendation(job)
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1001, []*structs.Recommendation{rec}))
+
+	invalidToken := mock.CreatePolicyAndToken(t, store, 1002, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
+	validToken := mock.CreatePolicyAndToken(t, store, 1003, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitRecommendation}))
+
+	list := &structs.RecommendationListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			AuthToken: invalidToken.SecretID,
+		},
+	}
+	var listResp structs.RecommendationListResponse
+	err := msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	get := &structs.RecommendationSpecificRequest{
+		RecommendationID: rec.ID,
+		QueryOptions:     structs.QueryOptions{Region: "global", AuthToken: invalidToken.SecretID},
+	}
+	var getResp structs.SingleRecommendationResponse
+	err = msgpackrpc.CallWithCodec(codec, "Recommendation.GetRecommendation", get, &getResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	list.AuthToken = validToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp))
+	must.Len(t, 1, listResp.Recommendations)
+
+	get.AuthToken = validToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.GetRecommendation", get, &getResp))
+	must.Eq(t, rec.ID, getResp.Recommendation.ID)
+
+	// Listing across namespaces only returns the allowed namespaces
+	list.Namespace = structs.AllNamespacesSentinel
+	list.AuthToken = invalidToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp))
+	must.Len(t, 0, listResp.Recommendations)
+
+	list.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.ListRecommendations", list, &listResp))
+	must.Len(t, 1, listResp.Recommendations)
+}
+
+func TestRecommendationEndpoint_ApplyRecommendations(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	job := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	cpu := mock.Recommendation(job)
+	mem := mock.Recommendation(job)
+	mem.Resource = structs.RecommendationResourceMemoryMB
+	mem.Value = 512
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1001, []*structs.Recommendation{cpu, mem}))
+
+	req := &structs.RecommendationApplyRequest{
+		Apply:        []string{cpu.ID},
+		Dismiss:      []string{mem.ID},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.RecommendationApplyResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.ApplyRecommendations", req, &resp))
+	must.SliceEmpty(t, resp.Errors)
+	must.Len(t, 1, resp.UpdatedJobs)
+	must.Eq(t, job.ID, resp.UpdatedJobs[0].JobID)
+	must.Eq(t, []string{cpu.ID}, resp.UpdatedJobs[0].Recommendations)
+	must.UUIDv4(t, resp.UpdatedJobs[0].EvalID)
+	must.NonZero(t, resp.Index)
+
+	// A new version of the job uses the recommended CPU
+	out, err := store.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, 1, out.Version)
+	must.Eq(t, 1000, out.TaskGroups[0].Tasks[0].Resources.CPU)
+	must.Eq(t, 256, out.TaskGroups[0].Tasks[0].Resources.MemoryMB)
+
+	// Both recommendations are gone
+	iter, err := store.RecommendationsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Nil(t, iter.Next())
+
+	// Recommendations that only apply to a previous version of the job are
+	// dismissed by a new version
+	enforced := mock.Recommendation(out)
+	enforced.EnforceVersion = true
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, resp.Index+1, []*structs.Recommendation{enforced}))
+
+	out = out.Copy()
+	out.Meta = map[string]string{"version": "2"}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, resp.Index+2, nil, out))
+
+	req.Apply = []string{enforced.ID}
+	req.Dismiss = nil
+	err = msgpackrpc.CallWithCodec(codec, "Recommendation.ApplyRecommendations", req, &resp)
+	must.ErrorContains(t, err, "not found")
+}
+
+func TestRecommendationEndpoint_DeleteRecommendations(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	job := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+	rec := mock.Recommendation(job)
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1001, []*structs.Recommendation{rec}))
+
+	req := &structs.RecommendationDeleteRequest{
+		IDs:          []string{rec.ID},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.DeleteRecommendations", req, &resp))
+	must.NonZero(t, resp.Index)
+
+	got, err := store.RecommendationByID(nil, rec.ID)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	// Unknown recommendations can't be deleted
+	err = msgpackrpc.CallWithCodec(codec, "Recommendation.DeleteRecommendations", req, &resp)
+	must.ErrorContains(t, err, "not found")
+}
@@ -0,0 +1,341 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// recommendationInterval is the interval at which the leader recomputes
+	// recommendations from the task usage reported by clients.
+	recommendationInterval = 5 * time.Minute
+
+	// recommendationHistory is how long reported task usage is kept.
+	recommendationHistory = 24 * time.Hour
+
+	// recommendationMaxReports bounds the number of reports kept per task.
+	recommendationMaxReports = 4096
+
+	// recommendationMinReports is the number of reports made under the
+	// current resources of a task required before recommending new ones.
+	recommendationMinReports = 12
+
+	// recommendationHeadroom is the fraction of the observed usage added on
+	// top of it when recommending a resource.
+	recommendationHeadroom = 0.2
+
+	// recommendationThreshold is the fraction of the current value of a
+	// resource the recommended value must differ by to be recommended.
+	recommendationThreshold = 0.1
+
+	// recommenderSource is the value of the "source" meta key of the
+	// recommendations made by the recommender. Recommendations submitted by
+	// other sources are never replaced or dismissed by it.
+	recommenderSource = "nomad"
+)
+
+// usageTarget is a task whose usage is reported.
+type usageTarget struct {
+	namespace string
+	jobID     string
+	group     string
+	task      string
+}
+
+// usageReport is a client's report of the usage of a task, along with the
+// resources the task was allocated at the time.
+type usageReport struct {
+	time          time.Time
+	cpu           float64
+	memoryMB      float64
+	allocCPU      int64
+	allocMemoryMB int64
+}
+
+// recommender computes CPU and memory recommendations for tasks from the usage
+// reported by clients. The usage history is only kept in memory on the
+// leader, so it starts over after a leader election.
+type recommender struct {
+	srv    *Server
+	logger hclog.Logger
+
+	enabled bool
+	history map[usageTarget][]usageReport
+	l       sync.Mutex
+}
+
+func newRecommender(srv *Server) *recommender {
+	return &recommender{
+		srv:     srv,
+		logger:  srv.logger.Named("recommender"),
+		history: make(map[usageTarget][]usageReport),
+	}
+}
+
+// SetEnabled enables or disables recording usage. The usage history is
+// dropped either way.
+func (r *recommender) SetEnabled(enabled bool) {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	r.enabled = enabled
+	r.history = make(map[usageTarget][]usageReport)
+}
+
+// Record adds the usage reported for a task of the allocation to the history.
+func (r *recommender) Record(alloc *structs.Allocation, usage *structs.TaskUsage, now time.Time) {
+	if usage.Samples == 0 || alloc.AllocatedResources == nil {
+		return
+	}
+	tr, ok := alloc.AllocatedResources.Tasks[usage.Task]
+	if !ok {
+		return
+	}
+
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	if !r.enabled {
+		return
+	}
+
+	target := usageTarget{alloc.Namespace, alloc.JobID, alloc.TaskGroup, usage.Task}
+	reports := append(r.history[target], usageReport{
+		time:          now,
+		cpu:           usage.CPU,
+		memoryMB:      usage.MemoryMB,
+		allocCPU:      tr.Cpu.CpuShares,
+		allocMemoryMB: tr.Memory.MemoryMB,
+	})
+	if len(reports) > recommendationMaxReports {
+		reports = reports[len(reports)-recommendationMaxReports:]
+	}
+	r.history[target] = reports
+}
+
+// run periodically recomputes the recommendations until stopCh is closed.
+func (r *recommender) run(stopCh chan struct{}) {
+	ticker := time.NewTicker(recommendationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			if err := r.recommend(time.Now()); err != nil {
+				r.logger.Error("failed to update recommendations", "error", err)
+			}
+		}
+	}
+}
+
+// recommend computes the recommendations and commits the ones that changed.
+func (r *recommender) recommend(now time.Time) error {
+	snap, err := r.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	upserts, deletes, err := r.compute(snap, now)
+	if err != nil {
+		return err
+	}
+
+	// Older servers can't apply the recommendation raft messages
+	if !ServersMeetMinimumVersion(
+		r.srv.serf.Members(), r.srv.Region(), minRecommendationVersion, true) {
+		r.logger.Trace("all servers must be upgraded to 1.9.0 before recommendations are written")
+		return nil
+	}
+
+	if len(upserts) > 0 {
+		req := &structs.RecommendationUpsertRequest{
+			Recommendations: upserts,
+			WriteRequest:    structs.WriteRequest{Region: r.srv.Region()},
+		}
+		if _, _, err := r.srv.raftApply(structs.RecommendationUpsertRequestType, req); err != nil {
+			return err
+		}
+	}
+	if len(deletes) > 0 {
+		req := &structs.RecommendationDeleteRequest{
+			IDs:          deletes,
+			WriteRequest: structs.WriteRequest{Region: r.srv.Region()},
+		}
+		if _, _, err := r.srv.raftApply(structs.RecommendationDeleteRequestType, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compute returns the recommendations to upsert and the IDs of the
+// recommendations to dismiss because the task is already sized correctly. It
+// prunes the history of reports that are too old or for tasks that are gone.
+func (r *recommender) compute(snap *state.StateSnapshot, now time.Time) ([]*structs.Recommendation, []string, error) {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	var upserts []*structs.Recommendation
+	var deletes []string
+
+	cutoff := now.Add(-recommendationHistory)
+	for target, reports := range r.history {
+		i := 0
+		for i < len(reports) && reports[i].time.Before(cutoff) {
+			i++
+		}
+		reports = reports[i:]
+
+		job, err := snap.JobByID(nil, target.namespace, target.jobID)
+		if err != nil {
+			return nil, nil, err
+		}
+		var task *structs.Task
+		if job != nil && !job.Stopped() {
+			if tg := job.LookupTaskGroup(target.group); tg != nil {
+				task = tg.LookupTask(target.task)
+			}
+		}
+		if len(reports) == 0 || task == nil || task.Resources == nil {
+			delete(r.history, target)
+			continue
+		}
+		r.history[target] = reports
+
+		existing, err := r.existing(snap, target)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, resource := range []string{structs.RecommendationResourceCPU, structs.RecommendationResourceMemoryMB} {
+			prev := existing[resource]
+			if prev != nil && prev.Meta["source"] != recommenderSource {
+				continue
+			}
+
+			// Tasks with reserved cores use whole cores
+			if resource == structs.RecommendationResourceCPU && task.Resources.Cores > 0 {
+				continue
+			}
+
+			rec := &structs.Recommendation{
+				Region:    r.srv.Region(),
+				Namespace: target.namespace,
+				JobID:     target.jobID,
+				Group:     target.group,
+				Task:      target.task,
+				Resource:  resource,
+			}
+			current := rec.CurrentValue(task)
+
+			value, stats, n, ok := recommendResource(resource, current, reports)
+			if n < recommendationMinReports {
+				continue
+			}
+			if !ok {
+				if prev != nil {
+					deletes = append(deletes, prev.ID)
+				}
+				continue
+			}
+			if prev != nil && prev.Value == value {
+				continue
+			}
+
+			rec.ID = uuid.Generate()
+			if prev != nil {
+				rec.ID = prev.ID
+			}
+			rec.Value = value
+			rec.Stats = stats
+			rec.Meta = map[string]interface{}{
+				"source":      recommenderSource,
+				"num_reports": n,
+			}
+			rec.SubmitTime = now.UnixNano()
+			upserts = append(upserts, rec)
+		}
+	}
+	return upserts, deletes, nil
+}
+
+// existing returns the recommendations for the task by resource.
+func (r *recommender) existing(snap *state.StateSnapshot, target usageTarget) (map[string]*structs.Recommendation, error) {
+	iter, err := snap.RecommendationsByJob(nil, target.namespace, target.jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]*structs.Recommendation)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		rec := raw.(*structs.Recommendation)
+		if rec.Group == target.group && rec.Task == target.task {
+			out[rec.Resource] = rec
+		}
+	}
+	return out, nil
+}
+
+// recommendResource computes the recommended value of the resource from the
+// reports made while the task was allocated its current value. CPU is sized
+// for the 95th percentile of the reported usage and memory for the peak, plus
+// headroom. It returns the value, the stats of the reports it was computed
+// from and their number, and whether the value differs enough from the current
+// one to be recommended.
+func recommendResource(resource string, current int, reports []usageReport) (int, map[string]float64, int, bool) {
+	var values []float64
+	for _, report := range reports {
+		switch resource {
+		case structs.RecommendationResourceCPU:
+			if report.allocCPU == int64(current) {
+				values = append(values, report.cpu)
+			}
+		case structs.RecommendationResourceMemoryMB:
+			if report.allocMemoryMB == int64(current) {
+				values = append(values, report.memoryMB)
+			}
+		}
+	}
+	if len(values) == 0 {
+		return 0, nil, 0, false
+	}
+	slices.Sort(values)
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	// Nearest-rank percentile
+	p95 := values[int(math.Ceil(0.95*float64(len(values))))-1]
+	stats := map[string]float64{
+		"min":  values[0],
+		"max":  values[len(values)-1],
+		"mean": sum / float64(len(values)),
+		"p95":  p95,
+	}
+
+	minResources := structs.MinResources()
+	var value int
+	switch resource {
+	case structs.RecommendationResourceCPU:
+		value = max(int(math.Ceil(p95*(1+recommendationHeadroom))), minResources.CPU)
+	case structs.RecommendationResourceMemoryMB:
+		value = max(int(math.Ceil(stats["max"]*(1+recommendationHeadroom))), minResources.MemoryMB)
+	}
+
+	diff := math.Abs(float64(value - current))
+	return value, stats, len(values), diff > recommendationThreshold*float64(current)
+}
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestRecommender_recommendResource(t *testing.T) {
+	ci.Parallel(t)
+
+	reports := func(n int, cpu, mem float64) []usageReport {
+		out := make([]usageReport, 0, n)
+		for i := 0; i < n; i++ {
+			out = append(out, usageReport{
+				cpu:           cpu + float64(i),
+				memoryMB:      mem + float64(i),
+				allocCPU:      500,
+				allocMemoryMB: 256,
+			})
+		}
+		return out
+	}
+
+	testCases := []struct {
+		name      string
+		resource  string
+		current   int
+		reports   []usageReport
+		expValue  int
+		expN      int
+		expChange bool
+	}{
+		{
+			name:      "cpu p95 with headroom",
+			resource:  structs.RecommendationResourceCPU,
+			current:   500,
+			reports:   reports(20, 100, 0),
+			expValue:  142, // ceil(118 * 1.2)
+			expN:      20,
+			expChange: true,
+		},
+		{
+			name:      "memory peak with headroom",
+			resource:  structs.RecommendationResourceMemoryMB,
+			current:   256,
+			reports:   reports(20, 0, 300),
+			expValue:  383, // ceil(319 * 1.2)
+			expN:      20,
+			expChange: true,
+		},
+		{
+			name:      "within threshold",
+			resource:  structs.RecommendationResourceCPU,
+			current:   500,
+			reports:   reports(1, 400, 0),
+			expValue:  480,
+			expN:      1,
+			expChange: false,
+		},
+		{
+			name:      "floored at the minimum",
+			resource:  structs.RecommendationResourceMemoryMB,
+			current:   256,
+			reports:   reports(1, 0, 1),
+			expValue:  10,
+			expN:      1,
+			expChange: true,
+		},
+		{
+			name:     "reports for other resources are ignored",
+			resource: structs.RecommendationResourceCPU,
+			current:  1000,
+			reports:  reports(20, 100, 0),
+			expN:     0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, _, n, ok := recommendResource(tc.resource, tc.current, tc.reports)
+			must.Eq(t, tc.expN, n)
+			if n == 0 {
+				return
+			}
+			must.Eq(t, tc.expValue, value)
+			must.Eq(t, tc.expChange, ok)
+		})
+	}
+}
+
+func TestRecommender_compute(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	alloc := mock.Alloc()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, alloc.Job))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	r := newRecommender(s1)
+	r.SetEnabled(true)
+	now := time.Now()
+
+	// Too few reports don't yield a recommendation
+	for i := 0; i < recommendationMinReports-1; i++ {
+		r.Record(alloc, &structs.TaskUsage{AllocID: alloc.ID, Task: "web", CPU: 100, MemoryMB: 300, Samples: 10}, now)
+	}
+	snap, err := store.Snapshot()
+	must.NoError(t, err)
+	upserts, deletes, err := r.compute(snap, now)
+	must.NoError(t, err)
+	must.SliceEmpty(t, upserts)
+	must.SliceEmpty(t, deletes)
+
+	r.Record(alloc, &structs.TaskUsage{AllocID: alloc.ID, Task: "web", CPU: 100, MemoryMB: 300, Samples: 10}, now)
+	upserts, deletes, err = r.compute(snap, now)
+	must.NoError(t, err)
+	must.SliceEmpty(t, deletes)
+	must.Len(t, 2, upserts)
+	for _, rec := range upserts {
+		must.Eq(t, recommenderSource, rec.Meta["source"])
+		switch rec.Resource {
+		case structs.RecommendationResourceCPU:
+			must.Eq(t, 120, rec.Value)
+		case structs.RecommendationResourceMemoryMB:
+			must.Eq(t, 360, rec.Value)
+		}
+	}
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1002, upserts))
+
+	// Unchanged recommendations are not upserted again
+	snap, err = store.Snapshot()
+	must.NoError(t, err)
+	upserts, deletes, err = r.compute(snap, now)
+	must.NoError(t, err)
+	must.SliceEmpty(t, upserts)
+	must.SliceEmpty(t, deletes)
+
+	// Recommendations submitted by other sources are left alone
+	existing, err := r.existing(snap, usageTarget{alloc.Namespace, alloc.JobID, alloc.TaskGroup, "web"})
+	must.NoError(t, err)
+	other := existing[structs.RecommendationResourceCPU].Copy()
+	other.Meta = map[string]interface{}{"source": "test"}
+	other.Value = 2000
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1003, []*structs.Recommendation{other}))
+
+	snap, err = store.Snapshot()
+	must.NoError(t, err)
+	upserts, _, err = r.compute(snap, now)
+	must.NoError(t, err)
+	must.SliceEmpty(t, upserts)
+
+	// Reports older than the history are pruned
+	_, _, err = r.compute(snap, now.Add(recommendationHistory+time.Minute))
+	must.NoError(t, err)
+	must.MapEmpty(t, r.history)
+}
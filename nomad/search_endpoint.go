@@ -41,6 +41,8 @@ var (
 		structs.ScalingPolicies,
 		structs.Variables,
 		structs.Namespaces,
+		structs.Quotas,
+		structs.Recommendations,
 	}
 )
 
@@ -90,6 +92,10 @@ func (s *Search) getPrefixMatches(iter memdb.ResultIterator, prefix string) ([]s
 			id = t.Name
 		case *structs.VariableEncrypted:
 			id = t.Path
+		case *structs.QuotaSpec:
+			id = t.Name
+		case *structs.Recommendation:
+			id = t.ID
 		default:
 			matchID, ok := getEnterpriseMatch(raw)
 			if !ok {
@@ -237,6 +243,9 @@ func (s *Search) fuzzyMatchSingle(raw interface{}, text string) (structs.Context
 		name = t.Path
 		scope = []string{t.Namespace, t.Path}
 		ctx = structs.Variables
+	case *structs.QuotaSpec:
+		name = t.Name
+		ctx = structs.Quotas
 	}
 
 	if idx := fuzzyIndex(name, text); idx >= 0 {
@@ -403,6 +412,8 @@ func getResourceIter(context structs.Context, aclObj *acl.ACL, namespace, prefix
 		return store.CSIPluginsByIDPrefix(ws, prefix)
 	case structs.ScalingPolicies:
 		return store.ScalingPoliciesByIDPrefix(ws, namespace, prefix)
+	case structs.Recommendations:
+		return store.RecommendationsByIDPrefix(ws, namespace, prefix)
 	case structs.Volumes:
 		return store.CSIVolumesByIDPrefix(ws, namespace, prefix)
 	case structs.Namespaces:
@@ -417,6 +428,12 @@ func getResourceIter(context structs.Context, aclObj *acl.ACL, namespace, prefix
 			return nil, err
 		}
 		return memdb.NewFilterIterator(iter, nsCapFilter(aclObj)), nil
+	case structs.Quotas:
+		iter, err := store.QuotaSpecsByNamePrefix(ws, prefix)
+		if err != nil {
+			return nil, err
+		}
+		return memdb.NewFilterIterator(iter, nsCapFilter(aclObj)), nil
 	default:
 		return getEnterpriseResourceIter(context, aclObj, namespace, prefix, ws, store)
 	}
@@ -481,6 +498,10 @@ func getFuzzyResourceIterator(context structs.Context, aclObj *acl.ACL, namespac
 		iter, err := store.Namespaces(ws)
 		return nsCapIterFilter(iter, err, aclObj)
 
+	case structs.Quotas:
+		iter, err := store.QuotaSpecs(ws)
+		return nsCapIterFilter(iter, err, aclObj)
+
 	default:
 		return getEnterpriseFuzzyResourceIter(context, aclObj, namespace, ws, store)
 	}
@@ -519,6 +540,9 @@ func nsCapFilter(aclObj *acl.ACL) memdb.FilterFunc {
 		case *structs.CSIPlugin:
 			return !aclObj.AllowPluginRead()
 
+		case *structs.QuotaSpec:
+			return !aclObj.AllowQuotaRead()
+
 		default:
 			return false
 		}
@@ -899,7 +923,7 @@ func filteredSearchContexts(aclObj *acl.ACL, namespace string, context structs.C
 	available := make([]structs.Context, 0, len(desired))
 	for _, c := range desired {
 		switch c {
-		case structs.Allocs, structs.Jobs, structs.Evals, structs.Deployments:
+		case structs.Allocs, structs.Jobs, structs.Evals, structs.Deployments, structs.Recommendations:
 			if jobRead {
 				available = append(available, c)
 			}
@@ -931,6 +955,10 @@ func filteredSearchContexts(aclObj *acl.ACL, namespace string, context structs.C
 			if aclObj.AllowPluginList() {
 				available = append(available, c)
 			}
+		case structs.Quotas:
+			if aclObj.AllowQuotaRead() {
+				available = append(available, c)
+			}
 		default:
 			if ok := filteredSearchContextsEnt(aclObj, namespace, c); ok {
 				available = append(available, c)
@@ -27,6 +27,8 @@ func contextToIndex(ctx structs.Context) string {
 	// Handle cases where context name and state store table name do not match
 	case structs.Variables:
 		return state.TableVariables
+	case structs.Quotas:
+		return state.TableQuotaSpec
 	default:
 		return string(ctx)
 	}
@@ -1071,6 +1071,35 @@ func TestSearch_PrefixSearch_Namespace(t *testing.T) {
 	require.Equal(t, uint64(2000), resp.Index)
 }
 
+func TestSearch_PrefixSearch_Quota(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanup := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanup()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	spec := mock.QuotaSpec()
+	must.NoError(t, s.fsm.State().UpsertQuotaSpecs(structs.MsgTypeTestSetup, 2000, []*structs.QuotaSpec{spec}))
+
+	req := &structs.SearchRequest{
+		Prefix:  spec.Name[:len(spec.Name)-2],
+		Context: structs.Quotas,
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+
+	var resp structs.SearchResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Search.PrefixSearch", req, &resp))
+
+	must.Eq(t, []string{spec.Name}, resp.Matches[structs.Quotas])
+	must.False(t, resp.Truncations[structs.Quotas])
+	must.Eq(t, 2000, resp.Index)
+}
+
 func TestSearch_PrefixSearch_Namespace_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1240,6 +1269,45 @@ func TestSearch_PrefixSearch_ScalingPolicy(t *testing.T) {
 	require.Equal(t, uint64(jobIndex), resp.Index)
 }
 
+func TestSearch_PrefixSearch_Recommendation(t *testing.T) {
+	ci.Parallel(t)
+
+	s, cleanupS := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	job := mock.Job()
+	rec := mock.Recommendation(job)
+	prefix := rec.ID[:6]
+	fsmState := s.fsm.State()
+
+	require.NoError(t, fsmState.UpsertJob(structs.MsgTypeTestSetup, jobIndex, nil, job))
+	require.NoError(t, fsmState.UpsertRecommendations(structs.MsgTypeTestSetup, jobIndex+1, []*structs.Recommendation{rec}))
+
+	req := &structs.SearchRequest{
+		Prefix:  prefix,
+		Context: structs.Recommendations,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	var resp structs.SearchResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Search.PrefixSearch", req, &resp))
+	require.Len(t, resp.Matches[structs.Recommendations], 1)
+	require.Equal(t, rec.ID, resp.Matches[structs.Recommendations][0])
+	require.Equal(t, uint64(jobIndex+1), resp.Index)
+
+	req.Context = structs.All
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Search.PrefixSearch", req, &resp))
+	require.Len(t, resp.Matches[structs.Recommendations], 1)
+	require.Equal(t, rec.ID, resp.Matches[structs.Recommendations][0])
+}
+
 func TestSearch_FuzzySearch_ACL(t *testing.T) {
 	ci.Parallel(t)
 
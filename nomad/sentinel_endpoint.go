@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/sentinel"
+)
+
+// Sentinel endpoint is used for manipulating Sentinel policies. All of its
+// operations require a management token.
+type Sentinel struct {
+	srv *Server
+	ctx *RPCContext
+}
+
+func NewSentinelEndpoint(srv *Server, ctx *RPCContext) *Sentinel {
+	return &Sentinel{srv: srv, ctx: ctx}
+}
+
+// UpsertPolicies is used to upsert a set of policies
+func (s *Sentinel) UpsertPolicies(args *structs.SentinelPolicyUpsertRequest,
+	reply *structs.GenericResponse) error {
+
+	authErr := s.srv.Authenticate(s.ctx, args)
+	if s.srv.config.ACLEnabled || args.Region == "" {
+		// only forward to the authoritative region if ACLs are enabled,
+		// otherwise we silently write to the local region
+		args.Region = s.srv.config.AuthoritativeRegion
+	}
+	if done, err := s.srv.forward("Sentinel.UpsertPolicies", args, args, reply); done {
+		return err
+	}
+	s.srv.MeasureRPCRate("sentinel", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "sentinel", "upsert_policies"}, time.Now())
+
+	// Check management level permissions
+	if aclObj, err := s.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !ServersMeetMinimumVersion(
+		s.srv.serf.Members(), s.srv.Region(), minSentinelPolicyVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to upsert Sentinel policies", minSentinelPolicyVersion)
+	}
+
+	// Validate there is at least one policy
+	if len(args.Policies) == 0 {
+		return fmt.Errorf("must specify at least one policy")
+	}
+
+	// Validate and compile the policies and set the hash
+	for _, policy := range args.Policies {
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("Invalid policy %q: %v", policy.Name, err)
+		}
+		if _, err := sentinel.Compile(policy.Name, policy.Scope, policy.Policy); err != nil {
+			return fmt.Errorf("Failed to compile policy %q: %v", policy.Name, err)
+		}
+
+		policy.SetHash()
+	}
+
+	// Update via Raft
+	_, index, err := s.srv.raftApply(structs.SentinelPolicyUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// DeletePolicies is used to delete a set of policies
+func (s *Sentinel) DeletePolicies(args *structs.SentinelPolicyDeleteRequest,
+	reply *structs.GenericResponse) error {
+
+	authErr := s.srv.Authenticate(s.ctx, args)
+	if s.srv.config.ACLEnabled || args.Region == "" {
+		// only forward to the authoritative region if ACLs are enabled,
+		// otherwise we silently write to the local region
+		args.Region = s.srv.config.AuthoritativeRegion
+	}
+	if done, err := s.srv.forward("Sentinel.DeletePolicies", args, args, reply); done {
+		return err
+	}
+	s.srv.MeasureRPCRate("sentinel", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "sentinel", "delete_policies"}, time.Now())
+
+	// Check management level permissions
+	if aclObj, err := s.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if !ServersMeetMinimumVersion(
+		s.srv.serf.Members(), s.srv.Region(), minSentinelPolicyVersion, true) {
+		return fmt.Errorf("all servers must be running version %v or later to delete Sentinel policies", minSentinelPolicyVersion)
+	}
+
+	// Validate at least one policy
+	if len(args.Names) == 0 {
+		return fmt.Errorf("must specify at least one policy to delete")
+	}
+
+	// Update via Raft
+	_, index, err := s.srv.raftApply(structs.SentinelPolicyDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// ListPolicies is used to list the policies
+func (s *Sentinel) ListPolicies(args *structs.SentinelPolicyListRequest,
+	reply *structs.SentinelPolicyListResponse) error {
+
+	authErr := s.srv.Authenticate(s.ctx, args)
+	if done, err := s.srv.forward("Sentinel.ListPolicies", args, args, reply); done {
+		return err
+	}
+	s.srv.MeasureRPCRate("sentinel", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "sentinel", "list_policies"}, time.Now())
+
+	// Check management level permissions
+	if aclObj, err := s.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			// Iterate over all the policies
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = store.SentinelPoliciesByNamePrefix(ws, prefix)
+			} else {
+				iter, err = store.SentinelPolicies(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			reply.Policies = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				reply.Policies = append(reply.Policies, raw.(*structs.SentinelPolicy))
+			}
+
+			// Use the last index that affected the policy table
+			return s.setIndex(store, &reply.QueryMeta)
+		}}
+	return s.srv.blockingRPC(&opts)
+}
+
+// GetPolicy is used to get a specific policy
+func (s *Sentinel) GetPolicy(args *structs.SentinelPolicySpecificRequest,
+	reply *structs.SingleSentinelPolicyResponse) error {
+
+	authErr := s.srv.Authenticate(s.ctx, args)
+	if done, err := s.srv.forward("Sentinel.GetPolicy", args, args, reply); done {
+		return err
+	}
+	s.srv.MeasureRPCRate("sentinel", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "sentinel", "get_policy"}, time.Now())
+
+	// Check management level permissions
+	if aclObj, err := s.srv.ResolveACL(args); err != nil {
+		return err
+	} else if !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			// Look for the policy
+			out, err := store.SentinelPolicyByName(ws, args.Name)
+			if err != nil {
+				return err
+			}
+
+			// Setup the output
+			reply.Policy = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+				return nil
+			}
+			return s.setIndex(store, &reply.QueryMeta)
+		}}
+	return s.srv.blockingRPC(&opts)
+}
+
+// setIndex sets the index of the reply to the last index that affected the
+// policy table.
+func (s *Sentinel) setIndex(store *state.StateStore, reply *structs.QueryMeta) error {
+	index, err := store.Index(state.TableSentinelPolicies)
+	if err != nil {
+		return err
+	}
+
+	// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+	// We floor the index at one, since realistically the first write must have a higher index.
+	if index == 0 {
+		index = 1
+	}
+	reply.Index = index
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestSentinelEndpoint_UpsertPolicies(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	policy := mock.SentinelPolicy()
+	policy.Hash = nil
+	req := &structs.SentinelPolicyUpsertRequest{
+		Policies:     []*structs.SentinelPolicy{policy},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Sentinel.UpsertPolicies", req, &resp))
+	must.NonZero(t, resp.Index)
+
+	got, err := s1.fsm.State().SentinelPolicyByName(nil, policy.Name)
+	must.NoError(t, err)
+	must.NotNil(t, got)
+	must.NotNil(t, got.Hash)
+	must.Eq(t, resp.Index, got.ModifyIndex)
+
+	// Invalid policies are rejected
+	invalid := mock.SentinelPolicy()
+	invalid.EnforcementLevel = "mandatory"
+	req.Policies = []*structs.SentinelPolicy{invalid}
+	err = msgpackrpc.CallWithCodec(codec, "Sentinel.UpsertPolicies", req, &resp)
+	must.ErrorContains(t, err, `invalid enforcement level "mandatory"`)
+
+	// Policies that don't compile are rejected
+	invalid = mock.SentinelPolicy()
+	invalid.Policy = `rule "a" { condition = node.Name == "a" }`
+	req.Policies = []*structs.SentinelPolicy{invalid}
+	err = msgpackrpc.CallWithCodec(codec, "Sentinel.UpsertPolicies", req, &resp)
+	must.ErrorContains(t, err, `unknown variable "node"`)
+}
+
+func TestSentinelEndpoint_DeletePolicies(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	policy := mock.SentinelPolicy()
+	must.NoError(t, store.UpsertSentinelPolicies(structs.MsgTypeTestSetup, 1000, []*structs.SentinelPolicy{policy}))
+
+	req := &structs.SentinelPolicyDeleteRequest{
+		Names:        []string{policy.Name},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Sentinel.DeletePolicies", req, &resp))
+
+	got, err := store.SentinelPolicyByName(nil, policy.Name)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	// Unknown policies can't be deleted
+	err = msgpackrpc.CallWithCodec(codec, "Sentinel.DeletePolicies", req, &resp)
+	must.ErrorContains(t, err, "not found")
+}
+
+func TestSentinelEndpoint_ListGetPolicies_ACL(t *testing.T) {
+	ci.Parallel(t)
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	store := s1.fsm.State()
+	p1, p2 := mock.SentinelPolicy(), mock.SentinelPolicy()
+	must.NoError(t, store.UpsertSentinelPolicies(structs.MsgTypeTestSetup, 1000, []*structs.SentinelPolicy{p1, p2}))
+
+	invalidToken := mock.CreatePolicyAndToken(t, store, 1001, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+
+	get := &structs.SentinelPolicySpecificRequest{
+		Name:         p1.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	list := &structs.SentinelPolicyListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	// Lookups without a management token are denied
+	var getResp structs.SingleSentinelPolicyResponse
+	err := msgpackrpc.CallWithCodec(codec, "Sentinel.GetPolicy", get, &getResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	get.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Sentinel.GetPolicy", get, &getResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	var listResp structs.SentinelPolicyListResponse
+	list.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Sentinel.ListPolicies", list, &listResp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Management tokens can read all policies
+	get.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Sentinel.GetPolicy", get, &getResp))
+	must.Eq(t, p1.Name, getResp.Policy.Name)
+	must.Eq(t, 1000, getResp.Index)
+
+	list.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Sentinel.ListPolicies", list, &listResp))
+	must.Len(t, 2, listResp.Policies)
+	must.Eq(t, 1000, listResp.Index)
+
+	// Unknown policies return nothing
+	get.Name = "unknown"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Sentinel.GetPolicy", get, &getResp))
+	must.Nil(t, getResp.Policy)
+}
@@ -46,10 +46,12 @@ import (
 	"github.com/hashicorp/nomad/nomad/lock"
 	"github.com/hashicorp/nomad/nomad/reporting"
 	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/nomad/volumewatcher"
 	"github.com/hashicorp/nomad/scheduler"
+	"github.com/hashicorp/nomad/sentinel"
 )
 
 const (
@@ -71,6 +73,7 @@ const (
 
 	raftState         = "raft/"
 	serfSnapshot      = "serf/snapshot"
+	eventLogDir       = "events"
 	snapshotsRetained = 2
 
 	// serverRPCCache controls how long we keep an idle connection open to a server
@@ -132,6 +135,10 @@ type Server struct {
 	// fsm is the state machine used with Raft
 	fsm *nomadFSM
 
+	// eventLog is the durable log of the event stream. It outlives the state
+	// stores replaced when restoring snapshots, and is nil if not enabled.
+	eventLog *stream.EventLog
+
 	// rpcListener is used to listen for incoming connections
 	rpcListener net.Listener
 	listenerCh  chan struct{}
@@ -224,6 +231,10 @@ type Server struct {
 	// volumeWatcher is used to release volume claims
 	volumeWatcher *volumewatcher.Watcher
 
+	// recommender computes recommendations for the resources of tasks from
+	// the usage reported by clients.
+	recommender *recommender
+
 	// volumeControllerFutures is a map of plugin IDs to pending controller RPCs. If
 	// no RPC is pending for a given plugin, this may be nil.
 	volumeControllerFutures map[string]context.Context
@@ -239,6 +250,9 @@ type Server struct {
 	// workload identities
 	encrypter *Encrypter
 
+	// sentinelPolicies caches the compiled Sentinel policies
+	sentinelPolicies *sentinel.PolicyCache
+
 	// periodicDispatcher is used to track and create evaluations for periodic jobs.
 	periodicDispatcher *PeriodicDispatch
 
@@ -364,6 +378,7 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 		workersEventCh:          make(chan interface{}, 1),
 		lockTTLTimer:            lock.NewTTLTimer(),
 		lockDelayTimer:          lock.NewDelayTimer(),
+		sentinelPolicies:        sentinel.NewPolicyCache(sentinelPolicyCacheSize),
 	}
 
 	s.shutdownCtx, s.shutdownCancel = context.WithCancel(context.Background())
@@ -503,6 +518,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigFunc
 	}
 	s.volumeControllerFutures = map[string]context.Context{}
 
+	// Setup the recommender
+	s.recommender = newRecommender(s)
+
 	// Start the eval broker notification system so any subscribers can get
 	// updates when the processes SetEnabled is triggered.
 	go s.evalBroker.enabledNotifier.Run()
@@ -758,6 +776,13 @@ func (s *Server) Shutdown() error {
 		s.fsm.Close()
 	}
 
+	// Close the event log once the event broker is stopped
+	if s.eventLog != nil {
+		if err := s.eventLog.Close(); err != nil {
+			s.logger.Warn("error closing event log", "error", err)
+		}
+	}
+
 	// Stop Vault token renewal and revocations
 	if s.vault != nil {
 		s.vault.Stop()
@@ -1168,6 +1193,7 @@ func (s *Server) setupDeploymentWatcher() error {
 		raftShim,
 		NewDeploymentEndpoint(s, nil),
 		NewJobEndpoints(s, nil),
+		s.ReplicationToken(),
 		s.config.DeploymentQueryRateLimit,
 		deploymentwatcher.CrossDeploymentUpdateBatchDuration,
 	)
@@ -1336,20 +1362,26 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	_ = server.Register(NewACLEndpoint(s, ctx))
 	_ = server.Register(NewAllocEndpoint(s, ctx))
 	_ = server.Register(NewClientCSIEndpoint(s, ctx))
+	_ = server.Register(NewClientHostVolumeEndpoint(s, ctx))
 	_ = server.Register(NewCSIVolumeEndpoint(s, ctx))
 	_ = server.Register(NewCSIPluginEndpoint(s, ctx))
 	_ = server.Register(NewDeploymentEndpoint(s, ctx))
 	_ = server.Register(NewEvalEndpoint(s, ctx))
+	_ = server.Register(NewHostVolumeEndpoint(s, ctx))
 	_ = server.Register(NewJobEndpoints(s, ctx))
 	_ = server.Register(NewKeyringEndpoint(s, ctx, s.encrypter))
 	_ = server.Register(NewNamespaceEndpoint(s, ctx))
 	_ = server.Register(NewNodeEndpoint(s, ctx))
+	_ = server.Register(newNodeImagesEndpoint(s, ctx))
 	_ = server.Register(NewNodePoolEndpoint(s, ctx))
 	_ = server.Register(NewPeriodicEndpoint(s, ctx))
 	_ = server.Register(NewPlanEndpoint(s, ctx))
+	_ = server.Register(NewQuotaEndpoint(s, ctx))
+	_ = server.Register(NewRecommendationEndpoint(s, ctx))
 	_ = server.Register(NewRegionEndpoint(s, ctx))
 	_ = server.Register(NewScalingEndpoint(s, ctx))
 	_ = server.Register(NewSearchEndpoint(s, ctx))
+	_ = server.Register(NewSentinelEndpoint(s, ctx))
 	_ = server.Register(NewServiceRegistrationEndpoint(s, ctx))
 	_ = server.Register(NewStatusEndpoint(s, ctx))
 	_ = server.Register(NewSystemEndpoint(s, ctx))
@@ -1373,6 +1405,25 @@ func (s *Server) setupRaft() error {
 		}
 	}()
 
+	var err error
+
+	// Open the event log before creating the FSM, so the events published
+	// while replaying the raft log are written to it
+	if s.config.EnableEventBroker && s.config.EventLogEnabled {
+		if s.config.DataDir == "" {
+			return fmt.Errorf("event log requires a data directory")
+		}
+		s.eventLog, err = stream.NewEventLog(stream.EventLogConfig{
+			Dir:      filepath.Join(s.config.DataDir, eventLogDir),
+			MaxBytes: s.config.EventLogMaxBytes,
+			MaxAge:   s.config.EventLogMaxAge,
+			Logger:   s.logger,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to open event log: %w", err)
+		}
+	}
+
 	// Create the FSM
 	fsmConfig := &FSMConfig{
 		EvalBroker:         s.evalBroker,
@@ -1382,9 +1433,9 @@ func (s *Server) setupRaft() error {
 		Region:             s.Region(),
 		EnableEventBroker:  s.config.EnableEventBroker,
 		EventBufferSize:    s.config.EventBufferSize,
+		EventLog:           s.eventLog,
 		JobTrackedVersions: s.config.JobTrackedVersions,
 	}
-	var err error
 	s.fsm, err = NewFSM(fsmConfig)
 	if err != nil {
 		return err
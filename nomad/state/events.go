@@ -41,6 +41,16 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.ServiceRegistrationUpsertRequestType:         structs.TypeServiceRegistration,
 	structs.ServiceRegistrationDeleteByIDRequestType:     structs.TypeServiceDeregistration,
 	structs.ServiceRegistrationDeleteByNodeIDRequestType: structs.TypeServiceDeregistration,
+	structs.VarApplyStateRequestType:                     structs.TypeVariableUpserted,
+	structs.NamespaceUpsertRequestType:                   structs.TypeNamespaceUpserted,
+	structs.NamespaceDeleteRequestType:                   structs.TypeNamespaceDeleted,
+	structs.CSIVolumeRegisterRequestType:                 structs.TypeCSIVolumeUpserted,
+	structs.CSIVolumeDeregisterRequestType:               structs.TypeCSIVolumeDeleted,
+	structs.CSIVolumeClaimRequestType:                    structs.TypeCSIVolumeUpserted,
+	structs.CSIVolumeClaimBatchRequestType:               structs.TypeCSIVolumeUpserted,
+	structs.CSIPluginDeleteRequestType:                   structs.TypeCSIPluginDeleted,
+	structs.RootKeyMetaUpsertRequestType:                 structs.TypeRootKeyUpserted,
+	structs.RootKeyMetaDeleteRequestType:                 structs.TypeRootKeyDeleted,
 }
 
 func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
@@ -52,7 +62,12 @@ func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
 	var events []structs.Event
 	for _, change := range changes.Changes {
 		if event, ok := eventFromChange(change); ok {
-			event.Type = eventType
+			// Objects that are also written as a side effect of other
+			// requests, such as the scaling policies of a job, set their own
+			// event type.
+			if event.Type == "" {
+				event.Type = eventType
+			}
 			event.Index = changes.Index
 			events = append(events, event)
 		}
@@ -181,6 +196,93 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 					Service: before,
 				},
 			}, true
+		case TableVariables:
+			before, ok := change.Before.(*structs.VariableEncrypted)
+			if !ok {
+				return structs.Event{}, false
+			}
+			meta := before.VariableMetadata
+			return structs.Event{
+				Topic:     structs.TopicVariables,
+				Type:      structs.TypeVariableDeleted,
+				Key:       before.Path,
+				Namespace: before.Namespace,
+				Payload: &structs.VariableEvent{
+					Variable: &meta,
+				},
+			}, true
+		case TableNamespaces:
+			before, ok := change.Before.(*structs.Namespace)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic:     structs.TopicNamespace,
+				Type:      structs.TypeNamespaceDeleted,
+				Key:       before.Name,
+				Namespace: before.Name,
+				Payload: &structs.NamespaceEvent{
+					Namespace: before,
+				},
+			}, true
+		case "csi_volumes":
+			before, ok := change.Before.(*structs.CSIVolume)
+			if !ok {
+				return structs.Event{}, false
+			}
+
+			before = before.Sanitize()
+			return structs.Event{
+				Topic:      structs.TopicCSIVolume,
+				Type:       structs.TypeCSIVolumeDeleted,
+				Key:        before.ID,
+				Namespace:  before.Namespace,
+				FilterKeys: []string{before.PluginID},
+				Payload: &structs.CSIVolumeEvent{
+					CSIVolume: before,
+				},
+			}, true
+		case "csi_plugins":
+			before, ok := change.Before.(*structs.CSIPlugin)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic: structs.TopicCSIPlugin,
+				Type:  structs.TypeCSIPluginDeleted,
+				Key:   before.ID,
+				Payload: &structs.CSIPluginEvent{
+					CSIPlugin: before,
+				},
+			}, true
+		case "scaling_policy":
+			before, ok := change.Before.(*structs.ScalingPolicy)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic:      structs.TopicScalingPolicy,
+				Type:       structs.TypeScalingPolicyDeleted,
+				Key:        before.ID,
+				Namespace:  before.Target[structs.ScalingTargetNamespace],
+				FilterKeys: []string{before.Target[structs.ScalingTargetJob]},
+				Payload: &structs.ScalingPolicyEvent{
+					ScalingPolicy: before,
+				},
+			}, true
+		case TableRootKeyMeta:
+			before, ok := change.Before.(*structs.RootKeyMeta)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic: structs.TopicRootKey,
+				Type:  structs.TypeRootKeyDeleted,
+				Key:   before.KeyID,
+				Payload: &structs.RootKeyEvent{
+					RootKeyMeta: before,
+				},
+			}, true
 		}
 		return structs.Event{}, false
 	}
@@ -358,6 +460,93 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 				Service: after,
 			},
 		}, true
+	case TableVariables:
+		after, ok := change.After.(*structs.VariableEncrypted)
+		if !ok {
+			return structs.Event{}, false
+		}
+		meta := after.VariableMetadata
+		return structs.Event{
+			Topic:     structs.TopicVariables,
+			Type:      structs.TypeVariableUpserted,
+			Key:       after.Path,
+			Namespace: after.Namespace,
+			Payload: &structs.VariableEvent{
+				Variable: &meta,
+			},
+		}, true
+	case TableNamespaces:
+		after, ok := change.After.(*structs.Namespace)
+		if !ok {
+			return structs.Event{}, false
+		}
+		return structs.Event{
+			Topic:     structs.TopicNamespace,
+			Type:      structs.TypeNamespaceUpserted,
+			Key:       after.Name,
+			Namespace: after.Name,
+			Payload: &structs.NamespaceEvent{
+				Namespace: after,
+			},
+		}, true
+	case "csi_volumes":
+		after, ok := change.After.(*structs.CSIVolume)
+		if !ok {
+			return structs.Event{}, false
+		}
+
+		after = after.Sanitize()
+		return structs.Event{
+			Topic:      structs.TopicCSIVolume,
+			Type:       structs.TypeCSIVolumeUpserted,
+			Key:        after.ID,
+			Namespace:  after.Namespace,
+			FilterKeys: []string{after.PluginID},
+			Payload: &structs.CSIVolumeEvent{
+				CSIVolume: after,
+			},
+		}, true
+	case "csi_plugins":
+		after, ok := change.After.(*structs.CSIPlugin)
+		if !ok {
+			return structs.Event{}, false
+		}
+		return structs.Event{
+			Topic: structs.TopicCSIPlugin,
+			Type:  structs.TypeCSIPluginUpserted,
+			Key:   after.ID,
+			Payload: &structs.CSIPluginEvent{
+				CSIPlugin: after,
+			},
+		}, true
+	case "scaling_policy":
+		after, ok := change.After.(*structs.ScalingPolicy)
+		if !ok {
+			return structs.Event{}, false
+		}
+		return structs.Event{
+			Topic:      structs.TopicScalingPolicy,
+			Type:       structs.TypeScalingPolicyUpserted,
+			Key:        after.ID,
+			Namespace:  after.Target[structs.ScalingTargetNamespace],
+			FilterKeys: []string{after.Target[structs.ScalingTargetJob]},
+			Payload: &structs.ScalingPolicyEvent{
+				ScalingPolicy: after,
+			},
+		}, true
+	case TableRootKeyMeta:
+		after, ok := change.After.(*structs.RootKeyMeta)
+		if !ok {
+			return structs.Event{}, false
+		}
+		return structs.Event{
+			Topic: structs.TopicRootKey,
+			Type:  structs.TypeRootKeyUpserted,
+			Key:   after.KeyID,
+			Payload: &structs.RootKeyEvent{
+				RootKeyMeta: after,
+			},
+		}, true
 	}
 
 	return structs.Event{}, false
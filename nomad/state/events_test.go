@@ -1272,3 +1272,166 @@ func testNodeID() string {
 func testNodeIDTwo() string {
 	return "694ff31d-8c59-4030-ac83-e15692560c8d"
 }
+
+func TestEventsFromChanges_Variables(t *testing.T) {
+	ci.Parallel(t)
+	s := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer s.StopEventBroker()
+
+	sv := mock.VariableEncrypted()
+	resp := s.VarSet(1000, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv})
+	must.NoError(t, resp.Error)
+
+	events := WaitForEvents(t, s, 1000, 1, 1*time.Second)
+	must.Len(t, 1, events)
+
+	e := events[0]
+	must.Eq(t, structs.TopicVariables, e.Topic)
+	must.Eq(t, structs.TypeVariableUpserted, e.Type)
+	must.Eq(t, sv.Path, e.Key)
+	must.Eq(t, sv.Namespace, e.Namespace)
+
+	// Only the metadata is included in the event
+	payload := e.Payload.(*structs.VariableEvent)
+	must.Eq(t, sv.Path, payload.Variable.Path)
+	must.Eq(t, 1000, payload.Variable.ModifyIndex)
+
+	resp = s.VarDelete(1001, &structs.VarApplyStateRequest{Op: structs.VarOpDelete, Var: sv})
+	must.NoError(t, resp.Error)
+
+	events = WaitForEvents(t, s, 1001, 1, 1*time.Second)
+	must.Len(t, 1, events)
+	must.Eq(t, structs.TypeVariableDeleted, events[0].Type)
+	must.Eq(t, sv.Path, events[0].Key)
+}
+
+func TestEventsFromChanges_Namespaces(t *testing.T) {
+	ci.Parallel(t)
+	s := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer s.StopEventBroker()
+
+	ns := mock.Namespace()
+	must.NoError(t, s.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	events := WaitForEvents(t, s, 1000, 1, 1*time.Second)
+	must.Len(t, 1, events)
+
+	e := events[0]
+	must.Eq(t, structs.TopicNamespace, e.Topic)
+	must.Eq(t, structs.TypeNamespaceUpserted, e.Type)
+	must.Eq(t, ns.Name, e.Key)
+	must.Eq(t, ns.Name, e.Namespace)
+
+	payload := e.Payload.(*structs.NamespaceEvent)
+	must.Eq(t, ns.Name, payload.Namespace.Name)
+
+	must.NoError(t, s.DeleteNamespaces(1001, []string{ns.Name}))
+
+	events = WaitForEvents(t, s, 1001, 1, 1*time.Second)
+	must.Len(t, 1, events)
+	must.Eq(t, structs.TypeNamespaceDeleted, events[0].Type)
+	must.Eq(t, ns.Name, events[0].Key)
+}
+
+func TestEventsFromChanges_CSIVolume(t *testing.T) {
+	ci.Parallel(t)
+	s := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer s.StopEventBroker()
+
+	plugin := mock.CSIPlugin()
+	vol := mock.CSIVolume(plugin)
+	vol.Secrets = structs.CSISecrets{"password": "hunter2"}
+	must.NoError(t, s.UpsertCSIVolume(1000, []*structs.CSIVolume{vol}))
+
+	events := WaitForEvents(t, s, 1000, 1, 1*time.Second)
+	must.Len(t, 1, events)
+
+	e := events[0]
+	must.Eq(t, structs.TopicCSIVolume, e.Topic)
+	must.Eq(t, structs.TypeCSIVolumeUpserted, e.Type)
+	must.Eq(t, vol.ID, e.Key)
+	must.Eq(t, vol.Namespace, e.Namespace)
+	must.Eq(t, []string{plugin.ID}, e.FilterKeys)
+
+	// Secrets are removed from the event but not from the state store
+	payload := e.Payload.(*structs.CSIVolumeEvent)
+	must.MapEmpty(t, payload.CSIVolume.Secrets)
+
+	got, err := s.CSIVolumeByID(nil, vol.Namespace, vol.ID)
+	must.NoError(t, err)
+	must.MapLen(t, 1, got.Secrets)
+
+	must.NoError(t, s.CSIVolumeDeregister(1001, vol.Namespace, []string{vol.ID}, false))
+
+	events = WaitForEvents(t, s, 1001, 1, 1*time.Second)
+	must.Len(t, 1, events)
+	must.Eq(t, structs.TypeCSIVolumeDeleted, events[0].Type)
+	must.MapEmpty(t, events[0].Payload.(*structs.CSIVolumeEvent).CSIVolume.Secrets)
+}
+
+func TestEventsFromChanges_ScalingPolicy(t *testing.T) {
+	ci.Parallel(t)
+	s := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer s.StopEventBroker()
+
+	job, policy := mock.JobWithScalingPolicy()
+	must.NoError(t, s.UpsertJob(structs.JobRegisterRequestType, 1000, nil, job))
+
+	// Scaling policies are written as part of the job and carry their own
+	// event type
+	events := WaitForEvents(t, s, 1000, 2, 1*time.Second)
+	must.Len(t, 2, events)
+
+	var found bool
+	for _, e := range events {
+		switch e.Topic {
+		case structs.TopicJob:
+			must.Eq(t, structs.TypeJobRegistered, e.Type)
+		case structs.TopicScalingPolicy:
+			found = true
+			must.Eq(t, structs.TypeScalingPolicyUpserted, e.Type)
+			must.Eq(t, policy.ID, e.Key)
+			must.Eq(t, job.Namespace, e.Namespace)
+			must.Eq(t, []string{job.ID}, e.FilterKeys)
+		}
+	}
+	must.True(t, found)
+
+	txn := s.db.WriteTxnMsgT(structs.JobDeregisterRequestType, 1001)
+	must.NoError(t, s.DeleteJobTxn(1001, job.Namespace, job.ID, txn))
+	must.NoError(t, txn.Commit())
+
+	events = WaitForEvents(t, s, 1001, 2, 1*time.Second)
+	var deleted []string
+	for _, e := range events {
+		if e.Topic == structs.TopicScalingPolicy {
+			deleted = append(deleted, e.Type)
+		}
+	}
+	must.Eq(t, []string{structs.TypeScalingPolicyDeleted}, deleted)
+}
+
+func TestEventsFromChanges_RootKey(t *testing.T) {
+	ci.Parallel(t)
+	s := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer s.StopEventBroker()
+
+	key := structs.NewRootKeyMeta()
+	key.SetActive()
+	must.NoError(t, s.UpsertRootKeyMeta(1000, key, false))
+
+	events := WaitForEvents(t, s, 1000, 1, 1*time.Second)
+	must.Len(t, 1, events)
+
+	e := events[0]
+	must.Eq(t, structs.TopicRootKey, e.Topic)
+	must.Eq(t, structs.TypeRootKeyUpserted, e.Type)
+	must.Eq(t, key.KeyID, e.Key)
+	must.Eq(t, key.KeyID, e.Payload.(*structs.RootKeyEvent).RootKeyMeta.KeyID)
+
+	must.NoError(t, s.DeleteRootKeyMeta(1001, key.KeyID))
+
+	events = WaitForEvents(t, s, 1001, 1, 1*time.Second)
+	must.Len(t, 1, events)
+	must.Eq(t, structs.TypeRootKeyDeleted, events[0].Type)
+}
@@ -16,6 +16,10 @@ const (
 	tableIndex = "index"
 
 	TableNamespaces           = "namespaces"
+	TableQuotaSpec            = "quota_spec"
+	TableQuotaUsage           = "quota_usage"
+	TableSentinelPolicies     = "sentinel_policy"
+	TableRecommendations      = "recommendations"
 	TableNodePools            = "node_pools"
 	TableServiceRegistrations = "service_registrations"
 	TableVariables            = "variables"
@@ -26,6 +30,7 @@ const (
 	TableACLBindingRules      = "acl_binding_rules"
 	TableAllocs               = "allocs"
 	TableJobSubmission        = "job_submission"
+	TableHostVolumes          = "host_volumes"
 )
 
 const (
@@ -87,9 +92,14 @@ func init() {
 		clusterMetaTableSchema,
 		csiVolumeTableSchema,
 		csiPluginTableSchema,
+		hostVolumeTableSchema,
 		scalingPolicyTableSchema,
 		scalingEventTableSchema,
 		namespaceTableSchema,
+		quotaSpecTableSchema,
+		quotaUsageTableSchema,
+		sentinelPolicyTableSchema,
+		recommendationTableSchema,
 		serviceRegistrationsTableSchema,
 		variablesTableSchema,
 		variablesQuotasTableSchema,
@@ -1372,6 +1382,144 @@ func namespaceTableSchema() *memdb.TableSchema {
 	}
 }
 
+// quotaSpecTableSchema returns the MemDB schema for the quota specification
+// table.
+func quotaSpecTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableQuotaSpec,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// quotaUsageTableSchema returns the MemDB schema for the quota usage table.
+// Usages share the name of the quota specification they track.
+func quotaUsageTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableQuotaUsage,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// hostVolumeTableSchema returns the MemDB schema for dynamic host volumes.
+// Volumes are identified by namespace and ID, and can be looked up by the
+// node they are provisioned on.
+func hostVolumeTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableHostVolumes,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "ID",
+						},
+					},
+				},
+			},
+			indexNodeID: {
+				Name:         indexNodeID,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "NodeID",
+				},
+			},
+		},
+	}
+}
+
+// sentinelPolicyTableSchema returns the MemDB schema for the Sentinel policy
+// table.
+func sentinelPolicyTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableSentinelPolicies,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+			"scope": {
+				Name:         "scope",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Scope",
+				},
+			},
+		},
+	}
+}
+
+// recommendationTableSchema returns the MemDB schema for the recommendation
+// table.
+func recommendationTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableRecommendations,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.UUIDFieldIndex{
+					Field: "ID",
+				},
+			},
+			"namespace": {
+				Name:         "namespace",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Namespace",
+				},
+			},
+			"job": {
+				Name:         "job",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // serviceRegistrationsTableSchema returns the MemDB schema for Nomad native
 // service registrations.
 func serviceRegistrationsTableSchema() *memdb.TableSchema {
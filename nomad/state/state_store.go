@@ -88,6 +88,9 @@ type StateStoreConfig struct {
 	// EventBufferSize configures the amount of events to hold in memory
 	EventBufferSize int64
 
+	// EventLog is the optional durable log the event broker writes events to
+	EventLog *stream.EventLog
+
 	// JobTrackedVersions is the number of historic job versions that are kept.
 	JobTrackedVersions int
 }
@@ -156,6 +159,7 @@ func NewStateStore(config *StateStoreConfig) (*StateStore, error) {
 		// Create new event publisher using provided config
 		broker, err := stream.NewEventBroker(ctx, &streamACLDelegate{s}, stream.EventBrokerCfg{
 			EventBufferSize: config.EventBufferSize,
+			EventLog:        config.EventLog,
 			Logger:          config.Logger,
 		})
 		if err != nil {
@@ -206,11 +210,16 @@ func (s *StateStore) namespaceInit() error {
 		Description: structs.DefaultNamespaceDescription,
 	}
 
-	if err := s.UpsertNamespaces(1, []*structs.Namespace{defaultNs}); err != nil {
+	// The default namespace is written without a message type so that
+	// creating a state store doesn't publish an event for it.
+	txn := s.db.WriteTxn(1)
+	defer txn.Abort()
+
+	if err := s.upsertNamespacesTxn(1, txn, []*structs.Namespace{defaultNs}); err != nil {
 		return fmt.Errorf("inserting default namespace failed: %v", err)
 	}
 
-	return nil
+	return txn.Commit()
 }
 
 // Config returns the state store configuration.
@@ -1080,17 +1089,24 @@ func deleteNodeTxn(txn *txn, index uint64, nodes []string) error {
 
 // UpdateNodeStatus is used to update the status of a node
 func (s *StateStore) UpdateNodeStatus(msgType structs.MessageType, index uint64, nodeID, status string, updatedAt int64, event *structs.NodeEvent) error {
+	return s.UpdateNodeStatusUtilization(msgType, index, nodeID, status, updatedAt, event, nil)
+}
+
+// UpdateNodeStatusUtilization is used to update the status of a node along
+// with the resource utilization it reported, if any
+func (s *StateStore) UpdateNodeStatusUtilization(msgType structs.MessageType, index uint64, nodeID, status string,
+	updatedAt int64, event *structs.NodeEvent, util *structs.NodeUtilization) error {
 	txn := s.db.WriteTxnMsgT(msgType, index)
 	defer txn.Abort()
 
-	if err := s.updateNodeStatusTxn(txn, nodeID, status, updatedAt, event); err != nil {
+	if err := s.updateNodeStatusTxn(txn, nodeID, status, updatedAt, event, util); err != nil {
 		return err
 	}
 
 	return txn.Commit()
 }
 
-func (s *StateStore) updateNodeStatusTxn(txn *txn, nodeID, status string, updatedAt int64, event *structs.NodeEvent) error {
+func (s *StateStore) updateNodeStatusTxn(txn *txn, nodeID, status string, updatedAt int64, event *structs.NodeEvent, util *structs.NodeUtilization) error {
 
 	// Lookup the node
 	existing, err := txn.First("nodes", "id", nodeID)
@@ -1104,7 +1120,14 @@ func (s *StateStore) updateNodeStatusTxn(txn *txn, nodeID, status string, update
 	// Copy the existing node
 	existingNode := existing.(*structs.Node)
 	copyNode := existingNode.Copy()
-	copyNode.StatusUpdatedAt = updatedAt
+
+	// A utilization report alone doesn't update the status
+	if util == nil || event != nil || existingNode.Status != status {
+		copyNode.StatusUpdatedAt = updatedAt
+	}
+	if util != nil {
+		copyNode.Utilization = util.Copy()
+	}
 
 	// Add the event if given
 	if event != nil {
@@ -1756,6 +1779,12 @@ func (s *StateStore) upsertJobImpl(index uint64, sub *structs.JobSubmission, job
 		return fmt.Errorf("job %q is in nonexistent node pool %q", job.ID, job.NodePool)
 	}
 
+	// Tags belong to the version they were applied to, so a new version
+	// (including a revert to a tagged version) starts untagged
+	if !keepVersion {
+		job.VersionTag = nil
+	}
+
 	// Check if the job already exists
 	existing, err := txn.First("jobs", "id", job.Namespace, job.ID)
 	var existingJob *structs.Job
@@ -2148,32 +2177,43 @@ func (s *StateStore) upsertJobVersion(index uint64, job *structs.Job, txn *txn)
 		return fmt.Errorf("failed to look up job versions for %q: %v", job.ID, err)
 	}
 
-	// If we are below the limit there is no GCing to be done
-	if len(all) <= s.config.JobTrackedVersions {
-		return nil
-	}
-
-	// We have to delete a historic job to make room.
-	// Find index of the highest versioned stable job
+	// Tagged versions are never GCed and don't count towards the limit.
+	// Find index of the highest versioned stable job among the rest, unless
+	// it's tagged and so already kept.
+	untagged := make([]*structs.Job, 0, len(all))
 	stableIdx := -1
-	for i, j := range all {
-		if j.Stable {
-			stableIdx = i
-			break
+	stableFound := false
+	for _, j := range all {
+		if j.Stable && !stableFound {
+			stableFound = true
+			if j.VersionTag == nil {
+				stableIdx = len(untagged)
+			}
+		}
+		if j.VersionTag == nil {
+			untagged = append(untagged, j)
 		}
 	}
 
-	// If the stable job is the oldest version, do a swap to bring it into the
-	// keep set.
+	// If we are below the limit there is no GCing to be done
 	max := s.config.JobTrackedVersions
-	if stableIdx == max {
-		all[max-1], all[max] = all[max], all[max-1]
+	if len(untagged) <= max {
+		return nil
+	}
+
+	// We have to delete historic jobs to make room. There can be more than
+	// one to delete if a tag was removed since the last upsert.
+	// If the stable job is outside of the keep set, do a swap to bring it
+	// into the keep set.
+	if stableIdx >= max {
+		untagged[max-1], untagged[stableIdx] = untagged[stableIdx], untagged[max-1]
 	}
 
-	// Delete the job outside of the set that are being kept.
-	d := all[max]
-	if err := txn.Delete("job_version", d); err != nil {
-		return fmt.Errorf("failed to delete job %v (%d) from job_version", d.ID, d.Version)
+	// Delete the jobs outside of the set that are being kept.
+	for _, d := range untagged[max:] {
+		if err := txn.Delete("job_version", d); err != nil {
+			return fmt.Errorf("failed to delete job %v (%d) from job_version", d.ID, d.Version)
+		}
 	}
 
 	return nil
@@ -2520,7 +2560,7 @@ func (s *StateStore) JobSummaryByPrefix(ws memdb.WatchSet, namespace, id string)
 
 // UpsertCSIVolume inserts a volume in the state store.
 func (s *StateStore) UpsertCSIVolume(index uint64, volumes []*structs.CSIVolume) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.CSIVolumeRegisterRequestType, index)
 	defer txn.Abort()
 
 	for _, v := range volumes {
@@ -2742,9 +2782,35 @@ func (s *StateStore) csiVolumesByNamespaceImpl(txn *txn, ws memdb.WatchSet, name
 
 // CSIVolumeClaim updates the volume's claim count and allocation list
 func (s *StateStore) CSIVolumeClaim(index uint64, namespace, id string, claim *structs.CSIVolumeClaim) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.CSIVolumeClaimRequestType, index)
+	defer txn.Abort()
+
+	if err := s.csiVolumeClaimTxn(txn, index, namespace, id, claim); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// CSIVolumeClaimBatch applies a batch of claims in a single transaction. If a
+// claim fails, the claims before it are still written and the remaining
+// claims are skipped.
+func (s *StateStore) CSIVolumeClaimBatch(index uint64, claims []structs.CSIVolumeClaimRequest) error {
+	txn := s.db.WriteTxnMsgT(structs.CSIVolumeClaimBatchRequestType, index)
 	defer txn.Abort()
 
+	for _, req := range claims {
+		err := s.csiVolumeClaimTxn(txn, index, req.RequestNamespace(), req.VolumeID, req.ToClaim())
+		if err != nil {
+			if commitErr := txn.Commit(); commitErr != nil {
+				return commitErr
+			}
+			return err
+		}
+	}
+	return txn.Commit()
+}
+
+func (s *StateStore) csiVolumeClaimTxn(txn *txn, index uint64, namespace, id string, claim *structs.CSIVolumeClaim) error {
 	row, err := txn.First("csi_volumes", "id", namespace, id)
 	if err != nil {
 		return fmt.Errorf("volume lookup failed: %s: %v", id, err)
@@ -2813,12 +2879,12 @@ func (s *StateStore) CSIVolumeClaim(index uint64, namespace, id string, claim *s
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
-	return txn.Commit()
+	return nil
 }
 
 // CSIVolumeDeregister removes the volume from the server
 func (s *StateStore) CSIVolumeDeregister(index uint64, namespace string, ids []string, force bool) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.CSIVolumeDeregisterRequestType, index)
 	defer txn.Abort()
 
 	for _, id := range ids {
@@ -3150,7 +3216,7 @@ func (s *StateStore) UpsertCSIPlugin(index uint64, plug *structs.CSIPlugin) erro
 
 // DeleteCSIPlugin deletes the plugin if it's not in use.
 func (s *StateStore) DeleteCSIPlugin(index uint64, id string) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.CSIPluginDeleteRequestType, index)
 	defer txn.Abort()
 
 	plug, err := s.CSIPluginByIDTxn(txn, nil, id)
@@ -4885,6 +4951,103 @@ func (s *StateStore) updateJobStabilityImpl(index uint64, namespace, jobID strin
 	return s.upsertJobImpl(index, nil, copy, true, txn)
 }
 
+// UpdateJobVersionTag attaches a tag to a job version, or removes the named tag
+// from whichever version it is attached to if the request has no tag.
+func (s *StateStore) UpdateJobVersionTag(index uint64, namespace string, req *structs.JobApplyTagRequest) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	if req.Tag == nil {
+		if err := s.unsetJobVersionTagImpl(index, namespace, req.JobID, req.Name, txn); err != nil {
+			return err
+		}
+	} else {
+		if err := s.updateJobVersionTagImpl(index, namespace, req.JobID, req.Version, req.Tag, txn); err != nil {
+			return err
+		}
+	}
+
+	return txn.Commit()
+}
+
+// updateJobVersionTagImpl attaches a tag to the given job version. Tags are
+// immutable, so the version must not already be tagged and the tag name must
+// not be in use by another version of the job.
+func (s *StateStore) updateJobVersionTagImpl(index uint64, namespace, jobID string, jobVersion uint64, tag *structs.JobVersionTag, txn *txn) error {
+	versions, err := s.jobVersionByID(txn, nil, namespace, jobID)
+	if err != nil {
+		return err
+	}
+
+	var job *structs.Job
+	for _, v := range versions {
+		if v.VersionTag != nil && v.VersionTag.Name == tag.Name {
+			return fmt.Errorf("tag %q already exists on version %d", tag.Name, v.Version)
+		}
+		if v.Version == jobVersion {
+			job = v
+		}
+	}
+	if job == nil {
+		return fmt.Errorf("job %q version %d not found", jobID, jobVersion)
+	}
+	if job.VersionTag != nil {
+		return fmt.Errorf("version %d is already tagged %q", jobVersion, job.VersionTag.Name)
+	}
+
+	return s.setJobVersionTag(index, job, tag.Copy(), txn)
+}
+
+// unsetJobVersionTagImpl removes the named tag from the job version it is
+// attached to.
+func (s *StateStore) unsetJobVersionTagImpl(index uint64, namespace, jobID, name string, txn *txn) error {
+	versions, err := s.jobVersionByID(txn, nil, namespace, jobID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		if v.VersionTag != nil && v.VersionTag.Name == name {
+			return s.setJobVersionTag(index, v, nil, txn)
+		}
+	}
+	return fmt.Errorf("tag %q not found on job %q", name, jobID)
+}
+
+// setJobVersionTag sets the tag on a job version, and on the current job if
+// it is that version. Tagging doesn't create a new version or modify the job
+// specification, so only the ModifyIndex is updated.
+func (s *StateStore) setJobVersionTag(index uint64, job *structs.Job, tag *structs.JobVersionTag, txn *txn) error {
+	copy := job.Copy()
+	copy.VersionTag = tag
+	copy.ModifyIndex = index
+	if err := txn.Insert("job_version", copy); err != nil {
+		return fmt.Errorf("failed to update job version: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"job_version", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	existing, err := txn.First("jobs", "id", job.Namespace, job.ID)
+	if err != nil {
+		return fmt.Errorf("job lookup failed: %v", err)
+	}
+	if existing == nil || existing.(*structs.Job).Version != job.Version {
+		return nil
+	}
+
+	current := existing.(*structs.Job).Copy()
+	current.VersionTag = tag.Copy()
+	current.ModifyIndex = index
+	if err := txn.Insert("jobs", current); err != nil {
+		return fmt.Errorf("job insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"jobs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
 // UpdateDeploymentPromotion is used to promote canaries in a deployment and
 // potentially make a evaluation
 func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, index uint64, req *structs.ApplyDeploymentPromoteRequest) error {
@@ -4996,6 +5159,9 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 	// If the deployment no longer needs promotion, update its status
 	if !copy.RequiresPromotion() && copy.Status == structs.DeploymentStatusRunning {
 		copy.StatusDescription = structs.DeploymentStatusDescriptionRunning
+		if req.StatusDescription != "" {
+			copy.StatusDescription = req.StatusDescription
+		}
 	}
 
 	// Insert the deployment
@@ -5679,6 +5845,14 @@ func (s *StateStore) pruneJobSubmissions(namespace, jobID string, txn *txn) erro
 		sub := next.(*structs.JobSubmission)
 		// scanning by prefix; make sure we collect exact matches only
 		if sub.Namespace == namespace && sub.JobID == jobID {
+			// keep the submissions of tagged versions along with the versions
+			tagged, err := s.jobVersionTagged(namespace, jobID, sub.Version, txn)
+			if err != nil {
+				return err
+			}
+			if tagged {
+				continue
+			}
 			stored = append(stored, lang.Pair[uint64, uint64]{First: sub.JobModifyIndex, Second: sub.Version})
 		}
 	}
@@ -5716,6 +5890,15 @@ func (s *StateStore) pruneJobSubmissions(namespace, jobID string, txn *txn) erro
 	return nil
 }
 
+// jobVersionTagged returns whether the given job version exists and is tagged.
+func (s *StateStore) jobVersionTagged(namespace, jobID string, version uint64, txn *txn) (bool, error) {
+	job, err := s.jobByIDAndVersionImpl(nil, namespace, jobID, version, txn)
+	if err != nil {
+		return false, err
+	}
+	return job != nil && job.VersionTag != nil, nil
+}
+
 // updateJobCSIPlugins runs on job update, and indexes the job in the plugin
 func (s *StateStore) updateJobCSIPlugins(index uint64, job, prev *structs.Job, txn *txn) error {
 	plugIns := make(map[string]*structs.CSIPlugin)
@@ -6872,9 +7055,16 @@ func (s *StateStore) NamespaceNames() ([]string, error) {
 
 // UpsertNamespaces is used to register or update a set of namespaces.
 func (s *StateStore) UpsertNamespaces(index uint64, namespaces []*structs.Namespace) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.NamespaceUpsertRequestType, index)
 	defer txn.Abort()
 
+	if err := s.upsertNamespacesTxn(index, txn, namespaces); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+func (s *StateStore) upsertNamespacesTxn(index uint64, txn *txn, namespaces []*structs.Namespace) error {
 	for _, ns := range namespaces {
 		// Handle upgrade path.
 		ns.Canonicalize()
@@ -6886,8 +7076,7 @@ func (s *StateStore) UpsertNamespaces(index uint64, namespaces []*structs.Namesp
 	if err := txn.Insert("index", &IndexEntry{TableNamespaces, index}); err != nil {
 		return fmt.Errorf("index update failed: %v", err)
 	}
-
-	return txn.Commit()
+	return nil
 }
 
 // upsertNamespaceImpl is used to upsert a namespace
@@ -6941,7 +7130,7 @@ func (s *StateStore) upsertNamespaceImpl(index uint64, txn *txn, namespace *stru
 
 // DeleteNamespaces is used to remove a set of namespaces
 func (s *StateStore) DeleteNamespaces(index uint64, names []string) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.NamespaceDeleteRequestType, index)
 	defer txn.Abort()
 
 	for _, name := range names {
@@ -6989,6 +7178,16 @@ func (s *StateStore) DeleteNamespaces(index uint64, names []string) error {
 				"All CSI volumes in namespace must be deleted before it can be deleted", name, vol.ID)
 		}
 
+		hvIter, err := txn.Get(TableHostVolumes, "id_prefix", name, "")
+		if err != nil {
+			return err
+		}
+		if rawVol := hvIter.Next(); rawVol != nil {
+			vol := rawVol.(*structs.HostVolume)
+			return fmt.Errorf("namespace %q contains at least one host volume %q. "+
+				"All host volumes in namespace must be deleted before it can be deleted", name, vol.ID)
+		}
+
 		varIter, err := s.getVariablesByNamespaceImpl(txn, nil, name)
 		if err != nil {
 			return err
@@ -7295,6 +7494,7 @@ func (s *StateSnapshot) DenormalizeAllocationDiffSlice(allocDiffs []*structs.All
 			allocCopy.PreemptedByAllocation = allocDiff.PreemptedByAllocation
 			allocCopy.DesiredDescription = getPreemptedAllocDesiredDescription(allocDiff.PreemptedByAllocation)
 			allocCopy.DesiredStatus = structs.AllocDesiredStatusEvict
+			allocCopy.Preemption = allocDiff.Preemption
 		} else {
 			// If alloc is a stopped alloc
 			allocCopy.DesiredDescription = allocDiff.DesiredDescription
@@ -7325,7 +7525,7 @@ func getPreemptedAllocDesiredDescription(preemptedByAllocID string) string {
 
 // UpsertRootKeyMeta saves root key meta or updates it in-place.
 func (s *StateStore) UpsertRootKeyMeta(index uint64, rootKeyMeta *structs.RootKeyMeta, rekey bool) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.RootKeyMetaUpsertRequestType, index)
 	defer txn.Abort()
 
 	// get any existing key for updating
@@ -7407,7 +7607,7 @@ func (s *StateStore) UpsertRootKeyMeta(index uint64, rootKeyMeta *structs.RootKe
 // DeleteRootKeyMeta deletes a single root key, or returns an error if
 // it doesn't exist.
 func (s *StateStore) DeleteRootKeyMeta(index uint64, keyID string) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.RootKeyMetaDeleteRequestType, index)
 	defer txn.Abort()
 
 	// find the old key
@@ -10,27 +10,42 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
-// quotaSpecExists on returns whether the quota exists
+// quotaSpecExists returns whether the quota exists
 func (s *StateStore) quotaSpecExists(txn *txn, name string) (bool, error) {
-	return false, nil
+	existing, err := txn.First(TableQuotaSpec, "id", name)
+	return existing != nil, err
 }
 
+// quotaReconcile recomputes the usage of the quotas affected by a namespace
+// changing the quota it accounts against.
 func (s *StateStore) quotaReconcile(index uint64, txn *txn, newQuota, oldQuota string) error {
+	if newQuota == oldQuota {
+		return nil
+	}
+
+	for _, quota := range []string{newQuota, oldQuota} {
+		if quota == "" {
+			continue
+		}
+		if err := s.reconcileQuotaUsageTxn(txn, index, quota); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // updateEntWithAlloc is used to update Nomad Enterprise objects when an allocation is
 // added/modified/deleted
 func (s *StateStore) updateEntWithAlloc(index uint64, new, existing *structs.Allocation, txn *txn) error {
-	return nil
+	return s.updateQuotaWithAlloc(index, new, existing, txn)
 }
 
 // deleteRecommendationsByJob deletes all recommendations for the specified job
 func (s *StateStore) deleteRecommendationsByJob(index uint64, txn Txn, job *structs.Job) error {
-	return nil
+	return s.deleteJobRecommendationsTxn(index, txn, job)
 }
 
 // updateJobRecommendations updates/deletes job recommendations as necessary for a job update
 func (s *StateStore) updateJobRecommendations(index uint64, txn Txn, prevJob, newJob *structs.Job) error {
-	return nil
+	return s.updateJobRecommendationsTxn(index, txn, prevJob, newJob)
 }
@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// HostVolumes returns an iterator over all the dynamic host volumes.
+func (s *StateStore) HostVolumes(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableHostVolumes, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("host volumes lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// HostVolumesByIDPrefix returns an iterator over the dynamic host volumes in
+// the namespace whose ID matches the given prefix.
+func (s *StateStore) HostVolumesByIDPrefix(ws memdb.WatchSet, namespace, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableHostVolumes, "id_prefix", namespace, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("host volumes prefix lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// HostVolumesByNodeID returns an iterator over the dynamic host volumes
+// provisioned on the node.
+func (s *StateStore) HostVolumesByNodeID(ws memdb.WatchSet, nodeID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableHostVolumes, indexNodeID, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("host volumes lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// HostVolumeByID returns the dynamic host volume with the given namespace
+// and ID or nil if there is no match.
+func (s *StateStore) HostVolumeByID(ws memdb.WatchSet, namespace, id string) (*structs.HostVolume, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableHostVolumes, indexID, namespace, id)
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.HostVolume), nil
+}
+
+// QuotaHostVolumesUsage returns the total capacity in bytes of the dynamic
+// host volumes in the namespaces that account their usage against the given
+// quota.
+func (s *StateStore) QuotaHostVolumesUsage(ws memdb.WatchSet, quota string) (int64, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableNamespaces, "quota", quota)
+	if err != nil {
+		return 0, fmt.Errorf("namespaces lookup failed: %w", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var total int64
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ns := raw.(*structs.Namespace)
+
+		volumes, err := txn.Get(TableHostVolumes, "id_prefix", ns.Name, "")
+		if err != nil {
+			return 0, fmt.Errorf("host volumes lookup failed: %w", err)
+		}
+		ws.Add(volumes.WatchCh())
+
+		for raw := volumes.Next(); raw != nil; raw = volumes.Next() {
+			total += raw.(*structs.HostVolume).QuotaBytes()
+		}
+	}
+	return total, nil
+}
+
+// UpsertHostVolumes inserts or updates the given set of dynamic host
+// volumes. New volumes must be on a node that exists, and growing a volume
+// must not exceed the host volumes limit of its namespace's quota.
+func (s *StateStore) UpsertHostVolumes(msgType structs.MessageType, index uint64, volumes []*structs.HostVolume) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, vol := range volumes {
+		if err := s.upsertHostVolumeTxn(txn, index, vol); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableHostVolumes, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+func (s *StateStore) upsertHostVolumeTxn(txn *txn, index uint64, vol *structs.HostVolume) error {
+	if vol == nil {
+		return nil
+	}
+
+	existing, err := txn.First(TableHostVolumes, indexID, vol.Namespace, vol.ID)
+	if err != nil {
+		return fmt.Errorf("host volume lookup failed: %w", err)
+	}
+
+	change := vol.QuotaBytes()
+	if existing != nil {
+		exist := existing.(*structs.HostVolume)
+		if exist.NodeID != vol.NodeID {
+			return fmt.Errorf("host volume %q can't be moved to another node", vol.ID)
+		}
+		vol.CreateIndex = exist.CreateIndex
+		vol.CreateTime = exist.CreateTime
+		change -= exist.QuotaBytes()
+	} else {
+		node, err := txn.First("nodes", "id", vol.NodeID)
+		if err != nil {
+			return fmt.Errorf("node lookup failed: %w", err)
+		}
+		if node == nil {
+			return fmt.Errorf("host volume %q is on node %q which does not exist", vol.ID, vol.NodeID)
+		}
+		vol.NodePool = node.(*structs.Node).NodePool
+		vol.CreateIndex = index
+	}
+	vol.ModifyIndex = index
+
+	if err := s.enforceHostVolumesQuota(txn, vol.Namespace, change); err != nil {
+		return err
+	}
+
+	if err := txn.Insert(TableHostVolumes, vol); err != nil {
+		return fmt.Errorf("host volume insert failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteHostVolume removes the dynamic host volume. Volumes can't be deleted
+// while they are in use by a non-terminal allocation.
+func (s *StateStore) DeleteHostVolume(msgType structs.MessageType, index uint64, namespace, id string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableHostVolumes, indexID, namespace, id)
+	if err != nil {
+		return fmt.Errorf("host volume lookup failed: %w", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("host volume %s not found", id)
+	}
+	vol := existing.(*structs.HostVolume)
+
+	allocs, err := allocsByNodeTxn(txn, nil, vol.NodeID)
+	if err != nil {
+		return fmt.Errorf("allocs lookup failed: %w", err)
+	}
+	for _, alloc := range allocs {
+		if !alloc.TerminalStatus() && allocUsesHostVolume(alloc, vol) {
+			return fmt.Errorf("host volume %q is in use by allocation %q", vol.ID, alloc.ID)
+		}
+	}
+
+	if err := txn.Delete(TableHostVolumes, existing); err != nil {
+		return fmt.Errorf("host volume deletion failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableHostVolumes, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// allocUsesHostVolume returns whether the allocation requests the host
+// volume.
+func allocUsesHostVolume(alloc *structs.Allocation, vol *structs.HostVolume) bool {
+	if alloc.Namespace != vol.Namespace || alloc.Job == nil {
+		return false
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return false
+	}
+
+	for _, req := range tg.Volumes {
+		if req.Type != structs.VolumeTypeHost {
+			continue
+		}
+		source := req.Source
+		if req.PerAlloc {
+			source += structs.AllocSuffix(alloc.Name)
+		}
+		if source == vol.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// enforceHostVolumesQuota returns an error if changing the capacity of the
+// host volumes in the namespace by the given number of bytes would exceed
+// the host volumes limit of the namespace's quota. The host volumes table
+// must not have been updated with the change yet.
+func (s *StateStore) enforceHostVolumesQuota(txn *txn, namespace string, change int64) error {
+	if change <= 0 {
+		// Always allow freeing up space, even if over quota.
+		return nil
+	}
+
+	raw, err := txn.First(TableNamespaces, indexID, namespace)
+	if err != nil {
+		return fmt.Errorf("namespace lookup failed: %w", err)
+	}
+	if raw == nil || raw.(*structs.Namespace).Quota == "" {
+		return nil
+	}
+	quota := raw.(*structs.Namespace).Quota
+
+	raw, err = txn.First(TableQuotaSpec, indexID, quota)
+	if err != nil {
+		return fmt.Errorf("quota spec lookup failed: %w", err)
+	}
+	if raw == nil {
+		return nil
+	}
+
+	limit := raw.(*structs.QuotaSpec).LimitForRegion(s.config.Region)
+	if limit == nil || limit.HostVolumesLimit == nil || *limit.HostVolumesLimit == 0 {
+		return nil
+	}
+	if *limit.HostVolumesLimit < 0 {
+		return fmt.Errorf("quota %q does not allow host volumes", quota)
+	}
+
+	namespaces, err := txn.Get(TableNamespaces, "quota", quota)
+	if err != nil {
+		return fmt.Errorf("namespaces lookup failed: %w", err)
+	}
+
+	total := change
+	for raw := namespaces.Next(); raw != nil; raw = namespaces.Next() {
+		ns := raw.(*structs.Namespace)
+
+		volumes, err := txn.Get(TableHostVolumes, "id_prefix", ns.Name, "")
+		if err != nil {
+			return fmt.Errorf("host volumes lookup failed: %w", err)
+		}
+		for raw := volumes.Next(); raw != nil; raw = volumes.Next() {
+			total += raw.(*structs.HostVolume).QuotaBytes()
+		}
+	}
+
+	limitBytes := int64(*limit.HostVolumesLimit) * structs.BytesInMegabyte
+	if total > limitBytes {
+		return fmt.Errorf("quota %q exceeded: host volumes would use %d bytes of a %d MiB limit",
+			quota, total, *limit.HostVolumesLimit)
+	}
+	return nil
+}
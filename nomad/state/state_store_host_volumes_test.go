@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func TestStateStore_HostVolumes_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+
+	nodes := []*structs.Node{mock.Node(), mock.Node()}
+	nodes[1].NodePool = "prod"
+	for _, node := range nodes {
+		index++
+		must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, index, node))
+	}
+
+	ns := mock.Namespace()
+	index++
+	must.NoError(t, store.UpsertNamespaces(index, []*structs.Namespace{ns}))
+
+	vols := []*structs.HostVolume{
+		mock.HostVolume(nodes[0]),
+		mock.HostVolume(nodes[1]),
+		mock.HostVolume(nodes[1]),
+	}
+	vols[2].Namespace = ns.Name
+	vols[1].NodePool = "" // set from the node
+
+	index++
+	must.NoError(t, store.UpsertHostVolumes(structs.MsgTypeTestSetup, index, vols))
+
+	vol, err := store.HostVolumeByID(nil, vols[1].Namespace, vols[1].ID)
+	must.NoError(t, err)
+	must.NotNil(t, vol)
+	must.Eq(t, "prod", vol.NodePool)
+	must.Eq(t, index, vol.CreateIndex)
+
+	iter, err := store.HostVolumesByNodeID(nil, nodes[1].ID)
+	must.NoError(t, err)
+	must.Len(t, 2, collectHostVolumes(iter))
+
+	iter, err = store.HostVolumesByIDPrefix(nil, structs.DefaultNamespace, "")
+	must.NoError(t, err)
+	must.Len(t, 2, collectHostVolumes(iter))
+
+	iter, err = store.HostVolumes(nil)
+	must.NoError(t, err)
+	must.Len(t, 3, collectHostVolumes(iter))
+
+	// Updates keep the create index but can't move the volume
+	createIndex := index
+	vol = vol.Copy()
+	vol.State = structs.HostVolumeStatePending
+	index++
+	must.NoError(t, store.UpsertHostVolumes(structs.MsgTypeTestSetup, index, []*structs.HostVolume{vol}))
+	vol, err = store.HostVolumeByID(nil, vol.Namespace, vol.ID)
+	must.NoError(t, err)
+	must.Eq(t, createIndex, vol.CreateIndex)
+	must.Eq(t, index, vol.ModifyIndex)
+
+	vol = vol.Copy()
+	vol.NodeID = nodes[0].ID
+	index++
+	err = store.UpsertHostVolumes(structs.MsgTypeTestSetup, index, []*structs.HostVolume{vol})
+	must.ErrorContains(t, err, "can't be moved to another node")
+
+	// Volumes on unknown nodes are rejected
+	orphan := mock.HostVolume(mock.Node())
+	err = store.UpsertHostVolumes(structs.MsgTypeTestSetup, index, []*structs.HostVolume{orphan})
+	must.ErrorContains(t, err, "does not exist")
+
+	// Volumes in use by running allocations can't be deleted
+	alloc := mock.Alloc()
+	alloc.NodeID = nodes[0].ID
+	alloc.Job.TaskGroups[0].Volumes = map[string]*structs.VolumeRequest{
+		"data": {
+			Name:   "data",
+			Type:   structs.VolumeTypeHost,
+			Source: vols[0].Name,
+		},
+	}
+	index++
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, index, []*structs.Allocation{alloc}))
+
+	index++
+	err = store.DeleteHostVolume(structs.MsgTypeTestSetup, index, vols[0].Namespace, vols[0].ID)
+	must.ErrorContains(t, err, "in use by allocation")
+
+	alloc = alloc.Copy()
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	alloc.DesiredStatus = structs.AllocDesiredStatusStop
+	index++
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, index, []*structs.Allocation{alloc}))
+
+	index++
+	must.NoError(t, store.DeleteHostVolume(structs.MsgTypeTestSetup, index, vols[0].Namespace, vols[0].ID))
+	vol, err = store.HostVolumeByID(nil, vols[0].Namespace, vols[0].ID)
+	must.NoError(t, err)
+	must.Nil(t, vol)
+
+	index++
+	err = store.DeleteHostVolume(structs.MsgTypeTestSetup, index, vols[0].Namespace, vols[0].ID)
+	must.ErrorContains(t, err, "not found")
+
+	// Namespaces with host volumes can't be deleted
+	index++
+	err = store.DeleteNamespaces(index, []string{ns.Name})
+	must.ErrorContains(t, err, "host volumes")
+}
+
+func TestStateStore_QuotaHostVolumesLimit(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	spec := mock.QuotaSpec()
+	spec.Limits[0].HostVolumesLimit = pointer.Of(300)
+	spec.SetHash()
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, store.UpsertNamespaces(1001, []*structs.Namespace{ns}))
+
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1002, node))
+
+	vol := mock.HostVolume(node)
+	vol.Namespace = ns.Name
+	must.NoError(t, store.UpsertHostVolumes(structs.MsgTypeTestSetup, 1003, []*structs.HostVolume{vol}))
+
+	used, err := store.QuotaHostVolumesUsage(nil, spec.Name)
+	must.NoError(t, err)
+	must.Eq(t, vol.CapacityBytes, used)
+
+	// Pending volumes are accounted for their requested capacity, which
+	// goes over the limit
+	vol2 := mock.HostVolume(node)
+	vol2.ID = uuid.Generate()
+	vol2.Namespace = ns.Name
+	vol2.State = structs.HostVolumeStatePending
+	vol2.CapacityBytes = 0
+	err = store.UpsertHostVolumes(structs.MsgTypeTestSetup, 1004, []*structs.HostVolume{vol2})
+	must.ErrorContains(t, err, "exceeded")
+
+	// Shrinking a volume is always allowed
+	vol = vol.Copy()
+	vol.CapacityBytes = 10 * structs.BytesInMegabyte
+	must.NoError(t, store.UpsertHostVolumes(structs.MsgTypeTestSetup, 1005, []*structs.HostVolume{vol}))
+
+	// Negative limits disallow host volumes
+	spec = spec.Copy()
+	spec.Limits[0].HostVolumesLimit = pointer.Of(-1)
+	spec.SetHash()
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1006, []*structs.QuotaSpec{spec}))
+
+	vol2.RequestedCapacityMaxBytes = structs.BytesInMegabyte
+	vol2.RequestedCapacityMinBytes = 0
+	err = store.UpsertHostVolumes(structs.MsgTypeTestSetup, 1007, []*structs.HostVolume{vol2})
+	must.ErrorContains(t, err, "does not allow host volumes")
+}
+
+func collectHostVolumes(iter interface{ Next() interface{} }) []*structs.HostVolume {
+	var vols []*structs.HostVolume
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		vols = append(vols, raw.(*structs.HostVolume))
+	}
+	return vols
+}
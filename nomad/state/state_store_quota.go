@@ -0,0 +1,367 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// QuotaSpecs returns an iterator over all the quota specifications.
+func (s *StateStore) QuotaSpecs(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableQuotaSpec, "id")
+	if err != nil {
+		return nil, fmt.Errorf("quota specs lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// QuotaSpecsByNamePrefix returns an iterator over all the quota
+// specifications that match the given name prefix.
+func (s *StateStore) QuotaSpecsByNamePrefix(ws memdb.WatchSet, namePrefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableQuotaSpec, "id_prefix", namePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("quota specs prefix lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// QuotaSpecByName returns the quota specification that matches the given
+// name or nil if there is no match.
+func (s *StateStore) QuotaSpecByName(ws memdb.WatchSet, name string) (*structs.QuotaSpec, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableQuotaSpec, "id", name)
+	if err != nil {
+		return nil, fmt.Errorf("quota spec lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.QuotaSpec), nil
+}
+
+// QuotaUsages returns an iterator over all the quota usages.
+func (s *StateStore) QuotaUsages(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableQuotaUsage, "id")
+	if err != nil {
+		return nil, fmt.Errorf("quota usages lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// QuotaUsagesByNamePrefix returns an iterator over all the quota usages that
+// match the given name prefix.
+func (s *StateStore) QuotaUsagesByNamePrefix(ws memdb.WatchSet, namePrefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableQuotaUsage, "id_prefix", namePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("quota usages prefix lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// QuotaUsageByName returns the usage of the quota with the given name or nil
+// if there is no match.
+func (s *StateStore) QuotaUsageByName(ws memdb.WatchSet, name string) (*structs.QuotaUsage, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableQuotaUsage, "id", name)
+	if err != nil {
+		return nil, fmt.Errorf("quota usage lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.QuotaUsage), nil
+}
+
+// NamespacesByQuota returns an iterator over all the namespaces that account
+// their usage against the given quota.
+func (s *StateStore) NamespacesByQuota(ws memdb.WatchSet, quota string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableNamespaces, "quota", quota)
+	if err != nil {
+		return nil, fmt.Errorf("namespaces lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// QuotaVariablesUsage returns the total size in bytes of the variables stored
+// in the namespaces that account their usage against the given quota.
+func (s *StateStore) QuotaVariablesUsage(ws memdb.WatchSet, quota string) (int64, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableNamespaces, "quota", quota)
+	if err != nil {
+		return 0, fmt.Errorf("namespaces lookup failed: %w", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var total int64
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ns := raw.(*structs.Namespace)
+
+		watchCh, existing, err := txn.FirstWatch(TableVariablesQuotas, indexID, ns.Name)
+		if err != nil {
+			return 0, fmt.Errorf("variable quota lookup failed: %w", err)
+		}
+		ws.Add(watchCh)
+
+		if existing != nil {
+			total += existing.(*structs.VariablesQuota).Size
+		}
+	}
+	return total, nil
+}
+
+// UpsertQuotaSpecs inserts or updates the given set of quota specifications
+// and recomputes their usage.
+func (s *StateStore) UpsertQuotaSpecs(msgType structs.MessageType, index uint64, specs []*structs.QuotaSpec) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, spec := range specs {
+		if err := s.upsertQuotaSpecTxn(txn, index, spec); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaSpec, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+func (s *StateStore) upsertQuotaSpecTxn(txn *txn, index uint64, spec *structs.QuotaSpec) error {
+	if spec == nil {
+		return nil
+	}
+
+	existing, err := txn.First(TableQuotaSpec, "id", spec.Name)
+	if err != nil {
+		return fmt.Errorf("quota spec lookup failed: %w", err)
+	}
+
+	if existing != nil {
+		exist := existing.(*structs.QuotaSpec)
+		spec.CreateIndex = exist.CreateIndex
+		spec.ModifyIndex = index
+	} else {
+		spec.CreateIndex = index
+		spec.ModifyIndex = index
+	}
+
+	if err := txn.Insert(TableQuotaSpec, spec); err != nil {
+		return fmt.Errorf("quota spec insert failed: %w", err)
+	}
+
+	// The limits may have changed so the usage is computed from scratch.
+	return s.reconcileQuotaUsageTxn(txn, index, spec.Name)
+}
+
+// DeleteQuotaSpecs removes the given set of quota specifications and their
+// usage.
+func (s *StateStore) DeleteQuotaSpecs(msgType structs.MessageType, index uint64, names []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, name := range names {
+		if err := s.deleteQuotaSpecTxn(txn, name); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaSpec, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaUsage, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+func (s *StateStore) deleteQuotaSpecTxn(txn *txn, name string) error {
+	existing, err := txn.First(TableQuotaSpec, "id", name)
+	if err != nil {
+		return fmt.Errorf("quota spec lookup failed: %w", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("quota spec %s not found", name)
+	}
+
+	// Prevent deleting quotas that are still referenced by namespaces.
+	ns, err := txn.First(TableNamespaces, "quota", name)
+	if err != nil {
+		return fmt.Errorf("namespace lookup failed: %w", err)
+	}
+	if ns != nil {
+		return fmt.Errorf("quota %q is in use by namespace %q", name, ns.(*structs.Namespace).Name)
+	}
+
+	if err := txn.Delete(TableQuotaSpec, existing); err != nil {
+		return fmt.Errorf("quota spec deletion failed: %w", err)
+	}
+
+	usage, err := txn.First(TableQuotaUsage, "id", name)
+	if err != nil {
+		return fmt.Errorf("quota usage lookup failed: %w", err)
+	}
+	if usage != nil {
+		if err := txn.Delete(TableQuotaUsage, usage); err != nil {
+			return fmt.Errorf("quota usage deletion failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileQuotaUsageTxn computes the usage of the quota from the
+// allocations of every namespace accounted against it.
+func (s *StateStore) reconcileQuotaUsageTxn(txn *txn, index uint64, quota string) error {
+	raw, err := txn.First(TableQuotaSpec, "id", quota)
+	if err != nil {
+		return fmt.Errorf("quota spec lookup failed: %w", err)
+	}
+	if raw == nil {
+		return nil
+	}
+	spec := raw.(*structs.QuotaSpec)
+
+	usage := spec.NewQuotaUsage(s.config.Region)
+	usage.CreateIndex = index
+	usage.ModifyIndex = index
+
+	existing, err := txn.First(TableQuotaUsage, "id", quota)
+	if err != nil {
+		return fmt.Errorf("quota usage lookup failed: %w", err)
+	}
+	if existing != nil {
+		usage.CreateIndex = existing.(*structs.QuotaUsage).CreateIndex
+	}
+
+	if used := usage.UsageForLimit(spec.LimitForRegion(s.config.Region)); used != nil {
+		namespaces, err := txn.Get(TableNamespaces, "quota", quota)
+		if err != nil {
+			return fmt.Errorf("namespaces lookup failed: %w", err)
+		}
+		for raw := namespaces.Next(); raw != nil; raw = namespaces.Next() {
+			ns := raw.(*structs.Namespace)
+
+			allocs, err := s.allocsByNamespaceImpl(nil, txn, ns.Name)
+			if err != nil {
+				return fmt.Errorf("allocs lookup failed: %w", err)
+			}
+			for raw := allocs.Next(); raw != nil; raw = allocs.Next() {
+				alloc := raw.(*structs.Allocation)
+				if !alloc.TerminalStatus() {
+					used.AddAllocation(alloc)
+				}
+			}
+		}
+	}
+
+	if err := txn.Insert(TableQuotaUsage, usage); err != nil {
+		return fmt.Errorf("quota usage insert failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaUsage, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
+
+// updateQuotaWithAlloc updates the usage of the quota the allocation's
+// namespace accounts against when an allocation is added/modified/deleted.
+func (s *StateStore) updateQuotaWithAlloc(index uint64, new, existing *structs.Allocation, txn *txn) error {
+	alloc := new
+	if alloc == nil {
+		alloc = existing
+	}
+	if alloc == nil {
+		return nil
+	}
+
+	existingCounted := existing != nil && !existing.TerminalStatus()
+	newCounted := new != nil && !new.TerminalStatus()
+	if !existingCounted && !newCounted {
+		return nil
+	}
+
+	raw, err := txn.First(TableNamespaces, indexID, alloc.Namespace)
+	if err != nil {
+		return fmt.Errorf("namespace lookup failed: %w", err)
+	}
+	if raw == nil || raw.(*structs.Namespace).Quota == "" {
+		return nil
+	}
+	quota := raw.(*structs.Namespace).Quota
+
+	raw, err = txn.First(TableQuotaUsage, "id", quota)
+	if err != nil {
+		return fmt.Errorf("quota usage lookup failed: %w", err)
+	}
+	if raw == nil {
+		return nil
+	}
+	current := raw.(*structs.QuotaUsage)
+	if len(current.Used) == 0 {
+		return nil
+	}
+
+	usage := current.Copy()
+	for _, used := range usage.Used {
+		if existingCounted {
+			used.SubtractAllocation(existing)
+		}
+		if newCounted {
+			used.AddAllocation(new)
+		}
+	}
+
+	changed := false
+	for key, used := range usage.Used {
+		if !used.Equal(current.Used[key]) {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	usage.ModifyIndex = index
+	if err := txn.Insert(TableQuotaUsage, usage); err != nil {
+		return fmt.Errorf("quota usage insert failed: %w", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableQuotaUsage, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func TestStateStore_UpsertQuotaSpecs(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	spec := mock.QuotaSpec()
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+
+	got, err := store.QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Eq(t, spec, got)
+	must.Eq(t, 1000, got.CreateIndex)
+
+	// The usage of the local region limit is created along with the spec.
+	usage, err := store.QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.NotNil(t, usage)
+	used := usage.UsageForLimit(spec.LimitForRegion("global"))
+	must.NotNil(t, used)
+	must.Eq(t, 0, used.RegionLimit.CPU)
+
+	// Updating the spec keeps the create index
+	spec = spec.Copy()
+	spec.Description = "updated"
+	spec.SetHash()
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1001, []*structs.QuotaSpec{spec}))
+
+	got, err = store.QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Eq(t, "updated", got.Description)
+	must.Eq(t, 1000, got.CreateIndex)
+	must.Eq(t, 1001, got.ModifyIndex)
+
+	index, err := store.Index(TableQuotaSpec)
+	must.NoError(t, err)
+	must.Eq(t, 1001, index)
+}
+
+func TestStateStore_DeleteQuotaSpecs(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	spec := mock.QuotaSpec()
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, store.UpsertNamespaces(1001, []*structs.Namespace{ns}))
+
+	// Quotas in use can't be deleted
+	err := store.DeleteQuotaSpecs(structs.MsgTypeTestSetup, 1002, []string{spec.Name})
+	must.ErrorContains(t, err, "is in use by namespace")
+
+	// Unknown quotas can't be deleted
+	err = store.DeleteQuotaSpecs(structs.MsgTypeTestSetup, 1002, []string{"unknown"})
+	must.ErrorContains(t, err, "not found")
+
+	ns = ns.Copy()
+	ns.Quota = ""
+	must.NoError(t, store.UpsertNamespaces(1003, []*structs.Namespace{ns}))
+	must.NoError(t, store.DeleteQuotaSpecs(structs.MsgTypeTestSetup, 1004, []string{spec.Name}))
+
+	got, err := store.QuotaSpecByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	usage, err := store.QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Nil(t, usage)
+}
+
+func TestStateStore_QuotaUsage_Allocs(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	spec := mock.QuotaSpec()
+	limit := spec.LimitForRegion("global")
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	must.NoError(t, store.UpsertNamespaces(1001, []*structs.Namespace{ns}))
+
+	// Allocations placed before the namespace is attached to the quota are
+	// accounted for when it is.
+	alloc1 := mock.Alloc()
+	alloc1.Namespace = ns.Name
+	alloc1.Job.Namespace = ns.Name
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, alloc1.Job))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1003, []*structs.Allocation{alloc1}))
+
+	ns = ns.Copy()
+	ns.Quota = spec.Name
+	must.NoError(t, store.UpsertNamespaces(1004, []*structs.Namespace{ns}))
+
+	usage, err := store.QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Eq(t, 500, usage.UsageForLimit(limit).RegionLimit.CPU)
+
+	// New allocations are added to the usage
+	ws := memdb.NewWatchSet()
+	_, err = store.QuotaUsageByName(ws, spec.Name)
+	must.NoError(t, err)
+
+	alloc2 := mock.Alloc()
+	alloc2.Namespace = ns.Name
+	alloc2.JobID = alloc1.JobID
+	alloc2.Job = alloc1.Job
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1005, []*structs.Allocation{alloc2}))
+	must.True(t, watchFired(ws))
+
+	usage, err = store.QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Eq(t, 1000, usage.UsageForLimit(limit).RegionLimit.CPU)
+	must.Eq(t, 512, usage.UsageForLimit(limit).RegionLimit.MemoryMB)
+	must.Eq(t, 1005, usage.ModifyIndex)
+
+	// Terminal allocations are removed from the usage
+	alloc2 = alloc2.Copy()
+	alloc2.ClientStatus = structs.AllocClientStatusComplete
+	must.NoError(t, store.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 1006, []*structs.Allocation{alloc2}))
+
+	usage, err = store.QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Eq(t, 500, usage.UsageForLimit(limit).RegionLimit.CPU)
+
+	// Detaching the namespace resets the usage
+	ns = ns.Copy()
+	ns.Quota = ""
+	must.NoError(t, store.UpsertNamespaces(1007, []*structs.Namespace{ns}))
+
+	usage, err = store.QuotaUsageByName(nil, spec.Name)
+	must.NoError(t, err)
+	must.Eq(t, 0, usage.UsageForLimit(limit).RegionLimit.CPU)
+}
+
+func TestStateStore_QuotaVariablesLimit(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	spec := mock.QuotaSpec()
+	spec.Limits[0].VariablesLimit = pointer.Of(1)
+	spec.SetHash()
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, store.UpsertNamespaces(1001, []*structs.Namespace{ns}))
+
+	sv := mock.VariableEncrypted()
+	sv.Namespace = ns.Name
+	sv.Data = make([]byte, structs.BytesInMegabyte/2)
+	resp := store.VarSet(1002, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv})
+	must.NoError(t, resp.Error)
+
+	used, err := store.QuotaVariablesUsage(nil, spec.Name)
+	must.NoError(t, err)
+	must.Eq(t, int64(len(sv.Data)), used)
+
+	// A second variable goes over the limit
+	sv2 := mock.VariableEncrypted()
+	sv2.Namespace = ns.Name
+	sv2.Data = make([]byte, structs.BytesInMegabyte/2+1)
+	resp = store.VarSet(1003, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv2})
+	must.ErrorContains(t, resp.Error, "exceeded")
+
+	// Negative limits disallow variables
+	spec = spec.Copy()
+	spec.Limits[0].VariablesLimit = pointer.Of(-1)
+	spec.SetHash()
+	must.NoError(t, store.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1004, []*structs.QuotaSpec{spec}))
+
+	sv2.Data = []byte("small")
+	resp = store.VarSet(1005, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv2})
+	must.ErrorContains(t, resp.Error, "does not allow variables")
+}
@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Recommendations returns an iterator over all the recommendations.
+func (s *StateStore) Recommendations(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableRecommendations, "id")
+	if err != nil {
+		return nil, fmt.Errorf("recommendations lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// RecommendationsByNamespace returns an iterator over all the recommendations
+// in the given namespace.
+func (s *StateStore) RecommendationsByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableRecommendations, "namespace", namespace)
+	if err != nil {
+		return nil, fmt.Errorf("recommendations lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// RecommendationsByJob returns an iterator over all the recommendations for
+// the given job.
+func (s *StateStore) RecommendationsByJob(ws memdb.WatchSet, namespace, jobID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableRecommendations, "job", namespace, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("recommendations lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// RecommendationsByIDPrefix returns an iterator over all the recommendations
+// in the given namespace whose ID matches the given prefix. The wildcard
+// namespace matches recommendations in all namespaces.
+func (s *StateStore) RecommendationsByIDPrefix(ws memdb.WatchSet, namespace, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableRecommendations, "id_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("recommendations lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+
+	if namespace == structs.AllNamespacesSentinel {
+		return iter, nil
+	}
+	return memdb.NewFilterIterator(iter, func(raw interface{}) bool {
+		rec, ok := raw.(*structs.Recommendation)
+		if !ok {
+			return true
+		}
+		return rec.Namespace != namespace
+	}), nil
+}
+
+// RecommendationByID returns the recommendation with the given ID or nil if
+// there is no match.
+func (s *StateStore) RecommendationByID(ws memdb.WatchSet, id string) (*structs.Recommendation, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableRecommendations, "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("recommendation lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.Recommendation), nil
+}
+
+// UpsertRecommendations inserts or updates the given set of recommendations.
+// A recommendation replaces any existing recommendation for the same resource
+// of the same task, and is rejected if its job has no such task.
+func (s *StateStore) UpsertRecommendations(msgType structs.MessageType, index uint64, recs []*structs.Recommendation) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, rec := range recs {
+		if rec == nil {
+			continue
+		}
+
+		job, err := s.JobByIDTxn(nil, rec.Namespace, rec.JobID, txn)
+		if err != nil {
+			return fmt.Errorf("job lookup failed: %w", err)
+		}
+		if job == nil {
+			return fmt.Errorf("job %q in namespace %q not found", rec.JobID, rec.Namespace)
+		}
+		task, err := rec.Target(job)
+		if err != nil {
+			return err
+		}
+		rec.JobVersion = job.Version
+		rec.Current = rec.CurrentValue(task)
+
+		existing, err := recommendationForTargetTxn(txn, rec)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if rec.ID != "" && rec.ID != existing.ID {
+				if err := txn.Delete(TableRecommendations, existing); err != nil {
+					return fmt.Errorf("recommendation deletion failed: %w", err)
+				}
+				rec.CreateIndex = index
+			} else {
+				rec.ID = existing.ID
+				rec.CreateIndex = existing.CreateIndex
+			}
+		} else {
+			rec.CreateIndex = index
+		}
+		rec.ModifyIndex = index
+
+		if err := txn.Insert(TableRecommendations, rec); err != nil {
+			return fmt.Errorf("recommendation insert failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableRecommendations, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// DeleteRecommendations removes the given set of recommendations.
+func (s *StateStore) DeleteRecommendations(msgType structs.MessageType, index uint64, ids []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, id := range ids {
+		existing, err := txn.First(TableRecommendations, "id", id)
+		if err != nil {
+			return fmt.Errorf("recommendation lookup failed: %w", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("recommendation %s not found", id)
+		}
+		if err := txn.Delete(TableRecommendations, existing); err != nil {
+			return fmt.Errorf("recommendation deletion failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableRecommendations, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// recommendationForTargetTxn returns the recommendation for the same resource
+// of the same task as rec, if any.
+func recommendationForTargetTxn(txn *txn, rec *structs.Recommendation) (*structs.Recommendation, error) {
+	iter, err := txn.Get(TableRecommendations, "job", rec.Namespace, rec.JobID)
+	if err != nil {
+		return nil, fmt.Errorf("recommendations lookup failed: %w", err)
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		existing := raw.(*structs.Recommendation)
+		if existing.SameTarget(rec) {
+			return existing, nil
+		}
+	}
+	return nil, nil
+}
+
+// deleteJobRecommendationsTxn deletes all the recommendations for the job.
+func (s *StateStore) deleteJobRecommendationsTxn(index uint64, txn *txn, job *structs.Job) error {
+	deleted, err := txn.DeleteAll(TableRecommendations, "job", job.Namespace, job.ID)
+	if err != nil {
+		return fmt.Errorf("recommendation deletion failed: %w", err)
+	}
+	if deleted == 0 {
+		return nil
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableRecommendations, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
+
+// updateJobRecommendationsTxn brings the recommendations for a job in line
+// with a new version of the job. Recommendations are dismissed when their
+// task was removed, when they only applied to the previous version, or when
+// the new version already uses the recommended value. The current value of
+// the others is updated.
+func (s *StateStore) updateJobRecommendationsTxn(index uint64, txn *txn, prevJob, newJob *structs.Job) error {
+	if prevJob == nil {
+		return nil
+	}
+
+	iter, err := txn.Get(TableRecommendations, "job", newJob.Namespace, newJob.ID)
+	if err != nil {
+		return fmt.Errorf("recommendations lookup failed: %w", err)
+	}
+
+	var deleted, updated []*structs.Recommendation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		rec := raw.(*structs.Recommendation)
+
+		task, err := rec.Target(newJob)
+		if err != nil || (rec.EnforceVersion && rec.JobVersion != newJob.Version) {
+			deleted = append(deleted, rec)
+			continue
+		}
+
+		current := rec.CurrentValue(task)
+		switch {
+		case current == rec.Value:
+			deleted = append(deleted, rec)
+		case current != rec.Current || rec.JobVersion != newJob.Version:
+			rec = rec.Copy()
+			rec.Current = current
+			rec.JobVersion = newJob.Version
+			rec.ModifyIndex = index
+			updated = append(updated, rec)
+		}
+	}
+
+	if len(deleted) == 0 && len(updated) == 0 {
+		return nil
+	}
+
+	for _, rec := range deleted {
+		if err := txn.Delete(TableRecommendations, rec); err != nil {
+			return fmt.Errorf("recommendation deletion failed: %w", err)
+		}
+	}
+	for _, rec := range updated {
+		if err := txn.Insert(TableRecommendations, rec); err != nil {
+			return fmt.Errorf("recommendation insert failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableRecommendations, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func TestStateStore_UpsertRecommendations(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	job := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	rec := mock.Recommendation(job)
+	ws := memdb.NewWatchSet()
+	_, err := store.RecommendationByID(ws, rec.ID)
+	must.NoError(t, err)
+
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1001, []*structs.Recommendation{rec}))
+	must.True(t, watchFired(ws))
+
+	got, err := store.RecommendationByID(nil, rec.ID)
+	must.NoError(t, err)
+	must.NotNil(t, got)
+	must.Eq(t, 500, got.Current)
+	must.Eq(t, job.Version, got.JobVersion)
+	must.Eq(t, 1001, got.CreateIndex)
+
+	// A recommendation for the same resource of the same task replaces the
+	// existing one and keeps its ID
+	update := mock.Recommendation(job)
+	update.ID = ""
+	update.Value = 750
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1002, []*structs.Recommendation{update}))
+
+	got, err = store.RecommendationByID(nil, rec.ID)
+	must.NoError(t, err)
+	must.Eq(t, 750, got.Value)
+	must.Eq(t, 1001, got.CreateIndex)
+	must.Eq(t, 1002, got.ModifyIndex)
+
+	iter, err := store.RecommendationsByJob(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, recommendationsFromIter(iter))
+
+	// Recommendations for unknown tasks are rejected
+	bad := mock.Recommendation(job)
+	bad.Task = "unknown"
+	err = store.UpsertRecommendations(structs.MsgTypeTestSetup, 1003, []*structs.Recommendation{bad})
+	must.ErrorContains(t, err, `has no task "unknown"`)
+
+	index, err := store.Index(TableRecommendations)
+	must.NoError(t, err)
+	must.Eq(t, 1002, index)
+}
+
+func TestStateStore_DeleteRecommendations(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	job := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	cpu := mock.Recommendation(job)
+	mem := mock.Recommendation(job)
+	mem.Resource = structs.RecommendationResourceMemoryMB
+	mem.Value = 512
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1001, []*structs.Recommendation{cpu, mem}))
+
+	// Unknown recommendations can't be deleted
+	err := store.DeleteRecommendations(structs.MsgTypeTestSetup, 1002, []string{cpu.ID, "unknown"})
+	must.ErrorContains(t, err, "not found")
+
+	must.NoError(t, store.DeleteRecommendations(structs.MsgTypeTestSetup, 1003, []string{cpu.ID}))
+
+	got, err := store.RecommendationByID(nil, cpu.ID)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	got, err = store.RecommendationByID(nil, mem.ID)
+	must.NoError(t, err)
+	must.NotNil(t, got)
+
+	index, err := store.Index(TableRecommendations)
+	must.NoError(t, err)
+	must.Eq(t, 1003, index)
+}
+
+func TestStateStore_RecommendationsByIDPrefix(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	ns := mock.Namespace()
+	must.NoError(t, store.UpsertNamespaces(999, []*structs.Namespace{ns}))
+
+	job1 := mock.Job()
+	job2 := mock.Job()
+	job2.Namespace = ns.Name
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job1))
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1001, nil, job2))
+
+	rec1 := mock.Recommendation(job1)
+	rec1.ID = "aaaa" + rec1.ID[4:]
+	rec2 := mock.Recommendation(job2)
+	rec2.ID = "aaab" + rec2.ID[4:]
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1002, []*structs.Recommendation{rec1, rec2}))
+
+	iter, err := store.RecommendationsByIDPrefix(nil, structs.DefaultNamespace, "aaa")
+	must.NoError(t, err)
+	must.Len(t, 1, recommendationsFromIter(iter))
+
+	iter, err = store.RecommendationsByIDPrefix(nil, structs.AllNamespacesSentinel, "aaa")
+	must.NoError(t, err)
+	must.Len(t, 2, recommendationsFromIter(iter))
+
+	iter, err = store.RecommendationsByIDPrefix(nil, structs.AllNamespacesSentinel, "aaab")
+	must.NoError(t, err)
+	must.Len(t, 1, recommendationsFromIter(iter))
+}
+
+func TestStateStore_UpdateJobRecommendations(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	job := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	cpu := mock.Recommendation(job)
+	mem := mock.Recommendation(job)
+	mem.Resource = structs.RecommendationResourceMemoryMB
+	mem.Value = 512
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1001, []*structs.Recommendation{cpu, mem}))
+
+	// A new version of the job that already uses the recommended memory
+	// dismisses the memory recommendation and updates the current CPU
+	job = job.Copy()
+	job.TaskGroups[0].Tasks[0].Resources.CPU = 600
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 512
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, job))
+
+	got, err := store.RecommendationByID(nil, mem.ID)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	got, err = store.RecommendationByID(nil, cpu.ID)
+	must.NoError(t, err)
+	must.Eq(t, 600, got.Current)
+	must.Eq(t, 1, got.JobVersion)
+	must.Eq(t, 1002, got.ModifyIndex)
+
+	// Recommendations that enforce the job version are dismissed by a new
+	// version of the job
+	cpu = got.Copy()
+	cpu.EnforceVersion = true
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1003, []*structs.Recommendation{cpu}))
+
+	job = job.Copy()
+	job.Meta = map[string]string{"version": "2"}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1004, nil, job))
+
+	got, err = store.RecommendationByID(nil, cpu.ID)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	// Deleting the job deletes its recommendations
+	rec := mock.Recommendation(job)
+	must.NoError(t, store.UpsertRecommendations(structs.MsgTypeTestSetup, 1005, []*structs.Recommendation{rec}))
+	must.NoError(t, store.DeleteJob(1006, job.Namespace, job.ID))
+
+	got, err = store.RecommendationByID(nil, rec.ID)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	index, err := store.Index(TableRecommendations)
+	must.NoError(t, err)
+	must.Eq(t, 1006, index)
+}
+
+func recommendationsFromIter(iter memdb.ResultIterator) []*structs.Recommendation {
+	var recs []*structs.Recommendation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		recs = append(recs, raw.(*structs.Recommendation))
+	}
+	return recs
+}
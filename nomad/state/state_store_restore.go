@@ -213,6 +213,46 @@ func (r *StateRestore) NamespaceRestore(ns *structs.Namespace) error {
 	return nil
 }
 
+// HostVolumeRestore is used to restore a dynamic host volume
+func (r *StateRestore) HostVolumeRestore(vol *structs.HostVolume) error {
+	if err := r.txn.Insert(TableHostVolumes, vol); err != nil {
+		return fmt.Errorf("host volume insert failed: %v", err)
+	}
+	return nil
+}
+
+// QuotaSpecRestore is used to restore a quota specification
+func (r *StateRestore) QuotaSpecRestore(spec *structs.QuotaSpec) error {
+	if err := r.txn.Insert(TableQuotaSpec, spec); err != nil {
+		return fmt.Errorf("quota spec insert failed: %v", err)
+	}
+	return nil
+}
+
+// QuotaUsageRestore is used to restore a quota usage
+func (r *StateRestore) QuotaUsageRestore(usage *structs.QuotaUsage) error {
+	if err := r.txn.Insert(TableQuotaUsage, usage); err != nil {
+		return fmt.Errorf("quota usage insert failed: %v", err)
+	}
+	return nil
+}
+
+// SentinelPolicyRestore is used to restore a Sentinel policy
+func (r *StateRestore) SentinelPolicyRestore(policy *structs.SentinelPolicy) error {
+	if err := r.txn.Insert(TableSentinelPolicies, policy); err != nil {
+		return fmt.Errorf("sentinel policy insert failed: %v", err)
+	}
+	return nil
+}
+
+// RecommendationRestore is used to restore a recommendation
+func (r *StateRestore) RecommendationRestore(rec *structs.Recommendation) error {
+	if err := r.txn.Insert(TableRecommendations, rec); err != nil {
+		return fmt.Errorf("recommendation insert failed: %v", err)
+	}
+	return nil
+}
+
 // ServiceRegistrationRestore is used to restore a single service registration
 // into the service_registrations table.
 func (r *StateRestore) ServiceRegistrationRestore(service *structs.ServiceRegistration) error {
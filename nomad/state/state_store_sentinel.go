@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// SentinelPolicies returns an iterator over all the Sentinel policies.
+func (s *StateStore) SentinelPolicies(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableSentinelPolicies, "id")
+	if err != nil {
+		return nil, fmt.Errorf("sentinel policies lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// SentinelPoliciesByNamePrefix returns an iterator over all the Sentinel
+// policies that match the given name prefix.
+func (s *StateStore) SentinelPoliciesByNamePrefix(ws memdb.WatchSet, namePrefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableSentinelPolicies, "id_prefix", namePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("sentinel policies prefix lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// SentinelPoliciesByScope returns an iterator over all the Sentinel policies
+// enforced in the given scope.
+func (s *StateStore) SentinelPoliciesByScope(ws memdb.WatchSet, scope string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableSentinelPolicies, "scope", scope)
+	if err != nil {
+		return nil, fmt.Errorf("sentinel policies scope lookup failed: %w", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// SentinelPolicyByName returns the Sentinel policy that matches the given
+// name or nil if there is no match.
+func (s *StateStore) SentinelPolicyByName(ws memdb.WatchSet, name string) (*structs.SentinelPolicy, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableSentinelPolicies, "id", name)
+	if err != nil {
+		return nil, fmt.Errorf("sentinel policy lookup failed: %w", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.SentinelPolicy), nil
+}
+
+// UpsertSentinelPolicies inserts or updates the given set of Sentinel
+// policies.
+func (s *StateStore) UpsertSentinelPolicies(msgType structs.MessageType, index uint64, policies []*structs.SentinelPolicy) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, policy := range policies {
+		if policy == nil {
+			continue
+		}
+
+		existing, err := txn.First(TableSentinelPolicies, "id", policy.Name)
+		if err != nil {
+			return fmt.Errorf("sentinel policy lookup failed: %w", err)
+		}
+
+		if existing != nil {
+			policy.CreateIndex = existing.(*structs.SentinelPolicy).CreateIndex
+			policy.ModifyIndex = index
+		} else {
+			policy.CreateIndex = index
+			policy.ModifyIndex = index
+		}
+
+		// Policies written by older servers or restored from replication may
+		// not have a hash yet.
+		if len(policy.Hash) == 0 {
+			policy.SetHash()
+		}
+
+		if err := txn.Insert(TableSentinelPolicies, policy); err != nil {
+			return fmt.Errorf("sentinel policy insert failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableSentinelPolicies, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
+
+// DeleteSentinelPolicies removes the given set of Sentinel policies.
+func (s *StateStore) DeleteSentinelPolicies(msgType structs.MessageType, index uint64, names []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, name := range names {
+		existing, err := txn.First(TableSentinelPolicies, "id", name)
+		if err != nil {
+			return fmt.Errorf("sentinel policy lookup failed: %w", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("sentinel policy %s not found", name)
+		}
+		if err := txn.Delete(TableSentinelPolicies, existing); err != nil {
+			return fmt.Errorf("sentinel policy deletion failed: %w", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableSentinelPolicies, index}); err != nil {
+		return fmt.Errorf("index update failed: %w", err)
+	}
+
+	return txn.Commit()
+}
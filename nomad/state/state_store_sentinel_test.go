@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/shoenig/test/must"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func TestStateStore_UpsertSentinelPolicies(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	policy := mock.SentinelPolicy()
+	ws := memdb.NewWatchSet()
+	_, err := store.SentinelPolicyByName(ws, policy.Name)
+	must.NoError(t, err)
+
+	must.NoError(t, store.UpsertSentinelPolicies(structs.MsgTypeTestSetup, 1000, []*structs.SentinelPolicy{policy}))
+	must.True(t, watchFired(ws))
+
+	got, err := store.SentinelPolicyByName(nil, policy.Name)
+	must.NoError(t, err)
+	must.Eq(t, policy, got)
+	must.Eq(t, 1000, got.CreateIndex)
+
+	// Updating the policy keeps the create index
+	policy = policy.Copy()
+	policy.EnforcementLevel = structs.SentinelEnforcementLevelHardMandatory
+	policy.SetHash()
+	must.NoError(t, store.UpsertSentinelPolicies(structs.MsgTypeTestSetup, 1001, []*structs.SentinelPolicy{policy}))
+
+	got, err = store.SentinelPolicyByName(nil, policy.Name)
+	must.NoError(t, err)
+	must.Eq(t, structs.SentinelEnforcementLevelHardMandatory, got.EnforcementLevel)
+	must.Eq(t, 1000, got.CreateIndex)
+	must.Eq(t, 1001, got.ModifyIndex)
+
+	index, err := store.Index(TableSentinelPolicies)
+	must.NoError(t, err)
+	must.Eq(t, 1001, index)
+}
+
+func TestStateStore_DeleteSentinelPolicies(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	p1, p2 := mock.SentinelPolicy(), mock.SentinelPolicy()
+	must.NoError(t, store.UpsertSentinelPolicies(structs.MsgTypeTestSetup, 1000, []*structs.SentinelPolicy{p1, p2}))
+
+	// Unknown policies can't be deleted
+	err := store.DeleteSentinelPolicies(structs.MsgTypeTestSetup, 1001, []string{p1.Name, "unknown"})
+	must.ErrorContains(t, err, "not found")
+
+	got, err := store.SentinelPolicyByName(nil, p1.Name)
+	must.NoError(t, err)
+	must.NotNil(t, got)
+
+	must.NoError(t, store.DeleteSentinelPolicies(structs.MsgTypeTestSetup, 1002, []string{p1.Name}))
+
+	got, err = store.SentinelPolicyByName(nil, p1.Name)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	index, err := store.Index(TableSentinelPolicies)
+	must.NoError(t, err)
+	must.Eq(t, 1002, index)
+}
+
+func TestStateStore_SentinelPoliciesByScope(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+
+	p1, p2 := mock.SentinelPolicy(), mock.SentinelPolicy()
+	p2.Name = "other-" + p2.Name
+	must.NoError(t, store.UpsertSentinelPolicies(structs.MsgTypeTestSetup, 1000, []*structs.SentinelPolicy{p1, p2}))
+
+	iter, err := store.SentinelPoliciesByScope(nil, structs.SentinelScopeSubmitJob)
+	must.NoError(t, err)
+	must.Len(t, 2, collectSentinelPolicies(iter))
+
+	iter, err = store.SentinelPoliciesByScope(nil, "unknown")
+	must.NoError(t, err)
+	must.Len(t, 0, collectSentinelPolicies(iter))
+
+	iter, err = store.SentinelPoliciesByNamePrefix(nil, "other-")
+	must.NoError(t, err)
+	policies := collectSentinelPolicies(iter)
+	must.Len(t, 1, policies)
+	must.Eq(t, p2.Name, policies[0].Name)
+}
+
+func collectSentinelPolicies(iter memdb.ResultIterator) []*structs.SentinelPolicy {
+	var policies []*structs.SentinelPolicy
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		policies = append(policies, raw.(*structs.SentinelPolicy))
+	}
+	return policies
+}
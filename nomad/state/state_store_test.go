@@ -1548,6 +1548,37 @@ func TestStateStore_UpdateNodeStatus_Node(t *testing.T) {
 	require.False(watchFired(ws))
 }
 
+func TestStateStore_UpdateNodeStatus_Utilization(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	node := mock.Node()
+	node.Status = structs.NodeStatusReady
+	node.StatusUpdatedAt = 50
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 800, node))
+
+	util := &structs.NodeUtilization{CPU: 1000, MemoryMB: 512, Samples: 10, UpdatedAt: 70}
+	must.NoError(t, state.UpdateNodeStatusUtilization(structs.MsgTypeTestSetup, 801,
+		node.ID, structs.NodeStatusReady, 70, nil, util))
+
+	out, err := state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, util, out.Utilization)
+	must.Eq(t, 801, out.ModifyIndex)
+
+	// A utilization report alone doesn't update the status timestamp
+	must.Eq(t, 50, out.StatusUpdatedAt)
+
+	// Re-registering the node retains the utilization report
+	node = node.Copy()
+	node.Utilization = nil
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 802, node))
+
+	out, err = state.NodeByID(nil, node.ID)
+	must.NoError(t, err)
+	must.Eq(t, util, out.Utilization)
+}
+
 func TestStatStore_UpdateNodeStatus_LastMissedHeartbeatIndex(t *testing.T) {
 	ci.Parallel(t)
 
@@ -8119,6 +8150,112 @@ func TestStateStore_UpdateJobStability(t *testing.T) {
 	require.False(t, jout.Stable)
 }
 
+func TestStateStore_UpdateJobVersionTag(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	// Insert a job three times to get three versions
+	job := mock.Job()
+	for i := uint64(1); i <= 3; i++ {
+		must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, i, nil, job.Copy()))
+	}
+
+	tagReq := func(version uint64, name string) *structs.JobApplyTagRequest {
+		return &structs.JobApplyTagRequest{
+			JobID:   job.ID,
+			Name:    name,
+			Version: version,
+			Tag:     &structs.JobVersionTag{Name: name, Description: "desc"},
+		}
+	}
+
+	must.NoError(t, state.UpdateJobVersionTag(4, job.Namespace, tagReq(0, "v0")))
+	out, err := state.JobByIDAndVersion(nil, job.Namespace, job.ID, 0)
+	must.NoError(t, err)
+	must.NotNil(t, out.VersionTag)
+	must.Eq(t, "v0", out.VersionTag.Name)
+	must.Eq(t, 4, out.ModifyIndex)
+
+	// Tagging an old version doesn't change the current job
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Nil(t, out.VersionTag)
+
+	must.NoError(t, state.UpdateJobVersionTag(5, job.Namespace, tagReq(2, "latest")))
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.NotNil(t, out.VersionTag)
+	must.Eq(t, "latest", out.VersionTag.Name)
+
+	// Tags are immutable and unique per job
+	err = state.UpdateJobVersionTag(6, job.Namespace, tagReq(1, "v0"))
+	must.ErrorContains(t, err, "already exists on version 0")
+	err = state.UpdateJobVersionTag(6, job.Namespace, tagReq(0, "other"))
+	must.ErrorContains(t, err, "already tagged")
+	err = state.UpdateJobVersionTag(6, job.Namespace, tagReq(7, "missing"))
+	must.ErrorContains(t, err, "not found")
+
+	// Unset removes the tag by name
+	must.NoError(t, state.UpdateJobVersionTag(6, job.Namespace,
+		&structs.JobApplyTagRequest{JobID: job.ID, Name: "v0"}))
+	out, err = state.JobByIDAndVersion(nil, job.Namespace, job.ID, 0)
+	must.NoError(t, err)
+	must.Nil(t, out.VersionTag)
+
+	err = state.UpdateJobVersionTag(7, job.Namespace,
+		&structs.JobApplyTagRequest{JobID: job.ID, Name: "v0"})
+	must.ErrorContains(t, err, "not found")
+
+	// A new version doesn't inherit the tag of the version it was made from,
+	// as happens on revert
+	tagged, err := state.JobByIDAndVersion(nil, job.Namespace, job.ID, 2)
+	must.NoError(t, err)
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 8, nil, tagged.Copy()))
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Eq(t, 3, out.Version)
+	must.Nil(t, out.VersionTag)
+}
+
+func TestStateStore_UpsertJob_TaggedVersionsNotGCed(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	job := mock.Job()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1, nil, job.Copy()))
+	must.NoError(t, state.UpdateJobVersionTag(2, job.Namespace, &structs.JobApplyTagRequest{
+		JobID: job.ID,
+		Name:  "keep",
+		Tag:   &structs.JobVersionTag{Name: "keep"},
+	}))
+
+	// Register enough versions that version 0 would have been GCed
+	index := uint64(3)
+	for i := 0; i < structs.JobDefaultTrackedVersions+2; i++ {
+		must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, index, nil, job.Copy()))
+		index++
+	}
+
+	versions, err := state.JobVersionsByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Len(t, structs.JobDefaultTrackedVersions+1, versions)
+	must.Eq(t, 0, versions[len(versions)-1].Version)
+
+	// Once untagged the version is GCed on the next register
+	must.NoError(t, state.UpdateJobVersionTag(index, job.Namespace, &structs.JobApplyTagRequest{
+		JobID: job.ID,
+		Name:  "keep",
+	}))
+	index++
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, index, nil, job.Copy()))
+
+	versions, err = state.JobVersionsByID(nil, job.Namespace, job.ID)
+	must.NoError(t, err)
+	must.Len(t, structs.JobDefaultTrackedVersions, versions)
+	must.NotEq(t, 0, versions[len(versions)-1].Version)
+}
+
 // Test that nonexistent deployment can't be promoted
 func TestStateStore_UpsertDeploymentPromotion_Nonexistent(t *testing.T) {
 	ci.Parallel(t)
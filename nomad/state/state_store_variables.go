@@ -122,7 +122,7 @@ func (s *StateStore) GetVariable(
 
 // VarSet is used to store a variable object.
 func (s *StateStore) VarSet(idx uint64, sv *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Perform the actual set.
@@ -141,7 +141,7 @@ func (s *StateStore) VarSet(idx uint64, sv *structs.VarApplyStateRequest) *struc
 // variable. The ModifyIndex in the provided entry is used to determine if
 // we should write the entry to the state store or not.
 func (s *StateStore) VarSetCAS(idx uint64, sv *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	resp := s.varSetCASTxn(tx, idx, sv)
@@ -300,7 +300,7 @@ func (s *StateStore) varSetTxn(tx WriteTxn, idx uint64, req *structs.VarApplySta
 // VarDelete is used to delete a single variable in the
 // the state store.
 func (s *StateStore) VarDelete(idx uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Perform the actual delete
@@ -322,7 +322,7 @@ func (s *StateStore) VarDelete(idx uint64, req *structs.VarApplyStateRequest) *s
 // last observed index for the given variable, then the call is a noop,
 // otherwise a normal delete is invoked.
 func (s *StateStore) VarDeleteCAS(idx uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	resp := s.svDeleteCASTxn(tx, idx, req)
@@ -486,7 +486,7 @@ func (s *StateStore) VariablesQuotaByNamespace(ws memdb.WatchSet, namespace stri
 // IMPORTANT: this method overwrites the variable, data included.
 func (s *StateStore) VarLockAcquire(idx uint64,
 	req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Try to fetch the variable.
@@ -523,7 +523,7 @@ func (s *StateStore) VarLockAcquire(idx uint64,
 
 func (s *StateStore) VarLockRelease(idx uint64,
 	req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
-	tx := s.db.WriteTxn(idx)
+	tx := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, idx)
 	defer tx.Abort()
 
 	// Look up the entry in the state store.
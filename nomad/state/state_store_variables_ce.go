@@ -6,6 +6,69 @@
 
 package state
 
-func (s *StateStore) enforceVariablesQuota(_ uint64, _ WriteTxn, _ string, _ int64) error {
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// enforceVariablesQuota returns an error if changing the size of the
+// variables stored in the namespace by the given number of bytes would
+// exceed the variables limit of the namespace's quota. The variables quota
+// table must not have been updated with the change yet.
+func (s *StateStore) enforceVariablesQuota(_ uint64, tx WriteTxn, namespace string, change int64) error {
+	if change < 0 {
+		// Always allow freeing up space, even if over quota.
+		return nil
+	}
+
+	raw, err := tx.First(TableNamespaces, indexID, namespace)
+	if err != nil {
+		return fmt.Errorf("namespace lookup failed: %w", err)
+	}
+	if raw == nil || raw.(*structs.Namespace).Quota == "" {
+		return nil
+	}
+	quota := raw.(*structs.Namespace).Quota
+
+	raw, err = tx.First(TableQuotaSpec, "id", quota)
+	if err != nil {
+		return fmt.Errorf("quota spec lookup failed: %w", err)
+	}
+	if raw == nil {
+		return nil
+	}
+
+	limit := raw.(*structs.QuotaSpec).LimitForRegion(s.config.Region)
+	if limit == nil || limit.VariablesLimit == nil || *limit.VariablesLimit == 0 {
+		return nil
+	}
+	if *limit.VariablesLimit < 0 {
+		return fmt.Errorf("quota %q does not allow variables", quota)
+	}
+
+	namespaces, err := tx.Get(TableNamespaces, "quota", quota)
+	if err != nil {
+		return fmt.Errorf("namespaces lookup failed: %w", err)
+	}
+
+	total := change
+	for raw := namespaces.Next(); raw != nil; raw = namespaces.Next() {
+		ns := raw.(*structs.Namespace)
+
+		existing, err := tx.First(TableVariablesQuotas, indexID, ns.Name)
+		if err != nil {
+			return fmt.Errorf("variable quota lookup failed: %w", err)
+		}
+		if existing != nil {
+			total += existing.(*structs.VariablesQuota).Size
+		}
+	}
+
+	limitBytes := int64(*limit.VariablesLimit) * structs.BytesInMegabyte
+	if total > limitBytes {
+		return fmt.Errorf("quota %q exceeded: variables would use %d bytes of a %d MiB limit",
+			quota, total, *limit.VariablesLimit)
+	}
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -27,7 +28,12 @@ const (
 
 type EventBrokerCfg struct {
 	EventBufferSize int64
-	Logger          hclog.Logger
+
+	// EventLog is an optional durable log that events are written to, which
+	// allows subscribers to resume from events no longer in the buffer.
+	EventLog *EventLog
+
+	Logger hclog.Logger
 }
 
 type EventBroker struct {
@@ -38,6 +44,15 @@ type EventBroker struct {
 	// eventBuf stores a configurable amount of events in memory
 	eventBuf *eventBuffer
 
+	// eventLog stores events on disk, subject to its retention limits. It is
+	// nil if the event log is not enabled.
+	eventLog *EventLog
+
+	// publishLock is held while appending events to the event log and the
+	// buffer, so subscribers catching up from the event log can find where
+	// the buffer continues it.
+	publishLock sync.Mutex
+
 	// publishCh is used to send messages from an active txn to a goroutine which
 	// publishes events, so that publishing can happen asynchronously from
 	// the Commit call in the FSM hot path.
@@ -69,6 +84,7 @@ func NewEventBroker(ctx context.Context, aclDelegate ACLDelegate, cfg EventBroke
 	e := &EventBroker{
 		logger:      cfg.Logger.Named("event_broker"),
 		eventBuf:    buffer,
+		eventLog:    cfg.EventLog,
 		publishCh:   make(chan *structs.Events, 64),
 		aclCh:       make(chan structs.Event, 10),
 		aclDelegate: aclDelegate,
@@ -137,12 +153,28 @@ func (e *EventBroker) SubscribeWithACLCheck(req *SubscribeRequest) (*Subscriptio
 // set and the index is no longer in the buffer or not yet in the buffer an error
 // will be returned.
 //
+// If the broker has an event log, a Subscription for an index older than the
+// buffer reads from the event log until it catches up with the buffer. If the
+// requested index was removed from the event log, the Subscription will first
+// receive an EventsTruncated event.
+//
 // When a caller is finished with the subscription it must call Subscription.Unsubscribe
 // to free ACL tracking resources.
 func (e *EventBroker) Subscribe(req *SubscribeRequest) (*Subscription, error) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	// Prevent events from being published while finding where the
+	// subscription starts.
+	e.publishLock.Lock()
+	defer e.publishLock.Unlock()
+
+	if e.eventLog != nil && req.Index != 0 {
+		if head := e.eventBuf.Head(); head.Events.Index == 0 || req.Index < head.Events.Index {
+			return e.subscribeFromLog(req)
+		}
+	}
+
 	var head *bufferItem
 	var offset int
 	if req.Index != 0 {
@@ -163,11 +195,53 @@ func (e *EventBroker) Subscribe(req *SubscribeRequest) (*Subscription, error) {
 	close(start.link.nextCh)
 
 	sub := newSubscription(req, start, e.subscriptions.unsubscribeFn(req))
+	sub.broker = e
+	if head.Events.Index != 0 {
+		sub.lastIndex = head.Events.Index - 1
+	} else if e.eventLog != nil {
+		sub.lastIndex = e.eventLog.LastIndex()
+	}
 
 	e.subscriptions.add(req, sub)
 	return sub, nil
 }
 
+// subscribeFromLog returns a Subscription that starts by reading the event log
+// from the requested index. The caller must hold e.mu and e.publishLock.
+func (e *EventBroker) subscribeFromLog(req *SubscribeRequest) (*Subscription, error) {
+	if req.StartExactlyAtIndex && req.Index <= e.eventLog.getTruncatedIndex() {
+		return nil, fmt.Errorf("requested index not in buffer or event log")
+	}
+
+	sub := newSubscription(req, newSentinelItem(), e.subscriptions.unsubscribeFn(req))
+	sub.broker = e
+	sub.lastIndex = req.Index - 1
+	sub.replay = e.eventLog.newReader(req.Index)
+
+	e.subscriptions.add(req, sub)
+	return sub, nil
+}
+
+// resumeFromLog reads the remaining events of a subscription's event log
+// reader with publishing paused. It returns those events along with the
+// buffer item the subscription continues from once they are delivered.
+func (e *EventBroker) resumeFromLog(r *eventLogReader) ([]*structs.Events, *bufferItem, error) {
+	e.publishLock.Lock()
+	defer e.publishLock.Unlock()
+
+	var rest []*structs.Events
+	for {
+		events, err := r.Next()
+		if err == io.EOF {
+			return rest, e.eventBuf.Tail(), nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		rest = append(rest, events)
+	}
+}
+
 // CloseAll closes all subscriptions
 func (e *EventBroker) CloseAll() {
 	e.subscriptions.closeAll()
@@ -180,9 +254,22 @@ func (e *EventBroker) handleUpdates(ctx context.Context) {
 			e.subscriptions.closeAll()
 			return
 		case update := <-e.publishCh:
-			e.eventBuf.Append(update)
+			e.append(update)
+		}
+	}
+}
+
+// append writes events to the event log, if any, and then to the buffer.
+func (e *EventBroker) append(events *structs.Events) {
+	e.publishLock.Lock()
+	defer e.publishLock.Unlock()
+
+	if e.eventLog != nil {
+		if err := e.eventLog.Append(events); err != nil {
+			e.logger.Error("failed to write events to event log", "index", events.Index, "error", err)
 		}
 	}
+	e.eventBuf.Append(events)
 }
 
 func (e *EventBroker) handleACLUpdates(ctx context.Context) {
@@ -373,6 +460,34 @@ func aclAllowsSubscription(aclObj *acl.ACL, subReq *SubscribeRequest) bool {
 			if ok := aclObj.IsManagement(); !ok {
 				return false
 			}
+		case structs.TopicVariables:
+			// Variable ACLs are scoped by path, so require list access to
+			// every path in the namespace since we can't filter out the
+			// variables the token doesn't have access to.
+			if ok := aclObj.AllowVariableOperation(subReq.Namespace, "*", acl.VariablesCapabilityList, nil); !ok {
+				return false
+			}
+		case structs.TopicNamespace:
+			if ok := aclObj.AllowNamespace(subReq.Namespace); !ok {
+				return false
+			}
+		case structs.TopicCSIVolume:
+			allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityCSIReadVolume,
+				acl.NamespaceCapabilityCSIMountVolume,
+				acl.NamespaceCapabilityReadJob)
+			if ok := allowVolume(aclObj, subReq.Namespace); !ok {
+				return false
+			}
+		case structs.TopicCSIPlugin:
+			if ok := aclObj.AllowPluginRead(); !ok {
+				return false
+			}
+		case structs.TopicScalingPolicy:
+			if ok := aclObj.AllowNsOp(subReq.Namespace, acl.NamespaceCapabilityReadScalingPolicy) ||
+				(aclObj.AllowNsOp(subReq.Namespace, acl.NamespaceCapabilityListJobs) &&
+					aclObj.AllowNsOp(subReq.Namespace, acl.NamespaceCapabilityReadJob)); !ok {
+				return false
+			}
 		default:
 			if ok := aclObj.IsManagement(); !ok {
 				return false
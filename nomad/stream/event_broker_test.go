@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
 
 	"github.com/stretchr/testify/require"
 )
@@ -1072,6 +1073,271 @@ func TestEventBroker_NodePool_ACL(t *testing.T) {
 
 }
 
+func TestEventBroker_Topics_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	testCases := []struct {
+		name        string
+		topic       structs.Topic
+		rules       string
+		expectedErr string
+	}{
+		{
+			name:  "variables list all paths",
+			topic: structs.TopicVariables,
+			rules: `namespace "default" { variables { path "*" { capabilities = ["list"] } } }`,
+		},
+		{
+			name:        "variables list some paths",
+			topic:       structs.TopicVariables,
+			rules:       `namespace "default" { variables { path "app/*" { capabilities = ["list", "read"] } } }`,
+			expectedErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:  "namespace read",
+			topic: structs.TopicNamespace,
+			rules: `namespace "default" { policy = "read" }`,
+		},
+		{
+			name:        "namespace other",
+			topic:       structs.TopicNamespace,
+			rules:       `namespace "other" { policy = "read" }`,
+			expectedErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:  "csi volume read",
+			topic: structs.TopicCSIVolume,
+			rules: `namespace "default" { capabilities = ["csi-read-volume"] }`,
+		},
+		{
+			name:        "csi volume list",
+			topic:       structs.TopicCSIVolume,
+			rules:       `namespace "default" { capabilities = ["csi-list-volume"] }`,
+			expectedErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:  "csi plugin read",
+			topic: structs.TopicCSIPlugin,
+			rules: `plugin { policy = "read" }`,
+		},
+		{
+			name:        "csi plugin denied",
+			topic:       structs.TopicCSIPlugin,
+			rules:       `namespace "default" { policy = "read" }`,
+			expectedErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:  "scaling policy read",
+			topic: structs.TopicScalingPolicy,
+			rules: `namespace "default" { capabilities = ["read-scaling-policy"] }`,
+		},
+		{
+			name:  "scaling policy list and read jobs",
+			topic: structs.TopicScalingPolicy,
+			rules: `namespace "default" { capabilities = ["list-jobs", "read-job"] }`,
+		},
+		{
+			name:        "scaling policy list jobs",
+			topic:       structs.TopicScalingPolicy,
+			rules:       `namespace "default" { capabilities = ["list-jobs"] }`,
+			expectedErr: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:        "root key",
+			topic:       structs.TopicRootKey,
+			rules:       `namespace "default" { policy = "write" } operator { policy = "write" }`,
+			expectedErr: structs.ErrPermissionDenied.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token := &structs.ACLToken{
+				AccessorID: uuid.Generate(),
+				SecretID:   uuid.Generate(),
+				Type:       structs.ACLClientToken,
+				Policies:   []string{"test"},
+			}
+			policy := &structs.ACLPolicy{
+				Name:  "test",
+				Rules: tc.rules,
+			}
+			tokenProvider := &fakeACLTokenProvider{token: token, policy: policy}
+			aclDelegate := &fakeACLDelegate{tokenProvider: tokenProvider}
+
+			publisher, err := NewEventBroker(ctx, aclDelegate, EventBrokerCfg{})
+			must.NoError(t, err)
+
+			_, _, err = publisher.SubscribeWithACLCheck(&SubscribeRequest{
+				Topics:    map[structs.Topic][]string{tc.topic: {"*"}},
+				Namespace: "default",
+				Token:     token.SecretID,
+			})
+
+			if tc.expectedErr != "" {
+				must.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				must.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEventBroker_EventLog_Resume(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	log, err := NewEventLog(EventLogConfig{Dir: t.TempDir()})
+	must.NoError(t, err)
+	t.Cleanup(func() { log.Close() })
+
+	publisher, err := NewEventBroker(ctx, nil, EventBrokerCfg{
+		EventBufferSize: 2,
+		EventLog:        log,
+	})
+	must.NoError(t, err)
+
+	for i := uint64(1); i <= 10; i++ {
+		publisher.Publish(testEvents(i))
+	}
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return log.LastIndex() == 10 }),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// Events no longer in the buffer are read from the event log
+	sub, err := publisher.Subscribe(&SubscribeRequest{
+		Index:  3,
+		Topics: map[structs.Topic][]string{"Test": {"sub-key"}},
+	})
+	must.NoError(t, err)
+	defer sub.Unsubscribe()
+	eventCh := consumeSubscription(ctx, sub)
+
+	for i := uint64(3); i <= 10; i++ {
+		result := nextResult(t, eventCh)
+		must.NoError(t, result.Err)
+		must.Len(t, 1, result.Events)
+		must.Eq(t, i, result.Events[0].Index)
+	}
+	assertNoResult(t, eventCh)
+
+	// New events are read from the buffer once caught up
+	publisher.Publish(testEvents(11))
+	result := nextResult(t, eventCh)
+	must.NoError(t, result.Err)
+	must.Eq(t, 11, result.Events[0].Index)
+}
+
+func TestEventBroker_EventLog_SlowSubscriber(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	log, err := NewEventLog(EventLogConfig{Dir: t.TempDir()})
+	must.NoError(t, err)
+	t.Cleanup(func() { log.Close() })
+
+	publisher, err := NewEventBroker(ctx, nil, EventBrokerCfg{
+		EventBufferSize: 2,
+		EventLog:        log,
+	})
+	must.NoError(t, err)
+
+	sub, err := publisher.Subscribe(&SubscribeRequest{
+		Topics: map[structs.Topic][]string{"Test": {"sub-key"}},
+	})
+	must.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	// Publish more events than the buffer holds before reading any
+	for i := uint64(1); i <= 10; i++ {
+		publisher.Publish(testEvents(i))
+	}
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return log.LastIndex() == 10 }),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// The subscriber continues from the event log without missing any
+	for i := uint64(1); i <= 10; i++ {
+		events, err := sub.Next(ctx)
+		must.NoError(t, err)
+		must.Eq(t, i, events.Index)
+	}
+}
+
+func TestEventBroker_EventLog_Truncated(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	log, err := NewEventLog(EventLogConfig{
+		Dir:          t.TempDir(),
+		MaxBytes:     2048,
+		SegmentBytes: 256,
+	})
+	must.NoError(t, err)
+	t.Cleanup(func() { log.Close() })
+
+	publisher, err := NewEventBroker(ctx, nil, EventBrokerCfg{
+		EventBufferSize: 2,
+		EventLog:        log,
+	})
+	must.NoError(t, err)
+
+	for i := uint64(1); i <= 100; i++ {
+		publisher.Publish(testEvents(i))
+	}
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return log.LastIndex() == 100 }),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+	first := log.FirstIndex()
+	must.Greater(t, 1, first)
+
+	// Subscriptions that must start at a removed index are rejected
+	_, err = publisher.Subscribe(&SubscribeRequest{
+		Index:               1,
+		Topics:              map[structs.Topic][]string{"Test": {"sub-key"}},
+		StartExactlyAtIndex: true,
+	})
+	must.ErrorContains(t, err, "requested index not in buffer or event log")
+
+	// Other subscriptions are told which events were removed, regardless of
+	// the topics they subscribed to
+	sub, err := publisher.Subscribe(&SubscribeRequest{
+		Index:  1,
+		Topics: map[structs.Topic][]string{"Test": {"sub-key"}},
+	})
+	must.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	events, err := sub.Next(ctx)
+	must.NoError(t, err)
+	must.Eq(t, first-1, events.Index)
+	must.Len(t, 1, events.Events)
+	must.Eq(t, structs.TypeEventsTruncated, events.Events[0].Type)
+
+	payload := events.Events[0].Payload.(*structs.EventsTruncatedEvent)
+	must.Eq(t, 1, payload.EventsTruncated.RequestedIndex)
+	must.Eq(t, first, payload.EventsTruncated.FirstIndex)
+
+	events, err = sub.Next(ctx)
+	must.NoError(t, err)
+	must.Eq(t, first, events.Index)
+}
+
 func consumeSubscription(ctx context.Context, sub *Subscription) <-chan subNextResult {
 	eventCh := make(chan subNextResult, 1)
 	go func() {
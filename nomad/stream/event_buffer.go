@@ -54,6 +54,10 @@ type eventBuffer struct {
 	maxSize int64
 }
 
+// errEventDropped is returned when reading the item following one that was
+// dropped from the buffer before the reader got to it.
+var errEventDropped = errors.New("event dropped from buffer")
+
 // newEventBuffer creates an eventBuffer ready for use.
 func newEventBuffer(size int64) *eventBuffer {
 	zero := int64(0)
@@ -270,7 +274,7 @@ func (i *bufferItem) Next(ctx context.Context, forceClose <-chan struct{}) (*buf
 	// between linkCh and droppedCh
 	select {
 	case <-i.link.droppedCh:
-		return nil, fmt.Errorf("event dropped from buffer")
+		return nil, errEventDropped
 	default:
 	}
 
@@ -0,0 +1,857 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package stream
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-msgpack/v2/codec"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// defaultEventLogSegmentBytes is the size at which the active segment of
+	// the event log is sealed and a new one is started.
+	defaultEventLogSegmentBytes = 16 * 1024 * 1024
+
+	// eventLogSegmentExt is the file extension of event log segments. Segments
+	// are named after the index of the first events they hold.
+	eventLogSegmentExt = ".log"
+
+	// eventLogTruncatedFile holds the index of the most recent events removed
+	// by retention, so that it is known across restarts which indexes can no
+	// longer be served.
+	eventLogTruncatedFile = "truncated"
+
+	// eventLogHeaderSize is the size of the header preceding each record: the
+	// length of the encoded events followed by their CRC32 checksum.
+	eventLogHeaderSize = 8
+
+	// defaultEventLogSyncInterval is how often the records appended to the
+	// active segment are synced to disk.
+	defaultEventLogSyncInterval = time.Second
+)
+
+// errEventLogClosed is returned when appending to a closed event log.
+var errEventLogClosed = errors.New("event log closed")
+
+// EventLogConfig configures an EventLog.
+type EventLogConfig struct {
+	// Dir is the directory the log segments are written to.
+	Dir string
+
+	// MaxBytes is the total size of the segments to retain. Zero means the
+	// log is not limited by size.
+	MaxBytes int64
+
+	// MaxAge is how long to retain segments after their last write. Zero
+	// means the log is not limited by age.
+	MaxAge time.Duration
+
+	// SegmentBytes is the size at which segments are rotated.
+	SegmentBytes int64
+
+	// SyncInterval is how often appended records are synced to disk.
+	SyncInterval time.Duration
+
+	Logger hclog.Logger
+}
+
+// EventLog is an append-only, on-disk log of published events. The log is
+// split into segments so that the oldest events can be removed once the log
+// grows past its retention limits. It allows subscribers to resume from
+// events that are no longer held in memory by the event buffer.
+type EventLog struct {
+	cfg    EventLogConfig
+	logger hclog.Logger
+
+	// mu guards the fields below
+	mu sync.Mutex
+
+	// segments is ordered by index, the last segment is the active one
+	segments []*eventLogSegment
+	active   *os.File
+	size     int64
+
+	// lastIndex is the index of the most recent events in the log
+	lastIndex uint64
+
+	// truncatedIndex is the index of the most recent events removed from the
+	// log by retention
+	truncatedIndex uint64
+
+	// unsynced is set when records were appended to the active segment since
+	// it was last synced
+	unsynced bool
+
+	closed bool
+
+	// stopCh stops the periodic sync when the log is closed
+	stopCh chan struct{}
+}
+
+type eventLogSegment struct {
+	path       string
+	firstIndex uint64
+	lastIndex  uint64
+	size       int64
+	modTime    time.Time
+}
+
+// NewEventLog opens the event log in the configured directory, creating it if
+// it doesn't exist. Records left partially written by a crash are discarded.
+func NewEventLog(cfg EventLogConfig) (*EventLog, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("event log directory must be set")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = hclog.NewNullLogger()
+	}
+	if cfg.SegmentBytes <= 0 {
+		cfg.SegmentBytes = defaultEventLogSegmentBytes
+	}
+	if cfg.SyncInterval <= 0 {
+		cfg.SyncInterval = defaultEventLogSyncInterval
+	}
+
+	// Keep segments small enough relative to the size limit that removing
+	// the oldest one doesn't discard a large share of the log.
+	if cfg.MaxBytes > 0 && cfg.SegmentBytes > cfg.MaxBytes/4 {
+		cfg.SegmentBytes = max(cfg.MaxBytes/4, 1)
+	}
+
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create event log directory: %w", err)
+	}
+
+	l := &EventLog{
+		cfg:    cfg,
+		logger: cfg.Logger.Named("event_log"),
+		stopCh: make(chan struct{}),
+	}
+
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	l.enforceRetention(time.Now())
+
+	go l.syncPeriodically()
+	return l, nil
+}
+
+// load reads the existing segments from disk, and opens the last one for
+// writing.
+func (l *EventLog) load() error {
+	raw, err := os.ReadFile(filepath.Join(l.cfg.Dir, eventLogTruncatedFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("failed to read event log truncated index: %w", err)
+	default:
+		l.truncatedIndex, err = strconv.ParseUint(strings.TrimSpace(string(raw)), 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse event log truncated index: %w", err)
+		}
+	}
+
+	entries, err := os.ReadDir(l.cfg.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to read event log directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, eventLogSegmentExt) {
+			continue
+		}
+		first, err := strconv.ParseUint(strings.TrimSuffix(name, eventLogSegmentExt), 10, 64)
+		if err != nil {
+			l.logger.Warn("ignoring unexpected file in event log directory", "file", name)
+			continue
+		}
+		l.segments = append(l.segments, &eventLogSegment{
+			path:       filepath.Join(l.cfg.Dir, name),
+			firstIndex: first,
+		})
+	}
+	sort.Slice(l.segments, func(i, j int) bool {
+		return l.segments[i].firstIndex < l.segments[j].firstIndex
+	})
+
+	segments := l.segments[:0]
+	for _, seg := range l.segments {
+		if err := l.scanSegment(seg); err != nil {
+			return err
+		}
+
+		// Segments without any events are left behind by a crash right
+		// after rotating, they can be removed
+		if seg.lastIndex == 0 {
+			if err := os.Remove(seg.path); err != nil {
+				return fmt.Errorf("failed to remove empty event log segment: %w", err)
+			}
+			continue
+		}
+
+		segments = append(segments, seg)
+		l.size += seg.size
+		l.lastIndex = seg.lastIndex
+	}
+	l.segments = segments
+
+	if len(l.segments) == 0 {
+		return nil
+	}
+
+	seg := l.segments[len(l.segments)-1]
+	l.active, err = os.OpenFile(seg.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open event log segment: %w", err)
+	}
+	return nil
+}
+
+// scanSegment reads the records of a segment to find its last index and
+// size. A trailing record that is incomplete or corrupt is truncated.
+func (l *EventLog) scanSegment(seg *eventLogSegment) error {
+	f, err := os.OpenFile(seg.path, os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open event log segment: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat event log segment: %w", err)
+	}
+	seg.modTime = info.ModTime()
+
+	var offset int64
+	for {
+		events, n, err := readEventLogRecord(f, offset)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			l.logger.Warn("truncating invalid record in event log segment",
+				"segment", seg.path, "offset", offset, "error", err)
+			if err := f.Truncate(offset); err != nil {
+				return fmt.Errorf("failed to truncate event log segment: %w", err)
+			}
+			break
+		}
+		offset += n
+		seg.lastIndex = events.Index
+	}
+	seg.size = offset
+	return nil
+}
+
+// Append writes events to the end of the log. Events at or below the last
+// index in the log are skipped, as they are published again when the raft
+// log is replayed on startup.
+func (l *EventLog) Append(events *structs.Events) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return errEventLogClosed
+	}
+	if events.Index <= l.lastIndex {
+		return nil
+	}
+
+	buf, err := encodeEventLogRecord(events)
+	if err != nil {
+		return err
+	}
+
+	record := make([]byte, eventLogHeaderSize+len(buf))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(buf)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(buf))
+	copy(record[eventLogHeaderSize:], buf)
+
+	if l.active == nil || l.needsRotate(int64(len(record))) {
+		if err := l.rotate(events.Index); err != nil {
+			return err
+		}
+	}
+
+	seg := l.segments[len(l.segments)-1]
+	n, err := l.active.Write(record)
+	if err != nil {
+		// Drop the partial record so the segment stays readable
+		if terr := l.active.Truncate(seg.size); terr != nil {
+			l.logger.Error("failed to truncate partial event log record", "error", terr)
+		}
+		return fmt.Errorf("failed to write events: %w", err)
+	}
+
+	now := time.Now()
+	seg.size += int64(n)
+	seg.lastIndex = events.Index
+	seg.modTime = now
+	l.size += int64(n)
+	l.lastIndex = events.Index
+	l.unsynced = true
+
+	l.enforceRetention(now)
+	return nil
+}
+
+// syncPeriodically syncs the records appended to the active segment every
+// sync interval until the log is closed. Appends are called while the event
+// broker publishes, so they only write the records and leave syncing them to
+// this loop. Events covered by a raft snapshot are never published again, so
+// records not yet synced when the server crashes may be lost.
+func (l *EventLog) syncPeriodically() {
+	ticker := time.NewTicker(l.cfg.SyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		if err := l.sync(); err != nil {
+			l.logger.Error("failed to sync event log segment", "error", err)
+		}
+	}
+}
+
+// sync syncs the active segment if records were appended to it since it was
+// last synced. The segment is synced without holding l.mu, so appends aren't
+// blocked while the disk catches up.
+func (l *EventLog) sync() error {
+	l.mu.Lock()
+	f := l.active
+	if !l.unsynced || f == nil || l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.unsynced = false
+	l.mu.Unlock()
+
+	err := f.Sync()
+	if errors.Is(err, os.ErrClosed) {
+		// The segment was rotated or the log closed, both of which sync it
+		return nil
+	}
+	if err != nil {
+		l.mu.Lock()
+		l.unsynced = true
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// needsRotate returns whether a record of the given size would grow the
+// active segment past the segment size. The caller must hold l.mu.
+func (l *EventLog) needsRotate(size int64) bool {
+	seg := l.segments[len(l.segments)-1]
+	return seg.size > 0 && seg.size+size > l.cfg.SegmentBytes
+}
+
+// rotate seals the active segment and starts a new one beginning at index.
+// The caller must hold l.mu.
+func (l *EventLog) rotate(index uint64) error {
+	if l.active != nil {
+		if err := l.active.Sync(); err != nil {
+			return fmt.Errorf("failed to sync event log segment: %w", err)
+		}
+		if err := l.active.Close(); err != nil {
+			return fmt.Errorf("failed to close event log segment: %w", err)
+		}
+		l.active = nil
+		l.unsynced = false
+	}
+
+	path := filepath.Join(l.cfg.Dir, fmt.Sprintf("%020d%s", index, eventLogSegmentExt))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create event log segment: %w", err)
+	}
+
+	l.active = f
+	l.segments = append(l.segments, &eventLogSegment{
+		path:       path,
+		firstIndex: index,
+		modTime:    time.Now(),
+	})
+	return nil
+}
+
+// enforceRetention removes the oldest segments while the log is over its size
+// limit or they are older than the max age. The active segment is never
+// removed. The caller must hold l.mu.
+func (l *EventLog) enforceRetention(now time.Time) {
+	for len(l.segments) > 1 {
+		oldest := l.segments[0]
+		oversized := l.cfg.MaxBytes > 0 && l.size > l.cfg.MaxBytes
+		expired := l.cfg.MaxAge > 0 && now.Sub(oldest.modTime) > l.cfg.MaxAge
+		if !oversized && !expired {
+			return
+		}
+
+		// Record the truncated index before removing the segment, so that a
+		// crash never leaves a gap that isn't reported to subscribers.
+		if err := l.writeTruncatedIndex(oldest.lastIndex); err != nil {
+			l.logger.Error("failed to persist event log truncated index", "error", err)
+			return
+		}
+		if err := os.Remove(oldest.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			l.logger.Warn("failed to remove event log segment", "segment", oldest.path, "error", err)
+			return
+		}
+
+		l.truncatedIndex = oldest.lastIndex
+		l.size -= oldest.size
+		l.segments = l.segments[1:]
+		l.logger.Trace("removed event log segment", "segment", oldest.path,
+			"last_index", oldest.lastIndex)
+	}
+}
+
+func (l *EventLog) writeTruncatedIndex(index uint64) error {
+	path := filepath.Join(l.cfg.Dir, eventLogTruncatedFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(index, 10)), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// FirstIndex returns the index of the oldest events retained in the log, or
+// zero if the log is empty.
+func (l *EventLog) FirstIndex() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.segments) == 0 {
+		return 0
+	}
+	return l.segments[0].firstIndex
+}
+
+// LastIndex returns the index of the most recent events in the log, or zero
+// if the log is empty.
+func (l *EventLog) LastIndex() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lastIndex
+}
+
+// Close syncs and closes the active segment. Further appends will fail, but
+// readers already open may continue to read the retained segments.
+func (l *EventLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	close(l.stopCh)
+
+	if l.active == nil {
+		return nil
+	}
+	if err := l.active.Sync(); err != nil {
+		l.active.Close()
+		return fmt.Errorf("failed to sync event log segment: %w", err)
+	}
+	return l.active.Close()
+}
+
+// segmentFor returns the segment holding index, or the oldest segment if
+// index is before the start of the log.
+func (l *EventLog) segmentFor(index uint64) (eventLogSegment, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.segments) == 0 {
+		return eventLogSegment{}, false
+	}
+	seg := l.segments[0]
+	for _, s := range l.segments[1:] {
+		if s.firstIndex > index {
+			break
+		}
+		seg = s
+	}
+	return *seg, true
+}
+
+// segmentAfter returns the oldest segment that starts after index, along with
+// the truncated index of the log.
+func (l *EventLog) segmentAfter(index uint64) (eventLogSegment, uint64, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, s := range l.segments {
+		if s.firstIndex > index {
+			return *s, l.truncatedIndex, true
+		}
+	}
+	return eventLogSegment{}, l.truncatedIndex, false
+}
+
+func (l *EventLog) getTruncatedIndex() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.truncatedIndex
+}
+
+// newReader returns a reader of the events in the log starting at index.
+func (l *EventLog) newReader(index uint64) *eventLogReader {
+	return &eventLogReader{
+		log:       l,
+		lastIndex: max(index, 1) - 1,
+	}
+}
+
+// eventLogTruncatedError is returned when opening a segment if events the
+// reader has not yet read were removed from the log by retention.
+type eventLogTruncatedError struct {
+	// RequestedIndex is the next index the reader expected
+	RequestedIndex uint64
+
+	// FirstIndex is the index the reader continues from
+	FirstIndex uint64
+}
+
+func (e *eventLogTruncatedError) Error() string {
+	return fmt.Sprintf("events from index %d to %d were removed from the event log",
+		e.RequestedIndex, e.FirstIndex-1)
+}
+
+// newEventsTruncated returns the events delivered to subscribers in place of
+// the events removed from the log. Its index is the last one removed, so that
+// subscribers resuming from the following index don't miss any more events.
+func newEventsTruncated(err *eventLogTruncatedError) *structs.Events {
+	index := err.FirstIndex - 1
+	return &structs.Events{
+		Index: index,
+		Events: []structs.Event{{
+			Topic: structs.TopicEventStream,
+			Type:  structs.TypeEventsTruncated,
+			Index: index,
+			Payload: &structs.EventsTruncatedEvent{
+				EventsTruncated: &structs.EventsTruncated{
+					RequestedIndex: err.RequestedIndex,
+					FirstIndex:     err.FirstIndex,
+				},
+			},
+		}},
+	}
+}
+
+// eventLogReader reads events from the log in order. When it reaches the end
+// of the log it returns io.EOF, and may be called again once more events have
+// been appended.
+type eventLogReader struct {
+	log *EventLog
+
+	// mu guards the fields below, so the reader may be closed while another
+	// goroutine is reading
+	mu sync.Mutex
+
+	file      *os.File
+	segment   uint64
+	offset    int64
+	lastIndex uint64
+	closed    bool
+}
+
+// Next returns the next events in the log, or io.EOF when there are no more
+// events. If events following the last ones read were removed from the log,
+// an EventsTruncated event is returned in their place and reading continues
+// from the oldest events retained.
+func (r *eventLogReader) Next() (*structs.Events, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil, errors.New("event log reader closed")
+	}
+
+	for {
+		if r.file == nil {
+			err := r.openNext()
+			var truncErr *eventLogTruncatedError
+			if errors.As(err, &truncErr) {
+				return newEventsTruncated(truncErr), nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if r.file == nil {
+				continue
+			}
+		}
+
+		events, n, err := readEventLogRecord(r.file, r.offset)
+		if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+			// The record may still be being written, so only move on to the
+			// next segment once there is one.
+			if _, _, ok := r.log.segmentAfter(r.segment); !ok {
+				return nil, io.EOF
+			}
+			r.file.Close()
+			r.file = nil
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		r.offset += n
+		if events.Index <= r.lastIndex {
+			continue
+		}
+		r.lastIndex = events.Index
+		return events, nil
+	}
+}
+
+// openNext opens the segment following the one last read. It may return
+// without an open segment if the segment was removed before it could be
+// opened. The caller must hold r.mu.
+func (r *eventLogReader) openNext() error {
+	var seg eventLogSegment
+	var ok bool
+	if r.segment == 0 {
+		seg, ok = r.log.segmentFor(r.lastIndex + 1)
+	} else {
+		seg, _, ok = r.log.segmentAfter(r.segment)
+	}
+	if !ok {
+		if truncated := r.log.getTruncatedIndex(); truncated > r.lastIndex {
+			return r.truncatedErr(truncated + 1)
+		}
+		return io.EOF
+	}
+
+	r.segment = seg.firstIndex
+	r.offset = 0
+
+	f, err := os.Open(seg.path)
+	if errors.Is(err, os.ErrNotExist) {
+		// The segment was removed by retention after it was looked up. Only
+		// sealed segments are removed, so its last index is final.
+		if seg.lastIndex > r.lastIndex {
+			return r.truncatedErr(seg.lastIndex + 1)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open event log segment: %w", err)
+	}
+	r.file = f
+
+	// Any events after the last ones read but before this segment have been
+	// removed from the log.
+	if seg.firstIndex > r.lastIndex+1 && r.log.getTruncatedIndex() > r.lastIndex {
+		return r.truncatedErr(seg.firstIndex)
+	}
+	return nil
+}
+
+// truncatedErr returns an error reporting that the events up to first were
+// removed, and skips the reader past them. The caller must hold r.mu.
+func (r *eventLogReader) truncatedErr(first uint64) error {
+	err := &eventLogTruncatedError{RequestedIndex: r.lastIndex + 1, FirstIndex: first}
+	r.lastIndex = first - 1
+	return err
+}
+
+// Close closes the reader's open segment.
+func (r *eventLogReader) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	if r.file != nil {
+		r.file.Close()
+		r.file = nil
+	}
+}
+
+// readEventLogRecord reads the record at offset and returns the decoded
+// events along with the size of the record. It returns io.EOF if there is
+// no record at offset and io.ErrUnexpectedEOF if the record is incomplete.
+func readEventLogRecord(f *os.File, offset int64) (*structs.Events, int64, error) {
+	var header [eventLogHeaderSize]byte
+	n, err := f.ReadAt(header[:], offset)
+	if n == 0 && err == io.EOF {
+		return nil, 0, io.EOF
+	}
+	if n < eventLogHeaderSize {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, err
+	}
+
+	length := binary.BigEndian.Uint32(header[0:4])
+	checksum := binary.BigEndian.Uint32(header[4:8])
+
+	buf := make([]byte, length)
+	n, err = f.ReadAt(buf, offset+eventLogHeaderSize)
+	if n < len(buf) {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, err
+	}
+	if crc32.ChecksumIEEE(buf) != checksum {
+		return nil, 0, errors.New("event log record checksum mismatch")
+	}
+
+	events, err := decodeEventLogRecord(buf)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, eventLogHeaderSize + int64(length), nil
+}
+
+// eventLogRecord is the encoded form of the events in a record. Payloads are
+// encoded separately from the rest of the event, so that they can be decoded
+// into the concrete type of their topic rather than into generic maps.
+type eventLogRecord struct {
+	Index  uint64
+	Events []eventLogEvent
+}
+
+type eventLogEvent struct {
+	Topic      structs.Topic
+	Type       string
+	Key        string
+	Namespace  string
+	FilterKeys []string
+	Index      uint64
+	Payload    []byte
+}
+
+func encodeEventLogRecord(events *structs.Events) ([]byte, error) {
+	record := eventLogRecord{
+		Index:  events.Index,
+		Events: make([]eventLogEvent, len(events.Events)),
+	}
+	for i, event := range events.Events {
+		var payload []byte
+		if err := codec.NewEncoderBytes(&payload, structs.MsgpackHandle).Encode(event.Payload); err != nil {
+			return nil, fmt.Errorf("failed to encode %s event payload: %w", event.Topic, err)
+		}
+		record.Events[i] = eventLogEvent{
+			Topic:      event.Topic,
+			Type:       event.Type,
+			Key:        event.Key,
+			Namespace:  event.Namespace,
+			FilterKeys: event.FilterKeys,
+			Index:      event.Index,
+			Payload:    payload,
+		}
+	}
+
+	var buf []byte
+	if err := codec.NewEncoderBytes(&buf, structs.MsgpackHandle).Encode(&record); err != nil {
+		return nil, fmt.Errorf("failed to encode events: %w", err)
+	}
+	return buf, nil
+}
+
+func decodeEventLogRecord(buf []byte) (*structs.Events, error) {
+	var record eventLogRecord
+	if err := codec.NewDecoderBytes(buf, structs.MsgpackHandle).Decode(&record); err != nil {
+		return nil, fmt.Errorf("failed to decode event log record: %w", err)
+	}
+
+	events := &structs.Events{
+		Index:  record.Index,
+		Events: make([]structs.Event, len(record.Events)),
+	}
+	for i, event := range record.Events {
+		payload := newEventPayload(event.Topic)
+		if err := codec.NewDecoderBytes(event.Payload, structs.MsgpackHandle).Decode(payload); err != nil {
+			return nil, fmt.Errorf("failed to decode %s event payload: %w", event.Topic, err)
+		}
+		if generic, ok := payload.(*interface{}); ok {
+			payload = *generic
+		}
+		events.Events[i] = structs.Event{
+			Topic:      event.Topic,
+			Type:       event.Type,
+			Key:        event.Key,
+			Namespace:  event.Namespace,
+			FilterKeys: event.FilterKeys,
+			Index:      event.Index,
+			Payload:    payload,
+		}
+	}
+	return events, nil
+}
+
+// newEventPayload returns a pointer to decode the payload of an event of the
+// topic into, of the same type the state store publishes. Payloads of unknown
+// topics are decoded generically.
+func newEventPayload(topic structs.Topic) interface{} {
+	switch topic {
+	case structs.TopicDeployment:
+		return new(structs.DeploymentEvent)
+	case structs.TopicEvaluation:
+		return new(structs.EvaluationEvent)
+	case structs.TopicAllocation:
+		return new(structs.AllocationEvent)
+	case structs.TopicJob:
+		return new(structs.JobEvent)
+	case structs.TopicNode:
+		return new(structs.NodeStreamEvent)
+	case structs.TopicNodePool:
+		return new(structs.NodePoolEvent)
+	case structs.TopicACLPolicy:
+		return new(structs.ACLPolicyEvent)
+	case structs.TopicACLToken:
+		return new(structs.ACLTokenEvent)
+	case structs.TopicACLRole:
+		return new(structs.ACLRoleStreamEvent)
+	case structs.TopicACLAuthMethod:
+		return new(structs.ACLAuthMethodEvent)
+	case structs.TopicACLBindingRule:
+		return new(structs.ACLBindingRuleEvent)
+	case structs.TopicService:
+		return new(structs.ServiceRegistrationStreamEvent)
+	case structs.TopicVariables:
+		return new(structs.VariableEvent)
+	case structs.TopicNamespace:
+		return new(structs.NamespaceEvent)
+	case structs.TopicCSIVolume:
+		return new(structs.CSIVolumeEvent)
+	case structs.TopicCSIPlugin:
+		return new(structs.CSIPluginEvent)
+	case structs.TopicScalingPolicy:
+		return new(structs.ScalingPolicyEvent)
+	case structs.TopicRootKey:
+		return new(structs.RootKeyEvent)
+	case structs.TopicEventStream:
+		return new(structs.EventsTruncatedEvent)
+	default:
+		return new(interface{})
+	}
+}
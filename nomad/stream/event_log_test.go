@@ -0,0 +1,277 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package stream
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func testEvents(index uint64) *structs.Events {
+	return &structs.Events{
+		Index: index,
+		Events: []structs.Event{{
+			Index:   index,
+			Topic:   "Test",
+			Key:     "sub-key",
+			Payload: "sample payload",
+		}},
+	}
+}
+
+// readIndexes reads events from the reader until the end of the log and
+// returns their indexes.
+func readIndexes(t *testing.T, r *eventLogReader) []uint64 {
+	t.Helper()
+
+	var indexes []uint64
+	for {
+		events, err := r.Next()
+		if err == io.EOF {
+			return indexes
+		}
+		must.NoError(t, err)
+		indexes = append(indexes, events.Index)
+	}
+}
+
+func TestEventLog_AppendAndRead(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	log, err := NewEventLog(EventLogConfig{Dir: dir})
+	must.NoError(t, err)
+
+	for i := uint64(1); i <= 5; i++ {
+		must.NoError(t, log.Append(testEvents(i)))
+	}
+	must.Eq(t, 1, log.FirstIndex())
+	must.Eq(t, 5, log.LastIndex())
+
+	// Events already in the log are skipped
+	must.NoError(t, log.Append(testEvents(3)))
+	must.Eq(t, 5, log.LastIndex())
+
+	r := log.newReader(3)
+	defer r.Close()
+	must.Eq(t, []uint64{3, 4, 5}, readIndexes(t, r))
+
+	// The reader continues once more events are appended
+	must.NoError(t, log.Append(testEvents(6)))
+	events, err := r.Next()
+	must.NoError(t, err)
+	must.Eq(t, 6, events.Index)
+	must.Eq(t, "sample payload", events.Events[0].Payload)
+
+	// The events are kept across restarts
+	must.NoError(t, log.Close())
+	must.ErrorIs(t, log.Append(testEvents(7)), errEventLogClosed)
+
+	log, err = NewEventLog(EventLogConfig{Dir: dir})
+	must.NoError(t, err)
+	defer log.Close()
+
+	must.Eq(t, 6, log.LastIndex())
+	must.NoError(t, log.Append(testEvents(7)))
+	must.Eq(t, []uint64{1, 2, 3, 4, 5, 6, 7}, readIndexes(t, log.newReader(0)))
+}
+
+func TestEventLog_Retention(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	cfg := EventLogConfig{
+		Dir:          dir,
+		MaxBytes:     2048,
+		SegmentBytes: 256,
+	}
+	log, err := NewEventLog(cfg)
+	must.NoError(t, err)
+
+	for i := uint64(1); i <= 100; i++ {
+		must.NoError(t, log.Append(testEvents(i)))
+	}
+	must.LessEq(t, cfg.MaxBytes+cfg.SegmentBytes, log.size)
+
+	first := log.FirstIndex()
+	must.Greater(t, 1, first)
+	must.Eq(t, first-1, log.getTruncatedIndex())
+
+	// Readers starting before the log report the events that were removed
+	// before continuing from the oldest events retained
+	r := log.newReader(10)
+	events, err := r.Next()
+	must.NoError(t, err)
+	must.Eq(t, first-1, events.Index)
+	must.Eq(t, structs.TopicEventStream, events.Events[0].Topic)
+	must.Eq(t, structs.TypeEventsTruncated, events.Events[0].Type)
+	must.Eq(t, &structs.EventsTruncatedEvent{
+		EventsTruncated: &structs.EventsTruncated{
+			RequestedIndex: 10,
+			FirstIndex:     first,
+		},
+	}, events.Events[0].Payload.(*structs.EventsTruncatedEvent))
+
+	indexes := readIndexes(t, r)
+	must.Eq(t, first, indexes[0])
+	must.Eq(t, 100, indexes[len(indexes)-1])
+	r.Close()
+
+	// The truncated index is kept across restarts
+	must.NoError(t, log.Close())
+	log, err = NewEventLog(cfg)
+	must.NoError(t, err)
+	defer log.Close()
+	must.Eq(t, first-1, log.getTruncatedIndex())
+}
+
+func TestEventLog_MaxAge(t *testing.T) {
+	ci.Parallel(t)
+
+	log, err := NewEventLog(EventLogConfig{
+		Dir:          t.TempDir(),
+		MaxAge:       time.Hour,
+		SegmentBytes: 1,
+	})
+	must.NoError(t, err)
+	defer log.Close()
+
+	// Each append is written to a new segment
+	for i := uint64(1); i <= 3; i++ {
+		must.NoError(t, log.Append(testEvents(i)))
+	}
+	must.Eq(t, 1, log.FirstIndex())
+
+	// Segments last written to before the max age are removed
+	log.segments[0].modTime = time.Now().Add(-2 * time.Hour)
+	must.NoError(t, log.Append(testEvents(4)))
+	must.Eq(t, 2, log.FirstIndex())
+	must.Eq(t, 1, log.getTruncatedIndex())
+}
+
+func TestEventLog_PartialRecord(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	log, err := NewEventLog(EventLogConfig{Dir: dir})
+	must.NoError(t, err)
+
+	must.NoError(t, log.Append(testEvents(1)))
+	must.NoError(t, log.Append(testEvents(2)))
+	path := log.segments[0].path
+	must.NoError(t, log.Close())
+
+	// Simulate a crash while writing a record
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	must.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 1, 0, 1, 2})
+	must.NoError(t, err)
+	must.NoError(t, f.Close())
+
+	log, err = NewEventLog(EventLogConfig{Dir: dir})
+	must.NoError(t, err)
+	defer log.Close()
+
+	must.Eq(t, 2, log.LastIndex())
+	must.NoError(t, log.Append(testEvents(3)))
+	must.Eq(t, []uint64{1, 2, 3}, readIndexes(t, log.newReader(1)))
+
+	entries, err := os.ReadDir(dir)
+	must.NoError(t, err)
+	must.Len(t, 1, entries)
+	must.Eq(t, filepath.Base(path), entries[0].Name())
+}
+
+func TestEventLog_PeriodicSync(t *testing.T) {
+	ci.Parallel(t)
+
+	log, err := NewEventLog(EventLogConfig{
+		Dir:          t.TempDir(),
+		SyncInterval: 10 * time.Millisecond,
+	})
+	must.NoError(t, err)
+	defer log.Close()
+
+	unsynced := func() bool {
+		log.mu.Lock()
+		defer log.mu.Unlock()
+		return log.unsynced
+	}
+
+	// Appends leave syncing to the periodic sync
+	must.NoError(t, log.Append(testEvents(1)))
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(func() bool { return !unsynced() }),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	// Syncing a closed log is a no-op
+	must.NoError(t, log.Append(testEvents(2)))
+	must.NoError(t, log.Close())
+	must.NoError(t, log.sync())
+}
+
+func TestEventLog_TypedPayloads(t *testing.T) {
+	ci.Parallel(t)
+
+	log, err := NewEventLog(EventLogConfig{Dir: t.TempDir()})
+	must.NoError(t, err)
+	defer log.Close()
+
+	job := mock.Job()
+	node := mock.Node()
+	must.NoError(t, log.Append(&structs.Events{
+		Index: 1,
+		Events: []structs.Event{
+			{
+				Topic:   structs.TopicJob,
+				Type:    structs.TypeJobRegistered,
+				Key:     job.ID,
+				Index:   1,
+				Payload: &structs.JobEvent{Job: job},
+			},
+			{
+				Topic:   structs.TopicNode,
+				Type:    structs.TypeNodeRegistration,
+				Key:     node.ID,
+				Index:   1,
+				Payload: &structs.NodeStreamEvent{Node: node},
+			},
+		},
+	}))
+	must.NoError(t, log.Append(testEvents(2)))
+
+	r := log.newReader(1)
+	defer r.Close()
+
+	// Payloads are decoded into the type published for their topic
+	events, err := r.Next()
+	must.NoError(t, err)
+	must.Len(t, 2, events.Events)
+
+	jobEvent, ok := events.Events[0].Payload.(*structs.JobEvent)
+	must.True(t, ok)
+	must.Eq(t, job.ID, jobEvent.Job.ID)
+	must.Eq(t, job.TaskGroups[0].Tasks[0].Resources.CPU, jobEvent.Job.TaskGroups[0].Tasks[0].Resources.CPU)
+
+	nodeEvent, ok := events.Events[1].Payload.(*structs.NodeStreamEvent)
+	must.True(t, ok)
+	must.Eq(t, node.ID, nodeEvent.Node.ID)
+	must.Eq(t, node.NodeResources.Memory.MemoryMB, nodeEvent.Node.NodeResources.Memory.MemoryMB)
+
+	// Payloads of unknown topics are decoded generically
+	events, err = r.Next()
+	must.NoError(t, err)
+	must.Eq(t, "sample payload", events.Events[0].Payload)
+}
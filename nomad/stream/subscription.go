@@ -6,6 +6,8 @@ package stream
 import (
 	"context"
 	"errors"
+	"io"
+	"sync"
 	"sync/atomic"
 
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -45,6 +47,26 @@ type Subscription struct {
 	// It must be safe to call the function from multiple goroutines and the function
 	// must be idempotent.
 	unsub func()
+
+	// broker is the EventBroker that created the subscription. Its event
+	// log, if any, is used to catch up when the subscription is behind the
+	// event buffer.
+	broker *EventBroker
+
+	// replay reads events from the event log while the subscription is
+	// catching up. It is nil once the subscription has caught up with the
+	// event buffer.
+	replay     *eventLogReader
+	replayLock sync.Mutex
+
+	// pending holds the last events read from the event log while catching
+	// up, to be delivered before continuing from the event buffer.
+	pending []*structs.Events
+
+	// lastIndex is the index of the most recent events read by the
+	// subscription, used to skip events already delivered and to resume
+	// from the event log.
+	lastIndex uint64
 }
 
 type SubscribeRequest struct {
@@ -76,23 +98,101 @@ func (s *Subscription) Next(ctx context.Context) (structs.Events, error) {
 	}
 
 	for {
-		next, err := s.currentItem.Next(ctx, s.forceClosed)
+		var next *structs.Events
 		switch {
-		case err != nil && atomic.LoadUint32(&s.state) == subscriptionStateClosed:
-			return structs.Events{}, ErrSubscriptionClosed
-		case err != nil:
-			return structs.Events{}, err
+		case len(s.pending) > 0:
+			next, s.pending = s.pending[0], s.pending[1:]
+
+		case s.getReplay() != nil:
+			if atomic.LoadUint32(&s.state) == subscriptionStateClosed {
+				return structs.Events{}, ErrSubscriptionClosed
+			}
+			if err := ctx.Err(); err != nil {
+				return structs.Events{}, err
+			}
+
+			events, err := s.nextFromLog()
+			switch {
+			case err != nil && atomic.LoadUint32(&s.state) == subscriptionStateClosed:
+				return structs.Events{}, ErrSubscriptionClosed
+			case err != nil:
+				return structs.Events{}, err
+			case events == nil:
+				// Caught up with the event buffer
+				continue
+			}
+			next = events
+
+		default:
+			item, err := s.currentItem.Next(ctx, s.forceClosed)
+			switch {
+			case err != nil && atomic.LoadUint32(&s.state) == subscriptionStateClosed:
+				return structs.Events{}, ErrSubscriptionClosed
+			case errors.Is(err, errEventDropped) && s.broker != nil && s.broker.eventLog != nil:
+				// The subscription fell behind the event buffer, so continue
+				// from the event log instead.
+				s.setReplay(s.broker.eventLog.newReader(s.lastIndex + 1))
+				continue
+			case err != nil:
+				return structs.Events{}, err
+			}
+			s.currentItem = item
+			next = item.Events
 		}
-		s.currentItem = next
 
-		events := filter(s.req, next.Events.Events)
+		// Skip events already read, which may be in both the event log and
+		// the event buffer.
+		if next.Index != 0 && next.Index <= s.lastIndex {
+			continue
+		}
+		s.lastIndex = max(s.lastIndex, next.Index)
+
+		events := filter(s.req, next.Events)
 		if len(events) == 0 {
 			continue
 		}
-		return structs.Events{Index: next.Events.Index, Events: events}, nil
+		return structs.Events{Index: next.Index, Events: events}, nil
 	}
 }
 
+// nextFromLog returns the next events from the event log. Once the end of the
+// log is reached, the subscription continues from the event buffer and nil
+// events are returned.
+func (s *Subscription) nextFromLog() (*structs.Events, error) {
+	replay := s.getReplay()
+	events, err := replay.Next()
+	if err != io.EOF {
+		return events, err
+	}
+
+	pending, tail, err := s.broker.resumeFromLog(replay)
+	if err != nil {
+		return nil, err
+	}
+	s.pending = pending
+	s.currentItem = tail
+	s.setReplay(nil)
+	return nil, nil
+}
+
+func (s *Subscription) getReplay() *eventLogReader {
+	s.replayLock.Lock()
+	defer s.replayLock.Unlock()
+	return s.replay
+}
+
+// setReplay replaces the event log reader of the subscription, closing the
+// previous one.
+func (s *Subscription) setReplay(r *eventLogReader) {
+	s.replayLock.Lock()
+	defer s.replayLock.Unlock()
+
+	if s.replay != nil {
+		s.replay.Close()
+	}
+	s.replay = r
+}
+
 func (s *Subscription) NextNoBlock() ([]structs.Event, error) {
 	if atomic.LoadUint32(&s.state) == subscriptionStateClosed {
 		return nil, ErrSubscriptionClosed
@@ -115,6 +215,7 @@ func (s *Subscription) NextNoBlock() ([]structs.Event, error) {
 
 func (s *Subscription) Unsubscribe() {
 	s.unsub()
+	s.setReplay(nil)
 }
 
 // filter events to only those that match a subscriptions topic/keys/namespace
@@ -133,6 +234,12 @@ func filter(req *SubscribeRequest, events []structs.Event) []structs.Event {
 	var result []structs.Event
 
 	for _, event := range events {
+		// Events about the stream itself are always delivered
+		if event.Topic == structs.TopicEventStream {
+			result = append(result, event)
+			continue
+		}
+
 		if req.Namespace != "*" && event.Namespace != "" && event.Namespace != req.Namespace {
 			continue
 		}
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/pointer"
+)
+
+const (
+	// CanaryAnalysisProviderPrometheus queries the HTTP API of Prometheus, or
+	// any server compatible with its instant query API.
+	CanaryAnalysisProviderPrometheus = "prometheus"
+
+	// minCanaryAnalysisInterval keeps the watcher from hammering the provider.
+	minCanaryAnalysisInterval = time.Second
+)
+
+const (
+	// DeploymentStatusDescriptionRunningCanaryAnalysis is the description of
+	// a deployment whose canaries are being analyzed.
+	DeploymentStatusDescriptionRunningCanaryAnalysis = "Deployment is running canary analysis"
+
+	// DeploymentStatusDescriptionCanaryAnalysisPassed is the description of a
+	// deployment that was promoted because its canaries passed analysis.
+	DeploymentStatusDescriptionCanaryAnalysisPassed = "Deployment is running after canaries passed analysis"
+)
+
+// DeploymentStatusDescriptionFailedCanaryAnalysis is used to get the status
+// description of a deployment whose canaries failed analysis.
+func DeploymentStatusDescriptionFailedCanaryAnalysis(group, metric string, err error) string {
+	return fmt.Sprintf("Failed canary analysis: group %q metric %q %v", group, metric, err)
+}
+
+// CanaryAnalysis configures the analysis of a task group's canaries against a
+// metrics provider. Once every canary is healthy, the metrics are queried at
+// each interval. A metric outside of its bounds fails the deployment, and
+// once enough consecutive runs pass the deployment is promoted.
+type CanaryAnalysis struct {
+	// Provider is the type of metrics provider to query.
+	Provider string
+
+	// Address is the base URL of the provider's HTTP API.
+	Address string
+
+	// Interval is the time between analysis runs.
+	Interval time.Duration
+
+	// Runs is the number of consecutive passing analysis runs required to
+	// promote the canaries.
+	Runs int
+
+	// Metrics are the queries run and the bounds their results must be in.
+	Metrics []*CanaryAnalysisMetric
+}
+
+// CanaryAnalysisMetric is a single query of a canary analysis. The query must
+// return a single value. The strings ${NOMAD_JOB_ID}, ${NOMAD_GROUP_NAME} and
+// ${NOMAD_DEPLOYMENT_ID} in the query are replaced before it is run.
+type CanaryAnalysisMetric struct {
+	Name  string
+	Query string
+
+	// Min and Max bound the value returned by the query, inclusively.
+	Min *float64
+	Max *float64
+}
+
+func (c *CanaryAnalysis) Copy() *CanaryAnalysis {
+	if c == nil {
+		return nil
+	}
+	nc := new(CanaryAnalysis)
+	*nc = *c
+	nc.Metrics = helper.CopySlice(c.Metrics)
+	return nc
+}
+
+func (c *CanaryAnalysis) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	switch c.Provider {
+	case CanaryAnalysisProviderPrometheus:
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid canary analysis provider: %q", c.Provider))
+	}
+	if c.Address == "" {
+		_ = multierror.Append(&mErr, errors.New("Canary analysis requires a provider address"))
+	}
+	if c.Interval < minCanaryAnalysisInterval {
+		_ = multierror.Append(&mErr, fmt.Errorf("Canary analysis interval must be at least %v: %v", minCanaryAnalysisInterval, c.Interval))
+	}
+	if c.Runs < 1 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Canary analysis runs must be at least 1: %d", c.Runs))
+	}
+	if len(c.Metrics) == 0 {
+		_ = multierror.Append(&mErr, errors.New("Canary analysis requires at least one metric"))
+	}
+
+	names := make(map[string]struct{}, len(c.Metrics))
+	for i, m := range c.Metrics {
+		if err := m.Validate(); err != nil {
+			_ = multierror.Append(&mErr, multierror.Prefix(err, fmt.Sprintf("Metric %d:", i+1)))
+			continue
+		}
+		if _, ok := names[m.Name]; ok {
+			_ = multierror.Append(&mErr, fmt.Errorf("Duplicate canary analysis metric %q", m.Name))
+		}
+		names[m.Name] = struct{}{}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+func (m *CanaryAnalysisMetric) Copy() *CanaryAnalysisMetric {
+	if m == nil {
+		return nil
+	}
+	nm := new(CanaryAnalysisMetric)
+	*nm = *m
+	nm.Min = pointer.Copy(m.Min)
+	nm.Max = pointer.Copy(m.Max)
+	return nm
+}
+
+func (m *CanaryAnalysisMetric) Validate() error {
+	if m == nil {
+		return errors.New("missing metric")
+	}
+
+	var mErr multierror.Error
+	if m.Name == "" {
+		_ = multierror.Append(&mErr, errors.New("Missing metric name"))
+	}
+	if m.Query == "" {
+		_ = multierror.Append(&mErr, errors.New("Missing metric query"))
+	}
+	if m.Min == nil && m.Max == nil {
+		_ = multierror.Append(&mErr, errors.New("Metric requires a min or max bound"))
+	}
+	if m.Min != nil && m.Max != nil && *m.Min > *m.Max {
+		_ = multierror.Append(&mErr, fmt.Errorf("Metric min %v is greater than max %v", *m.Min, *m.Max))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Check returns an error describing why the value is outside of the metric's
+// bounds, or nil if it is within them. NaN is never within bounds, while
+// infinite values are compared to the bounds like any other value.
+func (m *CanaryAnalysisMetric) Check(value float64) error {
+	if math.IsNaN(value) {
+		return errors.New("value is not a number")
+	}
+	if m.Max != nil && value > *m.Max {
+		return fmt.Errorf("value %v is above maximum %v", value, *m.Max)
+	}
+	if m.Min != nil && value < *m.Min {
+		return fmt.Errorf("value %v is below minimum %v", value, *m.Min)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestCanaryAnalysis_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	valid := func() *CanaryAnalysis {
+		return &CanaryAnalysis{
+			Provider: CanaryAnalysisProviderPrometheus,
+			Address:  "http://127.0.0.1:9090",
+			Interval: 30 * time.Second,
+			Runs:     3,
+			Metrics: []*CanaryAnalysisMetric{{
+				Name:  "error_rate",
+				Query: "sum(rate(errors[1m]))",
+				Max:   pointer.Of(0.01),
+			}},
+		}
+	}
+	must.NoError(t, valid().Validate())
+
+	cases := []struct {
+		name   string
+		modify func(*CanaryAnalysis)
+		expErr string
+	}{
+		{
+			name:   "unknown provider",
+			modify: func(c *CanaryAnalysis) { c.Provider = "datadog" },
+			expErr: "Invalid canary analysis provider",
+		},
+		{
+			name:   "short interval",
+			modify: func(c *CanaryAnalysis) { c.Interval = time.Millisecond },
+			expErr: "interval must be at least",
+		},
+		{
+			name:   "no runs",
+			modify: func(c *CanaryAnalysis) { c.Runs = 0 },
+			expErr: "runs must be at least 1",
+		},
+		{
+			name:   "unbounded metric",
+			modify: func(c *CanaryAnalysis) { c.Metrics[0].Max = nil },
+			expErr: "requires a min or max bound",
+		},
+		{
+			name: "inverted bounds",
+			modify: func(c *CanaryAnalysis) {
+				c.Metrics[0].Min = pointer.Of(1.0)
+			},
+			expErr: "is greater than max",
+		},
+		{
+			name: "duplicate metric",
+			modify: func(c *CanaryAnalysis) {
+				c.Metrics = append(c.Metrics, c.Metrics[0].Copy())
+			},
+			expErr: "Duplicate canary analysis metric",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := valid()
+			tc.modify(c)
+			must.ErrorContains(t, c.Validate(), tc.expErr)
+		})
+	}
+
+	// Analysis only applies to canaries
+	u := DefaultUpdateStrategy.Copy()
+	u.CanaryAnalysis = valid()
+	must.ErrorContains(t, u.Validate(), "requires a Canary count")
+	u.Canary = 1
+	must.NoError(t, u.Validate())
+}
+
+func TestCanaryAnalysisMetric_Check(t *testing.T) {
+	ci.Parallel(t)
+
+	m := &CanaryAnalysisMetric{Min: pointer.Of(1.0), Max: pointer.Of(2.0)}
+	must.NoError(t, m.Check(1))
+	must.NoError(t, m.Check(2))
+	must.EqError(t, m.Check(2.5), "value 2.5 is above maximum 2")
+	must.EqError(t, m.Check(0.5), "value 0.5 is below minimum 1")
+	must.EqError(t, m.Check(math.NaN()), "value is not a number")
+	must.EqError(t, m.Check(math.Inf(1)), "value +Inf is above maximum 2")
+	must.EqError(t, m.Check(math.Inf(-1)), "value -Inf is below minimum 1")
+
+	// Infinite values are within open bounds
+	m = &CanaryAnalysisMetric{Max: pointer.Of(2.0)}
+	must.NoError(t, m.Check(math.Inf(-1)))
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// LogSinksConfig restricts the sinks tasks may forward their logs to. Logmon
+// connects to sinks from the client host, so these limit the destinations a
+// job author can reach.
+type LogSinksConfig struct {
+	// AllowedTypes are the sink types tasks may use. An empty list disables
+	// log sinks.
+	AllowedTypes []string `hcl:"allowed_types"`
+
+	// AllowedHosts are the host names and CIDR blocks sinks may connect to.
+	// An empty list allows any host. Loopback, link-local and unspecified
+	// addresses are only allowed when covered by a CIDR block.
+	AllowedHosts []string `hcl:"allowed_hosts"`
+
+	// AllowUnixSockets allows sinks to connect to unix sockets on the client
+	// host.
+	AllowUnixSockets *bool `hcl:"allow_unix_sockets"`
+}
+
+// Copy returns a deep copy of the LogSinksConfig struct.
+func (l *LogSinksConfig) Copy() *LogSinksConfig {
+	if l == nil {
+		return nil
+	}
+	return &LogSinksConfig{
+		AllowedTypes:     slices.Clone(l.AllowedTypes),
+		AllowedHosts:     slices.Clone(l.AllowedHosts),
+		AllowUnixSockets: pointer.Copy(l.AllowUnixSockets),
+	}
+}
+
+// Merge returns a new LogSinksConfig where non-nil fields in the argument
+// have higher precedence.
+func (l *LogSinksConfig) Merge(o *LogSinksConfig) *LogSinksConfig {
+	switch {
+	case l == nil:
+		return o.Copy()
+	case o == nil:
+		return l.Copy()
+	}
+
+	result := l.Copy()
+	if o.AllowedTypes != nil {
+		result.AllowedTypes = slices.Clone(o.AllowedTypes)
+	}
+	if o.AllowedHosts != nil {
+		result.AllowedHosts = slices.Clone(o.AllowedHosts)
+	}
+	result.AllowUnixSockets = pointer.Merge(l.AllowUnixSockets, o.AllowUnixSockets)
+	return result
+}
+
+// Validate returns an error if a sink type is unknown or an allowed host is
+// neither a host name nor a CIDR block.
+func (l *LogSinksConfig) Validate() error {
+	if l == nil {
+		return fmt.Errorf("log_sinks must not be nil")
+	}
+
+	for _, t := range l.AllowedTypes {
+		switch t {
+		case structs.LogSinkTypeSyslog, structs.LogSinkTypeLoki, structs.LogSinkTypeOTLP, structs.LogSinkTypeFluent:
+		default:
+			return fmt.Errorf("allowed_types contains unknown sink type %q", t)
+		}
+	}
+
+	for _, h := range l.AllowedHosts {
+		if strings.Contains(h, "/") {
+			if _, _, err := net.ParseCIDR(h); err != nil {
+				return fmt.Errorf("allowed_hosts contains invalid CIDR block %q: %v", h, err)
+			}
+		} else if h == "" || (strings.ContainsAny(h, ":@ ") && net.ParseIP(h) == nil) {
+			return fmt.Errorf("allowed_hosts contains invalid host %q", h)
+		}
+	}
+	return nil
+}
+
+// DefaultLogSinksConfig returns the default log sinks configuration, which
+// allows every sink type to connect to any remote host.
+func DefaultLogSinksConfig() *LogSinksConfig {
+	return &LogSinksConfig{
+		AllowedTypes: []string{
+			structs.LogSinkTypeSyslog,
+			structs.LogSinkTypeLoki,
+			structs.LogSinkTypeOTLP,
+			structs.LogSinkTypeFluent,
+		},
+		AllowedHosts:     []string{},
+		AllowUnixSockets: pointer.Of(false),
+	}
+}
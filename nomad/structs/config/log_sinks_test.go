@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestLogSinksConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+
+	a := DefaultLogSinksConfig()
+	must.Eq(t, a, a.Merge(nil))
+	must.Eq(t, a, (*LogSinksConfig)(nil).Merge(a))
+
+	b := &LogSinksConfig{
+		AllowedHosts:     []string{"10.0.0.0/8"},
+		AllowUnixSockets: pointer.Of(true),
+	}
+	exp := &LogSinksConfig{
+		AllowedTypes:     a.AllowedTypes,
+		AllowedHosts:     []string{"10.0.0.0/8"},
+		AllowUnixSockets: pointer.Of(true),
+	}
+	must.Eq(t, exp, a.Merge(b))
+
+	// An empty list overrides the default types, disabling sinks
+	must.SliceEmpty(t, a.Merge(&LogSinksConfig{AllowedTypes: []string{}}).AllowedTypes)
+}
+
+func TestLogSinksConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, DefaultLogSinksConfig().Validate())
+	must.NoError(t, (&LogSinksConfig{
+		AllowedHosts: []string{"logs.example.com", "10.0.0.0/8", "fd00::/8", "::1"},
+	}).Validate())
+
+	must.ErrorContains(t, (*LogSinksConfig)(nil).Validate(), "must not be nil")
+	must.ErrorContains(t, (&LogSinksConfig{AllowedTypes: []string{"kafka"}}).Validate(), `unknown sink type "kafka"`)
+	must.ErrorContains(t, (&LogSinksConfig{AllowedHosts: []string{"10.0.0.0/33"}}).Validate(), "invalid CIDR block")
+	must.ErrorContains(t, (&LogSinksConfig{AllowedHosts: []string{"logs.example.com:514"}}).Validate(), "invalid host")
+}
@@ -510,6 +510,20 @@ func (v *CSIVolume) InUse() bool {
 		len(v.WriteAllocs) != 0
 }
 
+// Sanitize returns a copy of the volume omitting its secrets. It only returns
+// a copy if the volume has secrets.
+func (v *CSIVolume) Sanitize() *CSIVolume {
+	if v == nil {
+		return nil
+	}
+	if len(v.Secrets) == 0 {
+		return v
+	}
+	clean := v.Copy()
+	clean.Secrets = CSISecrets{}
+	return clean
+}
+
 // Copy returns a copy of the volume, which shares only the Topologies slice
 func (v *CSIVolume) Copy() *CSIVolume {
 	out := new(CSIVolume)
@@ -75,7 +75,7 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	diff := &JobDiff{Type: DiffTypeNone}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
 	filter := []string{"ID", "Status", "StatusDescription", "Version", "Stable", "CreateIndex",
-		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID", "VaultToken"}
+		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID", "VaultToken", "VersionTag"}
 
 	if j == nil && other == nil {
 		return diff, nil
@@ -136,6 +136,11 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	}
 	diff.TaskGroups = tgs
 
+	// PreemptionBudget diff
+	if pbDiff := primitiveObjectDiff(j.PreemptionBudget, other.PreemptionBudget, nil, "PreemptionBudget", contextual); pbDiff != nil {
+		diff.Objects = append(diff.Objects, pbDiff)
+	}
+
 	// Periodic diff
 	if pDiff := periodicDiff(j.Periodic, other.Periodic, contextual); pDiff != nil {
 		diff.Objects = append(diff.Objects, pDiff)
@@ -340,7 +345,21 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 
 	// Update diff
 	// COMPAT: Remove "Stagger" in 0.7.0.
-	if uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual); uDiff != nil {
+	uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual)
+	var oldAnalysis, newAnalysis *CanaryAnalysis
+	if tg.Update != nil {
+		oldAnalysis = tg.Update.CanaryAnalysis
+	}
+	if other.Update != nil {
+		newAnalysis = other.Update.CanaryAnalysis
+	}
+	if aDiff := canaryAnalysisDiff(oldAnalysis, newAnalysis, contextual); aDiff != nil {
+		if uDiff == nil {
+			uDiff = &ObjectDiff{Type: DiffTypeEdited, Name: "Update"}
+		}
+		uDiff.Objects = append(uDiff.Objects, aDiff)
+	}
+	if uDiff != nil {
 		diff.Objects = append(diff.Objects, uDiff)
 	}
 
@@ -527,6 +546,19 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 
 	// LogConfig diff
 	lDiff := primitiveObjectDiff(t.LogConfig, other.LogConfig, nil, "LogConfig", contextual)
+	var oldSinks, newSinks []*LogSink
+	if t.LogConfig != nil {
+		oldSinks = t.LogConfig.Sinks
+	}
+	if other.LogConfig != nil {
+		newSinks = other.LogConfig.Sinks
+	}
+	if sDiffs := logSinkDiffs(oldSinks, newSinks, contextual); sDiffs != nil {
+		if lDiff == nil {
+			lDiff = &ObjectDiff{Type: DiffTypeEdited, Name: "LogConfig"}
+		}
+		lDiff.Objects = append(lDiff.Objects, sDiffs...)
+	}
 	if lDiff != nil {
 		diff.Objects = append(diff.Objects, lDiff)
 	}
@@ -2749,6 +2781,80 @@ func disconectStrategyDiffs(old, new *DisconnectStrategy, contextual bool) *Obje
 	return diff
 }
 
+// logSinkDiffs diffs a task's log sinks, matching them by name.
+func logSinkDiffs(old, new []*LogSink, contextual bool) []*ObjectDiff {
+	if reflect.DeepEqual(old, new) {
+		return nil
+	}
+
+	newSinks := make(map[string]*LogSink, len(new))
+	for _, sink := range new {
+		newSinks[sink.Name] = sink
+	}
+
+	var diffs []*ObjectDiff
+	seen := make(map[string]bool, len(old))
+	for _, oldSink := range old {
+		seen[oldSink.Name] = true
+		if diff := logSinkDiff(oldSink, newSinks[oldSink.Name], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for _, newSink := range new {
+		if !seen[newSink.Name] {
+			diffs = append(diffs, logSinkDiff(nil, newSink, contextual))
+		}
+	}
+	return diffs
+}
+
+// logSinkDiff returns the diff of a single log sink. If contextual diff is
+// enabled, all fields will be returned, even if no diff occurred.
+func logSinkDiff(old, new *LogSink, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Sink"}
+	var oldFlat, newFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		diff.Type = DiffTypeAdded
+		newFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		diff.Type = DiffTypeDeleted
+		oldFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldFlat = flatmap.Flatten(old, nil, true)
+		newFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	diff.Fields = fieldDiffs(oldFlat, newFlat, contextual)
+	return diff
+}
+
+// canaryAnalysisDiff returns the diff of an update block's canary analysis.
+func canaryAnalysisDiff(old, new *CanaryAnalysis, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "CanaryAnalysis"}
+	var oldFlat, newFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		diff.Type = DiffTypeAdded
+		newFlat = flatmap.Flatten(new, nil, false)
+	} else if new == nil {
+		diff.Type = DiffTypeDeleted
+		oldFlat = flatmap.Flatten(old, nil, false)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldFlat = flatmap.Flatten(old, nil, false)
+		newFlat = flatmap.Flatten(new, nil, false)
+	}
+
+	diff.Fields = fieldDiffs(oldFlat, newFlat, contextual)
+	return diff
+}
+
 // networkResourceDiffs diffs a set of NetworkResources. If contextual diff is enabled,
 // non-changed fields will still be returned.
 func networkResourceDiffs(old, new []*NetworkResource, contextual bool) []*ObjectDiff {
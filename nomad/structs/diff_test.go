@@ -165,6 +165,12 @@ func TestJobDiff(t *testing.T) {
 						Old:  "false",
 						New:  "",
 					},
+					{
+						Type: DiffTypeDeleted,
+						Name: "Gang",
+						Old:  "false",
+						New:  "",
+					},
 					{
 						Type: DiffTypeDeleted,
 						Name: "Meta[foo]",
@@ -177,6 +183,12 @@ func TestJobDiff(t *testing.T) {
 						Old:  "foo",
 						New:  "",
 					},
+					{
+						Type: DiffTypeDeleted,
+						Name: "Prefetch",
+						Old:  "false",
+						New:  "",
+					},
 					{
 						Type: DiffTypeDeleted,
 						Name: "Priority",
@@ -234,6 +246,12 @@ func TestJobDiff(t *testing.T) {
 						Old:  "",
 						New:  "false",
 					},
+					{
+						Type: DiffTypeAdded,
+						Name: "Gang",
+						Old:  "",
+						New:  "false",
+					},
 					{
 						Type: DiffTypeAdded,
 						Name: "Meta[foo]",
@@ -246,6 +264,12 @@ func TestJobDiff(t *testing.T) {
 						Old:  "",
 						New:  "foo",
 					},
+					{
+						Type: DiffTypeAdded,
+						Name: "Prefetch",
+						Old:  "",
+						New:  "false",
+					},
 					{
 						Type: DiffTypeAdded,
 						Name: "Priority",
@@ -747,6 +771,78 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// PreemptionBudget added
+			Old: &Job{},
+			New: &Job{
+				PreemptionBudget: &PreemptionBudget{
+					MaxPreemptions: 3,
+					Window:         time.Hour,
+					MinRuntime:     10 * time.Minute,
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeAdded,
+						Name: "PreemptionBudget",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxPreemptions",
+								Old:  "",
+								New:  "3",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MinRuntime",
+								Old:  "",
+								New:  "600000000000",
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Window",
+								Old:  "",
+								New:  "3600000000000",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			// PreemptionBudget edited
+			Old: &Job{
+				PreemptionBudget: &PreemptionBudget{
+					MaxPreemptions: 3,
+					Window:         time.Hour,
+				},
+			},
+			New: &Job{
+				PreemptionBudget: &PreemptionBudget{
+					MaxPreemptions: 5,
+					Window:         time.Hour,
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "PreemptionBudget",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "MaxPreemptions",
+								Old:  "3",
+								New:  "5",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Periodic single to multiple times
 			Old: &Job{
@@ -3326,6 +3422,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "EphemeralDisk",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "LogBudgetMB",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Migrate",
@@ -3366,6 +3468,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "EphemeralDisk",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "LogBudgetMB",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Migrate",
@@ -3460,6 +3568,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "EphemeralDisk",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "LogBudgetMB",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Migrate",
@@ -5894,6 +6008,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "RotateInterval",
+								Old:  "",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -5934,6 +6054,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "RotateInterval",
+								Old:  "0",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -6009,6 +6135,12 @@ func TestTaskDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "LogConfig",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "Compression",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Disabled",
@@ -6027,6 +6159,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "1",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "RotateInterval",
+								Old:  "0",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -6282,6 +6420,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "IOWeight",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "MemoryMB",
@@ -6294,6 +6438,24 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "NetworkEgressMBits",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "ReadBps",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "WriteBps",
+								Old:  "0",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -6385,6 +6547,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "IOWeight",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "MemoryMB",
@@ -6397,6 +6565,24 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "200",
 								New:  "300",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "NetworkEgressMBits",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "ReadBps",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "WriteBps",
+								Old:  "0",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -6734,6 +6920,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "IOWeight",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "MemoryMB",
@@ -6746,6 +6938,24 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "NetworkEgressMBits",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "ReadBps",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "WriteBps",
+								Old:  "0",
+								New:  "0",
+							},
 						},
 						Objects: []*ObjectDiff{
 							{
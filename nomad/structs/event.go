@@ -31,6 +31,13 @@ const (
 	TopicACLAuthMethod  Topic = "ACLAuthMethod"
 	TopicACLBindingRule Topic = "ACLBindingRule"
 	TopicService        Topic = "Service"
+	TopicVariables      Topic = "Variables"
+	TopicNamespace      Topic = "Namespace"
+	TopicCSIVolume      Topic = "CSIVolume"
+	TopicCSIPlugin      Topic = "CSIPlugin"
+	TopicScalingPolicy  Topic = "ScalingPolicy"
+	TopicRootKey        Topic = "RootKey"
+	TopicEventStream    Topic = "EventStream"
 	TopicAll            Topic = "*"
 
 	TypeNodeRegistration              = "NodeRegistration"
@@ -63,6 +70,19 @@ const (
 	TypeACLBindingRuleDeleted         = "ACLBindingRuleDeleted"
 	TypeServiceRegistration           = "ServiceRegistration"
 	TypeServiceDeregistration         = "ServiceDeregistration"
+	TypeVariableUpserted              = "VariableUpserted"
+	TypeVariableDeleted               = "VariableDeleted"
+	TypeNamespaceUpserted             = "NamespaceUpserted"
+	TypeNamespaceDeleted              = "NamespaceDeleted"
+	TypeCSIVolumeUpserted             = "CSIVolumeUpserted"
+	TypeCSIVolumeDeleted              = "CSIVolumeDeleted"
+	TypeCSIPluginUpserted             = "CSIPluginUpserted"
+	TypeCSIPluginDeleted              = "CSIPluginDeleted"
+	TypeScalingPolicyUpserted         = "ScalingPolicyUpserted"
+	TypeScalingPolicyDeleted          = "ScalingPolicyDeleted"
+	TypeRootKeyUpserted               = "RootKeyUpserted"
+	TypeRootKeyDeleted                = "RootKeyDeleted"
+	TypeEventsTruncated               = "EventsTruncated"
 )
 
 // Event represents a change in Nomads state.
@@ -152,6 +172,54 @@ type ServiceRegistrationStreamEvent struct {
 	Service *ServiceRegistration
 }
 
+// VariableEvent holds a newly updated or deleted variable. Only the variable
+// metadata is included so the encrypted contents never leave the state store.
+type VariableEvent struct {
+	Variable *VariableMetadata
+}
+
+// NamespaceEvent holds a newly updated or deleted Namespace.
+type NamespaceEvent struct {
+	Namespace *Namespace
+}
+
+// CSIVolumeEvent holds a newly updated or deleted CSI volume. The volume's
+// secrets have been removed.
+type CSIVolumeEvent struct {
+	CSIVolume *CSIVolume
+}
+
+// CSIPluginEvent holds a newly updated or deleted CSI plugin.
+type CSIPluginEvent struct {
+	CSIPlugin *CSIPlugin
+}
+
+// ScalingPolicyEvent holds a newly updated or deleted ScalingPolicy.
+type ScalingPolicyEvent struct {
+	ScalingPolicy *ScalingPolicy
+}
+
+// RootKeyEvent holds the metadata of a newly updated or deleted root key.
+// The key material is never included.
+type RootKeyEvent struct {
+	RootKeyMeta *RootKeyMeta
+}
+
+// EventsTruncatedEvent is sent to subscribers in place of events that were
+// removed from the event log by retention before they could be delivered.
+type EventsTruncatedEvent struct {
+	EventsTruncated *EventsTruncated
+}
+
+// EventsTruncated describes the range of events a subscriber missed.
+type EventsTruncated struct {
+	// RequestedIndex is the first index that could not be delivered.
+	RequestedIndex uint64
+
+	// FirstIndex is the index the stream continues from.
+	FirstIndex uint64
+}
+
 // NewACLTokenEvent takes a token and creates a new ACLTokenEvent.  It creates
 // a copy of the passed in ACLToken and empties out the copied tokens SecretID
 func NewACLTokenEvent(token *ACLToken) *ACLTokenEvent {
@@ -515,6 +515,47 @@ func TestAllocsFit_MemoryOversubscription(t *testing.T) {
 	must.Eq(t, 12000, used.Flattened.Memory.MemoryMaxMB)
 }
 
+func TestAllocsFit_NetworkEgress(t *testing.T) {
+	ci.Parallel(t)
+
+	n := node2k()
+	n.ReservedResources = nil
+
+	a1 := &Allocation{
+		AllocatedResources: &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{
+				"web": {
+					Cpu: AllocatedCpuResources{
+						CpuShares: 100,
+					},
+					Memory: AllocatedMemoryResources{
+						MemoryMB: 100,
+					},
+					IO: AllocatedIOResources{
+						Weight:             100,
+						WriteBps:           BytesInMegabyte,
+						NetworkEgressMBits: 40,
+					},
+				},
+			},
+		},
+	}
+
+	// Should fit two allocations within the 100 MBits of the node
+	fit, dim, used, err := AllocsFit(n, []*Allocation{a1, a1}, nil, false)
+	must.NoError(t, err)
+	must.True(t, fit, must.Sprintf("bad dimension: %q", dim))
+	must.Eq(t, 80, used.Flattened.IO.NetworkEgressMBits)
+	must.Eq(t, 2*BytesInMegabyte, used.Flattened.IO.WriteBps)
+
+	// Should not fit a third allocation
+	fit, dim, used, err = AllocsFit(n, []*Allocation{a1, a1, a1}, nil, false)
+	must.NoError(t, err)
+	must.False(t, fit)
+	must.Eq(t, "network bandwidth", dim)
+	must.Eq(t, 120, used.Flattened.IO.NetworkEgressMBits)
+}
+
 func TestScoreFitBinPack(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// HostVolumePluginMkdirID is the ID of the built-in host volume plugin,
+	// which creates a directory under the client's host volumes directory.
+	HostVolumePluginMkdirID = "mkdir"
+
+	// HostVolumePluginVersionAttrFormat is the format of the node attribute
+	// that holds the version of a fingerprinted host volume plugin.
+	HostVolumePluginVersionAttrFormat = "plugins.host_volume.%s.version"
+)
+
+// HostVolumeState is the state of a dynamic host volume.
+type HostVolumeState string
+
+const (
+	// HostVolumeStatePending is the state of a volume that has been
+	// registered but not provisioned on its node yet.
+	HostVolumeStatePending HostVolumeState = "pending"
+
+	// HostVolumeStateReady is the state of a volume that has been
+	// provisioned on its node and can be placed.
+	HostVolumeStateReady HostVolumeState = "ready"
+)
+
+var (
+	// validHostVolumeName is the rule used to validate a host volume name.
+	// Volume names are used as directory names by the client, so path
+	// separators are not allowed.
+	validHostVolumeName = regexp.MustCompile("^[a-zA-Z0-9-_]{1,128}$")
+)
+
+// HostVolume is a host volume created through the API. Unlike host volumes
+// declared in the client configuration, a dynamic host volume is provisioned
+// by a plugin on its node and registered in the state store.
+type HostVolume struct {
+	// ID is a namespace unique identifier for the volume, generated by the
+	// server when the volume is created.
+	ID string
+
+	// Name is the name of the volume. It must be unique on its node and is
+	// used as the source of host volume requests in job specifications.
+	Name string
+
+	// Namespace is the namespace of the volume. Only jobs in the namespace
+	// can mount the volume.
+	Namespace string
+
+	// PluginID is the ID of the host volume plugin used to provision the
+	// volume on its node.
+	PluginID string
+
+	// NodePool is the node pool of the volume's node. If NodeID is not set
+	// when the volume is created, the node is chosen from this pool.
+	NodePool string
+
+	// NodeID is the ID of the node the volume is provisioned on.
+	NodeID string
+
+	// RequestedCapacityMinBytes and RequestedCapacityMaxBytes are the
+	// capacity requested from the plugin. Zero means no minimum or maximum.
+	RequestedCapacityMinBytes int64
+	RequestedCapacityMaxBytes int64
+
+	// CapacityBytes is the capacity reported by the plugin after the volume
+	// was provisioned.
+	CapacityBytes int64
+
+	// Parameters are passed to the plugin as-is.
+	Parameters map[string]string
+
+	// HostPath is the path of the volume on its node, as reported by the
+	// plugin.
+	HostPath string
+
+	// State is the state of the volume.
+	State HostVolumeState
+
+	CreateIndex uint64
+	ModifyIndex uint64
+	CreateTime  int64
+	ModifyTime  int64
+}
+
+// Copy returns a deep copy of the host volume.
+func (hv *HostVolume) Copy() *HostVolume {
+	if hv == nil {
+		return nil
+	}
+
+	nhv := new(HostVolume)
+	*nhv = *hv
+	nhv.Parameters = maps.Clone(hv.Parameters)
+	return nhv
+}
+
+// Stub returns a summary of the host volume for list responses.
+func (hv *HostVolume) Stub() *HostVolumeStub {
+	if hv == nil {
+		return nil
+	}
+
+	return &HostVolumeStub{
+		ID:            hv.ID,
+		Name:          hv.Name,
+		Namespace:     hv.Namespace,
+		PluginID:      hv.PluginID,
+		NodePool:      hv.NodePool,
+		NodeID:        hv.NodeID,
+		CapacityBytes: hv.CapacityBytes,
+		State:         hv.State,
+		CreateIndex:   hv.CreateIndex,
+		ModifyIndex:   hv.ModifyIndex,
+	}
+}
+
+// QuotaBytes returns the number of bytes the volume is accounted for in its
+// namespace's quota. Volumes that have not been provisioned yet are
+// accounted for the most capacity they requested.
+func (hv *HostVolume) QuotaBytes() int64 {
+	if hv.CapacityBytes > 0 {
+		return hv.CapacityBytes
+	}
+	return max(hv.RequestedCapacityMinBytes, hv.RequestedCapacityMaxBytes)
+}
+
+// Validate returns an error if the user provided fields of the host volume
+// are invalid.
+func (hv *HostVolume) Validate() error {
+	var mErr *multierror.Error
+
+	if !validHostVolumeName.MatchString(hv.Name) {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid name %q, must match regex %s", hv.Name, validHostVolumeName))
+	}
+	if hv.PluginID == "" {
+		mErr = multierror.Append(mErr, errors.New("missing plugin ID"))
+	}
+	if hv.RequestedCapacityMinBytes < 0 || hv.RequestedCapacityMaxBytes < 0 {
+		mErr = multierror.Append(mErr, errors.New("capacity must be non-negative"))
+	}
+	if hv.RequestedCapacityMaxBytes > 0 && hv.RequestedCapacityMinBytes > hv.RequestedCapacityMaxBytes {
+		mErr = multierror.Append(mErr, errors.New("capacity_max must be greater than or equal to capacity_min"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// HostVolumeStub is a summary of a host volume.
+type HostVolumeStub struct {
+	ID            string
+	Name          string
+	Namespace     string
+	PluginID      string
+	NodePool      string
+	NodeID        string
+	CapacityBytes int64
+	State         HostVolumeState
+	CreateIndex   uint64
+	ModifyIndex   uint64
+}
+
+// HostVolumeCreateRequest is used to create a host volume.
+type HostVolumeCreateRequest struct {
+	Volume *HostVolume
+	WriteRequest
+}
+
+// HostVolumeCreateResponse is the response to a host volume create request.
+type HostVolumeCreateResponse struct {
+	Volume *HostVolume
+	WriteMeta
+}
+
+// HostVolumeRegisterRequest is used to write host volumes to the state
+// store.
+type HostVolumeRegisterRequest struct {
+	Volumes []*HostVolume
+	WriteRequest
+}
+
+// HostVolumeDeleteRequest is used to delete a host volume.
+type HostVolumeDeleteRequest struct {
+	VolumeID string
+	WriteRequest
+}
+
+// HostVolumeDeleteResponse is the response to a host volume delete request.
+type HostVolumeDeleteResponse struct {
+	WriteMeta
+}
+
+// HostVolumeGetRequest is used to read a single host volume.
+type HostVolumeGetRequest struct {
+	ID string
+	QueryOptions
+}
+
+// HostVolumeGetResponse is the response to a host volume get request.
+type HostVolumeGetResponse struct {
+	Volume *HostVolume
+	QueryMeta
+}
+
+// HostVolumeListRequest is used to list host volumes, optionally filtered by
+// node or node pool.
+type HostVolumeListRequest struct {
+	NodeID   string
+	NodePool string
+	QueryOptions
+}
+
+// HostVolumeListResponse is the response to a host volume list request.
+type HostVolumeListResponse struct {
+	Volumes []*HostVolumeStub
+	QueryMeta
+}
@@ -261,6 +261,24 @@ func (j *Job) RequiredBridgeNetwork() set.Collection[string] {
 	return result
 }
 
+// RequiredBridgeEgressLimit identifies which task groups, if any, within the
+// job use bridge networking and limit the egress bandwidth of their tasks
+func (j *Job) RequiredBridgeEgressLimit() set.Collection[string] {
+	result := set.New[string](len(j.TaskGroups))
+	for _, tg := range j.TaskGroups {
+		if !tg.Networks.Modes().Contains("bridge") {
+			continue
+		}
+		for _, t := range tg.Tasks {
+			if t.Resources != nil && t.Resources.NetworkEgressMBits > 0 {
+				result.Insert(tg.Name)
+				break // to next TaskGroup
+			}
+		}
+	}
+	return result
+}
+
 // RequiredTransparentProxy identifies which task groups, if any, within the job
 // contain Connect blocks using transparent proxy
 func (j *Job) RequiredTransparentProxy() set.Collection[string] {
@@ -478,3 +478,67 @@ type NodeMetaResponse struct {
 	// Static is the static Node metadata (set via agent configuration)
 	Static map[string]string
 }
+
+// NodeImagePrefetchRequest is used to have a Client agent pull images before
+// any task uses them.
+type NodeImagePrefetchRequest struct {
+	QueryOptions // Client RPCs must use QueryOptions to set AllowStale=true
+
+	// NodeID is the node being targeted by this request (or the node
+	// receiving this request if NodeID is empty).
+	NodeID string
+
+	// Driver is the task driver pulling the images. Defaults to docker.
+	Driver string
+
+	// Images are the names of the images to pull.
+	Images []string
+}
+
+func (n *NodeImagePrefetchRequest) Canonicalize() {
+	if n.Driver == "" {
+		n.Driver = "docker"
+	}
+}
+
+func (n *NodeImagePrefetchRequest) Validate() error {
+	if len(n.Images) == 0 {
+		return fmt.Errorf("missing required Images")
+	}
+	for _, image := range n.Images {
+		if image == "" {
+			return fmt.Errorf("image names must not be empty")
+		}
+	}
+	return nil
+}
+
+// NodeImagePrefetchResponse lists the images a Client agent started pulling.
+type NodeImagePrefetchResponse struct {
+	// Images are the normalized names of the images being pulled.
+	Images []string
+}
+
+// NormalizeImageRef returns the image reference with the "latest" tag added
+// when it has neither a tag nor a digest, so that "redis" and "redis:latest"
+// refer to the same cached image.
+func NormalizeImageRef(image string) string {
+	if strings.Contains(image, "@") {
+		return image
+	}
+	name := image
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		name = image[i+1:]
+	}
+	if strings.Contains(name, ":") {
+		return image
+	}
+	return image + ":latest"
+}
+
+// ImageAttribute returns the node attribute under which a task driver reports
+// an image as cached on the node. Images come and go, so they're reported in
+// the unique namespace to keep them out of the node's computed class.
+func ImageAttribute(driver, image string) string {
+	return UniqueNamespace(fmt.Sprintf("driver.%s.image.%s", driver, NormalizeImageRef(image)))
+}
@@ -155,6 +155,15 @@ func TestNode_ComputedClass_Attr(t *testing.T) {
 		t.Fatal("ComputeClass() didn't ignore unique attr suffix")
 	}
 
+	// Add a cached image and compute the class again
+	n.Attributes[ImageAttribute("docker", "redis")] = "sha256:1"
+	if err := n.ComputeClass(); err != nil {
+		t.Fatalf("ComputeClass() failed: %v", err)
+	}
+	if old != n.ComputedClass {
+		t.Fatal("ComputeClass() didn't ignore image attr")
+	}
+
 	// Modify an attribute and compute the class again.
 	n.Attributes["version"] = "New Version"
 	if err := n.ComputeClass(); err != nil {
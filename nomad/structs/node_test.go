@@ -163,3 +163,21 @@ func TestNodeMeta_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeImageRef(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := map[string]string{
+		"redis":                          "redis:latest",
+		"redis:7":                        "redis:7",
+		"library/redis":                  "library/redis:latest",
+		"registry.local:5000/app":        "registry.local:5000/app:latest",
+		"registry.local:5000/app:1.2":    "registry.local:5000/app:1.2",
+		"redis@sha256:0123456789abcdef0": "redis@sha256:0123456789abcdef0",
+	}
+	for image, exp := range cases {
+		must.Eq(t, exp, NormalizeImageRef(image), must.Sprint(image))
+	}
+
+	must.Eq(t, "unique.driver.docker.image.redis:latest", ImageAttribute("docker", "redis"))
+}
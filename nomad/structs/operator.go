@@ -6,6 +6,7 @@ package structs
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"net/netip"
 	"time"
 
@@ -233,6 +234,21 @@ type SchedulerConfiguration struct {
 	// during leadership transitions.
 	PauseEvalBroker bool `hcl:"pause_eval_broker"`
 
+	// ScoringPlugins is the set of additional scoring plugins the generic
+	// scheduler applies when ranking nodes, along with their weights.
+	ScoringPlugins []*SchedulerScoringPlugin `hcl:"scoring_plugin"`
+
+	// EvalBrokerFairShare configures weighted fair-share dequeueing of
+	// evaluations across namespaces by the eval broker. When nil or not
+	// enabled, evaluations are dequeued strictly by priority.
+	EvalBrokerFairShare *EvalBrokerFairShare `hcl:"eval_broker_fair_share"`
+
+	// UtilizationScoring configures the bin packing score to blend the
+	// resources reserved on a node with the utilization observed by its
+	// client. When nil or not enabled, nodes are scored from reservations
+	// only.
+	UtilizationScoring *SchedulerUtilizationScoring `hcl:"utilization_scoring"`
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -244,6 +260,14 @@ func (s *SchedulerConfiguration) Copy() *SchedulerConfiguration {
 	}
 
 	ns := *s
+	if s.ScoringPlugins != nil {
+		ns.ScoringPlugins = make([]*SchedulerScoringPlugin, len(s.ScoringPlugins))
+		for i, p := range s.ScoringPlugins {
+			ns.ScoringPlugins[i] = p.Copy()
+		}
+	}
+	ns.EvalBrokerFairShare = s.EvalBrokerFairShare.Copy()
+	ns.UtilizationScoring = s.UtilizationScoring.Copy()
 	return &ns
 }
 
@@ -292,6 +316,222 @@ func (s *SchedulerConfiguration) Validate() error {
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
+	seen := make(map[string]struct{}, len(s.ScoringPlugins))
+	for _, p := range s.ScoringPlugins {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if _, ok := seen[p.Name]; ok {
+			return fmt.Errorf("duplicate scoring plugin %q", p.Name)
+		}
+		seen[p.Name] = struct{}{}
+	}
+
+	if err := s.EvalBrokerFairShare.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.UtilizationScoring.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+const (
+	// MaxEvalBrokerFairShareWeight is the largest weight that may be given
+	// to a namespace for fair-share dequeueing.
+	MaxEvalBrokerFairShareWeight = 1000
+)
+
+// EvalBrokerFairShare is the configuration for weighted fair-share
+// dequeueing of evaluations across namespaces. While enabled, the eval
+// broker round-robins between the namespaces that have ready evaluations in
+// proportion to their weights, and only orders evaluations by priority within
+// a namespace. This prevents a single namespace with a large backlog of
+// evaluations from starving the others.
+type EvalBrokerFairShare struct {
+	// Enabled specifies whether fair-share dequeueing is enabled.
+	Enabled bool `hcl:"enabled"`
+
+	// DefaultWeight is the weight of namespaces that are not listed in
+	// NamespaceWeights. Defaults to 1 if unset.
+	DefaultWeight int `hcl:"default_weight"`
+
+	// NamespaceWeights maps namespace names to their weight. A namespace with
+	// a weight of 2 is dequeued from twice as often as a namespace with a
+	// weight of 1 while both have evaluations ready.
+	NamespaceWeights map[string]int `hcl:"namespace_weights"`
+}
+
+// Weight returns the fair-share weight of the namespace.
+func (f *EvalBrokerFairShare) Weight(namespace string) int {
+	if f == nil {
+		return 1
+	}
+	if weight, ok := f.NamespaceWeights[namespace]; ok {
+		return weight
+	}
+	if f.DefaultWeight > 0 {
+		return f.DefaultWeight
+	}
+	return 1
+}
+
+func (f *EvalBrokerFairShare) Copy() *EvalBrokerFairShare {
+	if f == nil {
+		return nil
+	}
+
+	nf := *f
+	nf.NamespaceWeights = maps.Clone(f.NamespaceWeights)
+	return &nf
+}
+
+func (f *EvalBrokerFairShare) Validate() error {
+	if f == nil {
+		return nil
+	}
+
+	if f.DefaultWeight < 0 || f.DefaultWeight > MaxEvalBrokerFairShareWeight {
+		return fmt.Errorf("eval broker fair share default weight must be within the range [0, %d]",
+			MaxEvalBrokerFairShareWeight)
+	}
+	for namespace, weight := range f.NamespaceWeights {
+		if weight < 1 || weight > MaxEvalBrokerFairShareWeight {
+			return fmt.Errorf("eval broker fair share weight for namespace %q must be within the range [1, %d]",
+				namespace, MaxEvalBrokerFairShareWeight)
+		}
+	}
+	return nil
+}
+
+const (
+	// DefaultUtilizationObservedWeight is the percentage of the bin packing
+	// score taken from observed utilization when none is configured.
+	DefaultUtilizationObservedWeight = 50
+
+	// DefaultUtilizationMaxAge is how old a node's utilization report may be
+	// before the scheduler ignores it when none is configured. It is also the
+	// smallest maximum age that may be configured.
+	DefaultUtilizationMaxAge = 5 * time.Minute
+
+	// MaxUtilizationReportInterval is the longest interval at which clients
+	// may report their utilization. Servers refresh a report once it is older
+	// than half of the maximum age, so reports sent at most this often are
+	// never older than DefaultUtilizationMaxAge.
+	MaxUtilizationReportInterval = DefaultUtilizationMaxAge / 2
+
+	// UtilizationChangeThreshold is the fraction of a node's CPU or memory
+	// that its reported utilization must change by before servers write the
+	// new report to the state store.
+	UtilizationChangeThreshold = 0.05
+)
+
+// SchedulerUtilizationScoring is the configuration for utilization-aware bin
+// packing. Nodes are still only considered feasible if the reserved resources
+// fit, but they are scored from a blend of their reserved resources and the
+// usage observed by the client, so nodes full of over-reserved but idle
+// allocations are scored by how busy they actually are.
+type SchedulerUtilizationScoring struct {
+	// Enabled specifies whether utilization-aware scoring is enabled.
+	Enabled bool `hcl:"enabled"`
+
+	// ObservedWeight is the percentage of the score, between 0 and 100,
+	// computed from observed utilization. The remainder is computed from
+	// reserved resources. Defaults to 50 if unset.
+	ObservedWeight int `hcl:"observed_weight"`
+
+	// MaxAge is how old a node's utilization report may be before it is
+	// ignored and the node is scored from reserved resources only. Defaults
+	// to 5 minutes if unset, which is also the minimum.
+	MaxAge time.Duration `hcl:"max_age"`
+}
+
+// EffectiveObservedWeight returns the fraction of the score computed from
+// observed utilization, or 0 if utilization-aware scoring is disabled.
+func (u *SchedulerUtilizationScoring) EffectiveObservedWeight() float64 {
+	if u == nil || !u.Enabled {
+		return 0
+	}
+	if u.ObservedWeight == 0 {
+		return DefaultUtilizationObservedWeight / 100.0
+	}
+	return float64(u.ObservedWeight) / 100.0
+}
+
+// EffectiveMaxAge returns the maximum age of utilization reports.
+func (u *SchedulerUtilizationScoring) EffectiveMaxAge() time.Duration {
+	if u == nil || u.MaxAge == 0 {
+		return DefaultUtilizationMaxAge
+	}
+	return u.MaxAge
+}
+
+func (u *SchedulerUtilizationScoring) Copy() *SchedulerUtilizationScoring {
+	if u == nil {
+		return nil
+	}
+	nu := *u
+	return &nu
+}
+
+func (u *SchedulerUtilizationScoring) Validate() error {
+	if u == nil {
+		return nil
+	}
+
+	if u.ObservedWeight < 0 || u.ObservedWeight > 100 {
+		return fmt.Errorf("utilization scoring observed weight must be within the range [0, 100]")
+	}
+	if u.MaxAge != 0 && u.MaxAge < DefaultUtilizationMaxAge {
+		return fmt.Errorf("utilization scoring max age must be at least %s so reports sent at the maximum client report interval of %s are not stale",
+			DefaultUtilizationMaxAge, MaxUtilizationReportInterval)
+	}
+	return nil
+}
+
+const (
+	// MaxSchedulerScoringPluginWeight is the largest absolute weight that
+	// may be given to a scoring plugin. It matches the range of affinity
+	// weights so plugin and affinity scores are comparable.
+	MaxSchedulerScoringPluginWeight = 100
+)
+
+// SchedulerScoringPlugin enables a scoring plugin registered with the
+// scheduler. The score the plugin returns for a node is scaled by Weight
+// before it is combined with the scores of the built in iterators.
+type SchedulerScoringPlugin struct {
+	// Name is the name the plugin is registered with in the scheduler.
+	Name string `hcl:"name"`
+
+	// Weight scales the score of the plugin, between -100 and 100. Negative
+	// weights invert the preference expressed by the plugin.
+	Weight int8 `hcl:"weight"`
+}
+
+func (p *SchedulerScoringPlugin) Copy() *SchedulerScoringPlugin {
+	if p == nil {
+		return nil
+	}
+
+	np := *p
+	return &np
+}
+
+func (p *SchedulerScoringPlugin) Validate() error {
+	if p == nil {
+		return fmt.Errorf("scoring plugin must not be empty")
+	}
+	if p.Name == "" {
+		return fmt.Errorf("scoring plugin name must not be empty")
+	}
+	if p.Weight == 0 ||
+		p.Weight > MaxSchedulerScoringPluginWeight ||
+		p.Weight < -MaxSchedulerScoringPluginWeight {
+		return fmt.Errorf("scoring plugin %q weight must be non-zero and within the range [-%d, %d]",
+			p.Name, MaxSchedulerScoringPluginWeight, MaxSchedulerScoringPluginWeight)
+	}
 	return nil
 }
 
@@ -5,6 +5,7 @@ package structs
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper/pointer"
@@ -106,3 +107,207 @@ func TestSchedulerConfiguration_WithNodePool(t *testing.T) {
 		})
 	}
 }
+
+func TestSchedulerConfiguration_Validate_ScoringPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		plugins   []*SchedulerScoringPlugin
+		expectErr string
+	}{
+		{
+			name: "valid",
+			plugins: []*SchedulerScoringPlugin{
+				{Name: "alloc-churn", Weight: 50},
+				{Name: "other", Weight: -100},
+			},
+		},
+		{
+			name:      "missing name",
+			plugins:   []*SchedulerScoringPlugin{{Weight: 50}},
+			expectErr: "name must not be empty",
+		},
+		{
+			name:      "zero weight",
+			plugins:   []*SchedulerScoringPlugin{{Name: "alloc-churn"}},
+			expectErr: "weight must be non-zero",
+		},
+		{
+			name:      "weight out of range",
+			plugins:   []*SchedulerScoringPlugin{{Name: "alloc-churn", Weight: -101}},
+			expectErr: "weight must be non-zero",
+		},
+		{
+			name: "duplicate",
+			plugins: []*SchedulerScoringPlugin{
+				{Name: "alloc-churn", Weight: 50},
+				{Name: "alloc-churn", Weight: 10},
+			},
+			expectErr: "duplicate scoring plugin",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &SchedulerConfiguration{ScoringPlugins: tc.plugins}
+			err := config.Validate()
+			if tc.expectErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestSchedulerConfiguration_Copy_ScoringPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	config := &SchedulerConfiguration{
+		ScoringPlugins: []*SchedulerScoringPlugin{{Name: "alloc-churn", Weight: 50}},
+	}
+	configCopy := config.Copy()
+	must.Eq(t, config, configCopy)
+
+	configCopy.ScoringPlugins[0].Weight = 10
+	must.Eq(t, 50, config.ScoringPlugins[0].Weight)
+}
+
+func TestEvalBrokerFairShare_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		fairShare *EvalBrokerFairShare
+		expectErr string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "valid",
+			fairShare: &EvalBrokerFairShare{
+				Enabled:          true,
+				DefaultWeight:    2,
+				NamespaceWeights: map[string]int{"default": 1, "prod": 10},
+			},
+		},
+		{
+			name:      "negative default weight",
+			fairShare: &EvalBrokerFairShare{DefaultWeight: -1},
+			expectErr: "default weight must be within the range",
+		},
+		{
+			name:      "zero namespace weight",
+			fairShare: &EvalBrokerFairShare{NamespaceWeights: map[string]int{"prod": 0}},
+			expectErr: `weight for namespace "prod" must be within the range`,
+		},
+		{
+			name:      "namespace weight out of range",
+			fairShare: &EvalBrokerFairShare{NamespaceWeights: map[string]int{"prod": 1001}},
+			expectErr: `weight for namespace "prod" must be within the range`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &SchedulerConfiguration{EvalBrokerFairShare: tc.fairShare}
+			err := config.Validate()
+			if tc.expectErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestEvalBrokerFairShare_Weight(t *testing.T) {
+	ci.Parallel(t)
+
+	var fairShare *EvalBrokerFairShare
+	must.Eq(t, 1, fairShare.Weight("default"))
+
+	fairShare = &EvalBrokerFairShare{
+		NamespaceWeights: map[string]int{"prod": 5},
+	}
+	must.Eq(t, 5, fairShare.Weight("prod"))
+	must.Eq(t, 1, fairShare.Weight("default"))
+
+	fairShare.DefaultWeight = 3
+	must.Eq(t, 3, fairShare.Weight("default"))
+
+	fairShareCopy := fairShare.Copy()
+	fairShareCopy.NamespaceWeights["prod"] = 1
+	must.Eq(t, 5, fairShare.Weight("prod"))
+}
+
+func TestSchedulerUtilizationScoring_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		utilization *SchedulerUtilizationScoring
+		expectErr   string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "valid",
+			utilization: &SchedulerUtilizationScoring{
+				Enabled:        true,
+				ObservedWeight: 100,
+				MaxAge:         10 * time.Minute,
+			},
+		},
+		{
+			name:        "weight out of range",
+			utilization: &SchedulerUtilizationScoring{ObservedWeight: 101},
+			expectErr:   "observed weight must be within the range",
+		},
+		{
+			name:        "negative max age",
+			utilization: &SchedulerUtilizationScoring{MaxAge: -time.Second},
+			expectErr:   "max age must be at least",
+		},
+		{
+			name:        "max age below report interval",
+			utilization: &SchedulerUtilizationScoring{MaxAge: time.Minute},
+			expectErr:   "max age must be at least",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &SchedulerConfiguration{UtilizationScoring: tc.utilization}
+			err := config.Validate()
+			if tc.expectErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestSchedulerUtilizationScoring_Effective(t *testing.T) {
+	ci.Parallel(t)
+
+	var utilization *SchedulerUtilizationScoring
+	must.Eq(t, 0, utilization.EffectiveObservedWeight())
+	must.Eq(t, DefaultUtilizationMaxAge, utilization.EffectiveMaxAge())
+
+	utilization = &SchedulerUtilizationScoring{ObservedWeight: 20}
+	must.Eq(t, 0, utilization.EffectiveObservedWeight())
+
+	utilization.Enabled = true
+	must.Eq(t, 0.2, utilization.EffectiveObservedWeight())
+
+	utilization.ObservedWeight = 0
+	must.Eq(t, 0.5, utilization.EffectiveObservedWeight())
+
+	utilization.MaxAge = 10 * time.Minute
+	must.Eq(t, 10*time.Minute, utilization.EffectiveMaxAge())
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// PreemptionBudgetScopeJob is the scope of a budget set on a job.
+	PreemptionBudgetScopeJob = "job"
+
+	// PreemptionBudgetScopeNamespace is the scope of a budget set on a
+	// namespace.
+	PreemptionBudgetScopeNamespace = "namespace"
+)
+
+// PreemptionBudget limits how often the allocations of a job or namespace
+// can be preempted, and protects recently started allocations from
+// preemption.
+type PreemptionBudget struct {
+	// MaxPreemptions is the maximum number of allocations that can be
+	// preempted within Window. Zero means the number is not limited.
+	MaxPreemptions int
+
+	// Window is the period of time over which preemptions are counted
+	// against MaxPreemptions.
+	Window time.Duration
+
+	// MinRuntime is how long an allocation must have been running before it
+	// can be preempted.
+	MinRuntime time.Duration
+}
+
+func (p *PreemptionBudget) Copy() *PreemptionBudget {
+	if p == nil {
+		return nil
+	}
+	np := new(PreemptionBudget)
+	*np = *p
+	return np
+}
+
+func (p *PreemptionBudget) Equal(o *PreemptionBudget) bool {
+	if p == nil || o == nil {
+		return p == o
+	}
+	return *p == *o
+}
+
+// Limited returns whether the budget limits the number of preemptions.
+func (p *PreemptionBudget) Limited() bool {
+	return p != nil && p.MaxPreemptions > 0
+}
+
+func (p *PreemptionBudget) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if p.MaxPreemptions < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("max_preemptions must be non-negative, got %d", p.MaxPreemptions))
+	}
+	if p.Window < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("window must be non-negative, got %v", p.Window))
+	}
+	if p.MaxPreemptions > 0 && p.Window == 0 {
+		_ = multierror.Append(&mErr, errors.New("window must be set when max_preemptions is set"))
+	}
+	if p.MinRuntime < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("min_runtime must be non-negative, got %v", p.MinRuntime))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// AllocPreemption records why an allocation was preempted and the preemption
+// budgets its preemption was counted against.
+type AllocPreemption struct {
+	// Reason is a human readable description of the preemption.
+	Reason string
+
+	// Time is when the scheduler preempted the allocation, in nanoseconds
+	// since the Unix epoch.
+	Time int64
+
+	// Budgets is the usage of each budget that limited the preemption,
+	// including the preemption of this allocation.
+	Budgets []*PreemptionBudgetUsage
+}
+
+func (p *AllocPreemption) Copy() *AllocPreemption {
+	if p == nil {
+		return nil
+	}
+	np := new(AllocPreemption)
+	*np = *p
+	np.Budgets = slices.Clone(p.Budgets)
+	for i, b := range np.Budgets {
+		np.Budgets[i] = b.Copy()
+	}
+	return np
+}
+
+// PreemptionBudgetUsage is the number of preemptions counted against a
+// budget at the time an allocation was preempted.
+type PreemptionBudgetUsage struct {
+	// Scope is the object the budget is set on, either
+	// PreemptionBudgetScopeJob or PreemptionBudgetScopeNamespace.
+	Scope string
+
+	// Preemptions is the number of preemptions within the window.
+	Preemptions int
+
+	// MaxPreemptions and Window are the limits of the budget.
+	MaxPreemptions int
+	Window         time.Duration
+}
+
+func (u *PreemptionBudgetUsage) Copy() *PreemptionBudgetUsage {
+	if u == nil {
+		return nil
+	}
+	nu := new(PreemptionBudgetUsage)
+	*nu = *u
+	return nu
+}
+
+func (u *PreemptionBudgetUsage) String() string {
+	return fmt.Sprintf("%s: %d/%d in %v", u.Scope, u.Preemptions, u.MaxPreemptions, u.Window)
+}
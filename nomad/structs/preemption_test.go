@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestPreemptionBudget_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		budget    *PreemptionBudget
+		expectErr string
+	}{
+		{
+			name:   "nil",
+			budget: nil,
+		},
+		{
+			name: "valid",
+			budget: &PreemptionBudget{
+				MaxPreemptions: 3,
+				Window:         time.Hour,
+				MinRuntime:     10 * time.Minute,
+			},
+		},
+		{
+			name: "min runtime only",
+			budget: &PreemptionBudget{
+				MinRuntime: 10 * time.Minute,
+			},
+		},
+		{
+			name: "negative max preemptions",
+			budget: &PreemptionBudget{
+				MaxPreemptions: -1,
+			},
+			expectErr: "max_preemptions must be non-negative",
+		},
+		{
+			name: "max preemptions without window",
+			budget: &PreemptionBudget{
+				MaxPreemptions: 3,
+			},
+			expectErr: "window must be set",
+		},
+		{
+			name: "negative min runtime",
+			budget: &PreemptionBudget{
+				MinRuntime: -time.Minute,
+			},
+			expectErr: "min_runtime must be non-negative",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.budget.Validate()
+			if tc.expectErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestAllocPreemption_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	var nilPreemption *AllocPreemption
+	must.Nil(t, nilPreemption.Copy())
+
+	p := &AllocPreemption{
+		Reason: "Preempted to place an allocation of job \"example\" with priority 90",
+		Time:   time.Now().UnixNano(),
+		Budgets: []*PreemptionBudgetUsage{{
+			Scope:          PreemptionBudgetScopeJob,
+			Preemptions:    1,
+			MaxPreemptions: 3,
+			Window:         time.Hour,
+		}},
+	}
+	c := p.Copy()
+	must.Eq(t, p, c)
+
+	c.Budgets[0].Preemptions = 2
+	must.Eq(t, 1, p.Budgets[0].Preemptions)
+}
@@ -0,0 +1,589 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"golang.org/x/crypto/blake2b"
+)
+
+// QuotaSpec specifies the allowed resource usage across regions for the
+// namespaces that reference it.
+type QuotaSpec struct {
+	// Name is the name for the quota object
+	Name string
+
+	// Description is an optional description for the quota object
+	Description string
+
+	// Limits is the set of quota limits encapsulated by this quota object.
+	// Each limit applies quota in a particular region.
+	Limits []*QuotaLimit
+
+	// Hash is the hash of the object and is used to make replication
+	// efficient.
+	Hash []byte
+
+	// Raft indexes to track creation and modification
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Copy returns a deep copy of the quota specification.
+func (q *QuotaSpec) Copy() *QuotaSpec {
+	if q == nil {
+		return nil
+	}
+
+	nq := *q
+	nq.Hash = slices.Clone(q.Hash)
+	if q.Limits != nil {
+		nq.Limits = make([]*QuotaLimit, len(q.Limits))
+		for i, l := range q.Limits {
+			nq.Limits[i] = l.Copy()
+		}
+	}
+	return &nq
+}
+
+// Validate returns an error if the quota specification is invalid.
+func (q *QuotaSpec) Validate() error {
+	var mErr multierror.Error
+
+	if !validNamespaceName.MatchString(q.Name) {
+		err := fmt.Errorf("invalid name %q. Must match regex %s", q.Name, validNamespaceName)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if len(q.Description) > maxNamespaceDescriptionLength {
+		err := fmt.Errorf("description longer than %d", maxNamespaceDescriptionLength)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
+	regions := make(map[string]struct{}, len(q.Limits))
+	for i, l := range q.Limits {
+		if l == nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("limit %d is empty", i+1))
+			continue
+		}
+		if _, ok := regions[l.Region]; ok {
+			err := fmt.Errorf("multiple limits for region %q", l.Region)
+			mErr.Errors = append(mErr.Errors, err)
+		}
+		regions[l.Region] = struct{}{}
+
+		if err := l.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, multierror.Prefix(err, fmt.Sprintf("limit %d:", i+1)))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// SetHash is used to compute and set the hash of the quota specification and
+// each of its limits.
+func (q *QuotaSpec) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	_, _ = hash.Write([]byte(q.Name))
+	_, _ = hash.Write([]byte(q.Description))
+	for _, l := range q.Limits {
+		_, _ = hash.Write(l.SetHash())
+	}
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	q.Hash = hashVal
+	return hashVal
+}
+
+// LimitForRegion returns the quota limit that applies to the given region or
+// nil if the quota doesn't limit the region.
+func (q *QuotaSpec) LimitForRegion(region string) *QuotaLimit {
+	for _, l := range q.Limits {
+		if l.Region == region {
+			return l
+		}
+	}
+	return nil
+}
+
+// NewQuotaUsage returns an empty usage object for the limit of the quota that
+// applies to the given region.
+func (q *QuotaSpec) NewQuotaUsage(region string) *QuotaUsage {
+	usage := &QuotaUsage{
+		Name: q.Name,
+		Used: make(map[string]*QuotaLimit, 1),
+	}
+
+	if limit := q.LimitForRegion(region); limit != nil {
+		used := limit.newUsage()
+		usage.Used[used.Key()] = used
+	}
+	return usage
+}
+
+// QuotaLimit describes the resource limit in a particular region. Within
+// RegionLimit, VariablesLimit and HostVolumesLimit a value of zero is treated
+// as unlimited and a negative value is treated as fully disallowed. Devices are only limited when
+// listed in RegionLimit, in which case their count is the maximum number of
+// device instances that may be allocated.
+//
+// QuotaLimit is also used to track the usage of a limit, in which case the
+// values are the amount of each resource in use.
+type QuotaLimit struct {
+	// Region is the region in which this limit has affect
+	Region string
+
+	// RegionLimit is the quota limit that applies to any allocation within a
+	// referencing namespace in the region.
+	RegionLimit *Resources
+
+	// VariablesLimit is the maximum total size in MiB of all variables
+	// Variable.EncryptedData in the referencing namespaces.
+	VariablesLimit *int
+
+	// HostVolumesLimit is the maximum total capacity in MiB of all dynamic
+	// host volumes in the referencing namespaces.
+	HostVolumesLimit *int
+
+	// Hash is the hash of the object and is used to match usage to its limit.
+	Hash []byte
+}
+
+// Copy returns a deep copy of the quota limit.
+func (l *QuotaLimit) Copy() *QuotaLimit {
+	if l == nil {
+		return nil
+	}
+
+	nl := *l
+	nl.RegionLimit = l.RegionLimit.Copy()
+	nl.Hash = slices.Clone(l.Hash)
+	if l.VariablesLimit != nil {
+		v := *l.VariablesLimit
+		nl.VariablesLimit = &v
+	}
+	if l.HostVolumesLimit != nil {
+		v := *l.HostVolumesLimit
+		nl.HostVolumesLimit = &v
+	}
+	return &nl
+}
+
+// Validate returns an error if the quota limit is invalid.
+func (l *QuotaLimit) Validate() error {
+	var mErr multierror.Error
+
+	if l.Region == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("missing region"))
+	}
+
+	if r := l.RegionLimit; r != nil {
+		if r.DiskMB != 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("disk limits are not supported"))
+		}
+		if len(r.Networks) != 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("network limits are not supported"))
+		}
+
+		devices := make(map[string]struct{}, len(r.Devices))
+		for _, d := range r.Devices {
+			if d.Name == "" {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("device limit missing name"))
+				continue
+			}
+			if _, ok := devices[d.Name]; ok {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("multiple limits for device %q", d.Name))
+			}
+			devices[d.Name] = struct{}{}
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// SetHash is used to compute and set the hash of the quota limit.
+func (l *QuotaLimit) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	writeInt := func(v int64) {
+		_ = binary.Write(hash, binary.LittleEndian, v)
+	}
+
+	_, _ = hash.Write([]byte(l.Region))
+	if r := l.RegionLimit; r != nil {
+		writeInt(int64(r.CPU))
+		writeInt(int64(r.Cores))
+		writeInt(int64(r.MemoryMB))
+		writeInt(int64(r.MemoryMaxMB))
+		for _, d := range r.Devices {
+			_, _ = hash.Write([]byte(d.Name))
+			writeInt(int64(d.Count))
+		}
+	}
+	if l.VariablesLimit != nil {
+		writeInt(int64(*l.VariablesLimit))
+	}
+	if l.HostVolumesLimit != nil {
+		writeInt(int64(*l.HostVolumesLimit))
+	}
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	l.Hash = hashVal
+	return hashVal
+}
+
+// Key returns the key used to index the usage of this limit in
+// QuotaUsage.Used.
+func (l *QuotaLimit) Key() string {
+	return base64.StdEncoding.EncodeToString(l.Hash)
+}
+
+// newUsage returns a usage object with all tracked dimensions of the limit
+// set to zero.
+func (l *QuotaLimit) newUsage() *QuotaLimit {
+	used := &QuotaLimit{
+		Region:      l.Region,
+		RegionLimit: &Resources{},
+		Hash:        slices.Clone(l.Hash),
+	}
+
+	if l.RegionLimit != nil {
+		for _, d := range l.RegionLimit.Devices {
+			used.RegionLimit.Devices = append(used.RegionLimit.Devices, &RequestedDevice{Name: d.Name})
+		}
+	}
+	return used
+}
+
+// AddAllocation adds the resources of the allocation to the usage.
+func (l *QuotaLimit) AddAllocation(alloc *Allocation) {
+	l.updateAllocation(alloc, 1)
+}
+
+// SubtractAllocation removes the resources of the allocation from the usage.
+func (l *QuotaLimit) SubtractAllocation(alloc *Allocation) {
+	l.updateAllocation(alloc, -1)
+}
+
+// AddTaskGroup adds the resources requested by a single allocation of the task
+// group to the usage. Device requests are counted against every device limit
+// they could be satisfied by.
+func (l *QuotaLimit) AddTaskGroup(tg *TaskGroup) {
+	if tg == nil {
+		return
+	}
+	if l.RegionLimit == nil {
+		l.RegionLimit = &Resources{}
+	}
+
+	used := l.RegionLimit
+	for _, task := range tg.Tasks {
+		r := task.Resources
+		if r == nil {
+			continue
+		}
+
+		memoryMax := r.MemoryMaxMB
+		if memoryMax == 0 {
+			memoryMax = r.MemoryMB
+		}
+		used.CPU += r.CPU
+		used.Cores += r.Cores
+		used.MemoryMB += r.MemoryMB
+		used.MemoryMaxMB += memoryMax
+
+		for _, d := range used.Devices {
+			limitID := d.ID()
+			for _, req := range r.Devices {
+				reqID := req.ID()
+				if reqID.Matches(limitID) || limitID.Matches(reqID) {
+					d.Count += req.Count
+				}
+			}
+		}
+	}
+}
+
+func (l *QuotaLimit) updateAllocation(alloc *Allocation, sign int) {
+	if alloc == nil || alloc.AllocatedResources == nil {
+		return
+	}
+	if l.RegionLimit == nil {
+		l.RegionLimit = &Resources{}
+	}
+
+	res := alloc.AllocatedResources.Comparable().Flattened
+	memoryMax := res.Memory.MemoryMaxMB
+	if memoryMax == 0 {
+		memoryMax = res.Memory.MemoryMB
+	}
+
+	used := l.RegionLimit
+	used.CPU = max(0, used.CPU+sign*int(res.Cpu.CpuShares))
+	used.Cores = max(0, used.Cores+sign*len(res.Cpu.ReservedCores))
+	used.MemoryMB = max(0, used.MemoryMB+sign*int(res.Memory.MemoryMB))
+	used.MemoryMaxMB = max(0, used.MemoryMaxMB+sign*int(memoryMax))
+
+	for _, d := range used.Devices {
+		id := d.ID()
+		for _, tr := range alloc.AllocatedResources.Tasks {
+			for _, ad := range tr.Devices {
+				if !ad.ID().Matches(id) {
+					continue
+				}
+
+				count := uint64(len(ad.DeviceIDs))
+				if sign > 0 {
+					d.Count += count
+				} else {
+					d.Count -= min(d.Count, count)
+				}
+			}
+		}
+	}
+}
+
+// Equal returns whether the two quota limits are the same.
+func (l *QuotaLimit) Equal(o *QuotaLimit) bool {
+	if l == nil || o == nil {
+		return l == o
+	}
+	if l.Region != o.Region || !slices.Equal(l.Hash, o.Hash) {
+		return false
+	}
+	if (l.VariablesLimit == nil) != (o.VariablesLimit == nil) ||
+		(l.VariablesLimit != nil && *l.VariablesLimit != *o.VariablesLimit) {
+		return false
+	}
+	if (l.HostVolumesLimit == nil) != (o.HostVolumesLimit == nil) ||
+		(l.HostVolumesLimit != nil && *l.HostVolumesLimit != *o.HostVolumesLimit) {
+		return false
+	}
+
+	lr, or := l.RegionLimit, o.RegionLimit
+	if lr == nil || or == nil {
+		return lr == or
+	}
+	return lr.CPU == or.CPU &&
+		lr.Cores == or.Cores &&
+		lr.MemoryMB == or.MemoryMB &&
+		lr.MemoryMaxMB == or.MemoryMaxMB &&
+		slices.EqualFunc(lr.Devices, or.Devices, func(a, b *RequestedDevice) bool {
+			return a.Name == b.Name && a.Count == b.Count
+		})
+}
+
+// Exhausted returns the dimensions of the limit that the given usage goes
+// over. An empty result means the usage fits within the limit.
+func (l *QuotaLimit) Exhausted(used *QuotaLimit) []string {
+	return l.ExhaustedBy(nil, used)
+}
+
+// ExhaustedBy returns the dimensions of the limit that the usage after goes
+// over and that grew compared to the usage before. This allows changes that
+// don't increase the usage of a dimension that is already over its limit, for
+// example after the limit was lowered.
+func (l *QuotaLimit) ExhaustedBy(before, after *QuotaLimit) []string {
+	if l == nil || after == nil || l.RegionLimit == nil || after.RegionLimit == nil {
+		return nil
+	}
+
+	prev := &Resources{}
+	if before != nil && before.RegionLimit != nil {
+		prev = before.RegionLimit
+	}
+
+	var exhausted []string
+	check := func(dimension string, limit, prev, used int) {
+		if used <= prev || limit == 0 || (limit > 0 && used <= limit) {
+			return
+		}
+		exhausted = append(exhausted, fmt.Sprintf("%s exhausted (%d needed > %d limit)", dimension, used, max(0, limit)))
+	}
+
+	limit, usage := l.RegionLimit, after.RegionLimit
+	check("cpu", limit.CPU, prev.CPU, usage.CPU)
+	check("cores", limit.Cores, prev.Cores, usage.Cores)
+	check("memory", limit.MemoryMB, prev.MemoryMB, usage.MemoryMB)
+	check("memory_max", limit.MemoryMaxMB, prev.MemoryMaxMB, usage.MemoryMaxMB)
+
+	deviceCount := func(devices ResourceDevices, name string) uint64 {
+		for _, d := range devices {
+			if d.Name == name {
+				return d.Count
+			}
+		}
+		return 0
+	}
+	for _, d := range limit.Devices {
+		used := deviceCount(usage.Devices, d.Name)
+		if used > deviceCount(prev.Devices, d.Name) && used > d.Count {
+			exhausted = append(exhausted, fmt.Sprintf("device %q exhausted (%d needed > %d limit)", d.Name, used, d.Count))
+		}
+	}
+
+	return exhausted
+}
+
+// ApplyPlan updates the usage with the changes the plan would make to the
+// allocations of the namespaces accounted against the quota. The lookup
+// function is used to retrieve the existing version of allocations, since
+// plans may only contain the IDs of stopped or preempted allocations.
+func (l *QuotaLimit) ApplyPlan(plan *Plan, inQuota func(namespace string) bool,
+	lookup func(allocID string) (*Allocation, error)) error {
+
+	removed := make(map[string]struct{})
+	remove := func(id string) error {
+		if _, ok := removed[id]; ok {
+			return nil
+		}
+		removed[id] = struct{}{}
+
+		existing, err := lookup(id)
+		if err != nil {
+			return err
+		}
+		if existing != nil && !existing.TerminalStatus() && inQuota(existing.Namespace) {
+			l.SubtractAllocation(existing)
+		}
+		return nil
+	}
+
+	for _, allocs := range plan.NodeUpdate {
+		for _, alloc := range allocs {
+			if err := remove(alloc.ID); err != nil {
+				return err
+			}
+		}
+	}
+	for _, allocs := range plan.NodePreemptions {
+		for _, alloc := range allocs {
+			if err := remove(alloc.ID); err != nil {
+				return err
+			}
+		}
+	}
+	for _, allocs := range plan.NodeAllocation {
+		for _, alloc := range allocs {
+			if !inQuota(alloc.Namespace) {
+				continue
+			}
+			if err := remove(alloc.ID); err != nil {
+				return err
+			}
+			if !alloc.TerminalStatus() {
+				l.AddAllocation(alloc)
+			}
+		}
+	}
+
+	return nil
+}
+
+// QuotaUsage is the resource usage of a quota in the local region.
+type QuotaUsage struct {
+	// Name is the name of the quota specification
+	Name string
+
+	// Used is the usage of each limit of the quota that applies to the
+	// local region, keyed by the encoded hash of the limit.
+	Used map[string]*QuotaLimit
+
+	// Raft indexes to track creation and modification
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Copy returns a deep copy of the quota usage.
+func (q *QuotaUsage) Copy() *QuotaUsage {
+	if q == nil {
+		return nil
+	}
+
+	nq := *q
+	if q.Used != nil {
+		nq.Used = make(map[string]*QuotaLimit, len(q.Used))
+		for k, v := range q.Used {
+			nq.Used[k] = v.Copy()
+		}
+	}
+	return &nq
+}
+
+// UsageForLimit returns the usage tracked for the given limit or nil if the
+// limit isn't tracked.
+func (q *QuotaUsage) UsageForLimit(limit *QuotaLimit) *QuotaLimit {
+	if q == nil || limit == nil {
+		return nil
+	}
+	return q.Used[limit.Key()]
+}
+
+// QuotaSpecListRequest is used to request a list of quota specifications or
+// usages.
+type QuotaSpecListRequest struct {
+	QueryOptions
+}
+
+// QuotaSpecListResponse is used for a list request
+type QuotaSpecListResponse struct {
+	Quotas []*QuotaSpec
+	QueryMeta
+}
+
+// QuotaSpecSpecificRequest is used to query a specific quota specification
+// or its usage.
+type QuotaSpecSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// SingleQuotaSpecResponse is used to return a single quota specification
+type SingleQuotaSpecResponse struct {
+	Quota *QuotaSpec
+	QueryMeta
+}
+
+// QuotaUsageListResponse is used for a quota usage list request
+type QuotaUsageListResponse struct {
+	Usages []*QuotaUsage
+	QueryMeta
+}
+
+// SingleQuotaUsageResponse is used to return a single quota usage
+type SingleQuotaUsageResponse struct {
+	Usage *QuotaUsage
+	QueryMeta
+}
+
+// QuotaSpecUpsertRequest is used to upsert a set of quota specifications
+type QuotaSpecUpsertRequest struct {
+	Quotas []*QuotaSpec
+	WriteRequest
+}
+
+// QuotaSpecDeleteRequest is used to delete a set of quota specifications
+type QuotaSpecDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
@@ -0,0 +1,271 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pointer"
+	"github.com/shoenig/test/must"
+)
+
+func TestQuotaSpec_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name      string
+		spec      *QuotaSpec
+		expectErr string
+	}{
+		{
+			name: "valid",
+			spec: &QuotaSpec{
+				Name: "valid",
+				Limits: []*QuotaLimit{{
+					Region: "global",
+					RegionLimit: &Resources{
+						CPU:     1000,
+						Devices: []*RequestedDevice{{Name: "nvidia/gpu", Count: 2}},
+					},
+					VariablesLimit: pointer.Of(10),
+				}},
+			},
+		},
+		{
+			name:      "invalid name",
+			spec:      &QuotaSpec{Name: "not/valid"},
+			expectErr: "invalid name",
+		},
+		{
+			name: "missing region",
+			spec: &QuotaSpec{
+				Name:   "missing-region",
+				Limits: []*QuotaLimit{{RegionLimit: &Resources{CPU: 1000}}},
+			},
+			expectErr: "missing region",
+		},
+		{
+			name: "duplicate region",
+			spec: &QuotaSpec{
+				Name:   "duplicate-region",
+				Limits: []*QuotaLimit{{Region: "global"}, {Region: "global"}},
+			},
+			expectErr: `multiple limits for region "global"`,
+		},
+		{
+			name: "disk limit",
+			spec: &QuotaSpec{
+				Name:   "disk",
+				Limits: []*QuotaLimit{{Region: "global", RegionLimit: &Resources{DiskMB: 100}}},
+			},
+			expectErr: "disk limits are not supported",
+		},
+		{
+			name: "duplicate device",
+			spec: &QuotaSpec{
+				Name: "duplicate-device",
+				Limits: []*QuotaLimit{{
+					Region: "global",
+					RegionLimit: &Resources{
+						Devices: []*RequestedDevice{{Name: "gpu", Count: 1}, {Name: "gpu", Count: 2}},
+					},
+				}},
+			},
+			expectErr: `multiple limits for device "gpu"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.spec.Validate()
+			if tc.expectErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestQuotaSpec_SetHash(t *testing.T) {
+	ci.Parallel(t)
+
+	spec := &QuotaSpec{
+		Name:   "hash",
+		Limits: []*QuotaLimit{{Region: "global", RegionLimit: &Resources{CPU: 1000}}},
+	}
+	first := spec.SetHash()
+	must.NotNil(t, spec.Limits[0].Hash)
+
+	spec.Limits[0].RegionLimit.CPU = 2000
+	must.NotEq(t, first, spec.SetHash())
+}
+
+func TestQuotaLimit_AllocationUsage(t *testing.T) {
+	ci.Parallel(t)
+
+	spec := &QuotaSpec{
+		Name: "usage",
+		Limits: []*QuotaLimit{{
+			Region: "global",
+			RegionLimit: &Resources{
+				CPU:      1000,
+				MemoryMB: 1000,
+				Devices:  []*RequestedDevice{{Name: "nvidia/gpu", Count: 1}},
+			},
+		}},
+	}
+	spec.SetHash()
+	limit := spec.LimitForRegion("global")
+
+	usage := spec.NewQuotaUsage("global")
+	used := usage.UsageForLimit(limit)
+	must.NotNil(t, used)
+
+	alloc := &Allocation{
+		ID: "alloc",
+		AllocatedResources: &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{
+				"web": {
+					Cpu:    AllocatedCpuResources{CpuShares: 600},
+					Memory: AllocatedMemoryResources{MemoryMB: 256},
+					Devices: []*AllocatedDeviceResource{{
+						Vendor:    "nvidia",
+						Type:      "gpu",
+						Name:      "1080ti",
+						DeviceIDs: []string{"gpu-1"},
+					}},
+				},
+			},
+		},
+	}
+
+	used.AddAllocation(alloc)
+	must.Eq(t, 600, used.RegionLimit.CPU)
+	must.Eq(t, 256, used.RegionLimit.MemoryMB)
+	must.Eq(t, 256, used.RegionLimit.MemoryMaxMB)
+	must.Eq(t, 1, used.RegionLimit.Devices[0].Count)
+	must.SliceEmpty(t, limit.Exhausted(used))
+
+	// A second allocation goes over the cpu and device limits
+	before := used.Copy()
+	used.AddAllocation(alloc)
+	must.Eq(t, []string{
+		"cpu exhausted (1200 needed > 1000 limit)",
+		`device "nvidia/gpu" exhausted (2 needed > 1 limit)`,
+	}, limit.ExhaustedBy(before, used))
+
+	// Removing the allocation brings the usage back under the limit
+	used.SubtractAllocation(alloc)
+	must.Eq(t, before, used)
+}
+
+func TestQuotaLimit_ExhaustedBy(t *testing.T) {
+	ci.Parallel(t)
+
+	limit := &QuotaLimit{
+		Region:      "global",
+		RegionLimit: &Resources{CPU: 1000, MemoryMB: -1},
+	}
+
+	// Usage that doesn't grow is allowed even when over the limit
+	before := &QuotaLimit{RegionLimit: &Resources{CPU: 1500}}
+	after := &QuotaLimit{RegionLimit: &Resources{CPU: 1200}}
+	must.SliceEmpty(t, limit.ExhaustedBy(before, after))
+
+	// Negative limits disallow any usage
+	after = &QuotaLimit{RegionLimit: &Resources{CPU: 1200, MemoryMB: 1}}
+	must.Eq(t, []string{"memory exhausted (1 needed > 0 limit)"}, limit.ExhaustedBy(before, after))
+
+	// Zero limits are unlimited
+	limit.RegionLimit.MemoryMB = 0
+	must.SliceEmpty(t, limit.ExhaustedBy(before, after))
+}
+
+func TestQuotaLimit_ApplyPlan(t *testing.T) {
+	ci.Parallel(t)
+
+	newAlloc := func(id, namespace string, cpu int64) *Allocation {
+		return &Allocation{
+			ID:            id,
+			Namespace:     namespace,
+			DesiredStatus: AllocDesiredStatusRun,
+			ClientStatus:  AllocClientStatusRunning,
+			AllocatedResources: &AllocatedResources{
+				Tasks: map[string]*AllocatedTaskResources{
+					"web": {Cpu: AllocatedCpuResources{CpuShares: cpu}},
+				},
+			},
+		}
+	}
+
+	existing := map[string]*Allocation{
+		"stopped":  newAlloc("stopped", "prod", 100),
+		"updated":  newAlloc("updated", "prod", 200),
+		"external": newAlloc("external", "other", 400),
+	}
+	lookup := func(id string) (*Allocation, error) {
+		return existing[id], nil
+	}
+	inQuota := func(namespace string) bool {
+		return namespace == "prod"
+	}
+
+	used := &QuotaLimit{RegionLimit: &Resources{CPU: 300}}
+	plan := &Plan{
+		NodeUpdate: map[string][]*Allocation{
+			"node1": {{ID: "stopped"}},
+		},
+		NodePreemptions: map[string][]*Allocation{
+			"node1": {{ID: "external"}},
+		},
+		NodeAllocation: map[string][]*Allocation{
+			"node1": {
+				newAlloc("updated", "prod", 250),
+				newAlloc("placed", "prod", 1000),
+				newAlloc("ignored", "other", 1000),
+			},
+		},
+	}
+
+	must.NoError(t, used.ApplyPlan(plan, inQuota, lookup))
+	must.Eq(t, 1250, used.RegionLimit.CPU)
+}
+
+func TestQuotaLimit_AddTaskGroup(t *testing.T) {
+	ci.Parallel(t)
+
+	used := &QuotaLimit{
+		RegionLimit: &Resources{
+			Devices: []*RequestedDevice{{Name: "gpu"}, {Name: "fpga"}},
+		},
+	}
+	tg := &TaskGroup{
+		Tasks: []*Task{
+			{
+				Resources: &Resources{
+					CPU:         500,
+					MemoryMB:    256,
+					MemoryMaxMB: 512,
+					Devices:     []*RequestedDevice{{Name: "nvidia/gpu", Count: 2}},
+				},
+			},
+			{
+				Resources: &Resources{
+					Cores:    1,
+					MemoryMB: 128,
+				},
+			},
+		},
+	}
+
+	used.AddTaskGroup(tg)
+	must.Eq(t, 500, used.RegionLimit.CPU)
+	must.Eq(t, 1, used.RegionLimit.Cores)
+	must.Eq(t, 384, used.RegionLimit.MemoryMB)
+	must.Eq(t, 640, used.RegionLimit.MemoryMaxMB)
+	must.Eq(t, 2, used.RegionLimit.Devices[0].Count)
+	must.Eq(t, 0, used.RegionLimit.Devices[1].Count)
+}
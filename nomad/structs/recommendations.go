@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"maps"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// RecommendationResourceCPU is the resource of recommendations for the
+	// CPU of a task, in MHz.
+	RecommendationResourceCPU = "CPU"
+
+	// RecommendationResourceMemoryMB is the resource of recommendations for
+	// the memory of a task, in MB.
+	RecommendationResourceMemoryMB = "MemoryMB"
+)
+
+// Recommendation is a suggested value for a resource of a task. Applying a
+// recommendation registers a new version of the job with the resource set to
+// the recommended value.
+type Recommendation struct {
+	ID         string
+	Region     string
+	Namespace  string
+	JobID      string
+	JobVersion uint64
+	Group      string
+	Task       string
+
+	// Resource is the resource of the task the recommendation is for, either
+	// RecommendationResourceCPU or RecommendationResourceMemoryMB.
+	Resource string
+
+	// Value is the recommended value of the resource
+	Value int
+
+	// Current is the value of the resource in the current version of the
+	// job. It is kept up to date by the state store.
+	Current int
+
+	// Meta and Stats hold arbitrary information about how the recommendation
+	// was computed.
+	Meta  map[string]interface{}
+	Stats map[string]float64
+
+	// EnforceVersion dismisses the recommendation when a new version of the
+	// job is registered.
+	EnforceVersion bool
+
+	// SubmitTime is the time, in nanoseconds since the Unix epoch, the
+	// recommendation was last submitted.
+	SubmitTime int64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Copy returns a deep copy of the recommendation.
+func (r *Recommendation) Copy() *Recommendation {
+	if r == nil {
+		return nil
+	}
+
+	nr := *r
+	nr.Meta = maps.Clone(r.Meta)
+	nr.Stats = maps.Clone(r.Stats)
+	return &nr
+}
+
+// SameTarget returns whether the two recommendations are for the same
+// resource of the same task.
+func (r *Recommendation) SameTarget(o *Recommendation) bool {
+	return r.Namespace == o.Namespace &&
+		r.JobID == o.JobID &&
+		r.Group == o.Group &&
+		r.Task == o.Task &&
+		r.Resource == o.Resource
+}
+
+// Validate returns an error if the recommendation is invalid.
+func (r *Recommendation) Validate() error {
+	var mErr multierror.Error
+
+	if r.JobID == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("recommendation must specify a job"))
+	}
+	if r.Group == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("recommendation must specify a group"))
+	}
+	if r.Task == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("recommendation must specify a task"))
+	}
+
+	minResources := MinResources()
+	switch r.Resource {
+	case RecommendationResourceCPU:
+		if r.Value < minResources.CPU {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum CPU value is %d; got %d", minResources.CPU, r.Value))
+		}
+	case RecommendationResourceMemoryMB:
+		if r.Value < minResources.MemoryMB {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum MemoryMB value is %d; got %d", minResources.MemoryMB, r.Value))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("resource must be %q or %q; got %q",
+			RecommendationResourceCPU, RecommendationResourceMemoryMB, r.Resource))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Target returns the task of the job the recommendation is for, or an error if
+// the job has no such task.
+func (r *Recommendation) Target(job *Job) (*Task, error) {
+	tg := job.LookupTaskGroup(r.Group)
+	if tg == nil {
+		return nil, fmt.Errorf("job %q has no group %q", job.ID, r.Group)
+	}
+	task := tg.LookupTask(r.Task)
+	if task == nil {
+		return nil, fmt.Errorf("group %q of job %q has no task %q", r.Group, job.ID, r.Task)
+	}
+	return task, nil
+}
+
+// CurrentValue returns the value of the recommended resource of the task.
+func (r *Recommendation) CurrentValue(task *Task) int {
+	if task.Resources == nil {
+		return 0
+	}
+	switch r.Resource {
+	case RecommendationResourceCPU:
+		return task.Resources.CPU
+	case RecommendationResourceMemoryMB:
+		return task.Resources.MemoryMB
+	}
+	return 0
+}
+
+// ApplyTo sets the recommended resource of the task to the recommended value.
+func (r *Recommendation) ApplyTo(task *Task) {
+	if task.Resources == nil {
+		task.Resources = DefaultResources()
+	}
+	switch r.Resource {
+	case RecommendationResourceCPU:
+		task.Resources.CPU = r.Value
+	case RecommendationResourceMemoryMB:
+		task.Resources.MemoryMB = r.Value
+
+		// Oversubscribed tasks can't reserve more than their limit
+		if task.Resources.MemoryMaxMB != 0 && task.Resources.MemoryMaxMB < r.Value {
+			task.Resources.MemoryMaxMB = r.Value
+		}
+	}
+}
+
+// TaskUsage is the resource usage of a task observed by a client over one
+// report interval.
+type TaskUsage struct {
+	AllocID string
+	Task    string
+
+	// CPU is the 95th percentile of the CPU usage in MHz.
+	CPU float64
+
+	// MemoryMB is the peak memory usage in MB.
+	MemoryMB float64
+
+	// Samples is the number of samples the usage was computed from.
+	Samples int
+}
+
+// TaskUsageReportRequest is used by clients to report the resource usage of
+// their running tasks.
+type TaskUsageReportRequest struct {
+	NodeID string
+	Usage  []*TaskUsage
+	WriteRequest
+}
+
+// RecommendationListRequest is used to list recommendations, optionally
+// filtered to a job, group and task.
+type RecommendationListRequest struct {
+	JobID string
+	Group string
+	Task  string
+	QueryOptions
+}
+
+// RecommendationListResponse is used for a list request
+type RecommendationListResponse struct {
+	Recommendations []*Recommendation
+	QueryMeta
+}
+
+// RecommendationSpecificRequest is used to query a specific recommendation
+type RecommendationSpecificRequest struct {
+	RecommendationID string
+	QueryOptions
+}
+
+// SingleRecommendationResponse is used to return a single recommendation
+type SingleRecommendationResponse struct {
+	Recommendation *Recommendation
+	QueryMeta
+}
+
+// RecommendationUpsertRequest is used to upsert a set of recommendations
+type RecommendationUpsertRequest struct {
+	Recommendations []*Recommendation
+	WriteRequest
+}
+
+// RecommendationUpsertResponse returns the upserted recommendations
+type RecommendationUpsertResponse struct {
+	Recommendations []*Recommendation
+	WriteMeta
+}
+
+// RecommendationDeleteRequest is used to delete a set of recommendations
+type RecommendationDeleteRequest struct {
+	IDs []string
+	WriteRequest
+}
+
+// RecommendationApplyRequest is used to apply and dismiss a set of
+// recommendations
+type RecommendationApplyRequest struct {
+	Apply          []string
+	Dismiss        []string
+	PolicyOverride bool
+	WriteRequest
+}
+
+// RecommendationApplyResponse returns the jobs updated by applying
+// recommendations and the recommendations that failed to apply
+type RecommendationApplyResponse struct {
+	UpdatedJobs []*SingleRecommendationApplyResult
+	Errors      []*SingleRecommendationApplyError
+	WriteMeta
+}
+
+// SingleRecommendationApplyResult is the job registered by applying a set of
+// recommendations
+type SingleRecommendationApplyResult struct {
+	Namespace       string
+	JobID           string
+	JobModifyIndex  uint64
+	EvalID          string
+	EvalCreateIndex uint64
+	Warnings        string
+	Recommendations []string
+}
+
+// SingleRecommendationApplyError is the error applying a set of
+// recommendations to a job
+type SingleRecommendationApplyError struct {
+	Namespace       string
+	JobID           string
+	Recommendations []string
+	Error           string
+}
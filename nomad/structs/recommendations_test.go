@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestRecommendation_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	valid := func() *Recommendation {
+		return &Recommendation{
+			JobID:    "example",
+			Group:    "cache",
+			Task:     "redis",
+			Resource: RecommendationResourceMemoryMB,
+			Value:    512,
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		modify    func(*Recommendation)
+		expectErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(*Recommendation) {},
+		},
+		{
+			name:      "missing job",
+			modify:    func(r *Recommendation) { r.JobID = "" },
+			expectErr: "must specify a job",
+		},
+		{
+			name:      "missing group",
+			modify:    func(r *Recommendation) { r.Group = "" },
+			expectErr: "must specify a group",
+		},
+		{
+			name:      "missing task",
+			modify:    func(r *Recommendation) { r.Task = "" },
+			expectErr: "must specify a task",
+		},
+		{
+			name:      "invalid resource",
+			modify:    func(r *Recommendation) { r.Resource = "DiskMB" },
+			expectErr: `got "DiskMB"`,
+		},
+		{
+			name: "cpu below minimum",
+			modify: func(r *Recommendation) {
+				r.Resource = RecommendationResourceCPU
+				r.Value = 0
+			},
+			expectErr: "minimum CPU value is 1",
+		},
+		{
+			name:      "memory below minimum",
+			modify:    func(r *Recommendation) { r.Value = 5 },
+			expectErr: "minimum MemoryMB value is 10",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := valid()
+			tc.modify(rec)
+			err := rec.Validate()
+			if tc.expectErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestRecommendation_Target(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &Job{
+		ID: "example",
+		TaskGroups: []*TaskGroup{{
+			Name:  "cache",
+			Tasks: []*Task{{Name: "redis", Resources: &Resources{CPU: 500, MemoryMB: 256}}},
+		}},
+	}
+
+	rec := &Recommendation{Group: "cache", Task: "redis", Resource: RecommendationResourceCPU}
+	task, err := rec.Target(job)
+	must.NoError(t, err)
+	must.Eq(t, 500, rec.CurrentValue(task))
+
+	rec.Resource = RecommendationResourceMemoryMB
+	must.Eq(t, 256, rec.CurrentValue(task))
+
+	_, err = (&Recommendation{Group: "web", Task: "redis"}).Target(job)
+	must.ErrorContains(t, err, `has no group "web"`)
+
+	_, err = (&Recommendation{Group: "cache", Task: "web"}).Target(job)
+	must.ErrorContains(t, err, `has no task "web"`)
+}
+
+func TestRecommendation_ApplyTo(t *testing.T) {
+	ci.Parallel(t)
+
+	task := &Task{Resources: &Resources{CPU: 500, MemoryMB: 256, MemoryMaxMB: 512}}
+
+	(&Recommendation{Resource: RecommendationResourceCPU, Value: 250}).ApplyTo(task)
+	must.Eq(t, 250, task.Resources.CPU)
+
+	(&Recommendation{Resource: RecommendationResourceMemoryMB, Value: 384}).ApplyTo(task)
+	must.Eq(t, 384, task.Resources.MemoryMB)
+	must.Eq(t, 512, task.Resources.MemoryMaxMB)
+
+	// The memory limit is raised to the reserved memory
+	(&Recommendation{Resource: RecommendationResourceMemoryMB, Value: 1024}).ApplyTo(task)
+	must.Eq(t, 1024, task.Resources.MemoryMB)
+	must.Eq(t, 1024, task.Resources.MemoryMaxMB)
+}
@@ -0,0 +1,209 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"fmt"
+	"slices"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// SentinelScopeSubmitJob is the scope of policies enforced when a job is
+	// registered or planned.
+	SentinelScopeSubmitJob = "submit-job"
+
+	// SentinelEnforcementLevelAdvisory policies report their failures as
+	// warnings.
+	SentinelEnforcementLevelAdvisory = "advisory"
+
+	// SentinelEnforcementLevelSoftMandatory policies reject the request when
+	// they fail, unless the request sets PolicyOverride.
+	SentinelEnforcementLevelSoftMandatory = "soft-mandatory"
+
+	// SentinelEnforcementLevelHardMandatory policies always reject the request
+	// when they fail.
+	SentinelEnforcementLevelHardMandatory = "hard-mandatory"
+)
+
+// SentinelPolicy is a policy written as code that is evaluated by the servers
+// when a request in its scope is made.
+type SentinelPolicy struct {
+	// Name is the unique name of the policy
+	Name string
+
+	// Description is an optional description for the policy
+	Description string
+
+	// Scope is the type of request the policy is enforced on
+	Scope string
+
+	// EnforcementLevel determines how a failing policy is handled
+	EnforcementLevel string
+
+	// Namespaces restricts the policy to requests in the given namespaces. The
+	// policy applies to all namespaces when empty.
+	Namespaces []string
+
+	// Policy is the source of the policy
+	Policy string
+
+	// Hash is the hash of the object and is used to make replication
+	// efficient.
+	Hash []byte
+
+	// Raft indexes to track creation and modification
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Copy returns a deep copy of the policy.
+func (s *SentinelPolicy) Copy() *SentinelPolicy {
+	if s == nil {
+		return nil
+	}
+
+	ns := *s
+	ns.Namespaces = slices.Clone(s.Namespaces)
+	ns.Hash = slices.Clone(s.Hash)
+	return &ns
+}
+
+// Validate returns an error if the policy is invalid. The policy source is
+// compiled separately by the policy engine.
+func (s *SentinelPolicy) Validate() error {
+	var mErr multierror.Error
+
+	if !ValidPolicyName.MatchString(s.Name) {
+		err := fmt.Errorf("invalid name %q. Must match regex %s", s.Name, ValidPolicyName)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if len(s.Description) > maxPolicyDescriptionLength {
+		err := fmt.Errorf("description longer than %d", maxPolicyDescriptionLength)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
+	switch s.Scope {
+	case SentinelScopeSubmitJob:
+	default:
+		err := fmt.Errorf("invalid scope %q", s.Scope)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
+	switch s.EnforcementLevel {
+	case SentinelEnforcementLevelAdvisory,
+		SentinelEnforcementLevelSoftMandatory,
+		SentinelEnforcementLevelHardMandatory:
+	default:
+		err := fmt.Errorf("invalid enforcement level %q", s.EnforcementLevel)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
+	for _, ns := range s.Namespaces {
+		if !validNamespaceName.MatchString(ns) {
+			err := fmt.Errorf("invalid namespace %q. Must match regex %s", ns, validNamespaceName)
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
+
+	if s.Policy == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("missing policy"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// SetHash is used to compute and set the hash of the policy.
+func (s *SentinelPolicy) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	_, _ = hash.Write([]byte(s.Name))
+	_, _ = hash.Write([]byte(s.Description))
+	_, _ = hash.Write([]byte(s.Scope))
+	_, _ = hash.Write([]byte(s.EnforcementLevel))
+	for _, ns := range s.Namespaces {
+		_, _ = hash.Write([]byte(ns))
+	}
+	_, _ = hash.Write([]byte(s.Policy))
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	s.Hash = hashVal
+	return hashVal
+}
+
+// AppliesToNamespace returns whether the policy is enforced on requests in the
+// given namespace.
+func (s *SentinelPolicy) AppliesToNamespace(namespace string) bool {
+	return len(s.Namespaces) == 0 || slices.Contains(s.Namespaces, namespace)
+}
+
+// Stub returns a summary of the policy without its source.
+func (s *SentinelPolicy) Stub() *SentinelPolicyListStub {
+	return &SentinelPolicyListStub{
+		Name:             s.Name,
+		Description:      s.Description,
+		Scope:            s.Scope,
+		EnforcementLevel: s.EnforcementLevel,
+		Namespaces:       slices.Clone(s.Namespaces),
+		Hash:             slices.Clone(s.Hash),
+		CreateIndex:      s.CreateIndex,
+		ModifyIndex:      s.ModifyIndex,
+	}
+}
+
+// SentinelPolicyListStub is used for listing policies
+type SentinelPolicyListStub struct {
+	Name             string
+	Description      string
+	Scope            string
+	EnforcementLevel string
+	Namespaces       []string
+	Hash             []byte
+	CreateIndex      uint64
+	ModifyIndex      uint64
+}
+
+// SentinelPolicyListRequest is used to request a list of policies
+type SentinelPolicyListRequest struct {
+	QueryOptions
+}
+
+// SentinelPolicyListResponse is used for a list request
+type SentinelPolicyListResponse struct {
+	Policies []*SentinelPolicy
+	QueryMeta
+}
+
+// SentinelPolicySpecificRequest is used to query a specific policy
+type SentinelPolicySpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// SingleSentinelPolicyResponse is used to return a single policy
+type SingleSentinelPolicyResponse struct {
+	Policy *SentinelPolicy
+	QueryMeta
+}
+
+// SentinelPolicyUpsertRequest is used to upsert a set of policies
+type SentinelPolicyUpsertRequest struct {
+	Policies []*SentinelPolicy
+	WriteRequest
+}
+
+// SentinelPolicyDeleteRequest is used to delete a set of policies
+type SentinelPolicyDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
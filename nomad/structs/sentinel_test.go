@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestSentinelPolicy_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	valid := func() *SentinelPolicy {
+		return &SentinelPolicy{
+			Name:             "valid",
+			Scope:            SentinelScopeSubmitJob,
+			EnforcementLevel: SentinelEnforcementLevelSoftMandatory,
+			Namespaces:       []string{"default", "prod"},
+			Policy:           `rule "a" { condition = true }`,
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		modify    func(*SentinelPolicy)
+		expectErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(*SentinelPolicy) {},
+		},
+		{
+			name:      "invalid name",
+			modify:    func(p *SentinelPolicy) { p.Name = "not/valid" },
+			expectErr: "invalid name",
+		},
+		{
+			name:      "invalid scope",
+			modify:    func(p *SentinelPolicy) { p.Scope = "submit-node" },
+			expectErr: `invalid scope "submit-node"`,
+		},
+		{
+			name:      "invalid enforcement level",
+			modify:    func(p *SentinelPolicy) { p.EnforcementLevel = "mandatory" },
+			expectErr: `invalid enforcement level "mandatory"`,
+		},
+		{
+			name:      "invalid namespace",
+			modify:    func(p *SentinelPolicy) { p.Namespaces = []string{"not/valid"} },
+			expectErr: `invalid namespace "not/valid"`,
+		},
+		{
+			name:      "missing policy",
+			modify:    func(p *SentinelPolicy) { p.Policy = "" },
+			expectErr: "missing policy",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := valid()
+			tc.modify(policy)
+			err := policy.Validate()
+			if tc.expectErr == "" {
+				must.NoError(t, err)
+			} else {
+				must.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestSentinelPolicy_SetHash(t *testing.T) {
+	ci.Parallel(t)
+
+	policy := &SentinelPolicy{
+		Name:             "test",
+		Scope:            SentinelScopeSubmitJob,
+		EnforcementLevel: SentinelEnforcementLevelAdvisory,
+		Policy:           `rule "a" { condition = true }`,
+	}
+	out1 := policy.SetHash()
+	must.NotNil(t, out1)
+	must.Eq(t, out1, policy.Hash)
+
+	// Changing the namespaces changes the hash
+	policy.Namespaces = []string{"prod"}
+	out2 := policy.SetHash()
+	must.NotEq(t, out1, out2)
+
+	// Copies have the same hash
+	must.Eq(t, out2, policy.Copy().SetHash())
+}
+
+func TestSentinelPolicy_AppliesToNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	policy := &SentinelPolicy{}
+	must.True(t, policy.AppliesToNamespace("default"))
+
+	policy.Namespaces = []string{"prod"}
+	must.True(t, policy.AppliesToNamespace("prod"))
+	must.False(t, policy.AppliesToNamespace("default"))
+}
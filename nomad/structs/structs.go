@@ -12,6 +12,7 @@ import (
 	"crypto/sha512"
 	"encoding/base32"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -128,10 +129,28 @@ const (
 	ACLBindingRulesDeleteRequestType             MessageType = 58
 	NodePoolUpsertRequestType                    MessageType = 59
 	NodePoolDeleteRequestType                    MessageType = 60
+	HostVolumeRegisterRequestType                MessageType = 61
+	HostVolumeDeleteRequestType                  MessageType = 62
+	JobVersionTagRequestType                     MessageType = 63
 
 	// Namespace types were moved from enterprise and therefore start at 64
 	NamespaceUpsertRequestType MessageType = 64
 	NamespaceDeleteRequestType MessageType = 65
+
+	// Quota types were moved from enterprise and therefore follow the
+	// namespace types
+	QuotaSpecUpsertRequestType MessageType = 66
+	QuotaSpecDeleteRequestType MessageType = 67
+
+	// Sentinel policy types were moved from enterprise and therefore follow
+	// the quota types
+	SentinelPolicyUpsertRequestType MessageType = 68
+	SentinelPolicyDeleteRequestType MessageType = 69
+
+	// Recommendation types were moved from enterprise and therefore follow
+	// the Sentinel policy types
+	RecommendationUpsertRequestType MessageType = 70
+	RecommendationDeleteRequestType MessageType = 71
 )
 
 const (
@@ -640,6 +659,11 @@ type NodeUpdateStatusRequest struct {
 	Status    string
 	NodeEvent *NodeEvent
 	UpdatedAt int64
+
+	// Utilization is the node's observed resource usage. Clients that report
+	// utilization attach it to a heartbeat once per report interval.
+	Utilization *NodeUtilization
+
 	WriteRequest
 }
 
@@ -978,6 +1002,28 @@ type JobStabilityResponse struct {
 	WriteMeta
 }
 
+// JobApplyTagRequest is used to tag or untag a job version.
+type JobApplyTagRequest struct {
+	JobID string
+
+	// Name is the name of the tag. When Tag is nil the tag with this name is
+	// removed from whichever version it is attached to.
+	Name string
+
+	// Tag is the tag to attach to Version.
+	Tag     *JobVersionTag
+	Version uint64
+	WriteRequest
+}
+
+// JobTagResponse is the response when tagging or untagging a job version.
+type JobTagResponse struct {
+	Name        string
+	Description string
+	TaggedTime  int64
+	WriteMeta
+}
+
 // NodeListRequest is used to parameterize a list request
 type NodeListRequest struct {
 	QueryOptions
@@ -1061,6 +1107,12 @@ type EvalCountRequest struct {
 	QueryOptions
 }
 
+// EvalQueueDepthRequest is used to get the number of evaluations queued in
+// the eval broker for each namespace
+type EvalQueueDepthRequest struct {
+	QueryOptions
+}
+
 // PlanRequest is used to submit an allocation plan to the leader
 type PlanRequest struct {
 	Plan *Plan
@@ -1388,6 +1440,10 @@ type ApplyDeploymentPromoteRequest struct {
 
 	// An optional evaluation to create after promoting the canaries
 	Eval *Evaluation
+
+	// StatusDescription optionally overrides the description the deployment
+	// is given once it no longer requires promotion
+	StatusDescription string
 }
 
 // DeploymentPauseRequest is used to pause a deployment
@@ -1548,6 +1604,10 @@ type NodeUpdateResponse struct {
 	// has for their scheduling status during heartbeats.
 	SchedulingEligibility string
 
+	// UtilizationOnly is set when the update was committed only to record
+	// the node's utilization report and its status did not change.
+	UtilizationOnly bool
+
 	QueryMeta
 }
 
@@ -1651,6 +1711,11 @@ type JobListResponse struct {
 type JobVersionsRequest struct {
 	JobID string
 	Diffs bool
+
+	// DiffVersion and DiffTagName select a version that every version is
+	// diffed against, instead of its predecessor. At most one may be set.
+	DiffVersion *uint64
+	DiffTagName string
 	QueryOptions
 }
 
@@ -1785,6 +1850,28 @@ type EvalCountResponse struct {
 	QueryMeta
 }
 
+// EvalQueueDepthResponse is used for a queue depth request
+type EvalQueueDepthResponse struct {
+	// Namespaces is the queue depth of the eval broker by namespace
+	Namespaces map[string]*EvalQueueDepth
+	QueryMeta
+}
+
+// EvalQueueDepth is the number of evaluations in the eval broker for a
+// namespace
+type EvalQueueDepth struct {
+	// Ready is the number of evaluations waiting to be dequeued by a
+	// scheduler
+	Ready int
+
+	// Unacked is the number of evaluations being processed by a scheduler
+	Unacked int
+
+	// Pending is the number of evaluations waiting for another evaluation of
+	// the same job to be processed
+	Pending int
+}
+
 // EvalAllocationsResponse is used to return the allocations for an evaluation
 type EvalAllocationsResponse struct {
 	Allocations []*AllocListStub
@@ -2197,11 +2284,77 @@ type Node struct {
 	// updatedd its allocations status.
 	LastAllocUpdateIndex uint64
 
+	// Utilization is the observed resource usage last reported by the
+	// client, if the client reports utilization.
+	Utilization *NodeUtilization
+
 	// Raft Indexes
 	CreateIndex uint64
 	ModifyIndex uint64
 }
 
+// NodeUtilization is the observed resource usage of a node, as reported by
+// the client from its host stats. Values are the 95th percentile of the
+// samples in the client's rolling window and include usage from processes
+// outside of Nomad's control.
+type NodeUtilization struct {
+	// CPU is the observed CPU usage in MHz.
+	CPU int64
+
+	// MemoryMB is the observed memory usage in MB.
+	MemoryMB int64
+
+	// Samples is the number of samples the percentiles were computed from.
+	Samples int
+
+	// UpdatedAt is the time, in seconds since the Unix epoch, the server
+	// received the report.
+	UpdatedAt int64
+}
+
+func (u *NodeUtilization) Copy() *NodeUtilization {
+	if u == nil {
+		return nil
+	}
+	nu := *u
+	return &nu
+}
+
+// Stale returns whether the report is older than maxAge at time now.
+func (u *NodeUtilization) Stale(now time.Time, maxAge time.Duration) bool {
+	if u == nil {
+		return true
+	}
+	return now.Sub(time.Unix(u.UpdatedAt, 0)) > maxAge
+}
+
+// Changed returns whether the report u should replace the node's previous
+// report prev: either the CPU or memory usage moved by more than
+// UtilizationChangeThreshold of the node's capacity, or the previous report is
+// older than half of maxAge and must be refreshed before it goes stale.
+func (u *NodeUtilization) Changed(prev *NodeUtilization, node *Node, maxAge time.Duration) bool {
+	if u == nil {
+		return false
+	}
+	if prev == nil || prev.Samples == 0 || node.NodeResources == nil {
+		return true
+	}
+	if time.Unix(u.UpdatedAt, 0).Sub(time.Unix(prev.UpdatedAt, 0)) >= maxAge/2 {
+		return true
+	}
+
+	capacity := node.NodeResources.Comparable().Flattened
+	changed := func(cur, prev, total int64) bool {
+		delta := cur - prev
+		if delta < 0 {
+			delta = -delta
+		}
+		return float64(delta) > UtilizationChangeThreshold*float64(total)
+	}
+	return changed(u.CPU, prev.CPU, capacity.Cpu.CpuShares) ||
+		changed(u.MemoryMB, prev.MemoryMB, capacity.Memory.MemoryMB)
+}
+
 // GetID is a helper for getting the ID when the object may be nil and is
 // required for pagination.
 func (n *Node) GetID() string {
@@ -2299,6 +2452,7 @@ func (n *Node) Copy() *Node {
 	nn.HostVolumes = helper.DeepCopyMap(n.HostVolumes)
 	nn.HostNetworks = helper.DeepCopyMap(n.HostNetworks)
 	nn.LastDrain = nn.LastDrain.Copy()
+	nn.Utilization = nn.Utilization.Copy()
 	return &nn
 }
 
@@ -2432,10 +2586,28 @@ type Resources struct {
 	Networks    Networks
 	Devices     ResourceDevices
 	NUMA        *NUMA
+
+	// IOWeight is the relative share of disk I/O of the task, between
+	// MinIOWeight and MaxIOWeight. Zero leaves the default weight.
+	IOWeight int
+
+	// ReadBps and WriteBps limit the bytes per second the task can read
+	// from and write to the disk of its allocation directory
+	ReadBps  int64
+	WriteBps int64
+
+	// NetworkEgressMBits limits the outbound bandwidth of the task, and is
+	// reserved on the node's network like other resources
+	NetworkEgressMBits int
 }
 
 const (
 	BytesInMegabyte = 1024 * 1024
+
+	// MinIOWeight and MaxIOWeight bound the disk I/O weight of tasks, using
+	// the range of the cgroups v1 blkio weight
+	MinIOWeight = 10
+	MaxIOWeight = 1000
 )
 
 // DefaultResources is a small resources object that contains the
@@ -2502,6 +2674,19 @@ func (r *Resources) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("MemoryMaxMB value (%d) should be larger than MemoryMB value (%d)", r.MemoryMaxMB, r.MemoryMB))
 	}
 
+	if r.IOWeight != 0 && (r.IOWeight < MinIOWeight || r.IOWeight > MaxIOWeight) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("IOWeight value (%d) must be between %d and %d", r.IOWeight, MinIOWeight, MaxIOWeight))
+	}
+	if r.ReadBps < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("ReadBps value (%d) cannot be negative", r.ReadBps))
+	}
+	if r.WriteBps < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("WriteBps value (%d) cannot be negative", r.WriteBps))
+	}
+	if r.NetworkEgressMBits < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("NetworkEgressMBits value (%d) cannot be negative", r.NetworkEgressMBits))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -2529,6 +2714,18 @@ func (r *Resources) Merge(other *Resources) {
 	if len(other.Devices) != 0 {
 		r.Devices = other.Devices
 	}
+	if other.IOWeight != 0 {
+		r.IOWeight = other.IOWeight
+	}
+	if other.ReadBps != 0 {
+		r.ReadBps = other.ReadBps
+	}
+	if other.WriteBps != 0 {
+		r.WriteBps = other.WriteBps
+	}
+	if other.NetworkEgressMBits != 0 {
+		r.NetworkEgressMBits = other.NetworkEgressMBits
+	}
 }
 
 // Equal Resources.
@@ -2547,6 +2744,10 @@ func (r *Resources) Equal(o *Resources) bool {
 		r.MemoryMaxMB == o.MemoryMaxMB &&
 		r.DiskMB == o.DiskMB &&
 		r.IOPS == o.IOPS &&
+		r.IOWeight == o.IOWeight &&
+		r.ReadBps == o.ReadBps &&
+		r.WriteBps == o.WriteBps &&
+		r.NetworkEgressMBits == o.NetworkEgressMBits &&
 		r.Networks.Equal(&o.Networks) &&
 		r.Devices.Equal(&o.Devices)
 }
@@ -2636,15 +2837,19 @@ func (r *Resources) Copy() *Resources {
 		return nil
 	}
 	return &Resources{
-		CPU:         r.CPU,
-		Cores:       r.Cores,
-		MemoryMB:    r.MemoryMB,
-		MemoryMaxMB: r.MemoryMaxMB,
-		DiskMB:      r.DiskMB,
-		IOPS:        r.IOPS,
-		Networks:    r.Networks.Copy(),
-		Devices:     r.Devices.Copy(),
-		NUMA:        r.NUMA.Copy(),
+		CPU:                r.CPU,
+		Cores:              r.Cores,
+		MemoryMB:           r.MemoryMB,
+		MemoryMaxMB:        r.MemoryMaxMB,
+		DiskMB:             r.DiskMB,
+		IOPS:               r.IOPS,
+		Networks:           r.Networks.Copy(),
+		Devices:            r.Devices.Copy(),
+		NUMA:               r.NUMA.Copy(),
+		IOWeight:           r.IOWeight,
+		ReadBps:            r.ReadBps,
+		WriteBps:           r.WriteBps,
+		NetworkEgressMBits: r.NetworkEgressMBits,
 	}
 }
 
@@ -2671,6 +2876,9 @@ func (r *Resources) Add(delta *Resources) {
 		r.MemoryMaxMB += delta.MemoryMB
 	}
 	r.DiskMB += delta.DiskMB
+	r.ReadBps += delta.ReadBps
+	r.WriteBps += delta.WriteBps
+	r.NetworkEgressMBits += delta.NetworkEgressMBits
 
 	for _, n := range delta.Networks {
 		// Find the matching interface by IP or CIDR
@@ -3021,6 +3229,16 @@ func (ns Networks) NetIndex(n *NetworkResource) int {
 	return -1
 }
 
+// EgressMBits returns the total bandwidth of the networks, which bounds the
+// egress bandwidth that can be reserved by tasks.
+func (ns Networks) EgressMBits() int {
+	total := 0
+	for _, n := range ns {
+		total += n.MBits
+	}
+	return total
+}
+
 // Modes returns the set of network modes used by our NetworkResource blocks.
 func (ns Networks) Modes() *set.Set[string] {
 	return set.FromFunc(ns, func(nr *NetworkResource) string {
@@ -3218,6 +3436,9 @@ func (n *NodeResources) Comparable() *ComparableResources {
 			Memory: AllocatedMemoryResources{
 				MemoryMB: n.Memory.MemoryMB,
 			},
+			IO: AllocatedIOResources{
+				NetworkEgressMBits: int64(n.Networks.EgressMBits()),
+			},
 			Networks: n.Networks,
 		},
 		Shared: AllocatedSharedResources{
@@ -3850,6 +4071,7 @@ func (a *AllocatedResources) Canonicalize() {
 type AllocatedTaskResources struct {
 	Cpu      AllocatedCpuResources
 	Memory   AllocatedMemoryResources
+	IO       AllocatedIOResources
 	Networks Networks
 	Devices  []*AllocatedDeviceResource
 }
@@ -3888,6 +4110,7 @@ func (a *AllocatedTaskResources) Add(delta *AllocatedTaskResources) {
 
 	a.Cpu.Add(&delta.Cpu)
 	a.Memory.Add(&delta.Memory)
+	a.IO.Add(&delta.IO)
 
 	for _, n := range delta.Networks {
 		// Find the matching interface by IP or CIDR
@@ -3917,6 +4140,7 @@ func (a *AllocatedTaskResources) Max(other *AllocatedTaskResources) {
 
 	a.Cpu.Max(&other.Cpu)
 	a.Memory.Max(&other.Memory)
+	a.IO.Max(&other.IO)
 
 	for _, n := range other.Networks {
 		// Find the matching interface by IP or CIDR
@@ -3952,13 +4176,14 @@ func (a *AllocatedTaskResources) Comparable() *ComparableResources {
 				MemoryMB:    a.Memory.MemoryMB,
 				MemoryMaxMB: a.Memory.MemoryMaxMB,
 			},
+			IO: a.IO,
 		},
 	}
 	ret.Flattened.Networks = append(ret.Flattened.Networks, a.Networks...)
 	return ret
 }
 
-// Subtract only subtracts CPU and Memory resources. Network utilization
+// Subtract only subtracts CPU, Memory and IO resources. Network utilization
 // is managed separately in NetworkIndex
 func (a *AllocatedTaskResources) Subtract(delta *AllocatedTaskResources) {
 	if delta == nil {
@@ -3967,6 +4192,7 @@ func (a *AllocatedTaskResources) Subtract(delta *AllocatedTaskResources) {
 
 	a.Cpu.Subtract(&delta.Cpu)
 	a.Memory.Subtract(&delta.Memory)
+	a.IO.Subtract(&delta.IO)
 }
 
 // AllocatedSharedResources are the set of resources allocated to a task group.
@@ -4124,6 +4350,51 @@ func (a *AllocatedMemoryResources) Max(other *AllocatedMemoryResources) {
 	}
 }
 
+// AllocatedIOResources captures the disk I/O and network bandwidth limits of
+// a task. Only the network egress bandwidth is reserved on the node, the disk
+// limits are enforced by the client without being bin-packed.
+type AllocatedIOResources struct {
+	Weight             int64
+	ReadBps            int64
+	WriteBps           int64
+	NetworkEgressMBits int64
+}
+
+func (a *AllocatedIOResources) Add(delta *AllocatedIOResources) {
+	if delta == nil {
+		return
+	}
+
+	// weights are relative to other tasks, so they don't add up
+	if delta.Weight > a.Weight {
+		a.Weight = delta.Weight
+	}
+	a.ReadBps += delta.ReadBps
+	a.WriteBps += delta.WriteBps
+	a.NetworkEgressMBits += delta.NetworkEgressMBits
+}
+
+func (a *AllocatedIOResources) Subtract(delta *AllocatedIOResources) {
+	if delta == nil {
+		return
+	}
+
+	a.ReadBps -= delta.ReadBps
+	a.WriteBps -= delta.WriteBps
+	a.NetworkEgressMBits -= delta.NetworkEgressMBits
+}
+
+func (a *AllocatedIOResources) Max(other *AllocatedIOResources) {
+	if other == nil {
+		return
+	}
+
+	a.Weight = max(a.Weight, other.Weight)
+	a.ReadBps = max(a.ReadBps, other.ReadBps)
+	a.WriteBps = max(a.WriteBps, other.WriteBps)
+	a.NetworkEgressMBits = max(a.NetworkEgressMBits, other.NetworkEgressMBits)
+}
+
 type AllocatedDevices []*AllocatedDeviceResource
 
 // Index finds the matching index using the passed device. If not found, -1 is
@@ -4241,6 +4512,10 @@ func (c *ComparableResources) Superset(other *ComparableResources) (bool, string
 	if c.Shared.DiskMB < other.Shared.DiskMB {
 		return false, "disk"
 	}
+
+	if c.Flattened.IO.NetworkEgressMBits < other.Flattened.IO.NetworkEgressMBits {
+		return false, "network bandwidth"
+	}
 	return true, ""
 }
 
@@ -4391,11 +4666,27 @@ type Job struct {
 	// can preempt other jobs.
 	Priority int
 
+	// PreemptionBudget limits how often the allocations of this job can be
+	// preempted by higher priority jobs.
+	PreemptionBudget *PreemptionBudget
+
 	// AllAtOnce is used to control if incremental scheduling of task groups
 	// is allowed or if we must do a gang scheduling of the entire job. This
 	// can slow down larger jobs if resources are not available.
 	AllAtOnce bool
 
+	// Gang requires that every allocation placed by an evaluation of the job
+	// is placed together. If any placement fails, none are made and the job
+	// is blocked until all of them fit. Plans for gang jobs are always
+	// applied all at once, as if AllAtOnce were set, so the plan applier
+	// can't commit part of a gang either.
+	Gang bool
+
+	// Prefetch has the leader ask the eligible nodes of the job to pull the
+	// images of its tasks when the job is registered, so that allocations
+	// placed later start without waiting for the pull.
+	Prefetch bool
+
 	// Datacenters contains all the datacenters this job is allowed to span
 	Datacenters []string
 
@@ -4490,6 +4781,12 @@ type Job struct {
 	// on each job register.
 	Version uint64
 
+	// VersionTag is an optional tag attached to this version of the job.
+	// Tagged versions are never garbage collected and can be reverted to by
+	// name. Tags are not part of the job specification and are dropped when a
+	// new version is registered.
+	VersionTag *JobVersionTag
+
 	// SubmitTime is the time at which the job version was submitted as
 	// UnixNano in UTC
 	SubmitTime int64
@@ -4650,6 +4947,8 @@ func (j *Job) Copy() *Job {
 	nj.Affinities = CopySliceAffinities(j.Affinities)
 	nj.Multiregion = j.Multiregion.Copy()
 	nj.UI = j.UI.Copy()
+	nj.PreemptionBudget = j.PreemptionBudget.Copy()
+	nj.VersionTag = j.VersionTag.Copy()
 
 	if j.TaskGroups != nil {
 		tgs := make([]*TaskGroup, len(j.TaskGroups))
@@ -4740,6 +5039,14 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	if j.Gang && !(j.Type == JobTypeService || j.Type == JobTypeBatch) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Gang placement is only supported for service and batch jobs"))
+	}
+
+	if err := j.PreemptionBudget.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Preemption budget validation failed: %v", err))
+	}
+
 	const MaxDescriptionCharacters = 1000
 	if j.UI != nil {
 		if len(j.UI.Description) > MaxDescriptionCharacters {
@@ -5120,6 +5427,7 @@ func (j *Job) SpecChanged(new *Job) bool {
 	c.StatusDescription = j.StatusDescription
 	c.Stable = j.Stable
 	c.Version = j.Version
+	c.VersionTag = j.VersionTag
 	c.CreateIndex = j.CreateIndex
 	c.ModifyIndex = j.ModifyIndex
 	c.JobModifyIndex = j.JobModifyIndex
@@ -5131,6 +5439,48 @@ func (j *Job) SpecChanged(new *Job) bool {
 	return !reflect.DeepEqual(j, c)
 }
 
+// JobVersionTag is a named, immutable label attached to a job version.
+type JobVersionTag struct {
+	Name        string
+	Description string
+
+	// TaggedTime is the time the tag was applied as UnixNano in UTC.
+	TaggedTime int64
+}
+
+func (t *JobVersionTag) Copy() *JobVersionTag {
+	if t == nil {
+		return nil
+	}
+	nt := new(JobVersionTag)
+	*nt = *t
+	return nt
+}
+
+// Validate checks the tag name and description.
+func (t *JobVersionTag) Validate() error {
+	var mErr multierror.Error
+	if t.Name == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing tag name"))
+	} else if len(t.Name) > maxJobVersionTagNameLength {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf(
+			"tag name must be at most %d characters", maxJobVersionTagNameLength))
+	} else if _, err := strconv.ParseUint(t.Name, 10, 64); err == nil {
+		// numeric names would be ambiguous with versions on revert
+		mErr.Errors = append(mErr.Errors, errors.New("tag name must not be a number"))
+	}
+	if len(t.Description) > maxJobVersionTagDescriptionLength {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf(
+			"tag description must be at most %d characters", maxJobVersionTagDescriptionLength))
+	}
+	return mErr.ErrorOrNil()
+}
+
+const (
+	maxJobVersionTagNameLength        = 128
+	maxJobVersionTagDescriptionLength = 1024
+)
+
 func (j *Job) SetSubmitTime() {
 	j.SubmitTime = time.Now().UTC().UnixNano()
 }
@@ -5294,6 +5644,10 @@ type UpdateStrategy struct {
 	// Canary is the number of canaries to deploy when a change to the task
 	// group is detected.
 	Canary int
+
+	// CanaryAnalysis, if set, promotes or fails the deployment based on
+	// metrics queried while the canaries are running.
+	CanaryAnalysis *CanaryAnalysis
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -5303,6 +5657,7 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 
 	c := new(UpdateStrategy)
 	*c = *u
+	c.CanaryAnalysis = u.CanaryAnalysis.Copy()
 	return c
 }
 
@@ -5327,6 +5682,14 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Canary == 0 && u.AutoPromote {
 		_ = multierror.Append(&mErr, fmt.Errorf("Auto Promote requires a Canary count greater than zero"))
 	}
+	if u.CanaryAnalysis != nil {
+		if u.Canary == 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Canary analysis requires a Canary count greater than zero"))
+		}
+		if err := u.CanaryAnalysis.Validate(); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
+	}
 	if u.MinHealthyTime < 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Minimum healthy time may not be less than zero: %v", u.MinHealthyTime))
 	}
@@ -5369,11 +5732,73 @@ func (u *UpdateStrategy) Rolling() bool {
 	return u.Stagger > 0 && u.MaxParallel > 0
 }
 
+const (
+	// MultiregionGlobalRegion is the region a multiregion job is submitted
+	// with before it has been interpolated for each of its regions.
+	MultiregionGlobalRegion = "global"
+
+	// MultiregionOnFailureFailAll marks every region as failed when any one
+	// region's deployment fails.
+	MultiregionOnFailureFailAll = "fail_all"
+
+	// MultiregionOnFailureFailLocal marks only the region whose deployment
+	// failed as failed. The remaining regions continue on to blocked.
+	MultiregionOnFailureFailLocal = "fail_local"
+)
+
 type Multiregion struct {
 	Strategy *MultiregionStrategy
 	Regions  []*MultiregionRegion
 }
 
+// Region returns the configuration for the named region, or nil if the
+// region is not part of the multiregion job.
+func (m *Multiregion) Region(name string) *MultiregionRegion {
+	if m == nil {
+		return nil
+	}
+	for _, region := range m.Regions {
+		if region.Name == name {
+			return region
+		}
+	}
+	return nil
+}
+
+// InterpolateRegion parameterizes the job with the values of the named
+// region's block. The job's region is set to the named region, its
+// datacenters and node pool are overridden if the region sets them, the
+// region's meta is merged over the job's meta, and task groups with a count
+// of zero inherit the region's count.
+func (j *Job) InterpolateRegion(name string) error {
+	region := j.Multiregion.Region(name)
+	if region == nil {
+		return fmt.Errorf("region %q is not part of multiregion job %q", name, j.ID)
+	}
+
+	j.Region = name
+	if len(region.Datacenters) > 0 {
+		j.Datacenters = slices.Clone(region.Datacenters)
+	}
+	if region.NodePool != "" {
+		j.NodePool = region.NodePool
+	}
+	if len(region.Meta) > 0 {
+		if j.Meta == nil {
+			j.Meta = make(map[string]string, len(region.Meta))
+		}
+		for k, v := range region.Meta {
+			j.Meta[k] = v
+		}
+	}
+	for _, tg := range j.TaskGroups {
+		if tg.Count == 0 {
+			tg.Count = region.Count
+		}
+	}
+	return nil
+}
+
 func (m *Multiregion) Canonicalize() {
 	if m.Strategy == nil {
 		m.Strategy = &MultiregionStrategy{}
@@ -5451,6 +5876,10 @@ type Namespace struct {
 	VaultConfiguration  *NamespaceVaultConfiguration
 	ConsulConfiguration *NamespaceConsulConfiguration
 
+	// PreemptionBudget limits how often the allocations of the jobs in this
+	// namespace can be preempted, in total.
+	PreemptionBudget *PreemptionBudget
+
 	// Meta is the set of metadata key/value pairs that attached to the namespace
 	Meta map[string]string
 
@@ -5535,6 +5964,10 @@ func (n *Namespace) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid consul configuration: %v", e))
 	}
 
+	if err := n.PreemptionBudget.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid preemption budget: %v", err))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -5588,6 +6021,12 @@ func (n *Namespace) SetHash() []byte {
 		}
 	}
 
+	if n.PreemptionBudget != nil {
+		_ = binary.Write(hash, binary.LittleEndian, int64(n.PreemptionBudget.MaxPreemptions))
+		_ = binary.Write(hash, binary.LittleEndian, n.PreemptionBudget.Window)
+		_ = binary.Write(hash, binary.LittleEndian, n.PreemptionBudget.MinRuntime)
+	}
+
 	// sort keys to ensure hash stability when meta is stored later
 	var keys []string
 	for k := range n.Meta {
@@ -5637,6 +6076,7 @@ func (n *Namespace) Copy() *Namespace {
 		nc.Allowed = slices.Clone(n.ConsulConfiguration.Allowed)
 		nc.Denied = slices.Clone(n.ConsulConfiguration.Denied)
 	}
+	nc.PreemptionBudget = n.PreemptionBudget.Copy()
 
 	if n.Meta != nil {
 		nc.Meta = make(map[string]string, len(n.Meta))
@@ -6646,6 +7086,11 @@ type MigrateStrategy struct {
 	HealthCheck     string
 	MinHealthyTime  time.Duration
 	HealthyDeadline time.Duration
+
+	// Checkpoint enables checkpointing the tasks of allocations migrated off
+	// a draining node and restoring them on their new node. Tasks whose
+	// driver can't checkpoint are restarted instead.
+	Checkpoint bool
 }
 
 // DefaultMigrateStrategy is used for backwards compat with pre-0.8 Allocations
@@ -7077,11 +7522,20 @@ func (tg *TaskGroup) Validate(j *Job) error {
 				mErr = multierror.Append(mErr, err)
 			}
 		}
+	case JobTypeBatch:
+		// Batch allocations are only migrated once the drain deadline is
+		// reached, so only checkpointing applies to them
+		if tg.Migrate != nil && !tg.Migrate.Checkpoint {
+			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q only allows migrate block to enable checkpoint", j.Type))
+		}
 	default:
 		if tg.Migrate != nil {
 			mErr = multierror.Append(mErr, fmt.Errorf("Job type %q does not allow migrate block", j.Type))
 		}
 	}
+	if tg.Migrate != nil && tg.Migrate.Checkpoint && (tg.EphemeralDisk == nil || !tg.EphemeralDisk.Migrate) {
+		mErr = multierror.Append(mErr, errors.New("Migrate checkpoint requires ephemeral_disk migrate to be enabled"))
+	}
 
 	// Check that there is only one leader task if any
 	tasks := make(map[string]int)
@@ -7661,6 +8115,18 @@ type LogConfig struct {
 	MaxFiles      int
 	MaxFileSizeMB int
 	Disabled      bool
+
+	// RotateInterval is the maximum age of a log file before it is rotated,
+	// regardless of its size. Zero disables time based rotation.
+	RotateInterval time.Duration
+
+	// Compression is the algorithm used to compress rotated log files. The
+	// empty string leaves them uncompressed.
+	Compression string
+
+	// Sinks are the external systems the task's logs are forwarded to, in
+	// addition to being written to the rotated log files
+	Sinks []*LogSink
 }
 
 func (l *LogConfig) Equal(o *LogConfig) bool {
@@ -7680,6 +8146,18 @@ func (l *LogConfig) Equal(o *LogConfig) bool {
 		return false
 	}
 
+	if l.RotateInterval != o.RotateInterval {
+		return false
+	}
+
+	if l.Compression != o.Compression {
+		return false
+	}
+
+	if !slices.EqualFunc(l.Sinks, o.Sinks, (*LogSink).Equal) {
+		return false
+	}
+
 	return true
 }
 
@@ -7688,9 +8166,12 @@ func (l *LogConfig) Copy() *LogConfig {
 		return nil
 	}
 	return &LogConfig{
-		MaxFiles:      l.MaxFiles,
-		MaxFileSizeMB: l.MaxFileSizeMB,
-		Disabled:      l.Disabled,
+		MaxFiles:       l.MaxFiles,
+		MaxFileSizeMB:  l.MaxFileSizeMB,
+		Disabled:       l.Disabled,
+		RotateInterval: l.RotateInterval,
+		Compression:    l.Compression,
+		Sinks:          helper.CopySlice(l.Sinks),
 	}
 }
 
@@ -7714,8 +8195,38 @@ func (l *LogConfig) Validate(disk *EphemeralDisk) error {
 	if l.MaxFileSizeMB < 1 {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum file size is 1MB; got %d", l.MaxFileSizeMB))
 	}
+	if l.RotateInterval != 0 && l.RotateInterval < MinLogRotateInterval {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum rotate interval is %v; got %v", MinLogRotateInterval, l.RotateInterval))
+	}
+	switch l.Compression {
+	case "", LogCompressionGzip, LogCompressionZstd:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid compression %q", l.Compression))
+	}
+
+	// Sink spools are written to the alloc's log directory as well
+	logUsage := (l.MaxFiles * l.MaxFileSizeMB)
+	sinkNames := make(map[string]struct{}, len(l.Sinks))
+	for _, sink := range l.Sinks {
+		if err := sink.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("sink %q: %v", sink.Name, err))
+			continue
+		}
+		if _, ok := sinkNames[sink.Name]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("duplicate sink %q", sink.Name))
+		}
+		sinkNames[sink.Name] = struct{}{}
+		logUsage += sink.SpoolSizeMB
+	}
+	if l.Disabled && len(l.Sinks) > 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("sinks can't be used when logs are disabled"))
+	}
+
 	if disk != nil {
-		logUsage := (l.MaxFiles * l.MaxFileSizeMB)
+		// The log budget caps the log files of all of the alloc's tasks
+		if disk.LogBudgetMB > 0 && disk.LogBudgetMB < logUsage {
+			logUsage = disk.LogBudgetMB
+		}
 		if disk.SizeMB <= logUsage {
 			mErr.Errors = append(mErr.Errors,
 				fmt.Errorf("log storage (%d MB) must be less than requested disk capacity (%d MB)",
@@ -7725,6 +8236,100 @@ func (l *LogConfig) Validate(disk *EphemeralDisk) error {
 	return mErr.ErrorOrNil()
 }
 
+const (
+	// LogCompressionGzip compresses rotated log files with gzip
+	LogCompressionGzip = "gzip"
+
+	// LogCompressionZstd compresses rotated log files with zstd
+	LogCompressionZstd = "zstd"
+
+	// MinLogRotateInterval is the minimum time based rotation interval
+	MinLogRotateInterval = time.Minute
+)
+
+const (
+	// LogSinkTypeSyslog forwards logs as RFC 5424 messages over UDP or TCP
+	LogSinkTypeSyslog = "syslog"
+
+	// LogSinkTypeLoki forwards logs to the push API of Loki
+	LogSinkTypeLoki = "loki"
+
+	// LogSinkTypeOTLP forwards logs to an OTLP/HTTP logs endpoint
+	LogSinkTypeOTLP = "otlp"
+
+	// LogSinkTypeFluent forwards logs using the Fluentd forward protocol
+	LogSinkTypeFluent = "fluent"
+)
+
+// LogSink configures the forwarding of a task's logs to an external system.
+// Log lines are labeled with the task's job, group, allocation and name, and
+// are sent in batches. Batches that can't be sent are spooled to disk and
+// retried, dropping the oldest batches once the spool is full.
+type LogSink struct {
+	// Name uniquely identifies the sink within the task
+	Name string
+
+	// Type is the protocol used to forward logs
+	Type string
+
+	// Address is the URL or socket address of the sink
+	Address string
+
+	// BatchSize is the maximum number of lines sent at once
+	BatchSize int
+
+	// BatchWait is the maximum time lines are buffered before being sent
+	BatchWait time.Duration
+
+	// SpoolSizeMB is the maximum size of the on-disk spool of unsent batches
+	SpoolSizeMB int
+}
+
+func (s *LogSink) Equal(o *LogSink) bool {
+	if s == nil || o == nil {
+		return s == o
+	}
+	return *s == *o
+}
+
+func (s *LogSink) Copy() *LogSink {
+	if s == nil {
+		return nil
+	}
+	ns := new(LogSink)
+	*ns = *s
+	return ns
+}
+
+func (s *LogSink) Validate() error {
+	if s == nil {
+		return errors.New("missing sink")
+	}
+
+	var mErr multierror.Error
+	if s.Name == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing sink name"))
+	}
+	switch s.Type {
+	case LogSinkTypeSyslog, LogSinkTypeLoki, LogSinkTypeOTLP, LogSinkTypeFluent:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid sink type %q", s.Type))
+	}
+	if s.Address == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing sink address"))
+	}
+	if s.BatchSize < 1 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum batch size is 1; got %d", s.BatchSize))
+	}
+	if s.BatchWait <= 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("batch wait must be positive; got %v", s.BatchWait))
+	}
+	if s.SpoolSizeMB < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("spool size can't be negative; got %d", s.SpoolSizeMB))
+	}
+	return mErr.ErrorOrNil()
+}
+
 // Task is a single process typically that is executed as part of a task group.
 type Task struct {
 	// Name of the task
@@ -7827,7 +8432,7 @@ type Task struct {
 	// Alloc-exec-like runnable commands
 	Actions []*Action
 
-	// Schedule for pausing tasks. Enterprise only.
+	// Schedule for pausing tasks.
 	Schedule *TaskSchedule
 }
 
@@ -7866,6 +8471,17 @@ func (t *Task) IsPoststop() bool {
 		t.Lifecycle.Hook == TaskLifecycleHookPoststop
 }
 
+// Image returns the image the task runs from, or an empty string if its driver
+// config has no image or the image is only known once interpolated on the
+// client.
+func (t *Task) Image() string {
+	image, ok := t.Config["image"].(string)
+	if !ok || strings.Contains(image, "${") {
+		return ""
+	}
+	return image
+}
+
 func (t *Task) GetIdentity(name string) *WorkloadIdentity {
 	for _, wid := range t.Identities {
 		if wid.Name == name {
@@ -9002,8 +9618,7 @@ type TaskState struct {
 	// by remote task drivers to migrate task handles between allocations.
 	TaskHandle *TaskHandle
 
-	// Enterprise Only - Paused is set to the paused state of the task. See
-	// task_sched.go
+	// Paused is set to the paused state of the task. See task_sched.go
 	Paused TaskScheduleState
 }
 
@@ -9100,7 +9715,7 @@ const (
 	TaskStarted = "Started"
 
 	// TaskPausing indicates the task is being killed, but will be
-	// started again to await the next start of its task schedule (Enterprise).
+	// started again to await the next start of its task schedule.
 	TaskPausing = "Pausing"
 
 	// TaskTerminated indicates that the task was started and exited.
@@ -9189,8 +9804,20 @@ const (
 	TaskSkippingShutdownDelay = "Skipping shutdown delay"
 
 	// TaskRunning indicates a task is running due to a schedule or schedule
-	// override. (Enterprise)
+	// override.
 	TaskRunning = "Running"
+
+	// TaskCheckpointed indicates the task was checkpointed before being
+	// migrated.
+	TaskCheckpointed = "Checkpointed"
+
+	// TaskRestoredFromCheckpoint indicates the task was restored from the
+	// checkpoint of its previous allocation instead of being started afresh.
+	TaskRestoredFromCheckpoint = "Restored from checkpoint"
+
+	// TaskResourcesUpdated indicates the resource limits of the running task
+	// were changed in place by an allocation update.
+	TaskResourcesUpdated = "Resources Updated"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -10177,6 +10804,11 @@ type EphemeralDisk struct {
 	// Migrate determines if Nomad client should migrate the allocation dir for
 	// sticky allocations
 	Migrate bool
+
+	// LogBudgetMB is the maximum size of the log files of all of the
+	// allocation's tasks. Once exceeded the oldest rotated files are removed.
+	// Zero leaves the log files bounded only by each task's log config.
+	LogBudgetMB int
 }
 
 // DefaultEphemeralDisk returns a EphemeralDisk with default configurations
@@ -10197,6 +10829,8 @@ func (d *EphemeralDisk) Equal(o *EphemeralDisk) bool {
 		return false
 	case d.Migrate != o.Migrate:
 		return false
+	case d.LogBudgetMB != o.LogBudgetMB:
+		return false
 	}
 	return true
 }
@@ -10206,6 +10840,12 @@ func (d *EphemeralDisk) Validate() error {
 	if d.SizeMB < 10 {
 		return fmt.Errorf("minimum DiskMB value is 10; got %d", d.SizeMB)
 	}
+	if d.LogBudgetMB < 0 {
+		return fmt.Errorf("log budget can't be negative; got %d", d.LogBudgetMB)
+	}
+	if d.LogBudgetMB >= d.SizeMB {
+		return fmt.Errorf("log budget (%d MB) must be less than the disk size (%d MB)", d.LogBudgetMB, d.SizeMB)
+	}
 	return nil
 }
 
@@ -10963,6 +11603,10 @@ type Allocation struct {
 	// to stop running because it got preempted
 	PreemptedByAllocation string
 
+	// Preemption records the reason this allocation was preempted and the
+	// preemption budgets it was counted against
+	Preemption *AllocPreemption
+
 	// SignedIdentities is a map of task names to signed identity/capability
 	// claim tokens for those tasks. If needed, it is populated in the plan
 	// applier.
@@ -11146,6 +11790,7 @@ func (a *Allocation) copyImpl(job bool) *Allocation {
 
 	na.RescheduleTracker = a.RescheduleTracker.Copy()
 	na.PreemptedAllocations = slices.Clone(a.PreemptedAllocations)
+	na.Preemption = a.Preemption.Copy()
 	return na
 }
 
@@ -11661,7 +12306,7 @@ func (a *Allocation) LastStartOfTask(taskName string) time.Time {
 }
 
 // HasAnyPausedTasks returns true if any of the TaskStates on the alloc
-// are Paused (Enterprise feature) either due to a schedule or being forced.
+// are Paused either due to a schedule or being forced.
 func (a *Allocation) HasAnyPausedTasks() bool {
 	if a == nil {
 		return false
@@ -12683,7 +13328,8 @@ func (e *Evaluation) MakePlan(j *Job) *Plan {
 		NodePreemptions: make(map[string][]*Allocation),
 	}
 	if j != nil {
-		p.AllAtOnce = j.AllAtOnce
+		// A partially applied plan would break a gang up
+		p.AllAtOnce = j.AllAtOnce || j.Gang
 	}
 	return p
 }
@@ -12930,8 +13576,9 @@ func (p *Plan) AppendStoppedAlloc(alloc *Allocation, desiredDesc, clientStatus,
 }
 
 // AppendPreemptedAlloc is used to append an allocation that's being preempted to the plan.
-// To minimize the size of the plan, this only sets a minimal set of fields in the allocation
-func (p *Plan) AppendPreemptedAlloc(alloc *Allocation, preemptingAllocID string) {
+// To minimize the size of the plan, this only sets a minimal set of fields in the allocation.
+// The allocation added to the plan is returned.
+func (p *Plan) AppendPreemptedAlloc(alloc *Allocation, preemptingAllocID string) *Allocation {
 	newAlloc := &Allocation{}
 	newAlloc.ID = alloc.ID
 	newAlloc.JobID = alloc.JobID
@@ -12956,6 +13603,7 @@ func (p *Plan) AppendPreemptedAlloc(alloc *Allocation, preemptingAllocID string)
 	node := alloc.NodeID
 	existing := p.NodePreemptions[node]
 	p.NodePreemptions[node] = append(existing, newAlloc)
+	return newAlloc
 }
 
 // AppendUnknownAlloc marks an allocation as unknown.
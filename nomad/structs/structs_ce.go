@@ -42,12 +42,66 @@ func (n *NamespaceConsulConfiguration) Validate() error {
 	return nil
 }
 
+// Validate checks that the multiregion block is well-formed for the given job
+// type and job-level datacenters.
 func (m *Multiregion) Validate(jobType string, jobDatacenters []string) error {
-	if m != nil {
-		return errors.New("Multiregion jobs are unlicensed.")
+	if m == nil {
+		return nil
 	}
 
-	return nil
+	var mErr multierror.Error
+
+	if jobType == JobTypeSysBatch {
+		mErr.Errors = append(mErr.Errors,
+			fmt.Errorf("Multiregion jobs cannot be of type %q", jobType))
+	}
+
+	if m.Strategy != nil {
+		if m.Strategy.MaxParallel < 0 {
+			mErr.Errors = append(mErr.Errors,
+				errors.New("Multiregion max_parallel must be non-negative"))
+		}
+		switch m.Strategy.OnFailure {
+		case "", MultiregionOnFailureFailAll, MultiregionOnFailureFailLocal:
+		default:
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("Multiregion on_failure %q is not valid", m.Strategy.OnFailure))
+		}
+	}
+
+	if len(m.Regions) == 0 {
+		mErr.Errors = append(mErr.Errors,
+			errors.New("Multiregion jobs must have at least one region"))
+	}
+
+	seen := make(map[string]struct{}, len(m.Regions))
+	for i, region := range m.Regions {
+		if region.Name == "" {
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("Multiregion region %d is missing a name", i+1))
+			continue
+		}
+		if region.Name == MultiregionGlobalRegion {
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("Multiregion region cannot be named %q", MultiregionGlobalRegion))
+		}
+		if _, ok := seen[region.Name]; ok {
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("Multiregion region %q defined more than once", region.Name))
+		}
+		seen[region.Name] = struct{}{}
+
+		if region.Count < 0 {
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("Multiregion region %q count must be non-negative", region.Name))
+		}
+		if len(region.Datacenters) == 0 && len(jobDatacenters) == 0 {
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("Multiregion region %q must have at least one datacenter", region.Name))
+		}
+	}
+
+	return mErr.ErrorOrNil()
 }
 
 func (p *ScalingPolicy) validateType() multierror.Error {
@@ -64,3 +64,79 @@ func TestNamespace_Validate_Oss(t *testing.T) {
 		})
 	}
 }
+
+func TestMultiregion_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		multiregion *Multiregion
+		jobType     string
+		datacenters []string
+		expErr      []string
+	}{
+		{
+			name: "valid",
+			multiregion: &Multiregion{
+				Strategy: &MultiregionStrategy{MaxParallel: 1, OnFailure: MultiregionOnFailureFailAll},
+				Regions: []*MultiregionRegion{
+					{Name: "west", Count: 2},
+					{Name: "east", Datacenters: []string{"east-1"}},
+				},
+			},
+			jobType:     JobTypeService,
+			datacenters: []string{"dc1"},
+		},
+		{
+			name: "invalid strategy",
+			multiregion: &Multiregion{
+				Strategy: &MultiregionStrategy{MaxParallel: -1, OnFailure: "fail_some"},
+				Regions:  []*MultiregionRegion{{Name: "west"}},
+			},
+			jobType:     JobTypeService,
+			datacenters: []string{"dc1"},
+			expErr: []string{
+				"max_parallel must be non-negative",
+				`on_failure "fail_some" is not valid`,
+			},
+		},
+		{
+			name: "invalid regions",
+			multiregion: &Multiregion{
+				Regions: []*MultiregionRegion{
+					{Name: "west", Count: -1},
+					{Name: "west", Datacenters: []string{"west-1"}},
+					{Name: "global", Datacenters: []string{"dc1"}},
+					{},
+				},
+			},
+			jobType: JobTypeSysBatch,
+			expErr: []string{
+				`cannot be of type "sysbatch"`,
+				`region "west" count must be non-negative`,
+				`region "west" must have at least one datacenter`,
+				`region "west" defined more than once`,
+				`region cannot be named "global"`,
+				"region 4 is missing a name",
+			},
+		},
+		{
+			name:        "no regions",
+			multiregion: &Multiregion{},
+			jobType:     JobTypeService,
+			datacenters: []string{"dc1"},
+			expErr:      []string{"at least one region"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.multiregion.Validate(tc.jobType, tc.datacenters)
+			if len(tc.expErr) == 0 {
+				must.NoError(t, err)
+				return
+			}
+			requireErrors(t, err, tc.expErr...)
+		})
+	}
+}
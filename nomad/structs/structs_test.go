@@ -353,6 +353,28 @@ func TestJob_Validate(t *testing.T) {
 				"UI description must be under 1000 characters",
 			},
 		},
+		{
+			name: "job gang with system type",
+			job: &Job{
+				Type: JobTypeSystem,
+				Gang: true,
+			},
+			expErr: []string{
+				"Gang placement is only supported for service and batch jobs",
+			},
+		},
+		{
+			name: "job preemption budget without window",
+			job: &Job{
+				PreemptionBudget: &PreemptionBudget{
+					MaxPreemptions: 3,
+				},
+			},
+			expErr: []string{
+				"Preemption budget validation failed",
+				"window must be set when max_preemptions is set",
+			},
+		},
 		{
 			name: "job task group is type invalid",
 			job: &Job{
@@ -409,6 +431,56 @@ func TestJob_Validate(t *testing.T) {
 
 }
 
+func TestJob_InterpolateRegion(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &Job{
+		ID:          "example",
+		Region:      MultiregionGlobalRegion,
+		Datacenters: []string{"dc1"},
+		NodePool:    NodePoolDefault,
+		Meta:        map[string]string{"first": "job", "second": "job"},
+		Multiregion: &Multiregion{
+			Regions: []*MultiregionRegion{
+				{
+					Name:        "west",
+					Count:       2,
+					Datacenters: []string{"west-1"},
+					NodePool:    "gpu",
+					Meta:        map[string]string{"first": "west"},
+				},
+				{Name: "east", Count: 5},
+			},
+		},
+		TaskGroups: []*TaskGroup{
+			{Name: "worker", Count: 0},
+			{Name: "controller", Count: 1},
+		},
+	}
+
+	west := job.Copy()
+	must.NoError(t, west.InterpolateRegion("west"))
+	must.Eq(t, "west", west.Region)
+	must.Eq(t, []string{"west-1"}, west.Datacenters)
+	must.Eq(t, "gpu", west.NodePool)
+	must.Eq(t, map[string]string{"first": "west", "second": "job"}, west.Meta)
+	must.Eq(t, 2, west.TaskGroups[0].Count)
+	must.Eq(t, 1, west.TaskGroups[1].Count)
+
+	east := job.Copy()
+	must.NoError(t, east.InterpolateRegion("east"))
+	must.Eq(t, "east", east.Region)
+	must.Eq(t, []string{"dc1"}, east.Datacenters)
+	must.Eq(t, NodePoolDefault, east.NodePool)
+	must.Eq(t, 5, east.TaskGroups[0].Count)
+
+	// The original job is not modified
+	must.Eq(t, MultiregionGlobalRegion, job.Region)
+	must.Eq(t, 0, job.TaskGroups[0].Count)
+
+	must.ErrorContains(t, job.Copy().InterpolateRegion("north"), `region "north" is not part of`)
+}
+
 func TestJob_ValidateScaling(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1753,6 +1825,26 @@ func TestTaskGroup_Validate(t *testing.T) {
 			},
 			jobType: JobTypeService,
 		},
+		{
+			name: "migrate checkpoint without ephemeral disk migration",
+			tg: &TaskGroup{
+				Migrate: &MigrateStrategy{Checkpoint: true},
+			},
+			expErr: []string{
+				"Migrate checkpoint requires ephemeral_disk migrate to be enabled",
+			},
+			jobType: JobTypeBatch,
+		},
+		{
+			name: "batch migrate block without checkpoint",
+			tg: &TaskGroup{
+				Migrate: DefaultMigrateStrategy(),
+			},
+			expErr: []string{
+				`Job type "batch" only allows migrate block to enable checkpoint`,
+			},
+			jobType: JobTypeBatch,
+		},
 	}
 
 	for _, tc := range tests {
@@ -2276,6 +2368,44 @@ func TestTask_Validate_Resources(t *testing.T) {
 				MemoryMaxMB: -1,
 			},
 		},
+		{
+			name: "io limits",
+			res: &Resources{
+				CPU:                100,
+				MemoryMB:           200,
+				IOWeight:           500,
+				ReadBps:            10 * BytesInMegabyte,
+				WriteBps:           5 * BytesInMegabyte,
+				NetworkEgressMBits: 100,
+			},
+		},
+		{
+			name: "io weight out of range",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				IOWeight: 5,
+			},
+			err: "IOWeight value (5) must be between 10 and 1000",
+		},
+		{
+			name: "negative write bps",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				WriteBps: -1,
+			},
+			err: "WriteBps value (-1) cannot be negative",
+		},
+		{
+			name: "negative network egress",
+			res: &Resources{
+				CPU:                100,
+				MemoryMB:           200,
+				NetworkEgressMBits: -1,
+			},
+			err: "NetworkEgressMBits value (-1) cannot be negative",
+		},
 	}
 
 	for i := range cases {
@@ -3133,6 +3263,62 @@ func TestLogConfig_Equals(t *testing.T) {
 	})
 }
 
+func TestLogConfig_Validate_Sinks(t *testing.T) {
+	ci.Parallel(t)
+
+	sink := &LogSink{
+		Name:        "loki",
+		Type:        LogSinkTypeLoki,
+		Address:     "http://127.0.0.1:3100",
+		BatchSize:   100,
+		BatchWait:   time.Second,
+		SpoolSizeMB: 10,
+	}
+	l := DefaultLogConfig()
+	l.Sinks = []*LogSink{sink}
+	must.NoError(t, l.Validate(&EphemeralDisk{SizeMB: 300}))
+
+	// Spools count towards the log storage
+	must.ErrorContains(t, l.Validate(&EphemeralDisk{SizeMB: 105}), "log storage (110 MB)")
+
+	l.Sinks = []*LogSink{sink, sink.Copy()}
+	must.ErrorContains(t, l.Validate(nil), "duplicate sink")
+
+	bad := sink.Copy()
+	bad.Type = "kafka"
+	bad.BatchSize = 0
+	l.Sinks = []*LogSink{bad}
+	err := l.Validate(nil)
+	must.ErrorContains(t, err, `invalid sink type "kafka"`)
+	must.ErrorContains(t, err, "minimum batch size is 1")
+
+	must.False(t, l.Equal(&LogConfig{MaxFiles: l.MaxFiles, MaxFileSizeMB: l.MaxFileSizeMB}))
+	must.True(t, l.Equal(l.Copy()))
+}
+
+func TestLogConfig_Validate_Rotation(t *testing.T) {
+	ci.Parallel(t)
+
+	l := DefaultLogConfig()
+	l.RotateInterval = 24 * time.Hour
+	l.Compression = LogCompressionZstd
+	must.NoError(t, l.Validate(&EphemeralDisk{SizeMB: 300}))
+
+	l.RotateInterval = time.Second
+	l.Compression = "lz4"
+	err := l.Validate(nil)
+	must.ErrorContains(t, err, "minimum rotate interval is 1m0s")
+	must.ErrorContains(t, err, `invalid compression "lz4"`)
+
+	// The alloc's log budget caps the log storage
+	l = DefaultLogConfig()
+	must.ErrorContains(t, l.Validate(&EphemeralDisk{SizeMB: 50}), "log storage (100 MB)")
+	must.NoError(t, l.Validate(&EphemeralDisk{SizeMB: 50, LogBudgetMB: 40}))
+
+	must.ErrorContains(t, (&EphemeralDisk{SizeMB: 50, LogBudgetMB: 50}).Validate(), "log budget (50 MB)")
+	must.ErrorContains(t, (&EphemeralDisk{SizeMB: 50, LogBudgetMB: -1}).Validate(), "can't be negative")
+}
+
 func TestTask_Validate_CSIPluginConfig(t *testing.T) {
 	ci.Parallel(t)
 
@@ -7575,6 +7761,7 @@ func TestComparableResources_Superset(t *testing.T) {
 				ReservedCores: []uint16{0, 1, 2, 3},
 			},
 			Memory: AllocatedMemoryResources{MemoryMB: 4096},
+			IO:     AllocatedIOResources{NetworkEgressMBits: 1000},
 		},
 		Shared: AllocatedSharedResources{DiskMB: 10000},
 	}
@@ -7617,6 +7804,23 @@ func TestComparableResources_Superset(t *testing.T) {
 			},
 			dimension: "cores",
 		},
+		{
+			a: base,
+			b: &ComparableResources{
+				Flattened: AllocatedTaskResources{
+					IO: AllocatedIOResources{NetworkEgressMBits: 1000},
+				},
+			},
+		},
+		{
+			a: base,
+			b: &ComparableResources{
+				Flattened: AllocatedTaskResources{
+					IO: AllocatedIOResources{NetworkEgressMBits: 1001},
+				},
+			},
+			dimension: "network bandwidth",
+		},
 	}
 
 	for _, c := range cases {
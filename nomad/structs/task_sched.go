@@ -12,7 +12,7 @@ import (
 	"github.com/hashicorp/cronexpr"
 )
 
-// TaskScheduleState represents the scheduled execution state of a task (Enterprise)
+// TaskScheduleState represents the scheduled execution state of a task
 type TaskScheduleState string
 
 func (t TaskScheduleState) Stop() bool {
@@ -56,8 +56,6 @@ const (
 )
 
 // TaskSchedule allows specifying a time based execution schedule for tasks.
-//
-// Enterprise only.
 type TaskSchedule struct {
 	Cron *TaskScheduleCron
 }
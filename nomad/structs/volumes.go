@@ -31,6 +31,10 @@ type ClientHostVolumeConfig struct {
 	Name     string `hcl:",key"`
 	Path     string `hcl:"path"`
 	ReadOnly bool   `hcl:"read_only"`
+
+	// ID is the ID of the dynamic host volume, and is empty for host volumes
+	// declared in the client configuration.
+	ID string `hcl:"-"`
 }
 
 func (p *ClientHostVolumeConfig) Copy() *ClientHostVolumeConfig {
@@ -82,6 +82,7 @@ func (d *driverPluginClient) Capabilities() (*Capabilities, error) {
 		caps.RemoteTasks = resp.Capabilities.RemoteTasks
 		caps.DisableLogCollection = resp.Capabilities.DisableLogCollection
 		caps.DynamicWorkloadUsers = resp.Capabilities.DynamicWorkloadUsers
+		caps.Checkpoint = resp.Capabilities.Checkpoint
 	}
 
 	return caps, nil
@@ -510,3 +511,75 @@ func (d *driverPluginClient) DestroyNetwork(allocID string, spec *NetworkIsolati
 
 	return nil
 }
+
+var _ ResourceUpdateDriver = (*driverPluginClient)(nil)
+
+// UpdateResources changes the resource limits of the running task in place
+func (d *driverPluginClient) UpdateResources(taskID string, resources *Resources) error {
+	req := &proto.UpdateTaskResourcesRequest{
+		TaskId:    taskID,
+		Resources: ResourcesToProto(resources),
+	}
+
+	_, err := d.client.UpdateTaskResources(d.doneCtx, req)
+	return grpcutils.HandleGrpcErr(err, d.doneCtx)
+}
+
+var _ ImagePrefetchDriver = (*driverPluginClient)(nil)
+
+// PrefetchImage starts pulling the image ahead of the tasks that use it
+func (d *driverPluginClient) PrefetchImage(image string) error {
+	req := &proto.PrefetchImageRequest{
+		Image: image,
+	}
+
+	_, err := d.client.PrefetchImage(d.doneCtx, req)
+	return grpcutils.HandleGrpcErr(err, d.doneCtx)
+}
+
+var _ CheckpointDriver = (*driverPluginClient)(nil)
+
+// CheckpointTask dumps the state of the running task into the given directory
+func (d *driverPluginClient) CheckpointTask(taskID string, dir string) error {
+	req := &proto.CheckpointTaskRequest{
+		TaskId: taskID,
+		Dir:    dir,
+	}
+
+	_, err := d.client.CheckpointTask(d.doneCtx, req)
+	return grpcutils.HandleGrpcErr(err, d.doneCtx)
+}
+
+// RestoreTask starts the task from the state previously dumped into the given
+// directory by CheckpointTask
+func (d *driverPluginClient) RestoreTask(c *TaskConfig, dir string) (*TaskHandle, *DriverNetwork, error) {
+	req := &proto.RestoreTaskRequest{
+		Task: taskConfigToProto(c),
+		Dir:  dir,
+	}
+
+	resp, err := d.client.RestoreTask(d.doneCtx, req)
+	if err != nil {
+		st := status.Convert(err)
+		if len(st.Details()) > 0 {
+			if rec, ok := st.Details()[0].(*sproto.RecoverableError); ok {
+				return nil, nil, structs.NewRecoverableError(err, rec.Recoverable)
+			}
+		}
+		return nil, nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	var net *DriverNetwork
+	if resp.NetworkOverride != nil {
+		net = &DriverNetwork{
+			PortMap:       map[string]int{},
+			IP:            resp.NetworkOverride.Addr,
+			AutoAdvertise: resp.NetworkOverride.AutoAdvertise,
+		}
+		for k, v := range resp.NetworkOverride.PortMap {
+			net.PortMap[k] = int(v)
+		}
+	}
+
+	return taskHandleFromProto(resp.Handle), net, nil
+}
@@ -98,6 +98,38 @@ type DriverNetworkManager interface {
 	DestroyNetwork(allocID string, spec *NetworkIsolationSpec) error
 }
 
+// CheckpointDriver is the interface implemented by drivers able to checkpoint
+// the process tree of a running task to disk and restore it later, possibly on
+// another node. The client only uses it when the driver also sets the
+// Checkpoint capability.
+type CheckpointDriver interface {
+	// CheckpointTask dumps the state of the task into the given directory.
+	// The task is stopped once checkpointed.
+	CheckpointTask(taskID string, dir string) error
+
+	// RestoreTask starts the task from the state previously dumped into the
+	// given directory by CheckpointTask, instead of starting it afresh.
+	RestoreTask(cfg *TaskConfig, dir string) (*TaskHandle, *DriverNetwork, error)
+}
+
+// ResourceUpdateDriver is the interface implemented by drivers able to change
+// the resource limits of a running task without restarting it. The client
+// restarts the task instead when the driver doesn't implement it or the update
+// fails.
+type ResourceUpdateDriver interface {
+	// UpdateResources applies the given resources to the running task.
+	UpdateResources(taskID string, resources *Resources) error
+}
+
+// ImagePrefetchDriver is the interface implemented by drivers running tasks
+// from images that can pull an image before any task uses it, so that tasks
+// scheduled later on the node start without waiting for the pull.
+type ImagePrefetchDriver interface {
+	// PrefetchImage starts pulling the image in the background. It returns
+	// once the pull is started, errors pulling the image are only logged.
+	PrefetchImage(image string) error
+}
+
 // DriverSignalTaskNotSupported can be embedded by drivers which don't support
 // the SignalTask RPC. This satisfies the SignalTask func requirement for the
 // DriverPlugin interface.
@@ -183,6 +215,11 @@ type Capabilities struct {
 	// The allocation of a unique, not-in-use UID/GID is managed by Nomad client
 	// ensuring no overlap.
 	DynamicWorkloadUsers bool
+
+	// Checkpoint indicates this driver is currently able to checkpoint and
+	// restore tasks. It is only honored for drivers implementing the
+	// CheckpointDriver interface.
+	Checkpoint bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
@@ -1857,7 +1857,10 @@ type DriverCapabilities struct {
 	DisableLogCollection bool `protobuf:"varint,8,opt,name=disable_log_collection,json=disableLogCollection,proto3" json:"disable_log_collection,omitempty"`
 	// dynamic_workload_users indicates the task is capable of using UID/GID
 	// assigned from the Nomad client as user credentials for the task.
-	DynamicWorkloadUsers bool     `protobuf:"varint,9,opt,name=dynamic_workload_users,json=dynamicWorkloadUsers,proto3" json:"dynamic_workload_users,omitempty"`
+	DynamicWorkloadUsers bool `protobuf:"varint,9,opt,name=dynamic_workload_users,json=dynamicWorkloadUsers,proto3" json:"dynamic_workload_users,omitempty"`
+	// checkpoint indicates whether the driver can checkpoint a running task
+	// and restore it from that checkpoint.
+	Checkpoint           bool     `protobuf:"varint,10,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1951,6 +1954,13 @@ func (m *DriverCapabilities) GetDynamicWorkloadUsers() bool {
 	return false
 }
 
+func (m *DriverCapabilities) GetCheckpoint() bool {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return false
+}
+
 type NetworkIsolationSpec struct {
 	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
 	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -2414,6 +2424,7 @@ type AllocatedTaskResources struct {
 	Cpu                  *AllocatedCpuResources    `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
 	Memory               *AllocatedMemoryResources `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
 	Networks             []*NetworkResource        `protobuf:"bytes,5,rep,name=networks,proto3" json:"networks,omitempty"`
+	Io                   *AllocatedIOResources     `protobuf:"bytes,6,opt,name=io,proto3" json:"io,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
 	XXX_sizecache        int32                     `json:"-"`
@@ -2465,6 +2476,13 @@ func (m *AllocatedTaskResources) GetNetworks() []*NetworkResource {
 	return nil
 }
 
+func (m *AllocatedTaskResources) GetIo() *AllocatedIOResources {
+	if m != nil {
+		return m.Io
+	}
+	return nil
+}
+
 type AllocatedCpuResources struct {
 	CpuShares            int64    `protobuf:"varint,1,opt,name=cpu_shares,json=cpuShares,proto3" json:"cpu_shares,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -3685,6 +3703,400 @@ func (m *DriverTaskEvent) GetAnnotations() map[string]string {
 	return nil
 }
 
+type AllocatedIOResources struct {
+	Weight               int64    `protobuf:"varint,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	ReadBps              int64    `protobuf:"varint,2,opt,name=read_bps,json=readBps,proto3" json:"read_bps,omitempty"`
+	WriteBps             int64    `protobuf:"varint,3,opt,name=write_bps,json=writeBps,proto3" json:"write_bps,omitempty"`
+	NetworkEgressMbits   int64    `protobuf:"varint,4,opt,name=network_egress_mbits,json=networkEgressMbits,proto3" json:"network_egress_mbits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AllocatedIOResources) Reset()         { *m = AllocatedIOResources{} }
+func (m *AllocatedIOResources) String() string { return proto.CompactTextString(m) }
+func (*AllocatedIOResources) ProtoMessage()    {}
+func (*AllocatedIOResources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{57}
+}
+
+func (m *AllocatedIOResources) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AllocatedIOResources.Unmarshal(m, b)
+}
+func (m *AllocatedIOResources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AllocatedIOResources.Marshal(b, m, deterministic)
+}
+func (m *AllocatedIOResources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocatedIOResources.Merge(m, src)
+}
+func (m *AllocatedIOResources) XXX_Size() int {
+	return xxx_messageInfo_AllocatedIOResources.Size(m)
+}
+func (m *AllocatedIOResources) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocatedIOResources.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllocatedIOResources proto.InternalMessageInfo
+
+func (m *AllocatedIOResources) GetWeight() int64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *AllocatedIOResources) GetReadBps() int64 {
+	if m != nil {
+		return m.ReadBps
+	}
+	return 0
+}
+
+func (m *AllocatedIOResources) GetWriteBps() int64 {
+	if m != nil {
+		return m.WriteBps
+	}
+	return 0
+}
+
+func (m *AllocatedIOResources) GetNetworkEgressMbits() int64 {
+	if m != nil {
+		return m.NetworkEgressMbits
+	}
+	return 0
+}
+
+type UpdateTaskResourcesRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Resources are the new resources of the task
+	Resources            *Resources `protobuf:"bytes,2,opt,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UpdateTaskResourcesRequest) Reset()         { *m = UpdateTaskResourcesRequest{} }
+func (m *UpdateTaskResourcesRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateTaskResourcesRequest) ProtoMessage()    {}
+func (*UpdateTaskResourcesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{58}
+}
+
+func (m *UpdateTaskResourcesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Unmarshal(m, b)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTaskResourcesRequest.Merge(m, src)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Size(m)
+}
+func (m *UpdateTaskResourcesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTaskResourcesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTaskResourcesRequest proto.InternalMessageInfo
+
+func (m *UpdateTaskResourcesRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *UpdateTaskResourcesRequest) GetResources() *Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type UpdateTaskResourcesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateTaskResourcesResponse) Reset()         { *m = UpdateTaskResourcesResponse{} }
+func (m *UpdateTaskResourcesResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateTaskResourcesResponse) ProtoMessage()    {}
+func (*UpdateTaskResourcesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{59}
+}
+
+func (m *UpdateTaskResourcesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Unmarshal(m, b)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTaskResourcesResponse.Merge(m, src)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Size(m)
+}
+func (m *UpdateTaskResourcesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTaskResourcesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTaskResourcesResponse proto.InternalMessageInfo
+
+type PrefetchImageRequest struct {
+	// Image is the name of the image to pull
+	Image                string   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrefetchImageRequest) Reset()         { *m = PrefetchImageRequest{} }
+func (m *PrefetchImageRequest) String() string { return proto.CompactTextString(m) }
+func (*PrefetchImageRequest) ProtoMessage()    {}
+func (*PrefetchImageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{60}
+}
+
+func (m *PrefetchImageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefetchImageRequest.Unmarshal(m, b)
+}
+func (m *PrefetchImageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefetchImageRequest.Marshal(b, m, deterministic)
+}
+func (m *PrefetchImageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefetchImageRequest.Merge(m, src)
+}
+func (m *PrefetchImageRequest) XXX_Size() int {
+	return xxx_messageInfo_PrefetchImageRequest.Size(m)
+}
+func (m *PrefetchImageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefetchImageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefetchImageRequest proto.InternalMessageInfo
+
+func (m *PrefetchImageRequest) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+type PrefetchImageResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrefetchImageResponse) Reset()         { *m = PrefetchImageResponse{} }
+func (m *PrefetchImageResponse) String() string { return proto.CompactTextString(m) }
+func (*PrefetchImageResponse) ProtoMessage()    {}
+func (*PrefetchImageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{61}
+}
+
+func (m *PrefetchImageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefetchImageResponse.Unmarshal(m, b)
+}
+func (m *PrefetchImageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefetchImageResponse.Marshal(b, m, deterministic)
+}
+func (m *PrefetchImageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefetchImageResponse.Merge(m, src)
+}
+func (m *PrefetchImageResponse) XXX_Size() int {
+	return xxx_messageInfo_PrefetchImageResponse.Size(m)
+}
+func (m *PrefetchImageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefetchImageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefetchImageResponse proto.InternalMessageInfo
+
+type CheckpointTaskRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Dir is the directory the task state is dumped into
+	Dir                  string   `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskRequest) Reset()         { *m = CheckpointTaskRequest{} }
+func (m *CheckpointTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskRequest) ProtoMessage()    {}
+func (*CheckpointTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{62}
+}
+
+func (m *CheckpointTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskRequest.Unmarshal(m, b)
+}
+func (m *CheckpointTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskRequest.Merge(m, src)
+}
+func (m *CheckpointTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskRequest.Size(m)
+}
+func (m *CheckpointTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskRequest proto.InternalMessageInfo
+
+func (m *CheckpointTaskRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *CheckpointTaskRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+type CheckpointTaskResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskResponse) Reset()         { *m = CheckpointTaskResponse{} }
+func (m *CheckpointTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskResponse) ProtoMessage()    {}
+func (*CheckpointTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{63}
+}
+
+func (m *CheckpointTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskResponse.Unmarshal(m, b)
+}
+func (m *CheckpointTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskResponse.Merge(m, src)
+}
+func (m *CheckpointTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskResponse.Size(m)
+}
+func (m *CheckpointTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskResponse proto.InternalMessageInfo
+
+type RestoreTaskRequest struct {
+	// Task is the configuration of the task to restore
+	Task *TaskConfig `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Dir is the directory holding the state dumped by CheckpointTask
+	Dir                  string   `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTaskRequest) Reset()         { *m = RestoreTaskRequest{} }
+func (m *RestoreTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskRequest) ProtoMessage()    {}
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{64}
+}
+
+func (m *RestoreTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskRequest.Unmarshal(m, b)
+}
+func (m *RestoreTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskRequest.Merge(m, src)
+}
+func (m *RestoreTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskRequest.Size(m)
+}
+func (m *RestoreTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskRequest proto.InternalMessageInfo
+
+func (m *RestoreTaskRequest) GetTask() *TaskConfig {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *RestoreTaskRequest) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+type RestoreTaskResponse struct {
+	// Handle is opaque to the client, but must be stored in order to recover
+	// the task.
+	Handle *TaskHandle `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	// NetworkOverride is set if the driver sets network settings and the service ip/port
+	// needs to be set differently.
+	NetworkOverride      *NetworkOverride `protobuf:"bytes,2,opt,name=network_override,json=networkOverride,proto3" json:"network_override,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RestoreTaskResponse) Reset()         { *m = RestoreTaskResponse{} }
+func (m *RestoreTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskResponse) ProtoMessage()    {}
+func (*RestoreTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{65}
+}
+
+func (m *RestoreTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskResponse.Unmarshal(m, b)
+}
+func (m *RestoreTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskResponse.Merge(m, src)
+}
+func (m *RestoreTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskResponse.Size(m)
+}
+func (m *RestoreTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskResponse proto.InternalMessageInfo
+
+func (m *RestoreTaskResponse) GetHandle() *TaskHandle {
+	if m != nil {
+		return m.Handle
+	}
+	return nil
+}
+
+func (m *RestoreTaskResponse) GetNetworkOverride() *NetworkOverride {
+	if m != nil {
+		return m.NetworkOverride
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.TaskState", TaskState_name, TaskState_value)
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.FingerprintResponse_HealthState", FingerprintResponse_HealthState_name, FingerprintResponse_HealthState_value)
@@ -3761,6 +4173,15 @@ func init() {
 	proto.RegisterType((*MemoryUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.MemoryUsage")
 	proto.RegisterType((*DriverTaskEvent)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent.AnnotationsEntry")
+	proto.RegisterType((*AllocatedIOResources)(nil), "hashicorp.nomad.plugins.drivers.proto.AllocatedIOResources")
+	proto.RegisterType((*UpdateTaskResourcesRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.UpdateTaskResourcesRequest")
+	proto.RegisterType((*UpdateTaskResourcesResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.UpdateTaskResourcesResponse")
+	proto.RegisterType((*PrefetchImageRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.PrefetchImageRequest")
+	proto.RegisterType((*PrefetchImageResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.PrefetchImageResponse")
+	proto.RegisterType((*CheckpointTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskRequest")
+	proto.RegisterType((*CheckpointTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskResponse")
+	proto.RegisterType((*RestoreTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskRequest")
+	proto.RegisterType((*RestoreTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskResponse")
 }
 
 func init() {
@@ -3768,254 +4189,272 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3945 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4f, 0x73, 0xdb, 0x48,
-	0x76, 0x17, 0x08, 0x92, 0x22, 0x1f, 0x25, 0x0a, 0x6a, 0x49, 0x36, 0xcd, 0xd9, 0x64, 0xbc, 0xd8,
-	0x9a, 0x94, 0xb2, 0x3b, 0x43, 0xcf, 0x6a, 0x93, 0xf1, 0xd8, 0xeb, 0x59, 0x0f, 0x87, 0xa2, 0x2d,
-	0xda, 0x12, 0xa5, 0x34, 0xa9, 0x78, 0x1d, 0x27, 0x83, 0x40, 0x40, 0x9b, 0x82, 0x45, 0x02, 0x18,
-	0x34, 0x28, 0x4b, 0x9b, 0x4a, 0x25, 0xb5, 0xa9, 0x4a, 0x6d, 0xaa, 0x92, 0x4a, 0x2e, 0x93, 0xbd,
-	0xe4, 0xb4, 0x55, 0x39, 0xa5, 0x72, 0x4f, 0x6d, 0x6a, 0x4e, 0x39, 0xe4, 0x4b, 0xe4, 0x92, 0x5b,
-	0x6e, 0xa9, 0x7c, 0x82, 0x6c, 0xf5, 0x1f, 0x80, 0x80, 0x48, 0x8f, 0x41, 0xca, 0x27, 0xf2, 0xbd,
-	0xee, 0xfe, 0xf5, 0xc3, 0x7b, 0xaf, 0x5f, 0xbf, 0xee, 0x7e, 0xa0, 0xfb, 0xc3, 0xf1, 0xc0, 0x71,
-	0xe9, 0x1d, 0x3b, 0x70, 0xce, 0x49, 0x40, 0xef, 0xf8, 0x81, 0x17, 0x7a, 0x92, 0x6a, 0x70, 0x02,
-	0x7d, 0x70, 0x6a, 0xd2, 0x53, 0xc7, 0xf2, 0x02, 0xbf, 0xe1, 0x7a, 0x23, 0xd3, 0x6e, 0xc8, 0x31,
-	0x0d, 0x39, 0x46, 0x74, 0xab, 0xff, 0xf6, 0xc0, 0xf3, 0x06, 0x43, 0x22, 0x10, 0x4e, 0xc6, 0x2f,
-	0xef, 0xd8, 0xe3, 0xc0, 0x0c, 0x1d, 0xcf, 0x95, 0xed, 0xef, 0x5f, 0x6d, 0x0f, 0x9d, 0x11, 0xa1,
-	0xa1, 0x39, 0xf2, 0x65, 0x87, 0x0f, 0x22, 0x59, 0xe8, 0xa9, 0x19, 0x10, 0xfb, 0xce, 0xa9, 0x35,
-	0xa4, 0x3e, 0xb1, 0xd8, 0xaf, 0xc1, 0xfe, 0xc8, 0x6e, 0x1f, 0x5e, 0xe9, 0x46, 0xc3, 0x60, 0x6c,
-	0x85, 0x91, 0xe4, 0x66, 0x18, 0x06, 0xce, 0xc9, 0x38, 0x24, 0xa2, 0xb7, 0x7e, 0x0b, 0x6e, 0xf6,
-	0x4d, 0x7a, 0xd6, 0xf2, 0xdc, 0x97, 0xce, 0xa0, 0x67, 0x9d, 0x92, 0x91, 0x89, 0xc9, 0x57, 0x63,
-	0x42, 0x43, 0xfd, 0x8f, 0xa1, 0x36, 0xdd, 0x44, 0x7d, 0xcf, 0xa5, 0x04, 0x7d, 0x0e, 0x79, 0x36,
-	0x65, 0x4d, 0xb9, 0xad, 0x6c, 0x57, 0x76, 0x3e, 0x6c, 0xbc, 0x49, 0x05, 0x42, 0x86, 0x86, 0x14,
-	0xb5, 0xd1, 0xf3, 0x89, 0x85, 0xf9, 0x48, 0x7d, 0x0b, 0x36, 0x5a, 0xa6, 0x6f, 0x9e, 0x38, 0x43,
-	0x27, 0x74, 0x08, 0x8d, 0x26, 0x1d, 0xc3, 0x66, 0x9a, 0x2d, 0x27, 0xfc, 0x13, 0x58, 0xb1, 0x12,
-	0x7c, 0x39, 0xf1, 0xbd, 0x46, 0x26, 0xdd, 0x37, 0x76, 0x39, 0x95, 0x02, 0x4e, 0xc1, 0xe9, 0x9b,
-	0x80, 0x1e, 0x39, 0xee, 0x80, 0x04, 0x7e, 0xe0, 0xb8, 0x61, 0x24, 0xcc, 0x37, 0x2a, 0x6c, 0xa4,
-	0xd8, 0x52, 0x98, 0x57, 0x00, 0xb1, 0x1e, 0x99, 0x28, 0xea, 0x76, 0x65, 0xe7, 0x49, 0x46, 0x51,
-	0x66, 0xe0, 0x35, 0x9a, 0x31, 0x58, 0xdb, 0x0d, 0x83, 0x4b, 0x9c, 0x40, 0x47, 0x5f, 0x42, 0xf1,
-	0x94, 0x98, 0xc3, 0xf0, 0xb4, 0x96, 0xbb, 0xad, 0x6c, 0x57, 0x77, 0x1e, 0x5d, 0x63, 0x9e, 0x3d,
-	0x0e, 0xd4, 0x0b, 0xcd, 0x90, 0x60, 0x89, 0x8a, 0x3e, 0x02, 0x24, 0xfe, 0x19, 0x36, 0xa1, 0x56,
-	0xe0, 0xf8, 0xcc, 0x25, 0x6b, 0xea, 0x6d, 0x65, 0xbb, 0x8c, 0xd7, 0x45, 0xcb, 0xee, 0xa4, 0xa1,
-	0xee, 0xc3, 0xda, 0x15, 0x69, 0x91, 0x06, 0xea, 0x19, 0xb9, 0xe4, 0x16, 0x29, 0x63, 0xf6, 0x17,
-	0x3d, 0x86, 0xc2, 0xb9, 0x39, 0x1c, 0x13, 0x2e, 0x72, 0x65, 0xe7, 0x87, 0x6f, 0x73, 0x0f, 0xe9,
-	0xa2, 0x13, 0x3d, 0x60, 0x31, 0xfe, 0x7e, 0xee, 0x53, 0x45, 0xbf, 0x07, 0x95, 0x84, 0xdc, 0xa8,
-	0x0a, 0x70, 0xdc, 0xdd, 0x6d, 0xf7, 0xdb, 0xad, 0x7e, 0x7b, 0x57, 0x5b, 0x42, 0xab, 0x50, 0x3e,
-	0xee, 0xee, 0xb5, 0x9b, 0xfb, 0xfd, 0xbd, 0xe7, 0x9a, 0x82, 0x2a, 0xb0, 0x1c, 0x11, 0x39, 0xfd,
-	0x02, 0x10, 0x26, 0x96, 0x77, 0x4e, 0x02, 0xe6, 0xc8, 0xd2, 0xaa, 0xe8, 0x26, 0x2c, 0x87, 0x26,
-	0x3d, 0x33, 0x1c, 0x5b, 0xca, 0x5c, 0x64, 0x64, 0xc7, 0x46, 0x1d, 0x28, 0x9e, 0x9a, 0xae, 0x3d,
-	0x7c, 0xbb, 0xdc, 0x69, 0x55, 0x33, 0xf0, 0x3d, 0x3e, 0x10, 0x4b, 0x00, 0xe6, 0xdd, 0xa9, 0x99,
-	0x85, 0x01, 0xf4, 0xe7, 0xa0, 0xf5, 0x42, 0x33, 0x08, 0x93, 0xe2, 0xb4, 0x21, 0xcf, 0xe6, 0x97,
-	0x1e, 0x3d, 0xcf, 0x9c, 0x62, 0x65, 0x62, 0x3e, 0x5c, 0xff, 0xbf, 0x1c, 0xac, 0x27, 0xb0, 0xa5,
-	0xa7, 0x3e, 0x83, 0x62, 0x40, 0xe8, 0x78, 0x18, 0x72, 0xf8, 0xea, 0xce, 0xc3, 0x8c, 0xf0, 0x53,
-	0x48, 0x0d, 0xcc, 0x61, 0xb0, 0x84, 0x43, 0xdb, 0xa0, 0x89, 0x11, 0x06, 0x09, 0x02, 0x2f, 0x30,
-	0x46, 0x74, 0xc0, 0xb5, 0x56, 0xc6, 0x55, 0xc1, 0x6f, 0x33, 0xf6, 0x01, 0x1d, 0x24, 0xb4, 0xaa,
-	0x5e, 0x53, 0xab, 0xc8, 0x04, 0xcd, 0x25, 0xe1, 0x6b, 0x2f, 0x38, 0x33, 0x98, 0x6a, 0x03, 0xc7,
-	0x26, 0xb5, 0x3c, 0x07, 0xfd, 0x24, 0x23, 0x68, 0x57, 0x0c, 0x3f, 0x94, 0xa3, 0xf1, 0x9a, 0x9b,
-	0x66, 0xe8, 0x3f, 0x80, 0xa2, 0xf8, 0x52, 0xe6, 0x49, 0xbd, 0xe3, 0x56, 0xab, 0xdd, 0xeb, 0x69,
-	0x4b, 0xa8, 0x0c, 0x05, 0xdc, 0xee, 0x63, 0xe6, 0x61, 0x65, 0x28, 0x3c, 0x6a, 0xf6, 0x9b, 0xfb,
-	0x5a, 0x4e, 0xff, 0x3e, 0xac, 0x3d, 0x33, 0x9d, 0x30, 0x8b, 0x73, 0xe9, 0x1e, 0x68, 0x93, 0xbe,
-	0xd2, 0x3a, 0x9d, 0x94, 0x75, 0xb2, 0xab, 0xa6, 0x7d, 0xe1, 0x84, 0x57, 0xec, 0xa1, 0x81, 0x4a,
-	0x82, 0x40, 0x9a, 0x80, 0xfd, 0xd5, 0x5f, 0xc3, 0x5a, 0x2f, 0xf4, 0xfc, 0x4c, 0x9e, 0xff, 0x23,
-	0x58, 0x66, 0xbb, 0x8d, 0x37, 0x0e, 0xa5, 0xeb, 0xdf, 0x6a, 0x88, 0xdd, 0xa8, 0x11, 0xed, 0x46,
-	0x8d, 0x5d, 0xb9, 0x5b, 0xe1, 0xa8, 0x27, 0xba, 0x01, 0x45, 0xea, 0x0c, 0x5c, 0x73, 0x28, 0xa3,
-	0x85, 0xa4, 0x74, 0xc4, 0x9c, 0x3c, 0x9a, 0x58, 0x3a, 0x7e, 0x0b, 0xd0, 0x2e, 0xa1, 0x61, 0xe0,
-	0x5d, 0x66, 0x92, 0x67, 0x13, 0x0a, 0x2f, 0xbd, 0xc0, 0x12, 0x0b, 0xb1, 0x84, 0x05, 0xc1, 0x16,
-	0x55, 0x0a, 0x44, 0x62, 0x7f, 0x04, 0xa8, 0xe3, 0xb2, 0x3d, 0x25, 0x9b, 0x21, 0xfe, 0x21, 0x07,
-	0x1b, 0xa9, 0xfe, 0xd2, 0x18, 0x8b, 0xaf, 0x43, 0x16, 0x98, 0xc6, 0x54, 0xac, 0x43, 0x74, 0x08,
-	0x45, 0xd1, 0x43, 0x6a, 0xf2, 0xee, 0x1c, 0x40, 0x62, 0x9b, 0x92, 0x70, 0x12, 0x66, 0xa6, 0xd3,
-	0xab, 0xef, 0xd6, 0xe9, 0x5f, 0x83, 0x16, 0x7d, 0x07, 0x7d, 0xab, 0x6d, 0x9e, 0xc0, 0x86, 0xe5,
-	0x0d, 0x87, 0xc4, 0x62, 0xde, 0x60, 0x38, 0x6e, 0x48, 0x82, 0x73, 0x73, 0xf8, 0x76, 0xbf, 0x41,
-	0x93, 0x51, 0x1d, 0x39, 0x48, 0x7f, 0x01, 0xeb, 0x89, 0x89, 0xa5, 0x21, 0x1e, 0x41, 0x81, 0x32,
-	0x86, 0xb4, 0xc4, 0xc7, 0x73, 0x5a, 0x82, 0x62, 0x31, 0x5c, 0xdf, 0x10, 0xe0, 0xed, 0x73, 0xe2,
-	0xc6, 0x9f, 0xa5, 0xef, 0xc2, 0x7a, 0x8f, 0xbb, 0x69, 0x26, 0x3f, 0x9c, 0xb8, 0x78, 0x2e, 0xe5,
-	0xe2, 0x9b, 0x80, 0x92, 0x28, 0xd2, 0x11, 0x2f, 0x61, 0xad, 0x7d, 0x41, 0xac, 0x4c, 0xc8, 0x35,
-	0x58, 0xb6, 0xbc, 0xd1, 0xc8, 0x74, 0xed, 0x5a, 0xee, 0xb6, 0xba, 0x5d, 0xc6, 0x11, 0x99, 0x5c,
-	0x8b, 0x6a, 0xd6, 0xb5, 0xa8, 0xff, 0x9d, 0x02, 0xda, 0x64, 0x6e, 0xa9, 0x48, 0x26, 0x7d, 0x68,
-	0x33, 0x20, 0x36, 0xf7, 0x0a, 0x96, 0x94, 0xe4, 0x47, 0xe1, 0x42, 0xf0, 0x49, 0x10, 0x24, 0xc2,
-	0x91, 0x7a, 0xcd, 0x70, 0xa4, 0xef, 0xc1, 0x77, 0x22, 0x71, 0x7a, 0x61, 0x40, 0xcc, 0x91, 0xe3,
-	0x0e, 0x3a, 0x87, 0x87, 0x3e, 0x11, 0x82, 0x23, 0x04, 0x79, 0xdb, 0x0c, 0x4d, 0x29, 0x18, 0xff,
-	0xcf, 0x16, 0xbd, 0x35, 0xf4, 0x68, 0xbc, 0xe8, 0x39, 0xa1, 0xff, 0xa7, 0x0a, 0xb5, 0x29, 0xa8,
-	0x48, 0xbd, 0x2f, 0xa0, 0x40, 0x49, 0x38, 0xf6, 0xa5, 0xab, 0xb4, 0x33, 0x0b, 0x3c, 0x1b, 0xaf,
-	0xd1, 0x63, 0x60, 0x58, 0x60, 0xa2, 0x01, 0x94, 0xc2, 0xf0, 0xd2, 0xa0, 0xce, 0xcf, 0xa2, 0x84,
-	0x60, 0xff, 0xba, 0xf8, 0x7d, 0x12, 0x8c, 0x1c, 0xd7, 0x1c, 0xf6, 0x9c, 0x9f, 0x11, 0xbc, 0x1c,
-	0x86, 0x97, 0xec, 0x0f, 0x7a, 0xce, 0x1c, 0xde, 0x76, 0x5c, 0xa9, 0xf6, 0xd6, 0xa2, 0xb3, 0x24,
-	0x14, 0x8c, 0x05, 0x62, 0x7d, 0x1f, 0x0a, 0xfc, 0x9b, 0x16, 0x71, 0x44, 0x0d, 0xd4, 0x30, 0xbc,
-	0xe4, 0x42, 0x95, 0x30, 0xfb, 0x5b, 0x7f, 0x00, 0x2b, 0xc9, 0x2f, 0x60, 0x8e, 0x74, 0x4a, 0x9c,
-	0xc1, 0xa9, 0x70, 0xb0, 0x02, 0x96, 0x14, 0xb3, 0xe4, 0x6b, 0xc7, 0x96, 0x29, 0x6b, 0x01, 0x0b,
-	0x42, 0xff, 0xb7, 0x1c, 0xdc, 0x9a, 0xa1, 0x19, 0xe9, 0xac, 0x2f, 0x52, 0xce, 0xfa, 0x8e, 0xb4,
-	0x10, 0x79, 0xfc, 0x8b, 0x94, 0xc7, 0xbf, 0x43, 0x70, 0xb6, 0x6c, 0x6e, 0x40, 0x91, 0x5c, 0x38,
-	0x21, 0xb1, 0xa5, 0xaa, 0x24, 0x95, 0x58, 0x4e, 0xf9, 0xeb, 0x2e, 0xa7, 0x03, 0xd8, 0x6c, 0x05,
-	0xc4, 0x0c, 0x89, 0x0c, 0xe5, 0x91, 0xff, 0xdf, 0x82, 0x92, 0x39, 0x1c, 0x7a, 0xd6, 0xc4, 0xac,
-	0xcb, 0x9c, 0xee, 0xd8, 0xa8, 0x0e, 0xa5, 0x53, 0x8f, 0x86, 0xae, 0x39, 0x22, 0x32, 0x78, 0xc5,
-	0xb4, 0xfe, 0xb5, 0x02, 0x5b, 0x57, 0xf0, 0xa4, 0x15, 0x4e, 0xa0, 0xea, 0x50, 0x6f, 0xc8, 0x3f,
-	0xd0, 0x48, 0x9c, 0xf0, 0x7e, 0x3c, 0xdf, 0x56, 0xd3, 0x89, 0x30, 0xf8, 0x81, 0x6f, 0xd5, 0x49,
-	0x92, 0xdc, 0xe3, 0xf8, 0xe4, 0xb6, 0x5c, 0xe9, 0x11, 0xa9, 0xff, 0xa3, 0x02, 0x5b, 0x72, 0x87,
-	0xcf, 0xfe, 0xa1, 0xd3, 0x22, 0xe7, 0xde, 0xb5, 0xc8, 0x7a, 0x0d, 0x6e, 0x5c, 0x95, 0x4b, 0xc6,
-	0xfc, 0xff, 0x2d, 0x00, 0x9a, 0x3e, 0x5d, 0xa2, 0xef, 0xc2, 0x0a, 0x25, 0xae, 0x6d, 0x88, 0xfd,
-	0x42, 0x6c, 0x65, 0x25, 0x5c, 0x61, 0x3c, 0xb1, 0x71, 0x50, 0x16, 0x02, 0xc9, 0x85, 0x94, 0xb6,
-	0x84, 0xf9, 0x7f, 0x74, 0x0a, 0x2b, 0x2f, 0xa9, 0x11, 0xcf, 0xcd, 0x1d, 0xaa, 0x9a, 0x39, 0xac,
-	0x4d, 0xcb, 0xd1, 0x78, 0xd4, 0x8b, 0xbf, 0x0b, 0x57, 0x5e, 0xd2, 0x98, 0x40, 0xbf, 0x50, 0xe0,
-	0x66, 0x94, 0x56, 0x4c, 0xd4, 0x37, 0xf2, 0x6c, 0x42, 0x6b, 0xf9, 0xdb, 0xea, 0x76, 0x75, 0xe7,
-	0xe8, 0x1a, 0xfa, 0x9b, 0x62, 0x1e, 0x78, 0x36, 0xc1, 0x5b, 0xee, 0x0c, 0x2e, 0x45, 0x0d, 0xd8,
-	0x18, 0x8d, 0x69, 0x68, 0x08, 0x2f, 0x30, 0x64, 0xa7, 0x5a, 0x81, 0xeb, 0x65, 0x9d, 0x35, 0xa5,
-	0x7c, 0x15, 0x9d, 0xc1, 0xea, 0xc8, 0x1b, 0xbb, 0xa1, 0x61, 0xf1, 0xf3, 0x0f, 0xad, 0x15, 0xe7,
-	0x3a, 0x18, 0xcf, 0xd0, 0xd2, 0x01, 0x83, 0x13, 0xa7, 0x29, 0x8a, 0x57, 0x46, 0x09, 0x8a, 0x19,
-	0x32, 0x20, 0x23, 0x2f, 0x24, 0x06, 0x8b, 0x97, 0xb4, 0xb6, 0x2c, 0x0c, 0x29, 0x78, 0x2c, 0x34,
-	0x50, 0xf4, 0x7b, 0x70, 0xc3, 0x76, 0xa8, 0x79, 0x32, 0x24, 0xc6, 0xd0, 0x1b, 0x18, 0x93, 0x34,
-	0xa7, 0x56, 0xe2, 0x9d, 0x37, 0x65, 0xeb, 0xbe, 0x37, 0x68, 0xc5, 0x6d, 0x7c, 0xd4, 0xa5, 0x6b,
-	0x8e, 0x1c, 0xcb, 0x60, 0x5f, 0x35, 0xf4, 0x4c, 0xdb, 0x18, 0x53, 0x12, 0xd0, 0x5a, 0x59, 0x8e,
-	0x12, 0xad, 0xcf, 0x64, 0xe3, 0x31, 0x6b, 0xd3, 0xef, 0x43, 0x25, 0x61, 0x52, 0x54, 0x82, 0x7c,
-	0xf7, 0xb0, 0xdb, 0xd6, 0x96, 0x10, 0x40, 0xb1, 0xb5, 0x87, 0x0f, 0x0f, 0xfb, 0xe2, 0x84, 0xd2,
-	0x39, 0x68, 0x3e, 0x6e, 0x6b, 0x39, 0xc6, 0x3e, 0xee, 0xfe, 0x61, 0xbb, 0xb3, 0xaf, 0xa9, 0x7a,
-	0x1b, 0x56, 0x92, 0x1f, 0x8a, 0x10, 0x54, 0x8f, 0xbb, 0x4f, 0xbb, 0x87, 0xcf, 0xba, 0xc6, 0xc1,
-	0xe1, 0x71, 0xb7, 0xcf, 0xce, 0x39, 0x55, 0x80, 0x66, 0xf7, 0xf9, 0x84, 0x5e, 0x85, 0x72, 0xf7,
-	0x30, 0x22, 0x95, 0x7a, 0x4e, 0x53, 0xf4, 0xff, 0x50, 0x61, 0x73, 0x96, 0xcd, 0x91, 0x0d, 0x79,
-	0xe6, 0x3f, 0xf2, 0xa4, 0xf9, 0xee, 0xdd, 0x87, 0xa3, 0xb3, 0x65, 0xe3, 0x9b, 0x72, 0x6b, 0x29,
-	0x63, 0xfe, 0x1f, 0x19, 0x50, 0x1c, 0x9a, 0x27, 0x64, 0x48, 0x6b, 0x2a, 0xbf, 0x8b, 0x79, 0x7c,
-	0x9d, 0xb9, 0xf7, 0x39, 0x92, 0xb8, 0x88, 0x91, 0xb0, 0xa8, 0x0f, 0x15, 0x16, 0x3c, 0xa9, 0x50,
-	0x9d, 0x8c, 0xe7, 0x3b, 0x19, 0x67, 0xd9, 0x9b, 0x8c, 0xc4, 0x49, 0x98, 0xfa, 0x3d, 0xa8, 0x24,
-	0x26, 0x9b, 0x71, 0x8f, 0xb2, 0x99, 0xbc, 0x47, 0x29, 0x27, 0x2f, 0x45, 0x1e, 0x4e, 0xdb, 0x80,
-	0xe9, 0x88, 0x39, 0xc4, 0xde, 0x61, 0xaf, 0x2f, 0x4e, 0xac, 0x8f, 0xf1, 0xe1, 0xf1, 0x91, 0xa6,
-	0x30, 0x66, 0xbf, 0xd9, 0x7b, 0xaa, 0xe5, 0x62, 0x7f, 0x51, 0xf5, 0x16, 0x54, 0x12, 0x72, 0xa5,
-	0x76, 0x0b, 0x25, 0xbd, 0x5b, 0xb0, 0x78, 0x6d, 0xda, 0x76, 0x40, 0x28, 0x95, 0x72, 0x44, 0xa4,
-	0xfe, 0x02, 0xca, 0xbb, 0xdd, 0x9e, 0x84, 0xa8, 0xc1, 0x32, 0x25, 0x01, 0xfb, 0x6e, 0x7e, 0x23,
-	0x56, 0xc6, 0x11, 0xc9, 0xc0, 0x29, 0x31, 0x03, 0xeb, 0x94, 0x50, 0x99, 0x63, 0xc4, 0x34, 0x1b,
-	0xe5, 0xf1, 0x9b, 0x25, 0x61, 0xbb, 0x32, 0x8e, 0x48, 0xfd, 0xff, 0x4b, 0x00, 0x93, 0x5b, 0x0e,
-	0x54, 0x85, 0x5c, 0x1c, 0xfb, 0x73, 0x8e, 0xcd, 0xfc, 0x20, 0xb1, 0xb7, 0xf1, 0xff, 0x68, 0x07,
-	0xb6, 0x46, 0x74, 0xe0, 0x9b, 0xd6, 0x99, 0x21, 0x2f, 0x27, 0x44, 0x88, 0xe0, 0x71, 0x74, 0x05,
-	0x6f, 0xc8, 0x46, 0x19, 0x01, 0x04, 0xee, 0x3e, 0xa8, 0xc4, 0x3d, 0xe7, 0x31, 0xaf, 0xb2, 0x73,
-	0x7f, 0xee, 0xdb, 0x97, 0x46, 0xdb, 0x3d, 0x17, 0xbe, 0xc2, 0x60, 0x90, 0x01, 0x60, 0x93, 0x73,
-	0xc7, 0x22, 0x06, 0x03, 0x2d, 0x70, 0xd0, 0xcf, 0xe7, 0x07, 0xdd, 0xe5, 0x18, 0x31, 0x74, 0xd9,
-	0x8e, 0x68, 0xd4, 0x85, 0x72, 0x40, 0xa8, 0x37, 0x0e, 0x2c, 0x22, 0x02, 0x5f, 0xf6, 0x03, 0x12,
-	0x8e, 0xc6, 0xe1, 0x09, 0x04, 0xda, 0x85, 0x22, 0x8f, 0x77, 0x2c, 0xb2, 0xa9, 0xdf, 0x7a, 0x95,
-	0x9b, 0x06, 0xe3, 0x91, 0x04, 0xcb, 0xb1, 0xe8, 0x31, 0x2c, 0x0b, 0x11, 0x69, 0xad, 0xc4, 0x61,
-	0x3e, 0xca, 0x1a, 0x8c, 0xf9, 0x28, 0x1c, 0x8d, 0x66, 0x56, 0x65, 0x41, 0x90, 0xc7, 0xc0, 0x32,
-	0xe6, 0xff, 0xd1, 0x7b, 0x50, 0x16, 0x7b, 0xbf, 0xed, 0x04, 0x35, 0x10, 0xce, 0xc9, 0x19, 0xbb,
-	0x4e, 0x80, 0xde, 0x87, 0x8a, 0xc8, 0xf1, 0x0c, 0x1e, 0x15, 0x2a, 0xbc, 0x19, 0x04, 0xeb, 0x88,
-	0xc5, 0x06, 0xd1, 0x81, 0x04, 0x81, 0xe8, 0xb0, 0x12, 0x77, 0x20, 0x41, 0xc0, 0x3b, 0xfc, 0x0e,
-	0xac, 0xf1, 0xcc, 0x78, 0x10, 0x78, 0x63, 0xdf, 0xe0, 0x3e, 0xb5, 0xca, 0x3b, 0xad, 0x32, 0xf6,
-	0x63, 0xc6, 0xed, 0x32, 0xe7, 0xba, 0x05, 0xa5, 0x57, 0xde, 0x89, 0xe8, 0x50, 0x15, 0xeb, 0xe0,
-	0x95, 0x77, 0x12, 0x35, 0xc5, 0xd9, 0xc9, 0x5a, 0x3a, 0x3b, 0xf9, 0x0a, 0x6e, 0x4c, 0x6f, 0xb3,
-	0x3c, 0x4b, 0xd1, 0xae, 0x9f, 0xa5, 0x6c, 0xba, 0xb3, 0xe2, 0xf0, 0x17, 0xa0, 0xda, 0x2e, 0xad,
-	0xad, 0xcf, 0xe5, 0x1c, 0xf1, 0x3a, 0xc6, 0x6c, 0x30, 0xda, 0x82, 0x22, 0xfb, 0x58, 0xc7, 0xae,
-	0x21, 0x11, 0x7a, 0x5e, 0x79, 0x27, 0x1d, 0x1b, 0x7d, 0x07, 0xca, 0xec, 0xfb, 0xa9, 0x6f, 0x5a,
-	0xa4, 0xb6, 0xc1, 0x5b, 0x26, 0x0c, 0x66, 0x28, 0xd7, 0xb3, 0x89, 0x50, 0xd1, 0xa6, 0x30, 0x14,
-	0x63, 0x70, 0x1d, 0xdd, 0x84, 0x65, 0xde, 0xe8, 0xd8, 0xb5, 0x2d, 0x71, 0x00, 0x61, 0x64, 0xc7,
-	0x46, 0x3a, 0xac, 0xfa, 0x66, 0x40, 0xdc, 0xd0, 0x90, 0x33, 0xde, 0xe0, 0xcd, 0x15, 0xc1, 0x7c,
-	0xc2, 0xe6, 0xad, 0x7f, 0x02, 0xa5, 0x68, 0x31, 0xcc, 0x13, 0x26, 0xeb, 0x0f, 0xa0, 0x9a, 0x5e,
-	0x4a, 0x73, 0x05, 0xd9, 0x7f, 0xce, 0x41, 0x39, 0x5e, 0x34, 0xc8, 0x85, 0x0d, 0x6e, 0x54, 0x96,
-	0xa9, 0x1a, 0x93, 0x35, 0x28, 0xf2, 0xe3, 0xcf, 0x32, 0xaa, 0xb9, 0x19, 0x21, 0xc8, 0x83, 0xba,
-	0x5c, 0x90, 0x28, 0x46, 0x9e, 0xcc, 0xf7, 0x25, 0xac, 0x0d, 0x1d, 0x77, 0x7c, 0x91, 0x98, 0x4b,
-	0x24, 0xb6, 0xbf, 0x9f, 0x71, 0xae, 0x7d, 0x36, 0x7a, 0x32, 0x47, 0x75, 0x98, 0xa2, 0xd1, 0x1e,
-	0x14, 0x7c, 0x2f, 0x08, 0xa3, 0x3d, 0x33, 0xeb, 0x6e, 0x76, 0xe4, 0x05, 0xe1, 0x81, 0xe9, 0xfb,
-	0xec, 0xec, 0x26, 0x00, 0xf4, 0xaf, 0x73, 0x70, 0x63, 0xf6, 0x87, 0xa1, 0x2e, 0xa8, 0x96, 0x3f,
-	0x96, 0x4a, 0x7a, 0x30, 0xaf, 0x92, 0x5a, 0xfe, 0x78, 0x22, 0x3f, 0x03, 0x42, 0xcf, 0xa0, 0x38,
-	0x22, 0x23, 0x2f, 0xb8, 0x94, 0xba, 0x78, 0x38, 0x2f, 0xe4, 0x01, 0x1f, 0x3d, 0x41, 0x95, 0x70,
-	0x08, 0x43, 0x49, 0x2e, 0x26, 0x2a, 0xc3, 0xf6, 0x9c, 0xb7, 0x6b, 0x11, 0x24, 0x8e, 0x71, 0xf4,
-	0x4f, 0x60, 0x6b, 0xe6, 0xa7, 0xa0, 0xdf, 0x02, 0xb0, 0xfc, 0xb1, 0xc1, 0x5f, 0x3f, 0x84, 0x07,
-	0xa9, 0xb8, 0x6c, 0xf9, 0xe3, 0x1e, 0x67, 0xe8, 0x2f, 0xa0, 0xf6, 0x26, 0x79, 0xd9, 0x1a, 0x13,
-	0x12, 0x1b, 0xa3, 0x13, 0xae, 0x03, 0x15, 0x97, 0x04, 0xe3, 0xe0, 0x84, 0x2d, 0xa5, 0xa8, 0xd1,
-	0xbc, 0x60, 0x1d, 0x54, 0xde, 0xa1, 0x22, 0x3b, 0x98, 0x17, 0x07, 0x27, 0xfa, 0x2f, 0x73, 0xb0,
-	0x76, 0x45, 0x64, 0x76, 0x82, 0x15, 0x01, 0x38, 0xba, 0x1b, 0x10, 0x14, 0x8b, 0xc6, 0x96, 0x63,
-	0x47, 0xb7, 0xca, 0xfc, 0x3f, 0xdf, 0x87, 0x7d, 0x79, 0xe3, 0x9b, 0x73, 0x7c, 0xb6, 0x7c, 0x46,
-	0x27, 0x4e, 0x48, 0x79, 0x52, 0x54, 0xc0, 0x82, 0x40, 0xcf, 0xa1, 0x1a, 0x10, 0xbe, 0xff, 0xdb,
-	0x86, 0xf0, 0xb2, 0xc2, 0x5c, 0x5e, 0x26, 0x25, 0x64, 0xce, 0x86, 0x57, 0x23, 0x24, 0x46, 0x51,
-	0xf4, 0x0c, 0x56, 0xa3, 0xc4, 0x59, 0x20, 0x17, 0x17, 0x46, 0x5e, 0x91, 0x40, 0x1c, 0x58, 0xbf,
-	0x07, 0x95, 0x44, 0x23, 0xfb, 0x30, 0x9e, 0xfd, 0x49, 0x9d, 0x08, 0x22, 0x1d, 0x2d, 0x0a, 0x32,
-	0x5a, 0xe8, 0x27, 0x50, 0x49, 0xac, 0x8b, 0x79, 0x86, 0x32, 0x7d, 0x86, 0x1e, 0xd7, 0x67, 0x01,
-	0xe7, 0x42, 0x8f, 0xc5, 0x49, 0x96, 0x79, 0x19, 0x8e, 0xcf, 0x35, 0x5a, 0xc6, 0x45, 0x46, 0x76,
-	0x7c, 0xfd, 0xd7, 0x39, 0xa8, 0xa6, 0x97, 0x74, 0xe4, 0x47, 0x3e, 0x09, 0x1c, 0xcf, 0x4e, 0xf8,
-	0xd1, 0x11, 0x67, 0x30, 0x5f, 0x61, 0xcd, 0x5f, 0x8d, 0xbd, 0xd0, 0x8c, 0x7c, 0xc5, 0xf2, 0xc7,
-	0x7f, 0xc0, 0xe8, 0x2b, 0x3e, 0xa8, 0x5e, 0xf1, 0x41, 0xf4, 0x21, 0x20, 0xe9, 0x4a, 0x43, 0x67,
-	0xe4, 0x84, 0xc6, 0xc9, 0x65, 0x48, 0x84, 0x8d, 0x55, 0xac, 0x89, 0x96, 0x7d, 0xd6, 0xf0, 0x05,
-	0xe3, 0x33, 0xc7, 0xf3, 0xbc, 0x91, 0x41, 0x2d, 0x2f, 0x20, 0x86, 0x69, 0xbf, 0xe2, 0x87, 0x37,
-	0x15, 0x57, 0x3c, 0x6f, 0xd4, 0x63, 0xbc, 0xa6, 0xfd, 0x8a, 0x6d, 0xc4, 0x96, 0x3f, 0xa6, 0x24,
-	0x34, 0xd8, 0x0f, 0xcf, 0x5d, 0xca, 0x18, 0x04, 0xab, 0xe5, 0x8f, 0x29, 0xfa, 0x1e, 0xac, 0x46,
-	0x1d, 0xf8, 0x5e, 0x2c, 0x93, 0x80, 0x15, 0xd9, 0x85, 0xf3, 0x90, 0x0e, 0x2b, 0x47, 0x24, 0xb0,
-	0x88, 0x1b, 0xf6, 0x1d, 0xeb, 0x8c, 0xf2, 0x23, 0x96, 0x82, 0x53, 0xbc, 0x27, 0xf9, 0xd2, 0xb2,
-	0x56, 0xc2, 0xd1, 0x6c, 0x23, 0x32, 0xa2, 0xfa, 0xbf, 0x2a, 0x50, 0xe0, 0x29, 0x0b, 0x53, 0x0a,
-	0xdf, 0xee, 0x79, 0x36, 0x20, 0x53, 0x5d, 0xc6, 0xe0, 0xb9, 0xc0, 0x7b, 0x50, 0xe6, 0xca, 0x4f,
-	0x9c, 0x30, 0x78, 0x1e, 0xcc, 0x1b, 0xeb, 0x50, 0x0a, 0x88, 0x69, 0x7b, 0xee, 0x30, 0xba, 0x14,
-	0x8b, 0x69, 0xf4, 0xbb, 0xa0, 0xf9, 0x81, 0xe7, 0x9b, 0x83, 0xc9, 0x39, 0x5a, 0x9a, 0x6f, 0x2d,
-	0xc1, 0xe7, 0x29, 0xfa, 0xf7, 0x60, 0x95, 0x12, 0x11, 0xd9, 0x85, 0x93, 0x14, 0xc4, 0x67, 0x4a,
-	0x26, 0x3f, 0x11, 0xe8, 0x5f, 0x41, 0x51, 0x6c, 0x5c, 0xd7, 0x90, 0xf7, 0x23, 0x40, 0x42, 0x91,
-	0xcc, 0x41, 0x46, 0x0e, 0xa5, 0x32, 0xcb, 0xe6, 0x2f, 0xbb, 0xa2, 0xe5, 0x68, 0xd2, 0xa0, 0xff,
-	0x97, 0x22, 0xf2, 0x6d, 0xf1, 0xe6, 0xc6, 0x12, 0x73, 0xb6, 0x6a, 0xd8, 0x31, 0x56, 0x5c, 0xee,
-	0x45, 0x24, 0xea, 0x40, 0x51, 0xa6, 0xd5, 0xb9, 0x45, 0x9f, 0x2c, 0x25, 0x40, 0x74, 0xd5, 0x4f,
-	0xe4, 0x45, 0xc7, 0xbc, 0x57, 0xfd, 0x44, 0x5c, 0xf5, 0x13, 0x76, 0x4a, 0x97, 0x09, 0xbf, 0x80,
-	0xcb, 0xf3, 0x7c, 0xbf, 0x62, 0xc7, 0xef, 0x29, 0x44, 0xff, 0x1f, 0x25, 0x8e, 0x7b, 0xd1, 0xbb,
-	0x07, 0xfa, 0x12, 0x4a, 0x2c, 0x84, 0x18, 0x23, 0xd3, 0x97, 0xaf, 0xf8, 0xad, 0xc5, 0x9e, 0x54,
-	0xa2, 0x5d, 0x51, 0xa4, 0xeb, 0xcb, 0xbe, 0xa0, 0x58, 0xfc, 0x64, 0x47, 0xa5, 0x28, 0x7e, 0xb2,
-	0xff, 0xe8, 0x03, 0xa8, 0x9a, 0xe3, 0xd0, 0x33, 0x4c, 0xfb, 0x9c, 0x04, 0xa1, 0x43, 0x89, 0xf4,
-	0xa5, 0x55, 0xc6, 0x6d, 0x46, 0xcc, 0xfa, 0x7d, 0x58, 0x49, 0x62, 0xbe, 0x2d, 0x6f, 0x29, 0x24,
-	0xf3, 0x96, 0x3f, 0x05, 0x98, 0xdc, 0x21, 0x32, 0x1f, 0x21, 0x17, 0x4e, 0x68, 0x58, 0xd1, 0xd9,
-	0xbc, 0x80, 0x4b, 0x8c, 0xd1, 0x62, 0xce, 0x98, 0x7e, 0xe0, 0x28, 0x44, 0x0f, 0x1c, 0x2c, 0x3a,
-	0xb0, 0x05, 0x7d, 0xe6, 0x0c, 0x87, 0xf1, 0xbd, 0x66, 0xd9, 0xf3, 0x46, 0x4f, 0x39, 0x43, 0xff,
-	0x26, 0x27, 0x7c, 0x45, 0x3c, 0x55, 0x65, 0x3a, 0x9b, 0xbd, 0x2b, 0x53, 0xdf, 0x03, 0xa0, 0xa1,
-	0x19, 0xb0, 0x24, 0xcc, 0x8c, 0x6e, 0x56, 0xeb, 0x53, 0x2f, 0x24, 0xfd, 0xa8, 0x76, 0x06, 0x97,
-	0x65, 0xef, 0x66, 0x88, 0x3e, 0x83, 0x15, 0xcb, 0x1b, 0xf9, 0x43, 0x22, 0x07, 0x17, 0xde, 0x3a,
-	0xb8, 0x12, 0xf7, 0x6f, 0x86, 0x89, 0xfb, 0xdc, 0xe2, 0x75, 0xef, 0x73, 0x7f, 0xad, 0x88, 0x17,
-	0xb7, 0xe4, 0x83, 0x1f, 0x1a, 0xcc, 0xa8, 0x2a, 0x79, 0xbc, 0xe0, 0xeb, 0xe1, 0xb7, 0x95, 0x94,
-	0xd4, 0x3f, 0xcb, 0x52, 0xc3, 0xf1, 0xe6, 0xb4, 0xf8, 0xdf, 0x55, 0x28, 0xc7, 0x8f, 0x6d, 0x53,
-	0xb6, 0xff, 0x14, 0xca, 0x71, 0xe1, 0x92, 0x0c, 0x10, 0xdf, 0x6a, 0x9e, 0xb8, 0x33, 0x7a, 0x09,
-	0xc8, 0x1c, 0x0c, 0xe2, 0x74, 0xd7, 0x18, 0x53, 0x73, 0x10, 0x3d, 0x75, 0x7e, 0x3a, 0x87, 0x1e,
-	0xa2, 0xfd, 0xf1, 0x98, 0x8d, 0xc7, 0x9a, 0x39, 0x18, 0xa4, 0x38, 0xe8, 0xcf, 0x60, 0x2b, 0x3d,
-	0x87, 0x71, 0x72, 0x69, 0xf8, 0x8e, 0x2d, 0xef, 0x00, 0xf6, 0xe6, 0x7d, 0x6f, 0x6c, 0xa4, 0xe0,
-	0xbf, 0xb8, 0x3c, 0x72, 0x6c, 0xa1, 0x73, 0x14, 0x4c, 0x35, 0xd4, 0xff, 0x02, 0x6e, 0xbe, 0xa1,
-	0xfb, 0x0c, 0x1b, 0x74, 0xd3, 0x75, 0x34, 0x8b, 0x2b, 0x21, 0x61, 0xbd, 0x5f, 0x29, 0xe2, 0x59,
-	0x34, 0xad, 0x93, 0x66, 0x32, 0x4f, 0xbf, 0x93, 0x71, 0x9e, 0xd6, 0xd1, 0xb1, 0x80, 0xe7, 0xa9,
-	0xf9, 0x93, 0x2b, 0xa9, 0x79, 0xd6, 0x84, 0x4c, 0x64, 0xb8, 0x02, 0x48, 0x22, 0xe8, 0xff, 0xa2,
-	0x42, 0x29, 0x42, 0xe7, 0x27, 0xf8, 0x4b, 0x1a, 0x92, 0x91, 0x11, 0x5f, 0x2f, 0x2a, 0x18, 0x04,
-	0x8b, 0xef, 0xa8, 0xef, 0x41, 0x79, 0x4c, 0x49, 0x20, 0x9a, 0x73, 0xbc, 0xb9, 0xc4, 0x18, 0xbc,
-	0xf1, 0x7d, 0xa8, 0x84, 0x5e, 0x68, 0x0e, 0x8d, 0x90, 0xe7, 0x0b, 0xaa, 0x18, 0xcd, 0x59, 0x3c,
-	0x5b, 0x40, 0x3f, 0x80, 0xf5, 0xf0, 0x34, 0xf0, 0xc2, 0x70, 0xc8, 0x72, 0x55, 0x9e, 0x39, 0x89,
-	0x44, 0x27, 0x8f, 0xb5, 0xb8, 0x41, 0x64, 0x54, 0x94, 0x45, 0xef, 0x49, 0x67, 0xe6, 0xba, 0x3c,
-	0x88, 0xe4, 0xf1, 0x6a, 0xcc, 0x65, 0xae, 0xcd, 0x36, 0x4f, 0x5f, 0x64, 0x24, 0x3c, 0x56, 0x28,
-	0x38, 0x22, 0x91, 0x01, 0x6b, 0x23, 0x62, 0xd2, 0x71, 0x40, 0x6c, 0xe3, 0xa5, 0x43, 0x86, 0xb6,
-	0xb8, 0x78, 0xa9, 0x66, 0x3e, 0x6e, 0x44, 0x6a, 0x69, 0x3c, 0xe2, 0xa3, 0x71, 0x35, 0x82, 0x13,
-	0x34, 0xcb, 0x1c, 0xc4, 0x3f, 0xb4, 0x06, 0x95, 0xde, 0xf3, 0x5e, 0xbf, 0x7d, 0x60, 0x1c, 0x1c,
-	0xee, 0xb6, 0x65, 0xa9, 0x54, 0xaf, 0x8d, 0x05, 0xa9, 0xb0, 0xf6, 0xfe, 0x61, 0xbf, 0xb9, 0x6f,
-	0xf4, 0x3b, 0xad, 0xa7, 0x3d, 0x2d, 0x87, 0xb6, 0x60, 0xbd, 0xbf, 0x87, 0x0f, 0xfb, 0xfd, 0xfd,
-	0xf6, 0xae, 0x71, 0xd4, 0xc6, 0x9d, 0xc3, 0xdd, 0x9e, 0xa6, 0x22, 0x04, 0xd5, 0x09, 0xbb, 0xdf,
-	0x39, 0x68, 0x6b, 0x79, 0x54, 0x81, 0xe5, 0xa3, 0x36, 0x6e, 0xb5, 0xbb, 0x7d, 0xad, 0xa0, 0xff,
-	0x52, 0x85, 0x4a, 0xc2, 0x8a, 0xcc, 0x91, 0x03, 0x2a, 0xce, 0x35, 0x79, 0xcc, 0xfe, 0xf2, 0xa7,
-	0x5d, 0xd3, 0x3a, 0x15, 0xd6, 0xc9, 0x63, 0x41, 0xf0, 0xb3, 0x8c, 0x79, 0x91, 0x58, 0xe7, 0x79,
-	0x5c, 0x1a, 0x99, 0x17, 0x02, 0xe4, 0xbb, 0xb0, 0x72, 0x46, 0x02, 0x97, 0x0c, 0x65, 0xbb, 0xb0,
-	0x48, 0x45, 0xf0, 0x44, 0x97, 0x6d, 0xd0, 0x64, 0x97, 0x09, 0x8c, 0x30, 0x47, 0x55, 0xf0, 0x0f,
-	0x22, 0xb0, 0x4d, 0x28, 0x88, 0xe6, 0x65, 0x31, 0x3f, 0x27, 0xd8, 0x36, 0x45, 0x5f, 0x9b, 0x3e,
-	0xcf, 0x21, 0xf3, 0x98, 0xff, 0x47, 0x27, 0xd3, 0xf6, 0x29, 0x72, 0xfb, 0xdc, 0x9b, 0xdf, 0x9d,
-	0xdf, 0x64, 0xa2, 0xd3, 0xd8, 0x44, 0xcb, 0xa0, 0xe2, 0xa8, 0xbe, 0xa8, 0xd5, 0x6c, 0xed, 0x31,
-	0xb3, 0xac, 0x42, 0xf9, 0xa0, 0xf9, 0x53, 0xe3, 0xb8, 0x27, 0x6e, 0xf0, 0x35, 0x58, 0x79, 0xda,
-	0xc6, 0xdd, 0xf6, 0xbe, 0xe4, 0xa8, 0x68, 0x13, 0x34, 0xc9, 0x99, 0xf4, 0xcb, 0x33, 0x04, 0xf1,
-	0xb7, 0x80, 0x4a, 0x90, 0xef, 0x3d, 0x6b, 0x1e, 0x69, 0x45, 0xfd, 0xbf, 0x73, 0xb0, 0x26, 0xb6,
-	0x85, 0xb8, 0x12, 0xe2, 0xcd, 0x2f, 0xc1, 0xc9, 0x5b, 0xac, 0x5c, 0xfa, 0x16, 0x2b, 0x4a, 0x42,
-	0xf9, 0xae, 0xae, 0x4e, 0x92, 0x50, 0x7e, 0xb3, 0x93, 0x8a, 0xf8, 0xf9, 0x79, 0x22, 0x7e, 0x0d,
-	0x96, 0x47, 0x84, 0xc6, 0x76, 0x2b, 0xe3, 0x88, 0x44, 0x0e, 0x54, 0x4c, 0xd7, 0xf5, 0x42, 0x53,
-	0x5c, 0x0d, 0x17, 0xe7, 0xda, 0x0c, 0xaf, 0x7c, 0x71, 0xa3, 0x39, 0x41, 0x12, 0x81, 0x39, 0x89,
-	0x5d, 0xff, 0x09, 0x68, 0x57, 0x3b, 0xcc, 0xb3, 0x1d, 0x7e, 0xff, 0x87, 0x93, 0xdd, 0x90, 0xb0,
-	0x75, 0x21, 0xdf, 0x54, 0xb4, 0x25, 0x46, 0xe0, 0xe3, 0x6e, 0xb7, 0xd3, 0x7d, 0xac, 0x29, 0x08,
-	0xa0, 0xd8, 0xfe, 0x69, 0xa7, 0xdf, 0xde, 0xd5, 0x72, 0x3b, 0xbf, 0x5a, 0x87, 0xa2, 0x10, 0x12,
-	0x7d, 0x2d, 0x33, 0x81, 0x64, 0x95, 0x2d, 0xfa, 0xc9, 0xdc, 0x19, 0x75, 0xaa, 0x72, 0xb7, 0xfe,
-	0x70, 0xe1, 0xf1, 0xf2, 0x55, 0x73, 0x09, 0xfd, 0x8d, 0x02, 0x2b, 0xa9, 0x17, 0xcd, 0xac, 0x57,
-	0xe3, 0x33, 0x8a, 0x7a, 0xeb, 0x3f, 0x5e, 0x68, 0x6c, 0x2c, 0xcb, 0x2f, 0x14, 0xa8, 0x24, 0xca,
-	0x59, 0xd1, 0xbd, 0x45, 0x4a, 0x60, 0x85, 0x24, 0xf7, 0x17, 0xaf, 0x9e, 0xd5, 0x97, 0x3e, 0x56,
-	0xd0, 0x5f, 0x2b, 0x50, 0x49, 0x14, 0x76, 0x66, 0x16, 0x65, 0xba, 0x0c, 0x35, 0xb3, 0x28, 0xb3,
-	0xea, 0x48, 0x97, 0xd0, 0x5f, 0x2a, 0x50, 0x8e, 0x8b, 0x34, 0xd1, 0xdd, 0xf9, 0xcb, 0x3a, 0x85,
-	0x10, 0x9f, 0x2e, 0x5a, 0x0f, 0xaa, 0x2f, 0xa1, 0x3f, 0x87, 0x52, 0x54, 0xd1, 0x88, 0xb2, 0xee,
-	0x5e, 0x57, 0xca, 0x25, 0xeb, 0x77, 0xe7, 0x1e, 0x97, 0x9c, 0x3e, 0x2a, 0x33, 0xcc, 0x3c, 0xfd,
-	0x95, 0x82, 0xc8, 0xfa, 0xdd, 0xb9, 0xc7, 0xc5, 0xd3, 0x33, 0x4f, 0x48, 0x54, 0x23, 0x66, 0xf6,
-	0x84, 0xe9, 0x32, 0xc8, 0xcc, 0x9e, 0x30, 0xab, 0xf8, 0x51, 0x08, 0x92, 0xa8, 0x67, 0xcc, 0x2c,
-	0xc8, 0x74, 0xcd, 0x64, 0x66, 0x41, 0x66, 0x94, 0x4f, 0xea, 0x4b, 0xe8, 0xe7, 0x4a, 0xf2, 0x5c,
-	0x70, 0x77, 0xee, 0xb2, 0xbd, 0x39, 0x5d, 0x72, 0xaa, 0x70, 0x90, 0x2f, 0xd0, 0x9f, 0xcb, 0x5b,
-	0x0c, 0x51, 0xf5, 0x87, 0xe6, 0x01, 0x4b, 0x15, 0x0a, 0xd6, 0x3f, 0x59, 0x6c, 0xb3, 0xe1, 0x42,
-	0xfc, 0x95, 0x02, 0x30, 0xa9, 0x0f, 0xcc, 0x2c, 0xc4, 0x54, 0x61, 0x62, 0xfd, 0xde, 0x02, 0x23,
-	0x93, 0x0b, 0x24, 0xaa, 0x5f, 0xca, 0xbc, 0x40, 0xae, 0xd4, 0x2f, 0x66, 0x5e, 0x20, 0x57, 0x6b,
-	0x0f, 0xf5, 0x25, 0xf4, 0x4f, 0x0a, 0xac, 0x4f, 0xd5, 0x4f, 0xa1, 0x87, 0xd7, 0x2c, 0xa1, 0xab,
-	0x7f, 0xbe, 0x38, 0x40, 0x24, 0xda, 0xb6, 0xf2, 0xb1, 0x82, 0xfe, 0x56, 0x81, 0xd5, 0x74, 0x5d,
-	0x49, 0xe6, 0x5d, 0x6a, 0x46, 0x25, 0x56, 0xfd, 0xc1, 0x62, 0x83, 0x63, 0x6d, 0xfd, 0xbd, 0x02,
-	0xd5, 0x74, 0x89, 0x11, 0x7a, 0x30, 0x5f, 0x58, 0xb8, 0x22, 0xd0, 0x67, 0x0b, 0x8e, 0x8e, 0x24,
-	0xfa, 0x62, 0xf9, 0x8f, 0x0a, 0x22, 0x7b, 0x2b, 0xf2, 0x9f, 0x1f, 0xfd, 0x26, 0x00, 0x00, 0xff,
-	0xff, 0x5b, 0xc7, 0xff, 0xf4, 0x0c, 0x35, 0x00, 0x00,
+	// 4225 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x7a, 0xcf, 0x73, 0x1b, 0xc9,
+	0x75, 0xbf, 0x06, 0x03, 0x80, 0xc0, 0x03, 0x09, 0x0e, 0x9b, 0xa4, 0x04, 0x61, 0x6d, 0xaf, 0x3c,
+	0xae, 0xfd, 0x96, 0xbe, 0xf6, 0x2e, 0xb4, 0xa6, 0x93, 0xd5, 0x4a, 0x96, 0xac, 0x85, 0x40, 0x48,
+	0x84, 0x44, 0x82, 0x4c, 0x03, 0x8c, 0xac, 0x28, 0xd9, 0xc9, 0x70, 0xa6, 0x05, 0x8e, 0x08, 0xcc,
+	0xcc, 0x4e, 0x0f, 0x24, 0xd2, 0xa9, 0x54, 0x52, 0x4e, 0x25, 0xe5, 0x54, 0x25, 0xe5, 0x5c, 0x1c,
+	0xe7, 0x90, 0xca, 0x21, 0x55, 0x39, 0xa5, 0x72, 0x4f, 0x39, 0xe5, 0x93, 0x0f, 0xf9, 0x27, 0x72,
+	0x49, 0x4e, 0xb9, 0xe4, 0x90, 0xbf, 0x20, 0xa9, 0xfe, 0x31, 0x83, 0x19, 0x00, 0xb2, 0x06, 0xa0,
+	0x72, 0x02, 0xde, 0xeb, 0xee, 0x4f, 0xbf, 0x79, 0xef, 0xf5, 0xeb, 0xd7, 0xdd, 0x0f, 0x74, 0x7f,
+	0x38, 0x1e, 0x38, 0x2e, 0xbd, 0x65, 0x07, 0xce, 0x6b, 0x12, 0xd0, 0x5b, 0x7e, 0xe0, 0x85, 0x9e,
+	0xa4, 0x1a, 0x9c, 0x40, 0x1f, 0x9d, 0x9a, 0xf4, 0xd4, 0xb1, 0xbc, 0xc0, 0x6f, 0xb8, 0xde, 0xc8,
+	0xb4, 0x1b, 0x72, 0x4c, 0x43, 0x8e, 0x11, 0xdd, 0xea, 0xdf, 0x18, 0x78, 0xde, 0x60, 0x48, 0x04,
+	0xc2, 0xc9, 0xf8, 0xe5, 0x2d, 0x7b, 0x1c, 0x98, 0xa1, 0xe3, 0xb9, 0xb2, 0xfd, 0xc3, 0xe9, 0xf6,
+	0xd0, 0x19, 0x11, 0x1a, 0x9a, 0x23, 0x5f, 0x76, 0xf8, 0x28, 0x92, 0x85, 0x9e, 0x9a, 0x01, 0xb1,
+	0x6f, 0x9d, 0x5a, 0x43, 0xea, 0x13, 0x8b, 0xfd, 0x1a, 0xec, 0x8f, 0xec, 0xf6, 0xf1, 0x54, 0x37,
+	0x1a, 0x06, 0x63, 0x2b, 0x8c, 0x24, 0x37, 0xc3, 0x30, 0x70, 0x4e, 0xc6, 0x21, 0x11, 0xbd, 0xf5,
+	0xeb, 0x70, 0xad, 0x6f, 0xd2, 0xb3, 0x96, 0xe7, 0xbe, 0x74, 0x06, 0x3d, 0xeb, 0x94, 0x8c, 0x4c,
+	0x4c, 0xbe, 0x1a, 0x13, 0x1a, 0xea, 0xbf, 0x0b, 0xb5, 0xd9, 0x26, 0xea, 0x7b, 0x2e, 0x25, 0xe8,
+	0x0b, 0xc8, 0xb3, 0x29, 0x6b, 0xca, 0x0d, 0xe5, 0x66, 0x65, 0xe7, 0xe3, 0xc6, 0xdb, 0x54, 0x20,
+	0x64, 0x68, 0x48, 0x51, 0x1b, 0x3d, 0x9f, 0x58, 0x98, 0x8f, 0xd4, 0xb7, 0x61, 0xb3, 0x65, 0xfa,
+	0xe6, 0x89, 0x33, 0x74, 0x42, 0x87, 0xd0, 0x68, 0xd2, 0x31, 0x6c, 0xa5, 0xd9, 0x72, 0xc2, 0xdf,
+	0x83, 0x55, 0x2b, 0xc1, 0x97, 0x13, 0xdf, 0x69, 0x64, 0xd2, 0x7d, 0x63, 0x97, 0x53, 0x29, 0xe0,
+	0x14, 0x9c, 0xbe, 0x05, 0xe8, 0x91, 0xe3, 0x0e, 0x48, 0xe0, 0x07, 0x8e, 0x1b, 0x46, 0xc2, 0xfc,
+	0x52, 0x85, 0xcd, 0x14, 0x5b, 0x0a, 0xf3, 0x0a, 0x20, 0xd6, 0x23, 0x13, 0x45, 0xbd, 0x59, 0xd9,
+	0x79, 0x92, 0x51, 0x94, 0x39, 0x78, 0x8d, 0x66, 0x0c, 0xd6, 0x76, 0xc3, 0xe0, 0x02, 0x27, 0xd0,
+	0xd1, 0x97, 0x50, 0x3c, 0x25, 0xe6, 0x30, 0x3c, 0xad, 0xe5, 0x6e, 0x28, 0x37, 0xab, 0x3b, 0x8f,
+	0x2e, 0x31, 0xcf, 0x1e, 0x07, 0xea, 0x85, 0x66, 0x48, 0xb0, 0x44, 0x45, 0x9f, 0x00, 0x12, 0xff,
+	0x0c, 0x9b, 0x50, 0x2b, 0x70, 0x7c, 0xe6, 0x92, 0x35, 0xf5, 0x86, 0x72, 0xb3, 0x8c, 0x37, 0x44,
+	0xcb, 0xee, 0xa4, 0xa1, 0xee, 0xc3, 0xfa, 0x94, 0xb4, 0x48, 0x03, 0xf5, 0x8c, 0x5c, 0x70, 0x8b,
+	0x94, 0x31, 0xfb, 0x8b, 0x1e, 0x43, 0xe1, 0xb5, 0x39, 0x1c, 0x13, 0x2e, 0x72, 0x65, 0xe7, 0xbb,
+	0xef, 0x72, 0x0f, 0xe9, 0xa2, 0x13, 0x3d, 0x60, 0x31, 0xfe, 0x6e, 0xee, 0x73, 0x45, 0xbf, 0x03,
+	0x95, 0x84, 0xdc, 0xa8, 0x0a, 0x70, 0xdc, 0xdd, 0x6d, 0xf7, 0xdb, 0xad, 0x7e, 0x7b, 0x57, 0xbb,
+	0x82, 0xd6, 0xa0, 0x7c, 0xdc, 0xdd, 0x6b, 0x37, 0xf7, 0xfb, 0x7b, 0xcf, 0x35, 0x05, 0x55, 0x60,
+	0x25, 0x22, 0x72, 0xfa, 0x39, 0x20, 0x4c, 0x2c, 0xef, 0x35, 0x09, 0x98, 0x23, 0x4b, 0xab, 0xa2,
+	0x6b, 0xb0, 0x12, 0x9a, 0xf4, 0xcc, 0x70, 0x6c, 0x29, 0x73, 0x91, 0x91, 0x1d, 0x1b, 0x75, 0xa0,
+	0x78, 0x6a, 0xba, 0xf6, 0xf0, 0xdd, 0x72, 0xa7, 0x55, 0xcd, 0xc0, 0xf7, 0xf8, 0x40, 0x2c, 0x01,
+	0x98, 0x77, 0xa7, 0x66, 0x16, 0x06, 0xd0, 0x9f, 0x83, 0xd6, 0x0b, 0xcd, 0x20, 0x4c, 0x8a, 0xd3,
+	0x86, 0x3c, 0x9b, 0xbf, 0xa6, 0x2c, 0x3c, 0xa7, 0x58, 0x99, 0x98, 0x0f, 0xd7, 0xff, 0x3b, 0x07,
+	0x1b, 0x09, 0x6c, 0xe9, 0xa9, 0xcf, 0xa0, 0x18, 0x10, 0x3a, 0x1e, 0x86, 0x1c, 0xbe, 0xba, 0xf3,
+	0x20, 0x23, 0xfc, 0x0c, 0x52, 0x03, 0x73, 0x18, 0x2c, 0xe1, 0xd0, 0x4d, 0xd0, 0xc4, 0x08, 0x83,
+	0x04, 0x81, 0x17, 0x18, 0x23, 0x3a, 0xe0, 0x5a, 0x2b, 0xe3, 0xaa, 0xe0, 0xb7, 0x19, 0xfb, 0x80,
+	0x0e, 0x12, 0x5a, 0x55, 0x2f, 0xa9, 0x55, 0x64, 0x82, 0xe6, 0x92, 0xf0, 0x8d, 0x17, 0x9c, 0x19,
+	0x4c, 0xb5, 0x81, 0x63, 0x93, 0x5a, 0x9e, 0x83, 0x7e, 0x96, 0x11, 0xb4, 0x2b, 0x86, 0x1f, 0xca,
+	0xd1, 0x78, 0xdd, 0x4d, 0x33, 0xf4, 0xef, 0x40, 0x51, 0x7c, 0x29, 0xf3, 0xa4, 0xde, 0x71, 0xab,
+	0xd5, 0xee, 0xf5, 0xb4, 0x2b, 0xa8, 0x0c, 0x05, 0xdc, 0xee, 0x63, 0xe6, 0x61, 0x65, 0x28, 0x3c,
+	0x6a, 0xf6, 0x9b, 0xfb, 0x5a, 0x4e, 0xff, 0x36, 0xac, 0x3f, 0x33, 0x9d, 0x30, 0x8b, 0x73, 0xe9,
+	0x1e, 0x68, 0x93, 0xbe, 0xd2, 0x3a, 0x9d, 0x94, 0x75, 0xb2, 0xab, 0xa6, 0x7d, 0xee, 0x84, 0x53,
+	0xf6, 0xd0, 0x40, 0x25, 0x41, 0x20, 0x4d, 0xc0, 0xfe, 0xea, 0x6f, 0x60, 0xbd, 0x17, 0x7a, 0x7e,
+	0x26, 0xcf, 0xff, 0x1e, 0xac, 0xb0, 0xdd, 0xc6, 0x1b, 0x87, 0xd2, 0xf5, 0xaf, 0x37, 0xc4, 0x6e,
+	0xd4, 0x88, 0x76, 0xa3, 0xc6, 0xae, 0xdc, 0xad, 0x70, 0xd4, 0x13, 0x5d, 0x85, 0x22, 0x75, 0x06,
+	0xae, 0x39, 0x94, 0xd1, 0x42, 0x52, 0x3a, 0x02, 0x6d, 0x32, 0xb1, 0x74, 0xfc, 0x16, 0xa0, 0x5d,
+	0x42, 0xc3, 0xc0, 0xbb, 0xc8, 0x24, 0xcf, 0x16, 0x14, 0x5e, 0x7a, 0x81, 0x25, 0x16, 0x62, 0x09,
+	0x0b, 0x82, 0x2d, 0xaa, 0x14, 0x88, 0xc4, 0xfe, 0x04, 0x50, 0xc7, 0x65, 0x7b, 0x4a, 0x36, 0x43,
+	0xfc, 0x55, 0x0e, 0x36, 0x53, 0xfd, 0xa5, 0x31, 0x96, 0x5f, 0x87, 0x2c, 0x30, 0x8d, 0xa9, 0x58,
+	0x87, 0xe8, 0x10, 0x8a, 0xa2, 0x87, 0xd4, 0xe4, 0xed, 0x05, 0x80, 0xc4, 0x36, 0x25, 0xe1, 0x24,
+	0xcc, 0x5c, 0xa7, 0x57, 0xdf, 0xaf, 0xd3, 0xbf, 0x01, 0x2d, 0xfa, 0x0e, 0xfa, 0x4e, 0xdb, 0x3c,
+	0x81, 0x4d, 0xcb, 0x1b, 0x0e, 0x89, 0xc5, 0xbc, 0xc1, 0x70, 0xdc, 0x90, 0x04, 0xaf, 0xcd, 0xe1,
+	0xbb, 0xfd, 0x06, 0x4d, 0x46, 0x75, 0xe4, 0x20, 0xfd, 0x05, 0x6c, 0x24, 0x26, 0x96, 0x86, 0x78,
+	0x04, 0x05, 0xca, 0x18, 0xd2, 0x12, 0x9f, 0x2e, 0x68, 0x09, 0x8a, 0xc5, 0x70, 0x7d, 0x53, 0x80,
+	0xb7, 0x5f, 0x13, 0x37, 0xfe, 0x2c, 0x7d, 0x17, 0x36, 0x7a, 0xdc, 0x4d, 0x33, 0xf9, 0xe1, 0xc4,
+	0xc5, 0x73, 0x29, 0x17, 0xdf, 0x02, 0x94, 0x44, 0x91, 0x8e, 0x78, 0x01, 0xeb, 0xed, 0x73, 0x62,
+	0x65, 0x42, 0xae, 0xc1, 0x8a, 0xe5, 0x8d, 0x46, 0xa6, 0x6b, 0xd7, 0x72, 0x37, 0xd4, 0x9b, 0x65,
+	0x1c, 0x91, 0xc9, 0xb5, 0xa8, 0x66, 0x5d, 0x8b, 0xfa, 0x5f, 0x2a, 0xa0, 0x4d, 0xe6, 0x96, 0x8a,
+	0x64, 0xd2, 0x87, 0x36, 0x03, 0x62, 0x73, 0xaf, 0x62, 0x49, 0x49, 0x7e, 0x14, 0x2e, 0x04, 0x9f,
+	0x04, 0x41, 0x22, 0x1c, 0xa9, 0x97, 0x0c, 0x47, 0xfa, 0x1e, 0x7c, 0x2d, 0x12, 0xa7, 0x17, 0x06,
+	0xc4, 0x1c, 0x39, 0xee, 0xa0, 0x73, 0x78, 0xe8, 0x13, 0x21, 0x38, 0x42, 0x90, 0xb7, 0xcd, 0xd0,
+	0x94, 0x82, 0xf1, 0xff, 0x6c, 0xd1, 0x5b, 0x43, 0x8f, 0xc6, 0x8b, 0x9e, 0x13, 0xfa, 0xbf, 0xaa,
+	0x50, 0x9b, 0x81, 0x8a, 0xd4, 0xfb, 0x02, 0x0a, 0x94, 0x84, 0x63, 0x5f, 0xba, 0x4a, 0x3b, 0xb3,
+	0xc0, 0xf3, 0xf1, 0x1a, 0x3d, 0x06, 0x86, 0x05, 0x26, 0x1a, 0x40, 0x29, 0x0c, 0x2f, 0x0c, 0xea,
+	0xfc, 0x28, 0x4a, 0x08, 0xf6, 0x2f, 0x8b, 0xdf, 0x27, 0xc1, 0xc8, 0x71, 0xcd, 0x61, 0xcf, 0xf9,
+	0x11, 0xc1, 0x2b, 0x61, 0x78, 0xc1, 0xfe, 0xa0, 0xe7, 0xcc, 0xe1, 0x6d, 0xc7, 0x95, 0x6a, 0x6f,
+	0x2d, 0x3b, 0x4b, 0x42, 0xc1, 0x58, 0x20, 0xd6, 0xf7, 0xa1, 0xc0, 0xbf, 0x69, 0x19, 0x47, 0xd4,
+	0x40, 0x0d, 0xc3, 0x0b, 0x2e, 0x54, 0x09, 0xb3, 0xbf, 0xf5, 0x7b, 0xb0, 0x9a, 0xfc, 0x02, 0xe6,
+	0x48, 0xa7, 0xc4, 0x19, 0x9c, 0x0a, 0x07, 0x2b, 0x60, 0x49, 0x31, 0x4b, 0xbe, 0x71, 0x6c, 0x99,
+	0xb2, 0x16, 0xb0, 0x20, 0xf4, 0x7f, 0xce, 0xc1, 0xf5, 0x39, 0x9a, 0x91, 0xce, 0xfa, 0x22, 0xe5,
+	0xac, 0xef, 0x49, 0x0b, 0x91, 0xc7, 0xbf, 0x48, 0x79, 0xfc, 0x7b, 0x04, 0x67, 0xcb, 0xe6, 0x2a,
+	0x14, 0xc9, 0xb9, 0x13, 0x12, 0x5b, 0xaa, 0x4a, 0x52, 0x89, 0xe5, 0x94, 0xbf, 0xec, 0x72, 0x3a,
+	0x80, 0xad, 0x56, 0x40, 0xcc, 0x90, 0xc8, 0x50, 0x1e, 0xf9, 0xff, 0x75, 0x28, 0x99, 0xc3, 0xa1,
+	0x67, 0x4d, 0xcc, 0xba, 0xc2, 0xe9, 0x8e, 0x8d, 0xea, 0x50, 0x3a, 0xf5, 0x68, 0xe8, 0x9a, 0x23,
+	0x22, 0x83, 0x57, 0x4c, 0xeb, 0x3f, 0x53, 0x60, 0x7b, 0x0a, 0x4f, 0x5a, 0xe1, 0x04, 0xaa, 0x0e,
+	0xf5, 0x86, 0xfc, 0x03, 0x8d, 0xc4, 0x09, 0xef, 0xfb, 0x8b, 0x6d, 0x35, 0x9d, 0x08, 0x83, 0x1f,
+	0xf8, 0xd6, 0x9c, 0x24, 0xc9, 0x3d, 0x8e, 0x4f, 0x6e, 0xcb, 0x95, 0x1e, 0x91, 0xfa, 0x5f, 0x2b,
+	0xb0, 0x2d, 0x77, 0xf8, 0xec, 0x1f, 0x3a, 0x2b, 0x72, 0xee, 0x7d, 0x8b, 0xac, 0xd7, 0xe0, 0xea,
+	0xb4, 0x5c, 0x32, 0xe6, 0xff, 0xb4, 0x08, 0x68, 0xf6, 0x74, 0x89, 0xbe, 0x09, 0xab, 0x94, 0xb8,
+	0xb6, 0x21, 0xf6, 0x0b, 0xb1, 0x95, 0x95, 0x70, 0x85, 0xf1, 0xc4, 0xc6, 0x41, 0x59, 0x08, 0x24,
+	0xe7, 0x52, 0xda, 0x12, 0xe6, 0xff, 0xd1, 0x29, 0xac, 0xbe, 0xa4, 0x46, 0x3c, 0x37, 0x77, 0xa8,
+	0x6a, 0xe6, 0xb0, 0x36, 0x2b, 0x47, 0xe3, 0x51, 0x2f, 0xfe, 0x2e, 0x5c, 0x79, 0x49, 0x63, 0x02,
+	0xfd, 0x44, 0x81, 0x6b, 0x51, 0x5a, 0x31, 0x51, 0xdf, 0xc8, 0xb3, 0x09, 0xad, 0xe5, 0x6f, 0xa8,
+	0x37, 0xab, 0x3b, 0x47, 0x97, 0xd0, 0xdf, 0x0c, 0xf3, 0xc0, 0xb3, 0x09, 0xde, 0x76, 0xe7, 0x70,
+	0x29, 0x6a, 0xc0, 0xe6, 0x68, 0x4c, 0x43, 0x43, 0x78, 0x81, 0x21, 0x3b, 0xd5, 0x0a, 0x5c, 0x2f,
+	0x1b, 0xac, 0x29, 0xe5, 0xab, 0xe8, 0x0c, 0xd6, 0x46, 0xde, 0xd8, 0x0d, 0x0d, 0x8b, 0x9f, 0x7f,
+	0x68, 0xad, 0xb8, 0xd0, 0xc1, 0x78, 0x8e, 0x96, 0x0e, 0x18, 0x9c, 0x38, 0x4d, 0x51, 0xbc, 0x3a,
+	0x4a, 0x50, 0xcc, 0x90, 0x01, 0x19, 0x79, 0x21, 0x31, 0x58, 0xbc, 0xa4, 0xb5, 0x15, 0x61, 0x48,
+	0xc1, 0x63, 0xa1, 0x81, 0xa2, 0xdf, 0x80, 0xab, 0xb6, 0x43, 0xcd, 0x93, 0x21, 0x31, 0x86, 0xde,
+	0xc0, 0x98, 0xa4, 0x39, 0xb5, 0x12, 0xef, 0xbc, 0x25, 0x5b, 0xf7, 0xbd, 0x41, 0x2b, 0x6e, 0xe3,
+	0xa3, 0x2e, 0x5c, 0x73, 0xe4, 0x58, 0x06, 0xfb, 0xaa, 0xa1, 0x67, 0xda, 0xc6, 0x98, 0x92, 0x80,
+	0xd6, 0xca, 0x72, 0x94, 0x68, 0x7d, 0x26, 0x1b, 0x8f, 0x59, 0x1b, 0xfa, 0x06, 0x80, 0x75, 0x4a,
+	0xac, 0x33, 0xdf, 0x73, 0xdc, 0xb0, 0x06, 0xbc, 0x67, 0x82, 0xa3, 0xdf, 0x85, 0x4a, 0xc2, 0xe4,
+	0xa8, 0x04, 0xf9, 0xee, 0x61, 0xb7, 0xad, 0x5d, 0x41, 0x00, 0xc5, 0xd6, 0x1e, 0x3e, 0x3c, 0xec,
+	0x8b, 0x13, 0x4c, 0xe7, 0xa0, 0xf9, 0xb8, 0xad, 0xe5, 0x18, 0xfb, 0xb8, 0xfb, 0xdb, 0xed, 0xce,
+	0xbe, 0xa6, 0xea, 0x6d, 0x58, 0x4d, 0x2a, 0x02, 0x21, 0xa8, 0x1e, 0x77, 0x9f, 0x76, 0x0f, 0x9f,
+	0x75, 0x8d, 0x83, 0xc3, 0xe3, 0x6e, 0x9f, 0x9d, 0x83, 0xaa, 0x00, 0xcd, 0xee, 0xf3, 0x09, 0xbd,
+	0x06, 0xe5, 0xee, 0x61, 0x44, 0x2a, 0xf5, 0x9c, 0xa6, 0xe8, 0xbf, 0x52, 0x61, 0x6b, 0x9e, 0x4f,
+	0x20, 0x1b, 0xf2, 0xcc, 0xbf, 0xe4, 0x49, 0xf4, 0xfd, 0xbb, 0x17, 0x47, 0x67, 0xcb, 0xca, 0x37,
+	0xe5, 0xd6, 0x53, 0xc6, 0xfc, 0x3f, 0x32, 0xa0, 0x38, 0x34, 0x4f, 0xc8, 0x90, 0xd6, 0x54, 0x7e,
+	0x57, 0xf3, 0xf8, 0x32, 0x73, 0xef, 0x73, 0x24, 0x71, 0x51, 0x23, 0x61, 0x51, 0x1f, 0x2a, 0x2c,
+	0xb8, 0x52, 0xa1, 0x3a, 0x19, 0xef, 0x77, 0x32, 0xce, 0xb2, 0x37, 0x19, 0x89, 0x93, 0x30, 0xf5,
+	0x3b, 0x50, 0x49, 0x4c, 0x36, 0xe7, 0x9e, 0x65, 0x2b, 0x79, 0xcf, 0x52, 0x4e, 0x5e, 0x9a, 0x3c,
+	0x80, 0xad, 0x79, 0x3a, 0x62, 0x0e, 0xb1, 0x77, 0xd8, 0xeb, 0x8b, 0x13, 0xed, 0x63, 0x7c, 0x78,
+	0x7c, 0xa4, 0x29, 0x8c, 0xd9, 0x6f, 0xf6, 0x9e, 0x6a, 0xb9, 0xd8, 0x5f, 0x54, 0xbd, 0x05, 0x95,
+	0x84, 0x5c, 0xa9, 0xdd, 0x44, 0x49, 0xef, 0x26, 0x2c, 0x9e, 0x9b, 0xb6, 0x1d, 0x10, 0x4a, 0xa5,
+	0x1c, 0x11, 0xa9, 0xbf, 0x80, 0xf2, 0x6e, 0xb7, 0x27, 0x21, 0x6a, 0xb0, 0x42, 0x49, 0xc0, 0xbe,
+	0x9b, 0xdf, 0x98, 0x95, 0x71, 0x44, 0x32, 0x70, 0x4a, 0xcc, 0xc0, 0x3a, 0x25, 0x54, 0xe6, 0x20,
+	0x31, 0xcd, 0x46, 0x79, 0xfc, 0xe6, 0x49, 0xd8, 0xae, 0x8c, 0x23, 0x52, 0xff, 0x9f, 0x12, 0xc0,
+	0xe4, 0x16, 0x04, 0x55, 0x21, 0x17, 0xef, 0x0d, 0x39, 0xc7, 0x66, 0x7e, 0x90, 0xd8, 0xfb, 0xf8,
+	0x7f, 0xb4, 0x03, 0xdb, 0x23, 0x3a, 0xf0, 0x4d, 0xeb, 0xcc, 0x90, 0x97, 0x17, 0x22, 0x84, 0xf0,
+	0x38, 0xbb, 0x8a, 0x37, 0x65, 0xa3, 0x8c, 0x10, 0x02, 0x77, 0x1f, 0x54, 0xe2, 0xbe, 0xe6, 0x31,
+	0xb1, 0xb2, 0x73, 0x77, 0xe1, 0xdb, 0x99, 0x46, 0xdb, 0x7d, 0x2d, 0x7c, 0x85, 0xc1, 0x20, 0x03,
+	0xc0, 0x26, 0xaf, 0x1d, 0x8b, 0x18, 0x0c, 0xb4, 0xc0, 0x41, 0xbf, 0x58, 0x1c, 0x74, 0x97, 0x63,
+	0xc4, 0xd0, 0x65, 0x3b, 0xa2, 0x51, 0x17, 0xca, 0x01, 0xa1, 0xde, 0x38, 0xb0, 0x88, 0x08, 0x8c,
+	0xd9, 0x0f, 0x50, 0x38, 0x1a, 0x87, 0x27, 0x10, 0x68, 0x17, 0x8a, 0x3c, 0x1e, 0xb2, 0xc8, 0xa7,
+	0xfe, 0xda, 0xab, 0xde, 0x34, 0x18, 0x8f, 0x24, 0x58, 0x8e, 0x45, 0x8f, 0x61, 0x45, 0x88, 0x48,
+	0x6b, 0x25, 0x0e, 0xf3, 0x49, 0xd6, 0x60, 0xcd, 0x47, 0xe1, 0x68, 0x34, 0xb3, 0x2a, 0x0b, 0x92,
+	0x3c, 0x46, 0x96, 0x31, 0xff, 0x8f, 0x3e, 0x80, 0xb2, 0xc8, 0x0d, 0x6c, 0x27, 0xe0, 0x21, 0xb1,
+	0x8c, 0x45, 0xb2, 0xb0, 0xeb, 0x04, 0xe8, 0x43, 0xa8, 0x88, 0x1c, 0xd0, 0xe0, 0x51, 0xa1, 0xc2,
+	0x9b, 0x41, 0xb0, 0x8e, 0x58, 0x6c, 0x10, 0x1d, 0x48, 0x10, 0x88, 0x0e, 0xab, 0x71, 0x07, 0x12,
+	0x04, 0xbc, 0xc3, 0xff, 0x83, 0x75, 0x9e, 0x39, 0x0f, 0x02, 0x6f, 0xec, 0x1b, 0xdc, 0xa7, 0xd6,
+	0x78, 0xa7, 0x35, 0xc6, 0x7e, 0xcc, 0xb8, 0x5d, 0xe6, 0x5c, 0xd7, 0xa1, 0xf4, 0xca, 0x3b, 0x11,
+	0x1d, 0xaa, 0x62, 0x1d, 0xbc, 0xf2, 0x4e, 0xa2, 0xa6, 0x38, 0x7b, 0x59, 0x4f, 0x67, 0x2f, 0x5f,
+	0xc1, 0xd5, 0xd9, 0x6d, 0x98, 0x67, 0x31, 0xda, 0xe5, 0xb3, 0x98, 0x2d, 0x77, 0x0e, 0x17, 0x3d,
+	0x04, 0xd5, 0x76, 0x69, 0x6d, 0x63, 0x21, 0xe7, 0x88, 0xd7, 0x31, 0x66, 0x83, 0xd1, 0x36, 0x14,
+	0xd9, 0xc7, 0x3a, 0x76, 0x0d, 0x89, 0xd0, 0xf3, 0xca, 0x3b, 0xe9, 0xd8, 0xe8, 0x6b, 0x50, 0x66,
+	0xdf, 0x4f, 0x7d, 0xd3, 0x22, 0xb5, 0x4d, 0xde, 0x32, 0x61, 0x30, 0x43, 0xb9, 0x9e, 0x4d, 0x84,
+	0x8a, 0xb6, 0x84, 0xa1, 0x18, 0x83, 0xeb, 0xe8, 0x1a, 0xac, 0xf0, 0x46, 0xc7, 0xae, 0x6d, 0xf3,
+	0xa6, 0x22, 0x23, 0x3b, 0x36, 0xd2, 0x61, 0xcd, 0x37, 0x03, 0xe2, 0x86, 0x86, 0x9c, 0xf1, 0x2a,
+	0x6f, 0xae, 0x08, 0xe6, 0x13, 0x36, 0x6f, 0xfd, 0x33, 0x28, 0x45, 0x8b, 0x61, 0x91, 0x30, 0x59,
+	0xbf, 0x07, 0xd5, 0xf4, 0x52, 0x5a, 0x28, 0xc8, 0xfe, 0x43, 0x0e, 0xca, 0xf1, 0xa2, 0x41, 0x2e,
+	0x6c, 0x72, 0xa3, 0x9a, 0x21, 0xb1, 0x8d, 0xc9, 0x1a, 0x14, 0xf9, 0xf3, 0xfd, 0x8c, 0x6a, 0x6e,
+	0x46, 0x08, 0xf2, 0x20, 0x2f, 0x17, 0x24, 0x8a, 0x91, 0x27, 0xf3, 0x7d, 0x09, 0xeb, 0x43, 0xc7,
+	0x1d, 0x9f, 0x27, 0xe6, 0x12, 0x89, 0xef, 0x6f, 0x66, 0x9c, 0x6b, 0x9f, 0x8d, 0x9e, 0xcc, 0x51,
+	0x1d, 0xa6, 0x68, 0xb4, 0x07, 0x05, 0xdf, 0x0b, 0xc2, 0x68, 0xcf, 0xcc, 0xba, 0x9b, 0x1d, 0x79,
+	0x41, 0x78, 0x60, 0xfa, 0x3e, 0x3b, 0xdb, 0x09, 0x00, 0xfd, 0x3f, 0x72, 0x70, 0x75, 0xfe, 0x87,
+	0xa1, 0x2e, 0xa8, 0x96, 0x3f, 0x96, 0x4a, 0xba, 0xb7, 0xa8, 0x92, 0x5a, 0xfe, 0x78, 0x22, 0x3f,
+	0x03, 0x62, 0xf7, 0xdd, 0x23, 0x32, 0xf2, 0x82, 0x0b, 0xa9, 0x8b, 0x07, 0x8b, 0x42, 0x1e, 0xf0,
+	0xd1, 0x13, 0x54, 0x09, 0x87, 0x30, 0x94, 0xe4, 0x62, 0xa2, 0x32, 0x6c, 0x2f, 0x78, 0xfb, 0x16,
+	0x41, 0xe2, 0x18, 0x07, 0x3d, 0x85, 0x9c, 0xe3, 0xd5, 0x8a, 0x0b, 0xad, 0xf3, 0x58, 0xd0, 0xce,
+	0xe1, 0x44, 0xc8, 0x9c, 0xe3, 0xe9, 0x9f, 0xc1, 0xf6, 0x5c, 0xbd, 0xa0, 0xaf, 0x03, 0x58, 0xfe,
+	0xd8, 0xe0, 0x4f, 0x2d, 0xc2, 0x1d, 0x55, 0x5c, 0xb6, 0xfc, 0x71, 0x8f, 0x33, 0xf4, 0x17, 0x50,
+	0x7b, 0xdb, 0xc7, 0xb3, 0x05, 0x2b, 0x3e, 0xdf, 0x18, 0x9d, 0x70, 0x85, 0xaa, 0xb8, 0x24, 0x18,
+	0x07, 0x27, 0x6c, 0x5d, 0x46, 0x8d, 0xe6, 0x39, 0xeb, 0xa0, 0xf2, 0x0e, 0x15, 0xd9, 0xc1, 0x3c,
+	0x3f, 0x38, 0xd1, 0x7f, 0x9e, 0x83, 0xf5, 0xa9, 0xef, 0x67, 0xc7, 0x65, 0x11, 0xcd, 0xa3, 0x8b,
+	0x08, 0x41, 0xb1, 0xd0, 0x6e, 0x39, 0x76, 0x74, 0x85, 0xcd, 0xff, 0xf3, 0x4d, 0xdd, 0x97, 0xd7,
+	0xcb, 0x39, 0xc7, 0x67, 0x6b, 0x71, 0x74, 0xe2, 0x84, 0x94, 0x67, 0x58, 0x05, 0x2c, 0x08, 0xf4,
+	0x1c, 0xaa, 0x01, 0xe1, 0xc9, 0x84, 0x6d, 0x08, 0x97, 0x2d, 0x2c, 0xe4, 0xb2, 0x52, 0x42, 0xe6,
+	0xb9, 0x78, 0x2d, 0x42, 0x62, 0x14, 0x45, 0xcf, 0x60, 0x2d, 0xca, 0xd2, 0x05, 0x72, 0x71, 0x69,
+	0xe4, 0x55, 0x09, 0xc4, 0x81, 0xd9, 0xab, 0x56, 0xa2, 0x91, 0x7d, 0x18, 0x4f, 0x25, 0xa5, 0x4e,
+	0x04, 0x91, 0x0e, 0x3d, 0x05, 0x19, 0x7a, 0xf4, 0x13, 0xa8, 0x24, 0x16, 0xd9, 0x22, 0x43, 0x99,
+	0x3e, 0x43, 0x8f, 0xeb, 0xb3, 0x80, 0x73, 0xa1, 0xc7, 0x82, 0x2e, 0x4b, 0xe3, 0x0c, 0xc7, 0xe7,
+	0x1a, 0x2d, 0xe3, 0x22, 0x23, 0x3b, 0xbe, 0xfe, 0x8b, 0x1c, 0x54, 0xd3, 0xf1, 0x21, 0xf2, 0x23,
+	0x9f, 0x04, 0x8e, 0x67, 0x27, 0xfc, 0xe8, 0x88, 0x33, 0x98, 0xaf, 0xb0, 0xe6, 0xaf, 0xc6, 0x5e,
+	0x68, 0x46, 0xbe, 0x62, 0xf9, 0xe3, 0xdf, 0x62, 0xf4, 0x94, 0x0f, 0xaa, 0x53, 0x3e, 0x88, 0x3e,
+	0x06, 0x24, 0x5d, 0x69, 0xe8, 0x8c, 0x9c, 0xd0, 0x38, 0xb9, 0x08, 0x89, 0xb0, 0xb1, 0x8a, 0x35,
+	0xd1, 0xb2, 0xcf, 0x1a, 0x1e, 0x32, 0x3e, 0x73, 0x3c, 0xcf, 0x1b, 0x19, 0xd4, 0xf2, 0x02, 0x62,
+	0x98, 0xf6, 0x2b, 0x7e, 0x52, 0x54, 0x71, 0xc5, 0xf3, 0x46, 0x3d, 0xc6, 0x6b, 0xda, 0xaf, 0xd8,
+	0xae, 0x6e, 0xf9, 0x63, 0x4a, 0x42, 0x83, 0xfd, 0xf0, 0x35, 0x56, 0xc6, 0x20, 0x58, 0x2d, 0x7f,
+	0x4c, 0xd1, 0xb7, 0x60, 0x2d, 0xea, 0xc0, 0x37, 0x76, 0x99, 0x51, 0xac, 0xca, 0x2e, 0x9c, 0x87,
+	0x74, 0x58, 0x3d, 0x22, 0x81, 0x45, 0xdc, 0xb0, 0xef, 0x58, 0x67, 0x94, 0x9f, 0xe7, 0x14, 0x9c,
+	0xe2, 0x3d, 0xc9, 0x97, 0x56, 0xb4, 0x12, 0x8e, 0x66, 0x1b, 0x91, 0x11, 0xd5, 0xff, 0x49, 0x81,
+	0x02, 0xcf, 0x7f, 0x98, 0x52, 0x78, 0xee, 0xc0, 0x53, 0x0b, 0x99, 0x37, 0x33, 0x06, 0x4f, 0x2c,
+	0x3e, 0x80, 0x32, 0x57, 0x7e, 0xe2, 0xb8, 0xc2, 0x93, 0x6a, 0xde, 0x58, 0x87, 0x52, 0x40, 0x4c,
+	0xdb, 0x73, 0x87, 0xd1, 0x0d, 0x5c, 0x4c, 0xa3, 0xff, 0x0f, 0x9a, 0x1f, 0x78, 0xbe, 0x39, 0x98,
+	0x1c, 0xda, 0xa5, 0xf9, 0xd6, 0x13, 0x7c, 0x9e, 0xef, 0x7f, 0x0b, 0xd6, 0x28, 0x11, 0xdb, 0x84,
+	0x70, 0x92, 0x82, 0xf8, 0x4c, 0xc9, 0xe4, 0xc7, 0x0b, 0xfd, 0x2b, 0x28, 0x8a, 0x5d, 0xf0, 0x12,
+	0xf2, 0x7e, 0x02, 0x48, 0x28, 0x92, 0x39, 0xc8, 0xc8, 0xa1, 0x54, 0xa6, 0xec, 0xfc, 0x19, 0x59,
+	0xb4, 0x1c, 0x4d, 0x1a, 0xf4, 0x7f, 0x53, 0x00, 0x26, 0x0f, 0x7c, 0x2c, 0xcb, 0x67, 0xab, 0x86,
+	0x9d, 0x99, 0xc5, 0x4d, 0x62, 0x44, 0xb2, 0x4b, 0x34, 0x99, 0xa3, 0xe7, 0x96, 0x7d, 0x1f, 0x95,
+	0x00, 0xd1, 0xbb, 0x02, 0x91, 0xb7, 0x2a, 0x8b, 0xbe, 0x2b, 0x10, 0xf1, 0xae, 0x40, 0xd8, 0x95,
+	0x80, 0x3c, 0x3d, 0x08, 0xb8, 0x3c, 0x3f, 0x3c, 0x54, 0xec, 0xf8, 0xf1, 0x86, 0xe8, 0xff, 0xa9,
+	0xc4, 0x71, 0x2f, 0x7a, 0x64, 0x41, 0x5f, 0x42, 0x89, 0x85, 0x10, 0x63, 0x64, 0xfa, 0xb2, 0x64,
+	0xa0, 0xb5, 0xdc, 0xfb, 0x4d, 0xb4, 0xc5, 0x8a, 0xdc, 0x7f, 0xc5, 0x17, 0x14, 0x8b, 0x9f, 0xec,
+	0xdc, 0x15, 0xc5, 0x4f, 0xf6, 0x1f, 0x7d, 0x04, 0x55, 0x73, 0x1c, 0x7a, 0x86, 0x69, 0xbf, 0x26,
+	0x41, 0xe8, 0x50, 0x22, 0x7d, 0x69, 0x8d, 0x71, 0x9b, 0x11, 0xb3, 0x7e, 0x17, 0x56, 0x93, 0x98,
+	0xef, 0x4a, 0x82, 0x0a, 0xc9, 0x24, 0xe8, 0xf7, 0x01, 0x26, 0x17, 0x96, 0xcc, 0x47, 0xd8, 0xed,
+	0xa7, 0x61, 0x45, 0x07, 0xfd, 0x02, 0x2e, 0x31, 0x46, 0x8b, 0x39, 0x63, 0xfa, 0x35, 0xa5, 0x10,
+	0xbd, 0xa6, 0xb0, 0xe8, 0xc0, 0x16, 0xf4, 0x99, 0x33, 0x1c, 0xc6, 0x97, 0xa8, 0x65, 0xcf, 0x1b,
+	0x3d, 0xe5, 0x0c, 0xfd, 0x97, 0x39, 0xe1, 0x2b, 0xe2, 0x5d, 0x2c, 0xd3, 0x41, 0xef, 0x7d, 0x99,
+	0xfa, 0x0e, 0x00, 0x0d, 0xcd, 0x80, 0x65, 0x74, 0x66, 0x74, 0x8d, 0x5b, 0x9f, 0x79, 0x8e, 0xe9,
+	0x47, 0x85, 0x3a, 0xb8, 0x2c, 0x7b, 0x37, 0x43, 0x74, 0x1f, 0x56, 0x2d, 0x6f, 0xe4, 0x0f, 0x89,
+	0x1c, 0x5c, 0x78, 0xe7, 0xe0, 0x4a, 0xdc, 0xbf, 0x19, 0x26, 0x2e, 0x8f, 0x8b, 0x97, 0xbd, 0x3c,
+	0xfe, 0x85, 0x22, 0x9e, 0xf7, 0x92, 0xaf, 0x8b, 0x68, 0x30, 0xa7, 0x84, 0xe5, 0xf1, 0x92, 0x4f,
+	0x95, 0xbf, 0xae, 0x7e, 0xa5, 0x7e, 0x3f, 0x4b, 0xc1, 0xc8, 0xdb, 0x73, 0xec, 0x7f, 0x51, 0xa1,
+	0x1c, 0x99, 0x65, 0xd6, 0xf6, 0x9f, 0x43, 0x39, 0xae, 0x92, 0xaa, 0xe5, 0xde, 0xa9, 0xe1, 0x49,
+	0x67, 0xf4, 0x12, 0x90, 0x39, 0x18, 0xc4, 0xb9, 0xb3, 0x31, 0xa6, 0xe6, 0x20, 0x7a, 0x57, 0xfd,
+	0x7c, 0x01, 0x3d, 0x44, 0xfb, 0xe3, 0x31, 0x1b, 0x8f, 0x35, 0x73, 0x30, 0x48, 0x71, 0xd0, 0x1f,
+	0xc0, 0x76, 0x7a, 0x0e, 0xe3, 0xe4, 0xc2, 0xf0, 0x1d, 0x5b, 0x5e, 0x28, 0xec, 0x2d, 0xfa, 0xb8,
+	0xd9, 0x48, 0xc1, 0x3f, 0xbc, 0x38, 0x72, 0x6c, 0xa1, 0x73, 0x14, 0xcc, 0x34, 0xd4, 0xff, 0x08,
+	0xae, 0xbd, 0xa5, 0xfb, 0x1c, 0x1b, 0x74, 0xd3, 0x45, 0x3b, 0xcb, 0x2b, 0x21, 0x61, 0xbd, 0xbf,
+	0x57, 0x60, 0x63, 0xa6, 0x03, 0x6a, 0x26, 0x93, 0xfe, 0x5b, 0x19, 0xe7, 0x69, 0x1d, 0x1d, 0x0b,
+	0x78, 0x36, 0x16, 0x3d, 0x99, 0xca, 0xf3, 0xb3, 0x26, 0x64, 0x22, 0xc3, 0x15, 0x40, 0x12, 0x41,
+	0xff, 0x47, 0x15, 0x4a, 0x11, 0x3a, 0xbf, 0x0e, 0xb8, 0xa0, 0x21, 0x19, 0x19, 0xf1, 0x5d, 0xa5,
+	0x82, 0x41, 0xb0, 0xf8, 0x8e, 0xfa, 0x01, 0x94, 0xc7, 0x94, 0x04, 0xa2, 0x39, 0xc7, 0x9b, 0x4b,
+	0x8c, 0xc1, 0x1b, 0x3f, 0x84, 0x4a, 0xe8, 0x85, 0xe6, 0xd0, 0x08, 0x79, 0xbe, 0xa0, 0x8a, 0xd1,
+	0x9c, 0xc5, 0xb3, 0x05, 0xf4, 0x1d, 0xd8, 0x08, 0x4f, 0x03, 0x2f, 0x0c, 0x87, 0x2c, 0x57, 0xe5,
+	0x99, 0x93, 0x48, 0x74, 0xf2, 0x58, 0x8b, 0x1b, 0x44, 0x46, 0x45, 0x59, 0xf4, 0x9e, 0x74, 0x66,
+	0xae, 0xcb, 0x83, 0x48, 0x1e, 0xaf, 0xc5, 0x5c, 0xe6, 0xda, 0x6c, 0xf3, 0xf4, 0x45, 0x46, 0xc2,
+	0x63, 0x85, 0x82, 0x23, 0x12, 0x19, 0xb0, 0x3e, 0x22, 0x26, 0x1d, 0x07, 0xc4, 0x36, 0x5e, 0x3a,
+	0x64, 0x68, 0x8b, 0x5b, 0x9c, 0x6a, 0xe6, 0xb3, 0x4b, 0xa4, 0x96, 0xc6, 0x23, 0x3e, 0x1a, 0x57,
+	0x23, 0x38, 0x41, 0xb3, 0xcc, 0x41, 0xfc, 0x43, 0xeb, 0x50, 0xe9, 0x3d, 0xef, 0xf5, 0xdb, 0x07,
+	0xc6, 0xc1, 0xe1, 0x6e, 0x5b, 0xd6, 0x65, 0xf5, 0xda, 0x58, 0x90, 0x0a, 0x6b, 0xef, 0x1f, 0xf6,
+	0x9b, 0xfb, 0x46, 0xbf, 0xd3, 0x7a, 0xda, 0xd3, 0x72, 0x68, 0x1b, 0x36, 0xfa, 0x7b, 0xf8, 0xb0,
+	0xdf, 0xdf, 0x6f, 0xef, 0x1a, 0x47, 0x6d, 0xdc, 0x39, 0xdc, 0xed, 0x69, 0x2a, 0xbb, 0x74, 0x9e,
+	0xb0, 0xfb, 0x9d, 0x83, 0xb6, 0x96, 0x67, 0x95, 0x38, 0x47, 0x6d, 0xdc, 0x6a, 0x77, 0xfb, 0x5a,
+	0x41, 0xff, 0xb9, 0x0a, 0x95, 0x84, 0x15, 0x99, 0x23, 0x07, 0x54, 0x9c, 0x6b, 0xf2, 0x98, 0xfd,
+	0xe5, 0xef, 0xc8, 0xa6, 0x75, 0x2a, 0xac, 0x93, 0xc7, 0x82, 0xe0, 0x67, 0x19, 0xf3, 0x3c, 0xb1,
+	0xce, 0xf3, 0xb8, 0x34, 0x32, 0xcf, 0x05, 0xc8, 0x37, 0x61, 0xf5, 0x8c, 0x04, 0x2e, 0x19, 0xca,
+	0x76, 0x61, 0x91, 0x8a, 0xe0, 0x89, 0x2e, 0x37, 0x41, 0x93, 0x5d, 0x26, 0x30, 0xc2, 0x1c, 0x55,
+	0xc1, 0x3f, 0x88, 0xc0, 0xb6, 0xa0, 0x20, 0x9a, 0x57, 0xc4, 0xfc, 0x9c, 0x60, 0xdb, 0x14, 0x7d,
+	0x63, 0xfa, 0x3c, 0x87, 0xcc, 0x63, 0xfe, 0x1f, 0x9d, 0xcc, 0xda, 0xa7, 0xc8, 0xed, 0x73, 0x67,
+	0x71, 0x77, 0x7e, 0x9b, 0x89, 0x4e, 0x63, 0x13, 0xad, 0x80, 0x8a, 0xa3, 0x62, 0xa6, 0x56, 0xb3,
+	0xb5, 0xc7, 0xcc, 0xb2, 0x06, 0xe5, 0x83, 0xe6, 0x0f, 0x8d, 0xe3, 0x9e, 0x78, 0x0e, 0xd0, 0x60,
+	0xf5, 0x69, 0x1b, 0x77, 0xdb, 0xfb, 0x92, 0xa3, 0xa2, 0x2d, 0xd0, 0x24, 0x67, 0xd2, 0x2f, 0xcf,
+	0x10, 0xc4, 0xdf, 0x02, 0xbb, 0x32, 0xee, 0x3d, 0x6b, 0x1e, 0x69, 0x45, 0xfd, 0xdf, 0x73, 0xb0,
+	0x2e, 0xb6, 0x85, 0xb8, 0xec, 0xe2, 0xed, 0xcf, 0xce, 0xc9, 0x2b, 0xb1, 0x5c, 0xfa, 0x4a, 0x2c,
+	0x4a, 0x42, 0xf9, 0xae, 0xae, 0x4e, 0x92, 0x50, 0x7e, 0x4d, 0x94, 0x8a, 0xf8, 0xf9, 0x45, 0x22,
+	0x7e, 0x0d, 0x56, 0x46, 0x84, 0xc6, 0x76, 0x2b, 0xe3, 0x88, 0x44, 0x0e, 0x54, 0x4c, 0xd7, 0xf5,
+	0x42, 0x53, 0xdc, 0x33, 0x17, 0x17, 0xda, 0x0c, 0xa7, 0xbe, 0xb8, 0xd1, 0x9c, 0x20, 0x89, 0xc0,
+	0x9c, 0xc4, 0xae, 0xff, 0x00, 0xb4, 0xe9, 0x0e, 0x0b, 0x6d, 0x87, 0x7f, 0xa3, 0xc0, 0xd6, 0xbc,
+	0x2b, 0x00, 0x96, 0x5b, 0xbd, 0x99, 0x3c, 0xc5, 0xab, 0x58, 0x52, 0x4c, 0xcf, 0x01, 0x31, 0x6d,
+	0xe3, 0xc4, 0xa7, 0xf2, 0x54, 0xb6, 0xc2, 0xe8, 0x87, 0x3e, 0x3f, 0xdd, 0xbf, 0x09, 0x9c, 0x90,
+	0xf0, 0x36, 0x71, 0x26, 0x2b, 0x71, 0x06, 0x6b, 0xfc, 0x14, 0xa2, 0xcb, 0x43, 0x83, 0x0c, 0x02,
+	0x42, 0xa9, 0x31, 0x39, 0x78, 0xab, 0x18, 0xc9, 0xb6, 0x36, 0x6f, 0x3a, 0x60, 0x2d, 0xfa, 0x9f,
+	0x2a, 0x50, 0x3f, 0xf6, 0x6d, 0x53, 0x3c, 0x8b, 0xc5, 0x92, 0xbd, 0xb3, 0x12, 0x26, 0x75, 0x63,
+	0x9d, 0xbb, 0xf4, 0x8d, 0xb5, 0xfe, 0x75, 0xf8, 0x60, 0xae, 0x18, 0xf2, 0xc1, 0xf6, 0x63, 0xd8,
+	0x3a, 0x0a, 0xc8, 0x4b, 0x12, 0x5a, 0xa7, 0x9d, 0x11, 0xdb, 0x06, 0xa4, 0x7c, 0x5b, 0x50, 0x70,
+	0x18, 0x1d, 0x1d, 0xa3, 0x39, 0xa1, 0x5f, 0x83, 0xed, 0xa9, 0xde, 0x12, 0xe6, 0x21, 0x6c, 0xb7,
+	0xe2, 0x67, 0xb7, 0x4c, 0x15, 0x3f, 0x1a, 0xa8, 0xec, 0x82, 0x5a, 0x56, 0xe8, 0xd9, 0x4e, 0xc0,
+	0x5e, 0x95, 0xa7, 0x31, 0x24, 0xfa, 0x88, 0x15, 0xae, 0xd2, 0xd0, 0x0b, 0xc8, 0xfb, 0xaf, 0x14,
+	0x9d, 0x23, 0xc8, 0xaf, 0x14, 0xd8, 0x4c, 0xcd, 0x37, 0xa9, 0x4f, 0x94, 0xa5, 0x9b, 0xca, 0xff,
+	0x45, 0xe9, 0x66, 0xee, 0xbd, 0x56, 0xb1, 0x7d, 0xfb, 0xbb, 0x93, 0x4c, 0x91, 0xb0, 0x3d, 0x43,
+	0x3e, 0x5e, 0x6a, 0x57, 0x18, 0x81, 0x8f, 0xbb, 0xdd, 0x4e, 0xf7, 0xb1, 0xa6, 0xb0, 0x27, 0xcf,
+	0xf6, 0x0f, 0x3b, 0xac, 0x78, 0x38, 0xb7, 0xf3, 0x5f, 0xdb, 0x50, 0x14, 0x0b, 0x18, 0xfd, 0x4c,
+	0x66, 0xc9, 0xc9, 0x72, 0x77, 0xf4, 0x83, 0x85, 0x75, 0x9c, 0x2a, 0xa1, 0xaf, 0x3f, 0x58, 0x7a,
+	0xbc, 0x74, 0x84, 0x2b, 0xe8, 0xcf, 0x15, 0x58, 0x4d, 0x95, 0x16, 0x64, 0x7d, 0x83, 0x9a, 0x53,
+	0x5d, 0x5f, 0xff, 0xfe, 0x52, 0x63, 0x63, 0x59, 0x7e, 0xa2, 0x40, 0x25, 0x51, 0x57, 0x8e, 0xee,
+	0x2c, 0x53, 0x8b, 0x2e, 0x24, 0xb9, 0xbb, 0x7c, 0x19, 0xbb, 0x7e, 0xe5, 0x53, 0x05, 0xfd, 0x99,
+	0x02, 0x95, 0x44, 0x85, 0x75, 0x66, 0x51, 0x66, 0xeb, 0xc1, 0xeb, 0x77, 0x97, 0x19, 0x1a, 0xeb,
+	0xe4, 0x8f, 0x15, 0x28, 0xc7, 0xd5, 0xd2, 0xe8, 0xf6, 0xe2, 0xf5, 0xd5, 0x42, 0x88, 0xcf, 0x97,
+	0x2d, 0xcc, 0xd6, 0xaf, 0xa0, 0x3f, 0x84, 0x52, 0x54, 0x5a, 0x8c, 0xb2, 0xae, 0xa6, 0xa9, 0xba,
+	0xe5, 0xfa, 0xed, 0x85, 0xc7, 0x25, 0xa7, 0x8f, 0xea, 0x7d, 0x33, 0x4f, 0x3f, 0x55, 0x99, 0x5c,
+	0xbf, 0xbd, 0xf0, 0xb8, 0x78, 0x7a, 0xe6, 0x09, 0x89, 0xb2, 0xe0, 0xcc, 0x9e, 0x30, 0x5b, 0x8f,
+	0x5c, 0xbf, 0xbb, 0xcc, 0xd0, 0x94, 0x20, 0x89, 0xc2, 0xe2, 0xcc, 0x82, 0xcc, 0x16, 0x2f, 0xd7,
+	0xef, 0x2e, 0x33, 0x34, 0x16, 0xe4, 0xc7, 0x4a, 0xf2, 0xcc, 0x7c, 0x7b, 0xe1, 0xfa, 0xd9, 0x05,
+	0x5d, 0x72, 0xa6, 0x82, 0x97, 0x2f, 0xd0, 0x1f, 0xcb, 0x1b, 0x3e, 0x51, 0x7e, 0x8b, 0x16, 0x01,
+	0x4b, 0x55, 0xec, 0xd6, 0x3f, 0x5b, 0x2e, 0x11, 0xe3, 0x42, 0xfc, 0x89, 0x02, 0x30, 0x29, 0xd4,
+	0xcd, 0x2c, 0xc4, 0x4c, 0x85, 0x70, 0xfd, 0xce, 0x12, 0x23, 0x93, 0x0b, 0x24, 0x2a, 0x24, 0xcc,
+	0xbc, 0x40, 0xa6, 0x0a, 0x89, 0xeb, 0xb7, 0x17, 0x1e, 0x17, 0x4f, 0xff, 0xb7, 0x0a, 0x6c, 0xcc,
+	0x14, 0x32, 0xa2, 0x07, 0x97, 0xac, 0x65, 0xad, 0x7f, 0xb1, 0x3c, 0x40, 0x24, 0xda, 0x4d, 0xe5,
+	0x53, 0x05, 0xfd, 0x85, 0x02, 0x6b, 0xe9, 0x02, 0xaf, 0xcc, 0xbb, 0xd4, 0x9c, 0x92, 0xc8, 0xfa,
+	0xbd, 0xe5, 0x06, 0xc7, 0xda, 0xfa, 0xa9, 0x02, 0x55, 0xb9, 0xbe, 0x23, 0x79, 0xee, 0x2d, 0x16,
+	0x16, 0xa6, 0x04, 0xba, 0xbf, 0xe4, 0xe8, 0x58, 0xa2, 0xbf, 0x53, 0x60, 0x73, 0x4e, 0x46, 0x8b,
+	0x9a, 0x19, 0x81, 0xdf, 0x9e, 0x94, 0xd7, 0x1f, 0x5e, 0x06, 0x22, 0x16, 0x90, 0x59, 0x30, 0x95,
+	0x25, 0x67, 0xb6, 0xe0, 0xbc, 0x4c, 0xbc, 0x7e, 0x6f, 0xb9, 0xc1, 0x29, 0x0b, 0xa6, 0xf3, 0xea,
+	0xcc, 0x16, 0x9c, 0x9b, 0xd2, 0xd7, 0xef, 0x2f, 0x39, 0x3a, 0xb5, 0x33, 0x24, 0xf2, 0xeb, 0x05,
+	0x92, 0x95, 0xe9, 0x33, 0x40, 0xfd, 0xee, 0x32, 0x43, 0x23, 0x41, 0x1e, 0xae, 0xfc, 0x4e, 0x41,
+	0x1c, 0x92, 0x8b, 0xfc, 0xe7, 0x7b, 0xff, 0x3b, 0x00, 0x52, 0x8f, 0xb0, 0xd2, 0xe0, 0x3a, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4083,6 +4522,20 @@ type DriverClient interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(ctx context.Context, in *DestroyNetworkRequest, opts ...grpc.CallOption) (*DestroyNetworkResponse, error)
+	// UpdateTaskResources changes the resource limits of a running task in
+	// place. This rpc is only implemented if the driver can resize tasks
+	// without restarting them.
+	UpdateTaskResources(ctx context.Context, in *UpdateTaskResourcesRequest, opts ...grpc.CallOption) (*UpdateTaskResourcesResponse, error)
+	// PrefetchImage pulls an image ahead of the tasks that use it. This rpc is
+	// only implemented if the driver runs tasks from images.
+	PrefetchImage(ctx context.Context, in *PrefetchImageRequest, opts ...grpc.CallOption) (*PrefetchImageResponse, error)
+	// CheckpointTask dumps the state of a running task into a directory. This
+	// rpc is only implemented if the driver supports the checkpoint capability.
+	CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from the state previously dumped by
+	// CheckpointTask. This rpc is only implemented if the driver supports the
+	// checkpoint capability.
+	RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error)
 }
 
 type driverClient struct {
@@ -4328,6 +4781,42 @@ func (c *driverClient) DestroyNetwork(ctx context.Context, in *DestroyNetworkReq
 	return out, nil
 }
 
+func (c *driverClient) UpdateTaskResources(ctx context.Context, in *UpdateTaskResourcesRequest, opts ...grpc.CallOption) (*UpdateTaskResourcesResponse, error) {
+	out := new(UpdateTaskResourcesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/UpdateTaskResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) PrefetchImage(ctx context.Context, in *PrefetchImageRequest, opts ...grpc.CallOption) (*PrefetchImageResponse, error) {
+	out := new(PrefetchImageResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/PrefetchImage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error) {
+	out := new(CheckpointTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error) {
+	out := new(RestoreTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DriverServer is the server API for Driver service.
 type DriverServer interface {
 	// TaskConfigSchema returns the schema for parsing the driver
@@ -4383,6 +4872,20 @@ type DriverServer interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(context.Context, *DestroyNetworkRequest) (*DestroyNetworkResponse, error)
+	// UpdateTaskResources changes the resource limits of a running task in
+	// place. This rpc is only implemented if the driver can resize tasks
+	// without restarting them.
+	UpdateTaskResources(context.Context, *UpdateTaskResourcesRequest) (*UpdateTaskResourcesResponse, error)
+	// PrefetchImage pulls an image ahead of the tasks that use it. This rpc is
+	// only implemented if the driver runs tasks from images.
+	PrefetchImage(context.Context, *PrefetchImageRequest) (*PrefetchImageResponse, error)
+	// CheckpointTask dumps the state of a running task into a directory. This
+	// rpc is only implemented if the driver supports the checkpoint capability.
+	CheckpointTask(context.Context, *CheckpointTaskRequest) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from the state previously dumped by
+	// CheckpointTask. This rpc is only implemented if the driver supports the
+	// checkpoint capability.
+	RestoreTask(context.Context, *RestoreTaskRequest) (*RestoreTaskResponse, error)
 }
 
 // UnimplementedDriverServer can be embedded to have forward compatible implementations.
@@ -4437,6 +4940,18 @@ func (*UnimplementedDriverServer) CreateNetwork(ctx context.Context, req *Create
 func (*UnimplementedDriverServer) DestroyNetwork(ctx context.Context, req *DestroyNetworkRequest) (*DestroyNetworkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyNetwork not implemented")
 }
+func (*UnimplementedDriverServer) UpdateTaskResources(ctx context.Context, req *UpdateTaskResourcesRequest) (*UpdateTaskResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskResources not implemented")
+}
+func (*UnimplementedDriverServer) PrefetchImage(ctx context.Context, req *PrefetchImageRequest) (*PrefetchImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrefetchImage not implemented")
+}
+func (*UnimplementedDriverServer) CheckpointTask(ctx context.Context, req *CheckpointTaskRequest) (*CheckpointTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckpointTask not implemented")
+}
+func (*UnimplementedDriverServer) RestoreTask(ctx context.Context, req *RestoreTaskRequest) (*RestoreTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTask not implemented")
+}
 
 func RegisterDriverServer(s *grpc.Server, srv DriverServer) {
 	s.RegisterService(&_Driver_serviceDesc, srv)
@@ -4747,6 +5262,78 @@ func _Driver_DestroyNetwork_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Driver_UpdateTaskResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).UpdateTaskResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/UpdateTaskResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).UpdateTaskResources(ctx, req.(*UpdateTaskResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_PrefetchImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrefetchImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).PrefetchImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/PrefetchImage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).PrefetchImage(ctx, req.(*PrefetchImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_CheckpointTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).CheckpointTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).CheckpointTask(ctx, req.(*CheckpointTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_RestoreTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).RestoreTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).RestoreTask(ctx, req.(*RestoreTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Driver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.drivers.proto.Driver",
 	HandlerType: (*DriverServer)(nil),
@@ -4799,6 +5386,22 @@ var _Driver_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyNetwork",
 			Handler:    _Driver_DestroyNetwork_Handler,
 		},
+		{
+			MethodName: "UpdateTaskResources",
+			Handler:    _Driver_UpdateTaskResources_Handler,
+		},
+		{
+			MethodName: "PrefetchImage",
+			Handler:    _Driver_PrefetchImage_Handler,
+		},
+		{
+			MethodName: "CheckpointTask",
+			Handler:    _Driver_CheckpointTask_Handler,
+		},
+		{
+			MethodName: "RestoreTask",
+			Handler:    _Driver_RestoreTask_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
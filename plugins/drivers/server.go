@@ -51,6 +51,7 @@ func (b *driverPluginServer) Capabilities(ctx context.Context, req *proto.Capabi
 			NetworkIsolationModes: []proto.NetworkIsolationSpec_NetworkIsolationMode{},
 			RemoteTasks:           caps.RemoteTasks,
 			DynamicWorkloadUsers:  caps.DynamicWorkloadUsers,
+			Checkpoint:            caps.Checkpoint,
 		},
 	}
 
@@ -424,3 +425,82 @@ func (b *driverPluginServer) DestroyNetwork(ctx context.Context, req *proto.Dest
 
 	return &proto.DestroyNetworkResponse{}, nil
 }
+
+func (b *driverPluginServer) UpdateTaskResources(ctx context.Context, req *proto.UpdateTaskResourcesRequest) (*proto.UpdateTaskResourcesResponse, error) {
+	d, ok := b.impl.(ResourceUpdateDriver)
+	if !ok {
+		return nil, fmt.Errorf("UpdateTaskResources RPC not supported by driver")
+	}
+
+	if err := d.UpdateResources(req.TaskId, ResourcesFromProto(req.Resources)); err != nil {
+		return nil, err
+	}
+	return &proto.UpdateTaskResourcesResponse{}, nil
+}
+
+func (b *driverPluginServer) PrefetchImage(ctx context.Context, req *proto.PrefetchImageRequest) (*proto.PrefetchImageResponse, error) {
+	d, ok := b.impl.(ImagePrefetchDriver)
+	if !ok {
+		return nil, fmt.Errorf("PrefetchImage RPC not supported by driver")
+	}
+
+	if err := d.PrefetchImage(req.Image); err != nil {
+		return nil, err
+	}
+	return &proto.PrefetchImageResponse{}, nil
+}
+
+func (b *driverPluginServer) CheckpointTask(ctx context.Context, req *proto.CheckpointTaskRequest) (*proto.CheckpointTaskResponse, error) {
+	d, ok := b.impl.(CheckpointDriver)
+	if !ok {
+		return nil, fmt.Errorf("CheckpointTask RPC not supported by driver")
+	}
+
+	if err := d.CheckpointTask(req.TaskId, req.Dir); err != nil {
+		return nil, err
+	}
+	return &proto.CheckpointTaskResponse{}, nil
+}
+
+func (b *driverPluginServer) RestoreTask(ctx context.Context, req *proto.RestoreTaskRequest) (*proto.RestoreTaskResponse, error) {
+	d, ok := b.impl.(CheckpointDriver)
+	if !ok {
+		return nil, fmt.Errorf("RestoreTask RPC not supported by driver")
+	}
+
+	handle, net, err := d.RestoreTask(taskConfigFromProto(req.Task), req.Dir)
+	if err != nil {
+		if rec, ok := err.(structs.Recoverable); ok {
+			st := status.New(codes.FailedPrecondition, rec.Error())
+			st, err := st.WithDetails(&sproto.RecoverableError{Recoverable: rec.IsRecoverable()})
+			if err != nil {
+				// If this error, it will always error
+				panic(err)
+			}
+			return nil, st.Err()
+		}
+		return nil, err
+	}
+
+	var pbNet *proto.NetworkOverride
+	if net != nil {
+		pbNet = &proto.NetworkOverride{
+			PortMap:       map[string]int32{},
+			Addr:          net.IP,
+			AutoAdvertise: net.AutoAdvertise,
+		}
+		for k, v := range net.PortMap {
+			if v > math.MaxInt32 {
+				return nil, fmt.Errorf("port map out of bounds")
+			}
+			pbNet.PortMap[k] = int32(v)
+		}
+	}
+
+	resp := &proto.RestoreTaskResponse{
+		Handle:          taskHandleToProto(handle),
+		NetworkOverride: pbNet,
+	}
+
+	return resp, nil
+}
@@ -124,6 +124,13 @@ func ResourcesFromProto(pb *proto.Resources) *Resources {
 			r.NomadResources.Memory.MemoryMaxMB = pb.AllocatedResources.Memory.MemoryMaxMb
 		}
 
+		if pb.AllocatedResources.Io != nil {
+			r.NomadResources.IO.Weight = pb.AllocatedResources.Io.Weight
+			r.NomadResources.IO.ReadBps = pb.AllocatedResources.Io.ReadBps
+			r.NomadResources.IO.WriteBps = pb.AllocatedResources.Io.WriteBps
+			r.NomadResources.IO.NetworkEgressMBits = pb.AllocatedResources.Io.NetworkEgressMbits
+		}
+
 		for _, network := range pb.AllocatedResources.Networks {
 			var n structs.NetworkResource
 			n.Device = network.Device
@@ -190,6 +197,12 @@ func ResourcesToProto(r *Resources) *proto.Resources {
 				MemoryMb:    r.NomadResources.Memory.MemoryMB,
 				MemoryMaxMb: r.NomadResources.Memory.MemoryMaxMB,
 			},
+			Io: &proto.AllocatedIOResources{
+				Weight:             r.NomadResources.IO.Weight,
+				ReadBps:            r.NomadResources.IO.ReadBps,
+				WriteBps:           r.NomadResources.IO.WriteBps,
+				NetworkEgressMbits: r.NomadResources.IO.NetworkEgressMBits,
+			},
 			Networks: make([]*proto.NetworkResource, len(r.NomadResources.Networks)),
 		}
 
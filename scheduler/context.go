@@ -5,6 +5,7 @@ package scheduler
 
 import (
 	"regexp"
+	"time"
 
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
@@ -46,6 +47,10 @@ type Context interface {
 	// eval.
 	Eligibility() *EvalEligibility
 
+	// PreemptionBudgets returns a tracker for the preemption budgets of jobs
+	// and namespaces in the context of the eval.
+	PreemptionBudgets() *PreemptionBudgets
+
 	// SendEvent provides best-effort delivery of scheduling and placement
 	// events.
 	SendEvent(event interface{})
@@ -135,6 +140,7 @@ type EvalContext struct {
 	logger      log.Logger
 	metrics     *structs.AllocMetric
 	eligibility *EvalEligibility
+	budgets     *PreemptionBudgets
 }
 
 // NewEvalContext constructs a new EvalContext
@@ -167,6 +173,7 @@ func (e *EvalContext) Metrics() *structs.AllocMetric {
 
 func (e *EvalContext) SetState(s State) {
 	e.state = s
+	e.budgets = nil
 }
 
 func (e *EvalContext) Reset() {
@@ -224,6 +231,14 @@ func (e *EvalContext) Eligibility() *EvalEligibility {
 	return e.eligibility
 }
 
+func (e *EvalContext) PreemptionBudgets() *PreemptionBudgets {
+	if e.budgets == nil {
+		e.budgets = NewPreemptionBudgets(e.state, time.Now())
+	}
+
+	return e.budgets
+}
+
 func (e *EvalContext) SendEvent(event interface{}) {
 	if e == nil || e.eventsCh == nil {
 		return
@@ -137,7 +137,8 @@ func NewRandomIterator(ctx Context, nodes []*structs.Node) *StaticIterator {
 // HostVolumeChecker is a FeasibilityChecker which returns whether a node has
 // the host volumes necessary to schedule a task group.
 type HostVolumeChecker struct {
-	ctx Context
+	ctx       Context
+	namespace string
 
 	// volumes is a map[HostVolumeName][]RequestedVolume. The requested volumes are
 	// a slice because a single task group may request the same volume multiple times.
@@ -151,6 +152,12 @@ func NewHostVolumeChecker(ctx Context) *HostVolumeChecker {
 	}
 }
 
+// SetNamespace sets the namespace of the job, which dynamic host volumes must
+// belong to.
+func (h *HostVolumeChecker) SetNamespace(namespace string) {
+	h.namespace = namespace
+}
+
 // SetVolumes takes the volumes required by a task group and updates the checker.
 func (h *HostVolumeChecker) SetVolumes(allocName string, volumes map[string]*structs.VolumeRequest) {
 	lookupMap := make(map[string][]*structs.VolumeRequest)
@@ -202,6 +209,12 @@ func (h *HostVolumeChecker) hasVolumes(n *structs.Node) bool {
 			return false
 		}
 
+		// Dynamic host volumes can only be placed once they're ready, and
+		// only by jobs in the volume's namespace.
+		if nodeVolume.ID != "" && !h.dynamicVolumeReady(nodeVolume.ID) {
+			return false
+		}
+
 		// If the volume supports being mounted as ReadWrite, we do not need to
 		// do further validation for readonly placement.
 		if !nodeVolume.ReadOnly {
@@ -220,6 +233,17 @@ func (h *HostVolumeChecker) hasVolumes(n *structs.Node) bool {
 	return true
 }
 
+// dynamicVolumeReady returns whether the dynamic host volume with the given ID
+// is in the job's namespace and ready.
+func (h *HostVolumeChecker) dynamicVolumeReady(id string) bool {
+	vol, err := h.ctx.State().HostVolumeByID(nil, h.namespace, id)
+	if err != nil {
+		h.ctx.Logger().Error("failed to look up host volume", "volume_id", id, "error", err)
+		return false
+	}
+	return vol != nil && vol.State == structs.HostVolumeStateReady
+}
+
 type CSIVolumeChecker struct {
 	ctx       Context
 	namespace string
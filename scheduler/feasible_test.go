@@ -258,6 +258,83 @@ func TestHostVolumeChecker_ReadOnly(t *testing.T) {
 	}
 }
 
+func TestHostVolumeChecker_Dynamic(t *testing.T) {
+	ci.Parallel(t)
+
+	store, ctx := testContext(t)
+
+	node := mock.Node()
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	ready := &structs.HostVolume{
+		ID:        uuid.Generate(),
+		Name:      "ready",
+		Namespace: structs.DefaultNamespace,
+		PluginID:  structs.HostVolumePluginMkdirID,
+		NodeID:    node.ID,
+		State:     structs.HostVolumeStateReady,
+	}
+	pending := &structs.HostVolume{
+		ID:        uuid.Generate(),
+		Name:      "pending",
+		Namespace: structs.DefaultNamespace,
+		PluginID:  structs.HostVolumePluginMkdirID,
+		NodeID:    node.ID,
+		State:     structs.HostVolumeStatePending,
+	}
+	must.NoError(t, store.UpsertHostVolumes(structs.MsgTypeTestSetup, 1001,
+		[]*structs.HostVolume{ready, pending}))
+
+	node.HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+		"ready":   {Name: "ready", ID: ready.ID},
+		"pending": {Name: "pending", ID: pending.ID},
+		"deleted": {Name: "deleted", ID: uuid.Generate()},
+	}
+
+	cases := []struct {
+		name      string
+		namespace string
+		source    string
+		expect    bool
+	}{
+		{
+			name:      "ready",
+			namespace: structs.DefaultNamespace,
+			source:    "ready",
+			expect:    true,
+		},
+		{
+			name:      "pending",
+			namespace: structs.DefaultNamespace,
+			source:    "pending",
+			expect:    false,
+		},
+		{
+			name:      "not in state",
+			namespace: structs.DefaultNamespace,
+			source:    "deleted",
+			expect:    false,
+		},
+		{
+			name:      "other namespace",
+			namespace: "other",
+			source:    "ready",
+			expect:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewHostVolumeChecker(ctx)
+			checker.SetNamespace(tc.namespace)
+			checker.SetVolumes("example.web[0]", map[string]*structs.VolumeRequest{
+				"data": {Type: structs.VolumeTypeHost, Source: tc.source},
+			})
+			must.Eq(t, tc.expect, checker.Feasible(node))
+		})
+	}
+}
+
 func TestCSIVolumeChecker(t *testing.T) {
 	ci.Parallel(t)
 	state, ctx := testContext(t)
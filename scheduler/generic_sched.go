@@ -64,6 +64,15 @@ const (
 	// that are a result of failing to place all allocations.
 	blockedEvalFailedPlacements = "created to place remaining allocations"
 
+	// blockedEvalFailedGangPlacement is the description used for blocked
+	// evals of gang jobs that could not place every allocation.
+	blockedEvalFailedGangPlacement = "created to place all allocations of gang job"
+
+	// blockedEvalQuotaExhausted is the description used for blocked evals
+	// that failed to place allocations because the quota of the namespace
+	// was exhausted.
+	blockedEvalQuotaExhausted = "created due to quota exhausted"
+
 	// reschedulingFollowupEvalDesc is the description used when creating follow
 	// up evals for delayed rescheduling
 	reschedulingFollowupEvalDesc = "created for delayed rescheduling"
@@ -236,6 +245,10 @@ func (s *GenericScheduler) createBlockedEval(planFailure bool) error {
 	if planFailure {
 		s.blocked.TriggeredBy = structs.EvalTriggerMaxPlans
 		s.blocked.StatusDescription = blockedEvalMaxPlanDesc
+	} else if s.job != nil && s.job.Gang {
+		s.blocked.StatusDescription = blockedEvalFailedGangPlacement
+	} else if e.QuotaLimitReached() != "" {
+		s.blocked.StatusDescription = blockedEvalQuotaExhausted
 	} else {
 		s.blocked.StatusDescription = blockedEvalFailedPlacements
 	}
@@ -407,12 +420,24 @@ func (s *GenericScheduler) computeJobAllocs() error {
 	}
 
 	// Update the stored deployment
+	prevDeployment := s.deployment
 	if results.deployment != nil {
 		s.deployment = results.deployment
 	}
 
+	// Gang jobs must update and place every allocation or none of them, so
+	// allocations being replaced, such as those migrating off draining nodes,
+	// are only stopped along with their replacements. Lost allocations are
+	// stopped regardless since they no longer run.
+	gang := s.job != nil && s.job.Gang
+	var replacedStops []allocStopResult
+
 	// Handle the stop
 	for _, stop := range results.stop {
+		if gang && stop.clientStatus != structs.AllocClientStatusLost && isReplaced(stop.alloc, results.place) {
+			replacedStops = append(replacedStops, stop)
+			continue
+		}
 		s.plan.AppendStoppedAlloc(stop.alloc, stop.statusDescription, stop.clientStatus, stop.followupEvalID)
 	}
 
@@ -427,6 +452,17 @@ func (s *GenericScheduler) computeJobAllocs() error {
 		s.ctx.Plan().AppendAlloc(update, nil)
 	}
 
+	// Record the plan of gang jobs before the stops of replaced allocations,
+	// in-place updates and placements to be able to back them out.
+	var gangPlan *planLengths
+	if gang {
+		gangPlan = recordPlanLengths(s.plan)
+	}
+
+	for _, stop := range replacedStops {
+		s.plan.AppendStoppedAlloc(stop.alloc, stop.statusDescription, stop.clientStatus, stop.followupEvalID)
+	}
+
 	// Handle the in-place updates
 	for _, update := range results.inplaceUpdate {
 		if update.DeploymentID != s.deployment.GetID() {
@@ -466,7 +502,31 @@ func (s *GenericScheduler) computeJobAllocs() error {
 		s.queuedAllocs[p.placeTaskGroup.Name] += 1
 		destructive = append(destructive, p)
 	}
-	return s.computePlacements(destructive, place, results.taskGroupAllocNameIndexes)
+	if err := s.computePlacements(destructive, place, results.taskGroupAllocNameIndexes); err != nil {
+		return err
+	}
+
+	// If any placement of a gang job failed, discard everything the job's
+	// update would have done: the placements that succeeded along with the
+	// stops and preemptions they required, the in-place updates and the new
+	// deployment. The failed task groups remain recorded so a blocked eval
+	// is created for the job as a whole.
+	if gangPlan != nil && len(s.failedTGAllocs) != 0 {
+		gangPlan.rollback(s.plan)
+		if results.deployment != nil {
+			s.plan.Deployment = nil
+			s.deployment = prevDeployment
+		}
+
+		failed := make([]string, 0, len(s.failedTGAllocs))
+		for tg := range s.failedTGAllocs {
+			failed = append(failed, tg)
+		}
+		sort.Strings(failed)
+		s.logger.Debug("failed to place all allocations of gang job, discarding placements",
+			"failed_task_groups", failed)
+	}
+	return nil
 }
 
 // downgradedJobForPlacement returns the job appropriate for non-canary placement replacement
@@ -742,6 +802,92 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 	return nil
 }
 
+// isReplaced returns whether one of the placements replaces the allocation.
+func isReplaced(alloc *structs.Allocation, place []allocPlaceResult) bool {
+	for _, p := range place {
+		if prev := p.PreviousAllocation(); prev != nil && prev.ID == alloc.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// planLengths records the number of allocations per node in each of a
+// plan's allocation maps. Updating and placing allocations only appends to a
+// plan, so it can be used to roll a plan back to the point at which it was
+// recorded.
+type planLengths struct {
+	nodeUpdate      map[string]int
+	nodeAllocation  map[string]int
+	nodePreemptions map[string]int
+
+	// preemptedAllocs and preemptions record the preemption annotations
+	// of the plan, if any.
+	preemptedAllocs int
+	preemptions     map[string]uint64
+}
+
+// recordPlanLengths returns the current planLengths of the plan.
+func recordPlanLengths(plan *structs.Plan) *planLengths {
+	l := &planLengths{
+		nodeUpdate:      allocMapLengths(plan.NodeUpdate),
+		nodeAllocation:  allocMapLengths(plan.NodeAllocation),
+		nodePreemptions: allocMapLengths(plan.NodePreemptions),
+	}
+	if plan.Annotations != nil {
+		l.preemptedAllocs = len(plan.Annotations.PreemptedAllocs)
+		l.preemptions = make(map[string]uint64, len(plan.Annotations.DesiredTGUpdates))
+		for tg, desired := range plan.Annotations.DesiredTGUpdates {
+			l.preemptions[tg] = desired.Preemptions
+		}
+	}
+	return l
+}
+
+// rollback discards any allocations added to the plan since the lengths
+// were recorded. The plan's annotations no longer show any allocations being
+// updated or placed, so that a plan of a gang job that doesn't fit reports
+// no changes for its task groups.
+func (l *planLengths) rollback(plan *structs.Plan) {
+	truncateAllocMap(plan.NodeUpdate, l.nodeUpdate)
+	truncateAllocMap(plan.NodeAllocation, l.nodeAllocation)
+	truncateAllocMap(plan.NodePreemptions, l.nodePreemptions)
+
+	if plan.Annotations != nil {
+		if len(plan.Annotations.PreemptedAllocs) > l.preemptedAllocs {
+			plan.Annotations.PreemptedAllocs = plan.Annotations.PreemptedAllocs[:l.preemptedAllocs]
+		}
+		for tg, desired := range plan.Annotations.DesiredTGUpdates {
+			desired.Preemptions = l.preemptions[tg]
+			desired.Place = 0
+			desired.Migrate = 0
+			desired.InPlaceUpdate = 0
+			desired.DestructiveUpdate = 0
+			desired.Canary = 0
+		}
+	}
+}
+
+func allocMapLengths(m map[string][]*structs.Allocation) map[string]int {
+	lengths := make(map[string]int, len(m))
+	for nodeID, allocs := range m {
+		lengths[nodeID] = len(allocs)
+	}
+	return lengths
+}
+
+func truncateAllocMap(m map[string][]*structs.Allocation, lengths map[string]int) {
+	for nodeID, allocs := range m {
+		n, ok := lengths[nodeID]
+		switch {
+		case !ok || n == 0:
+			delete(m, nodeID)
+		case len(allocs) > n:
+			m[nodeID] = allocs[:n]
+		}
+	}
+}
+
 // setJob updates the stack with the given job and job's node pool scheduler
 // configuration.
 func (s *GenericScheduler) setJob(job *structs.Job) error {
@@ -949,7 +1095,8 @@ func (s *GenericScheduler) handlePreemptions(option *RankedNode, alloc *structs.
 	// If this placement involves preemption, set DesiredState to evict for those allocations
 	var preemptedAllocIDs []string
 	for _, stop := range option.PreemptedAllocs {
-		s.plan.AppendPreemptedAlloc(stop, alloc.ID)
+		preempted := s.plan.AppendPreemptedAlloc(stop, alloc.ID)
+		preempted.Preemption = s.ctx.PreemptionBudgets().Preemption(stop, s.job, s.plan)
 		preemptedAllocIDs = append(preemptedAllocIDs, stop.ID)
 
 		if s.eval.AnnotatePlan && s.plan.Annotations != nil {
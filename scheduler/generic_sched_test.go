@@ -170,7 +170,7 @@ func TestServiceSched_JobRegister_StickyAllocs(t *testing.T) {
 
 	// Update the job to force a rolling upgrade
 	updated := job.Copy()
-	updated.TaskGroups[0].Tasks[0].Resources.CPU += 10
+	updated.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
 	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, updated))
 
 	// Create a mock evaluation to handle the update
@@ -1271,6 +1271,264 @@ func TestServiceSched_JobRegister_AllocFail(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_JobRegister_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		bigMemoryMB int
+		expectPlace bool
+	}{
+		{
+			name:        "all groups fit",
+			bigMemoryMB: 256,
+			expectPlace: true,
+		},
+		{
+			name:        "one group does not fit",
+			bigMemoryMB: 1_000_000,
+			expectPlace: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHarness(t)
+
+			node := mock.Node()
+			must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+			// Create a gang job with a second task group that may not fit
+			job := mock.Job()
+			job.Gang = true
+			job.TaskGroups[0].Count = 2
+			big := job.TaskGroups[0].Copy()
+			big.Name = "big"
+			big.Count = 1
+			big.Tasks[0].Resources.MemoryMB = tc.bigMemoryMB
+			job.TaskGroups = append(job.TaskGroups, big)
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+			eval := &structs.Evaluation{
+				Namespace:   structs.DefaultNamespace,
+				ID:          uuid.Generate(),
+				Priority:    job.Priority,
+				TriggeredBy: structs.EvalTriggerJobRegister,
+				JobID:       job.ID,
+				Status:      structs.EvalStatusPending,
+			}
+			must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+			must.NoError(t, h.Process(NewServiceScheduler, eval))
+			must.Len(t, 1, h.Evals)
+			outEval := h.Evals[0]
+
+			if tc.expectPlace {
+				must.Len(t, 1, h.Plans)
+				must.True(t, h.Plans[0].AllAtOnce)
+				must.Len(t, 3, h.Plans[0].NodeAllocation[node.ID])
+				must.MapEmpty(t, outEval.FailedTGAllocs)
+				must.SliceEmpty(t, h.CreateEvals)
+				return
+			}
+
+			// No placements should have been made for any group
+			must.SliceEmpty(t, h.Plans)
+
+			// Only the group that didn't fit is reported as failed
+			must.MapLen(t, 1, outEval.FailedTGAllocs)
+			must.MapContainsKey(t, outEval.FailedTGAllocs, "big")
+
+			// The whole job is blocked
+			must.Len(t, 1, h.CreateEvals)
+			blocked := h.CreateEvals[0]
+			must.Eq(t, structs.EvalStatusBlocked, blocked.Status)
+			must.Eq(t, blockedEvalFailedGangPlacement, blocked.StatusDescription)
+			must.Eq(t, blocked.ID, outEval.BlockedEval)
+
+			// All allocations remain queued
+			must.Eq(t, 2, outEval.QueuedAllocations["web"])
+			must.Eq(t, 1, outEval.QueuedAllocations["big"])
+		})
+	}
+}
+
+func TestPlanLengths_Rollback(t *testing.T) {
+	ci.Parallel(t)
+
+	existing := mock.Alloc()
+	plan := &structs.Plan{
+		NodeUpdate: map[string][]*structs.Allocation{
+			existing.NodeID: {existing},
+		},
+		NodeAllocation:  map[string][]*structs.Allocation{},
+		NodePreemptions: map[string][]*structs.Allocation{},
+		Annotations: &structs.PlanAnnotations{
+			DesiredTGUpdates: map[string]*structs.DesiredUpdates{
+				"web": {Place: 2},
+			},
+		},
+	}
+	lengths := recordPlanLengths(plan)
+
+	// Append a stop, a placement and a preemption, as a placement would
+	stopped := mock.Alloc()
+	stopped.NodeID = existing.NodeID
+	plan.AppendStoppedAlloc(stopped, "", "", "")
+	placed := mock.Alloc()
+	plan.AppendAlloc(placed, nil)
+	preempted := mock.Alloc()
+	plan.AppendPreemptedAlloc(preempted, placed.ID)
+	plan.Annotations.PreemptedAllocs = append(plan.Annotations.PreemptedAllocs, preempted.Stub(nil))
+	plan.Annotations.DesiredTGUpdates["web"].Preemptions++
+
+	lengths.rollback(plan)
+	must.Eq(t, []*structs.Allocation{existing}, plan.NodeUpdate[existing.NodeID])
+	must.MapEmpty(t, plan.NodeAllocation)
+	must.MapEmpty(t, plan.NodePreemptions)
+	must.SliceEmpty(t, plan.Annotations.PreemptedAllocs)
+	must.Eq(t, 0, plan.Annotations.DesiredTGUpdates["web"].Preemptions)
+	must.Eq(t, 0, plan.Annotations.DesiredTGUpdates["web"].Place)
+}
+
+func TestServiceSched_JobModify_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, annotate := range []bool{false, true} {
+		t.Run(fmt.Sprintf("annotate=%v", annotate), func(t *testing.T) {
+			h := NewHarness(t)
+
+			node := mock.Node()
+			must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+			// Create a running gang job
+			job := mock.Job()
+			job.Gang = true
+			job.TaskGroups[0].Count = 2
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+			var allocs []*structs.Allocation
+			for i := 0; i < 2; i++ {
+				alloc := mock.AllocForNode(node)
+				alloc.Job = job
+				alloc.JobID = job.ID
+				alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+				alloc.ClientStatus = structs.AllocClientStatusRunning
+				allocs = append(allocs, alloc)
+			}
+			must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+			// Update the job in place, which creates a deployment, and add a
+			// group that doesn't fit
+			job2 := job.Copy()
+			job2.TaskGroups[0].Update = &structs.UpdateStrategy{
+				MaxParallel:     2,
+				HealthCheck:     structs.UpdateStrategyHealthCheck_Checks,
+				MinHealthyTime:  10 * time.Second,
+				HealthyDeadline: 10 * time.Minute,
+			}
+			big := job2.TaskGroups[0].Copy()
+			big.Name = "big"
+			big.Count = 1
+			big.Update = nil
+			big.Tasks[0].Resources.MemoryMB = 1_000_000
+			job2.TaskGroups = append(job2.TaskGroups, big)
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job2))
+
+			eval := &structs.Evaluation{
+				Namespace:    structs.DefaultNamespace,
+				ID:           uuid.Generate(),
+				Priority:     job.Priority,
+				TriggeredBy:  structs.EvalTriggerJobRegister,
+				JobID:        job.ID,
+				Status:       structs.EvalStatusPending,
+				AnnotatePlan: annotate,
+			}
+			must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+			must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+			if !annotate {
+				// Neither the in-place updates nor the deployment are
+				// submitted
+				must.SliceEmpty(t, h.Plans)
+				return
+			}
+
+			must.Len(t, 1, h.Plans)
+			plan := h.Plans[0]
+			must.MapEmpty(t, plan.NodeAllocation)
+			must.Nil(t, plan.Deployment)
+			for name, desired := range plan.Annotations.DesiredTGUpdates {
+				must.Eq(t, 0, desired.Place, must.Sprintf("group %s", name))
+				must.Eq(t, 0, desired.InPlaceUpdate, must.Sprintf("group %s", name))
+			}
+		})
+	}
+}
+
+func TestServiceSched_NodeDrain_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, fits := range []bool{false, true} {
+		t.Run(fmt.Sprintf("fits=%v", fits), func(t *testing.T) {
+			h := NewHarness(t)
+
+			// Register a draining node, and a node to migrate to if the
+			// allocations should fit
+			drained := mock.DrainNode()
+			must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), drained))
+			var node *structs.Node
+			if fits {
+				node = mock.Node()
+				must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+			}
+
+			job := mock.Job()
+			job.Gang = true
+			job.TaskGroups[0].Count = 2
+			must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+			var allocs []*structs.Allocation
+			for i := 0; i < 2; i++ {
+				alloc := mock.AllocForNode(drained)
+				alloc.Job = job
+				alloc.JobID = job.ID
+				alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+				alloc.ClientStatus = structs.AllocClientStatusRunning
+				alloc.DesiredTransition.Migrate = pointer.Of(true)
+				allocs = append(allocs, alloc)
+			}
+			must.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+			eval := &structs.Evaluation{
+				Namespace:   structs.DefaultNamespace,
+				ID:          uuid.Generate(),
+				Priority:    job.Priority,
+				TriggeredBy: structs.EvalTriggerNodeUpdate,
+				JobID:       job.ID,
+				NodeID:      drained.ID,
+				Status:      structs.EvalStatusPending,
+			}
+			must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+			must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+			if !fits {
+				// The migrating allocations keep running since their
+				// replacements can't be placed
+				must.SliceEmpty(t, h.Plans)
+				must.Len(t, 1, h.CreateEvals)
+				must.Eq(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+				return
+			}
+
+			must.Len(t, 1, h.Plans)
+			plan := h.Plans[0]
+			must.Len(t, 2, plan.NodeUpdate[drained.ID])
+			must.Len(t, 2, plan.NodeAllocation[node.ID])
+		})
+	}
+}
+
 func TestServiceSched_JobRegister_CreateBlockedEval(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5,6 +5,7 @@ package scheduler
 
 import (
 	"math"
+	"slices"
 	"sort"
 
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -102,6 +103,14 @@ type Preemptor struct {
 	// it tracks the number of preempted allocations per job/taskgroup
 	currentPreemptions map[structs.NamespacedID]map[string]int
 
+	// plannedPreemptions are the allocations already being preempted by the
+	// plan, and the allocations returned by this preemptor so far. They are
+	// counted against the preemption budgets of their jobs and namespaces
+	plannedPreemptions []*structs.Allocation
+
+	// budgets tracks the preemption budgets of jobs and namespaces
+	budgets *PreemptionBudgets
+
 	// allocDetails is a map computed when SetCandidates is called
 	// it stores some precomputed details about the allocation needed
 	// when scoring it for preemption
@@ -130,6 +139,7 @@ func NewPreemptor(jobPriority int, ctx Context, jobID *structs.NamespacedID) *Pr
 		jobPriority:        jobPriority,
 		jobID:              jobID,
 		allocDetails:       make(map[string]*allocInfo),
+		budgets:            ctx.PreemptionBudgets(),
 		ctx:                ctx,
 	}
 }
@@ -172,6 +182,7 @@ func (p *Preemptor) SetPreemptions(allocs []*structs.Allocation) {
 
 	// Clear out existing values since this can be called more than once
 	p.currentPreemptions = make(map[structs.NamespacedID]map[string]int)
+	p.plannedPreemptions = slices.Clone(allocs)
 
 	// Initialize counts
 	for _, alloc := range allocs {
@@ -195,6 +206,32 @@ func (p *Preemptor) getNumPreemptions(alloc *structs.Allocation) int {
 	return c
 }
 
+// preemptible returns whether the alloc can be preempted without preempting
+// it before its minimum runtime or exceeding a preemption budget, given the
+// preemptions already planned.
+func (p *Preemptor) preemptible(alloc *structs.Allocation) bool {
+	if p.budgets.Protected(alloc) {
+		return false
+	}
+	planned := append(slices.Clip(p.plannedPreemptions), alloc)
+	return !p.budgets.Exceeded(planned)
+}
+
+// withinBudgets returns the selected allocs if preempting all of them stays
+// within the preemption budgets, and records them as planned. Otherwise it
+// returns nil, as the ask can't be met.
+func (p *Preemptor) withinBudgets(selected []*structs.Allocation) []*structs.Allocation {
+	if len(selected) == 0 {
+		return selected
+	}
+	planned := append(slices.Clip(p.plannedPreemptions), selected...)
+	if p.budgets.Exceeded(planned) {
+		return nil
+	}
+	p.plannedPreemptions = planned
+	return selected
+}
+
 // PreemptForTaskGroup computes a list of allocations to preempt to accommodate
 // the resources asked for. Only allocs with a job priority < 10 of jobPriority are considered
 // This method is meant only for finding preemptible allocations based on CPU/Memory/Disk
@@ -208,7 +245,7 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 	}
 
 	// Group candidates by priority, filter out ineligible allocs
-	allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, p.currentAllocs)
+	allocsByPriority := p.filterAndGroupPreemptibleAllocs(p.currentAllocs)
 
 	var bestAllocs []*structs.Allocation
 	allRequirementsMet := false
@@ -263,7 +300,7 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 	basePreemptionResource := GetBasePreemptionResourceFactory()
 	resourcesNeeded = resourceAsk.Comparable()
 	filteredBestAllocs := p.filterSuperset(bestAllocs, p.nodeRemainingResources, resourcesNeeded, basePreemptionResource)
-	return filteredBestAllocs
+	return p.withinBudgets(filteredBestAllocs)
 
 }
 
@@ -309,8 +346,9 @@ func (p *Preemptor) PreemptForNetwork(networkResourceAsk *structs.NetworkResourc
 		// We only check first network - TODO: why?!?!
 		net := networks[0]
 
-		// Filter out alloc that's ineligible due to priority
-		if p.jobPriority-alloc.Job.Priority < 10 {
+		// Filter out alloc that's ineligible due to priority, minimum runtime
+		// or preemption budget
+		if p.jobPriority-alloc.Job.Priority < 10 || !p.preemptible(alloc) {
 			// Populate any reserved ports used by
 			// this allocation that cannot be preempted
 			for _, port := range net.ReservedPorts {
@@ -403,7 +441,7 @@ OUTER:
 		}
 
 		// Split by priority
-		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, currentAllocs)
+		allocsByPriority := p.filterAndGroupPreemptibleAllocs(currentAllocs)
 
 		for _, allocsGrp := range allocsByPriority {
 			allocs := allocsGrp.allocs
@@ -453,7 +491,7 @@ OUTER:
 		},
 	}
 	filteredBestAllocs := p.filterSuperset(allocsToPreempt, nodeRemainingResources, resourcesNeeded, preemptionResourceFactory)
-	return filteredBestAllocs
+	return p.withinBudgets(filteredBestAllocs)
 }
 
 // deviceGroupAllocs represents a group of allocs that share a device
@@ -520,7 +558,7 @@ func (p *Preemptor) PreemptForDevice(ask *structs.RequestedDevice, devAlloc *dev
 OUTER:
 	for deviceIDTuple, allocsGrp := range deviceToAllocs {
 		// First group and sort allocations using this device by priority
-		allocsByPriority := filterAndGroupPreemptibleAllocs(p.jobPriority, allocsGrp.allocs)
+		allocsByPriority := p.filterAndGroupPreemptibleAllocs(allocsGrp.allocs)
 
 		// Reset preempted count for this device
 		preemptedCount := 0
@@ -551,7 +589,7 @@ OUTER:
 
 	// Find the combination of allocs with lowest net priority
 	if len(preemptionOptions) > 0 {
-		return selectBestAllocs(preemptionOptions, int(neededCount))
+		return p.withinBudgets(selectBestAllocs(preemptionOptions, int(neededCount)))
 	}
 
 	return nil
@@ -662,8 +700,9 @@ func scoreForNetwork(resourceUsed *structs.NetworkResource, resourceNeeded *stru
 }
 
 // filterAndGroupPreemptibleAllocs groups allocations by priority after filtering allocs
-// that are not preemptible based on the jobPriority arg
-func filterAndGroupPreemptibleAllocs(jobPriority int, current []*structs.Allocation) []*groupedAllocs {
+// that are not preemptible based on the job priority, their minimum runtime and the
+// preemption budgets
+func (p *Preemptor) filterAndGroupPreemptibleAllocs(current []*structs.Allocation) []*groupedAllocs {
 	allocsByPriority := make(map[int][]*structs.Allocation)
 	for _, alloc := range current {
 		if alloc.Job == nil {
@@ -673,7 +712,13 @@ func filterAndGroupPreemptibleAllocs(jobPriority int, current []*structs.Allocat
 		// Skip allocs whose priority is within a delta of 10
 		// This also skips any allocs of the current job
 		// for which we are attempting preemption
-		if jobPriority-alloc.Job.Priority < 10 {
+		if p.jobPriority-alloc.Job.Priority < 10 {
+			continue
+		}
+
+		// Skip allocs that haven't run for their minimum runtime, or whose
+		// job or namespace have no preemption budget left
+		if !p.preemptible(alloc) {
 			continue
 		}
 		grpAllocs, ok := allocsByPriority[alloc.Job.Priority]
@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// PreemptionBudgets tracks the preemption budgets of the jobs and namespaces
+// whose allocations are candidates for preemption during an evaluation. The
+// budgets and the preemptions already counted against them are read from
+// the state once and cached for the rest of the evaluation.
+type PreemptionBudgets struct {
+	state State
+
+	// now is the time preemption windows and minimum runtimes are measured
+	// from. It is fixed for the evaluation so every placement sees the same
+	// budgets.
+	now time.Time
+
+	jobs       map[structs.NamespacedID]*budgetUsage
+	namespaces map[string]*budgetUsage
+}
+
+// budgetUsage is a preemption budget and the number of allocations preempted
+// within its window according to the state.
+type budgetUsage struct {
+	budget *structs.PreemptionBudget
+	used   int
+}
+
+// exceeded returns whether preempting count more allocations would exceed
+// the budget.
+func (u *budgetUsage) exceeded(count int) bool {
+	return u.budget.Limited() && u.used+count > u.budget.MaxPreemptions
+}
+
+// NewPreemptionBudgets returns a tracker for the preemption budgets in the
+// state, with windows ending at now.
+func NewPreemptionBudgets(state State, now time.Time) *PreemptionBudgets {
+	return &PreemptionBudgets{
+		state:      state,
+		now:        now,
+		jobs:       make(map[structs.NamespacedID]*budgetUsage),
+		namespaces: make(map[string]*budgetUsage),
+	}
+}
+
+// Protected returns whether the allocation has not been running for the
+// minimum runtime of its job or namespace yet, and so can't be preempted.
+func (b *PreemptionBudgets) Protected(alloc *structs.Allocation) bool {
+	minRuntime := time.Duration(0)
+	if budget := b.job(alloc.Namespace, alloc.JobID, alloc.Job).budget; budget != nil {
+		minRuntime = budget.MinRuntime
+	}
+	if budget := b.namespace(alloc.Namespace).budget; budget != nil && budget.MinRuntime > minRuntime {
+		minRuntime = budget.MinRuntime
+	}
+	if minRuntime == 0 {
+		return false
+	}
+	return b.now.Sub(time.Unix(0, alloc.CreateTime)) < minRuntime
+}
+
+// Exceeded returns whether preempting all of the given allocations would
+// exceed the budget of any of their jobs or namespaces.
+func (b *PreemptionBudgets) Exceeded(allocs []*structs.Allocation) bool {
+	jobs := make(map[structs.NamespacedID]int)
+	namespaces := make(map[string]int)
+	for _, alloc := range allocs {
+		jobs[structs.NewNamespacedID(alloc.JobID, alloc.Namespace)]++
+		namespaces[alloc.Namespace]++
+	}
+
+	for id, count := range jobs {
+		if b.job(id.Namespace, id.ID, nil).exceeded(count) {
+			return true
+		}
+	}
+	for ns, count := range namespaces {
+		if b.namespace(ns).exceeded(count) {
+			return true
+		}
+	}
+	return false
+}
+
+// Preemption returns the record of the preemption of the allocation to place
+// an allocation of the job. The allocation must already be in the plan's
+// preemptions, which are counted against the budgets along with the
+// preemptions in the state.
+func (b *PreemptionBudgets) Preemption(alloc *structs.Allocation, job *structs.Job, plan *structs.Plan) *structs.AllocPreemption {
+	jobCount, nsCount := 0, 0
+	for _, preempted := range plan.NodePreemptions {
+		for _, p := range preempted {
+			if p.Namespace != alloc.Namespace {
+				continue
+			}
+			nsCount++
+			if p.JobID == alloc.JobID {
+				jobCount++
+			}
+		}
+	}
+
+	preemption := &structs.AllocPreemption{
+		Reason: fmt.Sprintf("Preempted to place an allocation of job %q with priority %d", job.ID, job.Priority),
+		Time:   b.now.UnixNano(),
+	}
+	if u := b.job(alloc.Namespace, alloc.JobID, alloc.Job); u.budget.Limited() {
+		preemption.Budgets = append(preemption.Budgets, &structs.PreemptionBudgetUsage{
+			Scope:          structs.PreemptionBudgetScopeJob,
+			Preemptions:    u.used + jobCount,
+			MaxPreemptions: u.budget.MaxPreemptions,
+			Window:         u.budget.Window,
+		})
+	}
+	if u := b.namespace(alloc.Namespace); u.budget.Limited() {
+		preemption.Budgets = append(preemption.Budgets, &structs.PreemptionBudgetUsage{
+			Scope:          structs.PreemptionBudgetScopeNamespace,
+			Preemptions:    u.used + nsCount,
+			MaxPreemptions: u.budget.MaxPreemptions,
+			Window:         u.budget.Window,
+		})
+	}
+	return preemption
+}
+
+// job returns the budget usage of the job, falling back to the given job if
+// the job is not in the state.
+func (b *PreemptionBudgets) job(namespace, jobID string, fallback *structs.Job) *budgetUsage {
+	id := structs.NewNamespacedID(jobID, namespace)
+	if u, ok := b.jobs[id]; ok {
+		return u
+	}
+
+	u := &budgetUsage{}
+	b.jobs[id] = u
+
+	job, err := b.state.JobByID(nil, namespace, jobID)
+	if err != nil || job == nil {
+		job = fallback
+	}
+	if job == nil {
+		return u
+	}
+	u.budget = job.PreemptionBudget
+	if !u.budget.Limited() {
+		return u
+	}
+
+	allocs, err := b.state.AllocsByJob(nil, namespace, jobID, true)
+	if err != nil {
+		// Preempting more than the budget allows can't be undone, so treat
+		// the budget as spent if its usage can't be read
+		u.used = u.budget.MaxPreemptions
+		return u
+	}
+	for _, alloc := range allocs {
+		if b.preemptedWithin(alloc, u.budget.Window) {
+			u.used++
+		}
+	}
+	return u
+}
+
+// namespace returns the budget usage of the namespace.
+func (b *PreemptionBudgets) namespace(name string) *budgetUsage {
+	if u, ok := b.namespaces[name]; ok {
+		return u
+	}
+
+	u := &budgetUsage{}
+	b.namespaces[name] = u
+
+	ns, err := b.state.NamespaceByName(nil, name)
+	if err != nil || ns == nil {
+		return u
+	}
+	u.budget = ns.PreemptionBudget
+	if !u.budget.Limited() {
+		return u
+	}
+
+	iter, err := b.state.AllocsByNamespace(nil, name)
+	if err != nil {
+		u.used = u.budget.MaxPreemptions
+		return u
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if b.preemptedWithin(raw.(*structs.Allocation), u.budget.Window) {
+			u.used++
+		}
+	}
+	return u
+}
+
+// preemptedWithin returns whether the allocation was preempted within the
+// window. Allocations preempted before preemptions were recorded use their
+// modify time instead.
+func (b *PreemptionBudgets) preemptedWithin(alloc *structs.Allocation, window time.Duration) bool {
+	if alloc.PreemptedByAllocation == "" {
+		return false
+	}
+	preemptedAt := alloc.ModifyTime
+	if alloc.Preemption != nil && alloc.Preemption.Time != 0 {
+		preemptedAt = alloc.Preemption.Time
+	}
+	return b.now.Sub(time.Unix(0, preemptedAt)) < window
+}
@@ -0,0 +1,283 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// preemptedAlloc returns a terminal allocation of the job that was preempted
+// at the given time.
+func preemptedAlloc(job *structs.Job, at time.Time) *structs.Allocation {
+	alloc := createAlloc(uuid.Generate(), job, &structs.Resources{CPU: 100, MemoryMB: 256})
+	alloc.NodeID = uuid.Generate()
+	alloc.DesiredStatus = structs.AllocDesiredStatusEvict
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	alloc.PreemptedByAllocation = uuid.Generate()
+	alloc.Preemption = &structs.AllocPreemption{Time: at.UnixNano()}
+	return alloc
+}
+
+func TestPreemptionBudgets_Protected(t *testing.T) {
+	ci.Parallel(t)
+
+	store, _ := testContext(t)
+	now := time.Now()
+
+	job := mock.Job()
+	job.Priority = 30
+	job.PreemptionBudget = &structs.PreemptionBudget{MinRuntime: 30 * time.Minute}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	recent := createAlloc(uuid.Generate(), job, &structs.Resources{CPU: 100, MemoryMB: 256})
+	recent.CreateTime = now.Add(-10 * time.Minute).UnixNano()
+
+	old := createAlloc(uuid.Generate(), job, &structs.Resources{CPU: 100, MemoryMB: 256})
+	old.CreateTime = now.Add(-time.Hour).UnixNano()
+
+	budgets := NewPreemptionBudgets(store, now)
+	must.True(t, budgets.Protected(recent))
+	must.False(t, budgets.Protected(old))
+
+	// The longer minimum runtime of the job and namespace applies
+	ns := mock.Namespace()
+	ns.Name = structs.DefaultNamespace
+	ns.PreemptionBudget = &structs.PreemptionBudget{MinRuntime: 2 * time.Hour}
+	must.NoError(t, store.UpsertNamespaces(1001, []*structs.Namespace{ns}))
+
+	budgets = NewPreemptionBudgets(store, now)
+	must.True(t, budgets.Protected(old))
+}
+
+func TestPreemptionBudgets_Exceeded(t *testing.T) {
+	ci.Parallel(t)
+
+	store, _ := testContext(t)
+	now := time.Now()
+
+	job := mock.Job()
+	job.PreemptionBudget = &structs.PreemptionBudget{
+		MaxPreemptions: 2,
+		Window:         time.Hour,
+	}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	// Only the preemption within the window counts against the budget
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{
+		preemptedAlloc(job, now.Add(-10*time.Minute)),
+		preemptedAlloc(job, now.Add(-2*time.Hour)),
+	}))
+
+	a1 := createAlloc(uuid.Generate(), job, &structs.Resources{CPU: 100, MemoryMB: 256})
+	a2 := createAlloc(uuid.Generate(), job, &structs.Resources{CPU: 100, MemoryMB: 256})
+
+	other := mock.Job()
+	a3 := createAlloc(uuid.Generate(), other, &structs.Resources{CPU: 100, MemoryMB: 256})
+
+	budgets := NewPreemptionBudgets(store, now)
+	must.False(t, budgets.Exceeded([]*structs.Allocation{a1}))
+	must.False(t, budgets.Exceeded([]*structs.Allocation{a1, a3}))
+	must.True(t, budgets.Exceeded([]*structs.Allocation{a1, a2}))
+
+	// The namespace budget counts the preemptions of every job
+	ns := mock.Namespace()
+	ns.Name = structs.DefaultNamespace
+	ns.PreemptionBudget = &structs.PreemptionBudget{
+		MaxPreemptions: 2,
+		Window:         time.Hour,
+	}
+	must.NoError(t, store.UpsertNamespaces(1002, []*structs.Namespace{ns}))
+
+	budgets = NewPreemptionBudgets(store, now)
+	must.False(t, budgets.Exceeded([]*structs.Allocation{a3}))
+	must.True(t, budgets.Exceeded([]*structs.Allocation{a1, a3}))
+}
+
+func TestPreemptionBudgets_Preemption(t *testing.T) {
+	ci.Parallel(t)
+
+	store, _ := testContext(t)
+	now := time.Now()
+
+	job := mock.Job()
+	job.PreemptionBudget = &structs.PreemptionBudget{
+		MaxPreemptions: 3,
+		Window:         time.Hour,
+	}
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{
+		preemptedAlloc(job, now.Add(-10*time.Minute)),
+	}))
+
+	highPrioJob := mock.Job()
+	highPrioJob.Priority = 90
+
+	alloc := createAlloc(uuid.Generate(), job, &structs.Resources{CPU: 100, MemoryMB: 256})
+	plan := &structs.Plan{NodePreemptions: make(map[string][]*structs.Allocation)}
+	plan.AppendPreemptedAlloc(alloc, uuid.Generate())
+
+	budgets := NewPreemptionBudgets(store, now)
+	preemption := budgets.Preemption(alloc, highPrioJob, plan)
+	must.StrContains(t, preemption.Reason, highPrioJob.ID)
+	must.StrContains(t, preemption.Reason, "priority 90")
+	must.Eq(t, now.UnixNano(), preemption.Time)
+	must.Eq(t, []*structs.PreemptionBudgetUsage{{
+		Scope:          structs.PreemptionBudgetScopeJob,
+		Preemptions:    2,
+		MaxPreemptions: 3,
+		Window:         time.Hour,
+	}}, preemption.Budgets)
+}
+
+// TestPreemption_Budgets asserts the binpack iterator only preempts
+// allocations within their preemption budgets and minimum runtime.
+func TestPreemption_Budgets(t *testing.T) {
+	ci.Parallel(t)
+
+	legacyCpuResources, processorResources := cpuResources(4000)
+	nodeResources := &structs.NodeResources{
+		Processors: processorResources,
+		Cpu:        legacyCpuResources,
+		Memory: structs.NodeMemoryResources{
+			MemoryMB: 8192,
+		},
+		Disk: structs.NodeDiskResources{
+			DiskMB: 100 * 1024,
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		jobBudget      *structs.PreemptionBudget
+		nsBudget       *structs.PreemptionBudget
+		allocAge       time.Duration
+		preemptedSince []time.Duration
+		expectPreempt  bool
+	}{
+		{
+			name:          "no budget",
+			allocAge:      time.Minute,
+			expectPreempt: true,
+		},
+		{
+			name:          "within job budget",
+			jobBudget:     &structs.PreemptionBudget{MaxPreemptions: 2, Window: time.Hour},
+			allocAge:      time.Minute,
+			expectPreempt: true,
+		},
+		{
+			name:          "exceeds job budget",
+			jobBudget:     &structs.PreemptionBudget{MaxPreemptions: 1, Window: time.Hour},
+			allocAge:      time.Minute,
+			expectPreempt: false,
+		},
+		{
+			name:           "job budget spent within window",
+			jobBudget:      &structs.PreemptionBudget{MaxPreemptions: 2, Window: time.Hour},
+			allocAge:       time.Minute,
+			preemptedSince: []time.Duration{10 * time.Minute},
+			expectPreempt:  false,
+		},
+		{
+			name:           "job budget spent outside window",
+			jobBudget:      &structs.PreemptionBudget{MaxPreemptions: 2, Window: time.Hour},
+			allocAge:       time.Minute,
+			preemptedSince: []time.Duration{2 * time.Hour},
+			expectPreempt:  true,
+		},
+		{
+			name:          "exceeds namespace budget",
+			nsBudget:      &structs.PreemptionBudget{MaxPreemptions: 1, Window: time.Hour},
+			allocAge:      time.Minute,
+			expectPreempt: false,
+		},
+		{
+			name:          "within minimum runtime",
+			jobBudget:     &structs.PreemptionBudget{MinRuntime: 30 * time.Minute},
+			allocAge:      10 * time.Minute,
+			expectPreempt: false,
+		},
+		{
+			name:          "past minimum runtime",
+			jobBudget:     &structs.PreemptionBudget{MinRuntime: 30 * time.Minute},
+			allocAge:      time.Hour,
+			expectPreempt: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store, ctx := testContext(t)
+			now := time.Now()
+
+			node := mock.Node()
+			node.NodeResources = nodeResources
+			node.ReservedResources = nil
+			must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+			if tc.nsBudget != nil {
+				ns := mock.Namespace()
+				ns.Name = structs.DefaultNamespace
+				ns.PreemptionBudget = tc.nsBudget
+				must.NoError(t, store.UpsertNamespaces(1001, []*structs.Namespace{ns}))
+			}
+
+			lowPrioJob := mock.Job()
+			lowPrioJob.Priority = 30
+			lowPrioJob.PreemptionBudget = tc.jobBudget
+			must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1002, nil, lowPrioJob))
+
+			// Two allocations fill the node, so both must be preempted
+			var allocs []*structs.Allocation
+			for i := 0; i < 2; i++ {
+				alloc := createAlloc(uuid.Generate(), lowPrioJob, &structs.Resources{
+					CPU:      2000,
+					MemoryMB: 4096,
+				})
+				alloc.NodeID = node.ID
+				alloc.CreateTime = now.Add(-tc.allocAge).UnixNano()
+				allocs = append(allocs, alloc)
+			}
+			for _, since := range tc.preemptedSince {
+				allocs = append(allocs, preemptedAlloc(lowPrioJob, now.Add(-since)))
+			}
+			must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1003, allocs))
+
+			job := mock.Job()
+			job.Priority = 100
+
+			static := NewStaticRankIterator(ctx, []*RankedNode{{Node: node}})
+			binPackIter := NewBinPackIterator(ctx, static, true, job.Priority)
+			binPackIter.SetJob(job)
+			binPackIter.SetSchedulerConfiguration(testSchedulerConfig)
+			binPackIter.SetTaskGroup(&structs.TaskGroup{
+				EphemeralDisk: &structs.EphemeralDisk{},
+				Tasks: []*structs.Task{
+					{
+						Name: "web",
+						Resources: &structs.Resources{
+							CPU:      4000,
+							MemoryMB: 8192,
+						},
+					},
+				},
+			})
+
+			option := binPackIter.Next()
+			if !tc.expectPreempt {
+				must.Nil(t, option)
+				return
+			}
+			must.NotNil(t, option)
+			must.Len(t, 2, option.PreemptedAllocs)
+		})
+	}
+}
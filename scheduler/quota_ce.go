@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !ent
+// +build !ent
+
+package scheduler
+
+import (
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// QuotaIterator is a FeasibleIterator which returns no nodes when placing
+// another allocation of the task group would exceed the quota attached to the
+// namespace of the job. The usage accounts for the changes already made by the
+// plan being built.
+type QuotaIterator struct {
+	ctx    Context
+	source FeasibleIterator
+
+	tg *structs.TaskGroup
+
+	// namespace and quota are set when the namespace of the job has a quota
+	// that limits the local region.
+	namespace string
+	quota     string
+	limit     *structs.QuotaLimit
+	usage     *structs.QuotaLimit
+
+	// checked and exhausted cache the result of the quota check until the
+	// plan or task group changes.
+	checked   bool
+	exhausted bool
+}
+
+func (iter *QuotaIterator) SetJob(job *structs.Job) {
+	iter.namespace = job.Namespace
+	iter.quota = ""
+	iter.limit = nil
+	iter.usage = nil
+	iter.checked = false
+
+	state := iter.ctx.State()
+	ns, err := state.NamespaceByName(nil, job.Namespace)
+	if err != nil {
+		iter.ctx.Logger().Error("failed to lookup namespace", "namespace", job.Namespace, "error", err)
+		return
+	}
+	if ns == nil || ns.Quota == "" {
+		return
+	}
+
+	spec, err := state.QuotaSpecByName(nil, ns.Quota)
+	if err != nil {
+		iter.ctx.Logger().Error("failed to lookup quota", "quota", ns.Quota, "error", err)
+		return
+	}
+	if spec == nil {
+		return
+	}
+	limit := spec.LimitForRegion(state.Config().Region)
+	if limit == nil {
+		return
+	}
+
+	usage, err := state.QuotaUsageByName(nil, ns.Quota)
+	if err != nil {
+		iter.ctx.Logger().Error("failed to lookup quota usage", "quota", ns.Quota, "error", err)
+		return
+	}
+	used := usage.UsageForLimit(limit)
+	if used == nil {
+		return
+	}
+
+	iter.quota = ns.Quota
+	iter.limit = limit
+	iter.usage = used
+}
+
+func (iter *QuotaIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+	iter.checked = false
+}
+
+func (iter *QuotaIterator) Next() *structs.Node {
+	if iter.limit == nil {
+		return iter.source.Next()
+	}
+
+	if !iter.checked {
+		iter.exhausted = iter.quotaExhausted()
+		iter.checked = true
+	}
+	if iter.exhausted {
+		return nil
+	}
+	return iter.source.Next()
+}
+
+func (iter *QuotaIterator) Reset() {
+	iter.checked = false
+	iter.source.Reset()
+}
+
+// quotaExhausted returns whether placing an allocation of the task group
+// would exceed the quota. The exhausted dimensions are recorded in the metrics
+// so they are reported to the user.
+func (iter *QuotaIterator) quotaExhausted() bool {
+	state := iter.ctx.State()
+	inQuota := func(namespace string) bool {
+		if namespace == iter.namespace {
+			return true
+		}
+		ns, err := state.NamespaceByName(nil, namespace)
+		return err == nil && ns != nil && ns.Quota == iter.quota
+	}
+	lookup := func(allocID string) (*structs.Allocation, error) {
+		return state.AllocByID(nil, allocID)
+	}
+
+	proposed := iter.usage.Copy()
+	if err := proposed.ApplyPlan(iter.ctx.Plan(), inQuota, lookup); err != nil {
+		iter.ctx.Logger().Error("failed to compute quota usage", "quota", iter.quota, "error", err)
+		return false
+	}
+	proposed.AddTaskGroup(iter.tg)
+
+	exhausted := iter.limit.ExhaustedBy(iter.usage, proposed)
+	if len(exhausted) == 0 {
+		return false
+	}
+
+	iter.ctx.Metrics().ExhaustQuota(exhausted)
+	iter.ctx.Eligibility().SetQuotaLimitReached(iter.quota)
+	return true
+}
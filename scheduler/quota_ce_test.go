@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !ent
+// +build !ent
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestQuotaIterator(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+
+	spec := mock.QuotaSpec()
+	spec.Limits[0].RegionLimit.CPU = 1000
+	spec.SetHash()
+	must.NoError(t, state.UpsertQuotaSpecs(structs.MsgTypeTestSetup, 1000, []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, state.UpsertNamespaces(1001, []*structs.Namespace{ns}))
+
+	job := mock.Job()
+	job.Namespace = ns.Name
+	tg := job.TaskGroups[0]
+
+	nodes := []*structs.Node{mock.Node(), mock.Node()}
+	static := NewStaticIterator(ctx, nodes)
+	quota := NewQuotaIterator(ctx, static)
+	contextual := quota.(ContextualIterator)
+	contextual.SetJob(job)
+	contextual.SetTaskGroup(tg)
+
+	// The first placement fits within the quota
+	out := collectFeasible(quota)
+	must.Len(t, 2, out)
+
+	// Account for two placements already in the plan
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Namespace = ns.Name
+		alloc.NodeID = nodes[0].ID
+		ctx.Plan().AppendAlloc(alloc, nil)
+	}
+	quota.Reset()
+	contextual.SetTaskGroup(tg)
+
+	out = collectFeasible(quota)
+	must.SliceEmpty(t, out)
+	must.Eq(t, []string{"cpu exhausted (1500 needed > 1000 limit)"}, ctx.Metrics().QuotaExhausted)
+	must.Eq(t, spec.Name, ctx.Eligibility().QuotaLimitReached())
+
+	// Jobs in namespaces without a quota are not limited
+	other := mock.Job()
+	contextual.SetJob(other)
+	contextual.SetTaskGroup(other.TaskGroups[0])
+	quota.Reset()
+
+	out = collectFeasible(quota)
+	must.Len(t, 2, out)
+}
+
+func TestServiceSched_QuotaExhausted(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	node := mock.Node()
+	must.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	spec := mock.QuotaSpec()
+	spec.Limits[0].RegionLimit.CPU = 1000
+	spec.SetHash()
+	must.NoError(t, h.State.UpsertQuotaSpecs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.QuotaSpec{spec}))
+
+	ns := mock.Namespace()
+	ns.Quota = spec.Name
+	must.NoError(t, h.State.UpsertNamespaces(h.NextIndex(), []*structs.Namespace{ns}))
+
+	// Create a job that needs more cpu than the quota allows
+	job := mock.Job()
+	job.Namespace = ns.Name
+	job.TaskGroups[0].Count = 3
+	must.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), nil, job))
+
+	eval := &structs.Evaluation{
+		Namespace:   ns.Name,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	must.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	must.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Only the allocations within the quota are placed
+	must.Len(t, 1, h.Plans)
+	must.Len(t, 2, h.Plans[0].NodeAllocation[node.ID])
+
+	// The failed placement reports the exhausted quota
+	must.Len(t, 1, h.Evals)
+	outEval := h.Evals[0]
+	must.MapContainsKey(t, outEval.FailedTGAllocs, "web")
+	must.SliceNotEmpty(t, outEval.FailedTGAllocs["web"].QuotaExhausted)
+
+	// The blocked eval waits on the quota
+	must.Len(t, 1, h.CreateEvals)
+	blocked := h.CreateEvals[0]
+	must.Eq(t, structs.EvalStatusBlocked, blocked.Status)
+	must.Eq(t, spec.Name, blocked.QuotaLimitReached)
+	must.Eq(t, blockedEvalQuotaExhausted, blocked.StatusDescription)
+}
@@ -6,6 +6,8 @@ package scheduler
 import (
 	"fmt"
 	"math"
+	"slices"
+	"time"
 
 	"github.com/hashicorp/nomad/client/lib/idset"
 	"github.com/hashicorp/nomad/client/lib/numalib/hw"
@@ -162,6 +164,12 @@ type BinPackIterator struct {
 	taskGroup              *structs.TaskGroup
 	memoryOversubscription bool
 	scoreFit               func(*structs.Node, *structs.ComparableResources) float64
+
+	// utilizationWeight is the fraction of the bin packing score computed
+	// from the utilization observed on the node rather than the resources
+	// reserved on it. It is zero unless utilization-aware scoring is enabled.
+	utilizationWeight float64
+	utilizationMaxAge time.Duration
 }
 
 // NewBinPackIterator returns a BinPackIterator which tries to fit tasks
@@ -200,6 +208,13 @@ func (iter *BinPackIterator) SetSchedulerConfiguration(schedConfig *structs.Sche
 
 	// Set memory oversubscription.
 	iter.memoryOversubscription = schedConfig != nil && schedConfig.MemoryOversubscriptionEnabled
+
+	// Set utilization-aware scoring.
+	iter.utilizationWeight = 0
+	if schedConfig != nil {
+		iter.utilizationWeight = schedConfig.UtilizationScoring.EffectiveObservedWeight()
+		iter.utilizationMaxAge = schedConfig.UtilizationScoring.EffectiveMaxAge()
+	}
 }
 
 func (iter *BinPackIterator) Next() *RankedNode {
@@ -372,6 +387,12 @@ OUTER:
 				Memory: structs.AllocatedMemoryResources{
 					MemoryMB: int64(task.Resources.MemoryMB),
 				},
+				IO: structs.AllocatedIOResources{
+					Weight:             int64(task.Resources.IOWeight),
+					ReadBps:            task.Resources.ReadBps,
+					WriteBps:           task.Resources.WriteBps,
+					NetworkEgressMBits: int64(task.Resources.NetworkEgressMBits),
+				},
 			}
 			if iter.memoryOversubscription {
 				taskResources.Memory.MemoryMaxMB = int64(task.Resources.MemoryMaxMB)
@@ -569,6 +590,18 @@ OUTER:
 			option.PreemptedAllocs = allocsToPreempt
 		}
 
+		// Blend in the utilization observed on the node if enabled
+		if iter.utilizationWeight > 0 {
+			observed, ok := iter.observedUtilization(option.Node, total)
+			if ok {
+				if iter.exceedsObservedMemory(option.Node, observed) {
+					iter.ctx.Metrics().ExhaustedNode(option.Node, "memory: observed utilization")
+					continue
+				}
+				util = blendUtilization(util, observed, iter.utilizationWeight)
+			}
+		}
+
 		// Score the fit normally otherwise
 		fitness := iter.scoreFit(option.Node, util)
 		normalizedFit := fitness / binPackingMaxFitScore
@@ -794,6 +827,82 @@ func matchesAffinity(ctx Context, affinity *structs.Affinity, option *structs.No
 	return checkAffinity(ctx, affinity.Operand, lVal, rVal, lOk, rOk)
 }
 
+// ImageCacheIterator scores nodes by the share of the images of the task group
+// they report as cached, so that allocations prefer nodes where their tasks
+// start without pulling images first.
+type ImageCacheIterator struct {
+	ctx    Context
+	source RankIterator
+
+	// prefetch is whether the job asked for its images to be prefetched
+	prefetch bool
+
+	// images are the node attributes reporting the images of the task group
+	images []string
+}
+
+// NewImageCacheIterator is used to create an ImageCacheIterator that applies
+// a score to nodes with the images of the task group cached.
+func NewImageCacheIterator(ctx Context, source RankIterator) *ImageCacheIterator {
+	return &ImageCacheIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *ImageCacheIterator) SetJob(job *structs.Job) {
+	iter.prefetch = job.Prefetch
+}
+
+func (iter *ImageCacheIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.images = iter.images[:0]
+	for _, task := range tg.Tasks {
+		image := task.Image()
+		if image == "" {
+			continue
+		}
+		attr := structs.ImageAttribute(task.Driver, image)
+		if !slices.Contains(iter.images, attr) {
+			iter.images = append(iter.images, attr)
+		}
+	}
+}
+
+func (iter *ImageCacheIterator) hasImages() bool {
+	return len(iter.images) > 0
+}
+
+// prefetching returns true if the task group has images prefetched by the
+// prefetch hint of its job, so more nodes are worth scoring to find the ones
+// that have them.
+func (iter *ImageCacheIterator) prefetching() bool {
+	return iter.prefetch && iter.hasImages()
+}
+
+func (iter *ImageCacheIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil || !iter.hasImages() {
+		return option
+	}
+
+	cached := 0
+	for _, attr := range iter.images {
+		if _, ok := option.Node.Attributes[attr]; ok {
+			cached++
+		}
+	}
+	if cached > 0 {
+		score := float64(cached) / float64(len(iter.images))
+		option.Scores = append(option.Scores, score)
+		iter.ctx.Metrics().ScoreNode(option.Node, "image-cache", score)
+	}
+	return option
+}
+
+func (iter *ImageCacheIterator) Reset() {
+	iter.source.Reset()
+}
+
 // ScoreNormalizationIterator is used to combine scores from various prior
 // iterators and combine them into one final score. The current implementation
 // averages the scores together.
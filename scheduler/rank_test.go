@@ -2330,3 +2330,47 @@ func TestNodeAffinityIterator(t *testing.T) {
 	}
 
 }
+
+func TestImageCacheIterator(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	nodes[0].Node.Attributes["unique.driver.docker.image.redis:latest"] = "sha256:1"
+	nodes[0].Node.Attributes["unique.driver.docker.image.envoy:1.29"] = "sha256:2"
+	nodes[1].Node.Attributes["unique.driver.docker.image.envoy:1.29"] = "sha256:2"
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Tasks[0].Driver = "docker"
+	tg.Tasks[0].Config = map[string]interface{}{"image": "redis"}
+	sidecar := tg.Tasks[0].Copy()
+	sidecar.Name = "sidecar"
+	sidecar.Config = map[string]interface{}{"image": "envoy:1.29"}
+	tg.Tasks = append(tg.Tasks, sidecar)
+
+	imageCache := NewImageCacheIterator(ctx, NewStaticRankIterator(ctx, nodes))
+	imageCache.SetJob(job)
+	imageCache.SetTaskGroup(tg)
+	require.True(t, imageCache.hasImages())
+	require.False(t, imageCache.prefetching())
+
+	out := collectRanked(NewScoreNormalizationIterator(ctx, imageCache))
+	require.Len(t, out, 3)
+
+	expectedScores := map[string]float64{
+		nodes[0].Node.ID: 1.0,
+		nodes[1].Node.ID: 0.5,
+		nodes[2].Node.ID: 0.0,
+	}
+	for _, n := range out {
+		require.Equal(t, expectedScores[n.Node.ID], n.FinalScore)
+	}
+
+	job.Prefetch = true
+	imageCache.SetJob(job)
+	require.True(t, imageCache.prefetching())
+}
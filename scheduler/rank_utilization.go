@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// observedUtilization returns the resources observed in use on the node plus
+// the resources of the allocations that are not running there yet: those
+// placed on the node earlier in this plan and the task group being placed.
+// It returns false if the node has no recent utilization report, in which
+// case the node is scored from its reserved resources only.
+//
+// The observed usage is that of the whole host, so the node's reserved
+// resources are subtracted from it to compare it with the resources
+// available to allocations, like reservations are.
+func (iter *BinPackIterator) observedUtilization(node *structs.Node,
+	total *structs.AllocatedResources) (*structs.ComparableResources, bool) {
+
+	if node.Utilization == nil || node.Utilization.Samples == 0 ||
+		node.Utilization.Stale(time.Now(), iter.utilizationMaxAge) {
+		return nil, false
+	}
+
+	cpu, memory := node.Utilization.CPU, node.Utilization.MemoryMB
+	if reserved := node.ReservedResources.Comparable(); reserved != nil {
+		cpu = max(cpu-reserved.Flattened.Cpu.CpuShares, 0)
+		memory = max(memory-reserved.Flattened.Memory.MemoryMB, 0)
+	}
+	observed := &structs.ComparableResources{
+		Flattened: structs.AllocatedTaskResources{
+			Cpu: structs.AllocatedCpuResources{
+				CpuShares: cpu,
+			},
+			Memory: structs.AllocatedMemoryResources{
+				MemoryMB:    memory,
+				MemoryMaxMB: memory,
+			},
+		},
+	}
+
+	pending := total.Comparable()
+	for _, alloc := range iter.ctx.Plan().NodeAllocation[node.ID] {
+		if alloc.AllocatedResources == nil || iter.runningOnNode(alloc) {
+			continue
+		}
+		pending.Add(alloc.AllocatedResources.Comparable())
+	}
+	observed.Flattened.Cpu.CpuShares += pending.Flattened.Cpu.CpuShares
+	observed.Flattened.Memory.MemoryMB += pending.Flattened.Memory.MemoryMB
+	observed.Flattened.Memory.MemoryMaxMB += max(pending.Flattened.Memory.MemoryMB,
+		pending.Flattened.Memory.MemoryMaxMB)
+
+	return observed, true
+}
+
+// runningOnNode returns whether the planned allocation is an in-place update
+// of an allocation already on its node, whose usage is part of the observed
+// utilization.
+func (iter *BinPackIterator) runningOnNode(alloc *structs.Allocation) bool {
+	existing, err := iter.ctx.State().AllocByID(nil, alloc.ID)
+	return err == nil && existing != nil && existing.NodeID == alloc.NodeID &&
+		!existing.TerminalStatus()
+}
+
+// exceedsObservedMemory returns whether the memory observed in use on the
+// node, plus the memory_max of the allocations that are not running there
+// yet, exceeds the node's memory available to allocations. Both exclude the
+// node's reserved memory. With memory oversubscription enabled the
+// reserved memory of a node can fit while its tasks are already using more
+// than their reservations, so this is checked in addition to AllocsFit.
+func (iter *BinPackIterator) exceedsObservedMemory(node *structs.Node, observed *structs.ComparableResources) bool {
+	if !iter.memoryOversubscription {
+		return false
+	}
+
+	available := node.NodeResources.Comparable().Flattened.Memory.MemoryMB
+	if reserved := node.ReservedResources.Comparable(); reserved != nil {
+		available -= reserved.Flattened.Memory.MemoryMB
+	}
+	return observed.Flattened.Memory.MemoryMaxMB > available
+}
+
+// blendUtilization returns the CPU and memory used to score a node, weighting
+// the observed utilization by weight and the reserved resources by the
+// remainder.
+func blendUtilization(reserved, observed *structs.ComparableResources, weight float64) *structs.ComparableResources {
+	blended := reserved.Copy()
+	blended.Flattened.Cpu.CpuShares = int64((1-weight)*float64(reserved.Flattened.Cpu.CpuShares) +
+		weight*float64(observed.Flattened.Cpu.CpuShares))
+	blended.Flattened.Memory.MemoryMB = int64((1-weight)*float64(reserved.Flattened.Memory.MemoryMB) +
+		weight*float64(observed.Flattened.Memory.MemoryMB))
+	return blended
+}
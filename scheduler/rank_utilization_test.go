@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func utilizationTestNode(id string, util *structs.NodeUtilization) *RankedNode {
+	return &RankedNode{
+		Node: &structs.Node{
+			ID: id,
+			NodeResources: &structs.NodeResources{
+				Processors: processorResources4096,
+				Cpu:        legacyCpuResources4096,
+				Memory: structs.NodeMemoryResources{
+					MemoryMB: 4096,
+				},
+			},
+			ReservedResources: &structs.NodeReservedResources{},
+			Utilization:       util,
+		},
+	}
+}
+
+func TestBinPackIterator_UtilizationScoring(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().Unix()
+	newNodes := func() []*RankedNode {
+		return []*RankedNode{
+			utilizationTestNode("busy", &structs.NodeUtilization{
+				CPU: 3072, MemoryMB: 3072, Samples: 10, UpdatedAt: now,
+			}),
+			utilizationTestNode("idle", &structs.NodeUtilization{
+				CPU: 128, MemoryMB: 128, Samples: 10, UpdatedAt: now,
+			}),
+		}
+	}
+
+	taskGroup := &structs.TaskGroup{
+		EphemeralDisk: &structs.EphemeralDisk{},
+		Tasks: []*structs.Task{
+			{
+				Name: "web",
+				Resources: &structs.Resources{
+					CPU:      512,
+					MemoryMB: 512,
+				},
+			},
+		},
+	}
+
+	scores := func(config *structs.SchedulerConfiguration) map[string]float64 {
+		_, ctx := testContext(t)
+		static := NewStaticRankIterator(ctx, newNodes())
+		binp := NewBinPackIterator(ctx, static, false, 0)
+		binp.SetTaskGroup(taskGroup)
+		binp.SetSchedulerConfiguration(config)
+
+		out := map[string]float64{}
+		for _, option := range collectRanked(NewScoreNormalizationIterator(ctx, binp)) {
+			out[option.Node.ID] = option.FinalScore
+		}
+		must.MapLen(t, 2, out)
+		return out
+	}
+
+	// Without utilization scoring both nodes are empty by reservation
+	out := scores(testSchedulerConfig)
+	must.Eq(t, out["busy"], out["idle"])
+
+	// With utilization scoring the busy node is packed first
+	config := testSchedulerConfig.Copy()
+	config.UtilizationScoring = &structs.SchedulerUtilizationScoring{
+		Enabled:        true,
+		ObservedWeight: 100,
+	}
+	out = scores(config)
+	must.Greater(t, out["idle"], out["busy"])
+
+	// Spread prefers the idle node instead
+	config.SchedulerAlgorithm = structs.SchedulerAlgorithmSpread
+	out = scores(config)
+	must.Greater(t, out["busy"], out["idle"])
+}
+
+func TestBinPackIterator_UtilizationScoring_ObservedMemory(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().Unix()
+	nodes := []*RankedNode{
+		utilizationTestNode("full", &structs.NodeUtilization{
+			CPU: 128, MemoryMB: 3800, Samples: 10, UpdatedAt: now,
+		}),
+		utilizationTestNode("stale", &structs.NodeUtilization{
+			CPU: 128, MemoryMB: 3800, Samples: 10,
+			UpdatedAt: now - int64(time.Hour.Seconds()),
+		}),
+		utilizationTestNode("unreported", nil),
+	}
+
+	_, ctx := testContext(t)
+	static := NewStaticRankIterator(ctx, nodes)
+
+	taskGroup := &structs.TaskGroup{
+		EphemeralDisk: &structs.EphemeralDisk{},
+		Tasks: []*structs.Task{
+			{
+				Name: "web",
+				Resources: &structs.Resources{
+					CPU:         512,
+					MemoryMB:    256,
+					MemoryMaxMB: 512,
+				},
+			},
+		},
+	}
+
+	config := testSchedulerConfig.Copy()
+	config.UtilizationScoring = &structs.SchedulerUtilizationScoring{Enabled: true}
+
+	binp := NewBinPackIterator(ctx, static, false, 0)
+	binp.SetTaskGroup(taskGroup)
+	binp.SetSchedulerConfiguration(config)
+
+	out := collectRanked(binp)
+	must.Len(t, 2, out)
+	must.Eq(t, "stale", out[0].Node.ID)
+	must.Eq(t, "unreported", out[1].Node.ID)
+	must.Eq(t, 1, ctx.Metrics().DimensionExhausted["memory: observed utilization"])
+}
@@ -84,6 +84,10 @@ type State interface {
 	// AllocsByJob returns the allocations by JobID
 	AllocsByJob(ws memdb.WatchSet, namespace, jobID string, all bool) ([]*structs.Allocation, error)
 
+	// AllocsByNamespace returns an iterator over the allocations in the
+	// namespace. The type of each result is *structs.Allocation
+	AllocsByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error)
+
 	// AllocsByNode returns all the allocations by node
 	AllocsByNode(ws memdb.WatchSet, node string) ([]*structs.Allocation, error)
 
@@ -115,6 +119,18 @@ type State interface {
 	// CSIVolumeByID fetch CSI volumes, containing controller jobs
 	CSIVolumeByID(memdb.WatchSet, string, string) (*structs.CSIVolume, error)
 
+	// HostVolumeByID fetches a dynamic host volume by namespace and ID
+	HostVolumeByID(memdb.WatchSet, string, string) (*structs.HostVolume, error)
+
+	// NamespaceByName is used to lookup a namespace by name.
+	NamespaceByName(ws memdb.WatchSet, name string) (*structs.Namespace, error)
+
+	// QuotaSpecByName is used to lookup a quota specification by name.
+	QuotaSpecByName(ws memdb.WatchSet, name string) (*structs.QuotaSpec, error)
+
+	// QuotaUsageByName is used to lookup the usage of a quota by name.
+	QuotaUsageByName(ws memdb.WatchSet, name string) (*structs.QuotaUsage, error)
+
 	// CSIVolumeByID fetch CSI volumes, containing controller jobs
 	CSIVolumesByNodeID(memdb.WatchSet, string, string) (memdb.ResultIterator, error)
 
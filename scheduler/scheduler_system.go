@@ -491,7 +491,8 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 		if option.PreemptedAllocs != nil {
 			var preemptedAllocIDs []string
 			for _, stop := range option.PreemptedAllocs {
-				s.plan.AppendPreemptedAlloc(stop, alloc.ID)
+				preempted := s.plan.AppendPreemptedAlloc(stop, alloc.ID)
+				preempted.Preemption = s.ctx.PreemptionBudgets().Preemption(stop, s.job, s.plan)
 
 				preemptedAllocIDs = append(preemptedAllocIDs, stop.ID)
 				if s.eval.AnnotatePlan && s.plan.Annotations != nil {
@@ -525,6 +526,9 @@ func (s *SystemScheduler) addBlocked(node *structs.Node) error {
 
 	blocked := s.eval.CreateBlockedEval(classEligibility, escaped, e.QuotaLimitReached(), s.failedTGAllocs)
 	blocked.StatusDescription = blockedEvalFailedPlacements
+	if blocked.QuotaLimitReached != "" {
+		blocked.StatusDescription = blockedEvalQuotaExhausted
+	}
 	blocked.NodeID = node.ID
 
 	return s.planner.CreateEval(blocked)
@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// allocChurnWindow is the window of time in which terminal allocations
+	// on a node count towards its churn.
+	allocChurnWindow = 15 * time.Minute
+
+	// allocChurnMax is the number of recently terminated allocations at
+	// which a node receives the full churn penalty.
+	allocChurnMax = 10
+)
+
+// ScoringPlugin scores nodes for placement. Scoring plugins are registered
+// with the scheduler by name and enabled per cluster through the scheduler
+// configuration, which lets operators encode placement policy without
+// modifying the stack.
+type ScoringPlugin interface {
+	// SetJob is called with the job being scheduled before any nodes are
+	// scored.
+	SetJob(job *structs.Job)
+
+	// SetTaskGroup is called with the task group being placed before any
+	// nodes are scored for it.
+	SetTaskGroup(tg *structs.TaskGroup)
+
+	// Score returns a score between -1 and 1 for the ranked node, where
+	// higher values make the node more desirable. If ok is false the
+	// plugin has no opinion about the node and no score is recorded.
+	Score(option *RankedNode) (score float64, ok bool)
+}
+
+// ScoringPluginFactory is used to instantiate a new ScoringPlugin
+type ScoringPluginFactory func(Context) ScoringPlugin
+
+var (
+	// scoringPlugins contains the registered scoring plugins which may be
+	// enabled through the scheduler configuration.
+	scoringPlugins = map[string]ScoringPluginFactory{
+		"alloc-churn": NewAllocChurnScoringPlugin,
+	}
+
+	// scoringPluginsLock guards scoringPlugins.
+	scoringPluginsLock sync.RWMutex
+)
+
+// RegisterScoringPlugin makes a scoring plugin available to the scheduler
+// under the given name. It must be called before schedulers are started,
+// typically from an init function.
+func RegisterScoringPlugin(name string, factory ScoringPluginFactory) error {
+	if name == "" {
+		return fmt.Errorf("scoring plugin name must not be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("scoring plugin %q factory must not be nil", name)
+	}
+
+	scoringPluginsLock.Lock()
+	defer scoringPluginsLock.Unlock()
+	if _, ok := scoringPlugins[name]; ok {
+		return fmt.Errorf("scoring plugin %q already registered", name)
+	}
+	scoringPlugins[name] = factory
+	return nil
+}
+
+// ScoringPluginNames returns the sorted names of the registered scoring
+// plugins.
+func ScoringPluginNames() []string {
+	scoringPluginsLock.RLock()
+	defer scoringPluginsLock.RUnlock()
+
+	names := make([]string, 0, len(scoringPlugins))
+	for name := range scoringPlugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateScoringPlugins returns an error if any of the configured scoring
+// plugins have not been registered with the scheduler.
+func ValidateScoringPlugins(configs []*structs.SchedulerScoringPlugin) error {
+	scoringPluginsLock.RLock()
+	defer scoringPluginsLock.RUnlock()
+
+	for _, config := range configs {
+		if _, ok := scoringPlugins[config.Name]; !ok {
+			return fmt.Errorf("unknown scoring plugin %q", config.Name)
+		}
+	}
+	return nil
+}
+
+// weightedScoringPlugin is an instantiated scoring plugin along with the
+// weight it was configured with.
+type weightedScoringPlugin struct {
+	name   string
+	weight float64
+	plugin ScoringPlugin
+}
+
+// ScoringPluginIterator is a RankIterator that applies the scoring plugins
+// enabled in the scheduler configuration to each node.
+type ScoringPluginIterator struct {
+	ctx     Context
+	source  RankIterator
+	job     *structs.Job
+	configs []*structs.SchedulerScoringPlugin
+	plugins []*weightedScoringPlugin
+}
+
+// NewScoringPluginIterator is used to create a ScoringPluginIterator. No
+// plugins are applied until they are set from the scheduler configuration.
+func NewScoringPluginIterator(ctx Context, source RankIterator) *ScoringPluginIterator {
+	return &ScoringPluginIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+func (iter *ScoringPluginIterator) SetJob(job *structs.Job) {
+	iter.job = job
+	for _, p := range iter.plugins {
+		p.plugin.SetJob(job)
+	}
+}
+
+func (iter *ScoringPluginIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	for _, p := range iter.plugins {
+		p.plugin.SetTaskGroup(tg)
+	}
+}
+
+// SetSchedulerConfiguration instantiates the scoring plugins enabled in the
+// scheduler configuration. Plugins that are not registered with this
+// scheduler are skipped.
+func (iter *ScoringPluginIterator) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
+	var configs []*structs.SchedulerScoringPlugin
+	if schedConfig != nil {
+		configs = schedConfig.ScoringPlugins
+	}
+
+	// The configuration is set for every job the stack processes, so avoid
+	// discarding plugin state if it hasn't changed.
+	if scoringPluginsEqual(iter.configs, configs) {
+		return
+	}
+	iter.configs = configs
+	iter.plugins = nil
+
+	scoringPluginsLock.RLock()
+	defer scoringPluginsLock.RUnlock()
+
+	for _, config := range configs {
+		factory, ok := scoringPlugins[config.Name]
+		if !ok {
+			iter.ctx.Logger().Named("scoring_plugin").Warn(
+				"skipping unknown scoring plugin", "plugin", config.Name)
+			continue
+		}
+
+		plugin := factory(iter.ctx)
+		if iter.job != nil {
+			plugin.SetJob(iter.job)
+		}
+		iter.plugins = append(iter.plugins, &weightedScoringPlugin{
+			name:   config.Name,
+			weight: float64(config.Weight) / structs.MaxSchedulerScoringPluginWeight,
+			plugin: plugin,
+		})
+	}
+}
+
+// hasPlugins returns whether any scoring plugins are enabled.
+func (iter *ScoringPluginIterator) hasPlugins() bool {
+	return len(iter.plugins) > 0
+}
+
+func (iter *ScoringPluginIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil {
+		return nil
+	}
+
+	for _, p := range iter.plugins {
+		score, ok := p.plugin.Score(option)
+		if !ok {
+			continue
+		}
+
+		// Clamp scores so a misbehaving plugin can't overwhelm the
+		// built in scorers.
+		score = math.Max(-1, math.Min(1, score)) * p.weight
+		option.Scores = append(option.Scores, score)
+		iter.ctx.Metrics().ScoreNode(option.Node, "plugin:"+p.name, score)
+	}
+	return option
+}
+
+func (iter *ScoringPluginIterator) Reset() {
+	iter.source.Reset()
+}
+
+// scoringPluginsEqual returns whether two scoring plugin configurations are
+// the same.
+func scoringPluginsEqual(a, b []*structs.SchedulerScoringPlugin) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if *a[i] != *b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// AllocChurnScoringPlugin penalizes nodes where many allocations have
+// recently stopped. High churn is often a sign of a node that is unhealthy
+// in ways fingerprinting doesn't detect, or one that is busy with image
+// pulls and cleanup.
+type AllocChurnScoringPlugin struct {
+	ctx Context
+	now time.Time
+
+	// churn caches the churn of each node scored so far. Terminal
+	// allocations don't change while an evaluation is processed, so each
+	// node's allocations are only scanned once.
+	churn map[string]int
+}
+
+// NewAllocChurnScoringPlugin returns the scoring plugin registered as
+// "alloc-churn".
+func NewAllocChurnScoringPlugin(ctx Context) ScoringPlugin {
+	return &AllocChurnScoringPlugin{
+		ctx:   ctx,
+		now:   time.Now(),
+		churn: make(map[string]int),
+	}
+}
+
+func (p *AllocChurnScoringPlugin) SetJob(*structs.Job) {}
+
+func (p *AllocChurnScoringPlugin) SetTaskGroup(*structs.TaskGroup) {}
+
+func (p *AllocChurnScoringPlugin) Score(option *RankedNode) (float64, bool) {
+	churn, ok := p.churn[option.Node.ID]
+	if !ok {
+		allocs, err := p.ctx.State().AllocsByNodeTerminal(nil, option.Node.ID, true)
+		if err != nil {
+			p.ctx.Logger().Named("alloc_churn").Error(
+				"failed retrieving terminal allocations", "error", err)
+			return 0, false
+		}
+
+		cutoff := p.now.Add(-allocChurnWindow).UnixNano()
+		for _, alloc := range allocs {
+			if alloc.ModifyTime >= cutoff {
+				churn++
+			}
+		}
+		p.churn[option.Node.ID] = churn
+	}
+	if churn == 0 {
+		return 0, false
+	}
+
+	return -math.Min(1, float64(churn)/allocChurnMax), true
+}
@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+// staticScoringPlugin is a ScoringPlugin that returns a fixed score for
+// each node ID.
+type staticScoringPlugin struct {
+	scores map[string]float64
+}
+
+func (p *staticScoringPlugin) SetJob(*structs.Job)             {}
+func (p *staticScoringPlugin) SetTaskGroup(*structs.TaskGroup) {}
+
+func (p *staticScoringPlugin) Score(option *RankedNode) (float64, bool) {
+	score, ok := p.scores[option.Node.ID]
+	return score, ok
+}
+
+// registerTestScoringPlugin registers a scoring plugin and removes it from
+// the registry when the test completes. The registry is global to the
+// package, so tests calling it must not run in parallel.
+func registerTestScoringPlugin(t *testing.T, name string, factory ScoringPluginFactory) {
+	t.Helper()
+	must.NoError(t, RegisterScoringPlugin(name, factory))
+	t.Cleanup(func() {
+		scoringPluginsLock.Lock()
+		defer scoringPluginsLock.Unlock()
+		delete(scoringPlugins, name)
+	})
+}
+
+func TestRegisterScoringPlugin(t *testing.T) {
+	factory := func(Context) ScoringPlugin { return &staticScoringPlugin{} }
+
+	registerTestScoringPlugin(t, "test-register", factory)
+	must.SliceContains(t, ScoringPluginNames(), "test-register")
+
+	err := RegisterScoringPlugin("test-register", factory)
+	must.ErrorContains(t, err, "already registered")
+
+	err = RegisterScoringPlugin("", factory)
+	must.ErrorContains(t, err, "must not be empty")
+
+	must.NoError(t, ValidateScoringPlugins([]*structs.SchedulerScoringPlugin{
+		{Name: "test-register", Weight: 10},
+		{Name: "alloc-churn", Weight: 10},
+	}))
+	must.ErrorContains(t, ValidateScoringPlugins([]*structs.SchedulerScoringPlugin{
+		{Name: "unknown", Weight: 10},
+	}), `unknown scoring plugin "unknown"`)
+}
+
+func TestScoringPluginIterator(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	plugin := &staticScoringPlugin{
+		scores: map[string]float64{
+			nodes[0].Node.ID: 1,
+			nodes[1].Node.ID: 5, // clamped to 1
+		},
+	}
+	registerTestScoringPlugin(t, "test-iterator", func(Context) ScoringPlugin {
+		return plugin
+	})
+
+	static := NewStaticRankIterator(ctx, nodes)
+	iter := NewScoringPluginIterator(ctx, static)
+	iter.SetJob(mock.Job())
+	iter.SetSchedulerConfiguration(&structs.SchedulerConfiguration{
+		ScoringPlugins: []*structs.SchedulerScoringPlugin{
+			{Name: "test-iterator", Weight: -50},
+			{Name: "unknown", Weight: 100},
+		},
+	})
+
+	out := collectRanked(iter)
+	must.Len(t, 3, out)
+	must.Eq(t, []float64{-0.5}, out[0].Scores)
+	must.Eq(t, []float64{-0.5}, out[1].Scores)
+	must.SliceEmpty(t, out[2].Scores)
+}
+
+func TestScoringPluginIterator_NoPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{{Node: mock.Node()}}
+
+	static := NewStaticRankIterator(ctx, nodes)
+	iter := NewScoringPluginIterator(ctx, static)
+	iter.SetSchedulerConfiguration(nil)
+
+	out := collectRanked(iter)
+	must.Len(t, 1, out)
+	must.SliceEmpty(t, out[0].Scores)
+}
+
+func TestGenericStack_ScoringPluginLimit(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := make([]*structs.Node, 20)
+	for i := range nodes {
+		nodes[i] = mock.Node()
+	}
+
+	// Prefer a single node, which the stack only finds if it considers more
+	// than the usual power of two choices
+	preferred := nodes[len(nodes)-1].ID
+	registerTestScoringPlugin(t, "test-limit", func(Context) ScoringPlugin {
+		return &staticScoringPlugin{scores: map[string]float64{preferred: 1}}
+	})
+
+	stack := NewGenericStack(true, ctx)
+	stack.SetSchedulerConfiguration(&structs.SchedulerConfiguration{
+		ScoringPlugins: []*structs.SchedulerScoringPlugin{
+			{Name: "test-limit", Weight: 100},
+		},
+	})
+	stack.SetNodes(nodes)
+
+	job := mock.BatchJob()
+	stack.SetJob(job)
+
+	option := stack.Select(job.TaskGroups[0], &SelectOptions{})
+	must.NotNil(t, option)
+	must.Eq(t, preferred, option.Node.ID)
+}
+
+func TestAllocChurnScoringPlugin(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+
+	now := time.Now()
+	var allocs []*structs.Allocation
+
+	// The first node has a few recently stopped allocs.
+	for i := 0; i < 3; i++ {
+		alloc := mock.Alloc()
+		alloc.NodeID = nodes[0].Node.ID
+		alloc.ClientStatus = structs.AllocClientStatusComplete
+		alloc.ModifyTime = now.Add(-time.Minute).UnixNano()
+		allocs = append(allocs, alloc)
+	}
+
+	// The second node only has allocs that stopped outside the window.
+	alloc := mock.Alloc()
+	alloc.NodeID = nodes[1].Node.ID
+	alloc.ClientStatus = structs.AllocClientStatusFailed
+	alloc.ModifyTime = now.Add(-time.Hour).UnixNano()
+	allocs = append(allocs, alloc)
+
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, allocs))
+
+	plugin := NewAllocChurnScoringPlugin(ctx)
+	plugin.SetJob(mock.Job())
+	plugin.SetTaskGroup(mock.Job().TaskGroups[0])
+
+	score, ok := plugin.Score(nodes[0])
+	must.True(t, ok)
+	must.Eq(t, -0.3, score)
+
+	_, ok = plugin.Score(nodes[1])
+	must.False(t, ok)
+
+	_, ok = plugin.Score(nodes[2])
+	must.False(t, ok)
+}
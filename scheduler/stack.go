@@ -67,7 +67,9 @@ type GenericStack struct {
 	limit                      *LimitIterator
 	maxScore                   *MaxScoreIterator
 	nodeAffinity               *NodeAffinityIterator
+	imageCache                 *ImageCacheIterator
 	spread                     *SpreadIterator
+	scoringPlugins             *ScoringPluginIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -108,8 +110,11 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	s.binPack.SetJob(job)
 	s.jobAntiAff.SetJob(job)
 	s.nodeAffinity.SetJob(job)
+	s.imageCache.SetJob(job)
 	s.spread.SetJob(job)
+	s.scoringPlugins.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
+	s.taskGroupHostVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
 
@@ -123,6 +128,7 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 // on the node pool being used.
 func (s *GenericStack) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
 	s.binPack.SetSchedulerConfiguration(schedConfig)
+	s.scoringPlugins.SetSchedulerConfiguration(schedConfig)
 }
 
 func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *RankedNode {
@@ -171,13 +177,18 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 		s.nodeReschedulingPenalty.SetPenaltyNodes(options.PenaltyNodeIDs)
 	}
 	s.nodeAffinity.SetTaskGroup(tg)
+	s.imageCache.SetTaskGroup(tg)
 	s.spread.SetTaskGroup(tg)
+	s.scoringPlugins.SetTaskGroup(tg)
 
-	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() {
+	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() || s.scoringPlugins.hasPlugins() ||
+		s.imageCache.prefetching() {
 		// scoring spread across all nodes has quadratic behavior, so
 		// we need to consider a subset of nodes to keep evaluaton times
 		// reasonable but enough to ensure spread is correct. this
-		// value was empirically determined.
+		// value was empirically determined. Scoring plugins and
+		// prefetched images need the same room to have an effect on
+		// placement.
 		s.limit.SetLimit(tg.Count)
 		if tg.Count < 100 {
 			s.limit.SetLimit(100)
@@ -317,6 +328,7 @@ func (s *SystemStack) SetJob(job *structs.Job) {
 	s.distinctPropertyConstraint.SetJob(job)
 	s.binPack.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
+	s.taskGroupHostVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
 
@@ -448,11 +460,18 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Apply scores based on affinity block
 	s.nodeAffinity = NewNodeAffinityIterator(ctx, s.nodeReschedulingPenalty)
 
+	// Apply scores based on the images cached on the nodes
+	s.imageCache = NewImageCacheIterator(ctx, s.nodeAffinity)
+
 	// Apply scores based on spread block
-	s.spread = NewSpreadIterator(ctx, s.nodeAffinity)
+	s.spread = NewSpreadIterator(ctx, s.imageCache)
+
+	// Apply scores from the scoring plugins enabled in the scheduler
+	// configuration
+	s.scoringPlugins = NewScoringPluginIterator(ctx, s.spread)
 
 	// Add the preemption options scoring iterator
-	preemptionScorer := NewPreemptionScoringIterator(ctx, s.spread)
+	preemptionScorer := NewPreemptionScoringIterator(ctx, s.scoringPlugins)
 
 	// Normalizes scores by averaging them across various scorers
 	s.scoreNorm = NewScoreNormalizationIterator(ctx, preemptionScorer)
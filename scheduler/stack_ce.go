@@ -6,6 +6,10 @@
 
 package scheduler
 
-func NewQuotaIterator(_ Context, source FeasibleIterator) FeasibleIterator {
-	return source
+// NewQuotaIterator returns a QuotaIterator that filters the source iterator.
+func NewQuotaIterator(ctx Context, source FeasibleIterator) FeasibleIterator {
+	return &QuotaIterator{
+		ctx:    ctx,
+		source: source,
+	}
 }
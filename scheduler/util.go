@@ -353,17 +353,23 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 	return same
 }
 
+// nonNetworkResourcesUpdated returns true if the non-network resources of the
+// task changed in a way that requires replacing it. Changes to cpu, memory and
+// memory_max are applied in-place: the scheduler checks the node still fits
+// the allocation and the client resizes the running task.
 func nonNetworkResourcesUpdated(a, b *structs.Resources) comparison {
 	// Inspect the non-network resources
 	switch {
-	case a.CPU != b.CPU:
-		return difference("task cpu", a.CPU, b.CPU)
 	case a.Cores != b.Cores:
 		return difference("task cores", a.Cores, b.Cores)
-	case a.MemoryMB != b.MemoryMB:
-		return difference("task memory", a.MemoryMB, b.MemoryMB)
-	case a.MemoryMaxMB != b.MemoryMaxMB:
-		return difference("task memory max", a.MemoryMaxMB, b.MemoryMaxMB)
+	case a.IOWeight != b.IOWeight:
+		return difference("task io weight", a.IOWeight, b.IOWeight)
+	case a.ReadBps != b.ReadBps:
+		return difference("task read bps", a.ReadBps, b.ReadBps)
+	case a.WriteBps != b.WriteBps:
+		return difference("task write bps", a.WriteBps, b.WriteBps)
+	case a.NetworkEgressMBits != b.NetworkEgressMBits:
+		return difference("task network egress", a.NetworkEgressMBits, b.NetworkEgressMBits)
 	case !a.Devices.Equal(&b.Devices):
 		return difference("task devices", a.Devices, b.Devices)
 	case !a.NUMA.Equal(b.NUMA):
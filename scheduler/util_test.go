@@ -419,9 +419,15 @@ func TestTasksUpdated(t *testing.T) {
 	j10.TaskGroups[0].Tasks[0].Meta["baz"] = "boom"
 	must.True(t, tasksUpdated(j1, j10, name).modified)
 
+	// Resizing cpu or memory is done in-place
 	j11 := mock.Job()
 	j11.TaskGroups[0].Tasks[0].Resources.CPU = 1337
-	must.True(t, tasksUpdated(j1, j11, name).modified)
+	must.False(t, tasksUpdated(j1, j11, name).modified)
+
+	j11m1 := mock.Job()
+	j11m1.TaskGroups[0].Tasks[0].Resources.MemoryMB = 1024
+	j11m1.TaskGroups[0].Tasks[0].Resources.MemoryMaxMB = 2048
+	must.False(t, tasksUpdated(j1, j11m1, name).modified)
 
 	j11d1 := mock.Job()
 	j11d1.TaskGroups[0].Tasks[0].Resources.Devices = structs.ResourceDevices{
@@ -1054,6 +1060,48 @@ func TestInplaceUpdate_Success(t *testing.T) {
 	}
 }
 
+func TestInplaceUpdate_Resize(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+	eval := mock.Eval()
+	job := mock.Job()
+
+	node := mock.Node()
+	must.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 900, node))
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.Job = job
+	alloc.JobID = job.ID
+	must.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+	must.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	// Resize the task, which still fits on the node
+	job2 := job.Copy()
+	job2.TaskGroups[0].Tasks[0].Resources.CPU = 1000
+	job2.TaskGroups[0].Tasks[0].Resources.MemoryMB = 512
+	job2.TaskGroups[0].Tasks[0].Resources.MemoryMaxMB = 1024
+	must.False(t, tasksUpdated(job2, job, job2.TaskGroups[0].Name).modified)
+
+	updates := []allocTuple{{Alloc: alloc, TaskGroup: job2.TaskGroups[0]}}
+	stack := NewGenericStack(false, ctx)
+	stack.SetJob(job2)
+
+	unplaced, inplace := inplaceUpdate(ctx, eval, job2, stack, updates)
+	must.Len(t, 0, unplaced)
+	must.Len(t, 1, inplace)
+
+	planned := ctx.plan.NodeAllocation[node.ID]
+	must.Len(t, 1, planned)
+	must.Eq(t, alloc.ID, planned[0].ID)
+
+	resources := planned[0].AllocatedResources.Tasks["web"]
+	must.Eq(t, 1000, resources.Cpu.CpuShares)
+	must.Eq(t, 512, resources.Memory.MemoryMB)
+	must.Eq(t, 1024, resources.Memory.MemoryMaxMB)
+}
+
 func TestInplaceUpdate_WildcardDatacenters(t *testing.T) {
 	ci.Parallel(t)
 
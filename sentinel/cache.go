@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sentinel
+
+import (
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// PolicyCache caches compiled policies so that a policy is only compiled
+// again once it has been modified.
+type PolicyCache struct {
+	cache *lru.TwoQueueCache[string, cachedPolicy]
+}
+
+type cachedPolicy struct {
+	modifyIndex uint64
+	policy      *Policy
+}
+
+// NewPolicyCache returns a cache holding up to size compiled policies.
+func NewPolicyCache(size int) *PolicyCache {
+	cache, err := lru.New2Q[string, cachedPolicy](size)
+	if err != nil {
+		panic(err) // only possible if size is not positive
+	}
+	return &PolicyCache{cache: cache}
+}
+
+// Compile returns the compiled policy, compiling it only if it isn't cached
+// at its current modify index.
+func (c *PolicyCache) Compile(policy *structs.SentinelPolicy) (*Policy, error) {
+	if cached, ok := c.cache.Get(policy.Name); ok && cached.modifyIndex == policy.ModifyIndex {
+		return cached.policy, nil
+	}
+
+	compiled, err := Compile(policy.Name, policy.Scope, policy.Policy)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Add(policy.Name, cachedPolicy{
+		modifyIndex: policy.ModifyIndex,
+		policy:      compiled,
+	})
+	return compiled, nil
+}
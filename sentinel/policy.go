@@ -0,0 +1,434 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package sentinel implements the embedded policy engine used to enforce
+// Sentinel policies on requests made to the servers.
+//
+// Policies are written in HCL and consist of one or more rule blocks. Each
+// rule has a condition expression that must evaluate to true for the policy to
+// pass:
+//
+//	rule "no-raw-exec" {
+//	  description = "raw_exec tasks are not allowed"
+//	  condition   = alltrue([for t in flatten(job.TaskGroups[*].Tasks) : t.Driver != "raw_exec"])
+//	}
+package sentinel
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// VarJob is the job being submitted
+	VarJob = "job"
+
+	// VarExistingJob is the currently registered version of the job being
+	// submitted, or null if the job is new
+	VarExistingJob = "existing_job"
+
+	// VarNamespace is the namespace the job is submitted to
+	VarNamespace = "namespace"
+
+	// VarACLToken is the ACL token used to submit the job, without its
+	// secret, or null if ACLs are disabled
+	VarACLToken = "nomad_acl_token"
+)
+
+// scopeVariables maps each policy scope to the variables available to the
+// policies in that scope.
+var scopeVariables = map[string][]string{
+	structs.SentinelScopeSubmitJob: {VarJob, VarExistingJob, VarNamespace, VarACLToken},
+}
+
+var policySchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "rule", LabelNames: []string{"name"}},
+	},
+}
+
+var ruleSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "description"},
+		{Name: "condition", Required: true},
+	},
+}
+
+// Policy is a compiled policy that can be evaluated many times.
+type Policy struct {
+	Name  string
+	Scope string
+	Rules []*Rule
+}
+
+// Rule is a single condition of a policy.
+type Rule struct {
+	Name        string
+	Description string
+	Condition   hcl.Expression
+}
+
+// Failure describes a rule that did not pass.
+type Failure struct {
+	Rule        string
+	Description string
+
+	// Error is set if the rule could not be evaluated, which is always
+	// treated as a failure.
+	Error error
+}
+
+func (f *Failure) String() string {
+	switch {
+	case f.Error != nil:
+		return fmt.Sprintf("rule %q failed: %v", f.Rule, f.Error)
+	case f.Description != "":
+		return fmt.Sprintf("rule %q failed: %s", f.Rule, f.Description)
+	default:
+		return fmt.Sprintf("rule %q failed", f.Rule)
+	}
+}
+
+// Compile parses and validates the source of a policy in the given scope.
+func Compile(name, scope, src string) (*Policy, error) {
+	vars, ok := scopeVariables[scope]
+	if !ok {
+		return nil, fmt.Errorf("invalid scope %q", scope)
+	}
+	known := make(map[string]struct{}, len(vars))
+	for _, v := range vars {
+		known[v] = struct{}{}
+	}
+
+	file, diags := hclsyntax.ParseConfig([]byte(src), name, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	content, diags := file.Body.Content(policySchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if len(content.Blocks) == 0 {
+		return nil, errors.New("policy must contain at least one rule")
+	}
+
+	p := &Policy{
+		Name:  name,
+		Scope: scope,
+		Rules: make([]*Rule, 0, len(content.Blocks)),
+	}
+	seen := make(map[string]struct{}, len(content.Blocks))
+	for _, block := range content.Blocks {
+		rule, err := compileRule(block, known)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[rule.Name]; ok {
+			return nil, fmt.Errorf("duplicate rule %q", rule.Name)
+		}
+		seen[rule.Name] = struct{}{}
+		p.Rules = append(p.Rules, rule)
+	}
+
+	return p, nil
+}
+
+func compileRule(block *hcl.Block, known map[string]struct{}) (*Rule, error) {
+	rule := &Rule{Name: block.Labels[0]}
+
+	content, diags := block.Body.Content(ruleSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	if attr, ok := content.Attributes["description"]; ok {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		if val.IsNull() || val.Type() != cty.String {
+			return nil, fmt.Errorf("rule %q: description must be a string", rule.Name)
+		}
+		rule.Description = val.AsString()
+	}
+
+	rule.Condition = content.Attributes["condition"].Expr
+	for _, traversal := range rule.Condition.Variables() {
+		root := traversal.RootName()
+		if _, ok := known[root]; !ok {
+			return nil, fmt.Errorf("rule %q: unknown variable %q", rule.Name, root)
+		}
+	}
+
+	return rule, nil
+}
+
+// Eval evaluates every rule of the policy against the given data, which is
+// keyed by variable name. It returns the rules that did not pass.
+func (p *Policy) Eval(data map[string]any) ([]*Failure, error) {
+	vars := make(map[string]cty.Value, len(data))
+	for _, name := range scopeVariables[p.Scope] {
+		val, err := toValue(data[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %q: %w", name, err)
+		}
+		vars[name] = val
+	}
+
+	ctx := &hcl.EvalContext{
+		Variables: vars,
+		Functions: functions,
+	}
+
+	var failures []*Failure
+	for _, rule := range p.Rules {
+		if passed, err := rule.eval(ctx); err != nil || !passed {
+			failures = append(failures, &Failure{
+				Rule:        rule.Name,
+				Description: rule.Description,
+				Error:       err,
+			})
+		}
+	}
+	return failures, nil
+}
+
+func (r *Rule) eval(ctx *hcl.EvalContext) (bool, error) {
+	val, diags := evalCondition(r.Condition, ctx)
+	if diags.HasErrors() {
+		return false, diags
+	}
+	if val.Type() != cty.Bool {
+		return false, fmt.Errorf("condition must be a bool, got %s", val.Type().FriendlyName())
+	}
+	if val.IsNull() || !val.IsKnown() {
+		return false, errors.New("condition must not be null")
+	}
+	return val.True(), nil
+}
+
+// evalCondition evaluates the expression like expr.Value, except that the
+// logical operators short-circuit. This allows conditions to guard against
+// null values, such as the existing_job of new jobs:
+//
+//	existing_job == null || existing_job.Priority == job.Priority
+func evalCondition(expr hcl.Expression, ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+	switch e := expr.(type) {
+	case *hclsyntax.ParenthesesExpr:
+		return evalCondition(e.Expression, ctx)
+
+	case *hclsyntax.BinaryOpExpr:
+		var shortCircuit cty.Value
+		switch e.Op {
+		case hclsyntax.OpLogicalOr:
+			shortCircuit = cty.True
+		case hclsyntax.OpLogicalAnd:
+			shortCircuit = cty.False
+		default:
+			return expr.Value(ctx)
+		}
+
+		lhs, diags := evalCondition(e.LHS, ctx)
+		if diags.HasErrors() || !lhs.IsKnown() || lhs.IsNull() || lhs.Type() != cty.Bool {
+			return expr.Value(ctx)
+		}
+		if lhs.Equals(shortCircuit).True() {
+			return shortCircuit, diags
+		}
+
+		rhs, rhsDiags := evalCondition(e.RHS, ctx)
+		diags = append(diags, rhsDiags...)
+		if rhsDiags.HasErrors() || !rhs.IsKnown() || rhs.IsNull() || rhs.Type() != cty.Bool {
+			return expr.Value(ctx)
+		}
+		return rhs, diags
+	}
+
+	return expr.Value(ctx)
+}
+
+// toValue converts a Go value into a cty value. Structs are converted into
+// objects with the same attributes as their JSON encoding, so that policies
+// see the same field names as the HTTP API, while numbers keep their full
+// precision. Slices are converted into tuples and maps into objects.
+func toValue(v any) (cty.Value, error) {
+	return reflectValue(reflect.ValueOf(v))
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func reflectValue(v reflect.Value) (cty.Value, error) {
+	if !v.IsValid() {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+	if v.Type() == timeType {
+		return cty.StringVal(v.Interface().(time.Time).Format(time.RFC3339Nano)), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return cty.NullVal(cty.DynamicPseudoType), nil
+		}
+		return reflectValue(v.Elem())
+
+	case reflect.Bool:
+		return cty.BoolVal(v.Bool()), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cty.NumberIntVal(v.Int()), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cty.NumberUIntVal(v.Uint()), nil
+
+	case reflect.Float32, reflect.Float64:
+		return cty.NumberFloatVal(v.Float()), nil
+
+	case reflect.String:
+		return cty.StringVal(v.String()), nil
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return cty.NullVal(cty.DynamicPseudoType), nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return cty.StringVal(base64.StdEncoding.EncodeToString(v.Bytes())), nil
+		}
+		fallthrough
+
+	case reflect.Array:
+		elems := make([]cty.Value, v.Len())
+		for i := range elems {
+			elem, err := reflectValue(v.Index(i))
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elems[i] = elem
+		}
+		return cty.TupleVal(elems), nil
+
+	case reflect.Map:
+		if v.IsNil() {
+			return cty.NullVal(cty.DynamicPseudoType), nil
+		}
+		attrs := make(map[string]cty.Value, v.Len())
+		for it := v.MapRange(); it.Next(); {
+			key, err := mapKey(it.Key())
+			if err != nil {
+				return cty.NilVal, err
+			}
+			val, err := reflectValue(it.Value())
+			if err != nil {
+				return cty.NilVal, err
+			}
+			attrs[key] = val
+		}
+		return cty.ObjectVal(attrs), nil
+
+	case reflect.Struct:
+		attrs := make(map[string]cty.Value)
+		if err := structAttrs(v, attrs); err != nil {
+			return cty.NilVal, err
+		}
+		return cty.ObjectVal(attrs), nil
+	}
+
+	return cty.NilVal, fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// mapKey returns the attribute name of a map key, which like JSON may be a
+// string or an integer.
+func mapKey(k reflect.Value) (string, error) {
+	switch k.Kind() {
+	case reflect.String:
+		return k.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprint(k.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(k.Uint()), nil
+	}
+	return "", fmt.Errorf("unsupported map key type %s", k.Type())
+}
+
+// structAttrs adds the exported fields of the struct to attrs, following the
+// json struct tags. The fields of embedded structs are promoted unless the
+// embedding struct has a field of the same name.
+func structAttrs(v reflect.Value, attrs map[string]cty.Value) error {
+	t := v.Type()
+	promoted := make(map[string]cty.Value)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				ft, fv = ft.Elem(), fv.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := structAttrs(fv, promoted); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(opts, "omitempty") && isEmptyValue(fv) {
+			continue
+		}
+		val, err := reflectValue(fv)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		attrs[name] = val
+	}
+
+	for name, val := range promoted {
+		if _, ok := attrs[name]; !ok {
+			attrs[name] = val
+		}
+	}
+	return nil
+}
+
+// isEmptyValue reports whether the value is empty as defined by the
+// omitempty option of the json struct tag.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	case reflect.Struct:
+		return false
+	}
+	return v.IsZero()
+}
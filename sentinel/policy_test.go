@@ -0,0 +1,323 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sentinel
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestCompile(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		scope  string
+		src    string
+		expErr string
+	}{
+		{
+			name:  "valid",
+			scope: structs.SentinelScopeSubmitJob,
+			src: `
+rule "priority" {
+  description = "priority must be at most 80"
+  condition   = job.Priority <= 80
+}
+
+rule "new-job" {
+  condition = existing_job == null || namespace.Name == "default"
+}
+`,
+		},
+		{
+			name:   "invalid scope",
+			scope:  "submit-node",
+			src:    `rule "a" { condition = true }`,
+			expErr: `invalid scope "submit-node"`,
+		},
+		{
+			name:   "syntax error",
+			scope:  structs.SentinelScopeSubmitJob,
+			src:    `rule "a" { condition = `,
+			expErr: "Missing expression",
+		},
+		{
+			name:   "no rules",
+			scope:  structs.SentinelScopeSubmitJob,
+			src:    ``,
+			expErr: "at least one rule",
+		},
+		{
+			name:   "unknown block",
+			scope:  structs.SentinelScopeSubmitJob,
+			src:    `main { condition = true }`,
+			expErr: `Blocks of type "main" are not expected here`,
+		},
+		{
+			name:   "missing condition",
+			scope:  structs.SentinelScopeSubmitJob,
+			src:    `rule "a" { description = "a" }`,
+			expErr: `The argument "condition" is required`,
+		},
+		{
+			name:  "dynamic description",
+			scope: structs.SentinelScopeSubmitJob,
+			src: `
+rule "a" {
+  description = job.Name
+  condition   = true
+}
+`,
+			expErr: "Variables not allowed",
+		},
+		{
+			name:  "duplicate rule",
+			scope: structs.SentinelScopeSubmitJob,
+			src: `
+rule "a" { condition = true }
+rule "a" { condition = false }
+`,
+			expErr: `duplicate rule "a"`,
+		},
+		{
+			name:   "unknown variable",
+			scope:  structs.SentinelScopeSubmitJob,
+			src:    `rule "a" { condition = node.Name == "a" }`,
+			expErr: `unknown variable "node"`,
+		},
+		{
+			name:  "for expression variables",
+			scope: structs.SentinelScopeSubmitJob,
+			src:   `rule "a" { condition = alltrue([for tg in job.TaskGroups : tg.Count > 0]) }`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := Compile("test", tc.scope, tc.src)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, "test", p.Name)
+			must.Eq(t, tc.scope, p.Scope)
+		})
+	}
+}
+
+func TestPolicy_Eval(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &structs.Job{
+		ID:       "example",
+		Priority: 50,
+		TaskGroups: []*structs.TaskGroup{
+			{
+				Name:  "web",
+				Count: 2,
+				Tasks: []*structs.Task{
+					{Name: "server", Driver: "docker"},
+					{Name: "sidecar", Driver: "raw_exec"},
+				},
+			},
+		},
+	}
+
+	src := `
+rule "no-raw-exec" {
+  description = "raw_exec tasks are not allowed"
+  condition   = alltrue([for t in flatten(job.TaskGroups[*].Tasks) : t.Driver != "raw_exec"])
+}
+
+rule "priority" {
+  condition = job.Priority <= 80
+}
+
+rule "new-job" {
+  description = "only new jobs"
+  condition   = existing_job == null
+}
+
+rule "owner" {
+  condition = nomad_acl_token.Name == "ops"
+}
+
+rule "not-a-bool" {
+  condition = job.ID
+}
+`
+
+	p, err := Compile("test", structs.SentinelScopeSubmitJob, src)
+	must.NoError(t, err)
+
+	failures, err := p.Eval(map[string]any{
+		VarJob:         job,
+		VarExistingJob: (*structs.Job)(nil),
+		VarNamespace:   &structs.Namespace{Name: "default"},
+	})
+	must.NoError(t, err)
+	must.Len(t, 3, failures)
+
+	must.Eq(t, "no-raw-exec", failures[0].Rule)
+	must.Eq(t, "raw_exec tasks are not allowed", failures[0].Description)
+	must.NoError(t, failures[0].Error)
+	must.Eq(t, `rule "no-raw-exec" failed: raw_exec tasks are not allowed`, failures[0].String())
+
+	// Attributes of a null ACL token can not be accessed
+	must.Eq(t, "owner", failures[1].Rule)
+	must.Error(t, failures[1].Error)
+
+	must.Eq(t, "not-a-bool", failures[2].Rule)
+	must.ErrorContains(t, failures[2].Error, "condition must be a bool")
+
+	// Fixing the job and passing a token resolves all but the type error
+	job.TaskGroups[0].Tasks[1].Driver = "docker"
+	failures, err = p.Eval(map[string]any{
+		VarJob:       job,
+		VarNamespace: &structs.Namespace{Name: "default"},
+		VarACLToken:  &structs.ACLToken{Name: "ops"},
+	})
+	must.NoError(t, err)
+	must.Len(t, 1, failures)
+	must.Eq(t, "not-a-bool", failures[0].Rule)
+}
+
+func TestFunctions_AllTrueAnyTrue(t *testing.T) {
+	ci.Parallel(t)
+
+	src := `
+rule "all-empty"  { condition = alltrue([]) }
+rule "all"        { condition = alltrue([true, job.Priority > 10]) }
+rule "any-empty"  { condition = anytrue([]) }
+rule "any"        { condition = anytrue([false, job.Priority > 10]) }
+rule "any-false"  { condition = anytrue([false, false]) }
+`
+	p, err := Compile("test", structs.SentinelScopeSubmitJob, src)
+	must.NoError(t, err)
+
+	failures, err := p.Eval(map[string]any{VarJob: &structs.Job{Priority: 50}})
+	must.NoError(t, err)
+	must.Len(t, 2, failures)
+	must.Eq(t, "any-empty", failures[0].Rule)
+	must.Eq(t, "any-false", failures[1].Rule)
+}
+
+func TestPolicy_Eval_NewJob(t *testing.T) {
+	ci.Parallel(t)
+
+	src := `
+rule "keep-priority" {
+  description = "the priority of existing jobs can't change"
+  condition   = existing_job == null || existing_job.Priority == job.Priority
+}
+
+rule "existing-only" {
+  condition = existing_job != null && existing_job.Priority > 0
+}
+`
+	p, err := Compile("test", structs.SentinelScopeSubmitJob, src)
+	must.NoError(t, err)
+
+	// Attributes of existing_job are never accessed for new jobs
+	failures, err := p.Eval(map[string]any{
+		VarJob:         &structs.Job{Priority: 50},
+		VarExistingJob: (*structs.Job)(nil),
+	})
+	must.NoError(t, err)
+	must.Len(t, 1, failures)
+	must.Eq(t, "existing-only", failures[0].Rule)
+	must.NoError(t, failures[0].Error)
+
+	failures, err = p.Eval(map[string]any{
+		VarJob:         &structs.Job{Priority: 50},
+		VarExistingJob: &structs.Job{Priority: 70},
+	})
+	must.NoError(t, err)
+	must.Len(t, 1, failures)
+	must.Eq(t, "keep-priority", failures[0].Rule)
+}
+
+func TestToValue(t *testing.T) {
+	ci.Parallel(t)
+
+	type embedded struct {
+		Region string
+		Name   string
+	}
+	type value struct {
+		embedded
+		Name    string
+		Renamed string `json:"renamed"`
+		Omitted string `json:"-"`
+		Empty   string `json:",omitempty"`
+		Index   uint64
+		Bytes   []byte
+		Nil     *structs.Job
+		Tags    []string
+		Meta    map[string]string
+		private string
+	}
+
+	val, err := toValue(&value{
+		embedded: embedded{Region: "global", Name: "shadowed"},
+		Name:     "example",
+		Renamed:  "renamed",
+		Omitted:  "omitted",
+		Index:    1<<60 + 1,
+		Bytes:    []byte("abc"),
+		Tags:     []string{"a", "b"},
+		Meta:     map[string]string{"team": "ops"},
+		private:  "private",
+	})
+	must.NoError(t, err)
+
+	attrs := val.AsValueMap()
+	must.MapLen(t, 8, attrs)
+	must.Eq(t, "global", attrs["Region"].AsString())
+	must.Eq(t, "example", attrs["Name"].AsString())
+	must.Eq(t, "renamed", attrs["renamed"].AsString())
+	must.Eq(t, "YWJj", attrs["Bytes"].AsString())
+	must.True(t, attrs["Nil"].IsNull())
+	must.Eq(t, 2, attrs["Tags"].LengthInt())
+	must.Eq(t, "ops", attrs["Meta"].GetAttr("team").AsString())
+
+	// Large integers keep their precision
+	index, _ := attrs["Index"].AsBigFloat().Uint64()
+	must.Eq(t, uint64(1<<60+1), index)
+}
+
+func TestPolicyCache(t *testing.T) {
+	ci.Parallel(t)
+
+	cache := NewPolicyCache(10)
+	policy := &structs.SentinelPolicy{
+		Name:        "test",
+		Scope:       structs.SentinelScopeSubmitJob,
+		Policy:      `rule "priority" { condition = job.Priority <= 80 }`,
+		ModifyIndex: 10,
+	}
+
+	first, err := cache.Compile(policy)
+	must.NoError(t, err)
+
+	// Policies are only compiled again once modified
+	second, err := cache.Compile(policy)
+	must.NoError(t, err)
+	must.True(t, first == second)
+
+	policy.Policy = `rule "priority" { condition = job.Priority <= 50 }`
+	policy.ModifyIndex = 20
+	third, err := cache.Compile(policy)
+	must.NoError(t, err)
+	must.False(t, first == third)
+
+	failures, err := third.Eval(map[string]any{VarJob: &structs.Job{Priority: 70}})
+	must.NoError(t, err)
+	must.Len(t, 1, failures)
+}